@@ -0,0 +1,412 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: api/contracts/profile/v1/events.proto
+
+package profilecontractv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// FavoriteType 与 Profile 的 profile.v1.FavoriteType 取值一致；SHARE 与 RATING 尚未在已发布的 Go 类型中出现。
+type FavoriteType int32
+
+const (
+	FavoriteType_FAVORITE_TYPE_UNSPECIFIED FavoriteType = 0
+	FavoriteType_FAVORITE_TYPE_LIKE        FavoriteType = 1
+	FavoriteType_FAVORITE_TYPE_BOOKMARK    FavoriteType = 2
+	FavoriteType_FAVORITE_TYPE_SHARE       FavoriteType = 3 // 分享，不可撤销
+	FavoriteType_FAVORITE_TYPE_RATING      FavoriteType = 4 // 1-5 星评分
+)
+
+// Enum value maps for FavoriteType.
+var (
+	FavoriteType_name = map[int32]string{
+		0: "FAVORITE_TYPE_UNSPECIFIED",
+		1: "FAVORITE_TYPE_LIKE",
+		2: "FAVORITE_TYPE_BOOKMARK",
+		3: "FAVORITE_TYPE_SHARE",
+		4: "FAVORITE_TYPE_RATING",
+	}
+	FavoriteType_value = map[string]int32{
+		"FAVORITE_TYPE_UNSPECIFIED": 0,
+		"FAVORITE_TYPE_LIKE":        1,
+		"FAVORITE_TYPE_BOOKMARK":    2,
+		"FAVORITE_TYPE_SHARE":       3,
+		"FAVORITE_TYPE_RATING":      4,
+	}
+)
+
+func (x FavoriteType) Enum() *FavoriteType {
+	p := new(FavoriteType)
+	*p = x
+	return p
+}
+
+func (x FavoriteType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FavoriteType) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_contracts_profile_v1_events_proto_enumTypes[0].Descriptor()
+}
+
+func (FavoriteType) Type() protoreflect.EnumType {
+	return &file_api_contracts_profile_v1_events_proto_enumTypes[0]
+}
+
+func (x FavoriteType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FavoriteType.Descriptor instead.
+func (FavoriteType) EnumDescriptor() ([]byte, []int) {
+	return file_api_contracts_profile_v1_events_proto_rawDescGZIP(), []int{0}
+}
+
+// UserDeletedEvent 对应 profile.user.deleted：用户注销后 Profile 发布，Catalog 据此清理该用户的互动投影。
+type UserDeletedEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`          // 事件唯一标识 (UUID)
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`             // 被删除的用户 (UUID)
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"` // 用户删除时间，作为清理截止时间
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserDeletedEvent) Reset() {
+	*x = UserDeletedEvent{}
+	mi := &file_api_contracts_profile_v1_events_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserDeletedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserDeletedEvent) ProtoMessage() {}
+
+func (x *UserDeletedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_contracts_profile_v1_events_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserDeletedEvent.ProtoReflect.Descriptor instead.
+func (*UserDeletedEvent) Descriptor() ([]byte, []int) {
+	return file_api_contracts_profile_v1_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UserDeletedEvent) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *UserDeletedEvent) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserDeletedEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+// EngagementAddedEvent 对应 profile.engagement.added：字段 1-5 与已发布的 profile.v1.EngagementAddedEvent 相同，
+// 在其基础上补充 sequence 与 rating。
+type EngagementAddedEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`                                                        // 事件唯一标识 (UUID)
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                                                           // 用户 (UUID)
+	VideoId       string                 `protobuf:"bytes,3,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`                                                        // 视频 (UUID)
+	FavoriteType  FavoriteType           `protobuf:"varint,4,opt,name=favorite_type,json=favoriteType,proto3,enum=contracts.profile.v1.FavoriteType" json:"favorite_type,omitempty"` // 互动类型
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`                                               // 互动发生时间
+	Sequence      int64                  `protobuf:"varint,6,opt,name=sequence,proto3" json:"sequence,omitempty"`                                                                    // 同一 (user, video, favorite_type) 内单调递增的序号，0 表示未携带
+	Rating        int32                  `protobuf:"varint,7,opt,name=rating,proto3" json:"rating,omitempty"`                                                                        // 评分星级 1-5，仅 FAVORITE_TYPE_RATING 使用
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EngagementAddedEvent) Reset() {
+	*x = EngagementAddedEvent{}
+	mi := &file_api_contracts_profile_v1_events_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EngagementAddedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EngagementAddedEvent) ProtoMessage() {}
+
+func (x *EngagementAddedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_contracts_profile_v1_events_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EngagementAddedEvent.ProtoReflect.Descriptor instead.
+func (*EngagementAddedEvent) Descriptor() ([]byte, []int) {
+	return file_api_contracts_profile_v1_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *EngagementAddedEvent) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *EngagementAddedEvent) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *EngagementAddedEvent) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *EngagementAddedEvent) GetFavoriteType() FavoriteType {
+	if x != nil {
+		return x.FavoriteType
+	}
+	return FavoriteType_FAVORITE_TYPE_UNSPECIFIED
+}
+
+func (x *EngagementAddedEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *EngagementAddedEvent) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *EngagementAddedEvent) GetRating() int32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+// EngagementRemovedEvent 对应 profile.engagement.removed，字段含义同 EngagementAddedEvent。
+type EngagementRemovedEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`                                                        // 事件唯一标识 (UUID)
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                                                           // 用户 (UUID)
+	VideoId       string                 `protobuf:"bytes,3,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`                                                        // 视频 (UUID)
+	FavoriteType  FavoriteType           `protobuf:"varint,4,opt,name=favorite_type,json=favoriteType,proto3,enum=contracts.profile.v1.FavoriteType" json:"favorite_type,omitempty"` // 互动类型
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`                                               // 撤销发生时间
+	Sequence      int64                  `protobuf:"varint,6,opt,name=sequence,proto3" json:"sequence,omitempty"`                                                                    // 同一 (user, video, favorite_type) 内单调递增的序号，0 表示未携带
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EngagementRemovedEvent) Reset() {
+	*x = EngagementRemovedEvent{}
+	mi := &file_api_contracts_profile_v1_events_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EngagementRemovedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EngagementRemovedEvent) ProtoMessage() {}
+
+func (x *EngagementRemovedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_contracts_profile_v1_events_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EngagementRemovedEvent.ProtoReflect.Descriptor instead.
+func (*EngagementRemovedEvent) Descriptor() ([]byte, []int) {
+	return file_api_contracts_profile_v1_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *EngagementRemovedEvent) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *EngagementRemovedEvent) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *EngagementRemovedEvent) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *EngagementRemovedEvent) GetFavoriteType() FavoriteType {
+	if x != nil {
+		return x.FavoriteType
+	}
+	return FavoriteType_FAVORITE_TYPE_UNSPECIFIED
+}
+
+func (x *EngagementRemovedEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *EngagementRemovedEvent) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+var File_api_contracts_profile_v1_events_proto protoreflect.FileDescriptor
+
+const file_api_contracts_profile_v1_events_proto_rawDesc = "" +
+	"\n" +
+	"%api/contracts/profile/v1/events.proto\x12\x14contracts.profile.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x83\x01\n" +
+	"\x10UserDeletedEvent\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12;\n" +
+	"\voccurred_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\"\x9f\x02\n" +
+	"\x14EngagementAddedEvent\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x19\n" +
+	"\bvideo_id\x18\x03 \x01(\tR\avideoId\x12G\n" +
+	"\rfavorite_type\x18\x04 \x01(\x0e2\".contracts.profile.v1.FavoriteTypeR\ffavoriteType\x12;\n" +
+	"\voccurred_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12\x1a\n" +
+	"\bsequence\x18\x06 \x01(\x03R\bsequence\x12\x16\n" +
+	"\x06rating\x18\a \x01(\x05R\x06rating\"\x89\x02\n" +
+	"\x16EngagementRemovedEvent\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x19\n" +
+	"\bvideo_id\x18\x03 \x01(\tR\avideoId\x12G\n" +
+	"\rfavorite_type\x18\x04 \x01(\x0e2\".contracts.profile.v1.FavoriteTypeR\ffavoriteType\x12;\n" +
+	"\voccurred_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12\x1a\n" +
+	"\bsequence\x18\x06 \x01(\x03R\bsequence*\x94\x01\n" +
+	"\fFavoriteType\x12\x1d\n" +
+	"\x19FAVORITE_TYPE_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12FAVORITE_TYPE_LIKE\x10\x01\x12\x1a\n" +
+	"\x16FAVORITE_TYPE_BOOKMARK\x10\x02\x12\x17\n" +
+	"\x13FAVORITE_TYPE_SHARE\x10\x03\x12\x18\n" +
+	"\x14FAVORITE_TYPE_RATING\x10\x04BZZXgithub.com/bionicotaku/lingo-services-catalog/api/contracts/profile/v1;profilecontractv1b\x06proto3"
+
+var (
+	file_api_contracts_profile_v1_events_proto_rawDescOnce sync.Once
+	file_api_contracts_profile_v1_events_proto_rawDescData []byte
+)
+
+func file_api_contracts_profile_v1_events_proto_rawDescGZIP() []byte {
+	file_api_contracts_profile_v1_events_proto_rawDescOnce.Do(func() {
+		file_api_contracts_profile_v1_events_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_contracts_profile_v1_events_proto_rawDesc), len(file_api_contracts_profile_v1_events_proto_rawDesc)))
+	})
+	return file_api_contracts_profile_v1_events_proto_rawDescData
+}
+
+var file_api_contracts_profile_v1_events_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_api_contracts_profile_v1_events_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_api_contracts_profile_v1_events_proto_goTypes = []any{
+	(FavoriteType)(0),              // 0: contracts.profile.v1.FavoriteType
+	(*UserDeletedEvent)(nil),       // 1: contracts.profile.v1.UserDeletedEvent
+	(*EngagementAddedEvent)(nil),   // 2: contracts.profile.v1.EngagementAddedEvent
+	(*EngagementRemovedEvent)(nil), // 3: contracts.profile.v1.EngagementRemovedEvent
+	(*timestamppb.Timestamp)(nil),  // 4: google.protobuf.Timestamp
+}
+var file_api_contracts_profile_v1_events_proto_depIdxs = []int32{
+	4, // 0: contracts.profile.v1.UserDeletedEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	0, // 1: contracts.profile.v1.EngagementAddedEvent.favorite_type:type_name -> contracts.profile.v1.FavoriteType
+	4, // 2: contracts.profile.v1.EngagementAddedEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	0, // 3: contracts.profile.v1.EngagementRemovedEvent.favorite_type:type_name -> contracts.profile.v1.FavoriteType
+	4, // 4: contracts.profile.v1.EngagementRemovedEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_api_contracts_profile_v1_events_proto_init() }
+func file_api_contracts_profile_v1_events_proto_init() {
+	if File_api_contracts_profile_v1_events_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_contracts_profile_v1_events_proto_rawDesc), len(file_api_contracts_profile_v1_events_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_api_contracts_profile_v1_events_proto_goTypes,
+		DependencyIndexes: file_api_contracts_profile_v1_events_proto_depIdxs,
+		EnumInfos:         file_api_contracts_profile_v1_events_proto_enumTypes,
+		MessageInfos:      file_api_contracts_profile_v1_events_proto_msgTypes,
+	}.Build()
+	File_api_contracts_profile_v1_events_proto = out.File
+	file_api_contracts_profile_v1_events_proto_goTypes = nil
+	file_api_contracts_profile_v1_events_proto_depIdxs = nil
+}
@@ -43,20 +43,35 @@ const (
 	ErrorReason_ERROR_REASON_UPLOAD_INVALID ErrorReason = 7
 	// 上传已完成，不允许重复发起
 	ErrorReason_ERROR_REASON_UPLOAD_ALREADY_COMPLETED ErrorReason = 8
+	// 上传配额已用尽（当日会话数/并发上传数/存储字节数）
+	ErrorReason_ERROR_REASON_UPLOAD_QUOTA_EXCEEDED ErrorReason = 9
+	// 视频暂不可播放（转码产物未就绪或播放签名未配置）
+	ErrorReason_ERROR_REASON_PLAYBACK_UNAVAILABLE ErrorReason = 10
+	// 调用方缺少管理员角色（运维管理接口）
+	ErrorReason_ERROR_REASON_ADMIN_ROLE_REQUIRED ErrorReason = 11
+	// Outbox 事件未找到
+	ErrorReason_ERROR_REASON_OUTBOX_EVENT_NOT_FOUND ErrorReason = 12
+	// Outbox 处置请求无效（参数非法，或事件已发布/已放弃）
+	ErrorReason_ERROR_REASON_OUTBOX_ADMIN_INVALID ErrorReason = 13
 )
 
 // Enum value maps for ErrorReason.
 var (
 	ErrorReason_name = map[int32]string{
-		0: "ERROR_REASON_UNSPECIFIED",
-		1: "ERROR_REASON_VIDEO_NOT_FOUND",
-		2: "ERROR_REASON_VIDEO_ID_INVALID",
-		3: "ERROR_REASON_QUERY_VIDEO_FAILED",
-		4: "ERROR_REASON_QUERY_TIMEOUT",
-		5: "ERROR_REASON_VIDEO_UPDATE_INVALID",
-		6: "ERROR_REASON_VIDEO_DELETE_INVALID",
-		7: "ERROR_REASON_UPLOAD_INVALID",
-		8: "ERROR_REASON_UPLOAD_ALREADY_COMPLETED",
+		0:  "ERROR_REASON_UNSPECIFIED",
+		1:  "ERROR_REASON_VIDEO_NOT_FOUND",
+		2:  "ERROR_REASON_VIDEO_ID_INVALID",
+		3:  "ERROR_REASON_QUERY_VIDEO_FAILED",
+		4:  "ERROR_REASON_QUERY_TIMEOUT",
+		5:  "ERROR_REASON_VIDEO_UPDATE_INVALID",
+		6:  "ERROR_REASON_VIDEO_DELETE_INVALID",
+		7:  "ERROR_REASON_UPLOAD_INVALID",
+		8:  "ERROR_REASON_UPLOAD_ALREADY_COMPLETED",
+		9:  "ERROR_REASON_UPLOAD_QUOTA_EXCEEDED",
+		10: "ERROR_REASON_PLAYBACK_UNAVAILABLE",
+		11: "ERROR_REASON_ADMIN_ROLE_REQUIRED",
+		12: "ERROR_REASON_OUTBOX_EVENT_NOT_FOUND",
+		13: "ERROR_REASON_OUTBOX_ADMIN_INVALID",
 	}
 	ErrorReason_value = map[string]int32{
 		"ERROR_REASON_UNSPECIFIED":              0,
@@ -68,6 +83,11 @@ var (
 		"ERROR_REASON_VIDEO_DELETE_INVALID":     6,
 		"ERROR_REASON_UPLOAD_INVALID":           7,
 		"ERROR_REASON_UPLOAD_ALREADY_COMPLETED": 8,
+		"ERROR_REASON_UPLOAD_QUOTA_EXCEEDED":    9,
+		"ERROR_REASON_PLAYBACK_UNAVAILABLE":     10,
+		"ERROR_REASON_ADMIN_ROLE_REQUIRED":      11,
+		"ERROR_REASON_OUTBOX_EVENT_NOT_FOUND":   12,
+		"ERROR_REASON_OUTBOX_ADMIN_INVALID":     13,
 	}
 )
 
@@ -102,7 +122,7 @@ var File_api_video_v1_error_reason_proto protoreflect.FileDescriptor
 
 const file_api_video_v1_error_reason_proto_rawDesc = "" +
 	"\n" +
-	"\x1fapi/video/v1/error_reason.proto\x12\bvideo.v1*\xcf\x02\n" +
+	"\x1fapi/video/v1/error_reason.proto\x12\bvideo.v1*\x94\x04\n" +
 	"\vErrorReason\x12\x1c\n" +
 	"\x18ERROR_REASON_UNSPECIFIED\x10\x00\x12 \n" +
 	"\x1cERROR_REASON_VIDEO_NOT_FOUND\x10\x01\x12!\n" +
@@ -112,7 +132,13 @@ const file_api_video_v1_error_reason_proto_rawDesc = "" +
 	"!ERROR_REASON_VIDEO_UPDATE_INVALID\x10\x05\x12%\n" +
 	"!ERROR_REASON_VIDEO_DELETE_INVALID\x10\x06\x12\x1f\n" +
 	"\x1bERROR_REASON_UPLOAD_INVALID\x10\a\x12)\n" +
-	"%ERROR_REASON_UPLOAD_ALREADY_COMPLETED\x10\bBDZBgithub.com/bionicotaku/lingo-services-catalog/api/video/v1;videov1b\x06proto3"
+	"%ERROR_REASON_UPLOAD_ALREADY_COMPLETED\x10\b\x12&\n" +
+	"\"ERROR_REASON_UPLOAD_QUOTA_EXCEEDED\x10\t\x12%\n" +
+	"!ERROR_REASON_PLAYBACK_UNAVAILABLE\x10\n" +
+	"\x12$\n" +
+	" ERROR_REASON_ADMIN_ROLE_REQUIRED\x10\v\x12'\n" +
+	"#ERROR_REASON_OUTBOX_EVENT_NOT_FOUND\x10\f\x12%\n" +
+	"!ERROR_REASON_OUTBOX_ADMIN_INVALID\x10\rBDZBgithub.com/bionicotaku/lingo-services-catalog/api/video/v1;videov1b\x06proto3"
 
 var (
 	file_api_video_v1_error_reason_proto_rawDescOnce sync.Once
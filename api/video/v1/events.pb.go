@@ -25,10 +25,11 @@ const (
 type EventType int32
 
 const (
-	EventType_EVENT_TYPE_UNSPECIFIED   EventType = 0
-	EventType_EVENT_TYPE_VIDEO_CREATED EventType = 1
-	EventType_EVENT_TYPE_VIDEO_UPDATED EventType = 2
-	EventType_EVENT_TYPE_VIDEO_DELETED EventType = 3
+	EventType_EVENT_TYPE_UNSPECIFIED    EventType = 0
+	EventType_EVENT_TYPE_VIDEO_CREATED  EventType = 1
+	EventType_EVENT_TYPE_VIDEO_UPDATED  EventType = 2
+	EventType_EVENT_TYPE_VIDEO_DELETED  EventType = 3
+	EventType_EVENT_TYPE_VIDEO_SNAPSHOT EventType = 4 // 全量快照（回填产生，非实时变更）
 )
 
 // Enum value maps for EventType.
@@ -38,12 +39,14 @@ var (
 		1: "EVENT_TYPE_VIDEO_CREATED",
 		2: "EVENT_TYPE_VIDEO_UPDATED",
 		3: "EVENT_TYPE_VIDEO_DELETED",
+		4: "EVENT_TYPE_VIDEO_SNAPSHOT",
 	}
 	EventType_value = map[string]int32{
-		"EVENT_TYPE_UNSPECIFIED":   0,
-		"EVENT_TYPE_VIDEO_CREATED": 1,
-		"EVENT_TYPE_VIDEO_UPDATED": 2,
-		"EVENT_TYPE_VIDEO_DELETED": 3,
+		"EVENT_TYPE_UNSPECIFIED":    0,
+		"EVENT_TYPE_VIDEO_CREATED":  1,
+		"EVENT_TYPE_VIDEO_UPDATED":  2,
+		"EVENT_TYPE_VIDEO_DELETED":  3,
+		"EVENT_TYPE_VIDEO_SNAPSHOT": 4,
 	}
 )
 
@@ -91,6 +94,7 @@ type Event struct {
 	//	*Event_Created
 	//	*Event_Updated
 	//	*Event_Deleted
+	//	*Event_Snapshot
 	Payload       isEvent_Payload `protobuf_oneof:"payload"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -202,6 +206,15 @@ func (x *Event) GetDeleted() *Event_VideoDeleted {
 	return nil
 }
 
+func (x *Event) GetSnapshot() *Event_VideoSnapshot {
+	if x != nil {
+		if x, ok := x.Payload.(*Event_Snapshot); ok {
+			return x.Snapshot
+		}
+	}
+	return nil
+}
+
 type isEvent_Payload interface {
 	isEvent_Payload()
 }
@@ -218,12 +231,18 @@ type Event_Deleted struct {
 	Deleted *Event_VideoDeleted `protobuf:"bytes,12,opt,name=deleted,proto3,oneof"`
 }
 
+type Event_Snapshot struct {
+	Snapshot *Event_VideoSnapshot `protobuf:"bytes,13,opt,name=snapshot,proto3,oneof"`
+}
+
 func (*Event_Created) isEvent_Payload() {}
 
 func (*Event_Updated) isEvent_Payload() {}
 
 func (*Event_Deleted) isEvent_Payload() {}
 
+func (*Event_Snapshot) isEvent_Payload() {}
+
 // VideoCreated 表示视频创建事件
 // 当一个新视频元数据被创建时发布此事件
 type Event_VideoCreated struct {
@@ -373,6 +392,7 @@ type Event_VideoUpdated struct {
 	RawSubtitleUrl    *string  `protobuf:"bytes,21,opt,name=raw_subtitle_url,json=rawSubtitleUrl,proto3,oneof" json:"raw_subtitle_url,omitempty"`
 	PublishedAt       *string  `protobuf:"bytes,22,opt,name=published_at,json=publishedAt,proto3,oneof" json:"published_at,omitempty"`
 	VisibilityStatus  *string  `protobuf:"bytes,23,opt,name=visibility_status,json=visibilityStatus,proto3,oneof" json:"visibility_status,omitempty"`
+	RawMissing        *bool    `protobuf:"varint,24,opt,name=raw_missing,json=rawMissing,proto3,oneof" json:"raw_missing,omitempty"` // 原始对象已删除/归档（true）或已恢复（false）
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -526,6 +546,13 @@ func (x *Event_VideoUpdated) GetVisibilityStatus() string {
 	return ""
 }
 
+func (x *Event_VideoUpdated) GetRawMissing() bool {
+	if x != nil && x.RawMissing != nil {
+		return *x.RawMissing
+	}
+	return false
+}
+
 // VideoDeleted 表示视频删除事件
 // 当视频被删除时发布此事件
 type Event_VideoDeleted struct {
@@ -604,11 +631,234 @@ func (x *Event_VideoDeleted) GetReason() string {
 	return ""
 }
 
+// VideoSnapshot 表示视频全量状态快照
+// 由事件回填任务为新接入的下游消费者生成，携带视频当前的完整状态（非增量）
+// version / occurred_at 取视频最近一次更新时间，消费者可与实时事件按版本号比较，避免旧快照覆盖新状态
+type Event_VideoSnapshot struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	VideoId           string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`                             // 视频唯一标识 (UUID)
+	UploaderId        string                 `protobuf:"bytes,2,opt,name=uploader_id,json=uploaderId,proto3" json:"uploader_id,omitempty"`                    // 上传者标识 (UUID)
+	Title             string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`                                                // 视频标题
+	Description       *string                `protobuf:"bytes,4,opt,name=description,proto3,oneof" json:"description,omitempty"`                              // 视频描述（可选）
+	DurationMicros    *int64                 `protobuf:"varint,5,opt,name=duration_micros,json=durationMicros,proto3,oneof" json:"duration_micros,omitempty"` // 视频时长（微秒，可选）
+	Status            string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`                                              // 视频状态
+	MediaStatus       string                 `protobuf:"bytes,7,opt,name=media_status,json=mediaStatus,proto3" json:"media_status,omitempty"`                 // 媒体处理状态
+	AnalysisStatus    string                 `protobuf:"bytes,8,opt,name=analysis_status,json=analysisStatus,proto3" json:"analysis_status,omitempty"`        // 分析状态
+	ThumbnailUrl      *string                `protobuf:"bytes,9,opt,name=thumbnail_url,json=thumbnailUrl,proto3,oneof" json:"thumbnail_url,omitempty"`
+	HlsMasterPlaylist *string                `protobuf:"bytes,10,opt,name=hls_master_playlist,json=hlsMasterPlaylist,proto3,oneof" json:"hls_master_playlist,omitempty"`
+	Difficulty        *string                `protobuf:"bytes,11,opt,name=difficulty,proto3,oneof" json:"difficulty,omitempty"`
+	Summary           *string                `protobuf:"bytes,12,opt,name=summary,proto3,oneof" json:"summary,omitempty"`
+	Tags              []string               `protobuf:"bytes,13,rep,name=tags,proto3" json:"tags,omitempty"`
+	RawSubtitleUrl    *string                `protobuf:"bytes,14,opt,name=raw_subtitle_url,json=rawSubtitleUrl,proto3,oneof" json:"raw_subtitle_url,omitempty"`
+	VisibilityStatus  string                 `protobuf:"bytes,15,opt,name=visibility_status,json=visibilityStatus,proto3" json:"visibility_status,omitempty"`
+	PublishedAt       *string                `protobuf:"bytes,16,opt,name=published_at,json=publishedAt,proto3,oneof" json:"published_at,omitempty"` // 发布时间（UTC RFC3339，可选）
+	RawMissing        bool                   `protobuf:"varint,17,opt,name=raw_missing,json=rawMissing,proto3" json:"raw_missing,omitempty"`         // 原始对象已删除/归档
+	CreatedAt         string                 `protobuf:"bytes,18,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`             // 视频创建时间（UTC RFC3339）
+	UpdatedAt         string                 `protobuf:"bytes,19,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`             // 视频最近更新时间（UTC RFC3339）
+	Version           int64                  `protobuf:"varint,20,opt,name=version,proto3" json:"version,omitempty"`                                 // 聚合版本号
+	OccurredAt        string                 `protobuf:"bytes,21,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`          // 快照对应的状态时间（UTC RFC3339）
+	BackfillId        string                 `protobuf:"bytes,22,opt,name=backfill_id,json=backfillId,proto3" json:"backfill_id,omitempty"`          // 产生该快照的回填任务标识
+	SnapshotAt        string                 `protobuf:"bytes,23,opt,name=snapshot_at,json=snapshotAt,proto3" json:"snapshot_at,omitempty"`          // 快照读取时间（UTC RFC3339）
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Event_VideoSnapshot) Reset() {
+	*x = Event_VideoSnapshot{}
+	mi := &file_api_video_v1_events_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event_VideoSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event_VideoSnapshot) ProtoMessage() {}
+
+func (x *Event_VideoSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_api_video_v1_events_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event_VideoSnapshot.ProtoReflect.Descriptor instead.
+func (*Event_VideoSnapshot) Descriptor() ([]byte, []int) {
+	return file_api_video_v1_events_proto_rawDescGZIP(), []int{0, 3}
+}
+
+func (x *Event_VideoSnapshot) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *Event_VideoSnapshot) GetUploaderId() string {
+	if x != nil {
+		return x.UploaderId
+	}
+	return ""
+}
+
+func (x *Event_VideoSnapshot) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Event_VideoSnapshot) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *Event_VideoSnapshot) GetDurationMicros() int64 {
+	if x != nil && x.DurationMicros != nil {
+		return *x.DurationMicros
+	}
+	return 0
+}
+
+func (x *Event_VideoSnapshot) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Event_VideoSnapshot) GetMediaStatus() string {
+	if x != nil {
+		return x.MediaStatus
+	}
+	return ""
+}
+
+func (x *Event_VideoSnapshot) GetAnalysisStatus() string {
+	if x != nil {
+		return x.AnalysisStatus
+	}
+	return ""
+}
+
+func (x *Event_VideoSnapshot) GetThumbnailUrl() string {
+	if x != nil && x.ThumbnailUrl != nil {
+		return *x.ThumbnailUrl
+	}
+	return ""
+}
+
+func (x *Event_VideoSnapshot) GetHlsMasterPlaylist() string {
+	if x != nil && x.HlsMasterPlaylist != nil {
+		return *x.HlsMasterPlaylist
+	}
+	return ""
+}
+
+func (x *Event_VideoSnapshot) GetDifficulty() string {
+	if x != nil && x.Difficulty != nil {
+		return *x.Difficulty
+	}
+	return ""
+}
+
+func (x *Event_VideoSnapshot) GetSummary() string {
+	if x != nil && x.Summary != nil {
+		return *x.Summary
+	}
+	return ""
+}
+
+func (x *Event_VideoSnapshot) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Event_VideoSnapshot) GetRawSubtitleUrl() string {
+	if x != nil && x.RawSubtitleUrl != nil {
+		return *x.RawSubtitleUrl
+	}
+	return ""
+}
+
+func (x *Event_VideoSnapshot) GetVisibilityStatus() string {
+	if x != nil {
+		return x.VisibilityStatus
+	}
+	return ""
+}
+
+func (x *Event_VideoSnapshot) GetPublishedAt() string {
+	if x != nil && x.PublishedAt != nil {
+		return *x.PublishedAt
+	}
+	return ""
+}
+
+func (x *Event_VideoSnapshot) GetRawMissing() bool {
+	if x != nil {
+		return x.RawMissing
+	}
+	return false
+}
+
+func (x *Event_VideoSnapshot) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Event_VideoSnapshot) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *Event_VideoSnapshot) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Event_VideoSnapshot) GetOccurredAt() string {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return ""
+}
+
+func (x *Event_VideoSnapshot) GetBackfillId() string {
+	if x != nil {
+		return x.BackfillId
+	}
+	return ""
+}
+
+func (x *Event_VideoSnapshot) GetSnapshotAt() string {
+	if x != nil {
+		return x.SnapshotAt
+	}
+	return ""
+}
+
 var File_api_video_v1_events_proto protoreflect.FileDescriptor
 
 const file_api_video_v1_events_proto_rawDesc = "" +
 	"\n" +
-	"\x19api/video/v1/events.proto\x12\bvideo.v1\"\xf3\x0e\n" +
+	"\x19api/video/v1/events.proto\x12\bvideo.v1\"\xa9\x17\n" +
 	"\x05Event\x12\x19\n" +
 	"\bevent_id\x18\x01 \x01(\tR\aeventId\x122\n" +
 	"\n" +
@@ -621,7 +871,8 @@ const file_api_video_v1_events_proto_rawDesc = "" +
 	"\acreated\x18\n" +
 	" \x01(\v2\x1c.video.v1.Event.VideoCreatedH\x00R\acreated\x128\n" +
 	"\aupdated\x18\v \x01(\v2\x1c.video.v1.Event.VideoUpdatedH\x00R\aupdated\x128\n" +
-	"\adeleted\x18\f \x01(\v2\x1c.video.v1.Event.VideoDeletedH\x00R\adeleted\x1a\xb1\x03\n" +
+	"\adeleted\x18\f \x01(\v2\x1c.video.v1.Event.VideoDeletedH\x00R\adeleted\x12;\n" +
+	"\bsnapshot\x18\r \x01(\v2\x1d.video.v1.Event.VideoSnapshotH\x00R\bsnapshot\x1a\xb1\x03\n" +
 	"\fVideoCreated\x12\x19\n" +
 	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x1f\n" +
 	"\vuploader_id\x18\x02 \x01(\tR\n" +
@@ -639,7 +890,7 @@ const file_api_video_v1_events_proto_rawDesc = "" +
 	"\x0fanalysis_status\x18\v \x01(\tR\x0eanalysisStatusB\x0e\n" +
 	"\f_descriptionB\x12\n" +
 	"\x10_duration_microsB\x0f\n" +
-	"\r_published_at\x1a\xe6\x06\n" +
+	"\r_published_at\x1a\x9c\a\n" +
 	"\fVideoUpdated\x12\x19\n" +
 	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x18\n" +
 	"\aversion\x18\x02 \x01(\x03R\aversion\x12\x1f\n" +
@@ -662,7 +913,9 @@ const file_api_video_v1_events_proto_rawDesc = "" +
 	"\x10raw_subtitle_url\x18\x15 \x01(\tH\n" +
 	"R\x0erawSubtitleUrl\x88\x01\x01\x12&\n" +
 	"\fpublished_at\x18\x16 \x01(\tH\vR\vpublishedAt\x88\x01\x01\x120\n" +
-	"\x11visibility_status\x18\x17 \x01(\tH\fR\x10visibilityStatus\x88\x01\x01B\b\n" +
+	"\x11visibility_status\x18\x17 \x01(\tH\fR\x10visibilityStatus\x88\x01\x01\x12$\n" +
+	"\vraw_missing\x18\x18 \x01(\bH\rR\n" +
+	"rawMissing\x88\x01\x01B\b\n" +
 	"\x06_titleB\x0e\n" +
 	"\f_descriptionB\x12\n" +
 	"\x10_duration_microsB\t\n" +
@@ -676,7 +929,8 @@ const file_api_video_v1_events_proto_rawDesc = "" +
 	"\b_summaryB\x13\n" +
 	"\x11_raw_subtitle_urlB\x0f\n" +
 	"\r_published_atB\x14\n" +
-	"\x12_visibility_status\x1a\xbf\x01\n" +
+	"\x12_visibility_statusB\x0e\n" +
+	"\f_raw_missing\x1a\xbf\x01\n" +
 	"\fVideoDeleted\x12\x19\n" +
 	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x18\n" +
 	"\aversion\x18\x02 \x01(\x03R\aversion\x12\"\n" +
@@ -686,13 +940,57 @@ const file_api_video_v1_events_proto_rawDesc = "" +
 	"occurredAt\x12\x1b\n" +
 	"\x06reason\x18\x05 \x01(\tH\x01R\x06reason\x88\x01\x01B\r\n" +
 	"\v_deleted_atB\t\n" +
-	"\a_reasonB\t\n" +
-	"\apayload*\x81\x01\n" +
+	"\a_reason\x1a\xc0\a\n" +
+	"\rVideoSnapshot\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x1f\n" +
+	"\vuploader_id\x18\x02 \x01(\tR\n" +
+	"uploaderId\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12%\n" +
+	"\vdescription\x18\x04 \x01(\tH\x00R\vdescription\x88\x01\x01\x12,\n" +
+	"\x0fduration_micros\x18\x05 \x01(\x03H\x01R\x0edurationMicros\x88\x01\x01\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12!\n" +
+	"\fmedia_status\x18\a \x01(\tR\vmediaStatus\x12'\n" +
+	"\x0fanalysis_status\x18\b \x01(\tR\x0eanalysisStatus\x12(\n" +
+	"\rthumbnail_url\x18\t \x01(\tH\x02R\fthumbnailUrl\x88\x01\x01\x123\n" +
+	"\x13hls_master_playlist\x18\n" +
+	" \x01(\tH\x03R\x11hlsMasterPlaylist\x88\x01\x01\x12#\n" +
+	"\n" +
+	"difficulty\x18\v \x01(\tH\x04R\n" +
+	"difficulty\x88\x01\x01\x12\x1d\n" +
+	"\asummary\x18\f \x01(\tH\x05R\asummary\x88\x01\x01\x12\x12\n" +
+	"\x04tags\x18\r \x03(\tR\x04tags\x12-\n" +
+	"\x10raw_subtitle_url\x18\x0e \x01(\tH\x06R\x0erawSubtitleUrl\x88\x01\x01\x12+\n" +
+	"\x11visibility_status\x18\x0f \x01(\tR\x10visibilityStatus\x12&\n" +
+	"\fpublished_at\x18\x10 \x01(\tH\aR\vpublishedAt\x88\x01\x01\x12\x1f\n" +
+	"\vraw_missing\x18\x11 \x01(\bR\n" +
+	"rawMissing\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x12 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x13 \x01(\tR\tupdatedAt\x12\x18\n" +
+	"\aversion\x18\x14 \x01(\x03R\aversion\x12\x1f\n" +
+	"\voccurred_at\x18\x15 \x01(\tR\n" +
+	"occurredAt\x12\x1f\n" +
+	"\vbackfill_id\x18\x16 \x01(\tR\n" +
+	"backfillId\x12\x1f\n" +
+	"\vsnapshot_at\x18\x17 \x01(\tR\n" +
+	"snapshotAtB\x0e\n" +
+	"\f_descriptionB\x12\n" +
+	"\x10_duration_microsB\x10\n" +
+	"\x0e_thumbnail_urlB\x16\n" +
+	"\x14_hls_master_playlistB\r\n" +
+	"\v_difficultyB\n" +
+	"\n" +
+	"\b_summaryB\x13\n" +
+	"\x11_raw_subtitle_urlB\x0f\n" +
+	"\r_published_atB\t\n" +
+	"\apayload*\xa0\x01\n" +
 	"\tEventType\x12\x1a\n" +
 	"\x16EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x1c\n" +
 	"\x18EVENT_TYPE_VIDEO_CREATED\x10\x01\x12\x1c\n" +
 	"\x18EVENT_TYPE_VIDEO_UPDATED\x10\x02\x12\x1c\n" +
-	"\x18EVENT_TYPE_VIDEO_DELETED\x10\x03BDZBgithub.com/bionicotaku/lingo-services-catalog/api/video/v1;videov1b\x06proto3"
+	"\x18EVENT_TYPE_VIDEO_DELETED\x10\x03\x12\x1d\n" +
+	"\x19EVENT_TYPE_VIDEO_SNAPSHOT\x10\x04BDZBgithub.com/bionicotaku/lingo-services-catalog/api/video/v1;videov1b\x06proto3"
 
 var (
 	file_api_video_v1_events_proto_rawDescOnce sync.Once
@@ -707,24 +1005,26 @@ func file_api_video_v1_events_proto_rawDescGZIP() []byte {
 }
 
 var file_api_video_v1_events_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_api_video_v1_events_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_api_video_v1_events_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_api_video_v1_events_proto_goTypes = []any{
-	(EventType)(0),             // 0: video.v1.EventType
-	(*Event)(nil),              // 1: video.v1.Event
-	(*Event_VideoCreated)(nil), // 2: video.v1.Event.VideoCreated
-	(*Event_VideoUpdated)(nil), // 3: video.v1.Event.VideoUpdated
-	(*Event_VideoDeleted)(nil), // 4: video.v1.Event.VideoDeleted
+	(EventType)(0),              // 0: video.v1.EventType
+	(*Event)(nil),               // 1: video.v1.Event
+	(*Event_VideoCreated)(nil),  // 2: video.v1.Event.VideoCreated
+	(*Event_VideoUpdated)(nil),  // 3: video.v1.Event.VideoUpdated
+	(*Event_VideoDeleted)(nil),  // 4: video.v1.Event.VideoDeleted
+	(*Event_VideoSnapshot)(nil), // 5: video.v1.Event.VideoSnapshot
 }
 var file_api_video_v1_events_proto_depIdxs = []int32{
 	0, // 0: video.v1.Event.event_type:type_name -> video.v1.EventType
 	2, // 1: video.v1.Event.created:type_name -> video.v1.Event.VideoCreated
 	3, // 2: video.v1.Event.updated:type_name -> video.v1.Event.VideoUpdated
 	4, // 3: video.v1.Event.deleted:type_name -> video.v1.Event.VideoDeleted
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	5, // 4: video.v1.Event.snapshot:type_name -> video.v1.Event.VideoSnapshot
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_api_video_v1_events_proto_init() }
@@ -736,17 +1036,19 @@ func file_api_video_v1_events_proto_init() {
 		(*Event_Created)(nil),
 		(*Event_Updated)(nil),
 		(*Event_Deleted)(nil),
+		(*Event_Snapshot)(nil),
 	}
 	file_api_video_v1_events_proto_msgTypes[1].OneofWrappers = []any{}
 	file_api_video_v1_events_proto_msgTypes[2].OneofWrappers = []any{}
 	file_api_video_v1_events_proto_msgTypes[3].OneofWrappers = []any{}
+	file_api_video_v1_events_proto_msgTypes[4].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_video_v1_events_proto_rawDesc), len(file_api_video_v1_events_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   4,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
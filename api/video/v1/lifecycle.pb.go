@@ -717,6 +717,7 @@ type VideoRevision struct {
 	UpdatedAt      string                 `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	EventId        string                 `protobuf:"bytes,7,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
 	OccurredAt     string                 `protobuf:"bytes,8,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	RawMissing     bool                   `protobuf:"varint,9,opt,name=raw_missing,json=rawMissing,proto3" json:"raw_missing,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -807,6 +808,13 @@ func (x *VideoRevision) GetOccurredAt() string {
 	return ""
 }
 
+func (x *VideoRevision) GetRawMissing() bool {
+	if x != nil {
+		return x.RawMissing
+	}
+	return false
+}
+
 type UpdateOriginalMediaResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Revision      *VideoRevision         `protobuf:"bytes,1,opt,name=revision,proto3" json:"revision,omitempty"`
@@ -1121,7 +1129,7 @@ const file_api_video_v1_lifecycle_proto_rawDesc = "" +
 	"\x06reason\x18\x02 \x01(\tH\x00R\x06reason\x88\x01\x01\x127\n" +
 	"\x10expected_version\x18\x03 \x01(\x03B\a\xbaH\x04\"\x02 \x00H\x01R\x0fexpectedVersion\x88\x01\x01B\t\n" +
 	"\a_reasonB\x13\n" +
-	"\x11_expected_version\"\x83\x02\n" +
+	"\x11_expected_version\"\xa4\x02\n" +
 	"\rVideoRevision\x12\x19\n" +
 	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\x12!\n" +
@@ -1132,7 +1140,9 @@ const file_api_video_v1_lifecycle_proto_rawDesc = "" +
 	"updated_at\x18\x06 \x01(\tR\tupdatedAt\x12\x19\n" +
 	"\bevent_id\x18\a \x01(\tR\aeventId\x12\x1f\n" +
 	"\voccurred_at\x18\b \x01(\tR\n" +
-	"occurredAt\"R\n" +
+	"occurredAt\x12\x1f\n" +
+	"\vraw_missing\x18\t \x01(\bR\n" +
+	"rawMissing\"R\n" +
 	"\x1bUpdateOriginalMediaResponse\x123\n" +
 	"\brevision\x18\x01 \x01(\v2\x17.video.v1.VideoRevisionR\brevision\"U\n" +
 	"\x1eUpdateProcessingStatusResponse\x123\n" +
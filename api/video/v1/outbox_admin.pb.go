@@ -0,0 +1,795 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: api/video/v1/outbox_admin.proto
+
+package videov1
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// OutboxEventStatus 描述未发布事件的处置状态。
+type OutboxEventStatus int32
+
+const (
+	OutboxEventStatus_OUTBOX_EVENT_STATUS_UNSPECIFIED OutboxEventStatus = 0
+	// 等待发布（含从未投递过的事件）
+	OutboxEventStatus_OUTBOX_EVENT_STATUS_PENDING OutboxEventStatus = 1
+	// 等待发布且至少投递失败过一次
+	OutboxEventStatus_OUTBOX_EVENT_STATUS_FAILED OutboxEventStatus = 2
+	// 已被人工放弃
+	OutboxEventStatus_OUTBOX_EVENT_STATUS_ABANDONED OutboxEventStatus = 3
+	// 已发布（仅 GetOutboxEvent 返回）
+	OutboxEventStatus_OUTBOX_EVENT_STATUS_PUBLISHED OutboxEventStatus = 4
+)
+
+// Enum value maps for OutboxEventStatus.
+var (
+	OutboxEventStatus_name = map[int32]string{
+		0: "OUTBOX_EVENT_STATUS_UNSPECIFIED",
+		1: "OUTBOX_EVENT_STATUS_PENDING",
+		2: "OUTBOX_EVENT_STATUS_FAILED",
+		3: "OUTBOX_EVENT_STATUS_ABANDONED",
+		4: "OUTBOX_EVENT_STATUS_PUBLISHED",
+	}
+	OutboxEventStatus_value = map[string]int32{
+		"OUTBOX_EVENT_STATUS_UNSPECIFIED": 0,
+		"OUTBOX_EVENT_STATUS_PENDING":     1,
+		"OUTBOX_EVENT_STATUS_FAILED":      2,
+		"OUTBOX_EVENT_STATUS_ABANDONED":   3,
+		"OUTBOX_EVENT_STATUS_PUBLISHED":   4,
+	}
+)
+
+func (x OutboxEventStatus) Enum() *OutboxEventStatus {
+	p := new(OutboxEventStatus)
+	*p = x
+	return p
+}
+
+func (x OutboxEventStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OutboxEventStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_video_v1_outbox_admin_proto_enumTypes[0].Descriptor()
+}
+
+func (OutboxEventStatus) Type() protoreflect.EnumType {
+	return &file_api_video_v1_outbox_admin_proto_enumTypes[0]
+}
+
+func (x OutboxEventStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OutboxEventStatus.Descriptor instead.
+func (OutboxEventStatus) EnumDescriptor() ([]byte, []int) {
+	return file_api_video_v1_outbox_admin_proto_rawDescGZIP(), []int{0}
+}
+
+// OutboxEventSummary 是 Outbox 事件的投递状态快照。
+type OutboxEventSummary struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	EventId          string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	AggregateType    string                 `protobuf:"bytes,2,opt,name=aggregate_type,json=aggregateType,proto3" json:"aggregate_type,omitempty"`
+	AggregateId      string                 `protobuf:"bytes,3,opt,name=aggregate_id,json=aggregateId,proto3" json:"aggregate_id,omitempty"`
+	EventType        string                 `protobuf:"bytes,4,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	Status           OutboxEventStatus      `protobuf:"varint,5,opt,name=status,proto3,enum=video.v1.OutboxEventStatus" json:"status,omitempty"`
+	OccurredAtUnixms int64                  `protobuf:"varint,6,opt,name=occurred_at_unixms,json=occurredAtUnixms,proto3" json:"occurred_at_unixms,omitempty"`
+	// 下次可发布时间；已放弃的事件为 0
+	AvailableAtUnixms int64  `protobuf:"varint,7,opt,name=available_at_unixms,json=availableAtUnixms,proto3" json:"available_at_unixms,omitempty"`
+	PublishedAtUnixms int64  `protobuf:"varint,8,opt,name=published_at_unixms,json=publishedAtUnixms,proto3" json:"published_at_unixms,omitempty"`
+	DeliveryAttempts  int32  `protobuf:"varint,9,opt,name=delivery_attempts,json=deliveryAttempts,proto3" json:"delivery_attempts,omitempty"`
+	LastError         string `protobuf:"bytes,10,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	LockToken         string `protobuf:"bytes,11,opt,name=lock_token,json=lockToken,proto3" json:"lock_token,omitempty"`
+	LockedAtUnixms    int64  `protobuf:"varint,12,opt,name=locked_at_unixms,json=lockedAtUnixms,proto3" json:"locked_at_unixms,omitempty"`
+	AbandonedAtUnixms int64  `protobuf:"varint,13,opt,name=abandoned_at_unixms,json=abandonedAtUnixms,proto3" json:"abandoned_at_unixms,omitempty"`
+	AbandonedReason   string `protobuf:"bytes,14,opt,name=abandoned_reason,json=abandonedReason,proto3" json:"abandoned_reason,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *OutboxEventSummary) Reset() {
+	*x = OutboxEventSummary{}
+	mi := &file_api_video_v1_outbox_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OutboxEventSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutboxEventSummary) ProtoMessage() {}
+
+func (x *OutboxEventSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_api_video_v1_outbox_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutboxEventSummary.ProtoReflect.Descriptor instead.
+func (*OutboxEventSummary) Descriptor() ([]byte, []int) {
+	return file_api_video_v1_outbox_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OutboxEventSummary) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *OutboxEventSummary) GetAggregateType() string {
+	if x != nil {
+		return x.AggregateType
+	}
+	return ""
+}
+
+func (x *OutboxEventSummary) GetAggregateId() string {
+	if x != nil {
+		return x.AggregateId
+	}
+	return ""
+}
+
+func (x *OutboxEventSummary) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *OutboxEventSummary) GetStatus() OutboxEventStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OutboxEventStatus_OUTBOX_EVENT_STATUS_UNSPECIFIED
+}
+
+func (x *OutboxEventSummary) GetOccurredAtUnixms() int64 {
+	if x != nil {
+		return x.OccurredAtUnixms
+	}
+	return 0
+}
+
+func (x *OutboxEventSummary) GetAvailableAtUnixms() int64 {
+	if x != nil {
+		return x.AvailableAtUnixms
+	}
+	return 0
+}
+
+func (x *OutboxEventSummary) GetPublishedAtUnixms() int64 {
+	if x != nil {
+		return x.PublishedAtUnixms
+	}
+	return 0
+}
+
+func (x *OutboxEventSummary) GetDeliveryAttempts() int32 {
+	if x != nil {
+		return x.DeliveryAttempts
+	}
+	return 0
+}
+
+func (x *OutboxEventSummary) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *OutboxEventSummary) GetLockToken() string {
+	if x != nil {
+		return x.LockToken
+	}
+	return ""
+}
+
+func (x *OutboxEventSummary) GetLockedAtUnixms() int64 {
+	if x != nil {
+		return x.LockedAtUnixms
+	}
+	return 0
+}
+
+func (x *OutboxEventSummary) GetAbandonedAtUnixms() int64 {
+	if x != nil {
+		return x.AbandonedAtUnixms
+	}
+	return 0
+}
+
+func (x *OutboxEventSummary) GetAbandonedReason() string {
+	if x != nil {
+		return x.AbandonedReason
+	}
+	return ""
+}
+
+// ListOutboxEventsRequest 限定列出的事件；status 缺省为 PENDING，page_size 缺省 50、最大 500。
+type ListOutboxEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        OutboxEventStatus      `protobuf:"varint,1,opt,name=status,proto3,enum=video.v1.OutboxEventStatus" json:"status,omitempty"`
+	AggregateId   string                 `protobuf:"bytes,2,opt,name=aggregate_id,json=aggregateId,proto3" json:"aggregate_id,omitempty"`
+	EventType     string                 `protobuf:"bytes,3,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	MinAttempts   int32                  `protobuf:"varint,4,opt,name=min_attempts,json=minAttempts,proto3" json:"min_attempts,omitempty"`
+	PageSize      int32                  `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOutboxEventsRequest) Reset() {
+	*x = ListOutboxEventsRequest{}
+	mi := &file_api_video_v1_outbox_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOutboxEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOutboxEventsRequest) ProtoMessage() {}
+
+func (x *ListOutboxEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_video_v1_outbox_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOutboxEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListOutboxEventsRequest) Descriptor() ([]byte, []int) {
+	return file_api_video_v1_outbox_admin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListOutboxEventsRequest) GetStatus() OutboxEventStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OutboxEventStatus_OUTBOX_EVENT_STATUS_UNSPECIFIED
+}
+
+func (x *ListOutboxEventsRequest) GetAggregateId() string {
+	if x != nil {
+		return x.AggregateId
+	}
+	return ""
+}
+
+func (x *ListOutboxEventsRequest) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *ListOutboxEventsRequest) GetMinAttempts() int32 {
+	if x != nil {
+		return x.MinAttempts
+	}
+	return 0
+}
+
+func (x *ListOutboxEventsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// ListOutboxEventsResponse 按产生时间升序返回事件。
+type ListOutboxEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*OutboxEventSummary  `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOutboxEventsResponse) Reset() {
+	*x = ListOutboxEventsResponse{}
+	mi := &file_api_video_v1_outbox_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOutboxEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOutboxEventsResponse) ProtoMessage() {}
+
+func (x *ListOutboxEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_video_v1_outbox_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOutboxEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListOutboxEventsResponse) Descriptor() ([]byte, []int) {
+	return file_api_video_v1_outbox_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListOutboxEventsResponse) GetEvents() []*OutboxEventSummary {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+// GetOutboxEventRequest 按事件 ID 查询。
+type GetOutboxEventRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOutboxEventRequest) Reset() {
+	*x = GetOutboxEventRequest{}
+	mi := &file_api_video_v1_outbox_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOutboxEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOutboxEventRequest) ProtoMessage() {}
+
+func (x *GetOutboxEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_video_v1_outbox_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOutboxEventRequest.ProtoReflect.Descriptor instead.
+func (*GetOutboxEventRequest) Descriptor() ([]byte, []int) {
+	return file_api_video_v1_outbox_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetOutboxEventRequest) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+// GetOutboxEventResponse 返回事件快照、头部与解码后的负载；负载无法解码时 decode_error 记录原因。
+type GetOutboxEventResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Event *OutboxEventSummary    `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	// video.v1.Event 的 protojson 表示
+	PayloadJson string `protobuf:"bytes,2,opt,name=payload_json,json=payloadJson,proto3" json:"payload_json,omitempty"`
+	DecodeError string `protobuf:"bytes,3,opt,name=decode_error,json=decodeError,proto3" json:"decode_error,omitempty"`
+	// 事件头部（JSON 对象）
+	HeadersJson   string `protobuf:"bytes,4,opt,name=headers_json,json=headersJson,proto3" json:"headers_json,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOutboxEventResponse) Reset() {
+	*x = GetOutboxEventResponse{}
+	mi := &file_api_video_v1_outbox_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOutboxEventResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOutboxEventResponse) ProtoMessage() {}
+
+func (x *GetOutboxEventResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_video_v1_outbox_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOutboxEventResponse.ProtoReflect.Descriptor instead.
+func (*GetOutboxEventResponse) Descriptor() ([]byte, []int) {
+	return file_api_video_v1_outbox_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetOutboxEventResponse) GetEvent() *OutboxEventSummary {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *GetOutboxEventResponse) GetPayloadJson() string {
+	if x != nil {
+		return x.PayloadJson
+	}
+	return ""
+}
+
+func (x *GetOutboxEventResponse) GetDecodeError() string {
+	if x != nil {
+		return x.DecodeError
+	}
+	return ""
+}
+
+func (x *GetOutboxEventResponse) GetHeadersJson() string {
+	if x != nil {
+		return x.HeadersJson
+	}
+	return ""
+}
+
+// RetryOutboxEventRequest 指定需要强制重试的事件。
+type RetryOutboxEventRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryOutboxEventRequest) Reset() {
+	*x = RetryOutboxEventRequest{}
+	mi := &file_api_video_v1_outbox_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryOutboxEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryOutboxEventRequest) ProtoMessage() {}
+
+func (x *RetryOutboxEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_video_v1_outbox_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryOutboxEventRequest.ProtoReflect.Descriptor instead.
+func (*RetryOutboxEventRequest) Descriptor() ([]byte, []int) {
+	return file_api_video_v1_outbox_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RetryOutboxEventRequest) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+// RetryOutboxEventResponse 返回重置后的事件快照。
+type RetryOutboxEventResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Event         *OutboxEventSummary    `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryOutboxEventResponse) Reset() {
+	*x = RetryOutboxEventResponse{}
+	mi := &file_api_video_v1_outbox_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryOutboxEventResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryOutboxEventResponse) ProtoMessage() {}
+
+func (x *RetryOutboxEventResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_video_v1_outbox_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryOutboxEventResponse.ProtoReflect.Descriptor instead.
+func (*RetryOutboxEventResponse) Descriptor() ([]byte, []int) {
+	return file_api_video_v1_outbox_admin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RetryOutboxEventResponse) GetEvent() *OutboxEventSummary {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+// AbandonOutboxEventRequest 指定需要放弃的事件及原因。
+type AbandonOutboxEventRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AbandonOutboxEventRequest) Reset() {
+	*x = AbandonOutboxEventRequest{}
+	mi := &file_api_video_v1_outbox_admin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AbandonOutboxEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbandonOutboxEventRequest) ProtoMessage() {}
+
+func (x *AbandonOutboxEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_video_v1_outbox_admin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbandonOutboxEventRequest.ProtoReflect.Descriptor instead.
+func (*AbandonOutboxEventRequest) Descriptor() ([]byte, []int) {
+	return file_api_video_v1_outbox_admin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AbandonOutboxEventRequest) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *AbandonOutboxEventRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// AbandonOutboxEventResponse 返回放弃后的事件快照。
+type AbandonOutboxEventResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Event         *OutboxEventSummary    `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AbandonOutboxEventResponse) Reset() {
+	*x = AbandonOutboxEventResponse{}
+	mi := &file_api_video_v1_outbox_admin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AbandonOutboxEventResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbandonOutboxEventResponse) ProtoMessage() {}
+
+func (x *AbandonOutboxEventResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_video_v1_outbox_admin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbandonOutboxEventResponse.ProtoReflect.Descriptor instead.
+func (*AbandonOutboxEventResponse) Descriptor() ([]byte, []int) {
+	return file_api_video_v1_outbox_admin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AbandonOutboxEventResponse) GetEvent() *OutboxEventSummary {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+var File_api_video_v1_outbox_admin_proto protoreflect.FileDescriptor
+
+const file_api_video_v1_outbox_admin_proto_rawDesc = "" +
+	"\n" +
+	"\x1fapi/video/v1/outbox_admin.proto\x12\bvideo.v1\x1a\x1bbuf/validate/validate.proto\"\xe8\x04\n" +
+	"\x12OutboxEventSummary\x12#\n" +
+	"\bevent_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\aeventId\x12%\n" +
+	"\x0eaggregate_type\x18\x02 \x01(\tR\raggregateType\x12+\n" +
+	"\faggregate_id\x18\x03 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\vaggregateId\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x04 \x01(\tR\teventType\x123\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x1b.video.v1.OutboxEventStatusR\x06status\x12,\n" +
+	"\x12occurred_at_unixms\x18\x06 \x01(\x03R\x10occurredAtUnixms\x12.\n" +
+	"\x13available_at_unixms\x18\a \x01(\x03R\x11availableAtUnixms\x12.\n" +
+	"\x13published_at_unixms\x18\b \x01(\x03R\x11publishedAtUnixms\x124\n" +
+	"\x11delivery_attempts\x18\t \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\x10deliveryAttempts\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\n" +
+	" \x01(\tR\tlastError\x12\x1d\n" +
+	"\n" +
+	"lock_token\x18\v \x01(\tR\tlockToken\x12(\n" +
+	"\x10locked_at_unixms\x18\f \x01(\x03R\x0elockedAtUnixms\x12.\n" +
+	"\x13abandoned_at_unixms\x18\r \x01(\x03R\x11abandonedAtUnixms\x12)\n" +
+	"\x10abandoned_reason\x18\x0e \x01(\tR\x0fabandonedReason\"\x8f\x02\n" +
+	"\x17ListOutboxEventsRequest\x12?\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x1b.video.v1.OutboxEventStatusB\n" +
+	"\xbaH\a\x82\x01\x04\x10\x01 \x04R\x06status\x12?\n" +
+	"\faggregate_id\x18\x02 \x01(\tB\x1c\xbaH\x19r\x172\x15^([0-9a-fA-F-]{36})?$R\vaggregateId\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x03 \x01(\tR\teventType\x12*\n" +
+	"\fmin_attempts\x18\x04 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\vminAttempts\x12'\n" +
+	"\tpage_size\x18\x05 \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\xf4\x03(\x00R\bpageSize\"P\n" +
+	"\x18ListOutboxEventsResponse\x124\n" +
+	"\x06events\x18\x01 \x03(\v2\x1c.video.v1.OutboxEventSummaryR\x06events\"<\n" +
+	"\x15GetOutboxEventRequest\x12#\n" +
+	"\bevent_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\aeventId\"\xb5\x01\n" +
+	"\x16GetOutboxEventResponse\x122\n" +
+	"\x05event\x18\x01 \x01(\v2\x1c.video.v1.OutboxEventSummaryR\x05event\x12!\n" +
+	"\fpayload_json\x18\x02 \x01(\tR\vpayloadJson\x12!\n" +
+	"\fdecode_error\x18\x03 \x01(\tR\vdecodeError\x12!\n" +
+	"\fheaders_json\x18\x04 \x01(\tR\vheadersJson\">\n" +
+	"\x17RetryOutboxEventRequest\x12#\n" +
+	"\bevent_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\aeventId\"N\n" +
+	"\x18RetryOutboxEventResponse\x122\n" +
+	"\x05event\x18\x01 \x01(\v2\x1c.video.v1.OutboxEventSummaryR\x05event\"a\n" +
+	"\x19AbandonOutboxEventRequest\x12#\n" +
+	"\bevent_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\aeventId\x12\x1f\n" +
+	"\x06reason\x18\x02 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x06reason\"P\n" +
+	"\x1aAbandonOutboxEventResponse\x122\n" +
+	"\x05event\x18\x01 \x01(\v2\x1c.video.v1.OutboxEventSummaryR\x05event*\xbf\x01\n" +
+	"\x11OutboxEventStatus\x12#\n" +
+	"\x1fOUTBOX_EVENT_STATUS_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bOUTBOX_EVENT_STATUS_PENDING\x10\x01\x12\x1e\n" +
+	"\x1aOUTBOX_EVENT_STATUS_FAILED\x10\x02\x12!\n" +
+	"\x1dOUTBOX_EVENT_STATUS_ABANDONED\x10\x03\x12!\n" +
+	"\x1dOUTBOX_EVENT_STATUS_PUBLISHED\x10\x042\x87\x03\n" +
+	"\x19CatalogOutboxAdminService\x12Y\n" +
+	"\x10ListOutboxEvents\x12!.video.v1.ListOutboxEventsRequest\x1a\".video.v1.ListOutboxEventsResponse\x12S\n" +
+	"\x0eGetOutboxEvent\x12\x1f.video.v1.GetOutboxEventRequest\x1a .video.v1.GetOutboxEventResponse\x12Y\n" +
+	"\x10RetryOutboxEvent\x12!.video.v1.RetryOutboxEventRequest\x1a\".video.v1.RetryOutboxEventResponse\x12_\n" +
+	"\x12AbandonOutboxEvent\x12#.video.v1.AbandonOutboxEventRequest\x1a$.video.v1.AbandonOutboxEventResponseBDZBgithub.com/bionicotaku/lingo-services-catalog/api/video/v1;videov1b\x06proto3"
+
+var (
+	file_api_video_v1_outbox_admin_proto_rawDescOnce sync.Once
+	file_api_video_v1_outbox_admin_proto_rawDescData []byte
+)
+
+func file_api_video_v1_outbox_admin_proto_rawDescGZIP() []byte {
+	file_api_video_v1_outbox_admin_proto_rawDescOnce.Do(func() {
+		file_api_video_v1_outbox_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_video_v1_outbox_admin_proto_rawDesc), len(file_api_video_v1_outbox_admin_proto_rawDesc)))
+	})
+	return file_api_video_v1_outbox_admin_proto_rawDescData
+}
+
+var file_api_video_v1_outbox_admin_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_api_video_v1_outbox_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_api_video_v1_outbox_admin_proto_goTypes = []any{
+	(OutboxEventStatus)(0),             // 0: video.v1.OutboxEventStatus
+	(*OutboxEventSummary)(nil),         // 1: video.v1.OutboxEventSummary
+	(*ListOutboxEventsRequest)(nil),    // 2: video.v1.ListOutboxEventsRequest
+	(*ListOutboxEventsResponse)(nil),   // 3: video.v1.ListOutboxEventsResponse
+	(*GetOutboxEventRequest)(nil),      // 4: video.v1.GetOutboxEventRequest
+	(*GetOutboxEventResponse)(nil),     // 5: video.v1.GetOutboxEventResponse
+	(*RetryOutboxEventRequest)(nil),    // 6: video.v1.RetryOutboxEventRequest
+	(*RetryOutboxEventResponse)(nil),   // 7: video.v1.RetryOutboxEventResponse
+	(*AbandonOutboxEventRequest)(nil),  // 8: video.v1.AbandonOutboxEventRequest
+	(*AbandonOutboxEventResponse)(nil), // 9: video.v1.AbandonOutboxEventResponse
+}
+var file_api_video_v1_outbox_admin_proto_depIdxs = []int32{
+	0,  // 0: video.v1.OutboxEventSummary.status:type_name -> video.v1.OutboxEventStatus
+	0,  // 1: video.v1.ListOutboxEventsRequest.status:type_name -> video.v1.OutboxEventStatus
+	1,  // 2: video.v1.ListOutboxEventsResponse.events:type_name -> video.v1.OutboxEventSummary
+	1,  // 3: video.v1.GetOutboxEventResponse.event:type_name -> video.v1.OutboxEventSummary
+	1,  // 4: video.v1.RetryOutboxEventResponse.event:type_name -> video.v1.OutboxEventSummary
+	1,  // 5: video.v1.AbandonOutboxEventResponse.event:type_name -> video.v1.OutboxEventSummary
+	2,  // 6: video.v1.CatalogOutboxAdminService.ListOutboxEvents:input_type -> video.v1.ListOutboxEventsRequest
+	4,  // 7: video.v1.CatalogOutboxAdminService.GetOutboxEvent:input_type -> video.v1.GetOutboxEventRequest
+	6,  // 8: video.v1.CatalogOutboxAdminService.RetryOutboxEvent:input_type -> video.v1.RetryOutboxEventRequest
+	8,  // 9: video.v1.CatalogOutboxAdminService.AbandonOutboxEvent:input_type -> video.v1.AbandonOutboxEventRequest
+	3,  // 10: video.v1.CatalogOutboxAdminService.ListOutboxEvents:output_type -> video.v1.ListOutboxEventsResponse
+	5,  // 11: video.v1.CatalogOutboxAdminService.GetOutboxEvent:output_type -> video.v1.GetOutboxEventResponse
+	7,  // 12: video.v1.CatalogOutboxAdminService.RetryOutboxEvent:output_type -> video.v1.RetryOutboxEventResponse
+	9,  // 13: video.v1.CatalogOutboxAdminService.AbandonOutboxEvent:output_type -> video.v1.AbandonOutboxEventResponse
+	10, // [10:14] is the sub-list for method output_type
+	6,  // [6:10] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_api_video_v1_outbox_admin_proto_init() }
+func file_api_video_v1_outbox_admin_proto_init() {
+	if File_api_video_v1_outbox_admin_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_video_v1_outbox_admin_proto_rawDesc), len(file_api_video_v1_outbox_admin_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_video_v1_outbox_admin_proto_goTypes,
+		DependencyIndexes: file_api_video_v1_outbox_admin_proto_depIdxs,
+		EnumInfos:         file_api_video_v1_outbox_admin_proto_enumTypes,
+		MessageInfos:      file_api_video_v1_outbox_admin_proto_msgTypes,
+	}.Build()
+	File_api_video_v1_outbox_admin_proto = out.File
+	file_api_video_v1_outbox_admin_proto_goTypes = nil
+	file_api_video_v1_outbox_admin_proto_depIdxs = nil
+}
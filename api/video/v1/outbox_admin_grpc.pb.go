@@ -0,0 +1,248 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: api/video/v1/outbox_admin.proto
+
+package videov1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CatalogOutboxAdminService_ListOutboxEvents_FullMethodName   = "/video.v1.CatalogOutboxAdminService/ListOutboxEvents"
+	CatalogOutboxAdminService_GetOutboxEvent_FullMethodName     = "/video.v1.CatalogOutboxAdminService/GetOutboxEvent"
+	CatalogOutboxAdminService_RetryOutboxEvent_FullMethodName   = "/video.v1.CatalogOutboxAdminService/RetryOutboxEvent"
+	CatalogOutboxAdminService_AbandonOutboxEvent_FullMethodName = "/video.v1.CatalogOutboxAdminService/AbandonOutboxEvent"
+)
+
+// CatalogOutboxAdminServiceClient is the client API for CatalogOutboxAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// CatalogOutboxAdminService 供运维查看与处置未发布的 Outbox 事件，所有方法要求 admin 角色。
+type CatalogOutboxAdminServiceClient interface {
+	// ListOutboxEvents 按状态与过滤条件列出未发布事件（不含负载）。
+	ListOutboxEvents(ctx context.Context, in *ListOutboxEventsRequest, opts ...grpc.CallOption) (*ListOutboxEventsResponse, error)
+	// GetOutboxEvent 返回单条事件及解码后的 video.v1.Event 负载。
+	GetOutboxEvent(ctx context.Context, in *GetOutboxEventRequest, opts ...grpc.CallOption) (*GetOutboxEventResponse, error)
+	// RetryOutboxEvent 清零投递次数并立即重新发布；已放弃的事件同时恢复为待发布。
+	RetryOutboxEvent(ctx context.Context, in *RetryOutboxEventRequest, opts ...grpc.CallOption) (*RetryOutboxEventResponse, error)
+	// AbandonOutboxEvent 放弃待发布事件，发布器此后不再认领。
+	AbandonOutboxEvent(ctx context.Context, in *AbandonOutboxEventRequest, opts ...grpc.CallOption) (*AbandonOutboxEventResponse, error)
+}
+
+type catalogOutboxAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCatalogOutboxAdminServiceClient(cc grpc.ClientConnInterface) CatalogOutboxAdminServiceClient {
+	return &catalogOutboxAdminServiceClient{cc}
+}
+
+func (c *catalogOutboxAdminServiceClient) ListOutboxEvents(ctx context.Context, in *ListOutboxEventsRequest, opts ...grpc.CallOption) (*ListOutboxEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOutboxEventsResponse)
+	err := c.cc.Invoke(ctx, CatalogOutboxAdminService_ListOutboxEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogOutboxAdminServiceClient) GetOutboxEvent(ctx context.Context, in *GetOutboxEventRequest, opts ...grpc.CallOption) (*GetOutboxEventResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOutboxEventResponse)
+	err := c.cc.Invoke(ctx, CatalogOutboxAdminService_GetOutboxEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogOutboxAdminServiceClient) RetryOutboxEvent(ctx context.Context, in *RetryOutboxEventRequest, opts ...grpc.CallOption) (*RetryOutboxEventResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RetryOutboxEventResponse)
+	err := c.cc.Invoke(ctx, CatalogOutboxAdminService_RetryOutboxEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogOutboxAdminServiceClient) AbandonOutboxEvent(ctx context.Context, in *AbandonOutboxEventRequest, opts ...grpc.CallOption) (*AbandonOutboxEventResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AbandonOutboxEventResponse)
+	err := c.cc.Invoke(ctx, CatalogOutboxAdminService_AbandonOutboxEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CatalogOutboxAdminServiceServer is the server API for CatalogOutboxAdminService service.
+// All implementations must embed UnimplementedCatalogOutboxAdminServiceServer
+// for forward compatibility.
+//
+// CatalogOutboxAdminService 供运维查看与处置未发布的 Outbox 事件，所有方法要求 admin 角色。
+type CatalogOutboxAdminServiceServer interface {
+	// ListOutboxEvents 按状态与过滤条件列出未发布事件（不含负载）。
+	ListOutboxEvents(context.Context, *ListOutboxEventsRequest) (*ListOutboxEventsResponse, error)
+	// GetOutboxEvent 返回单条事件及解码后的 video.v1.Event 负载。
+	GetOutboxEvent(context.Context, *GetOutboxEventRequest) (*GetOutboxEventResponse, error)
+	// RetryOutboxEvent 清零投递次数并立即重新发布；已放弃的事件同时恢复为待发布。
+	RetryOutboxEvent(context.Context, *RetryOutboxEventRequest) (*RetryOutboxEventResponse, error)
+	// AbandonOutboxEvent 放弃待发布事件，发布器此后不再认领。
+	AbandonOutboxEvent(context.Context, *AbandonOutboxEventRequest) (*AbandonOutboxEventResponse, error)
+	mustEmbedUnimplementedCatalogOutboxAdminServiceServer()
+}
+
+// UnimplementedCatalogOutboxAdminServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCatalogOutboxAdminServiceServer struct{}
+
+func (UnimplementedCatalogOutboxAdminServiceServer) ListOutboxEvents(context.Context, *ListOutboxEventsRequest) (*ListOutboxEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOutboxEvents not implemented")
+}
+func (UnimplementedCatalogOutboxAdminServiceServer) GetOutboxEvent(context.Context, *GetOutboxEventRequest) (*GetOutboxEventResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOutboxEvent not implemented")
+}
+func (UnimplementedCatalogOutboxAdminServiceServer) RetryOutboxEvent(context.Context, *RetryOutboxEventRequest) (*RetryOutboxEventResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetryOutboxEvent not implemented")
+}
+func (UnimplementedCatalogOutboxAdminServiceServer) AbandonOutboxEvent(context.Context, *AbandonOutboxEventRequest) (*AbandonOutboxEventResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AbandonOutboxEvent not implemented")
+}
+func (UnimplementedCatalogOutboxAdminServiceServer) mustEmbedUnimplementedCatalogOutboxAdminServiceServer() {
+}
+func (UnimplementedCatalogOutboxAdminServiceServer) testEmbeddedByValue() {}
+
+// UnsafeCatalogOutboxAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CatalogOutboxAdminServiceServer will
+// result in compilation errors.
+type UnsafeCatalogOutboxAdminServiceServer interface {
+	mustEmbedUnimplementedCatalogOutboxAdminServiceServer()
+}
+
+func RegisterCatalogOutboxAdminServiceServer(s grpc.ServiceRegistrar, srv CatalogOutboxAdminServiceServer) {
+	// If the following call pancis, it indicates UnimplementedCatalogOutboxAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CatalogOutboxAdminService_ServiceDesc, srv)
+}
+
+func _CatalogOutboxAdminService_ListOutboxEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOutboxEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogOutboxAdminServiceServer).ListOutboxEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogOutboxAdminService_ListOutboxEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogOutboxAdminServiceServer).ListOutboxEvents(ctx, req.(*ListOutboxEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogOutboxAdminService_GetOutboxEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOutboxEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogOutboxAdminServiceServer).GetOutboxEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogOutboxAdminService_GetOutboxEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogOutboxAdminServiceServer).GetOutboxEvent(ctx, req.(*GetOutboxEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogOutboxAdminService_RetryOutboxEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetryOutboxEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogOutboxAdminServiceServer).RetryOutboxEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogOutboxAdminService_RetryOutboxEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogOutboxAdminServiceServer).RetryOutboxEvent(ctx, req.(*RetryOutboxEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogOutboxAdminService_AbandonOutboxEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AbandonOutboxEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogOutboxAdminServiceServer).AbandonOutboxEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogOutboxAdminService_AbandonOutboxEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogOutboxAdminServiceServer).AbandonOutboxEvent(ctx, req.(*AbandonOutboxEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CatalogOutboxAdminService_ServiceDesc is the grpc.ServiceDesc for CatalogOutboxAdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CatalogOutboxAdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "video.v1.CatalogOutboxAdminService",
+	HandlerType: (*CatalogOutboxAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListOutboxEvents",
+			Handler:    _CatalogOutboxAdminService_ListOutboxEvents_Handler,
+		},
+		{
+			MethodName: "GetOutboxEvent",
+			Handler:    _CatalogOutboxAdminService_GetOutboxEvent_Handler,
+		},
+		{
+			MethodName: "RetryOutboxEvent",
+			Handler:    _CatalogOutboxAdminService_RetryOutboxEvent_Handler,
+		},
+		{
+			MethodName: "AbandonOutboxEvent",
+			Handler:    _CatalogOutboxAdminService_AbandonOutboxEvent_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/video/v1/outbox_admin.proto",
+}
@@ -207,23 +207,31 @@ func (x *GetVideoDetailResponse) GetMetadata() *VideoMetadata {
 }
 
 type VideoDetail struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	VideoId        string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
-	Title          string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Status         string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
-	MediaStatus    string                 `protobuf:"bytes,4,opt,name=media_status,json=mediaStatus,proto3" json:"media_status,omitempty"`
-	AnalysisStatus string                 `protobuf:"bytes,5,opt,name=analysis_status,json=analysisStatus,proto3" json:"analysis_status,omitempty"`
-	CreatedAt      string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt      string                 `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	HasLiked       bool                   `protobuf:"varint,8,opt,name=has_liked,json=hasLiked,proto3" json:"has_liked,omitempty"`
-	HasBookmarked  bool                   `protobuf:"varint,9,opt,name=has_bookmarked,json=hasBookmarked,proto3" json:"has_bookmarked,omitempty"`
-	HasWatched     bool                   `protobuf:"varint,10,opt,name=has_watched,json=hasWatched,proto3" json:"has_watched,omitempty"`
-	LikeCount      int64                  `protobuf:"varint,11,opt,name=like_count,json=likeCount,proto3" json:"like_count,omitempty"`
-	BookmarkCount  int64                  `protobuf:"varint,12,opt,name=bookmark_count,json=bookmarkCount,proto3" json:"bookmark_count,omitempty"`
-	WatchCount     int64                  `protobuf:"varint,13,opt,name=watch_count,json=watchCount,proto3" json:"watch_count,omitempty"`
-	UniqueWatchers int64                  `protobuf:"varint,14,opt,name=unique_watchers,json=uniqueWatchers,proto3" json:"unique_watchers,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	state                      protoimpl.MessageState `protogen:"open.v1"`
+	VideoId                    string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	Title                      string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Status                     string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	MediaStatus                string                 `protobuf:"bytes,4,opt,name=media_status,json=mediaStatus,proto3" json:"media_status,omitempty"`
+	AnalysisStatus             string                 `protobuf:"bytes,5,opt,name=analysis_status,json=analysisStatus,proto3" json:"analysis_status,omitempty"`
+	CreatedAt                  string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt                  string                 `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	HasLiked                   bool                   `protobuf:"varint,8,opt,name=has_liked,json=hasLiked,proto3" json:"has_liked,omitempty"`
+	HasBookmarked              bool                   `protobuf:"varint,9,opt,name=has_bookmarked,json=hasBookmarked,proto3" json:"has_bookmarked,omitempty"`
+	HasWatched                 bool                   `protobuf:"varint,10,opt,name=has_watched,json=hasWatched,proto3" json:"has_watched,omitempty"`
+	LikeCount                  int64                  `protobuf:"varint,11,opt,name=like_count,json=likeCount,proto3" json:"like_count,omitempty"`
+	BookmarkCount              int64                  `protobuf:"varint,12,opt,name=bookmark_count,json=bookmarkCount,proto3" json:"bookmark_count,omitempty"`
+	WatchCount                 int64                  `protobuf:"varint,13,opt,name=watch_count,json=watchCount,proto3" json:"watch_count,omitempty"`
+	UniqueWatchers             int64                  `protobuf:"varint,14,opt,name=unique_watchers,json=uniqueWatchers,proto3" json:"unique_watchers,omitempty"`
+	TotalWatchSeconds          float64                `protobuf:"fixed64,15,opt,name=total_watch_seconds,json=totalWatchSeconds,proto3" json:"total_watch_seconds,omitempty"`                              // 累计观看秒数
+	CompletionRate             float64                `protobuf:"fixed64,16,opt,name=completion_rate,json=completionRate,proto3" json:"completion_rate,omitempty"`                                         // 完播率：各观看用户最大播放位置 / 视频时长的平均值，范围 [0, 1]
+	ResumePositionMicros       int64                  `protobuf:"varint,17,opt,name=resume_position_micros,json=resumePositionMicros,proto3" json:"resume_position_micros,omitempty"`                      // 当前用户最近一次上报的播放位置，用于继续观看
+	ShareCount                 int64                  `protobuf:"varint,18,opt,name=share_count,json=shareCount,proto3" json:"share_count,omitempty"`                                                      // 累计分享次数
+	RatingCount                int64                  `protobuf:"varint,19,opt,name=rating_count,json=ratingCount,proto3" json:"rating_count,omitempty"`                                                   // 评分人数
+	AverageRating              float64                `protobuf:"fixed64,20,opt,name=average_rating,json=averageRating,proto3" json:"average_rating,omitempty"`                                            // 平均星级（1-5），无评分时为 0
+	MyRating                   int32                  `protobuf:"varint,21,opt,name=my_rating,json=myRating,proto3" json:"my_rating,omitempty"`                                                            // 当前用户的评分，未评分时为 0
+	AverageViewDurationSeconds float64                `protobuf:"fixed64,22,opt,name=average_view_duration_seconds,json=averageViewDurationSeconds,proto3" json:"average_view_duration_seconds,omitempty"` // 平均观看时长：累计观看秒数 / 上报过播放进度的用户数
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
 }
 
 func (x *VideoDetail) Reset() {
@@ -354,27 +362,89 @@ func (x *VideoDetail) GetUniqueWatchers() int64 {
 	return 0
 }
 
+func (x *VideoDetail) GetTotalWatchSeconds() float64 {
+	if x != nil {
+		return x.TotalWatchSeconds
+	}
+	return 0
+}
+
+func (x *VideoDetail) GetCompletionRate() float64 {
+	if x != nil {
+		return x.CompletionRate
+	}
+	return 0
+}
+
+func (x *VideoDetail) GetResumePositionMicros() int64 {
+	if x != nil {
+		return x.ResumePositionMicros
+	}
+	return 0
+}
+
+func (x *VideoDetail) GetShareCount() int64 {
+	if x != nil {
+		return x.ShareCount
+	}
+	return 0
+}
+
+func (x *VideoDetail) GetRatingCount() int64 {
+	if x != nil {
+		return x.RatingCount
+	}
+	return 0
+}
+
+func (x *VideoDetail) GetAverageRating() float64 {
+	if x != nil {
+		return x.AverageRating
+	}
+	return 0
+}
+
+func (x *VideoDetail) GetMyRating() int32 {
+	if x != nil {
+		return x.MyRating
+	}
+	return 0
+}
+
+func (x *VideoDetail) GetAverageViewDurationSeconds() float64 {
+	if x != nil {
+		return x.AverageViewDurationSeconds
+	}
+	return 0
+}
+
 type VideoMetadata struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	Status            string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
-	MediaStatus       string                 `protobuf:"bytes,2,opt,name=media_status,json=mediaStatus,proto3" json:"media_status,omitempty"`
-	AnalysisStatus    string                 `protobuf:"bytes,3,opt,name=analysis_status,json=analysisStatus,proto3" json:"analysis_status,omitempty"`
-	DurationMicros    int64                  `protobuf:"varint,4,opt,name=duration_micros,json=durationMicros,proto3" json:"duration_micros,omitempty"`
-	EncodedResolution string                 `protobuf:"bytes,5,opt,name=encoded_resolution,json=encodedResolution,proto3" json:"encoded_resolution,omitempty"`
-	EncodedBitrate    int32                  `protobuf:"varint,6,opt,name=encoded_bitrate,json=encodedBitrate,proto3" json:"encoded_bitrate,omitempty"`
-	ThumbnailUrl      string                 `protobuf:"bytes,7,opt,name=thumbnail_url,json=thumbnailUrl,proto3" json:"thumbnail_url,omitempty"`
-	HlsMasterPlaylist string                 `protobuf:"bytes,8,opt,name=hls_master_playlist,json=hlsMasterPlaylist,proto3" json:"hls_master_playlist,omitempty"`
-	Difficulty        string                 `protobuf:"bytes,9,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
-	Summary           string                 `protobuf:"bytes,10,opt,name=summary,proto3" json:"summary,omitempty"`
-	Tags              []string               `protobuf:"bytes,11,rep,name=tags,proto3" json:"tags,omitempty"`
-	RawSubtitleUrl    string                 `protobuf:"bytes,12,opt,name=raw_subtitle_url,json=rawSubtitleUrl,proto3" json:"raw_subtitle_url,omitempty"`
-	UpdatedAt         string                 `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	Version           int64                  `protobuf:"varint,14,opt,name=version,proto3" json:"version,omitempty"`
-	LikeCount         int64                  `protobuf:"varint,15,opt,name=like_count,json=likeCount,proto3" json:"like_count,omitempty"`
-	BookmarkCount     int64                  `protobuf:"varint,16,opt,name=bookmark_count,json=bookmarkCount,proto3" json:"bookmark_count,omitempty"`
-	WatchCount        int64                  `protobuf:"varint,17,opt,name=watch_count,json=watchCount,proto3" json:"watch_count,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+	state                      protoimpl.MessageState `protogen:"open.v1"`
+	Status                     string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	MediaStatus                string                 `protobuf:"bytes,2,opt,name=media_status,json=mediaStatus,proto3" json:"media_status,omitempty"`
+	AnalysisStatus             string                 `protobuf:"bytes,3,opt,name=analysis_status,json=analysisStatus,proto3" json:"analysis_status,omitempty"`
+	DurationMicros             int64                  `protobuf:"varint,4,opt,name=duration_micros,json=durationMicros,proto3" json:"duration_micros,omitempty"`
+	EncodedResolution          string                 `protobuf:"bytes,5,opt,name=encoded_resolution,json=encodedResolution,proto3" json:"encoded_resolution,omitempty"`
+	EncodedBitrate             int32                  `protobuf:"varint,6,opt,name=encoded_bitrate,json=encodedBitrate,proto3" json:"encoded_bitrate,omitempty"`
+	ThumbnailUrl               string                 `protobuf:"bytes,7,opt,name=thumbnail_url,json=thumbnailUrl,proto3" json:"thumbnail_url,omitempty"`                  // 存储路径，不可直接播放；播放地址通过 GetPlaybackInfo 获取
+	HlsMasterPlaylist          string                 `protobuf:"bytes,8,opt,name=hls_master_playlist,json=hlsMasterPlaylist,proto3" json:"hls_master_playlist,omitempty"` // 存储路径，不可直接播放；播放地址通过 GetPlaybackInfo 获取
+	Difficulty                 string                 `protobuf:"bytes,9,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	Summary                    string                 `protobuf:"bytes,10,opt,name=summary,proto3" json:"summary,omitempty"`
+	Tags                       []string               `protobuf:"bytes,11,rep,name=tags,proto3" json:"tags,omitempty"`
+	RawSubtitleUrl             string                 `protobuf:"bytes,12,opt,name=raw_subtitle_url,json=rawSubtitleUrl,proto3" json:"raw_subtitle_url,omitempty"`
+	UpdatedAt                  string                 `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Version                    int64                  `protobuf:"varint,14,opt,name=version,proto3" json:"version,omitempty"`
+	LikeCount                  int64                  `protobuf:"varint,15,opt,name=like_count,json=likeCount,proto3" json:"like_count,omitempty"`
+	BookmarkCount              int64                  `protobuf:"varint,16,opt,name=bookmark_count,json=bookmarkCount,proto3" json:"bookmark_count,omitempty"`
+	WatchCount                 int64                  `protobuf:"varint,17,opt,name=watch_count,json=watchCount,proto3" json:"watch_count,omitempty"`
+	TotalWatchSeconds          float64                `protobuf:"fixed64,18,opt,name=total_watch_seconds,json=totalWatchSeconds,proto3" json:"total_watch_seconds,omitempty"`                              // 累计观看秒数
+	CompletionRate             float64                `protobuf:"fixed64,19,opt,name=completion_rate,json=completionRate,proto3" json:"completion_rate,omitempty"`                                         // 完播率，计算方式同 VideoDetail.completion_rate
+	ShareCount                 int64                  `protobuf:"varint,20,opt,name=share_count,json=shareCount,proto3" json:"share_count,omitempty"`                                                      // 累计分享次数
+	RatingCount                int64                  `protobuf:"varint,21,opt,name=rating_count,json=ratingCount,proto3" json:"rating_count,omitempty"`                                                   // 评分人数
+	AverageRating              float64                `protobuf:"fixed64,22,opt,name=average_rating,json=averageRating,proto3" json:"average_rating,omitempty"`                                            // 平均星级，计算方式同 VideoDetail.average_rating
+	AverageViewDurationSeconds float64                `protobuf:"fixed64,23,opt,name=average_view_duration_seconds,json=averageViewDurationSeconds,proto3" json:"average_view_duration_seconds,omitempty"` // 平均观看时长，计算方式同 VideoDetail.average_view_duration_seconds
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
 }
 
 func (x *VideoMetadata) Reset() {
@@ -526,6 +596,48 @@ func (x *VideoMetadata) GetWatchCount() int64 {
 	return 0
 }
 
+func (x *VideoMetadata) GetTotalWatchSeconds() float64 {
+	if x != nil {
+		return x.TotalWatchSeconds
+	}
+	return 0
+}
+
+func (x *VideoMetadata) GetCompletionRate() float64 {
+	if x != nil {
+		return x.CompletionRate
+	}
+	return 0
+}
+
+func (x *VideoMetadata) GetShareCount() int64 {
+	if x != nil {
+		return x.ShareCount
+	}
+	return 0
+}
+
+func (x *VideoMetadata) GetRatingCount() int64 {
+	if x != nil {
+		return x.RatingCount
+	}
+	return 0
+}
+
+func (x *VideoMetadata) GetAverageRating() float64 {
+	if x != nil {
+		return x.AverageRating
+	}
+	return 0
+}
+
+func (x *VideoMetadata) GetAverageViewDurationSeconds() float64 {
+	if x != nil {
+		return x.AverageViewDurationSeconds
+	}
+	return 0
+}
+
 type ListUserPublicVideosRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
@@ -852,6 +964,7 @@ type MyUploadListItem struct {
 	Version        int64                  `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
 	CreatedAt      string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt      string                 `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	RawMissing     bool                   `protobuf:"varint,9,opt,name=raw_missing,json=rawMissing,proto3" json:"raw_missing,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -942,109 +1055,1481 @@ func (x *MyUploadListItem) GetUpdatedAt() string {
 	return ""
 }
 
-var File_api_video_v1_query_proto protoreflect.FileDescriptor
+func (x *MyUploadListItem) GetRawMissing() bool {
+	if x != nil {
+		return x.RawMissing
+	}
+	return false
+}
 
-const file_api_video_v1_query_proto_rawDesc = "" +
-	"\n" +
-	"\x18api/video/v1/query.proto\x12\bvideo.v1\x1a\x1bbuf/validate/validate.proto\">\n" +
-	"\x17GetVideoMetadataRequest\x12#\n" +
-	"\bvideo_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\avideoId\"O\n" +
-	"\x18GetVideoMetadataResponse\x123\n" +
-	"\bmetadata\x18\x01 \x01(\v2\x17.video.v1.VideoMetadataR\bmetadata\"<\n" +
-	"\x15GetVideoDetailRequest\x12#\n" +
-	"\bvideo_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\avideoId\"|\n" +
-	"\x16GetVideoDetailResponse\x12-\n" +
-	"\x06detail\x18\x01 \x01(\v2\x15.video.v1.VideoDetailR\x06detail\x123\n" +
-	"\bmetadata\x18\x02 \x01(\v2\x17.video.v1.VideoMetadataR\bmetadata\"\xd5\x03\n" +
-	"\vVideoDetail\x12\x19\n" +
-	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x14\n" +
-	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
-	"\x06status\x18\x03 \x01(\tR\x06status\x12!\n" +
-	"\fmedia_status\x18\x04 \x01(\tR\vmediaStatus\x12'\n" +
-	"\x0fanalysis_status\x18\x05 \x01(\tR\x0eanalysisStatus\x12\x1d\n" +
-	"\n" +
-	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x1d\n" +
-	"\n" +
-	"updated_at\x18\a \x01(\tR\tupdatedAt\x12\x1b\n" +
-	"\thas_liked\x18\b \x01(\bR\bhasLiked\x12%\n" +
-	"\x0ehas_bookmarked\x18\t \x01(\bR\rhasBookmarked\x12\x1f\n" +
-	"\vhas_watched\x18\n" +
-	" \x01(\bR\n" +
-	"hasWatched\x12\x1d\n" +
-	"\n" +
-	"like_count\x18\v \x01(\x03R\tlikeCount\x12%\n" +
-	"\x0ebookmark_count\x18\f \x01(\x03R\rbookmarkCount\x12\x1f\n" +
-	"\vwatch_count\x18\r \x01(\x03R\n" +
-	"watchCount\x12'\n" +
-	"\x0funique_watchers\x18\x0e \x01(\x03R\x0euniqueWatchers\"\xe1\x04\n" +
-	"\rVideoMetadata\x12\x16\n" +
-	"\x06status\x18\x01 \x01(\tR\x06status\x12!\n" +
-	"\fmedia_status\x18\x02 \x01(\tR\vmediaStatus\x12'\n" +
-	"\x0fanalysis_status\x18\x03 \x01(\tR\x0eanalysisStatus\x12'\n" +
-	"\x0fduration_micros\x18\x04 \x01(\x03R\x0edurationMicros\x12-\n" +
-	"\x12encoded_resolution\x18\x05 \x01(\tR\x11encodedResolution\x12'\n" +
-	"\x0fencoded_bitrate\x18\x06 \x01(\x05R\x0eencodedBitrate\x12#\n" +
-	"\rthumbnail_url\x18\a \x01(\tR\fthumbnailUrl\x12.\n" +
-	"\x13hls_master_playlist\x18\b \x01(\tR\x11hlsMasterPlaylist\x12\x1e\n" +
-	"\n" +
-	"difficulty\x18\t \x01(\tR\n" +
-	"difficulty\x12\x18\n" +
-	"\asummary\x18\n" +
-	" \x01(\tR\asummary\x12\x12\n" +
-	"\x04tags\x18\v \x03(\tR\x04tags\x12(\n" +
-	"\x10raw_subtitle_url\x18\f \x01(\tR\x0erawSubtitleUrl\x12\x1d\n" +
-	"\n" +
-	"updated_at\x18\r \x01(\tR\tupdatedAt\x12\x18\n" +
-	"\aversion\x18\x0e \x01(\x03R\aversion\x12\x1d\n" +
-	"\n" +
-	"like_count\x18\x0f \x01(\x03R\tlikeCount\x12%\n" +
-	"\x0ebookmark_count\x18\x10 \x01(\x03R\rbookmarkCount\x12\x1f\n" +
-	"\vwatch_count\x18\x11 \x01(\x03R\n" +
-	"watchCount\"Y\n" +
-	"\x1bListUserPublicVideosRequest\x12\x1b\n" +
-	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
-	"\n" +
-	"page_token\x18\x02 \x01(\tR\tpageToken\"w\n" +
-	"\x1cListUserPublicVideosResponse\x12/\n" +
-	"\x06videos\x18\x01 \x03(\v2\x17.video.v1.VideoListItemR\x06videos\x12&\n" +
-	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xa0\x02\n" +
-	"\x14ListMyUploadsRequest\x12\x1b\n" +
-	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
-	"\n" +
-	"page_token\x18\x02 \x01(\tR\tpageToken\x12y\n" +
-	"\rstatus_filter\x18\x03 \x03(\tBT\xbaHQ\x92\x01N\"LrJR\x0epending_uploadR\n" +
-	"processingR\x05readyR\tpublishedR\x06failedR\brejectedR\barchivedR\fstatusFilter\x12Q\n" +
-	"\fstage_filter\x18\x04 \x03(\tB.\xbaH+\x92\x01(\"&r$R\apendingR\n" +
-	"processingR\x05readyR\x06failedR\vstageFilter\"s\n" +
-	"\x15ListMyUploadsResponse\x122\n" +
-	"\x06videos\x18\x01 \x03(\v2\x1a.video.v1.MyUploadListItemR\x06videos\x12&\n" +
-	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xe2\x01\n" +
-	"\rVideoListItem\x12\x19\n" +
-	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x14\n" +
-	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
-	"\x06status\x18\x03 \x01(\tR\x06status\x12!\n" +
-	"\fmedia_status\x18\x04 \x01(\tR\vmediaStatus\x12'\n" +
-	"\x0fanalysis_status\x18\x05 \x01(\tR\x0eanalysisStatus\x12\x1d\n" +
-	"\n" +
-	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x1d\n" +
-	"\n" +
-	"updated_at\x18\a \x01(\tR\tupdatedAt\"\xff\x01\n" +
-	"\x10MyUploadListItem\x12\x19\n" +
-	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x14\n" +
-	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
-	"\x06status\x18\x03 \x01(\tR\x06status\x12!\n" +
-	"\fmedia_status\x18\x04 \x01(\tR\vmediaStatus\x12'\n" +
-	"\x0fanalysis_status\x18\x05 \x01(\tR\x0eanalysisStatus\x12\x18\n" +
-	"\aversion\x18\x06 \x01(\x03R\aversion\x12\x1d\n" +
-	"\n" +
-	"created_at\x18\a \x01(\tR\tcreatedAt\x12\x1d\n" +
+type ListMyWatchHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMyWatchHistoryRequest) Reset() {
+	*x = ListMyWatchHistoryRequest{}
+	mi := &file_api_video_v1_query_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMyWatchHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMyWatchHistoryRequest) ProtoMessage() {}
+
+func (x *ListMyWatchHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_video_v1_query_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMyWatchHistoryRequest.ProtoReflect.Descriptor instead.
+func (*ListMyWatchHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_api_video_v1_query_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListMyWatchHistoryRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListMyWatchHistoryRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListMyWatchHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Videos        []*WatchHistoryItem    `protobuf:"bytes,1,rep,name=videos,proto3" json:"videos,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMyWatchHistoryResponse) Reset() {
+	*x = ListMyWatchHistoryResponse{}
+	mi := &file_api_video_v1_query_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMyWatchHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMyWatchHistoryResponse) ProtoMessage() {}
+
+func (x *ListMyWatchHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_video_v1_query_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMyWatchHistoryResponse.ProtoReflect.Descriptor instead.
+func (*ListMyWatchHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_api_video_v1_query_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListMyWatchHistoryResponse) GetVideos() []*WatchHistoryItem {
+	if x != nil {
+		return x.Videos
+	}
+	return nil
+}
+
+func (x *ListMyWatchHistoryResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// WatchHistoryItem 描述观看历史中的一条记录，按 last_watched_at 倒序返回。
+type WatchHistoryItem struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	VideoId              string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	Title                string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Status               string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	MediaStatus          string                 `protobuf:"bytes,4,opt,name=media_status,json=mediaStatus,proto3" json:"media_status,omitempty"`
+	AnalysisStatus       string                 `protobuf:"bytes,5,opt,name=analysis_status,json=analysisStatus,proto3" json:"analysis_status,omitempty"`
+	DurationMicros       int64                  `protobuf:"varint,6,opt,name=duration_micros,json=durationMicros,proto3" json:"duration_micros,omitempty"`
+	ResumePositionMicros int64                  `protobuf:"varint,7,opt,name=resume_position_micros,json=resumePositionMicros,proto3" json:"resume_position_micros,omitempty"`
+	LastWatchedAt        string                 `protobuf:"bytes,8,opt,name=last_watched_at,json=lastWatchedAt,proto3" json:"last_watched_at,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *WatchHistoryItem) Reset() {
+	*x = WatchHistoryItem{}
+	mi := &file_api_video_v1_query_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchHistoryItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchHistoryItem) ProtoMessage() {}
+
+func (x *WatchHistoryItem) ProtoReflect() protoreflect.Message {
+	mi := &file_api_video_v1_query_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchHistoryItem.ProtoReflect.Descriptor instead.
+func (*WatchHistoryItem) Descriptor() ([]byte, []int) {
+	return file_api_video_v1_query_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *WatchHistoryItem) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *WatchHistoryItem) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *WatchHistoryItem) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *WatchHistoryItem) GetMediaStatus() string {
+	if x != nil {
+		return x.MediaStatus
+	}
+	return ""
+}
+
+func (x *WatchHistoryItem) GetAnalysisStatus() string {
+	if x != nil {
+		return x.AnalysisStatus
+	}
+	return ""
+}
+
+func (x *WatchHistoryItem) GetDurationMicros() int64 {
+	if x != nil {
+		return x.DurationMicros
+	}
+	return 0
+}
+
+func (x *WatchHistoryItem) GetResumePositionMicros() int64 {
+	if x != nil {
+		return x.ResumePositionMicros
+	}
+	return 0
+}
+
+func (x *WatchHistoryItem) GetLastWatchedAt() string {
+	if x != nil {
+		return x.LastWatchedAt
+	}
+	return ""
+}
+
+type ListMyLikedVideosRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMyLikedVideosRequest) Reset() {
+	*x = ListMyLikedVideosRequest{}
+	mi := &file_api_video_v1_query_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMyLikedVideosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMyLikedVideosRequest) ProtoMessage() {}
+
+func (x *ListMyLikedVideosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_video_v1_query_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMyLikedVideosRequest.ProtoReflect.Descriptor instead.
+func (*ListMyLikedVideosRequest) Descriptor() ([]byte, []int) {
+	return file_api_video_v1_query_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListMyLikedVideosRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListMyLikedVideosRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListMyLikedVideosResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Videos        []*SavedVideoItem      `protobuf:"bytes,1,rep,name=videos,proto3" json:"videos,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMyLikedVideosResponse) Reset() {
+	*x = ListMyLikedVideosResponse{}
+	mi := &file_api_video_v1_query_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMyLikedVideosResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMyLikedVideosResponse) ProtoMessage() {}
+
+func (x *ListMyLikedVideosResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_video_v1_query_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMyLikedVideosResponse.ProtoReflect.Descriptor instead.
+func (*ListMyLikedVideosResponse) Descriptor() ([]byte, []int) {
+	return file_api_video_v1_query_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListMyLikedVideosResponse) GetVideos() []*SavedVideoItem {
+	if x != nil {
+		return x.Videos
+	}
+	return nil
+}
+
+func (x *ListMyLikedVideosResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ListMyBookmarkedVideosRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMyBookmarkedVideosRequest) Reset() {
+	*x = ListMyBookmarkedVideosRequest{}
+	mi := &file_api_video_v1_query_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMyBookmarkedVideosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMyBookmarkedVideosRequest) ProtoMessage() {}
+
+func (x *ListMyBookmarkedVideosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_video_v1_query_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMyBookmarkedVideosRequest.ProtoReflect.Descriptor instead.
+func (*ListMyBookmarkedVideosRequest) Descriptor() ([]byte, []int) {
+	return file_api_video_v1_query_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListMyBookmarkedVideosRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListMyBookmarkedVideosRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListMyBookmarkedVideosResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Videos        []*SavedVideoItem      `protobuf:"bytes,1,rep,name=videos,proto3" json:"videos,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMyBookmarkedVideosResponse) Reset() {
+	*x = ListMyBookmarkedVideosResponse{}
+	mi := &file_api_video_v1_query_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMyBookmarkedVideosResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMyBookmarkedVideosResponse) ProtoMessage() {}
+
+func (x *ListMyBookmarkedVideosResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_video_v1_query_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMyBookmarkedVideosResponse.ProtoReflect.Descriptor instead.
+func (*ListMyBookmarkedVideosResponse) Descriptor() ([]byte, []int) {
+	return file_api_video_v1_query_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListMyBookmarkedVideosResponse) GetVideos() []*SavedVideoItem {
+	if x != nil {
+		return x.Videos
+	}
+	return nil
+}
+
+func (x *ListMyBookmarkedVideosResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// SavedVideoItem 描述“我的点赞/我的收藏”中的一条记录，按 saved_at 倒序返回。
+type SavedVideoItem struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	VideoId        string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	Title          string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Status         string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	MediaStatus    string                 `protobuf:"bytes,4,opt,name=media_status,json=mediaStatus,proto3" json:"media_status,omitempty"`
+	AnalysisStatus string                 `protobuf:"bytes,5,opt,name=analysis_status,json=analysisStatus,proto3" json:"analysis_status,omitempty"`
+	DurationMicros int64                  `protobuf:"varint,6,opt,name=duration_micros,json=durationMicros,proto3" json:"duration_micros,omitempty"`
+	CreatedAt      string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	SavedAt        string                 `protobuf:"bytes,8,opt,name=saved_at,json=savedAt,proto3" json:"saved_at,omitempty"` // 点赞或收藏时间
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SavedVideoItem) Reset() {
+	*x = SavedVideoItem{}
+	mi := &file_api_video_v1_query_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SavedVideoItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SavedVideoItem) ProtoMessage() {}
+
+func (x *SavedVideoItem) ProtoReflect() protoreflect.Message {
+	mi := &file_api_video_v1_query_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SavedVideoItem.ProtoReflect.Descriptor instead.
+func (*SavedVideoItem) Descriptor() ([]byte, []int) {
+	return file_api_video_v1_query_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SavedVideoItem) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *SavedVideoItem) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *SavedVideoItem) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SavedVideoItem) GetMediaStatus() string {
+	if x != nil {
+		return x.MediaStatus
+	}
+	return ""
+}
+
+func (x *SavedVideoItem) GetAnalysisStatus() string {
+	if x != nil {
+		return x.AnalysisStatus
+	}
+	return ""
+}
+
+func (x *SavedVideoItem) GetDurationMicros() int64 {
+	if x != nil {
+		return x.DurationMicros
+	}
+	return 0
+}
+
+func (x *SavedVideoItem) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *SavedVideoItem) GetSavedAt() string {
+	if x != nil {
+		return x.SavedAt
+	}
+	return ""
+}
+
+// GetVideoStatsTimeseriesRequest 查询视频的分时统计，仅视频上传者可见。
+// 时间范围 [from, to) 按请求时区对齐到桶边界：time_zone（IANA 名称，含夏令时）与 tz_offset_minutes（固定偏移）二选一，均未设置时为 UTC。
+// 统计按 UTC 整点小时存储，天级序列按小时桶起点所在的当地日期归并；偏移不是整小时的时区中，跨越当地零点的小时整体计入其起点所在日。
+type GetVideoStatsTimeseriesRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	VideoId         string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	Granularity     string                 `protobuf:"bytes,2,opt,name=granularity,proto3" json:"granularity,omitempty"`
+	From            string                 `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`                                                 // RFC3339，含
+	To              string                 `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`                                                     // RFC3339，不含
+	TzOffsetMinutes int32                  `protobuf:"varint,5,opt,name=tz_offset_minutes,json=tzOffsetMinutes,proto3" json:"tz_offset_minutes,omitempty"` // 相对 UTC 的固定偏移（分钟），0 表示 UTC
+	TimeZone        string                 `protobuf:"bytes,6,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`                         // IANA 时区名，如 Asia/Shanghai；设置时 tz_offset_minutes 须为 0
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetVideoStatsTimeseriesRequest) Reset() {
+	*x = GetVideoStatsTimeseriesRequest{}
+	mi := &file_api_video_v1_query_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVideoStatsTimeseriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVideoStatsTimeseriesRequest) ProtoMessage() {}
+
+func (x *GetVideoStatsTimeseriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_video_v1_query_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVideoStatsTimeseriesRequest.ProtoReflect.Descriptor instead.
+func (*GetVideoStatsTimeseriesRequest) Descriptor() ([]byte, []int) {
+	return file_api_video_v1_query_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetVideoStatsTimeseriesRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *GetVideoStatsTimeseriesRequest) GetGranularity() string {
+	if x != nil {
+		return x.Granularity
+	}
+	return ""
+}
+
+func (x *GetVideoStatsTimeseriesRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *GetVideoStatsTimeseriesRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *GetVideoStatsTimeseriesRequest) GetTzOffsetMinutes() int32 {
+	if x != nil {
+		return x.TzOffsetMinutes
+	}
+	return 0
+}
+
+func (x *GetVideoStatsTimeseriesRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+type GetVideoStatsTimeseriesResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	VideoId         string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	Granularity     string                 `protobuf:"bytes,2,opt,name=granularity,proto3" json:"granularity,omitempty"`
+	TzOffsetMinutes int32                  `protobuf:"varint,3,opt,name=tz_offset_minutes,json=tzOffsetMinutes,proto3" json:"tz_offset_minutes,omitempty"` // 请求的固定偏移；按 time_zone 查询时为 0
+	Buckets         []*VideoStatsBucket    `protobuf:"bytes,4,rep,name=buckets,proto3" json:"buckets,omitempty"`                                           // 按 bucket_start 升序，无数据的桶补零
+	TimeZone        string                 `protobuf:"bytes,5,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`                         // 请求的 IANA 时区名；按固定偏移查询时为空
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetVideoStatsTimeseriesResponse) Reset() {
+	*x = GetVideoStatsTimeseriesResponse{}
+	mi := &file_api_video_v1_query_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVideoStatsTimeseriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVideoStatsTimeseriesResponse) ProtoMessage() {}
+
+func (x *GetVideoStatsTimeseriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_video_v1_query_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVideoStatsTimeseriesResponse.ProtoReflect.Descriptor instead.
+func (*GetVideoStatsTimeseriesResponse) Descriptor() ([]byte, []int) {
+	return file_api_video_v1_query_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetVideoStatsTimeseriesResponse) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *GetVideoStatsTimeseriesResponse) GetGranularity() string {
+	if x != nil {
+		return x.Granularity
+	}
+	return ""
+}
+
+func (x *GetVideoStatsTimeseriesResponse) GetTzOffsetMinutes() int32 {
+	if x != nil {
+		return x.TzOffsetMinutes
+	}
+	return 0
+}
+
+func (x *GetVideoStatsTimeseriesResponse) GetBuckets() []*VideoStatsBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+func (x *GetVideoStatsTimeseriesResponse) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+// VideoStatsBucket 描述一个统计桶内的增量。
+type VideoStatsBucket struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	BucketStart    string                 `protobuf:"bytes,1,opt,name=bucket_start,json=bucketStart,proto3" json:"bucket_start,omitempty"`           // 桶起点，RFC3339，带请求的时区偏移
+	LikeDelta      int64                  `protobuf:"varint,2,opt,name=like_delta,json=likeDelta,proto3" json:"like_delta,omitempty"`                // 点赞净增量（新增 - 取消，可为负）
+	BookmarkDelta  int64                  `protobuf:"varint,3,opt,name=bookmark_delta,json=bookmarkDelta,proto3" json:"bookmark_delta,omitempty"`    // 收藏净增量（新增 - 取消，可为负）
+	WatchCount     int64                  `protobuf:"varint,4,opt,name=watch_count,json=watchCount,proto3" json:"watch_count,omitempty"`             // 有效播放次数
+	UniqueWatchers int64                  `protobuf:"varint,5,opt,name=unique_watchers,json=uniqueWatchers,proto3" json:"unique_watchers,omitempty"` // 新增唯一观看用户数（用户首次有效播放落在该桶）
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *VideoStatsBucket) Reset() {
+	*x = VideoStatsBucket{}
+	mi := &file_api_video_v1_query_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VideoStatsBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VideoStatsBucket) ProtoMessage() {}
+
+func (x *VideoStatsBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_api_video_v1_query_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VideoStatsBucket.ProtoReflect.Descriptor instead.
+func (*VideoStatsBucket) Descriptor() ([]byte, []int) {
+	return file_api_video_v1_query_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *VideoStatsBucket) GetBucketStart() string {
+	if x != nil {
+		return x.BucketStart
+	}
+	return ""
+}
+
+func (x *VideoStatsBucket) GetLikeDelta() int64 {
+	if x != nil {
+		return x.LikeDelta
+	}
+	return 0
+}
+
+func (x *VideoStatsBucket) GetBookmarkDelta() int64 {
+	if x != nil {
+		return x.BookmarkDelta
+	}
+	return 0
+}
+
+func (x *VideoStatsBucket) GetWatchCount() int64 {
+	if x != nil {
+		return x.WatchCount
+	}
+	return 0
+}
+
+func (x *VideoStatsBucket) GetUniqueWatchers() int64 {
+	if x != nil {
+		return x.UniqueWatchers
+	}
+	return 0
+}
+
+// ListTrendingVideosRequest 按热度名次列出已发布的公开视频。
+// 首页请求读取最新热度快照，page_token 绑定该快照，翻页期间快照刷新不影响后续页。
+type ListTrendingVideosRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Difficulty    string                 `protobuf:"bytes,3,opt,name=difficulty,proto3" json:"difficulty,omitempty"` // 可选，按难度精确匹配
+	Tags          []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`             // 可选，视频须包含全部标签
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrendingVideosRequest) Reset() {
+	*x = ListTrendingVideosRequest{}
+	mi := &file_api_video_v1_query_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrendingVideosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrendingVideosRequest) ProtoMessage() {}
+
+func (x *ListTrendingVideosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_video_v1_query_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrendingVideosRequest.ProtoReflect.Descriptor instead.
+func (*ListTrendingVideosRequest) Descriptor() ([]byte, []int) {
+	return file_api_video_v1_query_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListTrendingVideosRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTrendingVideosRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListTrendingVideosRequest) GetDifficulty() string {
+	if x != nil {
+		return x.Difficulty
+	}
+	return ""
+}
+
+func (x *ListTrendingVideosRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type ListTrendingVideosResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Videos        []*TrendingVideoItem   `protobuf:"bytes,1,rep,name=videos,proto3" json:"videos,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	ComputedAt    string                 `protobuf:"bytes,3,opt,name=computed_at,json=computedAt,proto3" json:"computed_at,omitempty"` // 热度快照的计算时间，尚无快照时为空
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrendingVideosResponse) Reset() {
+	*x = ListTrendingVideosResponse{}
+	mi := &file_api_video_v1_query_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrendingVideosResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrendingVideosResponse) ProtoMessage() {}
+
+func (x *ListTrendingVideosResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_video_v1_query_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrendingVideosResponse.ProtoReflect.Descriptor instead.
+func (*ListTrendingVideosResponse) Descriptor() ([]byte, []int) {
+	return file_api_video_v1_query_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListTrendingVideosResponse) GetVideos() []*TrendingVideoItem {
+	if x != nil {
+		return x.Videos
+	}
+	return nil
+}
+
+func (x *ListTrendingVideosResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListTrendingVideosResponse) GetComputedAt() string {
+	if x != nil {
+		return x.ComputedAt
+	}
+	return ""
+}
+
+// TrendingVideoItem 描述热度榜中的一条记录，按 rank 升序返回。
+type TrendingVideoItem struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	VideoId        string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	Title          string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	DurationMicros int64                  `protobuf:"varint,3,opt,name=duration_micros,json=durationMicros,proto3" json:"duration_micros,omitempty"`
+	Difficulty     string                 `protobuf:"bytes,4,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	Tags           []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	CreatedAt      string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Rank           int32                  `protobuf:"varint,7,opt,name=rank,proto3" json:"rank,omitempty"`    // 快照内名次，过滤后可能不连续
+	Score          float64                `protobuf:"fixed64,8,opt,name=score,proto3" json:"score,omitempty"` // 时间衰减热度
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TrendingVideoItem) Reset() {
+	*x = TrendingVideoItem{}
+	mi := &file_api_video_v1_query_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrendingVideoItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrendingVideoItem) ProtoMessage() {}
+
+func (x *TrendingVideoItem) ProtoReflect() protoreflect.Message {
+	mi := &file_api_video_v1_query_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrendingVideoItem.ProtoReflect.Descriptor instead.
+func (*TrendingVideoItem) Descriptor() ([]byte, []int) {
+	return file_api_video_v1_query_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *TrendingVideoItem) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *TrendingVideoItem) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *TrendingVideoItem) GetDurationMicros() int64 {
+	if x != nil {
+		return x.DurationMicros
+	}
+	return 0
+}
+
+func (x *TrendingVideoItem) GetDifficulty() string {
+	if x != nil {
+		return x.Difficulty
+	}
+	return ""
+}
+
+func (x *TrendingVideoItem) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *TrendingVideoItem) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *TrendingVideoItem) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *TrendingVideoItem) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type GetPlaybackInfoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VideoId       string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlaybackInfoRequest) Reset() {
+	*x = GetPlaybackInfoRequest{}
+	mi := &file_api_video_v1_query_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlaybackInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlaybackInfoRequest) ProtoMessage() {}
+
+func (x *GetPlaybackInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_video_v1_query_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlaybackInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetPlaybackInfoRequest) Descriptor() ([]byte, []int) {
+	return file_api_video_v1_query_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetPlaybackInfoRequest) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+type GetPlaybackInfoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Playback      *PlaybackInfo          `protobuf:"bytes,1,opt,name=playback,proto3" json:"playback,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlaybackInfoResponse) Reset() {
+	*x = GetPlaybackInfoResponse{}
+	mi := &file_api_video_v1_query_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlaybackInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlaybackInfoResponse) ProtoMessage() {}
+
+func (x *GetPlaybackInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_video_v1_query_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlaybackInfoResponse.ProtoReflect.Descriptor instead.
+func (*GetPlaybackInfoResponse) Descriptor() ([]byte, []int) {
+	return file_api_video_v1_query_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetPlaybackInfoResponse) GetPlayback() *PlaybackInfo {
+	if x != nil {
+		return x.Playback
+	}
+	return nil
+}
+
+// PlaybackInfo 描述按调用方可见性策略签发的限时播放地址。
+type PlaybackInfo struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	VideoId              string                 `protobuf:"bytes,1,opt,name=video_id,json=videoId,proto3" json:"video_id,omitempty"`
+	HlsMasterPlaylistUrl string                 `protobuf:"bytes,2,opt,name=hls_master_playlist_url,json=hlsMasterPlaylistUrl,proto3" json:"hls_master_playlist_url,omitempty"` // 已签名的 HLS 主清单地址
+	ThumbnailUrl         string                 `protobuf:"bytes,3,opt,name=thumbnail_url,json=thumbnailUrl,proto3" json:"thumbnail_url,omitempty"`                             // 已签名的封面地址，无封面时为空
+	ExpiresAt            string                 `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`                                      // 主清单地址与 Cookie 的过期时间
+	DurationMicros       int64                  `protobuf:"varint,5,opt,name=duration_micros,json=durationMicros,proto3" json:"duration_micros,omitempty"`
+	SignedCookie         *SignedCookie          `protobuf:"bytes,6,opt,name=signed_cookie,json=signedCookie,proto3" json:"signed_cookie,omitempty"` // 启用 Cookie 模式时下发，覆盖 HLS 目录前缀下的分片请求
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *PlaybackInfo) Reset() {
+	*x = PlaybackInfo{}
+	mi := &file_api_video_v1_query_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaybackInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaybackInfo) ProtoMessage() {}
+
+func (x *PlaybackInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_video_v1_query_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaybackInfo.ProtoReflect.Descriptor instead.
+func (*PlaybackInfo) Descriptor() ([]byte, []int) {
+	return file_api_video_v1_query_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *PlaybackInfo) GetVideoId() string {
+	if x != nil {
+		return x.VideoId
+	}
+	return ""
+}
+
+func (x *PlaybackInfo) GetHlsMasterPlaylistUrl() string {
+	if x != nil {
+		return x.HlsMasterPlaylistUrl
+	}
+	return ""
+}
+
+func (x *PlaybackInfo) GetThumbnailUrl() string {
+	if x != nil {
+		return x.ThumbnailUrl
+	}
+	return ""
+}
+
+func (x *PlaybackInfo) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *PlaybackInfo) GetDurationMicros() int64 {
+	if x != nil {
+		return x.DurationMicros
+	}
+	return 0
+}
+
+func (x *PlaybackInfo) GetSignedCookie() *SignedCookie {
+	if x != nil {
+		return x.SignedCookie
+	}
+	return nil
+}
+
+// SignedCookie 描述覆盖 URL 前缀的签名 Cookie。
+type SignedCookie struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Domain        string                 `protobuf:"bytes,3,opt,name=domain,proto3" json:"domain,omitempty"`
+	Path          string                 `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+	ExpiresAt     string                 `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignedCookie) Reset() {
+	*x = SignedCookie{}
+	mi := &file_api_video_v1_query_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignedCookie) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignedCookie) ProtoMessage() {}
+
+func (x *SignedCookie) ProtoReflect() protoreflect.Message {
+	mi := &file_api_video_v1_query_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignedCookie.ProtoReflect.Descriptor instead.
+func (*SignedCookie) Descriptor() ([]byte, []int) {
+	return file_api_video_v1_query_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SignedCookie) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SignedCookie) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *SignedCookie) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *SignedCookie) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *SignedCookie) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+var File_api_video_v1_query_proto protoreflect.FileDescriptor
+
+const file_api_video_v1_query_proto_rawDesc = "" +
+	"\n" +
+	"\x18api/video/v1/query.proto\x12\bvideo.v1\x1a\x1bbuf/validate/validate.proto\">\n" +
+	"\x17GetVideoMetadataRequest\x12#\n" +
+	"\bvideo_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\avideoId\"O\n" +
+	"\x18GetVideoMetadataResponse\x123\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x17.video.v1.VideoMetadataR\bmetadata\"<\n" +
+	"\x15GetVideoDetailRequest\x12#\n" +
+	"\bvideo_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\avideoId\"|\n" +
+	"\x16GetVideoDetailResponse\x12-\n" +
+	"\x06detail\x18\x01 \x01(\v2\x15.video.v1.VideoDetailR\x06detail\x123\n" +
+	"\bmetadata\x18\x02 \x01(\v2\x17.video.v1.VideoMetadataR\bmetadata\"\xaf\x06\n" +
+	"\vVideoDetail\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12!\n" +
+	"\fmedia_status\x18\x04 \x01(\tR\vmediaStatus\x12'\n" +
+	"\x0fanalysis_status\x18\x05 \x01(\tR\x0eanalysisStatus\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\tR\tupdatedAt\x12\x1b\n" +
+	"\thas_liked\x18\b \x01(\bR\bhasLiked\x12%\n" +
+	"\x0ehas_bookmarked\x18\t \x01(\bR\rhasBookmarked\x12\x1f\n" +
+	"\vhas_watched\x18\n" +
+	" \x01(\bR\n" +
+	"hasWatched\x12\x1d\n" +
+	"\n" +
+	"like_count\x18\v \x01(\x03R\tlikeCount\x12%\n" +
+	"\x0ebookmark_count\x18\f \x01(\x03R\rbookmarkCount\x12\x1f\n" +
+	"\vwatch_count\x18\r \x01(\x03R\n" +
+	"watchCount\x12'\n" +
+	"\x0funique_watchers\x18\x0e \x01(\x03R\x0euniqueWatchers\x12.\n" +
+	"\x13total_watch_seconds\x18\x0f \x01(\x01R\x11totalWatchSeconds\x12'\n" +
+	"\x0fcompletion_rate\x18\x10 \x01(\x01R\x0ecompletionRate\x124\n" +
+	"\x16resume_position_micros\x18\x11 \x01(\x03R\x14resumePositionMicros\x12\x1f\n" +
+	"\vshare_count\x18\x12 \x01(\x03R\n" +
+	"shareCount\x12!\n" +
+	"\frating_count\x18\x13 \x01(\x03R\vratingCount\x12%\n" +
+	"\x0eaverage_rating\x18\x14 \x01(\x01R\raverageRating\x12\x1b\n" +
+	"\tmy_rating\x18\x15 \x01(\x05R\bmyRating\x12A\n" +
+	"\x1daverage_view_duration_seconds\x18\x16 \x01(\x01R\x1aaverageViewDurationSeconds\"\xe8\x06\n" +
+	"\rVideoMetadata\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12!\n" +
+	"\fmedia_status\x18\x02 \x01(\tR\vmediaStatus\x12'\n" +
+	"\x0fanalysis_status\x18\x03 \x01(\tR\x0eanalysisStatus\x12'\n" +
+	"\x0fduration_micros\x18\x04 \x01(\x03R\x0edurationMicros\x12-\n" +
+	"\x12encoded_resolution\x18\x05 \x01(\tR\x11encodedResolution\x12'\n" +
+	"\x0fencoded_bitrate\x18\x06 \x01(\x05R\x0eencodedBitrate\x12#\n" +
+	"\rthumbnail_url\x18\a \x01(\tR\fthumbnailUrl\x12.\n" +
+	"\x13hls_master_playlist\x18\b \x01(\tR\x11hlsMasterPlaylist\x12\x1e\n" +
+	"\n" +
+	"difficulty\x18\t \x01(\tR\n" +
+	"difficulty\x12\x18\n" +
+	"\asummary\x18\n" +
+	" \x01(\tR\asummary\x12\x12\n" +
+	"\x04tags\x18\v \x03(\tR\x04tags\x12(\n" +
+	"\x10raw_subtitle_url\x18\f \x01(\tR\x0erawSubtitleUrl\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\r \x01(\tR\tupdatedAt\x12\x18\n" +
+	"\aversion\x18\x0e \x01(\x03R\aversion\x12\x1d\n" +
+	"\n" +
+	"like_count\x18\x0f \x01(\x03R\tlikeCount\x12%\n" +
+	"\x0ebookmark_count\x18\x10 \x01(\x03R\rbookmarkCount\x12\x1f\n" +
+	"\vwatch_count\x18\x11 \x01(\x03R\n" +
+	"watchCount\x12.\n" +
+	"\x13total_watch_seconds\x18\x12 \x01(\x01R\x11totalWatchSeconds\x12'\n" +
+	"\x0fcompletion_rate\x18\x13 \x01(\x01R\x0ecompletionRate\x12\x1f\n" +
+	"\vshare_count\x18\x14 \x01(\x03R\n" +
+	"shareCount\x12!\n" +
+	"\frating_count\x18\x15 \x01(\x03R\vratingCount\x12%\n" +
+	"\x0eaverage_rating\x18\x16 \x01(\x01R\raverageRating\x12A\n" +
+	"\x1daverage_view_duration_seconds\x18\x17 \x01(\x01R\x1aaverageViewDurationSeconds\"Y\n" +
+	"\x1bListUserPublicVideosRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"w\n" +
+	"\x1cListUserPublicVideosResponse\x12/\n" +
+	"\x06videos\x18\x01 \x03(\v2\x17.video.v1.VideoListItemR\x06videos\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xa0\x02\n" +
+	"\x14ListMyUploadsRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12y\n" +
+	"\rstatus_filter\x18\x03 \x03(\tBT\xbaHQ\x92\x01N\"LrJR\x0epending_uploadR\n" +
+	"processingR\x05readyR\tpublishedR\x06failedR\brejectedR\barchivedR\fstatusFilter\x12Q\n" +
+	"\fstage_filter\x18\x04 \x03(\tB.\xbaH+\x92\x01(\"&r$R\apendingR\n" +
+	"processingR\x05readyR\x06failedR\vstageFilter\"s\n" +
+	"\x15ListMyUploadsResponse\x122\n" +
+	"\x06videos\x18\x01 \x03(\v2\x1a.video.v1.MyUploadListItemR\x06videos\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xe2\x01\n" +
+	"\rVideoListItem\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12!\n" +
+	"\fmedia_status\x18\x04 \x01(\tR\vmediaStatus\x12'\n" +
+	"\x0fanalysis_status\x18\x05 \x01(\tR\x0eanalysisStatus\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\tR\tupdatedAt\"\xa0\x02\n" +
+	"\x10MyUploadListItem\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12!\n" +
+	"\fmedia_status\x18\x04 \x01(\tR\vmediaStatus\x12'\n" +
+	"\x0fanalysis_status\x18\x05 \x01(\tR\x0eanalysisStatus\x12\x18\n" +
+	"\aversion\x18\x06 \x01(\x03R\aversion\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\tR\tupdatedAt\x12\x1f\n" +
+	"\vraw_missing\x18\t \x01(\bR\n" +
+	"rawMissing\"W\n" +
+	"\x19ListMyWatchHistoryRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"x\n" +
+	"\x1aListMyWatchHistoryResponse\x122\n" +
+	"\x06videos\x18\x01 \x03(\v2\x1a.video.v1.WatchHistoryItemR\x06videos\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xae\x02\n" +
+	"\x10WatchHistoryItem\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12!\n" +
+	"\fmedia_status\x18\x04 \x01(\tR\vmediaStatus\x12'\n" +
+	"\x0fanalysis_status\x18\x05 \x01(\tR\x0eanalysisStatus\x12'\n" +
+	"\x0fduration_micros\x18\x06 \x01(\x03R\x0edurationMicros\x124\n" +
+	"\x16resume_position_micros\x18\a \x01(\x03R\x14resumePositionMicros\x12&\n" +
+	"\x0flast_watched_at\x18\b \x01(\tR\rlastWatchedAt\"V\n" +
+	"\x18ListMyLikedVideosRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"u\n" +
+	"\x19ListMyLikedVideosResponse\x120\n" +
+	"\x06videos\x18\x01 \x03(\v2\x18.video.v1.SavedVideoItemR\x06videos\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"[\n" +
+	"\x1dListMyBookmarkedVideosRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"z\n" +
+	"\x1eListMyBookmarkedVideosResponse\x120\n" +
+	"\x06videos\x18\x01 \x03(\v2\x18.video.v1.SavedVideoItemR\x06videos\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x88\x02\n" +
+	"\x0eSavedVideoItem\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12!\n" +
+	"\fmedia_status\x18\x04 \x01(\tR\vmediaStatus\x12'\n" +
+	"\x0fanalysis_status\x18\x05 \x01(\tR\x0eanalysisStatus\x12'\n" +
+	"\x0fduration_micros\x18\x06 \x01(\x03R\x0edurationMicros\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\x12\x19\n" +
+	"\bsaved_at\x18\b \x01(\tR\asavedAt\"\x8d\x02\n" +
+	"\x1eGetVideoStatsTimeseriesRequest\x12#\n" +
+	"\bvideo_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\avideoId\x122\n" +
+	"\vgranularity\x18\x02 \x01(\tB\x10\xbaH\rr\vR\x04hourR\x03dayR\vgranularity\x12\x1b\n" +
+	"\x04from\x18\x03 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x04from\x12\x17\n" +
+	"\x02to\x18\x04 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x02to\x12?\n" +
+	"\x11tz_offset_minutes\x18\x05 \x01(\x05B\x13\xbaH\x10\x1a\x0e\x18\xc8\x06(\xb8\xf9\xff\xff\xff\xff\xff\xff\xff\x01R\x0ftzOffsetMinutes\x12\x1b\n" +
+	"\ttime_zone\x18\x06 \x01(\tR\btimeZone\"\xdd\x01\n" +
+	"\x1fGetVideoStatsTimeseriesResponse\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12 \n" +
+	"\vgranularity\x18\x02 \x01(\tR\vgranularity\x12*\n" +
+	"\x11tz_offset_minutes\x18\x03 \x01(\x05R\x0ftzOffsetMinutes\x124\n" +
+	"\abuckets\x18\x04 \x03(\v2\x1a.video.v1.VideoStatsBucketR\abuckets\x12\x1b\n" +
+	"\ttime_zone\x18\x05 \x01(\tR\btimeZone\"\xc5\x01\n" +
+	"\x10VideoStatsBucket\x12!\n" +
+	"\fbucket_start\x18\x01 \x01(\tR\vbucketStart\x12\x1d\n" +
+	"\n" +
+	"like_delta\x18\x02 \x01(\x03R\tlikeDelta\x12%\n" +
+	"\x0ebookmark_delta\x18\x03 \x01(\x03R\rbookmarkDelta\x12\x1f\n" +
+	"\vwatch_count\x18\x04 \x01(\x03R\n" +
+	"watchCount\x12'\n" +
+	"\x0funique_watchers\x18\x05 \x01(\x03R\x0euniqueWatchers\"\x9b\x01\n" +
+	"\x19ListTrendingVideosRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x1e\n" +
+	"\n" +
+	"difficulty\x18\x03 \x01(\tR\n" +
+	"difficulty\x12\"\n" +
+	"\x04tags\x18\x04 \x03(\tB\x0e\xbaH\v\x92\x01\b\x10\n" +
+	"\"\x04r\x02\x10\x01R\x04tags\"\x9a\x01\n" +
+	"\x1aListTrendingVideosResponse\x123\n" +
+	"\x06videos\x18\x01 \x03(\v2\x1b.video.v1.TrendingVideoItemR\x06videos\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1f\n" +
+	"\vcomputed_at\x18\x03 \x01(\tR\n" +
+	"computedAt\"\xea\x01\n" +
+	"\x11TrendingVideoItem\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12'\n" +
+	"\x0fduration_micros\x18\x03 \x01(\x03R\x0edurationMicros\x12\x1e\n" +
+	"\n" +
+	"difficulty\x18\x04 \x01(\tR\n" +
+	"difficulty\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x12\n" +
+	"\x04rank\x18\a \x01(\x05R\x04rank\x12\x14\n" +
+	"\x05score\x18\b \x01(\x01R\x05score\"=\n" +
+	"\x16GetPlaybackInfoRequest\x12#\n" +
+	"\bvideo_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\avideoId\"M\n" +
+	"\x17GetPlaybackInfoResponse\x122\n" +
+	"\bplayback\x18\x01 \x01(\v2\x16.video.v1.PlaybackInfoR\bplayback\"\x8a\x02\n" +
+	"\fPlaybackInfo\x12\x19\n" +
+	"\bvideo_id\x18\x01 \x01(\tR\avideoId\x125\n" +
+	"\x17hls_master_playlist_url\x18\x02 \x01(\tR\x14hlsMasterPlaylistUrl\x12#\n" +
+	"\rthumbnail_url\x18\x03 \x01(\tR\fthumbnailUrl\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\tR\texpiresAt\x12'\n" +
+	"\x0fduration_micros\x18\x05 \x01(\x03R\x0edurationMicros\x12;\n" +
+	"\rsigned_cookie\x18\x06 \x01(\v2\x16.video.v1.SignedCookieR\fsignedCookie\"\x83\x01\n" +
+	"\fSignedCookie\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12\x16\n" +
+	"\x06domain\x18\x03 \x01(\tR\x06domain\x12\x12\n" +
+	"\x04path\x18\x04 \x01(\tR\x04path\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\b \x01(\tR\tupdatedAt2\xfe\x02\n" +
+	"expires_at\x18\x05 \x01(\tR\texpiresAt2\xd3\a\n" +
 	"\x13CatalogQueryService\x12Y\n" +
 	"\x10GetVideoMetadata\x12!.video.v1.GetVideoMetadataRequest\x1a\".video.v1.GetVideoMetadataResponse\x12S\n" +
 	"\x0eGetVideoDetail\x12\x1f.video.v1.GetVideoDetailRequest\x1a .video.v1.GetVideoDetailResponse\x12e\n" +
 	"\x14ListUserPublicVideos\x12%.video.v1.ListUserPublicVideosRequest\x1a&.video.v1.ListUserPublicVideosResponse\x12P\n" +
-	"\rListMyUploads\x12\x1e.video.v1.ListMyUploadsRequest\x1a\x1f.video.v1.ListMyUploadsResponseBDZBgithub.com/bionicotaku/lingo-services-catalog/api/video/v1;videov1b\x06proto3"
+	"\rListMyUploads\x12\x1e.video.v1.ListMyUploadsRequest\x1a\x1f.video.v1.ListMyUploadsResponse\x12V\n" +
+	"\x0fGetPlaybackInfo\x12 .video.v1.GetPlaybackInfoRequest\x1a!.video.v1.GetPlaybackInfoResponse\x12_\n" +
+	"\x12ListMyWatchHistory\x12#.video.v1.ListMyWatchHistoryRequest\x1a$.video.v1.ListMyWatchHistoryResponse\x12\\\n" +
+	"\x11ListMyLikedVideos\x12\".video.v1.ListMyLikedVideosRequest\x1a#.video.v1.ListMyLikedVideosResponse\x12k\n" +
+	"\x16ListMyBookmarkedVideos\x12'.video.v1.ListMyBookmarkedVideosRequest\x1a(.video.v1.ListMyBookmarkedVideosResponse\x12n\n" +
+	"\x17GetVideoStatsTimeseries\x12(.video.v1.GetVideoStatsTimeseriesRequest\x1a).video.v1.GetVideoStatsTimeseriesResponse\x12_\n" +
+	"\x12ListTrendingVideos\x12#.video.v1.ListTrendingVideosRequest\x1a$.video.v1.ListTrendingVideosResponseBDZBgithub.com/bionicotaku/lingo-services-catalog/api/video/v1;videov1b\x06proto3"
 
 var (
 	file_api_video_v1_query_proto_rawDescOnce sync.Once
@@ -1058,20 +2543,38 @@ func file_api_video_v1_query_proto_rawDescGZIP() []byte {
 	return file_api_video_v1_query_proto_rawDescData
 }
 
-var file_api_video_v1_query_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_api_video_v1_query_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
 var file_api_video_v1_query_proto_goTypes = []any{
-	(*GetVideoMetadataRequest)(nil),      // 0: video.v1.GetVideoMetadataRequest
-	(*GetVideoMetadataResponse)(nil),     // 1: video.v1.GetVideoMetadataResponse
-	(*GetVideoDetailRequest)(nil),        // 2: video.v1.GetVideoDetailRequest
-	(*GetVideoDetailResponse)(nil),       // 3: video.v1.GetVideoDetailResponse
-	(*VideoDetail)(nil),                  // 4: video.v1.VideoDetail
-	(*VideoMetadata)(nil),                // 5: video.v1.VideoMetadata
-	(*ListUserPublicVideosRequest)(nil),  // 6: video.v1.ListUserPublicVideosRequest
-	(*ListUserPublicVideosResponse)(nil), // 7: video.v1.ListUserPublicVideosResponse
-	(*ListMyUploadsRequest)(nil),         // 8: video.v1.ListMyUploadsRequest
-	(*ListMyUploadsResponse)(nil),        // 9: video.v1.ListMyUploadsResponse
-	(*VideoListItem)(nil),                // 10: video.v1.VideoListItem
-	(*MyUploadListItem)(nil),             // 11: video.v1.MyUploadListItem
+	(*GetVideoMetadataRequest)(nil),         // 0: video.v1.GetVideoMetadataRequest
+	(*GetVideoMetadataResponse)(nil),        // 1: video.v1.GetVideoMetadataResponse
+	(*GetVideoDetailRequest)(nil),           // 2: video.v1.GetVideoDetailRequest
+	(*GetVideoDetailResponse)(nil),          // 3: video.v1.GetVideoDetailResponse
+	(*VideoDetail)(nil),                     // 4: video.v1.VideoDetail
+	(*VideoMetadata)(nil),                   // 5: video.v1.VideoMetadata
+	(*ListUserPublicVideosRequest)(nil),     // 6: video.v1.ListUserPublicVideosRequest
+	(*ListUserPublicVideosResponse)(nil),    // 7: video.v1.ListUserPublicVideosResponse
+	(*ListMyUploadsRequest)(nil),            // 8: video.v1.ListMyUploadsRequest
+	(*ListMyUploadsResponse)(nil),           // 9: video.v1.ListMyUploadsResponse
+	(*VideoListItem)(nil),                   // 10: video.v1.VideoListItem
+	(*MyUploadListItem)(nil),                // 11: video.v1.MyUploadListItem
+	(*ListMyWatchHistoryRequest)(nil),       // 12: video.v1.ListMyWatchHistoryRequest
+	(*ListMyWatchHistoryResponse)(nil),      // 13: video.v1.ListMyWatchHistoryResponse
+	(*WatchHistoryItem)(nil),                // 14: video.v1.WatchHistoryItem
+	(*ListMyLikedVideosRequest)(nil),        // 15: video.v1.ListMyLikedVideosRequest
+	(*ListMyLikedVideosResponse)(nil),       // 16: video.v1.ListMyLikedVideosResponse
+	(*ListMyBookmarkedVideosRequest)(nil),   // 17: video.v1.ListMyBookmarkedVideosRequest
+	(*ListMyBookmarkedVideosResponse)(nil),  // 18: video.v1.ListMyBookmarkedVideosResponse
+	(*SavedVideoItem)(nil),                  // 19: video.v1.SavedVideoItem
+	(*GetVideoStatsTimeseriesRequest)(nil),  // 20: video.v1.GetVideoStatsTimeseriesRequest
+	(*GetVideoStatsTimeseriesResponse)(nil), // 21: video.v1.GetVideoStatsTimeseriesResponse
+	(*VideoStatsBucket)(nil),                // 22: video.v1.VideoStatsBucket
+	(*ListTrendingVideosRequest)(nil),       // 23: video.v1.ListTrendingVideosRequest
+	(*ListTrendingVideosResponse)(nil),      // 24: video.v1.ListTrendingVideosResponse
+	(*TrendingVideoItem)(nil),               // 25: video.v1.TrendingVideoItem
+	(*GetPlaybackInfoRequest)(nil),          // 26: video.v1.GetPlaybackInfoRequest
+	(*GetPlaybackInfoResponse)(nil),         // 27: video.v1.GetPlaybackInfoResponse
+	(*PlaybackInfo)(nil),                    // 28: video.v1.PlaybackInfo
+	(*SignedCookie)(nil),                    // 29: video.v1.SignedCookie
 }
 var file_api_video_v1_query_proto_depIdxs = []int32{
 	5,  // 0: video.v1.GetVideoMetadataResponse.metadata:type_name -> video.v1.VideoMetadata
@@ -1079,19 +2582,38 @@ var file_api_video_v1_query_proto_depIdxs = []int32{
 	5,  // 2: video.v1.GetVideoDetailResponse.metadata:type_name -> video.v1.VideoMetadata
 	10, // 3: video.v1.ListUserPublicVideosResponse.videos:type_name -> video.v1.VideoListItem
 	11, // 4: video.v1.ListMyUploadsResponse.videos:type_name -> video.v1.MyUploadListItem
-	0,  // 5: video.v1.CatalogQueryService.GetVideoMetadata:input_type -> video.v1.GetVideoMetadataRequest
-	2,  // 6: video.v1.CatalogQueryService.GetVideoDetail:input_type -> video.v1.GetVideoDetailRequest
-	6,  // 7: video.v1.CatalogQueryService.ListUserPublicVideos:input_type -> video.v1.ListUserPublicVideosRequest
-	8,  // 8: video.v1.CatalogQueryService.ListMyUploads:input_type -> video.v1.ListMyUploadsRequest
-	1,  // 9: video.v1.CatalogQueryService.GetVideoMetadata:output_type -> video.v1.GetVideoMetadataResponse
-	3,  // 10: video.v1.CatalogQueryService.GetVideoDetail:output_type -> video.v1.GetVideoDetailResponse
-	7,  // 11: video.v1.CatalogQueryService.ListUserPublicVideos:output_type -> video.v1.ListUserPublicVideosResponse
-	9,  // 12: video.v1.CatalogQueryService.ListMyUploads:output_type -> video.v1.ListMyUploadsResponse
-	9,  // [9:13] is the sub-list for method output_type
-	5,  // [5:9] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	14, // 5: video.v1.ListMyWatchHistoryResponse.videos:type_name -> video.v1.WatchHistoryItem
+	19, // 6: video.v1.ListMyLikedVideosResponse.videos:type_name -> video.v1.SavedVideoItem
+	19, // 7: video.v1.ListMyBookmarkedVideosResponse.videos:type_name -> video.v1.SavedVideoItem
+	22, // 8: video.v1.GetVideoStatsTimeseriesResponse.buckets:type_name -> video.v1.VideoStatsBucket
+	25, // 9: video.v1.ListTrendingVideosResponse.videos:type_name -> video.v1.TrendingVideoItem
+	28, // 10: video.v1.GetPlaybackInfoResponse.playback:type_name -> video.v1.PlaybackInfo
+	29, // 11: video.v1.PlaybackInfo.signed_cookie:type_name -> video.v1.SignedCookie
+	0,  // 12: video.v1.CatalogQueryService.GetVideoMetadata:input_type -> video.v1.GetVideoMetadataRequest
+	2,  // 13: video.v1.CatalogQueryService.GetVideoDetail:input_type -> video.v1.GetVideoDetailRequest
+	6,  // 14: video.v1.CatalogQueryService.ListUserPublicVideos:input_type -> video.v1.ListUserPublicVideosRequest
+	8,  // 15: video.v1.CatalogQueryService.ListMyUploads:input_type -> video.v1.ListMyUploadsRequest
+	26, // 16: video.v1.CatalogQueryService.GetPlaybackInfo:input_type -> video.v1.GetPlaybackInfoRequest
+	12, // 17: video.v1.CatalogQueryService.ListMyWatchHistory:input_type -> video.v1.ListMyWatchHistoryRequest
+	15, // 18: video.v1.CatalogQueryService.ListMyLikedVideos:input_type -> video.v1.ListMyLikedVideosRequest
+	17, // 19: video.v1.CatalogQueryService.ListMyBookmarkedVideos:input_type -> video.v1.ListMyBookmarkedVideosRequest
+	20, // 20: video.v1.CatalogQueryService.GetVideoStatsTimeseries:input_type -> video.v1.GetVideoStatsTimeseriesRequest
+	23, // 21: video.v1.CatalogQueryService.ListTrendingVideos:input_type -> video.v1.ListTrendingVideosRequest
+	1,  // 22: video.v1.CatalogQueryService.GetVideoMetadata:output_type -> video.v1.GetVideoMetadataResponse
+	3,  // 23: video.v1.CatalogQueryService.GetVideoDetail:output_type -> video.v1.GetVideoDetailResponse
+	7,  // 24: video.v1.CatalogQueryService.ListUserPublicVideos:output_type -> video.v1.ListUserPublicVideosResponse
+	9,  // 25: video.v1.CatalogQueryService.ListMyUploads:output_type -> video.v1.ListMyUploadsResponse
+	27, // 26: video.v1.CatalogQueryService.GetPlaybackInfo:output_type -> video.v1.GetPlaybackInfoResponse
+	13, // 27: video.v1.CatalogQueryService.ListMyWatchHistory:output_type -> video.v1.ListMyWatchHistoryResponse
+	16, // 28: video.v1.CatalogQueryService.ListMyLikedVideos:output_type -> video.v1.ListMyLikedVideosResponse
+	18, // 29: video.v1.CatalogQueryService.ListMyBookmarkedVideos:output_type -> video.v1.ListMyBookmarkedVideosResponse
+	21, // 30: video.v1.CatalogQueryService.GetVideoStatsTimeseries:output_type -> video.v1.GetVideoStatsTimeseriesResponse
+	24, // 31: video.v1.CatalogQueryService.ListTrendingVideos:output_type -> video.v1.ListTrendingVideosResponse
+	22, // [22:32] is the sub-list for method output_type
+	12, // [12:22] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_api_video_v1_query_proto_init() }
@@ -1105,7 +2627,7 @@ func file_api_video_v1_query_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_video_v1_query_proto_rawDesc), len(file_api_video_v1_query_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   30,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
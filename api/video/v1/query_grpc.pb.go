@@ -19,10 +19,16 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	CatalogQueryService_GetVideoMetadata_FullMethodName     = "/video.v1.CatalogQueryService/GetVideoMetadata"
-	CatalogQueryService_GetVideoDetail_FullMethodName       = "/video.v1.CatalogQueryService/GetVideoDetail"
-	CatalogQueryService_ListUserPublicVideos_FullMethodName = "/video.v1.CatalogQueryService/ListUserPublicVideos"
-	CatalogQueryService_ListMyUploads_FullMethodName        = "/video.v1.CatalogQueryService/ListMyUploads"
+	CatalogQueryService_GetVideoMetadata_FullMethodName        = "/video.v1.CatalogQueryService/GetVideoMetadata"
+	CatalogQueryService_GetVideoDetail_FullMethodName          = "/video.v1.CatalogQueryService/GetVideoDetail"
+	CatalogQueryService_ListUserPublicVideos_FullMethodName    = "/video.v1.CatalogQueryService/ListUserPublicVideos"
+	CatalogQueryService_ListMyUploads_FullMethodName           = "/video.v1.CatalogQueryService/ListMyUploads"
+	CatalogQueryService_GetPlaybackInfo_FullMethodName         = "/video.v1.CatalogQueryService/GetPlaybackInfo"
+	CatalogQueryService_ListMyWatchHistory_FullMethodName      = "/video.v1.CatalogQueryService/ListMyWatchHistory"
+	CatalogQueryService_ListMyLikedVideos_FullMethodName       = "/video.v1.CatalogQueryService/ListMyLikedVideos"
+	CatalogQueryService_ListMyBookmarkedVideos_FullMethodName  = "/video.v1.CatalogQueryService/ListMyBookmarkedVideos"
+	CatalogQueryService_GetVideoStatsTimeseries_FullMethodName = "/video.v1.CatalogQueryService/GetVideoStatsTimeseries"
+	CatalogQueryService_ListTrendingVideos_FullMethodName      = "/video.v1.CatalogQueryService/ListTrendingVideos"
 )
 
 // CatalogQueryServiceClient is the client API for CatalogQueryService service.
@@ -35,6 +41,12 @@ type CatalogQueryServiceClient interface {
 	GetVideoDetail(ctx context.Context, in *GetVideoDetailRequest, opts ...grpc.CallOption) (*GetVideoDetailResponse, error)
 	ListUserPublicVideos(ctx context.Context, in *ListUserPublicVideosRequest, opts ...grpc.CallOption) (*ListUserPublicVideosResponse, error)
 	ListMyUploads(ctx context.Context, in *ListMyUploadsRequest, opts ...grpc.CallOption) (*ListMyUploadsResponse, error)
+	GetPlaybackInfo(ctx context.Context, in *GetPlaybackInfoRequest, opts ...grpc.CallOption) (*GetPlaybackInfoResponse, error)
+	ListMyWatchHistory(ctx context.Context, in *ListMyWatchHistoryRequest, opts ...grpc.CallOption) (*ListMyWatchHistoryResponse, error)
+	ListMyLikedVideos(ctx context.Context, in *ListMyLikedVideosRequest, opts ...grpc.CallOption) (*ListMyLikedVideosResponse, error)
+	ListMyBookmarkedVideos(ctx context.Context, in *ListMyBookmarkedVideosRequest, opts ...grpc.CallOption) (*ListMyBookmarkedVideosResponse, error)
+	GetVideoStatsTimeseries(ctx context.Context, in *GetVideoStatsTimeseriesRequest, opts ...grpc.CallOption) (*GetVideoStatsTimeseriesResponse, error)
+	ListTrendingVideos(ctx context.Context, in *ListTrendingVideosRequest, opts ...grpc.CallOption) (*ListTrendingVideosResponse, error)
 }
 
 type catalogQueryServiceClient struct {
@@ -85,6 +97,66 @@ func (c *catalogQueryServiceClient) ListMyUploads(ctx context.Context, in *ListM
 	return out, nil
 }
 
+func (c *catalogQueryServiceClient) GetPlaybackInfo(ctx context.Context, in *GetPlaybackInfoRequest, opts ...grpc.CallOption) (*GetPlaybackInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPlaybackInfoResponse)
+	err := c.cc.Invoke(ctx, CatalogQueryService_GetPlaybackInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogQueryServiceClient) ListMyWatchHistory(ctx context.Context, in *ListMyWatchHistoryRequest, opts ...grpc.CallOption) (*ListMyWatchHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMyWatchHistoryResponse)
+	err := c.cc.Invoke(ctx, CatalogQueryService_ListMyWatchHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogQueryServiceClient) ListMyLikedVideos(ctx context.Context, in *ListMyLikedVideosRequest, opts ...grpc.CallOption) (*ListMyLikedVideosResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMyLikedVideosResponse)
+	err := c.cc.Invoke(ctx, CatalogQueryService_ListMyLikedVideos_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogQueryServiceClient) ListMyBookmarkedVideos(ctx context.Context, in *ListMyBookmarkedVideosRequest, opts ...grpc.CallOption) (*ListMyBookmarkedVideosResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMyBookmarkedVideosResponse)
+	err := c.cc.Invoke(ctx, CatalogQueryService_ListMyBookmarkedVideos_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogQueryServiceClient) GetVideoStatsTimeseries(ctx context.Context, in *GetVideoStatsTimeseriesRequest, opts ...grpc.CallOption) (*GetVideoStatsTimeseriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVideoStatsTimeseriesResponse)
+	err := c.cc.Invoke(ctx, CatalogQueryService_GetVideoStatsTimeseries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogQueryServiceClient) ListTrendingVideos(ctx context.Context, in *ListTrendingVideosRequest, opts ...grpc.CallOption) (*ListTrendingVideosResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTrendingVideosResponse)
+	err := c.cc.Invoke(ctx, CatalogQueryService_ListTrendingVideos_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CatalogQueryServiceServer is the server API for CatalogQueryService service.
 // All implementations must embed UnimplementedCatalogQueryServiceServer
 // for forward compatibility.
@@ -95,6 +167,12 @@ type CatalogQueryServiceServer interface {
 	GetVideoDetail(context.Context, *GetVideoDetailRequest) (*GetVideoDetailResponse, error)
 	ListUserPublicVideos(context.Context, *ListUserPublicVideosRequest) (*ListUserPublicVideosResponse, error)
 	ListMyUploads(context.Context, *ListMyUploadsRequest) (*ListMyUploadsResponse, error)
+	GetPlaybackInfo(context.Context, *GetPlaybackInfoRequest) (*GetPlaybackInfoResponse, error)
+	ListMyWatchHistory(context.Context, *ListMyWatchHistoryRequest) (*ListMyWatchHistoryResponse, error)
+	ListMyLikedVideos(context.Context, *ListMyLikedVideosRequest) (*ListMyLikedVideosResponse, error)
+	ListMyBookmarkedVideos(context.Context, *ListMyBookmarkedVideosRequest) (*ListMyBookmarkedVideosResponse, error)
+	GetVideoStatsTimeseries(context.Context, *GetVideoStatsTimeseriesRequest) (*GetVideoStatsTimeseriesResponse, error)
+	ListTrendingVideos(context.Context, *ListTrendingVideosRequest) (*ListTrendingVideosResponse, error)
 	mustEmbedUnimplementedCatalogQueryServiceServer()
 }
 
@@ -117,6 +195,24 @@ func (UnimplementedCatalogQueryServiceServer) ListUserPublicVideos(context.Conte
 func (UnimplementedCatalogQueryServiceServer) ListMyUploads(context.Context, *ListMyUploadsRequest) (*ListMyUploadsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListMyUploads not implemented")
 }
+func (UnimplementedCatalogQueryServiceServer) GetPlaybackInfo(context.Context, *GetPlaybackInfoRequest) (*GetPlaybackInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPlaybackInfo not implemented")
+}
+func (UnimplementedCatalogQueryServiceServer) ListMyWatchHistory(context.Context, *ListMyWatchHistoryRequest) (*ListMyWatchHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMyWatchHistory not implemented")
+}
+func (UnimplementedCatalogQueryServiceServer) ListMyLikedVideos(context.Context, *ListMyLikedVideosRequest) (*ListMyLikedVideosResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMyLikedVideos not implemented")
+}
+func (UnimplementedCatalogQueryServiceServer) ListMyBookmarkedVideos(context.Context, *ListMyBookmarkedVideosRequest) (*ListMyBookmarkedVideosResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMyBookmarkedVideos not implemented")
+}
+func (UnimplementedCatalogQueryServiceServer) GetVideoStatsTimeseries(context.Context, *GetVideoStatsTimeseriesRequest) (*GetVideoStatsTimeseriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVideoStatsTimeseries not implemented")
+}
+func (UnimplementedCatalogQueryServiceServer) ListTrendingVideos(context.Context, *ListTrendingVideosRequest) (*ListTrendingVideosResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTrendingVideos not implemented")
+}
 func (UnimplementedCatalogQueryServiceServer) mustEmbedUnimplementedCatalogQueryServiceServer() {}
 func (UnimplementedCatalogQueryServiceServer) testEmbeddedByValue()                             {}
 
@@ -210,6 +306,114 @@ func _CatalogQueryService_ListMyUploads_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CatalogQueryService_GetPlaybackInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPlaybackInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogQueryServiceServer).GetPlaybackInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogQueryService_GetPlaybackInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogQueryServiceServer).GetPlaybackInfo(ctx, req.(*GetPlaybackInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogQueryService_ListMyWatchHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMyWatchHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogQueryServiceServer).ListMyWatchHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogQueryService_ListMyWatchHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogQueryServiceServer).ListMyWatchHistory(ctx, req.(*ListMyWatchHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogQueryService_ListMyLikedVideos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMyLikedVideosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogQueryServiceServer).ListMyLikedVideos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogQueryService_ListMyLikedVideos_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogQueryServiceServer).ListMyLikedVideos(ctx, req.(*ListMyLikedVideosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogQueryService_ListMyBookmarkedVideos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMyBookmarkedVideosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogQueryServiceServer).ListMyBookmarkedVideos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogQueryService_ListMyBookmarkedVideos_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogQueryServiceServer).ListMyBookmarkedVideos(ctx, req.(*ListMyBookmarkedVideosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogQueryService_GetVideoStatsTimeseries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVideoStatsTimeseriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogQueryServiceServer).GetVideoStatsTimeseries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogQueryService_GetVideoStatsTimeseries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogQueryServiceServer).GetVideoStatsTimeseries(ctx, req.(*GetVideoStatsTimeseriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogQueryService_ListTrendingVideos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTrendingVideosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogQueryServiceServer).ListTrendingVideos(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogQueryService_ListTrendingVideos_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogQueryServiceServer).ListTrendingVideos(ctx, req.(*ListTrendingVideosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CatalogQueryService_ServiceDesc is the grpc.ServiceDesc for CatalogQueryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -233,6 +437,30 @@ var CatalogQueryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListMyUploads",
 			Handler:    _CatalogQueryService_ListMyUploads_Handler,
 		},
+		{
+			MethodName: "GetPlaybackInfo",
+			Handler:    _CatalogQueryService_GetPlaybackInfo_Handler,
+		},
+		{
+			MethodName: "ListMyWatchHistory",
+			Handler:    _CatalogQueryService_ListMyWatchHistory_Handler,
+		},
+		{
+			MethodName: "ListMyLikedVideos",
+			Handler:    _CatalogQueryService_ListMyLikedVideos_Handler,
+		},
+		{
+			MethodName: "ListMyBookmarkedVideos",
+			Handler:    _CatalogQueryService_ListMyBookmarkedVideos_Handler,
+		},
+		{
+			MethodName: "GetVideoStatsTimeseries",
+			Handler:    _CatalogQueryService_GetVideoStatsTimeseries_Handler,
+		},
+		{
+			MethodName: "ListTrendingVideos",
+			Handler:    _CatalogQueryService_ListTrendingVideos_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/video/v1/query.proto",
@@ -31,8 +31,10 @@ type InitResumableUploadRequest struct {
 	DurationSeconds int32                  `protobuf:"varint,4,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
 	Title           string                 `protobuf:"bytes,5,opt,name=title,proto3" json:"title,omitempty"`
 	Description     string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// 可选：内容 SHA-256（hex 64），用于跨会话的原始资产去重。
+	ContentSha256Hex string `protobuf:"bytes,7,opt,name=content_sha256_hex,json=contentSha256Hex,proto3" json:"content_sha256_hex,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *InitResumableUploadRequest) Reset() {
@@ -107,6 +109,13 @@ func (x *InitResumableUploadRequest) GetDescription() string {
 	return ""
 }
 
+func (x *InitResumableUploadRequest) GetContentSha256Hex() string {
+	if x != nil {
+		return x.ContentSha256Hex
+	}
+	return ""
+}
+
 // InitResumableUploadResponse 返回预留的视频标识与会话初始化 URL。
 type InitResumableUploadResponse struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
@@ -168,11 +177,194 @@ func (x *InitResumableUploadResponse) GetExpiresAtUnixms() int64 {
 	return 0
 }
 
+// GetMyUploadQuotaRequest 查询当前用户（由 metadata 解析）的上传配额。
+type GetMyUploadQuotaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMyUploadQuotaRequest) Reset() {
+	*x = GetMyUploadQuotaRequest{}
+	mi := &file_api_video_v1_upload_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMyUploadQuotaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMyUploadQuotaRequest) ProtoMessage() {}
+
+func (x *GetMyUploadQuotaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_video_v1_upload_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMyUploadQuotaRequest.ProtoReflect.Descriptor instead.
+func (*GetMyUploadQuotaRequest) Descriptor() ([]byte, []int) {
+	return file_api_video_v1_upload_proto_rawDescGZIP(), []int{2}
+}
+
+// UploadQuotaLimit 描述单项配额的上限与当前用量；limit 为 0 表示不限。
+type UploadQuotaLimit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int64                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Used          int64                  `protobuf:"varint,2,opt,name=used,proto3" json:"used,omitempty"`
+	Remaining     int64                  `protobuf:"varint,3,opt,name=remaining,proto3" json:"remaining,omitempty"`
+	Unlimited     bool                   `protobuf:"varint,4,opt,name=unlimited,proto3" json:"unlimited,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadQuotaLimit) Reset() {
+	*x = UploadQuotaLimit{}
+	mi := &file_api_video_v1_upload_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadQuotaLimit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadQuotaLimit) ProtoMessage() {}
+
+func (x *UploadQuotaLimit) ProtoReflect() protoreflect.Message {
+	mi := &file_api_video_v1_upload_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadQuotaLimit.ProtoReflect.Descriptor instead.
+func (*UploadQuotaLimit) Descriptor() ([]byte, []int) {
+	return file_api_video_v1_upload_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UploadQuotaLimit) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *UploadQuotaLimit) GetUsed() int64 {
+	if x != nil {
+		return x.Used
+	}
+	return 0
+}
+
+func (x *UploadQuotaLimit) GetRemaining() int64 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
+func (x *UploadQuotaLimit) GetUnlimited() bool {
+	if x != nil {
+		return x.Unlimited
+	}
+	return false
+}
+
+// GetMyUploadQuotaResponse 返回配额档位、各项用量以及每日额度的重置时间。
+type GetMyUploadQuotaResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Tier               string                 `protobuf:"bytes,1,opt,name=tier,proto3" json:"tier,omitempty"`
+	DailySessions      *UploadQuotaLimit      `protobuf:"bytes,2,opt,name=daily_sessions,json=dailySessions,proto3" json:"daily_sessions,omitempty"`
+	ConcurrentUploads  *UploadQuotaLimit      `protobuf:"bytes,3,opt,name=concurrent_uploads,json=concurrentUploads,proto3" json:"concurrent_uploads,omitempty"`
+	StorageBytes       *UploadQuotaLimit      `protobuf:"bytes,4,opt,name=storage_bytes,json=storageBytes,proto3" json:"storage_bytes,omitempty"`
+	DailyResetAtUnixms int64                  `protobuf:"varint,5,opt,name=daily_reset_at_unixms,json=dailyResetAtUnixms,proto3" json:"daily_reset_at_unixms,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *GetMyUploadQuotaResponse) Reset() {
+	*x = GetMyUploadQuotaResponse{}
+	mi := &file_api_video_v1_upload_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMyUploadQuotaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMyUploadQuotaResponse) ProtoMessage() {}
+
+func (x *GetMyUploadQuotaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_video_v1_upload_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMyUploadQuotaResponse.ProtoReflect.Descriptor instead.
+func (*GetMyUploadQuotaResponse) Descriptor() ([]byte, []int) {
+	return file_api_video_v1_upload_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetMyUploadQuotaResponse) GetTier() string {
+	if x != nil {
+		return x.Tier
+	}
+	return ""
+}
+
+func (x *GetMyUploadQuotaResponse) GetDailySessions() *UploadQuotaLimit {
+	if x != nil {
+		return x.DailySessions
+	}
+	return nil
+}
+
+func (x *GetMyUploadQuotaResponse) GetConcurrentUploads() *UploadQuotaLimit {
+	if x != nil {
+		return x.ConcurrentUploads
+	}
+	return nil
+}
+
+func (x *GetMyUploadQuotaResponse) GetStorageBytes() *UploadQuotaLimit {
+	if x != nil {
+		return x.StorageBytes
+	}
+	return nil
+}
+
+func (x *GetMyUploadQuotaResponse) GetDailyResetAtUnixms() int64 {
+	if x != nil {
+		return x.DailyResetAtUnixms
+	}
+	return 0
+}
+
 var File_api_video_v1_upload_proto protoreflect.FileDescriptor
 
 const file_api_video_v1_upload_proto_rawDesc = "" +
 	"\n" +
-	"\x19api/video/v1/upload.proto\x12\bvideo.v1\x1a\x1bbuf/validate/validate.proto\"\xb6\x02\n" +
+	"\x19api/video/v1/upload.proto\x12\bvideo.v1\x1a\x1bbuf/validate/validate.proto\"\x81\x03\n" +
 	"\x1aInitResumableUploadRequest\x12&\n" +
 	"\n" +
 	"size_bytes\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\tsizeBytes\x12*\n" +
@@ -181,13 +373,27 @@ const file_api_video_v1_upload_proto_rawDesc = "" +
 	"\x10duration_seconds\x18\x04 \x01(\x05B\n" +
 	"\xbaH\a\x1a\x05\x18\xac\x02(\x01R\x0fdurationSeconds\x12\x1d\n" +
 	"\x05title\x18\x05 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x05title\x12)\n" +
-	"\vdescription\x18\x06 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\vdescription\"\xae\x01\n" +
+	"\vdescription\x18\x06 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\vdescription\x12I\n" +
+	"\x12content_sha256_hex\x18\a \x01(\tB\x1b\xbaH\x18r\x162\x14^([a-fA-F0-9]{64})?$R\x10contentSha256Hex\"\xae\x01\n" +
 	"\x1bInitResumableUploadResponse\x12#\n" +
 	"\bvideo_id\x18\x01 \x01(\tB\b\xbaH\x05r\x03\xb0\x01\x01R\avideoId\x125\n" +
 	"\x12resumable_init_url\x18\x02 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x10resumableInitUrl\x123\n" +
-	"\x11expires_at_unixms\x18\x03 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x0fexpiresAtUnixms2s\n" +
+	"\x11expires_at_unixms\x18\x03 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x0fexpiresAtUnixms\"\x19\n" +
+	"\x17GetMyUploadQuotaRequest\"\x93\x01\n" +
+	"\x10UploadQuotaLimit\x12\x1d\n" +
+	"\x05limit\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\x05limit\x12\x1b\n" +
+	"\x04used\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\x04used\x12%\n" +
+	"\tremaining\x18\x03 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\tremaining\x12\x1c\n" +
+	"\tunlimited\x18\x04 \x01(\bR\tunlimited\"\xb9\x02\n" +
+	"\x18GetMyUploadQuotaResponse\x12\x12\n" +
+	"\x04tier\x18\x01 \x01(\tR\x04tier\x12A\n" +
+	"\x0edaily_sessions\x18\x02 \x01(\v2\x1a.video.v1.UploadQuotaLimitR\rdailySessions\x12I\n" +
+	"\x12concurrent_uploads\x18\x03 \x01(\v2\x1a.video.v1.UploadQuotaLimitR\x11concurrentUploads\x12?\n" +
+	"\rstorage_bytes\x18\x04 \x01(\v2\x1a.video.v1.UploadQuotaLimitR\fstorageBytes\x12:\n" +
+	"\x15daily_reset_at_unixms\x18\x05 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x12dailyResetAtUnixms2\xce\x01\n" +
 	"\rUploadService\x12b\n" +
-	"\x13InitResumableUpload\x12$.video.v1.InitResumableUploadRequest\x1a%.video.v1.InitResumableUploadResponseBDZBgithub.com/bionicotaku/lingo-services-catalog/api/video/v1;videov1b\x06proto3"
+	"\x13InitResumableUpload\x12$.video.v1.InitResumableUploadRequest\x1a%.video.v1.InitResumableUploadResponse\x12Y\n" +
+	"\x10GetMyUploadQuota\x12!.video.v1.GetMyUploadQuotaRequest\x1a\".video.v1.GetMyUploadQuotaResponseBDZBgithub.com/bionicotaku/lingo-services-catalog/api/video/v1;videov1b\x06proto3"
 
 var (
 	file_api_video_v1_upload_proto_rawDescOnce sync.Once
@@ -201,19 +407,27 @@ func file_api_video_v1_upload_proto_rawDescGZIP() []byte {
 	return file_api_video_v1_upload_proto_rawDescData
 }
 
-var file_api_video_v1_upload_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_api_video_v1_upload_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_api_video_v1_upload_proto_goTypes = []any{
 	(*InitResumableUploadRequest)(nil),  // 0: video.v1.InitResumableUploadRequest
 	(*InitResumableUploadResponse)(nil), // 1: video.v1.InitResumableUploadResponse
+	(*GetMyUploadQuotaRequest)(nil),     // 2: video.v1.GetMyUploadQuotaRequest
+	(*UploadQuotaLimit)(nil),            // 3: video.v1.UploadQuotaLimit
+	(*GetMyUploadQuotaResponse)(nil),    // 4: video.v1.GetMyUploadQuotaResponse
 }
 var file_api_video_v1_upload_proto_depIdxs = []int32{
-	0, // 0: video.v1.UploadService.InitResumableUpload:input_type -> video.v1.InitResumableUploadRequest
-	1, // 1: video.v1.UploadService.InitResumableUpload:output_type -> video.v1.InitResumableUploadResponse
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	3, // 0: video.v1.GetMyUploadQuotaResponse.daily_sessions:type_name -> video.v1.UploadQuotaLimit
+	3, // 1: video.v1.GetMyUploadQuotaResponse.concurrent_uploads:type_name -> video.v1.UploadQuotaLimit
+	3, // 2: video.v1.GetMyUploadQuotaResponse.storage_bytes:type_name -> video.v1.UploadQuotaLimit
+	0, // 3: video.v1.UploadService.InitResumableUpload:input_type -> video.v1.InitResumableUploadRequest
+	2, // 4: video.v1.UploadService.GetMyUploadQuota:input_type -> video.v1.GetMyUploadQuotaRequest
+	1, // 5: video.v1.UploadService.InitResumableUpload:output_type -> video.v1.InitResumableUploadResponse
+	4, // 6: video.v1.UploadService.GetMyUploadQuota:output_type -> video.v1.GetMyUploadQuotaResponse
+	5, // [5:7] is the sub-list for method output_type
+	3, // [3:5] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_api_video_v1_upload_proto_init() }
@@ -227,7 +441,7 @@ func file_api_video_v1_upload_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_video_v1_upload_proto_rawDesc), len(file_api_video_v1_upload_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
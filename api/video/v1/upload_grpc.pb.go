@@ -20,6 +20,7 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	UploadService_InitResumableUpload_FullMethodName = "/video.v1.UploadService/InitResumableUpload"
+	UploadService_GetMyUploadQuota_FullMethodName    = "/video.v1.UploadService/GetMyUploadQuota"
 )
 
 // UploadServiceClient is the client API for UploadService service.
@@ -30,6 +31,8 @@ const (
 type UploadServiceClient interface {
 	// InitResumableUpload 预留 video_id 并返回一次性的 V4 Signed URL。
 	InitResumableUpload(ctx context.Context, in *InitResumableUploadRequest, opts ...grpc.CallOption) (*InitResumableUploadResponse, error)
+	// GetMyUploadQuota 返回当前用户所属档位的上传配额及剩余额度。
+	GetMyUploadQuota(ctx context.Context, in *GetMyUploadQuotaRequest, opts ...grpc.CallOption) (*GetMyUploadQuotaResponse, error)
 }
 
 type uploadServiceClient struct {
@@ -50,6 +53,16 @@ func (c *uploadServiceClient) InitResumableUpload(ctx context.Context, in *InitR
 	return out, nil
 }
 
+func (c *uploadServiceClient) GetMyUploadQuota(ctx context.Context, in *GetMyUploadQuotaRequest, opts ...grpc.CallOption) (*GetMyUploadQuotaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMyUploadQuotaResponse)
+	err := c.cc.Invoke(ctx, UploadService_GetMyUploadQuota_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UploadServiceServer is the server API for UploadService service.
 // All implementations must embed UnimplementedUploadServiceServer
 // for forward compatibility.
@@ -58,6 +71,8 @@ func (c *uploadServiceClient) InitResumableUpload(ctx context.Context, in *InitR
 type UploadServiceServer interface {
 	// InitResumableUpload 预留 video_id 并返回一次性的 V4 Signed URL。
 	InitResumableUpload(context.Context, *InitResumableUploadRequest) (*InitResumableUploadResponse, error)
+	// GetMyUploadQuota 返回当前用户所属档位的上传配额及剩余额度。
+	GetMyUploadQuota(context.Context, *GetMyUploadQuotaRequest) (*GetMyUploadQuotaResponse, error)
 	mustEmbedUnimplementedUploadServiceServer()
 }
 
@@ -71,6 +86,9 @@ type UnimplementedUploadServiceServer struct{}
 func (UnimplementedUploadServiceServer) InitResumableUpload(context.Context, *InitResumableUploadRequest) (*InitResumableUploadResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method InitResumableUpload not implemented")
 }
+func (UnimplementedUploadServiceServer) GetMyUploadQuota(context.Context, *GetMyUploadQuotaRequest) (*GetMyUploadQuotaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMyUploadQuota not implemented")
+}
 func (UnimplementedUploadServiceServer) mustEmbedUnimplementedUploadServiceServer() {}
 func (UnimplementedUploadServiceServer) testEmbeddedByValue()                       {}
 
@@ -110,6 +128,24 @@ func _UploadService_InitResumableUpload_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UploadService_GetMyUploadQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMyUploadQuotaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UploadServiceServer).GetMyUploadQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UploadService_GetMyUploadQuota_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UploadServiceServer).GetMyUploadQuota(ctx, req.(*GetMyUploadQuotaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UploadService_ServiceDesc is the grpc.ServiceDesc for UploadService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -121,6 +157,10 @@ var UploadService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "InitResumableUpload",
 			Handler:    _UploadService_InitResumableUpload_Handler,
 		},
+		{
+			MethodName: "GetMyUploadQuota",
+			Handler:    _UploadService_GetMyUploadQuota_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/video/v1/upload.proto",
@@ -0,0 +1,35 @@
+// Package main 提供 catalogctl 运维命令行入口，直接连接 Catalog 数据库执行管理操作。
+//
+// `catalogctl -conf <path> outbox <list|show|retry|abandon> [flags]` 查看与处置未发布的 Outbox 事件。
+// catalogctl 以 admin 角色调用服务层：能够读取数据库凭据的运维即视为管理员，授权边界由凭据分发保证。
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	configloader "github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
+)
+
+const usage = "catalogctl -conf <path> " + outboxUsage
+
+func main() {
+	ctx := context.Background()
+
+	confFlag := flag.String("conf", "", "config path or directory, eg: -conf configs/config.yaml")
+	flag.Parse()
+
+	params := configloader.Params{ConfPath: *confFlag}
+	switch flag.Arg(0) {
+	case "outbox":
+		if err := runOutbox(ctx, params, flag.Args()[1:]); err != nil {
+			fmt.Fprintf(os.Stderr, "catalogctl outbox failed: %v\n", err)
+			os.Exit(1)
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usage)
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"os/user"
+	"strings"
+	"syscall"
+
+	configloader "github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
+	"github.com/bionicotaku/lingo-services-catalog/internal/metadata"
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/vo"
+	"github.com/bionicotaku/lingo-services-catalog/internal/services"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+)
+
+const outboxUsage = "outbox list [-status pending|failed|abandoned] [-aggregate-id ID] [-event-type T] [-min-attempts N] [-limit N] | show -event-id ID | retry -event-id ID | abandon -event-id ID -reason R"
+
+type outboxAdminApp struct {
+	Service *services.OutboxAdminService
+	Logger  log.Logger
+}
+
+// runOutbox 执行 outbox 子命令（list/show/retry/abandon），结果以 JSON 写入 stdout。
+func runOutbox(ctx context.Context, params configloader.Params, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing subcommand, usage: %s", outboxUsage)
+	}
+	app, cleanup, err := wireOutboxAdmin(ctx, params)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	runCtx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	return runOutboxCommand(metadata.Inject(runCtx, operatorMetadata()), app.Service, args, os.Stdout)
+}
+
+func runOutboxCommand(ctx context.Context, svc *services.OutboxAdminService, args []string, stdout io.Writer) error {
+	if svc == nil {
+		return fmt.Errorf("outbox admin service not initialized")
+	}
+	enc := json.NewEncoder(stdout)
+	enc.SetIndent("", "  ")
+
+	switch args[0] {
+	case "list":
+		fs := flag.NewFlagSet("outbox list", flag.ContinueOnError)
+		status := fs.String("status", string(vo.OutboxEventPending), "pending, failed or abandoned")
+		aggregateFlag := fs.String("aggregate-id", "", "only list events of this aggregate")
+		eventType := fs.String("event-type", "", "only list events of this type")
+		minAttempts := fs.Int("min-attempts", 0, "only list events with at least N delivery attempts")
+		limit := fs.Int("limit", 50, "max events to list")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		input := services.ListOutboxEventsInput{
+			Status:      vo.OutboxEventStatus(strings.ToLower(strings.TrimSpace(*status))),
+			EventType:   *eventType,
+			MinAttempts: int32(*minAttempts),
+			Limit:       *limit,
+		}
+		if raw := strings.TrimSpace(*aggregateFlag); raw != "" {
+			aggregateID, err := uuid.Parse(raw)
+			if err != nil {
+				return fmt.Errorf("invalid -aggregate-id: %w", err)
+			}
+			input.AggregateID = &aggregateID
+		}
+		events, err := svc.ListOutboxEvents(ctx, input)
+		if err != nil {
+			return err
+		}
+		return enc.Encode(events)
+	case "show":
+		eventID, _, err := parseOutboxFlags("outbox show", args[1:], false)
+		if err != nil {
+			return err
+		}
+		detail, err := svc.GetOutboxEvent(ctx, eventID)
+		if err != nil {
+			return err
+		}
+		return enc.Encode(detail)
+	case "retry":
+		eventID, _, err := parseOutboxFlags("outbox retry", args[1:], false)
+		if err != nil {
+			return err
+		}
+		event, err := svc.RetryOutboxEvent(ctx, eventID)
+		if err != nil {
+			return err
+		}
+		return enc.Encode(event)
+	case "abandon":
+		eventID, reason, err := parseOutboxFlags("outbox abandon", args[1:], true)
+		if err != nil {
+			return err
+		}
+		event, err := svc.AbandonOutboxEvent(ctx, eventID, reason)
+		if err != nil {
+			return err
+		}
+		return enc.Encode(event)
+	default:
+		return fmt.Errorf("unknown subcommand %q, usage: %s", args[0], outboxUsage)
+	}
+}
+
+func parseOutboxFlags(name string, args []string, withReason bool) (uuid.UUID, string, error) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	eventFlag := fs.String("event-id", "", "outbox event_id")
+	var reason *string
+	if withReason {
+		reason = fs.String("reason", "", "why the event is abandoned")
+	}
+	if err := fs.Parse(args); err != nil {
+		return uuid.Nil, "", err
+	}
+	raw := strings.TrimSpace(*eventFlag)
+	if raw == "" {
+		return uuid.Nil, "", fmt.Errorf("-event-id is required")
+	}
+	eventID, err := uuid.Parse(raw)
+	if err != nil {
+		return uuid.Nil, "", fmt.Errorf("invalid -event-id: %w", err)
+	}
+	if reason == nil {
+		return eventID, "", nil
+	}
+	return eventID, strings.TrimSpace(*reason), nil
+}
+
+// operatorMetadata 以当前系统用户标识 catalogctl 调用方并授予 admin 角色，操作日志据此记录执行人。
+func operatorMetadata() metadata.HandlerMetadata {
+	operator := "catalogctl"
+	if current, err := user.Current(); err == nil && current.Username != "" {
+		operator = "catalogctl:" + current.Username
+	}
+	return metadata.HandlerMetadata{
+		UserID:  operator,
+		IsAdmin: true,
+	}
+}
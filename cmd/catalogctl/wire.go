@@ -0,0 +1,41 @@
+//go:build wireinject
+// +build wireinject
+
+// Package main 为 catalogctl 提供 Wire 依赖注入定义。
+package main
+
+import (
+	"context"
+
+	configloader "github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-services-catalog/internal/services"
+
+	"github.com/bionicotaku/lingo-utils/gclog"
+	"github.com/bionicotaku/lingo-utils/pgxpoolx"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/wire"
+)
+
+//go:generate go run github.com/google/wire/cmd/wire
+
+func wireOutboxAdmin(context.Context, configloader.Params) (*outboxAdminApp, func(), error) {
+	panic(wire.Build(
+		configloader.ProviderSet,
+		gclog.ProviderSet,
+		pgxpoolx.ProviderSet,
+		txmanager.ProviderSet,
+		repositories.NewOutboxRepository,
+		wire.Bind(new(services.OutboxAdminRepo), new(*repositories.OutboxRepository)),
+		services.NewOutboxAdminService,
+		newOutboxAdminApp,
+	))
+}
+
+func newOutboxAdminApp(logger log.Logger, svc *services.OutboxAdminService) *outboxAdminApp {
+	return &outboxAdminApp{
+		Service: svc,
+		Logger:  logger,
+	}
+}
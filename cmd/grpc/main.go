@@ -10,9 +10,9 @@ import (
 
 	configloader "github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
 	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/engagement"
+	outboxrunner "github.com/bionicotaku/lingo-services-catalog/internal/tasks/outbox"
 	uploadrunner "github.com/bionicotaku/lingo-services-catalog/internal/tasks/uploads"
 	obswire "github.com/bionicotaku/lingo-utils/observability"
-	outboxpublisher "github.com/bionicotaku/lingo-utils/outbox/publisher"
 	"github.com/go-kratos/kratos/v2"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/go-kratos/kratos/v2/transport/grpc"
@@ -34,7 +34,7 @@ func newApp(
 	logger log.Logger,
 	gs *grpc.Server,
 	meta configloader.ServiceInfo,
-	publisher *outboxpublisher.Runner,
+	publisher *outboxrunner.Runner,
 	engagementRunner *engagement.Runner,
 	uploadsRunner *uploadrunner.Runner,
 ) *kratos.App {
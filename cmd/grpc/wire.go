@@ -11,7 +11,9 @@ import (
 	"context"
 
 	"github.com/bionicotaku/lingo-services-catalog/internal/controllers"
+	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/cdn"
 	configloader "github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
+	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/eventsink"
 	gcssigner "github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/gcs"
 	grpcserver "github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/grpc_server"
 	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
@@ -52,6 +54,9 @@ func wireApp(context.Context, configloader.Params) (*kratos.App, func(), error)
 		gcpubsub.ProviderSet,   // Pub/Sub 发布与订阅
 		grpcserver.ProviderSet, // gRPC Server
 		gcssigner.ProvideResumableSigner,
+		gcssigner.ProvideObjectReader,
+		cdn.ProvidePlaybackSigner,   // 播放地址签名
+		eventsink.ProvideOutboxSink, // Outbox 发布端（按 topic 选择 Pub/Sub / Kafka / NATS / Webhook）
 		// grpcclient.ProviderSet, // 暂时不使用, 未来需要调用外部 gRPC 服务时再启用
 		// clients.ProviderSet,    // 暂时不使用, 未来需要调用外部服务时再启用
 		repositories.ProviderSet, // 数据访问层（sqlc）
@@ -59,9 +64,13 @@ func wireApp(context.Context, configloader.Params) (*kratos.App, func(), error)
 		wire.Bind(new(services.VideoQueryRepo), new(*repositories.VideoRepository)), // 读仓储绑定
 		wire.Bind(new(services.OriginalMediaRepo), new(*repositories.VideoRepository)),
 		wire.Bind(new(services.VideoLookupRepo), new(*repositories.VideoRepository)),
+		wire.Bind(new(services.PlaybackRepo), new(*repositories.VideoRepository)),
 		wire.Bind(new(services.LifecycleOutboxWriter), new(*repositories.OutboxRepository)),
+		wire.Bind(new(services.OutboxAdminRepo), new(*repositories.OutboxRepository)),
 		wire.Bind(new(services.UploadRepositoryContract), new(*repositories.UploadRepository)),
+		wire.Bind(new(services.UploadQuotaRepo), new(*repositories.UploadRepository)),
 		wire.Bind(new(services.UploadSigner), new(*gcssigner.ResumableSigner)),
+		wire.Bind(new(uploadtasks.ObjectReader), new(*gcssigner.ObjectReader)),
 		services.ProviderSet,    // 业务逻辑层
 		controllers.ProviderSet, // 控制器层（gRPC handlers）
 		outboxtasks.ProvideRunner,
@@ -190,6 +199,9 @@ func wireApp(context.Context, configloader.Params) (*kratos.App, func(), error)
 //                                      *repositories.VideoRepository
 //       构造视频仓储层，使用 sqlc 生成的查询方法。
 //
+//   - cdn.ProvidePlaybackSigner(configloader.PlaybackConfig, log.Logger) (services.PlaybackSigner, error)
+//       按 playback.signer 构造播放地址签名器（当前仅 HMAC）。
+//
 //   - services.NewLifecycleWriter(services.LifecycleRepo, services.LifecycleOutboxWriter, txmanager.Manager, log.Logger)
 //                               *services.LifecycleWriter
 //   - services.NewRegisterUploadService(*services.LifecycleWriter) *services.RegisterUploadService
@@ -203,11 +215,14 @@ func wireApp(context.Context, configloader.Params) (*kratos.App, func(), error)
 //       *services.VisibilityService) *services.LifecycleService
 //   - services.NewVideoQueryService(services.VideoQueryRepo, txmanager.Manager, log.Logger)
 //                               *services.VideoQueryService
+//   - services.NewPlaybackService(services.PlaybackRepo, services.PlaybackSigner, services.PlaybackPolicy,
+//       txmanager.Manager, log.Logger) *services.PlaybackService
 //       组装视频业务用例，协调仓储访问及 Outbox 写入。
 //       注: VideoRepo / OutboxRepo 接口通过 wire.Bind 绑定到对应 Repository 实现。
 //
 //   - controllers.NewLifecycleHandler(*services.LifecycleService, *controllers.BaseHandler) *controllers.LifecycleHandler
-//   - controllers.NewVideoQueryHandler(*services.VideoQueryService, *controllers.BaseHandler) *controllers.VideoQueryHandler
+//   - controllers.NewVideoQueryHandler(*services.VideoQueryService, *services.PlaybackService,
+//       *controllers.BaseHandler) *controllers.VideoQueryHandler
 //       构造视频控制层，为 gRPC handler 提供入口。
 //
 // ┌─────────────────────────────────────────────────────────────────────────┐
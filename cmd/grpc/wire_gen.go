@@ -9,7 +9,9 @@ package main
 import (
 	"context"
 	"github.com/bionicotaku/lingo-services-catalog/internal/controllers"
+	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/cdn"
 	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
+	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/eventsink"
 	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/gcs"
 	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/grpc_server"
 	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
@@ -113,9 +115,23 @@ func wireApp(contextContext context.Context, params configloader.Params) (*krato
 	baseHandler := controllers.NewBaseHandler(handlerTimeouts)
 	lifecycleHandler := controllers.NewLifecycleHandler(lifecycleService, baseHandler)
 	videoUserStatesRepository := repositories.NewVideoUserStatesRepository(pool, logger)
-	videoEngagementStatsRepository := repositories.NewVideoEngagementStatsRepository(pool, logger)
+	countersConfig := configloader.ProvideCountersConfig(runtimeConfig)
+	statsShardPolicy := configloader.ProvideStatsShardPolicy(countersConfig)
+	videoEngagementStatsRepository := repositories.NewVideoEngagementStatsRepository(pool, logger, statsShardPolicy)
 	videoQueryService := services.NewVideoQueryService(videoRepository, videoUserStatesRepository, videoEngagementStatsRepository, manager, logger)
-	videoQueryHandler := controllers.NewVideoQueryHandler(videoQueryService, baseHandler)
+	playbackConfig := configloader.ProvidePlaybackConfig(runtimeConfig)
+	playbackSigner, err := cdn.ProvidePlaybackSigner(playbackConfig, logger)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	playbackPolicy := configloader.ProvidePlaybackPolicy(playbackConfig)
+	playbackService := services.NewPlaybackService(videoRepository, playbackSigner, playbackPolicy, manager, logger)
+	videoQueryHandler := controllers.NewVideoQueryHandler(videoQueryService, playbackService, baseHandler)
 	uploadRepository := repositories.NewUploadRepository(pool, logger)
 	gcsConfig := configloader.ProvideGCSConfig(runtimeConfig)
 	resumableSigner, err := gcs.ProvideResumableSigner(contextContext, gcsConfig, logger)
@@ -127,9 +143,11 @@ func wireApp(contextContext context.Context, params configloader.Params) (*krato
 		cleanup()
 		return nil, nil, err
 	}
+	uploadQuotaPolicy := configloader.ProvideUploadQuotaPolicy(runtimeConfig)
+	uploadQuota := services.NewUploadQuota(uploadRepository, manager, uploadQuotaPolicy, logger)
 	string2 := gcsConfig.Bucket
 	duration := gcsConfig.SignedURLTTL
-	uploadService, err := services.NewUploadService(uploadRepository, resumableSigner, string2, duration, logger)
+	uploadService, err := services.NewUploadService(uploadRepository, resumableSigner, uploadQuota, string2, duration, logger)
 	if err != nil {
 		cleanup5()
 		cleanup4()
@@ -139,7 +157,9 @@ func wireApp(contextContext context.Context, params configloader.Params) (*krato
 		return nil, nil, err
 	}
 	uploadHandler := controllers.NewUploadHandler(baseHandler, uploadService)
-	server := grpcserver.NewGRPCServer(serverConfig, metricsConfig, serverMiddleware, lifecycleHandler, videoQueryHandler, uploadHandler, logger)
+	outboxAdminService := services.NewOutboxAdminService(outboxRepository, manager, logger)
+	outboxAdminHandler := controllers.NewOutboxAdminHandler(baseHandler, outboxAdminService)
+	server := grpcserver.NewGRPCServer(serverConfig, metricsConfig, serverMiddleware, lifecycleHandler, videoQueryHandler, uploadHandler, outboxAdminHandler, logger)
 	gcpubsubConfig := configloader.ProvidePubSubConfig(messagingConfig)
 	dependencies := configloader.ProvidePubSubDependencies(logger)
 	gcpubsubComponent, cleanup6, err := gcpubsub.NewComponent(contextContext, gcpubsubConfig, dependencies)
@@ -152,11 +172,75 @@ func wireApp(contextContext context.Context, params configloader.Params) (*krato
 		return nil, nil, err
 	}
 	publisher := gcpubsub.ProvidePublisher(gcpubsubComponent)
-	runner := outbox.ProvideRunner(outboxRepository, publisher, gcpubsubConfig, configConfig, logger)
+	webhookDeliveryRepository := repositories.NewWebhookDeliveryRepository(pool, logger)
+	outboxTopicDeliveryRepository := repositories.NewOutboxTopicDeliveryRepository(pool, logger)
+	sink, cleanup7, err := eventsink.ProvideOutboxSink(contextContext, messagingConfig, publisher, dependencies, webhookDeliveryRepository, outboxTopicDeliveryRepository, logger)
+	if err != nil {
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	runner := outbox.ProvideRunner(outboxRepository, pool, sink, configConfig, logger)
 	inboxRepository := repositories.NewInboxRepository(pool, logger, configConfig)
+	inboxQuarantineRepository := repositories.NewInboxQuarantineRepository(pool, logger)
 	engagementPubSubConfig := configloader.ProvideEngagementConfig(messagingConfig)
-	engagementSubscriber, cleanup7, err := configloader.ProvideEngagementSubscriber(contextContext, engagementPubSubConfig, dependencies)
+	engagementSubscriber, cleanup8, err := configloader.ProvideEngagementSubscriber(contextContext, engagementPubSubConfig, dependencies)
+	if err != nil {
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	engagementPurgeRepository := repositories.NewEngagementPurgeRepository(pool, logger)
+	videoEventsPubSubConfig := configloader.ProvideVideoEventsConfig(messagingConfig)
+	videoEventsSubscriber, cleanup9, err := configloader.ProvideVideoEventsSubscriber(contextContext, videoEventsPubSubConfig, dependencies)
+	if err != nil {
+		cleanup8()
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	profileUserPubSubConfig := configloader.ProvideProfileUserConfig(messagingConfig)
+	profileUserSubscriber, cleanup10, err := configloader.ProvideProfileUserSubscriber(contextContext, profileUserPubSubConfig, dependencies)
+	if err != nil {
+		cleanup9()
+		cleanup8()
+		cleanup7()
+		cleanup6()
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	viewQualificationConfig := configloader.ProvideViewQualificationConfig(runtimeConfig)
+	rollupRetentionConfig := configloader.ProvideRollupRetentionConfig(runtimeConfig)
+	trendingConfig := configloader.ProvideTrendingConfig(runtimeConfig)
+	purgeConfig := configloader.ProvidePurgeConfig(runtimeConfig)
+	batchConfig := configloader.ProvideBatchConfig(runtimeConfig)
+	advisoryLockRepository := repositories.NewAdvisoryLockRepository(pool, logger)
+	engagementRunner := engagement.ProvideRunner(videoUserStatesRepository, videoEngagementStatsRepository, engagementPurgeRepository, inboxRepository, inboxQuarantineRepository, advisoryLockRepository, manager, engagementSubscriber, videoEventsSubscriber, profileUserSubscriber, configConfig, viewQualificationConfig, rollupRetentionConfig, trendingConfig, purgeConfig, countersConfig, batchConfig, logger)
+	rawAssetRepository := repositories.NewRawAssetRepository(pool, logger)
+	objectReader, cleanup11, err := gcs.ProvideObjectReader(contextContext, logger)
 	if err != nil {
+		cleanup10()
+		cleanup9()
+		cleanup8()
+		cleanup7()
 		cleanup6()
 		cleanup5()
 		cleanup4()
@@ -165,10 +249,13 @@ func wireApp(contextContext context.Context, params configloader.Params) (*krato
 		cleanup()
 		return nil, nil, err
 	}
-	engagementRunner := engagement.ProvideRunner(videoUserStatesRepository, videoEngagementStatsRepository, inboxRepository, manager, engagementSubscriber, configConfig, logger)
 	uploadPubSubConfig := configloader.ProvideUploadConfig(messagingConfig)
-	uploadSubscriber, cleanup8, err := configloader.ProvideUploadSubscriber(contextContext, uploadPubSubConfig, dependencies)
+	uploadSubscriber, cleanup12, err := configloader.ProvideUploadSubscriber(contextContext, uploadPubSubConfig, dependencies)
 	if err != nil {
+		cleanup11()
+		cleanup10()
+		cleanup9()
+		cleanup8()
 		cleanup7()
 		cleanup6()
 		cleanup5()
@@ -178,9 +265,13 @@ func wireApp(contextContext context.Context, params configloader.Params) (*krato
 		cleanup()
 		return nil, nil, err
 	}
-	uploadsRunner := uploads.ProvideRunner(uploadRepository, inboxRepository, lifecycleWriter, manager, uploadSubscriber, configConfig, logger)
+	uploadsRunner := uploads.ProvideRunner(uploadRepository, rawAssetRepository, inboxRepository, inboxQuarantineRepository, lifecycleWriter, objectReader, manager, uploadSubscriber, configConfig, logger)
 	app := newApp(observabilityComponent, logger, server, serviceInfo, runner, engagementRunner, uploadsRunner)
 	return app, func() {
+		cleanup12()
+		cleanup11()
+		cleanup10()
+		cleanup9()
 		cleanup8()
 		cleanup7()
 		cleanup6()
@@ -1,10 +1,15 @@
 // Package main 提供 Engagement Runner 独立进程入口。
+//
+// 默认启动消费循环；`engagement -conf <path> replay [flags]` 从 catalog.inbox_events 历史重建投影，
+// `engagement -conf <path> reconcile [flags]` 按明细表校正统计投影计数，
+// `engagement -conf <path> quarantine <list|show|replay|discard> [flags]` 处置隔离的毒消息。
 package main
 
 import (
 	"context"
 	"errors"
 	"flag"
+	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
@@ -29,6 +34,28 @@ func main() {
 	flag.Parse()
 
 	params := configloader.Params{ConfPath: *confFlag}
+	if flag.Arg(0) == "replay" {
+		if err := runReplay(ctx, params, flag.Args()[1:]); err != nil {
+			fmt.Fprintf(os.Stderr, "engagement replay failed: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if flag.Arg(0) == "reconcile" {
+		if err := runReconcile(ctx, params, flag.Args()[1:]); err != nil {
+			fmt.Fprintf(os.Stderr, "engagement reconcile failed: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if flag.Arg(0) == "quarantine" {
+		if err := runQuarantine(ctx, params, flag.Args()[1:]); err != nil {
+			fmt.Fprintf(os.Stderr, "engagement quarantine failed: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	app, cleanup, err := wireEngagementTask(ctx, params)
 	if err != nil {
 		panic(err)
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	configloader "github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/quarantine"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+type quarantineApp struct {
+	Admin  *quarantine.Admin
+	Logger log.Logger
+}
+
+// runQuarantine 执行 quarantine 子命令（list/show/replay/discard），处置 Engagement 消费者隔离的事件。
+func runQuarantine(ctx context.Context, params configloader.Params, args []string) error {
+	app, cleanup, err := wireEngagementQuarantine(ctx, params)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	runCtx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	return quarantine.RunCommand(runCtx, app.Admin, args, os.Stdout)
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	configloader "github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/engagement"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+)
+
+type reconcileApp struct {
+	Reconciler *engagement.Reconciler
+	Logger     log.Logger
+}
+
+// runReconcile 解析 reconcile 子命令参数，校正统计投影计数漂移，并将报告以 JSON 输出到 stdout。
+func runReconcile(ctx context.Context, params configloader.Params, args []string) error {
+	fs := flag.NewFlagSet("reconcile", flag.ContinueOnError)
+	videoFlag := fs.String("video-id", "", "only reconcile this video_id")
+	sinceFlag := fs.String("since", "", "only reconcile videos updated since an RFC3339 time or a duration ago (eg: 24h)")
+	batchSize := fs.Int("batch", 200, "videos scanned per page and repaired per transaction")
+	dryRun := fs.Bool("dry-run", false, "report drift without repairing")
+	samples := fs.Int("samples", 20, "max drift samples in the report")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	opts := engagement.ReconcileOptions{
+		BatchSize:   *batchSize,
+		DryRun:      *dryRun,
+		SampleLimit: *samples,
+	}
+	if raw := strings.TrimSpace(*videoFlag); raw != "" {
+		videoID, err := uuid.Parse(raw)
+		if err != nil {
+			return fmt.Errorf("invalid -video-id: %w", err)
+		}
+		opts.VideoID = &videoID
+	}
+	if raw := strings.TrimSpace(*sinceFlag); raw != "" {
+		since, err := parseSince(raw, time.Now())
+		if err != nil {
+			return err
+		}
+		opts.Since = &since
+	}
+
+	app, cleanup, err := wireEngagementReconcile(ctx, params)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	helper := log.NewHelper(app.Logger)
+	helper.Infof("starting engagement stats reconcile: dry_run=%t", opts.DryRun)
+
+	runCtx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	report, runErr := app.Reconciler.Run(runCtx, opts)
+	if report != nil {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			helper.Warnf("encode reconcile report failed: %v", err)
+		}
+	}
+	return runErr
+}
+
+// parseSince 接受 RFC3339 时间或相对 now 的时长。
+func parseSince(raw string, now time.Time) (time.Time, error) {
+	if ts, err := time.Parse(time.RFC3339, raw); err == nil {
+		return ts, nil
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil || d <= 0 {
+		return time.Time{}, fmt.Errorf("invalid -since %q: want RFC3339 time or positive duration", raw)
+	}
+	return now.Add(-d), nil
+}
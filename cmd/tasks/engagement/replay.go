@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	configloader "github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/engagement"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+)
+
+type replayApp struct {
+	Replayer *engagement.Replayer
+	Logger   log.Logger
+}
+
+// runReplay 解析 replay 子命令参数，从 Inbox 历史重建 Engagement 投影，并将报告以 JSON 输出到 stdout。
+func runReplay(ctx context.Context, params configloader.Params, args []string) error {
+	fs := flag.NewFlagSet("replay", flag.ContinueOnError)
+	replayID := fs.String("id", "engagement-replay", "checkpoint id; rerun with the same id to resume")
+	userFlag := fs.String("user", "", "only replay events of this user_id (stats are left untouched)")
+	videoFlag := fs.String("video", "", "only replay events of this video_id")
+	batchSize := fs.Int("batch", 500, "events applied per transaction")
+	dryRun := fs.Bool("dry-run", false, "replay in memory and report the diff against current projections")
+	restart := fs.Bool("restart", false, "discard the existing checkpoint and rebuild from scratch")
+	samples := fs.Int("samples", 20, "max diff samples per table in dry-run mode")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	opts := engagement.ReplayOptions{
+		ReplayID:    *replayID,
+		BatchSize:   *batchSize,
+		DryRun:      *dryRun,
+		Restart:     *restart,
+		SampleLimit: *samples,
+	}
+	var err error
+	if opts.Scope, err = parseReplayScope(*userFlag, *videoFlag); err != nil {
+		return err
+	}
+
+	app, cleanup, err := wireEngagementReplay(ctx, params)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	helper := log.NewHelper(app.Logger)
+	helper.Infof("starting engagement replay: id=%s dry_run=%t restart=%t", opts.ReplayID, opts.DryRun, opts.Restart)
+
+	runCtx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	report, runErr := app.Replayer.Run(runCtx, opts)
+	if report != nil {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			helper.Warnf("encode replay report failed: %v", err)
+		}
+	}
+	return runErr
+}
+
+func parseReplayScope(userRaw, videoRaw string) (repositories.ReplayScope, error) {
+	var scope repositories.ReplayScope
+	if userRaw = strings.TrimSpace(userRaw); userRaw != "" {
+		userID, err := uuid.Parse(userRaw)
+		if err != nil {
+			return scope, fmt.Errorf("invalid -user: %w", err)
+		}
+		scope.UserID = &userID
+	}
+	if videoRaw = strings.TrimSpace(videoRaw); videoRaw != "" {
+		videoID, err := uuid.Parse(videoRaw)
+		if err != nil {
+			return scope, fmt.Errorf("invalid -video: %w", err)
+		}
+		scope.VideoID = &videoID
+	}
+	return scope, nil
+}
@@ -10,6 +10,7 @@ import (
 	configloader "github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
 	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
 	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/engagement"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/quarantine"
 
 	"github.com/bionicotaku/lingo-utils/gclog"
 	"github.com/bionicotaku/lingo-utils/pgxpoolx"
@@ -32,6 +33,42 @@ func wireEngagementTask(context.Context, configloader.Params) (*engagementApp, f
 	))
 }
 
+func wireEngagementReplay(context.Context, configloader.Params) (*replayApp, func(), error) {
+	panic(wire.Build(
+		configloader.ProviderSet,
+		gclog.ProviderSet,
+		pgxpoolx.ProviderSet,
+		txmanager.ProviderSet,
+		repositories.ProviderSet,
+		engagement.ProvideReplayer,
+		newReplayApp,
+	))
+}
+
+func wireEngagementReconcile(context.Context, configloader.Params) (*reconcileApp, func(), error) {
+	panic(wire.Build(
+		configloader.ProviderSet,
+		gclog.ProviderSet,
+		pgxpoolx.ProviderSet,
+		txmanager.ProviderSet,
+		repositories.ProviderSet,
+		engagement.ProvideReconciler,
+		newReconcileApp,
+	))
+}
+
+func wireEngagementQuarantine(context.Context, configloader.Params) (*quarantineApp, func(), error) {
+	panic(wire.Build(
+		configloader.ProviderSet,
+		gclog.ProviderSet,
+		pgxpoolx.ProviderSet,
+		txmanager.ProviderSet,
+		repositories.ProviderSet,
+		engagement.ProvideQuarantineAdmin,
+		newQuarantineApp,
+	))
+}
+
 func newEngagementApp(logger log.Logger, runner *engagement.Runner) (*engagementApp, error) {
 	if runner == nil {
 		return &engagementApp{Logger: logger}, nil
@@ -44,3 +81,24 @@ func newEngagementApp(logger log.Logger, runner *engagement.Runner) (*engagement
 		Logger: logger,
 	}, nil
 }
+
+func newReplayApp(logger log.Logger, replayer *engagement.Replayer) *replayApp {
+	return &replayApp{
+		Replayer: replayer,
+		Logger:   logger,
+	}
+}
+
+func newReconcileApp(logger log.Logger, reconciler *engagement.Reconciler) *reconcileApp {
+	return &reconcileApp{
+		Reconciler: reconciler,
+		Logger:     logger,
+	}
+}
+
+func newQuarantineApp(logger log.Logger, admin *quarantine.Admin) *quarantineApp {
+	return &quarantineApp{
+		Admin:  admin,
+		Logger: logger,
+	}
+}
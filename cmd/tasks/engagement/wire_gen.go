@@ -12,6 +12,7 @@ import (
 	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
 	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
 	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/engagement"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/quarantine"
 	"github.com/bionicotaku/lingo-utils/gclog"
 	"github.com/bionicotaku/lingo-utils/pgxpoolx"
 	"github.com/bionicotaku/lingo-utils/txmanager"
@@ -41,10 +42,13 @@ func wireEngagementTask(contextContext context.Context, params configloader.Para
 	}
 	pool := pgxpoolx.ProvidePool(pgxpoolxComponent)
 	videoUserStatesRepository := repositories.NewVideoUserStatesRepository(pool, logger)
-	videoEngagementStatsRepository := repositories.NewVideoEngagementStatsRepository(pool, logger)
+	countersConfig := configloader.ProvideCountersConfig(runtimeConfig)
+	statsShardPolicy := configloader.ProvideStatsShardPolicy(countersConfig)
+	videoEngagementStatsRepository := repositories.NewVideoEngagementStatsRepository(pool, logger, statsShardPolicy)
 	messagingConfig := configloader.ProvideMessagingConfig(runtimeConfig)
 	configConfig := configloader.ProvideOutboxConfig(messagingConfig)
 	inboxRepository := repositories.NewInboxRepository(pool, logger, configConfig)
+	inboxQuarantineRepository := repositories.NewInboxQuarantineRepository(pool, logger)
 	txmanagerConfig := configloader.ProvideTxConfig(runtimeConfig)
 	txmanagerComponent, cleanup3, err := txmanager.NewComponent(txmanagerConfig, pool, logger)
 	if err != nil {
@@ -62,9 +66,37 @@ func wireEngagementTask(contextContext context.Context, params configloader.Para
 		cleanup()
 		return nil, nil, err
 	}
-	runner := engagement.ProvideRunner(videoUserStatesRepository, videoEngagementStatsRepository, inboxRepository, manager, engagementSubscriber, configConfig, logger)
+	engagementPurgeRepository := repositories.NewEngagementPurgeRepository(pool, logger)
+	videoEventsPubSubConfig := configloader.ProvideVideoEventsConfig(messagingConfig)
+	videoEventsSubscriber, cleanup5, err := configloader.ProvideVideoEventsSubscriber(contextContext, videoEventsPubSubConfig, dependencies)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	profileUserPubSubConfig := configloader.ProvideProfileUserConfig(messagingConfig)
+	profileUserSubscriber, cleanup6, err := configloader.ProvideProfileUserSubscriber(contextContext, profileUserPubSubConfig, dependencies)
+	if err != nil {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	viewQualificationConfig := configloader.ProvideViewQualificationConfig(runtimeConfig)
+	rollupRetentionConfig := configloader.ProvideRollupRetentionConfig(runtimeConfig)
+	trendingConfig := configloader.ProvideTrendingConfig(runtimeConfig)
+	purgeConfig := configloader.ProvidePurgeConfig(runtimeConfig)
+	batchConfig := configloader.ProvideBatchConfig(runtimeConfig)
+	advisoryLockRepository := repositories.NewAdvisoryLockRepository(pool, logger)
+	runner := engagement.ProvideRunner(videoUserStatesRepository, videoEngagementStatsRepository, engagementPurgeRepository, inboxRepository, inboxQuarantineRepository, advisoryLockRepository, manager, engagementSubscriber, videoEventsSubscriber, profileUserSubscriber, configConfig, viewQualificationConfig, rollupRetentionConfig, trendingConfig, purgeConfig, countersConfig, batchConfig, logger)
 	mainEngagementApp, err := newEngagementApp(logger, runner)
 	if err != nil {
+		cleanup6()
+		cleanup5()
 		cleanup4()
 		cleanup3()
 		cleanup2()
@@ -72,6 +104,8 @@ func wireEngagementTask(contextContext context.Context, params configloader.Para
 		return nil, nil, err
 	}
 	return mainEngagementApp, func() {
+		cleanup6()
+		cleanup5()
 		cleanup4()
 		cleanup3()
 		cleanup2()
@@ -79,6 +113,153 @@ func wireEngagementTask(contextContext context.Context, params configloader.Para
 	}, nil
 }
 
+func wireEngagementReplay(contextContext context.Context, params configloader.Params) (*replayApp, func(), error) {
+	runtimeConfig, err := configloader.LoadRuntimeConfig(params)
+	if err != nil {
+		return nil, nil, err
+	}
+	serviceInfo := configloader.ProvideServiceInfo(runtimeConfig)
+	config := configloader.ProvideLoggerConfig(serviceInfo)
+	component, cleanup, err := gclog.NewComponent(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	logger := gclog.ProvideLogger(component)
+	databaseConfig := configloader.ProvideDatabaseConfig(runtimeConfig)
+	pgxpoolxConfig := configloader.ProvidePgxConfig(databaseConfig)
+	pgxpoolxComponent, cleanup2, err := pgxpoolx.ProvideComponent(contextContext, pgxpoolxConfig, logger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	pool := pgxpoolx.ProvidePool(pgxpoolxComponent)
+	videoUserStatesRepository := repositories.NewVideoUserStatesRepository(pool, logger)
+	countersConfig := configloader.ProvideCountersConfig(runtimeConfig)
+	statsShardPolicy := configloader.ProvideStatsShardPolicy(countersConfig)
+	videoEngagementStatsRepository := repositories.NewVideoEngagementStatsRepository(pool, logger, statsShardPolicy)
+	engagementReplayRepository := repositories.NewEngagementReplayRepository(pool, logger)
+	engagementPurgeRepository := repositories.NewEngagementPurgeRepository(pool, logger)
+	txmanagerConfig := configloader.ProvideTxConfig(runtimeConfig)
+	txmanagerComponent, cleanup3, err := txmanager.NewComponent(txmanagerConfig, pool, logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	manager := txmanager.ProvideManager(txmanagerComponent)
+	viewQualificationConfig := configloader.ProvideViewQualificationConfig(runtimeConfig)
+	advisoryLockRepository := repositories.NewAdvisoryLockRepository(pool, logger)
+	replayer, err := engagement.ProvideReplayer(videoUserStatesRepository, videoEngagementStatsRepository, engagementReplayRepository, engagementPurgeRepository, advisoryLockRepository, manager, viewQualificationConfig, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	mainReplayApp := newReplayApp(logger, replayer)
+	return mainReplayApp, func() {
+		cleanup3()
+		cleanup2()
+		cleanup()
+	}, nil
+}
+
+func wireEngagementReconcile(contextContext context.Context, params configloader.Params) (*reconcileApp, func(), error) {
+	runtimeConfig, err := configloader.LoadRuntimeConfig(params)
+	if err != nil {
+		return nil, nil, err
+	}
+	serviceInfo := configloader.ProvideServiceInfo(runtimeConfig)
+	config := configloader.ProvideLoggerConfig(serviceInfo)
+	component, cleanup, err := gclog.NewComponent(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	logger := gclog.ProvideLogger(component)
+	databaseConfig := configloader.ProvideDatabaseConfig(runtimeConfig)
+	pgxpoolxConfig := configloader.ProvidePgxConfig(databaseConfig)
+	pgxpoolxComponent, cleanup2, err := pgxpoolx.ProvideComponent(contextContext, pgxpoolxConfig, logger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	pool := pgxpoolx.ProvidePool(pgxpoolxComponent)
+	countersConfig := configloader.ProvideCountersConfig(runtimeConfig)
+	statsShardPolicy := configloader.ProvideStatsShardPolicy(countersConfig)
+	videoEngagementStatsRepository := repositories.NewVideoEngagementStatsRepository(pool, logger, statsShardPolicy)
+	txmanagerConfig := configloader.ProvideTxConfig(runtimeConfig)
+	txmanagerComponent, cleanup3, err := txmanager.NewComponent(txmanagerConfig, pool, logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	manager := txmanager.ProvideManager(txmanagerComponent)
+	reconciler, err := engagement.ProvideReconciler(videoEngagementStatsRepository, manager, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	mainReconcileApp := newReconcileApp(logger, reconciler)
+	return mainReconcileApp, func() {
+		cleanup3()
+		cleanup2()
+		cleanup()
+	}, nil
+}
+
+func wireEngagementQuarantine(contextContext context.Context, params configloader.Params) (*quarantineApp, func(), error) {
+	runtimeConfig, err := configloader.LoadRuntimeConfig(params)
+	if err != nil {
+		return nil, nil, err
+	}
+	serviceInfo := configloader.ProvideServiceInfo(runtimeConfig)
+	config := configloader.ProvideLoggerConfig(serviceInfo)
+	component, cleanup, err := gclog.NewComponent(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	logger := gclog.ProvideLogger(component)
+	databaseConfig := configloader.ProvideDatabaseConfig(runtimeConfig)
+	pgxpoolxConfig := configloader.ProvidePgxConfig(databaseConfig)
+	pgxpoolxComponent, cleanup2, err := pgxpoolx.ProvideComponent(contextContext, pgxpoolxConfig, logger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	pool := pgxpoolx.ProvidePool(pgxpoolxComponent)
+	videoUserStatesRepository := repositories.NewVideoUserStatesRepository(pool, logger)
+	countersConfig := configloader.ProvideCountersConfig(runtimeConfig)
+	statsShardPolicy := configloader.ProvideStatsShardPolicy(countersConfig)
+	videoEngagementStatsRepository := repositories.NewVideoEngagementStatsRepository(pool, logger, statsShardPolicy)
+	engagementPurgeRepository := repositories.NewEngagementPurgeRepository(pool, logger)
+	inboxQuarantineRepository := repositories.NewInboxQuarantineRepository(pool, logger)
+	txmanagerConfig := configloader.ProvideTxConfig(runtimeConfig)
+	txmanagerComponent, cleanup3, err := txmanager.NewComponent(txmanagerConfig, pool, logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	manager := txmanager.ProvideManager(txmanagerComponent)
+	viewQualificationConfig := configloader.ProvideViewQualificationConfig(runtimeConfig)
+	admin, err := engagement.ProvideQuarantineAdmin(videoUserStatesRepository, videoEngagementStatsRepository, engagementPurgeRepository, inboxQuarantineRepository, manager, viewQualificationConfig, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	mainQuarantineApp := newQuarantineApp(logger, admin)
+	return mainQuarantineApp, func() {
+		cleanup3()
+		cleanup2()
+		cleanup()
+	}, nil
+}
+
 // wire.go:
 
 func newEngagementApp(logger log.Logger, runner *engagement.Runner) (*engagementApp, error) {
@@ -93,3 +274,24 @@ func newEngagementApp(logger log.Logger, runner *engagement.Runner) (*engagement
 		Logger: logger,
 	}, nil
 }
+
+func newReplayApp(logger log.Logger, replayer *engagement.Replayer) *replayApp {
+	return &replayApp{
+		Replayer: replayer,
+		Logger:   logger,
+	}
+}
+
+func newReconcileApp(logger log.Logger, reconciler *engagement.Reconciler) *reconcileApp {
+	return &reconcileApp{
+		Reconciler: reconciler,
+		Logger:     logger,
+	}
+}
+
+func newQuarantineApp(logger log.Logger, admin *quarantine.Admin) *quarantineApp {
+	return &quarantineApp{
+		Admin:  admin,
+		Logger: logger,
+	}
+}
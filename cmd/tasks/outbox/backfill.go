@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	configloader "github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/backfill"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+)
+
+// backfillTarget 为回填发布目标在 messaging.topics 下的键。
+type backfillTarget string
+
+type backfillApp struct {
+	Backfiller *backfill.Backfiller
+	Target     backfillTarget
+	Logger     log.Logger
+}
+
+// runBackfill 解析 backfill 子命令参数，把范围内视频的当前状态作为快照事件发布到指定 topic，并将报告以 JSON 输出到 stdout。
+func runBackfill(ctx context.Context, params configloader.Params, args []string) error {
+	fs := flag.NewFlagSet("backfill", flag.ContinueOnError)
+	backfillID := fs.String("id", "catalog-backfill", "checkpoint id, also sent as the backfill_id attribute; rerun with the same id to resume")
+	topic := fs.String("topic", "", "required: target key under messaging.topics (its sink settings decide pubsub/kafka/nats/webhook)")
+	statusFlag := fs.String("status", "", "only backfill videos in these statuses, comma separated (eg: ready,published)")
+	visibility := fs.String("visibility", "", "only backfill videos with this visibility (public, unlisted or private)")
+	uploaderFlag := fs.String("uploader", "", "only backfill videos of this upload_user_id")
+	pageSize := fs.Int("page", 500, "videos scanned per page; the checkpoint advances after each page")
+	ratePerSec := fs.Float64("rate", 100, "max snapshot events published per second, 0 for unlimited")
+	concurrency := fs.Int("concurrency", 8, "events published in parallel within a page")
+	maxAttempts := fs.Int("max-attempts", 5, "publish attempts per event before the run aborts (rerun to resume)")
+	dryRun := fs.Bool("dry-run", false, "only count the videos in scope, publish nothing")
+	restart := fs.Bool("restart", false, "discard the existing checkpoint and backfill from the first video")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	target := strings.TrimSpace(*topic)
+	if target == "" {
+		return fmt.Errorf("-topic is required")
+	}
+	opts := backfill.Options{
+		BackfillID:  *backfillID,
+		Target:      target,
+		PageSize:    *pageSize,
+		Rate:        *ratePerSec,
+		Concurrency: *concurrency,
+		MaxAttempts: *maxAttempts,
+		DryRun:      *dryRun,
+		Restart:     *restart,
+	}
+	var err error
+	if opts.Scope, err = parseBackfillScope(*statusFlag, *visibility, *uploaderFlag); err != nil {
+		return err
+	}
+
+	app, cleanup, err := wireOutboxBackfill(ctx, params, backfillTarget(target))
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	helper := log.NewHelper(app.Logger)
+	helper.Infof("starting event backfill: id=%s topic=%s dry_run=%t restart=%t rate=%.0f/s",
+		opts.BackfillID, opts.Target, opts.DryRun, opts.Restart, opts.Rate)
+
+	runCtx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	report, runErr := app.Backfiller.Run(runCtx, opts)
+	if report != nil {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			helper.Warnf("encode backfill report failed: %v", err)
+		}
+	}
+	return runErr
+}
+
+func parseBackfillScope(statusRaw, visibilityRaw, uploaderRaw string) (repositories.BackfillScope, error) {
+	var scope repositories.BackfillScope
+	for _, raw := range strings.Split(statusRaw, ",") {
+		status := po.VideoStatus(strings.ToLower(strings.TrimSpace(raw)))
+		if status == "" {
+			continue
+		}
+		switch status {
+		case po.VideoStatusPendingUpload, po.VideoStatusProcessing, po.VideoStatusReady, po.VideoStatusPublished,
+			po.VideoStatusFailed, po.VideoStatusRejected, po.VideoStatusArchived:
+			scope.Statuses = append(scope.Statuses, status)
+		default:
+			return scope, fmt.Errorf("invalid -status %q", raw)
+		}
+	}
+	if visibility := strings.ToLower(strings.TrimSpace(visibilityRaw)); visibility != "" {
+		switch visibility {
+		case po.VisibilityPublic, po.VisibilityUnlisted, po.VisibilityPrivate:
+			scope.VisibilityStatus = &visibility
+		default:
+			return scope, fmt.Errorf("invalid -visibility %q", visibilityRaw)
+		}
+	}
+	if uploaderRaw = strings.TrimSpace(uploaderRaw); uploaderRaw != "" {
+		uploaderID, err := uuid.Parse(uploaderRaw)
+		if err != nil {
+			return scope, fmt.Errorf("invalid -uploader: %w", err)
+		}
+		scope.UploaderID = &uploaderID
+	}
+	return scope, nil
+}
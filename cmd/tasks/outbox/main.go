@@ -1,21 +1,24 @@
 // Package main 提供 Outbox Runner 独立进程入口，便于在后台单独运行发布器。
+//
+// `outbox -conf <path> backfill [flags]` 为新接入的下游消费者回填全量视频快照事件到指定 topic。
 package main
 
 import (
 	"context"
 	"errors"
 	"flag"
+	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
 
 	configloader "github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
-	outboxpublisher "github.com/bionicotaku/lingo-utils/outbox/publisher"
+	outboxtasks "github.com/bionicotaku/lingo-services-catalog/internal/tasks/outbox"
 	"github.com/go-kratos/kratos/v2/log"
 )
 
 type outboxTaskApp struct {
-	Runner *outboxpublisher.Runner
+	Runner *outboxtasks.Runner
 	Logger log.Logger
 }
 
@@ -26,6 +29,14 @@ func main() {
 	flag.Parse()
 
 	params := configloader.Params{ConfPath: *confFlag}
+	if flag.Arg(0) == "backfill" {
+		if err := runBackfill(ctx, params, flag.Args()[1:]); err != nil {
+			fmt.Fprintf(os.Stderr, "outbox backfill failed: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	app, cleanup, err := wireOutboxTask(ctx, params)
 	if err != nil {
 		panic(err)
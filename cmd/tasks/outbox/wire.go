@@ -9,12 +9,13 @@ import (
 	"fmt"
 
 	configloader "github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
+	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/eventsink"
 	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/backfill"
 	outboxtasks "github.com/bionicotaku/lingo-services-catalog/internal/tasks/outbox"
 
 	"github.com/bionicotaku/lingo-utils/gclog"
 	"github.com/bionicotaku/lingo-utils/gcpubsub"
-	outboxpublisher "github.com/bionicotaku/lingo-utils/outbox/publisher"
 	"github.com/bionicotaku/lingo-utils/pgxpoolx"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/google/wire"
@@ -22,7 +23,7 @@ import (
 
 //go:generate go run github.com/google/wire/cmd/wire
 
-var outboxRepositorySet = wire.NewSet(repositories.NewOutboxRepository)
+var outboxRepositorySet = wire.NewSet(repositories.NewOutboxRepository, repositories.NewWebhookDeliveryRepository, repositories.NewOutboxTopicDeliveryRepository)
 
 func wireOutboxTask(context.Context, configloader.Params) (*outboxTaskApp, func(), error) {
 	panic(wire.Build(
@@ -31,12 +32,57 @@ func wireOutboxTask(context.Context, configloader.Params) (*outboxTaskApp, func(
 		pgxpoolx.ProviderSet,
 		gcpubsub.ProviderSet,
 		outboxRepositorySet,
+		eventsink.ProvideOutboxSink,
 		outboxtasks.ProvideRunner,
 		newOutboxTaskApp,
 	))
 }
 
-func newOutboxTaskApp(logger log.Logger, runner *outboxpublisher.Runner) (*outboxTaskApp, error) {
+func wireOutboxBackfill(context.Context, configloader.Params, backfillTarget) (*backfillApp, func(), error) {
+	panic(wire.Build(
+		configloader.LoadRuntimeConfig,
+		configloader.ProvideServiceInfo,
+		configloader.ProvideLoggerConfig,
+		configloader.ProvideDatabaseConfig,
+		configloader.ProvidePgxConfig,
+		configloader.ProvideMessagingConfig,
+		configloader.ProvidePubSubDependencies,
+		configloader.PubSubConfigForTopic,
+		gclog.ProviderSet,
+		pgxpoolx.ProviderSet,
+		gcpubsub.ProviderSet,
+		repositories.NewWebhookDeliveryRepository,
+		repositories.NewEventBackfillRepository,
+		repositories.NewAdvisoryLockRepository,
+		provideBackfillTopicConfig,
+		eventsink.ProvideSink,
+		newBackfillApp,
+	))
+}
+
+// provideBackfillTopicConfig 按 -topic 选择回填目标；目标未配置时直接失败，不回退到 Outbox 使用的 default。
+func provideBackfillTopicConfig(msg configloader.MessagingConfig, target backfillTarget) (configloader.OutboxTopicConfig, error) {
+	return configloader.TopicConfigFor(msg, string(target))
+}
+
+func newBackfillApp(logger log.Logger, repo *repositories.EventBackfillRepository, locks *repositories.AdvisoryLockRepository, sink eventsink.Sink, target backfillTarget) (*backfillApp, error) {
+	backfiller, err := backfill.NewBackfiller(backfill.Params{
+		Store:  repo,
+		Sink:   sink,
+		Locker: locks,
+		Logger: logger,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &backfillApp{
+		Backfiller: backfiller,
+		Target:     target,
+		Logger:     logger,
+	}, nil
+}
+
+func newOutboxTaskApp(logger log.Logger, runner *outboxtasks.Runner) (*outboxTaskApp, error) {
 	if runner == nil {
 		return &outboxTaskApp{Logger: logger}, nil
 	}
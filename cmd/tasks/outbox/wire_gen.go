@@ -10,11 +10,12 @@ import (
 	"context"
 	"fmt"
 	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
+	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/eventsink"
 	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/backfill"
 	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/outbox"
 	"github.com/bionicotaku/lingo-utils/gclog"
 	"github.com/bionicotaku/lingo-utils/gcpubsub"
-	"github.com/bionicotaku/lingo-utils/outbox/publisher"
 	"github.com/bionicotaku/lingo-utils/pgxpoolx"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/google/wire"
@@ -54,15 +55,88 @@ func wireOutboxTask(contextContext context.Context, params configloader.Params)
 		return nil, nil, err
 	}
 	publisher := gcpubsub.ProvidePublisher(gcpubsubComponent)
-	runner := outbox.ProvideRunner(outboxRepository, publisher, gcpubsubConfig, configConfig, logger)
+	webhookDeliveryRepository := repositories.NewWebhookDeliveryRepository(pool, logger)
+	outboxTopicDeliveryRepository := repositories.NewOutboxTopicDeliveryRepository(pool, logger)
+	sink, cleanup4, err := eventsink.ProvideOutboxSink(contextContext, messagingConfig, publisher, dependencies, webhookDeliveryRepository, outboxTopicDeliveryRepository, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	runner := outbox.ProvideRunner(outboxRepository, pool, sink, configConfig, logger)
 	mainOutboxTaskApp, err := newOutboxTaskApp(logger, runner)
 	if err != nil {
+		cleanup4()
 		cleanup3()
 		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
 	return mainOutboxTaskApp, func() {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+	}, nil
+}
+
+func wireOutboxBackfill(contextContext context.Context, params configloader.Params, mainBackfillTarget backfillTarget) (*backfillApp, func(), error) {
+	runtimeConfig, err := configloader.LoadRuntimeConfig(params)
+	if err != nil {
+		return nil, nil, err
+	}
+	serviceInfo := configloader.ProvideServiceInfo(runtimeConfig)
+	config := configloader.ProvideLoggerConfig(serviceInfo)
+	component, cleanup, err := gclog.NewComponent(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	logger := gclog.ProvideLogger(component)
+	databaseConfig := configloader.ProvideDatabaseConfig(runtimeConfig)
+	pgxpoolxConfig := configloader.ProvidePgxConfig(databaseConfig)
+	pgxpoolxComponent, cleanup2, err := pgxpoolx.ProvideComponent(contextContext, pgxpoolxConfig, logger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	pool := pgxpoolx.ProvidePool(pgxpoolxComponent)
+	eventBackfillRepository := repositories.NewEventBackfillRepository(pool, logger)
+	messagingConfig := configloader.ProvideMessagingConfig(runtimeConfig)
+	outboxTopicConfig, err := provideBackfillTopicConfig(messagingConfig, mainBackfillTarget)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	gcpubsubConfig := configloader.PubSubConfigForTopic(outboxTopicConfig)
+	dependencies := configloader.ProvidePubSubDependencies(logger)
+	gcpubsubComponent, cleanup3, err := gcpubsub.NewComponent(contextContext, gcpubsubConfig, dependencies)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	publisher := gcpubsub.ProvidePublisher(gcpubsubComponent)
+	webhookDeliveryRepository := repositories.NewWebhookDeliveryRepository(pool, logger)
+	sink, cleanup4, err := eventsink.ProvideSink(outboxTopicConfig, publisher, webhookDeliveryRepository, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	advisoryLockRepository := repositories.NewAdvisoryLockRepository(pool, logger)
+	mainBackfillApp, err := newBackfillApp(logger, eventBackfillRepository, advisoryLockRepository, sink, mainBackfillTarget)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	return mainBackfillApp, func() {
+		cleanup4()
 		cleanup3()
 		cleanup2()
 		cleanup()
@@ -71,9 +145,31 @@ func wireOutboxTask(contextContext context.Context, params configloader.Params)
 
 // wire.go:
 
-var outboxRepositorySet = wire.NewSet(repositories.NewOutboxRepository)
+var outboxRepositorySet = wire.NewSet(repositories.NewOutboxRepository, repositories.NewWebhookDeliveryRepository, repositories.NewOutboxTopicDeliveryRepository)
+
+// provideBackfillTopicConfig 按 -topic 选择回填目标；目标未配置时直接失败，不回退到 Outbox 使用的 default。
+func provideBackfillTopicConfig(msg configloader.MessagingConfig, target backfillTarget) (configloader.OutboxTopicConfig, error) {
+	return configloader.TopicConfigFor(msg, string(target))
+}
+
+func newBackfillApp(logger log.Logger, repo *repositories.EventBackfillRepository, locks *repositories.AdvisoryLockRepository, sink eventsink.Sink, target backfillTarget) (*backfillApp, error) {
+	backfiller, err := backfill.NewBackfiller(backfill.Params{
+		Store:  repo,
+		Sink:   sink,
+		Locker: locks,
+		Logger: logger,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &backfillApp{
+		Backfiller: backfiller,
+		Target:     target,
+		Logger:     logger,
+	}, nil
+}
 
-func newOutboxTaskApp(logger log.Logger, runner *publisher.Runner) (*outboxTaskApp, error) {
+func newOutboxTaskApp(logger log.Logger, runner *outbox.Runner) (*outboxTaskApp, error) {
 	if runner == nil {
 		return &outboxTaskApp{Logger: logger}, nil
 	}
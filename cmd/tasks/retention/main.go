@@ -0,0 +1,60 @@
+// Package main 提供 Retention Runner 独立进程入口，按保留期清理（可选归档/导出）Outbox 与 Inbox 表。
+//
+// 默认常驻并按 messaging.retention.interval 周期清理；`retention -conf <path> -once` 清理一轮后退出，便于由 cron 调度。
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+
+	configloader "github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/retention"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+type retentionApp struct {
+	Pruner *retention.Pruner
+	Logger log.Logger
+}
+
+func main() {
+	ctx := context.Background()
+
+	confFlag := flag.String("conf", "", "config path or directory, eg: -conf configs/config.yaml")
+	onceFlag := flag.Bool("once", false, "prune once and exit")
+	flag.Parse()
+
+	params := configloader.Params{ConfPath: *confFlag}
+	app, cleanup, err := wireRetentionTask(ctx, params)
+	if err != nil {
+		panic(err)
+	}
+	defer cleanup()
+
+	logger := app.Logger
+	if logger == nil {
+		logger = log.NewStdLogger(os.Stdout)
+	}
+	helper := log.NewHelper(logger)
+
+	runCtx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if *onceFlag {
+		result, err := app.Pruner.PruneOnce(runCtx)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			helper.Errorf("retention prune failed: %v", err)
+			os.Exit(1)
+		}
+		helper.Infof("retention prune finished: outbox=%d inbox=%d", result.Outbox, result.Inbox)
+		return
+	}
+
+	helper.Info("starting retention runner")
+	app.Pruner.Run(runCtx)
+	helper.Info("retention runner stopped")
+}
@@ -0,0 +1,49 @@
+//go:build wireinject
+// +build wireinject
+
+// Package main 为 retention 任务 CLI 提供 Wire 依赖注入定义。
+package main
+
+import (
+	"context"
+	"fmt"
+
+	configloader "github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/retention"
+
+	"github.com/bionicotaku/lingo-utils/gclog"
+	"github.com/bionicotaku/lingo-utils/pgxpoolx"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/wire"
+)
+
+//go:generate go run github.com/google/wire/cmd/wire
+
+var retentionRepositorySet = wire.NewSet(repositories.NewRetentionRepository)
+
+func wireRetentionTask(context.Context, configloader.Params) (*retentionApp, func(), error) {
+	panic(wire.Build(
+		configloader.ProviderSet,
+		gclog.ProviderSet,
+		pgxpoolx.ProviderSet,
+		txmanager.ProviderSet,
+		retentionRepositorySet,
+		retention.ProvidePruner,
+		newRetentionApp,
+	))
+}
+
+func newRetentionApp(logger log.Logger, pruner *retention.Pruner) (*retentionApp, error) {
+	if logger == nil {
+		return nil, fmt.Errorf("logger not initialized")
+	}
+	if pruner == nil {
+		return nil, fmt.Errorf("retention pruner not initialized")
+	}
+	return &retentionApp{
+		Pruner: pruner,
+		Logger: logger,
+	}, nil
+}
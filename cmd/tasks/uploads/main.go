@@ -1,10 +1,13 @@
 // Package main 提供上传回调 Runner 的独立进程入口，便于后台单独运行。
+//
+// `uploads -conf <path> quarantine <list|show|replay|discard> [flags]` 处置隔离的毒消息。
 package main
 
 import (
 	"context"
 	"errors"
 	"flag"
+	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
@@ -26,6 +29,14 @@ func main() {
 	flag.Parse()
 
 	params := configloader.Params{ConfPath: *confFlag}
+	if flag.Arg(0) == "quarantine" {
+		if err := runQuarantine(ctx, params, flag.Args()[1:]); err != nil {
+			fmt.Fprintf(os.Stderr, "uploads quarantine failed: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	app, cleanup, err := wireUploadsTask(ctx, params)
 	if err != nil {
 		panic(err)
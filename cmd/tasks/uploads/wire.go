@@ -9,8 +9,10 @@ import (
 	"fmt"
 
 	configloader "github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
+	gcsinfra "github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/gcs"
 	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
 	"github.com/bionicotaku/lingo-services-catalog/internal/services"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/quarantine"
 	uploadtasks "github.com/bionicotaku/lingo-services-catalog/internal/tasks/uploads"
 
 	"github.com/bionicotaku/lingo-utils/gclog"
@@ -32,11 +34,30 @@ func wireUploadsTask(context.Context, configloader.Params) (*uploadsTaskApp, fun
 		services.NewLifecycleWriter,
 		wire.Bind(new(services.LifecycleRepo), new(*repositories.VideoRepository)),
 		wire.Bind(new(services.LifecycleOutboxWriter), new(*repositories.OutboxRepository)),
+		gcsinfra.ProvideObjectReader,
+		wire.Bind(new(uploadtasks.ObjectReader), new(*gcsinfra.ObjectReader)),
 		uploadtasks.ProvideRunner,
 		newUploadsTaskApp,
 	))
 }
 
+func wireUploadsQuarantine(context.Context, configloader.Params) (*quarantineApp, func(), error) {
+	panic(wire.Build(
+		configloader.ProviderSet,
+		gclog.ProviderSet,
+		pgxpoolx.ProviderSet,
+		txmanager.ProviderSet,
+		repositories.ProviderSet,
+		services.NewLifecycleWriter,
+		wire.Bind(new(services.LifecycleRepo), new(*repositories.VideoRepository)),
+		wire.Bind(new(services.LifecycleOutboxWriter), new(*repositories.OutboxRepository)),
+		gcsinfra.ProvideObjectReader,
+		wire.Bind(new(uploadtasks.ObjectReader), new(*gcsinfra.ObjectReader)),
+		uploadtasks.ProvideQuarantineAdmin,
+		newQuarantineApp,
+	))
+}
+
 func newUploadsTaskApp(logger log.Logger, runner *uploadtasks.Runner) (*uploadsTaskApp, error) {
 	if runner == nil {
 		return &uploadsTaskApp{Logger: logger}, nil
@@ -49,3 +70,10 @@ func newUploadsTaskApp(logger log.Logger, runner *uploadtasks.Runner) (*uploadsT
 		Logger: logger,
 	}, nil
 }
+
+func newQuarantineApp(logger log.Logger, admin *quarantine.Admin) *quarantineApp {
+	return &quarantineApp{
+		Admin:  admin,
+		Logger: logger,
+	}
+}
@@ -10,8 +10,10 @@ import (
 	"context"
 	"fmt"
 	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
+	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/gcs"
 	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
 	"github.com/bionicotaku/lingo-services-catalog/internal/services"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/quarantine"
 	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/uploads"
 	"github.com/bionicotaku/lingo-utils/gclog"
 	"github.com/bionicotaku/lingo-utils/pgxpoolx"
@@ -42,9 +44,11 @@ func wireUploadsTask(contextContext context.Context, params configloader.Params)
 	}
 	pool := pgxpoolx.ProvidePool(pgxpoolxComponent)
 	uploadRepository := repositories.NewUploadRepository(pool, logger)
+	rawAssetRepository := repositories.NewRawAssetRepository(pool, logger)
 	messagingConfig := configloader.ProvideMessagingConfig(runtimeConfig)
 	configConfig := configloader.ProvideOutboxConfig(messagingConfig)
 	inboxRepository := repositories.NewInboxRepository(pool, logger, configConfig)
+	inboxQuarantineRepository := repositories.NewInboxQuarantineRepository(pool, logger)
 	videoRepository := repositories.NewVideoRepository(pool, logger)
 	outboxRepository := repositories.NewOutboxRepository(pool, logger, configConfig)
 	txmanagerConfig := configloader.ProvideTxConfig(runtimeConfig)
@@ -56,18 +60,27 @@ func wireUploadsTask(contextContext context.Context, params configloader.Params)
 	}
 	manager := txmanager.ProvideManager(txmanagerComponent)
 	lifecycleWriter := services.NewLifecycleWriter(videoRepository, outboxRepository, manager, logger)
+	objectReader, cleanup4, err := gcs.ProvideObjectReader(contextContext, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
 	uploadPubSubConfig := configloader.ProvideUploadConfig(messagingConfig)
 	dependencies := configloader.ProvidePubSubDependencies(logger)
-	uploadSubscriber, cleanup4, err := configloader.ProvideUploadSubscriber(contextContext, uploadPubSubConfig, dependencies)
+	uploadSubscriber, cleanup5, err := configloader.ProvideUploadSubscriber(contextContext, uploadPubSubConfig, dependencies)
 	if err != nil {
+		cleanup4()
 		cleanup3()
 		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
-	runner := uploads.ProvideRunner(uploadRepository, inboxRepository, lifecycleWriter, manager, uploadSubscriber, configConfig, logger)
+	runner := uploads.ProvideRunner(uploadRepository, rawAssetRepository, inboxRepository, inboxQuarantineRepository, lifecycleWriter, objectReader, manager, uploadSubscriber, configConfig, logger)
 	mainUploadsTaskApp, err := newUploadsTaskApp(logger, runner)
 	if err != nil {
+		cleanup5()
 		cleanup4()
 		cleanup3()
 		cleanup2()
@@ -75,6 +88,67 @@ func wireUploadsTask(contextContext context.Context, params configloader.Params)
 		return nil, nil, err
 	}
 	return mainUploadsTaskApp, func() {
+		cleanup5()
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+	}, nil
+}
+
+func wireUploadsQuarantine(contextContext context.Context, params configloader.Params) (*quarantineApp, func(), error) {
+	runtimeConfig, err := configloader.LoadRuntimeConfig(params)
+	if err != nil {
+		return nil, nil, err
+	}
+	serviceInfo := configloader.ProvideServiceInfo(runtimeConfig)
+	config := configloader.ProvideLoggerConfig(serviceInfo)
+	component, cleanup, err := gclog.NewComponent(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	logger := gclog.ProvideLogger(component)
+	databaseConfig := configloader.ProvideDatabaseConfig(runtimeConfig)
+	pgxpoolxConfig := configloader.ProvidePgxConfig(databaseConfig)
+	pgxpoolxComponent, cleanup2, err := pgxpoolx.ProvideComponent(contextContext, pgxpoolxConfig, logger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	pool := pgxpoolx.ProvidePool(pgxpoolxComponent)
+	uploadRepository := repositories.NewUploadRepository(pool, logger)
+	rawAssetRepository := repositories.NewRawAssetRepository(pool, logger)
+	inboxQuarantineRepository := repositories.NewInboxQuarantineRepository(pool, logger)
+	videoRepository := repositories.NewVideoRepository(pool, logger)
+	messagingConfig := configloader.ProvideMessagingConfig(runtimeConfig)
+	configConfig := configloader.ProvideOutboxConfig(messagingConfig)
+	outboxRepository := repositories.NewOutboxRepository(pool, logger, configConfig)
+	txmanagerConfig := configloader.ProvideTxConfig(runtimeConfig)
+	txmanagerComponent, cleanup3, err := txmanager.NewComponent(txmanagerConfig, pool, logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	manager := txmanager.ProvideManager(txmanagerComponent)
+	lifecycleWriter := services.NewLifecycleWriter(videoRepository, outboxRepository, manager, logger)
+	objectReader, cleanup4, err := gcs.ProvideObjectReader(contextContext, logger)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	admin, err := uploads.ProvideQuarantineAdmin(uploadRepository, rawAssetRepository, inboxQuarantineRepository, lifecycleWriter, objectReader, manager, logger)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	mainQuarantineApp := newQuarantineApp(logger, admin)
+	return mainQuarantineApp, func() {
 		cleanup4()
 		cleanup3()
 		cleanup2()
@@ -96,3 +170,10 @@ func newUploadsTaskApp(logger log.Logger, runner *uploads.Runner) (*uploadsTaskA
 		Logger: logger,
 	}, nil
 }
+
+func newQuarantineApp(logger log.Logger, admin *quarantine.Admin) *quarantineApp {
+	return &quarantineApp{
+		Admin:  admin,
+		Logger: logger,
+	}
+}
@@ -30,6 +30,9 @@ type Bootstrap struct {
 	Observability *Observability         `protobuf:"bytes,3,opt,name=observability,proto3" json:"observability,omitempty"`
 	Messaging     *Messaging             `protobuf:"bytes,4,opt,name=messaging,proto3" json:"messaging,omitempty"`
 	Gcs           *GCS                   `protobuf:"bytes,5,opt,name=gcs,proto3" json:"gcs,omitempty"`
+	UploadQuota   *UploadQuota           `protobuf:"bytes,6,opt,name=upload_quota,json=uploadQuota,proto3" json:"upload_quota,omitempty"`
+	Playback      *Playback              `protobuf:"bytes,7,opt,name=playback,proto3" json:"playback,omitempty"`
+	Engagement    *Engagement            `protobuf:"bytes,8,opt,name=engagement,proto3" json:"engagement,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -99,6 +102,27 @@ func (x *Bootstrap) GetGcs() *GCS {
 	return nil
 }
 
+func (x *Bootstrap) GetUploadQuota() *UploadQuota {
+	if x != nil {
+		return x.UploadQuota
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetPlayback() *Playback {
+	if x != nil {
+		return x.Playback
+	}
+	return nil
+}
+
+func (x *Bootstrap) GetEngagement() *Engagement {
+	if x != nil {
+		return x.Engagement
+	}
+	return nil
+}
+
 type Server struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Grpc          *Server_GRPC           `protobuf:"bytes,1,opt,name=grpc,proto3" json:"grpc,omitempty"`
@@ -287,6 +311,242 @@ func (x *GCS) GetSignedUrlTtl() *durationpb.Duration {
 	return nil
 }
 
+type UploadQuota struct {
+	state         protoimpl.MessageState       `protogen:"open.v1"`
+	DefaultTier   string                       `protobuf:"bytes,1,opt,name=default_tier,json=defaultTier,proto3" json:"default_tier,omitempty"` // 用户未携带 tier 声明或档位未配置时使用
+	Tiers         map[string]*UploadQuota_Tier `protobuf:"bytes,2,rep,name=tiers,proto3" json:"tiers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadQuota) Reset() {
+	*x = UploadQuota{}
+	mi := &file_configs_conf_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadQuota) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadQuota) ProtoMessage() {}
+
+func (x *UploadQuota) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadQuota.ProtoReflect.Descriptor instead.
+func (*UploadQuota) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UploadQuota) GetDefaultTier() string {
+	if x != nil {
+		return x.DefaultTier
+	}
+	return ""
+}
+
+func (x *UploadQuota) GetTiers() map[string]*UploadQuota_Tier {
+	if x != nil {
+		return x.Tiers
+	}
+	return nil
+}
+
+type Playback struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Signer            string                 `protobuf:"bytes,1,opt,name=signer,proto3" json:"signer,omitempty"`                              // 签名实现，目前支持 hmac（Cloud CDN 兼容，亦用于本地开发/测试）
+	KeyName           string                 `protobuf:"bytes,2,opt,name=key_name,json=keyName,proto3" json:"key_name,omitempty"`             // Cloud CDN 签名密钥名
+	Key               string                 `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`                                    // base64url 编码的 HMAC 密钥
+	CdnHost           string                 `protobuf:"bytes,4,opt,name=cdn_host,json=cdnHost,proto3" json:"cdn_host,omitempty"`             // 播放域名，如 https://media.example.com；存储路径会改写到该域名
+	PlaylistTtl       *durationpb.Duration   `protobuf:"bytes,5,opt,name=playlist_ttl,json=playlistTtl,proto3" json:"playlist_ttl,omitempty"` // HLS 主清单与签名 Cookie 的有效期
+	ThumbnailTtl      *durationpb.Duration   `protobuf:"bytes,6,opt,name=thumbnail_ttl,json=thumbnailTtl,proto3" json:"thumbnail_ttl,omitempty"`
+	SignedCookie      bool                   `protobuf:"varint,7,opt,name=signed_cookie,json=signedCookie,proto3" json:"signed_cookie,omitempty"`                  // true 时额外下发覆盖 HLS 目录前缀的签名 Cookie
+	AllowEphemeralKey bool                   `protobuf:"varint,8,opt,name=allow_ephemeral_key,json=allowEphemeralKey,proto3" json:"allow_ephemeral_key,omitempty"` // 仅本地开发：未配置 key 时允许生成进程内临时密钥，否则启动失败
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Playback) Reset() {
+	*x = Playback{}
+	mi := &file_configs_conf_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Playback) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Playback) ProtoMessage() {}
+
+func (x *Playback) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Playback.ProtoReflect.Descriptor instead.
+func (*Playback) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Playback) GetSigner() string {
+	if x != nil {
+		return x.Signer
+	}
+	return ""
+}
+
+func (x *Playback) GetKeyName() string {
+	if x != nil {
+		return x.KeyName
+	}
+	return ""
+}
+
+func (x *Playback) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Playback) GetCdnHost() string {
+	if x != nil {
+		return x.CdnHost
+	}
+	return ""
+}
+
+func (x *Playback) GetPlaylistTtl() *durationpb.Duration {
+	if x != nil {
+		return x.PlaylistTtl
+	}
+	return nil
+}
+
+func (x *Playback) GetThumbnailTtl() *durationpb.Duration {
+	if x != nil {
+		return x.ThumbnailTtl
+	}
+	return nil
+}
+
+func (x *Playback) GetSignedCookie() bool {
+	if x != nil {
+		return x.SignedCookie
+	}
+	return false
+}
+
+func (x *Playback) GetAllowEphemeralKey() bool {
+	if x != nil {
+		return x.AllowEphemeralKey
+	}
+	return false
+}
+
+type Engagement struct {
+	state         protoimpl.MessageState        `protogen:"open.v1"`
+	Views         *Engagement_ViewQualification `protobuf:"bytes,1,opt,name=views,proto3" json:"views,omitempty"`
+	Rollups       *Engagement_Rollups           `protobuf:"bytes,2,opt,name=rollups,proto3" json:"rollups,omitempty"`
+	Trending      *Engagement_Trending          `protobuf:"bytes,3,opt,name=trending,proto3" json:"trending,omitempty"`
+	Purge         *Engagement_Purge             `protobuf:"bytes,4,opt,name=purge,proto3" json:"purge,omitempty"`
+	Counters      *Engagement_Counters          `protobuf:"bytes,5,opt,name=counters,proto3" json:"counters,omitempty"`
+	Batch         *Engagement_Batch             `protobuf:"bytes,6,opt,name=batch,proto3" json:"batch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Engagement) Reset() {
+	*x = Engagement{}
+	mi := &file_configs_conf_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Engagement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Engagement) ProtoMessage() {}
+
+func (x *Engagement) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Engagement.ProtoReflect.Descriptor instead.
+func (*Engagement) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Engagement) GetViews() *Engagement_ViewQualification {
+	if x != nil {
+		return x.Views
+	}
+	return nil
+}
+
+func (x *Engagement) GetRollups() *Engagement_Rollups {
+	if x != nil {
+		return x.Rollups
+	}
+	return nil
+}
+
+func (x *Engagement) GetTrending() *Engagement_Trending {
+	if x != nil {
+		return x.Trending
+	}
+	return nil
+}
+
+func (x *Engagement) GetPurge() *Engagement_Purge {
+	if x != nil {
+		return x.Purge
+	}
+	return nil
+}
+
+func (x *Engagement) GetCounters() *Engagement_Counters {
+	if x != nil {
+		return x.Counters
+	}
+	return nil
+}
+
+func (x *Engagement) GetBatch() *Engagement_Batch {
+	if x != nil {
+		return x.Batch
+	}
+	return nil
+}
+
 type Observability struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	GlobalAttributes map[string]string      `protobuf:"bytes,1,rep,name=global_attributes,json=globalAttributes,proto3" json:"global_attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
@@ -298,7 +558,7 @@ type Observability struct {
 
 func (x *Observability) Reset() {
 	*x = Observability{}
-	mi := &file_configs_conf_proto_msgTypes[4]
+	mi := &file_configs_conf_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -310,7 +570,7 @@ func (x *Observability) String() string {
 func (*Observability) ProtoMessage() {}
 
 func (x *Observability) ProtoReflect() protoreflect.Message {
-	mi := &file_configs_conf_proto_msgTypes[4]
+	mi := &file_configs_conf_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -323,7 +583,7 @@ func (x *Observability) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Observability.ProtoReflect.Descriptor instead.
 func (*Observability) Descriptor() ([]byte, []int) {
-	return file_configs_conf_proto_rawDescGZIP(), []int{4}
+	return file_configs_conf_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *Observability) GetGlobalAttributes() map[string]string {
@@ -353,13 +613,14 @@ type Messaging struct {
 	Topics        map[string]*PubSub        `protobuf:"bytes,2,rep,name=topics,proto3" json:"topics,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	Outbox        *OutboxPublisher          `protobuf:"bytes,3,opt,name=outbox,proto3" json:"outbox,omitempty"`
 	Inboxes       map[string]*InboxConsumer `protobuf:"bytes,4,rep,name=inboxes,proto3" json:"inboxes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Retention     *Retention                `protobuf:"bytes,5,opt,name=retention,proto3" json:"retention,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Messaging) Reset() {
 	*x = Messaging{}
-	mi := &file_configs_conf_proto_msgTypes[5]
+	mi := &file_configs_conf_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -371,7 +632,7 @@ func (x *Messaging) String() string {
 func (*Messaging) ProtoMessage() {}
 
 func (x *Messaging) ProtoReflect() protoreflect.Message {
-	mi := &file_configs_conf_proto_msgTypes[5]
+	mi := &file_configs_conf_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -384,7 +645,7 @@ func (x *Messaging) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Messaging.ProtoReflect.Descriptor instead.
 func (*Messaging) Descriptor() ([]byte, []int) {
-	return file_configs_conf_proto_rawDescGZIP(), []int{5}
+	return file_configs_conf_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *Messaging) GetSchema() string {
@@ -415,6 +676,13 @@ func (x *Messaging) GetInboxes() map[string]*InboxConsumer {
 	return nil
 }
 
+func (x *Messaging) GetRetention() *Retention {
+	if x != nil {
+		return x.Retention
+	}
+	return nil
+}
+
 type PubSub struct {
 	state               protoimpl.MessageState `protogen:"open.v1"`
 	ProjectId           string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
@@ -428,13 +696,22 @@ type PubSub struct {
 	Receive             *Receive               `protobuf:"bytes,9,opt,name=receive,proto3" json:"receive,omitempty"`
 	ExactlyOnceDelivery bool                   `protobuf:"varint,10,opt,name=exactly_once_delivery,json=exactlyOnceDelivery,proto3" json:"exactly_once_delivery,omitempty"`
 	DeadLetterTopicId   string                 `protobuf:"bytes,11,opt,name=dead_letter_topic_id,json=deadLetterTopicId,proto3" json:"dead_letter_topic_id,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+	// sink 选择该 topic 的发布端：pubsub（默认）、kafka、nats、webhook；只影响发布，订阅始终走 Pub/Sub。
+	// kafka 与 nats 以 topic_id 作为 Kafka topic / NATS subject，webhook 不使用 topic_id。
+	Sink    string       `protobuf:"bytes,12,opt,name=sink,proto3" json:"sink,omitempty"`
+	Kafka   *KafkaSink   `protobuf:"bytes,13,opt,name=kafka,proto3" json:"kafka,omitempty"`
+	Nats    *NatsSink    `protobuf:"bytes,14,opt,name=nats,proto3" json:"nats,omitempty"`
+	Webhook *WebhookSink `protobuf:"bytes,15,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	// outbox_event_types 非空时，Outbox 除 default 外也把列出的事件类型发布到该 topic（"*" 表示全部）；
+	// default 始终接收全部事件，此字段对 default 无效。
+	OutboxEventTypes []string `protobuf:"bytes,16,rep,name=outbox_event_types,json=outboxEventTypes,proto3" json:"outbox_event_types,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *PubSub) Reset() {
 	*x = PubSub{}
-	mi := &file_configs_conf_proto_msgTypes[6]
+	mi := &file_configs_conf_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -446,7 +723,7 @@ func (x *PubSub) String() string {
 func (*PubSub) ProtoMessage() {}
 
 func (x *PubSub) ProtoReflect() protoreflect.Message {
-	mi := &file_configs_conf_proto_msgTypes[6]
+	mi := &file_configs_conf_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -459,7 +736,7 @@ func (x *PubSub) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PubSub.ProtoReflect.Descriptor instead.
 func (*PubSub) Descriptor() ([]byte, []int) {
-	return file_configs_conf_proto_rawDescGZIP(), []int{6}
+	return file_configs_conf_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *PubSub) GetProjectId() string {
@@ -539,32 +816,67 @@ func (x *PubSub) GetDeadLetterTopicId() string {
 	return ""
 }
 
-type Receive struct {
-	state                  protoimpl.MessageState `protogen:"open.v1"`
-	NumGoroutines          int32                  `protobuf:"varint,1,opt,name=num_goroutines,json=numGoroutines,proto3" json:"num_goroutines,omitempty"`
-	MaxOutstandingMessages int32                  `protobuf:"varint,2,opt,name=max_outstanding_messages,json=maxOutstandingMessages,proto3" json:"max_outstanding_messages,omitempty"`
-	MaxOutstandingBytes    int32                  `protobuf:"varint,3,opt,name=max_outstanding_bytes,json=maxOutstandingBytes,proto3" json:"max_outstanding_bytes,omitempty"`
-	MaxExtension           *durationpb.Duration   `protobuf:"bytes,4,opt,name=max_extension,json=maxExtension,proto3" json:"max_extension,omitempty"`
-	MaxExtensionPeriod     *durationpb.Duration   `protobuf:"bytes,5,opt,name=max_extension_period,json=maxExtensionPeriod,proto3" json:"max_extension_period,omitempty"`
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+func (x *PubSub) GetSink() string {
+	if x != nil {
+		return x.Sink
+	}
+	return ""
 }
 
-func (x *Receive) Reset() {
-	*x = Receive{}
-	mi := &file_configs_conf_proto_msgTypes[7]
+func (x *PubSub) GetKafka() *KafkaSink {
+	if x != nil {
+		return x.Kafka
+	}
+	return nil
+}
+
+func (x *PubSub) GetNats() *NatsSink {
+	if x != nil {
+		return x.Nats
+	}
+	return nil
+}
+
+func (x *PubSub) GetWebhook() *WebhookSink {
+	if x != nil {
+		return x.Webhook
+	}
+	return nil
+}
+
+func (x *PubSub) GetOutboxEventTypes() []string {
+	if x != nil {
+		return x.OutboxEventTypes
+	}
+	return nil
+}
+
+// KafkaSink 配置 Kafka 发布端：消息 key 为 aggregate_id，同一聚合的事件落在同一分区；发布失败重试的事件可能晚于同一聚合的后续事件，消费者需按 version 判断新旧。
+type KafkaSink struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Brokers       []string               `protobuf:"bytes,1,rep,name=brokers,proto3" json:"brokers,omitempty"`                               // broker 地址列表，host:port
+	ClientId      string                 `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`             // 客户端标识，默认 catalog-outbox
+	RequiredAcks  string                 `protobuf:"bytes,3,opt,name=required_acks,json=requiredAcks,proto3" json:"required_acks,omitempty"` // 写入确认级别，默认 all
+	WriteTimeout  *durationpb.Duration   `protobuf:"bytes,4,opt,name=write_timeout,json=writeTimeout,proto3" json:"write_timeout,omitempty"` // 单次写入超时，默认 10s
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KafkaSink) Reset() {
+	*x = KafkaSink{}
+	mi := &file_configs_conf_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Receive) String() string {
+func (x *KafkaSink) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Receive) ProtoMessage() {}
+func (*KafkaSink) ProtoMessage() {}
 
-func (x *Receive) ProtoReflect() protoreflect.Message {
-	mi := &file_configs_conf_proto_msgTypes[7]
+func (x *KafkaSink) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -575,21 +887,274 @@ func (x *Receive) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Receive.ProtoReflect.Descriptor instead.
-func (*Receive) Descriptor() ([]byte, []int) {
-	return file_configs_conf_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use KafkaSink.ProtoReflect.Descriptor instead.
+func (*KafkaSink) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *Receive) GetNumGoroutines() int32 {
+func (x *KafkaSink) GetBrokers() []string {
 	if x != nil {
-		return x.NumGoroutines
+		return x.Brokers
 	}
-	return 0
+	return nil
 }
 
-func (x *Receive) GetMaxOutstandingMessages() int32 {
+func (x *KafkaSink) GetClientId() string {
 	if x != nil {
-		return x.MaxOutstandingMessages
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *KafkaSink) GetRequiredAcks() string {
+	if x != nil {
+		return x.RequiredAcks
+	}
+	return ""
+}
+
+func (x *KafkaSink) GetWriteTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.WriteTimeout
+	}
+	return nil
+}
+
+// NatsSink 配置 NATS JetStream 发布端：以 event_id 作为 Nats-Msg-Id，重复发布在 stream 去重窗口内被丢弃。
+type NatsSink struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Url             string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`                                                // 服务器地址，逗号分隔多个
+	Stream          string                 `protobuf:"bytes,2,opt,name=stream,proto3" json:"stream,omitempty"`                                          // 非空时要求发布确认来自该 stream
+	CredentialsFile string                 `protobuf:"bytes,3,opt,name=credentials_file,json=credentialsFile,proto3" json:"credentials_file,omitempty"` // .creds 凭证文件，为空表示不认证
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *NatsSink) Reset() {
+	*x = NatsSink{}
+	mi := &file_configs_conf_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NatsSink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NatsSink) ProtoMessage() {}
+
+func (x *NatsSink) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NatsSink.ProtoReflect.Descriptor instead.
+func (*NatsSink) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *NatsSink) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *NatsSink) GetStream() string {
+	if x != nil {
+		return x.Stream
+	}
+	return ""
+}
+
+func (x *NatsSink) GetCredentialsFile() string {
+	if x != nil {
+		return x.CredentialsFile
+	}
+	return ""
+}
+
+// WebhookSink 配置 HTTP Webhook 发布端：每次发布对尚未成功的端点各尝试一次，每次尝试写入 catalog.webhook_deliveries，
+// 失败由 Outbox 按 next_retry_at 退避后重新认领。
+type WebhookSink struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Endpoints     []*WebhookEndpoint     `protobuf:"bytes,1,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookSink) Reset() {
+	*x = WebhookSink{}
+	mi := &file_configs_conf_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookSink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookSink) ProtoMessage() {}
+
+func (x *WebhookSink) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookSink.ProtoReflect.Descriptor instead.
+func (*WebhookSink) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *WebhookSink) GetEndpoints() []*WebhookEndpoint {
+	if x != nil {
+		return x.Endpoints
+	}
+	return nil
+}
+
+// WebhookEndpoint 描述单个 Webhook 端点及其签名密钥。
+type WebhookEndpoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                                                                 // 端点名，唯一，写入投递日志
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`                                                                                   // 投递地址
+	Secret        string                 `protobuf:"bytes,3,opt,name=secret,proto3" json:"secret,omitempty"`                                                                             // HMAC-SHA256 签名密钥，必填，可由 WEBHOOK_SECRET_<NAME> 覆盖
+	Timeout       *durationpb.Duration   `protobuf:"bytes,4,opt,name=timeout,proto3" json:"timeout,omitempty"`                                                                           // 单次请求超时，默认 5s
+	Headers       map[string]string      `protobuf:"bytes,8,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 附加请求头
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookEndpoint) Reset() {
+	*x = WebhookEndpoint{}
+	mi := &file_configs_conf_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookEndpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookEndpoint) ProtoMessage() {}
+
+func (x *WebhookEndpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookEndpoint.ProtoReflect.Descriptor instead.
+func (*WebhookEndpoint) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *WebhookEndpoint) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WebhookEndpoint) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *WebhookEndpoint) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *WebhookEndpoint) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *WebhookEndpoint) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+type Receive struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	NumGoroutines          int32                  `protobuf:"varint,1,opt,name=num_goroutines,json=numGoroutines,proto3" json:"num_goroutines,omitempty"`
+	MaxOutstandingMessages int32                  `protobuf:"varint,2,opt,name=max_outstanding_messages,json=maxOutstandingMessages,proto3" json:"max_outstanding_messages,omitempty"`
+	MaxOutstandingBytes    int32                  `protobuf:"varint,3,opt,name=max_outstanding_bytes,json=maxOutstandingBytes,proto3" json:"max_outstanding_bytes,omitempty"`
+	MaxExtension           *durationpb.Duration   `protobuf:"bytes,4,opt,name=max_extension,json=maxExtension,proto3" json:"max_extension,omitempty"`
+	MaxExtensionPeriod     *durationpb.Duration   `protobuf:"bytes,5,opt,name=max_extension_period,json=maxExtensionPeriod,proto3" json:"max_extension_period,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *Receive) Reset() {
+	*x = Receive{}
+	mi := &file_configs_conf_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Receive) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Receive) ProtoMessage() {}
+
+func (x *Receive) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Receive.ProtoReflect.Descriptor instead.
+func (*Receive) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Receive) GetNumGoroutines() int32 {
+	if x != nil {
+		return x.NumGoroutines
+	}
+	return 0
+}
+
+func (x *Receive) GetMaxOutstandingMessages() int32 {
+	if x != nil {
+		return x.MaxOutstandingMessages
 	}
 	return 0
 }
@@ -633,7 +1198,7 @@ type OutboxPublisher struct {
 
 func (x *OutboxPublisher) Reset() {
 	*x = OutboxPublisher{}
-	mi := &file_configs_conf_proto_msgTypes[8]
+	mi := &file_configs_conf_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -645,7 +1210,7 @@ func (x *OutboxPublisher) String() string {
 func (*OutboxPublisher) ProtoMessage() {}
 
 func (x *OutboxPublisher) ProtoReflect() protoreflect.Message {
-	mi := &file_configs_conf_proto_msgTypes[8]
+	mi := &file_configs_conf_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -658,7 +1223,7 @@ func (x *OutboxPublisher) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OutboxPublisher.ProtoReflect.Descriptor instead.
 func (*OutboxPublisher) Descriptor() ([]byte, []int) {
-	return file_configs_conf_proto_rawDescGZIP(), []int{8}
+	return file_configs_conf_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *OutboxPublisher) GetBatchSize() int32 {
@@ -731,31 +1296,37 @@ func (x *OutboxPublisher) GetMetricsEnabled() bool {
 	return false
 }
 
-type InboxConsumer struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	SourceService  string                 `protobuf:"bytes,1,opt,name=source_service,json=sourceService,proto3" json:"source_service,omitempty"`
-	MaxConcurrency int32                  `protobuf:"varint,2,opt,name=max_concurrency,json=maxConcurrency,proto3" json:"max_concurrency,omitempty"`
-	LoggingEnabled *bool                  `protobuf:"varint,3,opt,name=logging_enabled,json=loggingEnabled,proto3,oneof" json:"logging_enabled,omitempty"`
-	MetricsEnabled *bool                  `protobuf:"varint,4,opt,name=metrics_enabled,json=metricsEnabled,proto3,oneof" json:"metrics_enabled,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
-}
-
-func (x *InboxConsumer) Reset() {
-	*x = InboxConsumer{}
-	mi := &file_configs_conf_proto_msgTypes[9]
+// Retention 描述 Outbox/Inbox 表的保留与归档：Retention Runner 每 interval 分批删除发布超过
+// outbox_published_retention 的 Outbox 行、放弃超过 outbox_abandoned_retention 的 Outbox 行与处理完成超过 inbox_processed_retention 的 Inbox 行；
+// archive 为 true 时先移入对应的 *_archive 表，export_dir 非空时在提交删除前把每批行导出为 NDJSON 文件。
+type Retention struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	OutboxPublishedRetention *durationpb.Duration   `protobuf:"bytes,1,opt,name=outbox_published_retention,json=outboxPublishedRetention,proto3" json:"outbox_published_retention,omitempty"` // 已发布 Outbox 行保留期，默认 168h（7 天）
+	InboxProcessedRetention  *durationpb.Duration   `protobuf:"bytes,2,opt,name=inbox_processed_retention,json=inboxProcessedRetention,proto3" json:"inbox_processed_retention,omitempty"`    // 已处理 Inbox 行保留期，默认 720h（30 天），需长于订阅消息保留期与重放窗口
+	Interval                 *durationpb.Duration   `protobuf:"bytes,3,opt,name=interval,proto3" json:"interval,omitempty"`                                                                   // 清理周期，默认 1h
+	BatchSize                int32                  `protobuf:"varint,4,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`                                               // 每个事务每张表删除行数，默认 1000
+	Archive                  bool                   `protobuf:"varint,5,opt,name=archive,proto3" json:"archive,omitempty"`                                                                    // 是否移入归档表而非直接删除
+	ExportDir                string                 `protobuf:"bytes,6,opt,name=export_dir,json=exportDir,proto3" json:"export_dir,omitempty"`                                                // NDJSON 导出目录，为空表示不导出
+	OutboxAbandonedRetention *durationpb.Duration   `protobuf:"bytes,7,opt,name=outbox_abandoned_retention,json=outboxAbandonedRetention,proto3" json:"outbox_abandoned_retention,omitempty"` // 已放弃 Outbox 行保留期，默认 720h（30 天），供运维审计
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *Retention) Reset() {
+	*x = Retention{}
+	mi := &file_configs_conf_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *InboxConsumer) String() string {
+func (x *Retention) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InboxConsumer) ProtoMessage() {}
+func (*Retention) ProtoMessage() {}
 
-func (x *InboxConsumer) ProtoReflect() protoreflect.Message {
-	mi := &file_configs_conf_proto_msgTypes[9]
+func (x *Retention) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -766,53 +1337,142 @@ func (x *InboxConsumer) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InboxConsumer.ProtoReflect.Descriptor instead.
-func (*InboxConsumer) Descriptor() ([]byte, []int) {
-	return file_configs_conf_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use Retention.ProtoReflect.Descriptor instead.
+func (*Retention) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *InboxConsumer) GetSourceService() string {
+func (x *Retention) GetOutboxPublishedRetention() *durationpb.Duration {
 	if x != nil {
-		return x.SourceService
+		return x.OutboxPublishedRetention
 	}
-	return ""
+	return nil
 }
 
-func (x *InboxConsumer) GetMaxConcurrency() int32 {
+func (x *Retention) GetInboxProcessedRetention() *durationpb.Duration {
 	if x != nil {
-		return x.MaxConcurrency
+		return x.InboxProcessedRetention
 	}
-	return 0
+	return nil
 }
 
-func (x *InboxConsumer) GetLoggingEnabled() bool {
-	if x != nil && x.LoggingEnabled != nil {
-		return *x.LoggingEnabled
+func (x *Retention) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
 	}
-	return false
+	return nil
 }
 
-func (x *InboxConsumer) GetMetricsEnabled() bool {
-	if x != nil && x.MetricsEnabled != nil {
-		return *x.MetricsEnabled
+func (x *Retention) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
 	}
-	return false
+	return 0
 }
 
-type Server_GRPC struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Network       string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
-	Addr          string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
-	Timeout       *durationpb.Duration   `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Retention) GetArchive() bool {
+	if x != nil {
+		return x.Archive
+	}
+	return false
 }
 
-func (x *Server_GRPC) Reset() {
-	*x = Server_GRPC{}
-	mi := &file_configs_conf_proto_msgTypes[10]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Retention) GetExportDir() string {
+	if x != nil {
+		return x.ExportDir
+	}
+	return ""
+}
+
+func (x *Retention) GetOutboxAbandonedRetention() *durationpb.Duration {
+	if x != nil {
+		return x.OutboxAbandonedRetention
+	}
+	return nil
+}
+
+type InboxConsumer struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SourceService  string                 `protobuf:"bytes,1,opt,name=source_service,json=sourceService,proto3" json:"source_service,omitempty"`
+	MaxConcurrency int32                  `protobuf:"varint,2,opt,name=max_concurrency,json=maxConcurrency,proto3" json:"max_concurrency,omitempty"`
+	LoggingEnabled *bool                  `protobuf:"varint,3,opt,name=logging_enabled,json=loggingEnabled,proto3,oneof" json:"logging_enabled,omitempty"`
+	MetricsEnabled *bool                  `protobuf:"varint,4,opt,name=metrics_enabled,json=metricsEnabled,proto3,oneof" json:"metrics_enabled,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *InboxConsumer) Reset() {
+	*x = InboxConsumer{}
+	mi := &file_configs_conf_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InboxConsumer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InboxConsumer) ProtoMessage() {}
+
+func (x *InboxConsumer) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InboxConsumer.ProtoReflect.Descriptor instead.
+func (*InboxConsumer) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *InboxConsumer) GetSourceService() string {
+	if x != nil {
+		return x.SourceService
+	}
+	return ""
+}
+
+func (x *InboxConsumer) GetMaxConcurrency() int32 {
+	if x != nil {
+		return x.MaxConcurrency
+	}
+	return 0
+}
+
+func (x *InboxConsumer) GetLoggingEnabled() bool {
+	if x != nil && x.LoggingEnabled != nil {
+		return *x.LoggingEnabled
+	}
+	return false
+}
+
+func (x *InboxConsumer) GetMetricsEnabled() bool {
+	if x != nil && x.MetricsEnabled != nil {
+		return *x.MetricsEnabled
+	}
+	return false
+}
+
+type Server_GRPC struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Network       string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	Addr          string                 `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
+	Timeout       *durationpb.Duration   `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_GRPC) Reset() {
+	*x = Server_GRPC{}
+	mi := &file_configs_conf_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *Server_GRPC) String() string {
@@ -822,7 +1482,7 @@ func (x *Server_GRPC) String() string {
 func (*Server_GRPC) ProtoMessage() {}
 
 func (x *Server_GRPC) ProtoReflect() protoreflect.Message {
-	mi := &file_configs_conf_proto_msgTypes[10]
+	mi := &file_configs_conf_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -859,31 +1519,539 @@ func (x *Server_GRPC) GetTimeout() *durationpb.Duration {
 	return nil
 }
 
-type Server_JWT struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	ExpectedAudience string                 `protobuf:"bytes,1,opt,name=expected_audience,json=expectedAudience,proto3" json:"expected_audience,omitempty"`
-	SkipValidate     bool                   `protobuf:"varint,2,opt,name=skip_validate,json=skipValidate,proto3" json:"skip_validate,omitempty"`
-	Required         bool                   `protobuf:"varint,3,opt,name=required,proto3" json:"required,omitempty"`
-	HeaderKey        string                 `protobuf:"bytes,4,opt,name=header_key,json=headerKey,proto3" json:"header_key,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+type Server_JWT struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ExpectedAudience string                 `protobuf:"bytes,1,opt,name=expected_audience,json=expectedAudience,proto3" json:"expected_audience,omitempty"`
+	SkipValidate     bool                   `protobuf:"varint,2,opt,name=skip_validate,json=skipValidate,proto3" json:"skip_validate,omitempty"`
+	Required         bool                   `protobuf:"varint,3,opt,name=required,proto3" json:"required,omitempty"`
+	HeaderKey        string                 `protobuf:"bytes,4,opt,name=header_key,json=headerKey,proto3" json:"header_key,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Server_JWT) Reset() {
+	*x = Server_JWT{}
+	mi := &file_configs_conf_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_JWT) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_JWT) ProtoMessage() {}
+
+func (x *Server_JWT) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_JWT.ProtoReflect.Descriptor instead.
+func (*Server_JWT) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{1, 1}
+}
+
+func (x *Server_JWT) GetExpectedAudience() string {
+	if x != nil {
+		return x.ExpectedAudience
+	}
+	return ""
+}
+
+func (x *Server_JWT) GetSkipValidate() bool {
+	if x != nil {
+		return x.SkipValidate
+	}
+	return false
+}
+
+func (x *Server_JWT) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *Server_JWT) GetHeaderKey() string {
+	if x != nil {
+		return x.HeaderKey
+	}
+	return ""
+}
+
+type Server_Handlers struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	DefaultTimeout *durationpb.Duration   `protobuf:"bytes,1,opt,name=default_timeout,json=defaultTimeout,proto3" json:"default_timeout,omitempty"`
+	CommandTimeout *durationpb.Duration   `protobuf:"bytes,2,opt,name=command_timeout,json=commandTimeout,proto3" json:"command_timeout,omitempty"`
+	QueryTimeout   *durationpb.Duration   `protobuf:"bytes,3,opt,name=query_timeout,json=queryTimeout,proto3" json:"query_timeout,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Server_Handlers) Reset() {
+	*x = Server_Handlers{}
+	mi := &file_configs_conf_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_Handlers) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_Handlers) ProtoMessage() {}
+
+func (x *Server_Handlers) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_Handlers.ProtoReflect.Descriptor instead.
+func (*Server_Handlers) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{1, 2}
+}
+
+func (x *Server_Handlers) GetDefaultTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.DefaultTimeout
+	}
+	return nil
+}
+
+func (x *Server_Handlers) GetCommandTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.CommandTimeout
+	}
+	return nil
+}
+
+func (x *Server_Handlers) GetQueryTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.QueryTimeout
+	}
+	return nil
+}
+
+// PostgreSQL 数据库配置（Supabase 专用）
+type Data_PostgreSQL struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// DSN 连接串（必填）
+	Dsn string `protobuf:"bytes,1,opt,name=dsn,proto3" json:"dsn,omitempty"`
+	// 连接池配置
+	MaxOpenConns      int32                `protobuf:"varint,2,opt,name=max_open_conns,json=maxOpenConns,proto3" json:"max_open_conns,omitempty"`
+	MinOpenConns      int32                `protobuf:"varint,3,opt,name=min_open_conns,json=minOpenConns,proto3" json:"min_open_conns,omitempty"`
+	MaxConnLifetime   *durationpb.Duration `protobuf:"bytes,4,opt,name=max_conn_lifetime,json=maxConnLifetime,proto3" json:"max_conn_lifetime,omitempty"`
+	MaxConnIdleTime   *durationpb.Duration `protobuf:"bytes,5,opt,name=max_conn_idle_time,json=maxConnIdleTime,proto3" json:"max_conn_idle_time,omitempty"`
+	HealthCheckPeriod *durationpb.Duration `protobuf:"bytes,6,opt,name=health_check_period,json=healthCheckPeriod,proto3" json:"health_check_period,omitempty"`
+	// Supabase 特定配置
+	Schema                    string                       `protobuf:"bytes,7,opt,name=schema,proto3" json:"schema,omitempty"`                                                                           // 默认 schema (如 "kratos_template")
+	PreparedStatementsEnabled bool                         `protobuf:"varint,8,opt,name=prepared_statements_enabled,json=preparedStatementsEnabled,proto3" json:"prepared_statements_enabled,omitempty"` // Pooler 模式建议设为 false
+	Transaction               *Data_PostgreSQL_Transaction `protobuf:"bytes,9,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	PoolMetricsEnabled        *bool                        `protobuf:"varint,10,opt,name=pool_metrics_enabled,json=poolMetricsEnabled,proto3,oneof" json:"pool_metrics_enabled,omitempty"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *Data_PostgreSQL) Reset() {
+	*x = Data_PostgreSQL{}
+	mi := &file_configs_conf_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_PostgreSQL) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_PostgreSQL) ProtoMessage() {}
+
+func (x *Data_PostgreSQL) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_PostgreSQL.ProtoReflect.Descriptor instead.
+func (*Data_PostgreSQL) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{2, 0}
+}
+
+func (x *Data_PostgreSQL) GetDsn() string {
+	if x != nil {
+		return x.Dsn
+	}
+	return ""
+}
+
+func (x *Data_PostgreSQL) GetMaxOpenConns() int32 {
+	if x != nil {
+		return x.MaxOpenConns
+	}
+	return 0
+}
+
+func (x *Data_PostgreSQL) GetMinOpenConns() int32 {
+	if x != nil {
+		return x.MinOpenConns
+	}
+	return 0
+}
+
+func (x *Data_PostgreSQL) GetMaxConnLifetime() *durationpb.Duration {
+	if x != nil {
+		return x.MaxConnLifetime
+	}
+	return nil
+}
+
+func (x *Data_PostgreSQL) GetMaxConnIdleTime() *durationpb.Duration {
+	if x != nil {
+		return x.MaxConnIdleTime
+	}
+	return nil
+}
+
+func (x *Data_PostgreSQL) GetHealthCheckPeriod() *durationpb.Duration {
+	if x != nil {
+		return x.HealthCheckPeriod
+	}
+	return nil
+}
+
+func (x *Data_PostgreSQL) GetSchema() string {
+	if x != nil {
+		return x.Schema
+	}
+	return ""
+}
+
+func (x *Data_PostgreSQL) GetPreparedStatementsEnabled() bool {
+	if x != nil {
+		return x.PreparedStatementsEnabled
+	}
+	return false
+}
+
+func (x *Data_PostgreSQL) GetTransaction() *Data_PostgreSQL_Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *Data_PostgreSQL) GetPoolMetricsEnabled() bool {
+	if x != nil && x.PoolMetricsEnabled != nil {
+		return *x.PoolMetricsEnabled
+	}
+	return false
+}
+
+// gRPC Client 配置（可选，用于服务间调用）
+type Data_Client struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Target        string                 `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"` // 目标地址，留空则不创建 client
+	Jwt           *Data_Client_JWT       `protobuf:"bytes,2,opt,name=jwt,proto3" json:"jwt,omitempty"`
+	MetadataKeys  []string               `protobuf:"bytes,3,rep,name=metadata_keys,json=metadataKeys,proto3" json:"metadata_keys,omitempty"` // 转发到下游的 header 列表（默认同 server.metadata_keys）
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data_Client) Reset() {
+	*x = Data_Client{}
+	mi := &file_configs_conf_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Client) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_Client) ProtoMessage() {}
+
+func (x *Data_Client) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_Client.ProtoReflect.Descriptor instead.
+func (*Data_Client) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{2, 1}
+}
+
+func (x *Data_Client) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *Data_Client) GetJwt() *Data_Client_JWT {
+	if x != nil {
+		return x.Jwt
+	}
+	return nil
+}
+
+func (x *Data_Client) GetMetadataKeys() []string {
+	if x != nil {
+		return x.MetadataKeys
+	}
+	return nil
+}
+
+type Data_PostgreSQL_Transaction struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	DefaultIsolation string                 `protobuf:"bytes,1,opt,name=default_isolation,json=defaultIsolation,proto3" json:"default_isolation,omitempty"`
+	DefaultTimeout   *durationpb.Duration   `protobuf:"bytes,2,opt,name=default_timeout,json=defaultTimeout,proto3" json:"default_timeout,omitempty"`
+	LockTimeout      *durationpb.Duration   `protobuf:"bytes,3,opt,name=lock_timeout,json=lockTimeout,proto3" json:"lock_timeout,omitempty"`
+	MaxRetries       int32                  `protobuf:"varint,4,opt,name=max_retries,json=maxRetries,proto3" json:"max_retries,omitempty"`
+	MetricsEnabled   *bool                  `protobuf:"varint,5,opt,name=metrics_enabled,json=metricsEnabled,proto3,oneof" json:"metrics_enabled,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Data_PostgreSQL_Transaction) Reset() {
+	*x = Data_PostgreSQL_Transaction{}
+	mi := &file_configs_conf_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_PostgreSQL_Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_PostgreSQL_Transaction) ProtoMessage() {}
+
+func (x *Data_PostgreSQL_Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_PostgreSQL_Transaction.ProtoReflect.Descriptor instead.
+func (*Data_PostgreSQL_Transaction) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{2, 0, 0}
+}
+
+func (x *Data_PostgreSQL_Transaction) GetDefaultIsolation() string {
+	if x != nil {
+		return x.DefaultIsolation
+	}
+	return ""
+}
+
+func (x *Data_PostgreSQL_Transaction) GetDefaultTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.DefaultTimeout
+	}
+	return nil
+}
+
+func (x *Data_PostgreSQL_Transaction) GetLockTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.LockTimeout
+	}
+	return nil
+}
+
+func (x *Data_PostgreSQL_Transaction) GetMaxRetries() int32 {
+	if x != nil {
+		return x.MaxRetries
+	}
+	return 0
+}
+
+func (x *Data_PostgreSQL_Transaction) GetMetricsEnabled() bool {
+	if x != nil && x.MetricsEnabled != nil {
+		return *x.MetricsEnabled
+	}
+	return false
+}
+
+type Data_Client_JWT struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Audience      string                 `protobuf:"bytes,1,opt,name=audience,proto3" json:"audience,omitempty"`
+	Disabled      bool                   `protobuf:"varint,2,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	HeaderKey     string                 `protobuf:"bytes,3,opt,name=header_key,json=headerKey,proto3" json:"header_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data_Client_JWT) Reset() {
+	*x = Data_Client_JWT{}
+	mi := &file_configs_conf_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Client_JWT) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_Client_JWT) ProtoMessage() {}
+
+func (x *Data_Client_JWT) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_Client_JWT.ProtoReflect.Descriptor instead.
+func (*Data_Client_JWT) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{2, 1, 0}
+}
+
+func (x *Data_Client_JWT) GetAudience() string {
+	if x != nil {
+		return x.Audience
+	}
+	return ""
+}
+
+func (x *Data_Client_JWT) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+func (x *Data_Client_JWT) GetHeaderKey() string {
+	if x != nil {
+		return x.HeaderKey
+	}
+	return ""
+}
+
+// Tier 描述单个档位的配额上限，0 表示不限。
+type UploadQuota_Tier struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	DailySessionLimit     int32                  `protobuf:"varint,1,opt,name=daily_session_limit,json=dailySessionLimit,proto3" json:"daily_session_limit,omitempty"`
+	ConcurrentUploadLimit int32                  `protobuf:"varint,2,opt,name=concurrent_upload_limit,json=concurrentUploadLimit,proto3" json:"concurrent_upload_limit,omitempty"`
+	StorageBytesLimit     int64                  `protobuf:"varint,3,opt,name=storage_bytes_limit,json=storageBytesLimit,proto3" json:"storage_bytes_limit,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *UploadQuota_Tier) Reset() {
+	*x = UploadQuota_Tier{}
+	mi := &file_configs_conf_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadQuota_Tier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadQuota_Tier) ProtoMessage() {}
+
+func (x *UploadQuota_Tier) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadQuota_Tier.ProtoReflect.Descriptor instead.
+func (*UploadQuota_Tier) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{4, 0}
+}
+
+func (x *UploadQuota_Tier) GetDailySessionLimit() int32 {
+	if x != nil {
+		return x.DailySessionLimit
+	}
+	return 0
+}
+
+func (x *UploadQuota_Tier) GetConcurrentUploadLimit() int32 {
+	if x != nil {
+		return x.ConcurrentUploadLimit
+	}
+	return 0
+}
+
+func (x *UploadQuota_Tier) GetStorageBytesLimit() int64 {
+	if x != nil {
+		return x.StorageBytesLimit
+	}
+	return 0
+}
+
+// ViewQualification 描述有效播放判定：会话内观看秒数或其占视频时长的比例任一达到阈值即计一次播放，
+// 同一用户在一个会话内至多计一次；两次进度事件间隔达到 session_window 视为新会话。
+type Engagement_ViewQualification struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 未配置时分别默认 30 秒与 0.5；显式配置为 0 表示不启用该项阈值。
+	MinWatchSeconds *float64             `protobuf:"fixed64,1,opt,name=min_watch_seconds,json=minWatchSeconds,proto3,oneof" json:"min_watch_seconds,omitempty"`
+	MinWatchRatio   *float64             `protobuf:"fixed64,2,opt,name=min_watch_ratio,json=minWatchRatio,proto3,oneof" json:"min_watch_ratio,omitempty"`
+	SessionWindow   *durationpb.Duration `protobuf:"bytes,3,opt,name=session_window,json=sessionWindow,proto3" json:"session_window,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *Server_JWT) Reset() {
-	*x = Server_JWT{}
-	mi := &file_configs_conf_proto_msgTypes[11]
+func (x *Engagement_ViewQualification) Reset() {
+	*x = Engagement_ViewQualification{}
+	mi := &file_configs_conf_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Server_JWT) String() string {
+func (x *Engagement_ViewQualification) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Server_JWT) ProtoMessage() {}
+func (*Engagement_ViewQualification) ProtoMessage() {}
 
-func (x *Server_JWT) ProtoReflect() protoreflect.Message {
-	mi := &file_configs_conf_proto_msgTypes[11]
+func (x *Engagement_ViewQualification) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -894,63 +2062,58 @@ func (x *Server_JWT) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Server_JWT.ProtoReflect.Descriptor instead.
-func (*Server_JWT) Descriptor() ([]byte, []int) {
-	return file_configs_conf_proto_rawDescGZIP(), []int{1, 1}
-}
-
-func (x *Server_JWT) GetExpectedAudience() string {
-	if x != nil {
-		return x.ExpectedAudience
-	}
-	return ""
+// Deprecated: Use Engagement_ViewQualification.ProtoReflect.Descriptor instead.
+func (*Engagement_ViewQualification) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{6, 0}
 }
 
-func (x *Server_JWT) GetSkipValidate() bool {
-	if x != nil {
-		return x.SkipValidate
+func (x *Engagement_ViewQualification) GetMinWatchSeconds() float64 {
+	if x != nil && x.MinWatchSeconds != nil {
+		return *x.MinWatchSeconds
 	}
-	return false
+	return 0
 }
 
-func (x *Server_JWT) GetRequired() bool {
-	if x != nil {
-		return x.Required
+func (x *Engagement_ViewQualification) GetMinWatchRatio() float64 {
+	if x != nil && x.MinWatchRatio != nil {
+		return *x.MinWatchRatio
 	}
-	return false
+	return 0
 }
 
-func (x *Server_JWT) GetHeaderKey() string {
+func (x *Engagement_ViewQualification) GetSessionWindow() *durationpb.Duration {
 	if x != nil {
-		return x.HeaderKey
+		return x.SessionWindow
 	}
-	return ""
+	return nil
 }
 
-type Server_Handlers struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	DefaultTimeout *durationpb.Duration   `protobuf:"bytes,1,opt,name=default_timeout,json=defaultTimeout,proto3" json:"default_timeout,omitempty"`
-	CommandTimeout *durationpb.Duration   `protobuf:"bytes,2,opt,name=command_timeout,json=commandTimeout,proto3" json:"command_timeout,omitempty"`
-	QueryTimeout   *durationpb.Duration   `protobuf:"bytes,3,opt,name=query_timeout,json=queryTimeout,proto3" json:"query_timeout,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+// Rollups 描述分时统计桶的保留期；过期桶由 Engagement Runner 按 prune_interval 定期分批清理。
+type Engagement_Rollups struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	HourlyRetention *durationpb.Duration   `protobuf:"bytes,1,opt,name=hourly_retention,json=hourlyRetention,proto3" json:"hourly_retention,omitempty"` // 小时桶保留期，默认 2160h（90 天）
+	DailyRetention  *durationpb.Duration   `protobuf:"bytes,2,opt,name=daily_retention,json=dailyRetention,proto3" json:"daily_retention,omitempty"`    // 天级桶保留期，默认 17520h（730 天）
+	PruneInterval   *durationpb.Duration   `protobuf:"bytes,3,opt,name=prune_interval,json=pruneInterval,proto3" json:"prune_interval,omitempty"`       // 清理周期，默认 1h
+	PruneBatchSize  int32                  `protobuf:"varint,4,opt,name=prune_batch_size,json=pruneBatchSize,proto3" json:"prune_batch_size,omitempty"` // 每个事务每张表删除的桶数，默认 1000
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *Server_Handlers) Reset() {
-	*x = Server_Handlers{}
-	mi := &file_configs_conf_proto_msgTypes[12]
+func (x *Engagement_Rollups) Reset() {
+	*x = Engagement_Rollups{}
+	mi := &file_configs_conf_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Server_Handlers) String() string {
+func (x *Engagement_Rollups) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Server_Handlers) ProtoMessage() {}
+func (*Engagement_Rollups) ProtoMessage() {}
 
-func (x *Server_Handlers) ProtoReflect() protoreflect.Message {
-	mi := &file_configs_conf_proto_msgTypes[12]
+func (x *Engagement_Rollups) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -961,67 +2124,69 @@ func (x *Server_Handlers) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Server_Handlers.ProtoReflect.Descriptor instead.
-func (*Server_Handlers) Descriptor() ([]byte, []int) {
-	return file_configs_conf_proto_rawDescGZIP(), []int{1, 2}
+// Deprecated: Use Engagement_Rollups.ProtoReflect.Descriptor instead.
+func (*Engagement_Rollups) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{6, 1}
 }
 
-func (x *Server_Handlers) GetDefaultTimeout() *durationpb.Duration {
+func (x *Engagement_Rollups) GetHourlyRetention() *durationpb.Duration {
 	if x != nil {
-		return x.DefaultTimeout
+		return x.HourlyRetention
 	}
 	return nil
 }
 
-func (x *Server_Handlers) GetCommandTimeout() *durationpb.Duration {
+func (x *Engagement_Rollups) GetDailyRetention() *durationpb.Duration {
 	if x != nil {
-		return x.CommandTimeout
+		return x.DailyRetention
 	}
 	return nil
 }
 
-func (x *Server_Handlers) GetQueryTimeout() *durationpb.Duration {
+func (x *Engagement_Rollups) GetPruneInterval() *durationpb.Duration {
 	if x != nil {
-		return x.QueryTimeout
+		return x.PruneInterval
 	}
 	return nil
 }
 
-// PostgreSQL 数据库配置（Supabase 专用）
-type Data_PostgreSQL struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// DSN 连接串（必填）
-	Dsn string `protobuf:"bytes,1,opt,name=dsn,proto3" json:"dsn,omitempty"`
-	// 连接池配置
-	MaxOpenConns      int32                `protobuf:"varint,2,opt,name=max_open_conns,json=maxOpenConns,proto3" json:"max_open_conns,omitempty"`
-	MinOpenConns      int32                `protobuf:"varint,3,opt,name=min_open_conns,json=minOpenConns,proto3" json:"min_open_conns,omitempty"`
-	MaxConnLifetime   *durationpb.Duration `protobuf:"bytes,4,opt,name=max_conn_lifetime,json=maxConnLifetime,proto3" json:"max_conn_lifetime,omitempty"`
-	MaxConnIdleTime   *durationpb.Duration `protobuf:"bytes,5,opt,name=max_conn_idle_time,json=maxConnIdleTime,proto3" json:"max_conn_idle_time,omitempty"`
-	HealthCheckPeriod *durationpb.Duration `protobuf:"bytes,6,opt,name=health_check_period,json=healthCheckPeriod,proto3" json:"health_check_period,omitempty"`
-	// Supabase 特定配置
-	Schema                    string                       `protobuf:"bytes,7,opt,name=schema,proto3" json:"schema,omitempty"`                                                                           // 默认 schema (如 "kratos_template")
-	PreparedStatementsEnabled bool                         `protobuf:"varint,8,opt,name=prepared_statements_enabled,json=preparedStatementsEnabled,proto3" json:"prepared_statements_enabled,omitempty"` // Pooler 模式建议设为 false
-	Transaction               *Data_PostgreSQL_Transaction `protobuf:"bytes,9,opt,name=transaction,proto3" json:"transaction,omitempty"`
-	PoolMetricsEnabled        *bool                        `protobuf:"varint,10,opt,name=pool_metrics_enabled,json=poolMetricsEnabled,proto3,oneof" json:"pool_metrics_enabled,omitempty"`
-	unknownFields             protoimpl.UnknownFields
-	sizeCache                 protoimpl.SizeCache
+func (x *Engagement_Rollups) GetPruneBatchSize() int32 {
+	if x != nil {
+		return x.PruneBatchSize
+	}
+	return 0
 }
 
-func (x *Data_PostgreSQL) Reset() {
-	*x = Data_PostgreSQL{}
-	mi := &file_configs_conf_proto_msgTypes[13]
+// Trending 描述热度榜计算：Engagement Runner 每 interval 对最近 window 内的小时统计桶按 half_life 衰减加权，
+// 生成新的热度快照；旧快照保留 snapshot_ttl，保证翻页期间游标仍然有效。
+type Engagement_Trending struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Interval      *durationpb.Duration   `protobuf:"bytes,1,opt,name=interval,proto3" json:"interval,omitempty"`                          // 计算周期，默认 10m
+	Window        *durationpb.Duration   `protobuf:"bytes,2,opt,name=window,proto3" json:"window,omitempty"`                              // 参与计算的时间窗口，默认 72h
+	HalfLife      *durationpb.Duration   `protobuf:"bytes,3,opt,name=half_life,json=halfLife,proto3" json:"half_life,omitempty"`          // 半衰期，默认 24h
+	LikeWeight    float64                `protobuf:"fixed64,4,opt,name=like_weight,json=likeWeight,proto3" json:"like_weight,omitempty"`  // 点赞净增量权重，默认 3
+	ViewWeight    float64                `protobuf:"fixed64,5,opt,name=view_weight,json=viewWeight,proto3" json:"view_weight,omitempty"`  // 有效播放权重，默认 1
+	MaxVideos     int32                  `protobuf:"varint,6,opt,name=max_videos,json=maxVideos,proto3" json:"max_videos,omitempty"`      // 单个快照最多入榜视频数，默认 1000
+	SnapshotTtl   *durationpb.Duration   `protobuf:"bytes,7,opt,name=snapshot_ttl,json=snapshotTtl,proto3" json:"snapshot_ttl,omitempty"` // 旧快照保留期，默认 1h
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Engagement_Trending) Reset() {
+	*x = Engagement_Trending{}
+	mi := &file_configs_conf_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Data_PostgreSQL) String() string {
+func (x *Engagement_Trending) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Data_PostgreSQL) ProtoMessage() {}
+func (*Engagement_Trending) ProtoMessage() {}
 
-func (x *Data_PostgreSQL) ProtoReflect() protoreflect.Message {
-	mi := &file_configs_conf_proto_msgTypes[13]
+func (x *Engagement_Trending) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1032,106 +2197,85 @@ func (x *Data_PostgreSQL) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Data_PostgreSQL.ProtoReflect.Descriptor instead.
-func (*Data_PostgreSQL) Descriptor() ([]byte, []int) {
-	return file_configs_conf_proto_rawDescGZIP(), []int{2, 0}
-}
-
-func (x *Data_PostgreSQL) GetDsn() string {
-	if x != nil {
-		return x.Dsn
-	}
-	return ""
-}
-
-func (x *Data_PostgreSQL) GetMaxOpenConns() int32 {
-	if x != nil {
-		return x.MaxOpenConns
-	}
-	return 0
+// Deprecated: Use Engagement_Trending.ProtoReflect.Descriptor instead.
+func (*Engagement_Trending) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{6, 2}
 }
 
-func (x *Data_PostgreSQL) GetMinOpenConns() int32 {
+func (x *Engagement_Trending) GetInterval() *durationpb.Duration {
 	if x != nil {
-		return x.MinOpenConns
+		return x.Interval
 	}
-	return 0
+	return nil
 }
 
-func (x *Data_PostgreSQL) GetMaxConnLifetime() *durationpb.Duration {
+func (x *Engagement_Trending) GetWindow() *durationpb.Duration {
 	if x != nil {
-		return x.MaxConnLifetime
+		return x.Window
 	}
 	return nil
 }
 
-func (x *Data_PostgreSQL) GetMaxConnIdleTime() *durationpb.Duration {
+func (x *Engagement_Trending) GetHalfLife() *durationpb.Duration {
 	if x != nil {
-		return x.MaxConnIdleTime
+		return x.HalfLife
 	}
 	return nil
 }
 
-func (x *Data_PostgreSQL) GetHealthCheckPeriod() *durationpb.Duration {
+func (x *Engagement_Trending) GetLikeWeight() float64 {
 	if x != nil {
-		return x.HealthCheckPeriod
+		return x.LikeWeight
 	}
-	return nil
+	return 0
 }
 
-func (x *Data_PostgreSQL) GetSchema() string {
+func (x *Engagement_Trending) GetViewWeight() float64 {
 	if x != nil {
-		return x.Schema
+		return x.ViewWeight
 	}
-	return ""
+	return 0
 }
 
-func (x *Data_PostgreSQL) GetPreparedStatementsEnabled() bool {
+func (x *Engagement_Trending) GetMaxVideos() int32 {
 	if x != nil {
-		return x.PreparedStatementsEnabled
+		return x.MaxVideos
 	}
-	return false
+	return 0
 }
 
-func (x *Data_PostgreSQL) GetTransaction() *Data_PostgreSQL_Transaction {
+func (x *Engagement_Trending) GetSnapshotTtl() *durationpb.Duration {
 	if x != nil {
-		return x.Transaction
+		return x.SnapshotTtl
 	}
 	return nil
 }
 
-func (x *Data_PostgreSQL) GetPoolMetricsEnabled() bool {
-	if x != nil && x.PoolMetricsEnabled != nil {
-		return *x.PoolMetricsEnabled
-	}
-	return false
-}
-
-// gRPC Client 配置（可选，用于服务间调用）
-type Data_Client struct {
+// Purge 描述投影清理：视频删除/归档、用户删除后，Engagement Runner 每 interval 扫描待处理的清理请求，
+// 每个事务对每张投影表至多删除 batch_size 行，直到清理干净。
+type Engagement_Purge struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Target        string                 `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"` // 目标地址，留空则不创建 client
-	Jwt           *Data_Client_JWT       `protobuf:"bytes,2,opt,name=jwt,proto3" json:"jwt,omitempty"`
-	MetadataKeys  []string               `protobuf:"bytes,3,rep,name=metadata_keys,json=metadataKeys,proto3" json:"metadata_keys,omitempty"` // 转发到下游的 header 列表（默认同 server.metadata_keys）
+	Interval      *durationpb.Duration   `protobuf:"bytes,1,opt,name=interval,proto3" json:"interval,omitempty"`                     // 扫描周期，默认 30s
+	BatchSize     int32                  `protobuf:"varint,2,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"` // 每批每表删除行数，默认 500
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Data_Client) Reset() {
-	*x = Data_Client{}
-	mi := &file_configs_conf_proto_msgTypes[14]
+func (x *Engagement_Purge) Reset() {
+	*x = Engagement_Purge{}
+	mi := &file_configs_conf_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Data_Client) String() string {
+func (x *Engagement_Purge) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Data_Client) ProtoMessage() {}
+func (*Engagement_Purge) ProtoMessage() {}
 
-func (x *Data_Client) ProtoReflect() protoreflect.Message {
-	mi := &file_configs_conf_proto_msgTypes[14]
+func (x *Engagement_Purge) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1142,58 +2286,51 @@ func (x *Data_Client) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Data_Client.ProtoReflect.Descriptor instead.
-func (*Data_Client) Descriptor() ([]byte, []int) {
-	return file_configs_conf_proto_rawDescGZIP(), []int{2, 1}
-}
-
-func (x *Data_Client) GetTarget() string {
-	if x != nil {
-		return x.Target
-	}
-	return ""
+// Deprecated: Use Engagement_Purge.ProtoReflect.Descriptor instead.
+func (*Engagement_Purge) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{6, 3}
 }
 
-func (x *Data_Client) GetJwt() *Data_Client_JWT {
+func (x *Engagement_Purge) GetInterval() *durationpb.Duration {
 	if x != nil {
-		return x.Jwt
+		return x.Interval
 	}
 	return nil
 }
 
-func (x *Data_Client) GetMetadataKeys() []string {
+func (x *Engagement_Purge) GetBatchSize() int32 {
 	if x != nil {
-		return x.MetadataKeys
+		return x.BatchSize
 	}
-	return nil
+	return 0
 }
 
-type Data_PostgreSQL_Transaction struct {
+// Counters 描述累计统计的分片计数：shards > 1 时热门视频的并发增量分散写入 shards 个分片行，读取时累加；
+// 后台压实循环每 compact_interval 将至多 compact_batch_size 个分片行折叠回主行。
+type Engagement_Counters struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
-	DefaultIsolation string                 `protobuf:"bytes,1,opt,name=default_isolation,json=defaultIsolation,proto3" json:"default_isolation,omitempty"`
-	DefaultTimeout   *durationpb.Duration   `protobuf:"bytes,2,opt,name=default_timeout,json=defaultTimeout,proto3" json:"default_timeout,omitempty"`
-	LockTimeout      *durationpb.Duration   `protobuf:"bytes,3,opt,name=lock_timeout,json=lockTimeout,proto3" json:"lock_timeout,omitempty"`
-	MaxRetries       int32                  `protobuf:"varint,4,opt,name=max_retries,json=maxRetries,proto3" json:"max_retries,omitempty"`
-	MetricsEnabled   *bool                  `protobuf:"varint,5,opt,name=metrics_enabled,json=metricsEnabled,proto3,oneof" json:"metrics_enabled,omitempty"`
+	Shards           int32                  `protobuf:"varint,1,opt,name=shards,proto3" json:"shards,omitempty"`                                               // 分片数，0 或 1 表示直接更新主行（默认）
+	CompactInterval  *durationpb.Duration   `protobuf:"bytes,2,opt,name=compact_interval,json=compactInterval,proto3" json:"compact_interval,omitempty"`       // 压实周期，默认 10s
+	CompactBatchSize int32                  `protobuf:"varint,3,opt,name=compact_batch_size,json=compactBatchSize,proto3" json:"compact_batch_size,omitempty"` // 每个事务折叠的分片行数，默认 1000
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
 
-func (x *Data_PostgreSQL_Transaction) Reset() {
-	*x = Data_PostgreSQL_Transaction{}
-	mi := &file_configs_conf_proto_msgTypes[15]
+func (x *Engagement_Counters) Reset() {
+	*x = Engagement_Counters{}
+	mi := &file_configs_conf_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Data_PostgreSQL_Transaction) String() string {
+func (x *Engagement_Counters) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Data_PostgreSQL_Transaction) ProtoMessage() {}
+func (*Engagement_Counters) ProtoMessage() {}
 
-func (x *Data_PostgreSQL_Transaction) ProtoReflect() protoreflect.Message {
-	mi := &file_configs_conf_proto_msgTypes[15]
+func (x *Engagement_Counters) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1204,70 +2341,57 @@ func (x *Data_PostgreSQL_Transaction) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Data_PostgreSQL_Transaction.ProtoReflect.Descriptor instead.
-func (*Data_PostgreSQL_Transaction) Descriptor() ([]byte, []int) {
-	return file_configs_conf_proto_rawDescGZIP(), []int{2, 0, 0}
-}
-
-func (x *Data_PostgreSQL_Transaction) GetDefaultIsolation() string {
-	if x != nil {
-		return x.DefaultIsolation
-	}
-	return ""
+// Deprecated: Use Engagement_Counters.ProtoReflect.Descriptor instead.
+func (*Engagement_Counters) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{6, 4}
 }
 
-func (x *Data_PostgreSQL_Transaction) GetDefaultTimeout() *durationpb.Duration {
+func (x *Engagement_Counters) GetShards() int32 {
 	if x != nil {
-		return x.DefaultTimeout
+		return x.Shards
 	}
-	return nil
+	return 0
 }
 
-func (x *Data_PostgreSQL_Transaction) GetLockTimeout() *durationpb.Duration {
+func (x *Engagement_Counters) GetCompactInterval() *durationpb.Duration {
 	if x != nil {
-		return x.LockTimeout
+		return x.CompactInterval
 	}
 	return nil
 }
 
-func (x *Data_PostgreSQL_Transaction) GetMaxRetries() int32 {
+func (x *Engagement_Counters) GetCompactBatchSize() int32 {
 	if x != nil {
-		return x.MaxRetries
+		return x.CompactBatchSize
 	}
 	return 0
 }
 
-func (x *Data_PostgreSQL_Transaction) GetMetricsEnabled() bool {
-	if x != nil && x.MetricsEnabled != nil {
-		return *x.MetricsEnabled
-	}
-	return false
-}
-
-type Data_Client_JWT struct {
+// Batch 描述批量消费：max_events > 1 时攒够 max_events 条或等待 max_wait 后，在同一事务内合并写入一批事件，
+// 提交后再统一确认消息；0 或 1 表示逐条消费（默认）。
+type Engagement_Batch struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Audience      string                 `protobuf:"bytes,1,opt,name=audience,proto3" json:"audience,omitempty"`
-	Disabled      bool                   `protobuf:"varint,2,opt,name=disabled,proto3" json:"disabled,omitempty"`
-	HeaderKey     string                 `protobuf:"bytes,3,opt,name=header_key,json=headerKey,proto3" json:"header_key,omitempty"`
+	MaxEvents     int32                  `protobuf:"varint,1,opt,name=max_events,json=maxEvents,proto3" json:"max_events,omitempty"` // 每批最多事件数
+	MaxWait       *durationpb.Duration   `protobuf:"bytes,2,opt,name=max_wait,json=maxWait,proto3" json:"max_wait,omitempty"`        // 首条事件到达后最多等待时长，默认 100ms
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Data_Client_JWT) Reset() {
-	*x = Data_Client_JWT{}
-	mi := &file_configs_conf_proto_msgTypes[16]
+func (x *Engagement_Batch) Reset() {
+	*x = Engagement_Batch{}
+	mi := &file_configs_conf_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Data_Client_JWT) String() string {
+func (x *Engagement_Batch) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Data_Client_JWT) ProtoMessage() {}
+func (*Engagement_Batch) ProtoMessage() {}
 
-func (x *Data_Client_JWT) ProtoReflect() protoreflect.Message {
-	mi := &file_configs_conf_proto_msgTypes[16]
+func (x *Engagement_Batch) ProtoReflect() protoreflect.Message {
+	mi := &file_configs_conf_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1278,30 +2402,23 @@ func (x *Data_Client_JWT) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Data_Client_JWT.ProtoReflect.Descriptor instead.
-func (*Data_Client_JWT) Descriptor() ([]byte, []int) {
-	return file_configs_conf_proto_rawDescGZIP(), []int{2, 1, 0}
-}
-
-func (x *Data_Client_JWT) GetAudience() string {
-	if x != nil {
-		return x.Audience
-	}
-	return ""
+// Deprecated: Use Engagement_Batch.ProtoReflect.Descriptor instead.
+func (*Engagement_Batch) Descriptor() ([]byte, []int) {
+	return file_configs_conf_proto_rawDescGZIP(), []int{6, 5}
 }
 
-func (x *Data_Client_JWT) GetDisabled() bool {
+func (x *Engagement_Batch) GetMaxEvents() int32 {
 	if x != nil {
-		return x.Disabled
+		return x.MaxEvents
 	}
-	return false
+	return 0
 }
 
-func (x *Data_Client_JWT) GetHeaderKey() string {
+func (x *Engagement_Batch) GetMaxWait() *durationpb.Duration {
 	if x != nil {
-		return x.HeaderKey
+		return x.MaxWait
 	}
-	return ""
+	return nil
 }
 
 type Observability_Tracing struct {
@@ -1327,7 +2444,7 @@ type Observability_Tracing struct {
 
 func (x *Observability_Tracing) Reset() {
 	*x = Observability_Tracing{}
-	mi := &file_configs_conf_proto_msgTypes[17]
+	mi := &file_configs_conf_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1339,7 +2456,7 @@ func (x *Observability_Tracing) String() string {
 func (*Observability_Tracing) ProtoMessage() {}
 
 func (x *Observability_Tracing) ProtoReflect() protoreflect.Message {
-	mi := &file_configs_conf_proto_msgTypes[17]
+	mi := &file_configs_conf_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1352,7 +2469,7 @@ func (x *Observability_Tracing) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Observability_Tracing.ProtoReflect.Descriptor instead.
 func (*Observability_Tracing) Descriptor() ([]byte, []int) {
-	return file_configs_conf_proto_rawDescGZIP(), []int{4, 0}
+	return file_configs_conf_proto_rawDescGZIP(), []int{7, 0}
 }
 
 func (x *Observability_Tracing) GetEnabled() bool {
@@ -1479,7 +2596,7 @@ type Observability_Metrics struct {
 
 func (x *Observability_Metrics) Reset() {
 	*x = Observability_Metrics{}
-	mi := &file_configs_conf_proto_msgTypes[18]
+	mi := &file_configs_conf_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1491,7 +2608,7 @@ func (x *Observability_Metrics) String() string {
 func (*Observability_Metrics) ProtoMessage() {}
 
 func (x *Observability_Metrics) ProtoReflect() protoreflect.Message {
-	mi := &file_configs_conf_proto_msgTypes[18]
+	mi := &file_configs_conf_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1504,7 +2621,7 @@ func (x *Observability_Metrics) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Observability_Metrics.ProtoReflect.Descriptor instead.
 func (*Observability_Metrics) Descriptor() ([]byte, []int) {
-	return file_configs_conf_proto_rawDescGZIP(), []int{4, 1}
+	return file_configs_conf_proto_rawDescGZIP(), []int{7, 1}
 }
 
 func (x *Observability_Metrics) GetEnabled() bool {
@@ -1589,13 +2706,18 @@ var File_configs_conf_proto protoreflect.FileDescriptor
 const file_configs_conf_proto_rawDesc = "" +
 	"\n" +
 	"\x12configs/conf.proto\x12\n" +
-	"kratos.api\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bbuf/validate/validate.proto\"\xf6\x01\n" +
+	"kratos.api\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bbuf/validate/validate.proto\"\x9c\x03\n" +
 	"\tBootstrap\x12*\n" +
 	"\x06server\x18\x01 \x01(\v2\x12.kratos.api.ServerR\x06server\x12$\n" +
 	"\x04data\x18\x02 \x01(\v2\x10.kratos.api.DataR\x04data\x12?\n" +
 	"\robservability\x18\x03 \x01(\v2\x19.kratos.api.ObservabilityR\robservability\x123\n" +
 	"\tmessaging\x18\x04 \x01(\v2\x15.kratos.api.MessagingR\tmessaging\x12!\n" +
-	"\x03gcs\x18\x05 \x01(\v2\x0f.kratos.api.GCSR\x03gcs\"\x92\x05\n" +
+	"\x03gcs\x18\x05 \x01(\v2\x0f.kratos.api.GCSR\x03gcs\x12:\n" +
+	"\fupload_quota\x18\x06 \x01(\v2\x17.kratos.api.UploadQuotaR\vuploadQuota\x120\n" +
+	"\bplayback\x18\a \x01(\v2\x14.kratos.api.PlaybackR\bplayback\x126\n" +
+	"\n" +
+	"engagement\x18\b \x01(\v2\x16.kratos.api.EngagementR\n" +
+	"engagement\"\x92\x05\n" +
 	"\x06Server\x12+\n" +
 	"\x04grpc\x18\x01 \x01(\v2\x17.kratos.api.Server.GRPCR\x04grpc\x12(\n" +
 	"\x03jwt\x18\x02 \x01(\v2\x16.kratos.api.Server.JWTR\x03jwt\x127\n" +
@@ -1655,7 +2777,71 @@ const file_configs_conf_proto_rawDesc = "" +
 	"project_id\x18\x01 \x01(\tR\tprojectId\x12\x1f\n" +
 	"\x06bucket\x18\x02 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x06bucket\x12=\n" +
 	"\x16signer_service_account\x18\x03 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x14signerServiceAccount\x12?\n" +
-	"\x0esigned_url_ttl\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\fsignedUrlTtl\"\x8a\x0e\n" +
+	"\x0esigned_url_ttl\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\fsignedUrlTtl\"\xfe\x02\n" +
+	"\vUploadQuota\x12!\n" +
+	"\fdefault_tier\x18\x01 \x01(\tR\vdefaultTier\x128\n" +
+	"\x05tiers\x18\x02 \x03(\v2\".kratos.api.UploadQuota.TiersEntryR\x05tiers\x1a\xb9\x01\n" +
+	"\x04Tier\x127\n" +
+	"\x13daily_session_limit\x18\x01 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\x11dailySessionLimit\x12?\n" +
+	"\x17concurrent_upload_limit\x18\x02 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\x15concurrentUploadLimit\x127\n" +
+	"\x13storage_bytes_limit\x18\x03 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\x11storageBytesLimit\x1aV\n" +
+	"\n" +
+	"TiersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x122\n" +
+	"\x05value\x18\x02 \x01(\v2\x1c.kratos.api.UploadQuota.TierR\x05value:\x028\x01\"\xbd\x02\n" +
+	"\bPlayback\x12\x16\n" +
+	"\x06signer\x18\x01 \x01(\tR\x06signer\x12\x19\n" +
+	"\bkey_name\x18\x02 \x01(\tR\akeyName\x12\x10\n" +
+	"\x03key\x18\x03 \x01(\tR\x03key\x12\x19\n" +
+	"\bcdn_host\x18\x04 \x01(\tR\acdnHost\x12<\n" +
+	"\fplaylist_ttl\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\vplaylistTtl\x12>\n" +
+	"\rthumbnail_ttl\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\fthumbnailTtl\x12#\n" +
+	"\rsigned_cookie\x18\a \x01(\bR\fsignedCookie\x12.\n" +
+	"\x13allow_ephemeral_key\x18\b \x01(\bR\x11allowEphemeralKey\"\xf3\f\n" +
+	"\n" +
+	"Engagement\x12>\n" +
+	"\x05views\x18\x01 \x01(\v2(.kratos.api.Engagement.ViewQualificationR\x05views\x128\n" +
+	"\arollups\x18\x02 \x01(\v2\x1e.kratos.api.Engagement.RollupsR\arollups\x12;\n" +
+	"\btrending\x18\x03 \x01(\v2\x1f.kratos.api.Engagement.TrendingR\btrending\x122\n" +
+	"\x05purge\x18\x04 \x01(\v2\x1c.kratos.api.Engagement.PurgeR\x05purge\x12;\n" +
+	"\bcounters\x18\x05 \x01(\v2\x1f.kratos.api.Engagement.CountersR\bcounters\x122\n" +
+	"\x05batch\x18\x06 \x01(\v2\x1c.kratos.api.Engagement.BatchR\x05batch\x1a\x86\x02\n" +
+	"\x11ViewQualification\x12?\n" +
+	"\x11min_watch_seconds\x18\x01 \x01(\x01B\x0e\xbaH\v\x12\t)\x00\x00\x00\x00\x00\x00\x00\x00H\x00R\x0fminWatchSeconds\x88\x01\x01\x12D\n" +
+	"\x0fmin_watch_ratio\x18\x02 \x01(\x01B\x17\xbaH\x14\x12\x12\x19\x00\x00\x00\x00\x00\x00\xf0?)\x00\x00\x00\x00\x00\x00\x00\x00H\x01R\rminWatchRatio\x88\x01\x01\x12@\n" +
+	"\x0esession_window\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\rsessionWindowB\x14\n" +
+	"\x12_min_watch_secondsB\x12\n" +
+	"\x10_min_watch_ratio\x1a\x88\x02\n" +
+	"\aRollups\x12D\n" +
+	"\x10hourly_retention\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\x0fhourlyRetention\x12B\n" +
+	"\x0fdaily_retention\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x0edailyRetention\x12@\n" +
+	"\x0eprune_interval\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\rpruneInterval\x121\n" +
+	"\x10prune_batch_size\x18\x04 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\x0epruneBatchSize\x1a\xf4\x02\n" +
+	"\bTrending\x125\n" +
+	"\binterval\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\binterval\x121\n" +
+	"\x06window\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x06window\x126\n" +
+	"\thalf_life\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\bhalfLife\x12/\n" +
+	"\vlike_weight\x18\x04 \x01(\x01B\x0e\xbaH\v\x12\t)\x00\x00\x00\x00\x00\x00\x00\x00R\n" +
+	"likeWeight\x12/\n" +
+	"\vview_weight\x18\x05 \x01(\x01B\x0e\xbaH\v\x12\t)\x00\x00\x00\x00\x00\x00\x00\x00R\n" +
+	"viewWeight\x12&\n" +
+	"\n" +
+	"max_videos\x18\x06 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\tmaxVideos\x12<\n" +
+	"\fsnapshot_ttl\x18\a \x01(\v2\x19.google.protobuf.DurationR\vsnapshotTtl\x1af\n" +
+	"\x05Purge\x125\n" +
+	"\binterval\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\binterval\x12&\n" +
+	"\n" +
+	"batch_size\x18\x02 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\tbatchSize\x1a\xab\x01\n" +
+	"\bCounters\x12\"\n" +
+	"\x06shards\x18\x01 \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\x80\b(\x00R\x06shards\x12D\n" +
+	"\x10compact_interval\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x0fcompactInterval\x125\n" +
+	"\x12compact_batch_size\x18\x03 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\x10compactBatchSize\x1ah\n" +
+	"\x05Batch\x12)\n" +
+	"\n" +
+	"max_events\x18\x01 \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\x90N(\x00R\tmaxEvents\x124\n" +
+	"\bmax_wait\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\amaxWait\"\x8a\x0e\n" +
 	"\rObservability\x12\\\n" +
 	"\x11global_attributes\x18\x01 \x03(\v2/.kratos.api.Observability.GlobalAttributesEntryR\x10globalAttributes\x12;\n" +
 	"\atracing\x18\x02 \x01(\v2!.kratos.api.Observability.TracingR\atracing\x12;\n" +
@@ -1708,18 +2894,19 @@ const file_configs_conf_proto_rawDesc = "" +
 	"\x14_grpc_include_health\x1aC\n" +
 	"\x15GlobalAttributesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xf7\x02\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xac\x03\n" +
 	"\tMessaging\x12\x16\n" +
 	"\x06schema\x18\x01 \x01(\tR\x06schema\x129\n" +
 	"\x06topics\x18\x02 \x03(\v2!.kratos.api.Messaging.TopicsEntryR\x06topics\x123\n" +
 	"\x06outbox\x18\x03 \x01(\v2\x1b.kratos.api.OutboxPublisherR\x06outbox\x12<\n" +
-	"\ainboxes\x18\x04 \x03(\v2\".kratos.api.Messaging.InboxesEntryR\ainboxes\x1aM\n" +
+	"\ainboxes\x18\x04 \x03(\v2\".kratos.api.Messaging.InboxesEntryR\ainboxes\x123\n" +
+	"\tretention\x18\x05 \x01(\v2\x15.kratos.api.RetentionR\tretention\x1aM\n" +
 	"\vTopicsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12(\n" +
 	"\x05value\x18\x02 \x01(\v2\x12.kratos.api.PubSubR\x05value:\x028\x01\x1aU\n" +
 	"\fInboxesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12/\n" +
-	"\x05value\x18\x02 \x01(\v2\x19.kratos.api.InboxConsumerR\x05value:\x028\x01\"\xf4\x03\n" +
+	"\x05value\x18\x02 \x01(\v2\x19.kratos.api.InboxConsumerR\x05value:\x028\x01\"\xe7\x05\n" +
 	"\x06PubSub\x12\x1d\n" +
 	"\n" +
 	"project_id\x18\x01 \x01(\tR\tprojectId\x12\x19\n" +
@@ -1733,7 +2920,32 @@ const file_configs_conf_proto_rawDesc = "" +
 	"\areceive\x18\t \x01(\v2\x13.kratos.api.ReceiveR\areceive\x122\n" +
 	"\x15exactly_once_delivery\x18\n" +
 	" \x01(\bR\x13exactlyOnceDelivery\x12/\n" +
-	"\x14dead_letter_topic_id\x18\v \x01(\tR\x11deadLetterTopicId\"\xab\x02\n" +
+	"\x14dead_letter_topic_id\x18\v \x01(\tR\x11deadLetterTopicId\x129\n" +
+	"\x04sink\x18\f \x01(\tB%\xbaH\"r R\x00R\x06pubsubR\x05kafkaR\x04natsR\awebhookR\x04sink\x12+\n" +
+	"\x05kafka\x18\r \x01(\v2\x15.kratos.api.KafkaSinkR\x05kafka\x12(\n" +
+	"\x04nats\x18\x0e \x01(\v2\x14.kratos.api.NatsSinkR\x04nats\x121\n" +
+	"\awebhook\x18\x0f \x01(\v2\x17.kratos.api.WebhookSinkR\awebhook\x12,\n" +
+	"\x12outbox_event_types\x18\x10 \x03(\tR\x10outboxEventTypes\"\xc0\x01\n" +
+	"\tKafkaSink\x12\x18\n" +
+	"\abrokers\x18\x01 \x03(\tR\abrokers\x12\x1b\n" +
+	"\tclient_id\x18\x02 \x01(\tR\bclientId\x12<\n" +
+	"\rrequired_acks\x18\x03 \x01(\tB\x17\xbaH\x14r\x12R\x00R\x03allR\x03oneR\x04noneR\frequiredAcks\x12>\n" +
+	"\rwrite_timeout\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\fwriteTimeout\"_\n" +
+	"\bNatsSink\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x16\n" +
+	"\x06stream\x18\x02 \x01(\tR\x06stream\x12)\n" +
+	"\x10credentials_file\x18\x03 \x01(\tR\x0fcredentialsFile\"H\n" +
+	"\vWebhookSink\x129\n" +
+	"\tendpoints\x18\x01 \x03(\v2\x1b.kratos.api.WebhookEndpointR\tendpoints\"\xdd\x02\n" +
+	"\x0fWebhookEndpoint\x12\x1b\n" +
+	"\x04name\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x04name\x12\x19\n" +
+	"\x03url\x18\x02 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x03url\x12\x1f\n" +
+	"\x06secret\x18\x03 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x06secret\x123\n" +
+	"\atimeout\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x12B\n" +
+	"\aheaders\x18\b \x03(\v2(.kratos.api.WebhookEndpoint.HeadersEntryR\aheaders\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01J\x04\b\x05\x10\x06J\x04\b\x06\x10\aJ\x04\b\a\x10\bR\fmax_attemptsR\x0finitial_backoffR\vmax_backoff\"\xab\x02\n" +
 	"\aReceive\x12%\n" +
 	"\x0enum_goroutines\x18\x01 \x01(\x05R\rnumGoroutines\x128\n" +
 	"\x18max_outstanding_messages\x18\x02 \x01(\x05R\x16maxOutstandingMessages\x122\n" +
@@ -1755,7 +2967,17 @@ const file_configs_conf_proto_rawDesc = "" +
 	"\x0fmetrics_enabled\x18\n" +
 	" \x01(\bH\x01R\x0emetricsEnabled\x88\x01\x01B\x12\n" +
 	"\x10_logging_enabledB\x12\n" +
-	"\x10_metrics_enabled\"\xe3\x01\n" +
+	"\x10_metrics_enabled\"\xac\x03\n" +
+	"\tRetention\x12W\n" +
+	"\x1aoutbox_published_retention\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\x18outboxPublishedRetention\x12U\n" +
+	"\x19inbox_processed_retention\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x17inboxProcessedRetention\x125\n" +
+	"\binterval\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\binterval\x12&\n" +
+	"\n" +
+	"batch_size\x18\x04 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\tbatchSize\x12\x18\n" +
+	"\aarchive\x18\x05 \x01(\bR\aarchive\x12\x1d\n" +
+	"\n" +
+	"export_dir\x18\x06 \x01(\tR\texportDir\x12W\n" +
+	"\x1aoutbox_abandoned_retention\x18\a \x01(\v2\x19.google.protobuf.DurationR\x18outboxAbandonedRetention\"\xe3\x01\n" +
 	"\rInboxConsumer\x12%\n" +
 	"\x0esource_service\x18\x01 \x01(\tR\rsourceService\x12'\n" +
 	"\x0fmax_concurrency\x18\x02 \x01(\x05R\x0emaxConcurrency\x12,\n" +
@@ -1776,88 +2998,141 @@ func file_configs_conf_proto_rawDescGZIP() []byte {
 	return file_configs_conf_proto_rawDescData
 }
 
-var file_configs_conf_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
+var file_configs_conf_proto_msgTypes = make([]protoimpl.MessageInfo, 43)
 var file_configs_conf_proto_goTypes = []any{
-	(*Bootstrap)(nil),                   // 0: kratos.api.Bootstrap
-	(*Server)(nil),                      // 1: kratos.api.Server
-	(*Data)(nil),                        // 2: kratos.api.Data
-	(*GCS)(nil),                         // 3: kratos.api.GCS
-	(*Observability)(nil),               // 4: kratos.api.Observability
-	(*Messaging)(nil),                   // 5: kratos.api.Messaging
-	(*PubSub)(nil),                      // 6: kratos.api.PubSub
-	(*Receive)(nil),                     // 7: kratos.api.Receive
-	(*OutboxPublisher)(nil),             // 8: kratos.api.OutboxPublisher
-	(*InboxConsumer)(nil),               // 9: kratos.api.InboxConsumer
-	(*Server_GRPC)(nil),                 // 10: kratos.api.Server.GRPC
-	(*Server_JWT)(nil),                  // 11: kratos.api.Server.JWT
-	(*Server_Handlers)(nil),             // 12: kratos.api.Server.Handlers
-	(*Data_PostgreSQL)(nil),             // 13: kratos.api.Data.PostgreSQL
-	(*Data_Client)(nil),                 // 14: kratos.api.Data.Client
-	(*Data_PostgreSQL_Transaction)(nil), // 15: kratos.api.Data.PostgreSQL.Transaction
-	(*Data_Client_JWT)(nil),             // 16: kratos.api.Data.Client.JWT
-	(*Observability_Tracing)(nil),       // 17: kratos.api.Observability.Tracing
-	(*Observability_Metrics)(nil),       // 18: kratos.api.Observability.Metrics
-	nil,                                 // 19: kratos.api.Observability.GlobalAttributesEntry
-	nil,                                 // 20: kratos.api.Observability.Tracing.HeadersEntry
-	nil,                                 // 21: kratos.api.Observability.Tracing.AttributesEntry
-	nil,                                 // 22: kratos.api.Observability.Metrics.HeadersEntry
-	nil,                                 // 23: kratos.api.Observability.Metrics.ResourceAttributesEntry
-	nil,                                 // 24: kratos.api.Messaging.TopicsEntry
-	nil,                                 // 25: kratos.api.Messaging.InboxesEntry
-	(*durationpb.Duration)(nil),         // 26: google.protobuf.Duration
+	(*Bootstrap)(nil),                    // 0: kratos.api.Bootstrap
+	(*Server)(nil),                       // 1: kratos.api.Server
+	(*Data)(nil),                         // 2: kratos.api.Data
+	(*GCS)(nil),                          // 3: kratos.api.GCS
+	(*UploadQuota)(nil),                  // 4: kratos.api.UploadQuota
+	(*Playback)(nil),                     // 5: kratos.api.Playback
+	(*Engagement)(nil),                   // 6: kratos.api.Engagement
+	(*Observability)(nil),                // 7: kratos.api.Observability
+	(*Messaging)(nil),                    // 8: kratos.api.Messaging
+	(*PubSub)(nil),                       // 9: kratos.api.PubSub
+	(*KafkaSink)(nil),                    // 10: kratos.api.KafkaSink
+	(*NatsSink)(nil),                     // 11: kratos.api.NatsSink
+	(*WebhookSink)(nil),                  // 12: kratos.api.WebhookSink
+	(*WebhookEndpoint)(nil),              // 13: kratos.api.WebhookEndpoint
+	(*Receive)(nil),                      // 14: kratos.api.Receive
+	(*OutboxPublisher)(nil),              // 15: kratos.api.OutboxPublisher
+	(*Retention)(nil),                    // 16: kratos.api.Retention
+	(*InboxConsumer)(nil),                // 17: kratos.api.InboxConsumer
+	(*Server_GRPC)(nil),                  // 18: kratos.api.Server.GRPC
+	(*Server_JWT)(nil),                   // 19: kratos.api.Server.JWT
+	(*Server_Handlers)(nil),              // 20: kratos.api.Server.Handlers
+	(*Data_PostgreSQL)(nil),              // 21: kratos.api.Data.PostgreSQL
+	(*Data_Client)(nil),                  // 22: kratos.api.Data.Client
+	(*Data_PostgreSQL_Transaction)(nil),  // 23: kratos.api.Data.PostgreSQL.Transaction
+	(*Data_Client_JWT)(nil),              // 24: kratos.api.Data.Client.JWT
+	(*UploadQuota_Tier)(nil),             // 25: kratos.api.UploadQuota.Tier
+	nil,                                  // 26: kratos.api.UploadQuota.TiersEntry
+	(*Engagement_ViewQualification)(nil), // 27: kratos.api.Engagement.ViewQualification
+	(*Engagement_Rollups)(nil),           // 28: kratos.api.Engagement.Rollups
+	(*Engagement_Trending)(nil),          // 29: kratos.api.Engagement.Trending
+	(*Engagement_Purge)(nil),             // 30: kratos.api.Engagement.Purge
+	(*Engagement_Counters)(nil),          // 31: kratos.api.Engagement.Counters
+	(*Engagement_Batch)(nil),             // 32: kratos.api.Engagement.Batch
+	(*Observability_Tracing)(nil),        // 33: kratos.api.Observability.Tracing
+	(*Observability_Metrics)(nil),        // 34: kratos.api.Observability.Metrics
+	nil,                                  // 35: kratos.api.Observability.GlobalAttributesEntry
+	nil,                                  // 36: kratos.api.Observability.Tracing.HeadersEntry
+	nil,                                  // 37: kratos.api.Observability.Tracing.AttributesEntry
+	nil,                                  // 38: kratos.api.Observability.Metrics.HeadersEntry
+	nil,                                  // 39: kratos.api.Observability.Metrics.ResourceAttributesEntry
+	nil,                                  // 40: kratos.api.Messaging.TopicsEntry
+	nil,                                  // 41: kratos.api.Messaging.InboxesEntry
+	nil,                                  // 42: kratos.api.WebhookEndpoint.HeadersEntry
+	(*durationpb.Duration)(nil),          // 43: google.protobuf.Duration
 }
 var file_configs_conf_proto_depIdxs = []int32{
 	1,  // 0: kratos.api.Bootstrap.server:type_name -> kratos.api.Server
 	2,  // 1: kratos.api.Bootstrap.data:type_name -> kratos.api.Data
-	4,  // 2: kratos.api.Bootstrap.observability:type_name -> kratos.api.Observability
-	5,  // 3: kratos.api.Bootstrap.messaging:type_name -> kratos.api.Messaging
+	7,  // 2: kratos.api.Bootstrap.observability:type_name -> kratos.api.Observability
+	8,  // 3: kratos.api.Bootstrap.messaging:type_name -> kratos.api.Messaging
 	3,  // 4: kratos.api.Bootstrap.gcs:type_name -> kratos.api.GCS
-	10, // 5: kratos.api.Server.grpc:type_name -> kratos.api.Server.GRPC
-	11, // 6: kratos.api.Server.jwt:type_name -> kratos.api.Server.JWT
-	12, // 7: kratos.api.Server.handlers:type_name -> kratos.api.Server.Handlers
-	13, // 8: kratos.api.Data.postgres:type_name -> kratos.api.Data.PostgreSQL
-	14, // 9: kratos.api.Data.grpc_client:type_name -> kratos.api.Data.Client
-	26, // 10: kratos.api.GCS.signed_url_ttl:type_name -> google.protobuf.Duration
-	19, // 11: kratos.api.Observability.global_attributes:type_name -> kratos.api.Observability.GlobalAttributesEntry
-	17, // 12: kratos.api.Observability.tracing:type_name -> kratos.api.Observability.Tracing
-	18, // 13: kratos.api.Observability.metrics:type_name -> kratos.api.Observability.Metrics
-	24, // 14: kratos.api.Messaging.topics:type_name -> kratos.api.Messaging.TopicsEntry
-	8,  // 15: kratos.api.Messaging.outbox:type_name -> kratos.api.OutboxPublisher
-	25, // 16: kratos.api.Messaging.inboxes:type_name -> kratos.api.Messaging.InboxesEntry
-	26, // 17: kratos.api.PubSub.publish_timeout:type_name -> google.protobuf.Duration
-	7,  // 18: kratos.api.PubSub.receive:type_name -> kratos.api.Receive
-	26, // 19: kratos.api.Receive.max_extension:type_name -> google.protobuf.Duration
-	26, // 20: kratos.api.Receive.max_extension_period:type_name -> google.protobuf.Duration
-	26, // 21: kratos.api.OutboxPublisher.tick_interval:type_name -> google.protobuf.Duration
-	26, // 22: kratos.api.OutboxPublisher.initial_backoff:type_name -> google.protobuf.Duration
-	26, // 23: kratos.api.OutboxPublisher.max_backoff:type_name -> google.protobuf.Duration
-	26, // 24: kratos.api.OutboxPublisher.publish_timeout:type_name -> google.protobuf.Duration
-	26, // 25: kratos.api.OutboxPublisher.lock_ttl:type_name -> google.protobuf.Duration
-	26, // 26: kratos.api.Server.GRPC.timeout:type_name -> google.protobuf.Duration
-	26, // 27: kratos.api.Server.Handlers.default_timeout:type_name -> google.protobuf.Duration
-	26, // 28: kratos.api.Server.Handlers.command_timeout:type_name -> google.protobuf.Duration
-	26, // 29: kratos.api.Server.Handlers.query_timeout:type_name -> google.protobuf.Duration
-	26, // 30: kratos.api.Data.PostgreSQL.max_conn_lifetime:type_name -> google.protobuf.Duration
-	26, // 31: kratos.api.Data.PostgreSQL.max_conn_idle_time:type_name -> google.protobuf.Duration
-	26, // 32: kratos.api.Data.PostgreSQL.health_check_period:type_name -> google.protobuf.Duration
-	15, // 33: kratos.api.Data.PostgreSQL.transaction:type_name -> kratos.api.Data.PostgreSQL.Transaction
-	16, // 34: kratos.api.Data.Client.jwt:type_name -> kratos.api.Data.Client.JWT
-	26, // 35: kratos.api.Data.PostgreSQL.Transaction.default_timeout:type_name -> google.protobuf.Duration
-	26, // 36: kratos.api.Data.PostgreSQL.Transaction.lock_timeout:type_name -> google.protobuf.Duration
-	20, // 37: kratos.api.Observability.Tracing.headers:type_name -> kratos.api.Observability.Tracing.HeadersEntry
-	26, // 38: kratos.api.Observability.Tracing.batch_timeout:type_name -> google.protobuf.Duration
-	26, // 39: kratos.api.Observability.Tracing.export_timeout:type_name -> google.protobuf.Duration
-	21, // 40: kratos.api.Observability.Tracing.attributes:type_name -> kratos.api.Observability.Tracing.AttributesEntry
-	22, // 41: kratos.api.Observability.Metrics.headers:type_name -> kratos.api.Observability.Metrics.HeadersEntry
-	26, // 42: kratos.api.Observability.Metrics.interval:type_name -> google.protobuf.Duration
-	23, // 43: kratos.api.Observability.Metrics.resource_attributes:type_name -> kratos.api.Observability.Metrics.ResourceAttributesEntry
-	6,  // 44: kratos.api.Messaging.TopicsEntry.value:type_name -> kratos.api.PubSub
-	9,  // 45: kratos.api.Messaging.InboxesEntry.value:type_name -> kratos.api.InboxConsumer
-	46, // [46:46] is the sub-list for method output_type
-	46, // [46:46] is the sub-list for method input_type
-	46, // [46:46] is the sub-list for extension type_name
-	46, // [46:46] is the sub-list for extension extendee
-	0,  // [0:46] is the sub-list for field type_name
+	4,  // 5: kratos.api.Bootstrap.upload_quota:type_name -> kratos.api.UploadQuota
+	5,  // 6: kratos.api.Bootstrap.playback:type_name -> kratos.api.Playback
+	6,  // 7: kratos.api.Bootstrap.engagement:type_name -> kratos.api.Engagement
+	18, // 8: kratos.api.Server.grpc:type_name -> kratos.api.Server.GRPC
+	19, // 9: kratos.api.Server.jwt:type_name -> kratos.api.Server.JWT
+	20, // 10: kratos.api.Server.handlers:type_name -> kratos.api.Server.Handlers
+	21, // 11: kratos.api.Data.postgres:type_name -> kratos.api.Data.PostgreSQL
+	22, // 12: kratos.api.Data.grpc_client:type_name -> kratos.api.Data.Client
+	43, // 13: kratos.api.GCS.signed_url_ttl:type_name -> google.protobuf.Duration
+	26, // 14: kratos.api.UploadQuota.tiers:type_name -> kratos.api.UploadQuota.TiersEntry
+	43, // 15: kratos.api.Playback.playlist_ttl:type_name -> google.protobuf.Duration
+	43, // 16: kratos.api.Playback.thumbnail_ttl:type_name -> google.protobuf.Duration
+	27, // 17: kratos.api.Engagement.views:type_name -> kratos.api.Engagement.ViewQualification
+	28, // 18: kratos.api.Engagement.rollups:type_name -> kratos.api.Engagement.Rollups
+	29, // 19: kratos.api.Engagement.trending:type_name -> kratos.api.Engagement.Trending
+	30, // 20: kratos.api.Engagement.purge:type_name -> kratos.api.Engagement.Purge
+	31, // 21: kratos.api.Engagement.counters:type_name -> kratos.api.Engagement.Counters
+	32, // 22: kratos.api.Engagement.batch:type_name -> kratos.api.Engagement.Batch
+	35, // 23: kratos.api.Observability.global_attributes:type_name -> kratos.api.Observability.GlobalAttributesEntry
+	33, // 24: kratos.api.Observability.tracing:type_name -> kratos.api.Observability.Tracing
+	34, // 25: kratos.api.Observability.metrics:type_name -> kratos.api.Observability.Metrics
+	40, // 26: kratos.api.Messaging.topics:type_name -> kratos.api.Messaging.TopicsEntry
+	15, // 27: kratos.api.Messaging.outbox:type_name -> kratos.api.OutboxPublisher
+	41, // 28: kratos.api.Messaging.inboxes:type_name -> kratos.api.Messaging.InboxesEntry
+	16, // 29: kratos.api.Messaging.retention:type_name -> kratos.api.Retention
+	43, // 30: kratos.api.PubSub.publish_timeout:type_name -> google.protobuf.Duration
+	14, // 31: kratos.api.PubSub.receive:type_name -> kratos.api.Receive
+	10, // 32: kratos.api.PubSub.kafka:type_name -> kratos.api.KafkaSink
+	11, // 33: kratos.api.PubSub.nats:type_name -> kratos.api.NatsSink
+	12, // 34: kratos.api.PubSub.webhook:type_name -> kratos.api.WebhookSink
+	43, // 35: kratos.api.KafkaSink.write_timeout:type_name -> google.protobuf.Duration
+	13, // 36: kratos.api.WebhookSink.endpoints:type_name -> kratos.api.WebhookEndpoint
+	43, // 37: kratos.api.WebhookEndpoint.timeout:type_name -> google.protobuf.Duration
+	42, // 38: kratos.api.WebhookEndpoint.headers:type_name -> kratos.api.WebhookEndpoint.HeadersEntry
+	43, // 39: kratos.api.Receive.max_extension:type_name -> google.protobuf.Duration
+	43, // 40: kratos.api.Receive.max_extension_period:type_name -> google.protobuf.Duration
+	43, // 41: kratos.api.OutboxPublisher.tick_interval:type_name -> google.protobuf.Duration
+	43, // 42: kratos.api.OutboxPublisher.initial_backoff:type_name -> google.protobuf.Duration
+	43, // 43: kratos.api.OutboxPublisher.max_backoff:type_name -> google.protobuf.Duration
+	43, // 44: kratos.api.OutboxPublisher.publish_timeout:type_name -> google.protobuf.Duration
+	43, // 45: kratos.api.OutboxPublisher.lock_ttl:type_name -> google.protobuf.Duration
+	43, // 46: kratos.api.Retention.outbox_published_retention:type_name -> google.protobuf.Duration
+	43, // 47: kratos.api.Retention.inbox_processed_retention:type_name -> google.protobuf.Duration
+	43, // 48: kratos.api.Retention.interval:type_name -> google.protobuf.Duration
+	43, // 49: kratos.api.Retention.outbox_abandoned_retention:type_name -> google.protobuf.Duration
+	43, // 50: kratos.api.Server.GRPC.timeout:type_name -> google.protobuf.Duration
+	43, // 51: kratos.api.Server.Handlers.default_timeout:type_name -> google.protobuf.Duration
+	43, // 52: kratos.api.Server.Handlers.command_timeout:type_name -> google.protobuf.Duration
+	43, // 53: kratos.api.Server.Handlers.query_timeout:type_name -> google.protobuf.Duration
+	43, // 54: kratos.api.Data.PostgreSQL.max_conn_lifetime:type_name -> google.protobuf.Duration
+	43, // 55: kratos.api.Data.PostgreSQL.max_conn_idle_time:type_name -> google.protobuf.Duration
+	43, // 56: kratos.api.Data.PostgreSQL.health_check_period:type_name -> google.protobuf.Duration
+	23, // 57: kratos.api.Data.PostgreSQL.transaction:type_name -> kratos.api.Data.PostgreSQL.Transaction
+	24, // 58: kratos.api.Data.Client.jwt:type_name -> kratos.api.Data.Client.JWT
+	43, // 59: kratos.api.Data.PostgreSQL.Transaction.default_timeout:type_name -> google.protobuf.Duration
+	43, // 60: kratos.api.Data.PostgreSQL.Transaction.lock_timeout:type_name -> google.protobuf.Duration
+	25, // 61: kratos.api.UploadQuota.TiersEntry.value:type_name -> kratos.api.UploadQuota.Tier
+	43, // 62: kratos.api.Engagement.ViewQualification.session_window:type_name -> google.protobuf.Duration
+	43, // 63: kratos.api.Engagement.Rollups.hourly_retention:type_name -> google.protobuf.Duration
+	43, // 64: kratos.api.Engagement.Rollups.daily_retention:type_name -> google.protobuf.Duration
+	43, // 65: kratos.api.Engagement.Rollups.prune_interval:type_name -> google.protobuf.Duration
+	43, // 66: kratos.api.Engagement.Trending.interval:type_name -> google.protobuf.Duration
+	43, // 67: kratos.api.Engagement.Trending.window:type_name -> google.protobuf.Duration
+	43, // 68: kratos.api.Engagement.Trending.half_life:type_name -> google.protobuf.Duration
+	43, // 69: kratos.api.Engagement.Trending.snapshot_ttl:type_name -> google.protobuf.Duration
+	43, // 70: kratos.api.Engagement.Purge.interval:type_name -> google.protobuf.Duration
+	43, // 71: kratos.api.Engagement.Counters.compact_interval:type_name -> google.protobuf.Duration
+	43, // 72: kratos.api.Engagement.Batch.max_wait:type_name -> google.protobuf.Duration
+	36, // 73: kratos.api.Observability.Tracing.headers:type_name -> kratos.api.Observability.Tracing.HeadersEntry
+	43, // 74: kratos.api.Observability.Tracing.batch_timeout:type_name -> google.protobuf.Duration
+	43, // 75: kratos.api.Observability.Tracing.export_timeout:type_name -> google.protobuf.Duration
+	37, // 76: kratos.api.Observability.Tracing.attributes:type_name -> kratos.api.Observability.Tracing.AttributesEntry
+	38, // 77: kratos.api.Observability.Metrics.headers:type_name -> kratos.api.Observability.Metrics.HeadersEntry
+	43, // 78: kratos.api.Observability.Metrics.interval:type_name -> google.protobuf.Duration
+	39, // 79: kratos.api.Observability.Metrics.resource_attributes:type_name -> kratos.api.Observability.Metrics.ResourceAttributesEntry
+	9,  // 80: kratos.api.Messaging.TopicsEntry.value:type_name -> kratos.api.PubSub
+	17, // 81: kratos.api.Messaging.InboxesEntry.value:type_name -> kratos.api.InboxConsumer
+	82, // [82:82] is the sub-list for method output_type
+	82, // [82:82] is the sub-list for method input_type
+	82, // [82:82] is the sub-list for extension type_name
+	82, // [82:82] is the sub-list for extension extendee
+	0,  // [0:82] is the sub-list for field type_name
 }
 
 func init() { file_configs_conf_proto_init() }
@@ -1865,18 +3140,19 @@ func file_configs_conf_proto_init() {
 	if File_configs_conf_proto != nil {
 		return
 	}
-	file_configs_conf_proto_msgTypes[8].OneofWrappers = []any{}
-	file_configs_conf_proto_msgTypes[9].OneofWrappers = []any{}
-	file_configs_conf_proto_msgTypes[13].OneofWrappers = []any{}
 	file_configs_conf_proto_msgTypes[15].OneofWrappers = []any{}
-	file_configs_conf_proto_msgTypes[18].OneofWrappers = []any{}
+	file_configs_conf_proto_msgTypes[17].OneofWrappers = []any{}
+	file_configs_conf_proto_msgTypes[21].OneofWrappers = []any{}
+	file_configs_conf_proto_msgTypes[23].OneofWrappers = []any{}
+	file_configs_conf_proto_msgTypes[27].OneofWrappers = []any{}
+	file_configs_conf_proto_msgTypes[34].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_configs_conf_proto_rawDesc), len(file_configs_conf_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   26,
+			NumMessages:   43,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
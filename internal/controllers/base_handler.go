@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"context"
+	"slices"
 	"strings"
 	"time"
 
@@ -109,6 +110,12 @@ func (h *BaseHandler) ExtractMetadata(ctx context.Context) metadata.HandlerMetad
 		} else {
 			meta.InvalidUserInfo = true
 		}
+		if tier, err := metadata.ExtractUserTierFromUserInfo(rawUserInfo); err == nil {
+			meta.UserTier = tier
+		}
+		if roles, err := metadata.ExtractUserRolesFromUserInfo(rawUserInfo); err == nil {
+			meta.IsAdmin = slices.Contains(roles, metadata.RoleAdmin)
+		}
 	}
 	return meta
 }
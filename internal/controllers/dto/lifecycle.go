@@ -205,6 +205,7 @@ func NewVideoRevisionMessage(revision *services.VideoRevision) *videov1.VideoRev
 		UpdatedAt:      FormatTime(revision.UpdatedAt),
 		EventId:        revision.EventID.String(),
 		OccurredAt:     FormatTime(revision.OccurredAt),
+		RawMissing:     revision.RawMissing,
 	}
 }
 
@@ -0,0 +1,119 @@
+package dto
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	videov1 "github.com/bionicotaku/lingo-services-catalog/api/video/v1"
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/vo"
+	"github.com/bionicotaku/lingo-services-catalog/internal/services"
+
+	"github.com/google/uuid"
+)
+
+// ToListOutboxEventsInput 将列表请求转换为服务层输入；aggregate_id 非法时返回错误。
+func ToListOutboxEventsInput(req *videov1.ListOutboxEventsRequest) (services.ListOutboxEventsInput, error) {
+	if req == nil {
+		return services.ListOutboxEventsInput{}, nil
+	}
+	input := services.ListOutboxEventsInput{
+		EventType:   strings.TrimSpace(req.GetEventType()),
+		MinAttempts: req.GetMinAttempts(),
+		Limit:       int(req.GetPageSize()),
+	}
+	switch req.GetStatus() {
+	case videov1.OutboxEventStatus_OUTBOX_EVENT_STATUS_UNSPECIFIED, videov1.OutboxEventStatus_OUTBOX_EVENT_STATUS_PENDING:
+		input.Status = vo.OutboxEventPending
+	case videov1.OutboxEventStatus_OUTBOX_EVENT_STATUS_FAILED:
+		input.Status = vo.OutboxEventFailed
+	case videov1.OutboxEventStatus_OUTBOX_EVENT_STATUS_ABANDONED:
+		input.Status = vo.OutboxEventAbandoned
+	default:
+		return services.ListOutboxEventsInput{}, fmt.Errorf("unsupported status: %s", req.GetStatus())
+	}
+	if raw := strings.TrimSpace(req.GetAggregateId()); raw != "" {
+		aggregateID, err := uuid.Parse(raw)
+		if err != nil {
+			return services.ListOutboxEventsInput{}, fmt.Errorf("invalid aggregate_id: %w", err)
+		}
+		input.AggregateID = &aggregateID
+	}
+	return input, nil
+}
+
+// ParseOutboxEventID 解析事件 ID。
+func ParseOutboxEventID(raw string) (uuid.UUID, error) {
+	eventID, err := uuid.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid event_id: %w", err)
+	}
+	return eventID, nil
+}
+
+// NewListOutboxEventsResponse 将事件快照列表转换为 gRPC 响应。
+func NewListOutboxEventsResponse(events []*vo.OutboxEvent) *videov1.ListOutboxEventsResponse {
+	resp := &videov1.ListOutboxEventsResponse{Events: make([]*videov1.OutboxEventSummary, 0, len(events))}
+	for _, event := range events {
+		resp.Events = append(resp.Events, NewOutboxEventSummary(event))
+	}
+	return resp
+}
+
+// NewGetOutboxEventResponse 将事件详情转换为 gRPC 响应。
+func NewGetOutboxEventResponse(detail *vo.OutboxEventDetail) *videov1.GetOutboxEventResponse {
+	if detail == nil {
+		return &videov1.GetOutboxEventResponse{}
+	}
+	return &videov1.GetOutboxEventResponse{
+		Event:       NewOutboxEventSummary(&detail.OutboxEvent),
+		PayloadJson: string(detail.Payload),
+		DecodeError: detail.DecodeError,
+		HeadersJson: string(detail.Headers),
+	}
+}
+
+// NewOutboxEventSummary 将事件快照转换为 gRPC 消息。
+func NewOutboxEventSummary(event *vo.OutboxEvent) *videov1.OutboxEventSummary {
+	if event == nil {
+		return nil
+	}
+	return &videov1.OutboxEventSummary{
+		EventId:           event.EventID.String(),
+		AggregateType:     event.AggregateType,
+		AggregateId:       event.AggregateID.String(),
+		EventType:         event.EventType,
+		Status:            outboxEventStatusToProto(event.Status),
+		OccurredAtUnixms:  event.OccurredAt.UTC().UnixMilli(),
+		AvailableAtUnixms: unixMilliOrZero(event.AvailableAt),
+		PublishedAtUnixms: unixMilliOrZero(event.PublishedAt),
+		DeliveryAttempts:  event.DeliveryAttempts,
+		LastError:         event.LastError,
+		LockToken:         event.LockToken,
+		LockedAtUnixms:    unixMilliOrZero(event.LockedAt),
+		AbandonedAtUnixms: unixMilliOrZero(event.AbandonedAt),
+		AbandonedReason:   event.AbandonedReason,
+	}
+}
+
+func outboxEventStatusToProto(status vo.OutboxEventStatus) videov1.OutboxEventStatus {
+	switch status {
+	case vo.OutboxEventPending:
+		return videov1.OutboxEventStatus_OUTBOX_EVENT_STATUS_PENDING
+	case vo.OutboxEventFailed:
+		return videov1.OutboxEventStatus_OUTBOX_EVENT_STATUS_FAILED
+	case vo.OutboxEventAbandoned:
+		return videov1.OutboxEventStatus_OUTBOX_EVENT_STATUS_ABANDONED
+	case vo.OutboxEventPublished:
+		return videov1.OutboxEventStatus_OUTBOX_EVENT_STATUS_PUBLISHED
+	default:
+		return videov1.OutboxEventStatus_OUTBOX_EVENT_STATUS_UNSPECIFIED
+	}
+}
+
+func unixMilliOrZero(t *time.Time) int64 {
+	if t == nil {
+		return 0
+	}
+	return t.UTC().UnixMilli()
+}
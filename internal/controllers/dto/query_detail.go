@@ -54,6 +54,16 @@ func NewVideoDetail(detail *vo.VideoDetail) *videov1.VideoDetail {
 		BookmarkCount:  detail.BookmarkCount,
 		WatchCount:     detail.WatchCount,
 		UniqueWatchers: detail.UniqueWatchers,
+
+		TotalWatchSeconds:    detail.TotalWatchSeconds,
+		CompletionRate:       detail.CompletionRate,
+		ResumePositionMicros: detail.ResumePositionMicros,
+		ShareCount:           detail.ShareCount,
+		RatingCount:          detail.RatingCount,
+		AverageRating:        detail.AverageRating,
+		MyRating:             detail.MyRating,
+
+		AverageViewDurationSeconds: detail.AverageViewDurationSeconds,
 	}
 }
 
@@ -80,5 +90,36 @@ func NewVideoMetadata(meta *vo.VideoMetadata) *videov1.VideoMetadata {
 		LikeCount:         meta.LikeCount,
 		BookmarkCount:     meta.BookmarkCount,
 		WatchCount:        meta.WatchCount,
+		TotalWatchSeconds: meta.TotalWatchSeconds,
+		CompletionRate:    meta.CompletionRate,
+		ShareCount:        meta.ShareCount,
+		RatingCount:       meta.RatingCount,
+		AverageRating:     meta.AverageRating,
+
+		AverageViewDurationSeconds: meta.AverageViewDurationSeconds,
+	}
+}
+
+// NewPlaybackInfo 将播放信息 VO 转换为 Proto。
+func NewPlaybackInfo(info *vo.PlaybackInfo) *videov1.PlaybackInfo {
+	if info == nil {
+		return &videov1.PlaybackInfo{}
+	}
+	resp := &videov1.PlaybackInfo{
+		VideoId:              info.VideoID.String(),
+		HlsMasterPlaylistUrl: info.HLSMasterPlaylistURL,
+		ThumbnailUrl:         info.ThumbnailURL,
+		ExpiresAt:            FormatTime(info.ExpiresAt),
+		DurationMicros:       info.DurationMicros,
+	}
+	if cookie := info.SignedCookie; cookie != nil {
+		resp.SignedCookie = &videov1.SignedCookie{
+			Name:      cookie.Name,
+			Value:     cookie.Value,
+			Domain:    cookie.Domain,
+			Path:      cookie.Path,
+			ExpiresAt: FormatTime(cookie.ExpiresAt),
+		}
 	}
+	return resp
 }
@@ -40,11 +40,90 @@ func NewMyUploadListItems(items []vo.MyUploadListItem) []*videov1.MyUploadListIt
 			Version:        it.Version,
 			CreatedAt:      FormatTime(it.CreatedAt),
 			UpdatedAt:      FormatTime(it.UpdatedAt),
+			RawMissing:     it.RawMissing,
 		})
 	}
 	return result
 }
 
+// NewWatchHistoryItems 将观看历史转换为 proto。
+func NewWatchHistoryItems(items []vo.WatchHistoryItem) []*videov1.WatchHistoryItem {
+	result := make([]*videov1.WatchHistoryItem, 0, len(items))
+	for _, it := range items {
+		result = append(result, &videov1.WatchHistoryItem{
+			VideoId:              it.VideoID.String(),
+			Title:                it.Title,
+			Status:               it.Status,
+			MediaStatus:          it.MediaStatus,
+			AnalysisStatus:       it.AnalysisStatus,
+			DurationMicros:       it.DurationMicros,
+			ResumePositionMicros: it.ResumePositionMicros,
+			LastWatchedAt:        FormatTime(it.LastWatchedAt),
+		})
+	}
+	return result
+}
+
+// NewSavedVideoItems 将点赞/收藏列表转换为 proto。
+func NewSavedVideoItems(items []vo.SavedVideoItem) []*videov1.SavedVideoItem {
+	result := make([]*videov1.SavedVideoItem, 0, len(items))
+	for _, it := range items {
+		result = append(result, &videov1.SavedVideoItem{
+			VideoId:        it.VideoID.String(),
+			Title:          it.Title,
+			Status:         it.Status,
+			MediaStatus:    it.MediaStatus,
+			AnalysisStatus: it.AnalysisStatus,
+			DurationMicros: it.DurationMicros,
+			CreatedAt:      FormatTime(it.CreatedAt),
+			SavedAt:        FormatTime(it.SavedAt),
+		})
+	}
+	return result
+}
+
+// NewTrendingVideoItems 将热度榜列表转换为 proto。
+func NewTrendingVideoItems(items []vo.TrendingVideoItem) []*videov1.TrendingVideoItem {
+	result := make([]*videov1.TrendingVideoItem, 0, len(items))
+	for _, it := range items {
+		result = append(result, &videov1.TrendingVideoItem{
+			VideoId:        it.VideoID.String(),
+			Title:          it.Title,
+			DurationMicros: it.DurationMicros,
+			Difficulty:     it.Difficulty,
+			Tags:           it.Tags,
+			CreatedAt:      FormatTime(it.CreatedAt),
+			Rank:           it.Rank,
+			Score:          it.Score,
+		})
+	}
+	return result
+}
+
+// ParseTrendingFilters 规整热度榜的难度与标签过滤条件：去除首尾空白、标签去重；空难度视为不过滤。
+func ParseTrendingFilters(difficulty string, tags []string) (*string, []string, error) {
+	var difficultyFilter *string
+	if trimmed := strings.TrimSpace(difficulty); trimmed != "" {
+		difficultyFilter = &trimmed
+	}
+	if len(tags) == 0 {
+		return difficultyFilter, nil, nil
+	}
+	seen := make(map[string]struct{}, len(tags))
+	result := make([]string, 0, len(tags))
+	for _, item := range tags {
+		tag := strings.TrimSpace(item)
+		if tag == "" {
+			return nil, nil, fmt.Errorf("invalid tags value: %q", item)
+		}
+		if _, ok := seen[tag]; !ok {
+			seen[tag] = struct{}{}
+			result = append(result, tag)
+		}
+	}
+	return difficultyFilter, result, nil
+}
+
 // ParseStatusFilters 校验并转换视频状态过滤条件。
 func ParseStatusFilters(raw []string) ([]po.VideoStatus, error) {
 	if len(raw) == 0 {
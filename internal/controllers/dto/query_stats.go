@@ -0,0 +1,82 @@
+package dto
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	// 内嵌 IANA 时区库，运行镜像缺少系统 zoneinfo 时仍可解析 time_zone。
+	_ "time/tzdata"
+
+	videov1 "github.com/bionicotaku/lingo-services-catalog/api/video/v1"
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/vo"
+)
+
+// ParseStatsGranularity 解析分时统计粒度（hour/day）。
+func ParseStatsGranularity(raw string) (po.StatsGranularity, error) {
+	granularity := po.StatsGranularity(strings.ToLower(strings.TrimSpace(raw)))
+	switch granularity {
+	case po.StatsGranularityHour, po.StatsGranularityDay:
+		return granularity, nil
+	default:
+		return "", fmt.Errorf("invalid granularity: %s", raw)
+	}
+}
+
+// ParseStatsTime 解析 RFC3339 时间参数。
+func ParseStatsTime(field, raw string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(raw))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s: want RFC3339 time", field)
+	}
+	return t, nil
+}
+
+// ParseStatsZone 解析分时统计的时区：timeZone 为 IANA 名称，与固定偏移 offsetMinutes 二选一，均未设置时为 UTC。
+func ParseStatsZone(timeZone string, offsetMinutes int32) (vo.StatsZone, error) {
+	name := strings.TrimSpace(timeZone)
+	if name != "" {
+		if offsetMinutes != 0 {
+			return vo.StatsZone{}, fmt.Errorf("time_zone and tz_offset_minutes are mutually exclusive")
+		}
+		if strings.EqualFold(name, "Local") {
+			return vo.StatsZone{}, fmt.Errorf("invalid time_zone: %s", timeZone)
+		}
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			return vo.StatsZone{}, fmt.Errorf("invalid time_zone: %s", timeZone)
+		}
+		return vo.StatsZone{TimeZone: name, Location: loc}, nil
+	}
+	if offsetMinutes < -14*60 || offsetMinutes > 14*60 {
+		return vo.StatsZone{}, fmt.Errorf("tz_offset_minutes must be within [-840, 840]")
+	}
+	if offsetMinutes == 0 {
+		return vo.UTCStatsZone(), nil
+	}
+	return vo.StatsZone{OffsetMinutes: offsetMinutes, Location: time.FixedZone("", int(offsetMinutes)*60)}, nil
+}
+
+// NewGetVideoStatsTimeseriesResponse 将时间序列 VO 转换为 gRPC 响应；bucket_start 保留请求的时区偏移。
+func NewGetVideoStatsTimeseriesResponse(series *vo.VideoStatsTimeseries) *videov1.GetVideoStatsTimeseriesResponse {
+	if series == nil {
+		return &videov1.GetVideoStatsTimeseriesResponse{}
+	}
+	buckets := make([]*videov1.VideoStatsBucket, 0, len(series.Buckets))
+	for _, bucket := range series.Buckets {
+		buckets = append(buckets, &videov1.VideoStatsBucket{
+			BucketStart:    bucket.BucketStart.Format(time.RFC3339),
+			LikeDelta:      bucket.LikeDelta,
+			BookmarkDelta:  bucket.BookmarkDelta,
+			WatchCount:     bucket.WatchCount,
+			UniqueWatchers: bucket.UniqueWatchers,
+		})
+	}
+	return &videov1.GetVideoStatsTimeseriesResponse{
+		VideoId:         series.VideoID.String(),
+		Granularity:     series.Granularity,
+		TzOffsetMinutes: series.TZOffsetMinutes,
+		Buckets:         buckets,
+		TimeZone:        series.TimeZone,
+	}
+}
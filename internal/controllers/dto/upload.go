@@ -5,6 +5,7 @@ import (
 
 	videov1 "github.com/bionicotaku/lingo-services-catalog/api/video/v1"
 	"github.com/bionicotaku/lingo-services-catalog/internal/metadata"
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/vo"
 	"github.com/bionicotaku/lingo-services-catalog/internal/services"
 
 	"github.com/google/uuid"
@@ -16,13 +17,38 @@ func ToInitResumableUploadInput(req *videov1.InitResumableUploadRequest, userID
 		return services.InitResumableUploadInput{}
 	}
 	return services.InitResumableUploadInput{
-		UserID:          userID,
-		SizeBytes:       req.GetSizeBytes(),
-		ContentType:     strings.TrimSpace(req.GetContentType()),
-		ContentMD5Hex:   strings.TrimSpace(strings.ToLower(req.GetContentMd5Hex())),
-		DurationSeconds: req.GetDurationSeconds(),
-		Title:           strings.TrimSpace(req.GetTitle()),
-		Description:     strings.TrimSpace(req.GetDescription()),
-		IdempotencyKey:  strings.TrimSpace(meta.IdempotencyKey),
+		UserID:           userID,
+		Tier:             meta.UserTier,
+		SizeBytes:        req.GetSizeBytes(),
+		ContentType:      strings.TrimSpace(req.GetContentType()),
+		ContentMD5Hex:    strings.TrimSpace(strings.ToLower(req.GetContentMd5Hex())),
+		ContentSHA256Hex: strings.TrimSpace(strings.ToLower(req.GetContentSha256Hex())),
+		DurationSeconds:  req.GetDurationSeconds(),
+		Title:            strings.TrimSpace(req.GetTitle()),
+		Description:      strings.TrimSpace(req.GetDescription()),
+		IdempotencyKey:   strings.TrimSpace(meta.IdempotencyKey),
+	}
+}
+
+// NewGetMyUploadQuotaResponse 将配额视图转换为 gRPC 响应。
+func NewGetMyUploadQuotaResponse(quota *vo.UploadQuota) *videov1.GetMyUploadQuotaResponse {
+	if quota == nil {
+		return &videov1.GetMyUploadQuotaResponse{}
+	}
+	return &videov1.GetMyUploadQuotaResponse{
+		Tier:               quota.Tier,
+		DailySessions:      newUploadQuotaLimit(quota.DailySessions),
+		ConcurrentUploads:  newUploadQuotaLimit(quota.ConcurrentUploads),
+		StorageBytes:       newUploadQuotaLimit(quota.StorageBytes),
+		DailyResetAtUnixms: quota.DailyResetAt.UTC().UnixMilli(),
+	}
+}
+
+func newUploadQuotaLimit(limit vo.UploadQuotaLimit) *videov1.UploadQuotaLimit {
+	return &videov1.UploadQuotaLimit{
+		Limit:     limit.Limit,
+		Used:      limit.Used,
+		Remaining: limit.Remaining,
+		Unlimited: limit.Unlimited,
 	}
 }
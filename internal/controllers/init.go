@@ -10,4 +10,5 @@ var ProviderSet = wire.NewSet(
 	NewLifecycleHandler,
 	NewVideoQueryHandler,
 	NewUploadHandler,
+	NewOutboxAdminHandler,
 )
@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"context"
+
+	videov1 "github.com/bionicotaku/lingo-services-catalog/api/video/v1"
+	"github.com/bionicotaku/lingo-services-catalog/internal/controllers/dto"
+	"github.com/bionicotaku/lingo-services-catalog/internal/services"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+)
+
+// OutboxAdminHandler 实现 CatalogOutboxAdminService gRPC 接口；admin 角色校验由服务层完成。
+type OutboxAdminHandler struct {
+	videov1.UnimplementedCatalogOutboxAdminServiceServer
+
+	*BaseHandler
+	svc *services.OutboxAdminService
+}
+
+// NewOutboxAdminHandler 构造 OutboxAdminHandler。
+func NewOutboxAdminHandler(base *BaseHandler, svc *services.OutboxAdminService) *OutboxAdminHandler {
+	if base == nil {
+		base = NewBaseHandler(HandlerTimeouts{})
+	}
+	return &OutboxAdminHandler{BaseHandler: base, svc: svc}
+}
+
+// ListOutboxEvents 列出未发布的 Outbox 事件。
+func (h *OutboxAdminHandler) ListOutboxEvents(ctx context.Context, req *videov1.ListOutboxEventsRequest) (*videov1.ListOutboxEventsResponse, error) {
+	if h.svc == nil {
+		return nil, kerrors.InternalServer(videov1.ErrorReason_ERROR_REASON_OUTBOX_ADMIN_INVALID.String(), "outbox admin service not available")
+	}
+	input, err := dto.ToListOutboxEventsInput(req)
+	if err != nil {
+		return nil, kerrors.BadRequest(videov1.ErrorReason_ERROR_REASON_OUTBOX_ADMIN_INVALID.String(), err.Error())
+	}
+
+	timeoutCtx, cancel := h.WithTimeout(ctx, HandlerTypeQuery)
+	defer cancel()
+	timeoutCtx = InjectHandlerMetadata(timeoutCtx, h.ExtractMetadata(ctx))
+
+	events, err := h.svc.ListOutboxEvents(timeoutCtx, input)
+	if err != nil {
+		return nil, toOutboxAdminError(err, "list outbox events failed")
+	}
+	return dto.NewListOutboxEventsResponse(events), nil
+}
+
+// GetOutboxEvent 返回单条事件及解码后的负载。
+func (h *OutboxAdminHandler) GetOutboxEvent(ctx context.Context, req *videov1.GetOutboxEventRequest) (*videov1.GetOutboxEventResponse, error) {
+	if h.svc == nil {
+		return nil, kerrors.InternalServer(videov1.ErrorReason_ERROR_REASON_OUTBOX_ADMIN_INVALID.String(), "outbox admin service not available")
+	}
+	eventID, err := dto.ParseOutboxEventID(req.GetEventId())
+	if err != nil {
+		return nil, kerrors.BadRequest(videov1.ErrorReason_ERROR_REASON_OUTBOX_ADMIN_INVALID.String(), err.Error())
+	}
+
+	timeoutCtx, cancel := h.WithTimeout(ctx, HandlerTypeQuery)
+	defer cancel()
+	timeoutCtx = InjectHandlerMetadata(timeoutCtx, h.ExtractMetadata(ctx))
+
+	detail, err := h.svc.GetOutboxEvent(timeoutCtx, eventID)
+	if err != nil {
+		return nil, toOutboxAdminError(err, "get outbox event failed")
+	}
+	return dto.NewGetOutboxEventResponse(detail), nil
+}
+
+// RetryOutboxEvent 重置事件投递状态并立即重新开放发布。
+func (h *OutboxAdminHandler) RetryOutboxEvent(ctx context.Context, req *videov1.RetryOutboxEventRequest) (*videov1.RetryOutboxEventResponse, error) {
+	if h.svc == nil {
+		return nil, kerrors.InternalServer(videov1.ErrorReason_ERROR_REASON_OUTBOX_ADMIN_INVALID.String(), "outbox admin service not available")
+	}
+	eventID, err := dto.ParseOutboxEventID(req.GetEventId())
+	if err != nil {
+		return nil, kerrors.BadRequest(videov1.ErrorReason_ERROR_REASON_OUTBOX_ADMIN_INVALID.String(), err.Error())
+	}
+
+	timeoutCtx, cancel := h.WithTimeout(ctx, HandlerTypeCommand)
+	defer cancel()
+	timeoutCtx = InjectHandlerMetadata(timeoutCtx, h.ExtractMetadata(ctx))
+
+	event, err := h.svc.RetryOutboxEvent(timeoutCtx, eventID)
+	if err != nil {
+		return nil, toOutboxAdminError(err, "retry outbox event failed")
+	}
+	return &videov1.RetryOutboxEventResponse{Event: dto.NewOutboxEventSummary(event)}, nil
+}
+
+// AbandonOutboxEvent 放弃待发布事件。
+func (h *OutboxAdminHandler) AbandonOutboxEvent(ctx context.Context, req *videov1.AbandonOutboxEventRequest) (*videov1.AbandonOutboxEventResponse, error) {
+	if h.svc == nil {
+		return nil, kerrors.InternalServer(videov1.ErrorReason_ERROR_REASON_OUTBOX_ADMIN_INVALID.String(), "outbox admin service not available")
+	}
+	eventID, err := dto.ParseOutboxEventID(req.GetEventId())
+	if err != nil {
+		return nil, kerrors.BadRequest(videov1.ErrorReason_ERROR_REASON_OUTBOX_ADMIN_INVALID.String(), err.Error())
+	}
+
+	timeoutCtx, cancel := h.WithTimeout(ctx, HandlerTypeCommand)
+	defer cancel()
+	timeoutCtx = InjectHandlerMetadata(timeoutCtx, h.ExtractMetadata(ctx))
+
+	event, err := h.svc.AbandonOutboxEvent(timeoutCtx, eventID, req.GetReason())
+	if err != nil {
+		return nil, toOutboxAdminError(err, "abandon outbox event failed")
+	}
+	return &videov1.AbandonOutboxEventResponse{Event: dto.NewOutboxEventSummary(event)}, nil
+}
+
+func toOutboxAdminError(err error, message string) error {
+	if ke := kerrors.FromError(err); ke != nil && ke.Code != 500 {
+		return ke
+	}
+	return kerrors.InternalServer(videov1.ErrorReason_ERROR_REASON_OUTBOX_ADMIN_INVALID.String(), message).WithCause(err)
+}
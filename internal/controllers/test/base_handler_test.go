@@ -88,3 +88,28 @@ func TestBaseHandlerInvalidUserInfo(t *testing.T) {
 		t.Fatalf("expected empty user id, got %q", meta.UserID)
 	}
 }
+
+func TestBaseHandlerExtractAdminRole(t *testing.T) {
+	handler := controllers.NewBaseHandler(controllers.HandlerTimeouts{})
+	for name, tc := range map[string]struct {
+		claims map[string]any
+		admin  bool
+	}{
+		"admin in roles":        {claims: map[string]any{"sub": "u1", "roles": []any{"editor", "Admin"}}, admin: true},
+		"admin in app_metadata": {claims: map[string]any{"sub": "u1", "app_metadata": map[string]any{"role": "admin"}}, admin: true},
+		"regular user":          {claims: map[string]any{"sub": "u1", "role": "authenticated"}, admin: false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			payload, err := json.Marshal(tc.claims)
+			if err != nil {
+				t.Fatalf("marshal claims: %v", err)
+			}
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+				"x-apigateway-api-userinfo", base64.RawURLEncoding.EncodeToString(payload),
+			))
+			if meta := handler.ExtractMetadata(ctx); meta.IsAdmin != tc.admin {
+				t.Fatalf("expected IsAdmin=%v, got %v", tc.admin, meta.IsAdmin)
+			}
+		})
+	}
+}
@@ -130,7 +130,7 @@ func TestUploadHandler_AlreadyCompletedError(t *testing.T) {
 
 func newUploadServiceForHandler(t *testing.T, repo *handlerRepoStub, signer handlerSignerStub) *services.UploadService {
 	t.Helper()
-	svc, err := services.NewUploadService(repo, signer, "bucket", 5*time.Minute, log.NewStdLogger(discardWriter{}))
+	svc, err := services.NewUploadService(repo, signer, nil, "bucket", 5*time.Minute, log.NewStdLogger(discardWriter{}))
 	if err != nil {
 		t.Fatalf("NewUploadService: %v", err)
 	}
@@ -68,3 +68,34 @@ func (h *UploadHandler) InitResumableUpload(ctx context.Context, req *videov1.In
 	}
 	return resp, nil
 }
+
+// GetMyUploadQuota 返回当前用户的上传配额与剩余额度。
+func (h *UploadHandler) GetMyUploadQuota(ctx context.Context, _ *videov1.GetMyUploadQuotaRequest) (*videov1.GetMyUploadQuotaResponse, error) {
+	if h.svc == nil {
+		return nil, kerrors.InternalServer(videov1.ErrorReason_ERROR_REASON_UPLOAD_INVALID.String(), "upload service not available")
+	}
+	meta := h.ExtractMetadata(ctx)
+	if meta.InvalidUserInfo {
+		return nil, kerrors.BadRequest(videov1.ErrorReason_ERROR_REASON_UPLOAD_INVALID.String(), "invalid user metadata")
+	}
+	userID, ok := meta.UserUUID()
+	if !ok {
+		if strings.TrimSpace(meta.UserID) != "" || strings.TrimSpace(meta.RawUserInfo) != "" {
+			return nil, kerrors.BadRequest(videov1.ErrorReason_ERROR_REASON_UPLOAD_INVALID.String(), "invalid user metadata")
+		}
+		return nil, kerrors.Unauthorized(videov1.ErrorReason_ERROR_REASON_UPLOAD_INVALID.String(), "user metadata required")
+	}
+
+	timeoutCtx, cancel := h.WithTimeout(ctx, HandlerTypeQuery)
+	defer cancel()
+	timeoutCtx = InjectHandlerMetadata(timeoutCtx, meta)
+
+	quota, err := h.svc.GetUploadQuota(timeoutCtx, userID, meta.UserTier)
+	if err != nil {
+		if ke := kerrors.FromError(err); ke != nil {
+			return nil, ke
+		}
+		return nil, kerrors.InternalServer(videov1.ErrorReason_ERROR_REASON_UPLOAD_INVALID.String(), "get upload quota failed").WithCause(err)
+	}
+	return dto.NewGetMyUploadQuotaResponse(quota), nil
+}
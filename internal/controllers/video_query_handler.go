@@ -15,15 +15,16 @@ type VideoQueryHandler struct {
 	videov1.UnimplementedCatalogQueryServiceServer
 
 	*BaseHandler
-	svc *services.VideoQueryService
+	svc      *services.VideoQueryService
+	playback *services.PlaybackService
 }
 
-// NewVideoQueryHandler 构造查询 Handler。
-func NewVideoQueryHandler(svc *services.VideoQueryService, base *BaseHandler) *VideoQueryHandler {
+// NewVideoQueryHandler 构造查询 Handler；playback 为空时 GetPlaybackInfo 返回 PLAYBACK_UNAVAILABLE。
+func NewVideoQueryHandler(svc *services.VideoQueryService, playback *services.PlaybackService, base *BaseHandler) *VideoQueryHandler {
 	if base == nil {
 		base = NewBaseHandler(HandlerTimeouts{})
 	}
-	return &VideoQueryHandler{BaseHandler: base, svc: svc}
+	return &VideoQueryHandler{BaseHandler: base, svc: svc, playback: playback}
 }
 
 // GetVideoMetadata 返回独立的媒体/AI 元数据。
@@ -63,6 +64,26 @@ func (h *VideoQueryHandler) GetVideoDetail(ctx context.Context, req *videov1.Get
 	return dto.NewGetVideoDetailResponse(detail, metadata), nil
 }
 
+// GetPlaybackInfo 按调用方可见性签发限时播放地址。
+func (h *VideoQueryHandler) GetPlaybackInfo(ctx context.Context, req *videov1.GetPlaybackInfoRequest) (*videov1.GetPlaybackInfoResponse, error) {
+	videoID, err := dto.ParseVideoID(req.GetVideoId())
+	if err != nil {
+		return nil, errors.BadRequest(videov1.ErrorReason_ERROR_REASON_VIDEO_ID_INVALID.String(), err.Error())
+	}
+
+	meta := h.ExtractMetadata(ctx)
+	timeoutCtx, cancel := h.WithTimeout(ctx, HandlerTypeQuery)
+	defer cancel()
+
+	timeoutCtx = InjectHandlerMetadata(timeoutCtx, meta)
+
+	info, err := h.playback.GetPlaybackInfo(timeoutCtx, videoID)
+	if err != nil {
+		return nil, err
+	}
+	return &videov1.GetPlaybackInfoResponse{Playback: dto.NewPlaybackInfo(info)}, nil
+}
+
 // ListUserPublicVideos 实现公共视频列表查询。
 func (h *VideoQueryHandler) ListUserPublicVideos(ctx context.Context, req *videov1.ListUserPublicVideosRequest) (*videov1.ListUserPublicVideosResponse, error) {
 	meta := h.ExtractMetadata(ctx)
@@ -108,3 +129,117 @@ func (h *VideoQueryHandler) ListMyUploads(ctx context.Context, req *videov1.List
 		NextPageToken: nextToken,
 	}, nil
 }
+
+// ListMyWatchHistory 实现当前用户观看历史查询。
+func (h *VideoQueryHandler) ListMyWatchHistory(ctx context.Context, req *videov1.ListMyWatchHistoryRequest) (*videov1.ListMyWatchHistoryResponse, error) {
+	meta := h.ExtractMetadata(ctx)
+	timeoutCtx, cancel := h.WithTimeout(ctx, HandlerTypeQuery)
+	defer cancel()
+
+	timeoutCtx = InjectHandlerMetadata(timeoutCtx, meta)
+
+	items, nextToken, err := h.svc.ListMyWatchHistory(timeoutCtx, req.GetPageSize(), req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	return &videov1.ListMyWatchHistoryResponse{
+		Videos:        dto.NewWatchHistoryItems(items),
+		NextPageToken: nextToken,
+	}, nil
+}
+
+// ListMyLikedVideos 实现当前用户点赞列表查询。
+func (h *VideoQueryHandler) ListMyLikedVideos(ctx context.Context, req *videov1.ListMyLikedVideosRequest) (*videov1.ListMyLikedVideosResponse, error) {
+	meta := h.ExtractMetadata(ctx)
+	timeoutCtx, cancel := h.WithTimeout(ctx, HandlerTypeQuery)
+	defer cancel()
+
+	timeoutCtx = InjectHandlerMetadata(timeoutCtx, meta)
+
+	items, nextToken, err := h.svc.ListMyLikedVideos(timeoutCtx, req.GetPageSize(), req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	return &videov1.ListMyLikedVideosResponse{
+		Videos:        dto.NewSavedVideoItems(items),
+		NextPageToken: nextToken,
+	}, nil
+}
+
+// ListMyBookmarkedVideos 实现当前用户收藏列表查询。
+func (h *VideoQueryHandler) ListMyBookmarkedVideos(ctx context.Context, req *videov1.ListMyBookmarkedVideosRequest) (*videov1.ListMyBookmarkedVideosResponse, error) {
+	meta := h.ExtractMetadata(ctx)
+	timeoutCtx, cancel := h.WithTimeout(ctx, HandlerTypeQuery)
+	defer cancel()
+
+	timeoutCtx = InjectHandlerMetadata(timeoutCtx, meta)
+
+	items, nextToken, err := h.svc.ListMyBookmarkedVideos(timeoutCtx, req.GetPageSize(), req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	return &videov1.ListMyBookmarkedVideosResponse{
+		Videos:        dto.NewSavedVideoItems(items),
+		NextPageToken: nextToken,
+	}, nil
+}
+
+// GetVideoStatsTimeseries 实现视频分时统计查询，仅视频上传者可调用。
+func (h *VideoQueryHandler) GetVideoStatsTimeseries(ctx context.Context, req *videov1.GetVideoStatsTimeseriesRequest) (*videov1.GetVideoStatsTimeseriesResponse, error) {
+	videoID, err := dto.ParseVideoID(req.GetVideoId())
+	if err != nil {
+		return nil, errors.BadRequest(videov1.ErrorReason_ERROR_REASON_VIDEO_ID_INVALID.String(), err.Error())
+	}
+	granularity, err := dto.ParseStatsGranularity(req.GetGranularity())
+	if err != nil {
+		return nil, errors.BadRequest(videov1.ErrorReason_ERROR_REASON_VIDEO_UPDATE_INVALID.String(), err.Error())
+	}
+	from, err := dto.ParseStatsTime("from", req.GetFrom())
+	if err != nil {
+		return nil, errors.BadRequest(videov1.ErrorReason_ERROR_REASON_VIDEO_UPDATE_INVALID.String(), err.Error())
+	}
+	to, err := dto.ParseStatsTime("to", req.GetTo())
+	if err != nil {
+		return nil, errors.BadRequest(videov1.ErrorReason_ERROR_REASON_VIDEO_UPDATE_INVALID.String(), err.Error())
+	}
+	zone, err := dto.ParseStatsZone(req.GetTimeZone(), req.GetTzOffsetMinutes())
+	if err != nil {
+		return nil, errors.BadRequest(videov1.ErrorReason_ERROR_REASON_VIDEO_UPDATE_INVALID.String(), err.Error())
+	}
+
+	meta := h.ExtractMetadata(ctx)
+	timeoutCtx, cancel := h.WithTimeout(ctx, HandlerTypeQuery)
+	defer cancel()
+
+	timeoutCtx = InjectHandlerMetadata(timeoutCtx, meta)
+
+	series, err := h.svc.GetVideoStatsTimeseries(timeoutCtx, videoID, granularity, from, to, zone)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewGetVideoStatsTimeseriesResponse(series), nil
+}
+
+// ListTrendingVideos 实现热度榜查询。
+func (h *VideoQueryHandler) ListTrendingVideos(ctx context.Context, req *videov1.ListTrendingVideosRequest) (*videov1.ListTrendingVideosResponse, error) {
+	meta := h.ExtractMetadata(ctx)
+	timeoutCtx, cancel := h.WithTimeout(ctx, HandlerTypeQuery)
+	defer cancel()
+
+	timeoutCtx = InjectHandlerMetadata(timeoutCtx, meta)
+
+	difficulty, tags, err := dto.ParseTrendingFilters(req.GetDifficulty(), req.GetTags())
+	if err != nil {
+		return nil, errors.BadRequest(videov1.ErrorReason_ERROR_REASON_VIDEO_UPDATE_INVALID.String(), err.Error())
+	}
+
+	page, err := h.svc.ListTrendingVideos(timeoutCtx, req.GetPageSize(), req.GetPageToken(), difficulty, tags)
+	if err != nil {
+		return nil, err
+	}
+	return &videov1.ListTrendingVideosResponse{
+		Videos:        dto.NewTrendingVideoItems(page.Items),
+		NextPageToken: page.NextPageToken,
+		ComputedAt:    dto.FormatTime(page.ComputedAt),
+	}, nil
+}
@@ -0,0 +1,171 @@
+// Package cdn 提供播放地址签名的基础设施实现。
+package cdn
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	configloader "github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
+	"github.com/bionicotaku/lingo-services-catalog/internal/services"
+)
+
+// CookieName 是 Cloud CDN 识别的签名 Cookie 名称。
+const CookieName = "Cloud-CDN-Cookie"
+
+const signerHMAC = "hmac"
+
+// ErrInvalidSignature 表示签名缺失、被篡改或已过期。
+var ErrInvalidSignature = errors.New("cdn: invalid signature")
+
+// HMACSigner 生成 Cloud CDN 兼容的签名 URL（Expires/KeyName/Signature）与签名 Cookie，
+// 生产环境由 CDN 校验，本地开发与测试可通过 VerifyURL/VerifyCookie 自行校验。
+type HMACSigner struct {
+	keyName string
+	key     []byte
+}
+
+// NewHMACSigner 使用密钥名与原始密钥字节构造 HMACSigner。
+func NewHMACSigner(keyName string, key []byte) (*HMACSigner, error) {
+	if strings.TrimSpace(keyName) == "" {
+		return nil, errors.New("cdn signer: key name is required")
+	}
+	if len(key) == 0 {
+		return nil, errors.New("cdn signer: key is required")
+	}
+	return &HMACSigner{keyName: keyName, key: append([]byte(nil), key...)}, nil
+}
+
+// SignURL 为 rawURL 追加 Expires/KeyName/Signature 查询参数。
+func (s *HMACSigner) SignURL(_ context.Context, rawURL string, expiresAt time.Time) (string, error) {
+	if _, err := url.Parse(rawURL); err != nil {
+		return "", fmt.Errorf("parse url: %w", err)
+	}
+	sep := "?"
+	if strings.Contains(rawURL, "?") {
+		sep = "&"
+	}
+	unsigned := fmt.Sprintf("%s%sExpires=%d&KeyName=%s", rawURL, sep, expiresAt.Unix(), s.keyName)
+	return unsigned + "&Signature=" + s.sign(unsigned), nil
+}
+
+// SignCookie 生成覆盖 urlPrefix 的签名 Cookie，返回 Cookie 名称与取值。
+func (s *HMACSigner) SignCookie(_ context.Context, urlPrefix string, expiresAt time.Time) (string, string, error) {
+	if strings.TrimSpace(urlPrefix) == "" {
+		return "", "", errors.New("url prefix is required")
+	}
+	policy := fmt.Sprintf("URLPrefix=%s:Expires=%d:KeyName=%s",
+		base64.URLEncoding.EncodeToString([]byte(urlPrefix)), expiresAt.Unix(), s.keyName)
+	return CookieName, policy + ":Signature=" + s.sign(policy), nil
+}
+
+// VerifyURL 校验 SignURL 生成的地址，供本地开发与测试使用。
+func (s *HMACSigner) VerifyURL(signedURL string, now time.Time) error {
+	idx := strings.LastIndex(signedURL, "&Signature=")
+	if idx < 0 {
+		return ErrInvalidSignature
+	}
+	unsigned, signature := signedURL[:idx], signedURL[idx+len("&Signature="):]
+	if !hmac.Equal([]byte(signature), []byte(s.sign(unsigned))) {
+		return ErrInvalidSignature
+	}
+	parsed, err := url.Parse(unsigned)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	return checkExpiry(parsed.Query().Get("Expires"), now)
+}
+
+// VerifyCookie 校验 SignCookie 生成的 Cookie 是否覆盖 requestURL，供本地开发与测试使用。
+func (s *HMACSigner) VerifyCookie(value, requestURL string, now time.Time) error {
+	idx := strings.LastIndex(value, ":Signature=")
+	if idx < 0 {
+		return ErrInvalidSignature
+	}
+	policy, signature := value[:idx], value[idx+len(":Signature="):]
+	if !hmac.Equal([]byte(signature), []byte(s.sign(policy))) {
+		return ErrInvalidSignature
+	}
+	fields := make(map[string]string, 3)
+	for _, part := range strings.Split(policy, ":") {
+		if k, v, ok := strings.Cut(part, "="); ok {
+			fields[k] = v
+		}
+	}
+	prefix, err := base64.URLEncoding.DecodeString(fields["URLPrefix"])
+	if err != nil || !strings.HasPrefix(requestURL, string(prefix)) {
+		return ErrInvalidSignature
+	}
+	return checkExpiry(fields["Expires"], now)
+}
+
+// sign 按 Cloud CDN 协议计算 HMAC-SHA1 并以 base64url 编码。
+func (s *HMACSigner) sign(payload string) string {
+	mac := hmac.New(sha1.New, s.key)
+	mac.Write([]byte(payload))
+	return base64.URLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func checkExpiry(raw string, now time.Time) error {
+	expires, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || now.Unix() > expires {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// DecodeKey 解析 base64url 编码的签名密钥（兼容带/不带填充）。
+func DecodeKey(encoded string) ([]byte, error) {
+	encoded = strings.TrimSpace(encoded)
+	if key, err := base64.URLEncoding.DecodeString(encoded); err == nil {
+		return key, nil
+	}
+	key, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("decode signing key: %w", err)
+	}
+	return key, nil
+}
+
+// ProvidePlaybackSigner 按配置选择播放签名实现。未配置密钥时启动失败，
+// 仅当显式开启 allow_ephemeral_key（本地开发）时生成进程内临时密钥。
+func ProvidePlaybackSigner(cfg configloader.PlaybackConfig, logger log.Logger) (services.PlaybackSigner, error) {
+	switch cfg.Signer {
+	case "", signerHMAC:
+	default:
+		return nil, fmt.Errorf("cdn signer: unsupported signer %q", cfg.Signer)
+	}
+
+	keyName := cfg.KeyName
+	if keyName == "" {
+		keyName = "catalog-local"
+	}
+	var key []byte
+	if cfg.Key != "" {
+		decoded, err := DecodeKey(cfg.Key)
+		if err != nil {
+			return nil, fmt.Errorf("cdn signer: %w", err)
+		}
+		key = decoded
+	} else {
+		if !cfg.AllowEphemeralKey {
+			return nil, errors.New("cdn signer: playback key not configured, set playback.key or PLAYBACK_SIGNING_KEY")
+		}
+		key = make([]byte, 16)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("cdn signer: generate ephemeral key: %w", err)
+		}
+		log.NewHelper(logger).Warnf("playback signing key not configured, using ephemeral key: key_name=%s", keyName)
+	}
+	return NewHMACSigner(keyName, key)
+}
@@ -0,0 +1,104 @@
+package cdn_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/cdn"
+	configloader "github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+func newTestSigner(t *testing.T) *cdn.HMACSigner {
+	t.Helper()
+	key, err := cdn.DecodeKey("ZGV2LXBsYXliYWNrLXNpZ25pbmcta2V5")
+	if err != nil {
+		t.Fatalf("DecodeKey: %v", err)
+	}
+	signer, err := cdn.NewHMACSigner("test-key", key)
+	if err != nil {
+		t.Fatalf("NewHMACSigner: %v", err)
+	}
+	return signer
+}
+
+func TestHMACSignerSignURL(t *testing.T) {
+	signer := newTestSigner(t)
+	now := time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+	expires := now.Add(time.Hour)
+
+	signed, err := signer.SignURL(context.Background(), "https://media.example.com/hls/v1/master.m3u8", expires)
+	if err != nil {
+		t.Fatalf("SignURL: %v", err)
+	}
+	if !strings.Contains(signed, "?Expires=1735736400&KeyName=test-key&Signature=") {
+		t.Fatalf("unexpected signed url: %s", signed)
+	}
+	if err := signer.VerifyURL(signed, now); err != nil {
+		t.Fatalf("VerifyURL: %v", err)
+	}
+	if err := signer.VerifyURL(signed, expires.Add(time.Second)); !errors.Is(err, cdn.ErrInvalidSignature) {
+		t.Fatalf("expected expired signature, got %v", err)
+	}
+	tampered := strings.Replace(signed, "/v1/", "/v2/", 1)
+	if err := signer.VerifyURL(tampered, now); !errors.Is(err, cdn.ErrInvalidSignature) {
+		t.Fatalf("expected tampered signature rejected, got %v", err)
+	}
+
+	withQuery, err := signer.SignURL(context.Background(), "https://media.example.com/thumb.jpg?w=320", expires)
+	if err != nil {
+		t.Fatalf("SignURL with query: %v", err)
+	}
+	if !strings.Contains(withQuery, "?w=320&Expires=") {
+		t.Fatalf("existing query should be preserved: %s", withQuery)
+	}
+	if err := signer.VerifyURL(withQuery, now); err != nil {
+		t.Fatalf("VerifyURL with query: %v", err)
+	}
+}
+
+func TestHMACSignerSignCookie(t *testing.T) {
+	signer := newTestSigner(t)
+	now := time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	name, value, err := signer.SignCookie(context.Background(), "https://media.example.com/hls/v1/", now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("SignCookie: %v", err)
+	}
+	if name != cdn.CookieName {
+		t.Fatalf("unexpected cookie name %s", name)
+	}
+	if err := signer.VerifyCookie(value, "https://media.example.com/hls/v1/480p/seg-3.ts", now); err != nil {
+		t.Fatalf("VerifyCookie: %v", err)
+	}
+	if err := signer.VerifyCookie(value, "https://media.example.com/hls/v10/master.m3u8", now); !errors.Is(err, cdn.ErrInvalidSignature) {
+		t.Fatalf("expected prefix mismatch, got %v", err)
+	}
+	if err := signer.VerifyCookie(value, "https://media.example.com/hls/v1/master.m3u8", now.Add(2*time.Hour)); !errors.Is(err, cdn.ErrInvalidSignature) {
+		t.Fatalf("expected expired cookie, got %v", err)
+	}
+	if _, _, err := signer.SignCookie(context.Background(), " ", now); err == nil {
+		t.Fatalf("expected error for empty prefix")
+	}
+}
+
+func TestProvidePlaybackSigner(t *testing.T) {
+	logger := log.NewStdLogger(io.Discard)
+	if _, err := cdn.ProvidePlaybackSigner(configloader.PlaybackConfig{Signer: "kms"}, logger); err == nil {
+		t.Fatalf("expected unsupported signer error")
+	}
+	if _, err := cdn.ProvidePlaybackSigner(configloader.PlaybackConfig{Key: "%%%"}, logger); err == nil {
+		t.Fatalf("expected invalid key error")
+	}
+	if _, err := cdn.ProvidePlaybackSigner(configloader.PlaybackConfig{Signer: "hmac"}, logger); err == nil {
+		t.Fatalf("expected missing key error without dev mode")
+	}
+	signer, err := cdn.ProvidePlaybackSigner(configloader.PlaybackConfig{Signer: "hmac", AllowEphemeralKey: true}, logger)
+	if err != nil || signer == nil {
+		t.Fatalf("expected ephemeral signer, got %v", err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"strings"
 
 	configpb "github.com/bionicotaku/lingo-services-catalog/configs"
 
@@ -24,6 +25,8 @@ const (
 	envConfPath           = "CONF_PATH"
 	envDatabaseURL        = "DATABASE_URL"
 	envPort               = "PORT"
+	envPlaybackSigningKey = "PLAYBACK_SIGNING_KEY"
+	envWebhookSecret      = "WEBHOOK_SECRET_"
 	envServiceName        = "SERVICE_NAME"
 	envServiceVersion     = "SERVICE_VERSION"
 	envEnvironment        = "APP_ENV"
@@ -200,6 +203,33 @@ func applyEnvOverrides(b *configpb.Bootstrap) {
 			server.Grpc.Addr = replacePort(server.Grpc.GetAddr(), port)
 		}
 	}
+	if key := os.Getenv(envPlaybackSigningKey); key != "" {
+		if b.Playback == nil {
+			b.Playback = &configpb.Playback{}
+		}
+		b.Playback.Key = key
+	}
+	for _, topic := range b.GetMessaging().GetTopics() {
+		for _, ep := range topic.GetWebhook().GetEndpoints() {
+			if secret := os.Getenv(webhookSecretEnv(ep.GetName())); secret != "" {
+				ep.Secret = secret
+			}
+		}
+	}
+}
+
+// webhookSecretEnv 返回覆盖端点签名密钥的环境变量名：WEBHOOK_SECRET_ 加大写端点名，非字母数字替换为下划线。
+func webhookSecretEnv(name string) string {
+	var b strings.Builder
+	b.WriteString(envWebhookSecret)
+	for _, r := range strings.ToUpper(name) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
 }
 
 func replacePort(addr, port string) string {
@@ -1,6 +1,7 @@
 package configloader
 
 import (
+	"strings"
 	"time"
 
 	configpb "github.com/bionicotaku/lingo-services-catalog/configs"
@@ -24,6 +25,9 @@ func fromProto(b *configpb.Bootstrap) RuntimeConfig {
 		Observability: observabilityFromProto(b.GetObservability()),
 		Messaging:     messagingFromProto(b.GetMessaging(), b.GetData()),
 		GCS:           gcsFromProto(b.GetGcs()),
+		UploadQuota:   uploadQuotaFromProto(b.GetUploadQuota()),
+		Playback:      playbackFromProto(b.GetPlayback()),
+		Engagement:    engagementFromProto(b.GetEngagement()),
 	}
 	return rc
 }
@@ -201,6 +205,17 @@ func messagingFromProto(msg *configpb.Messaging, data *configpb.Data) MessagingC
 		}
 		cfg.Inboxes[key] = inboxFromProto(inbox)
 	}
+	if retention := msg.GetRetention(); retention != nil {
+		cfg.Retention = RetentionConfig{
+			OutboxPublishedRetention: durationOrZero(retention.GetOutboxPublishedRetention()),
+			OutboxAbandonedRetention: durationOrZero(retention.GetOutboxAbandonedRetention()),
+			InboxProcessedRetention:  durationOrZero(retention.GetInboxProcessedRetention()),
+			Interval:                 durationOrZero(retention.GetInterval()),
+			BatchSize:                retention.GetBatchSize(),
+			Archive:                  retention.GetArchive(),
+			ExportDir:                strings.TrimSpace(retention.GetExportDir()),
+		}
+	}
 	return cfg
 }
 
@@ -216,6 +231,97 @@ func gcsFromProto(cfg *configpb.GCS) GCSConfig {
 	}
 }
 
+func uploadQuotaFromProto(cfg *configpb.UploadQuota) UploadQuotaConfig {
+	if cfg == nil {
+		return UploadQuotaConfig{}
+	}
+	quota := UploadQuotaConfig{
+		DefaultTier: strings.ToLower(strings.TrimSpace(cfg.GetDefaultTier())),
+	}
+	for name, tier := range cfg.GetTiers() {
+		if quota.Tiers == nil {
+			quota.Tiers = make(map[string]UploadQuotaTierConfig, len(cfg.GetTiers()))
+		}
+		quota.Tiers[strings.ToLower(strings.TrimSpace(name))] = UploadQuotaTierConfig{
+			DailySessions:     int(tier.GetDailySessionLimit()),
+			ConcurrentUploads: int(tier.GetConcurrentUploadLimit()),
+			StorageBytes:      tier.GetStorageBytesLimit(),
+		}
+	}
+	return quota
+}
+
+func playbackFromProto(cfg *configpb.Playback) PlaybackConfig {
+	if cfg == nil {
+		return PlaybackConfig{}
+	}
+	return PlaybackConfig{
+		Signer:            strings.ToLower(strings.TrimSpace(cfg.GetSigner())),
+		KeyName:           strings.TrimSpace(cfg.GetKeyName()),
+		Key:               strings.TrimSpace(cfg.GetKey()),
+		CDNHost:           strings.TrimRight(strings.TrimSpace(cfg.GetCdnHost()), "/"),
+		PlaylistTTL:       durationOrZero(cfg.GetPlaylistTtl()),
+		ThumbnailTTL:      durationOrZero(cfg.GetThumbnailTtl()),
+		SignedCookie:      cfg.GetSignedCookie(),
+		AllowEphemeralKey: cfg.GetAllowEphemeralKey(),
+	}
+}
+
+func engagementFromProto(cfg *configpb.Engagement) EngagementConfig {
+	// 有效播放阈值按字段是否出现决定是否取默认值，显式配置的 0 保留为关闭该项阈值。
+	out := EngagementConfig{
+		Views: ViewQualificationConfig{MinWatchSeconds: 30, MinWatchRatio: 0.5},
+	}
+	if views := cfg.GetViews(); views != nil {
+		if views.MinWatchSeconds != nil {
+			out.Views.MinWatchSeconds = views.GetMinWatchSeconds()
+		}
+		if views.MinWatchRatio != nil {
+			out.Views.MinWatchRatio = views.GetMinWatchRatio()
+		}
+		out.Views.SessionWindow = durationOrZero(views.GetSessionWindow())
+	}
+	if rollups := cfg.GetRollups(); rollups != nil {
+		out.Rollups = RollupRetentionConfig{
+			HourlyRetention: durationOrZero(rollups.GetHourlyRetention()),
+			DailyRetention:  durationOrZero(rollups.GetDailyRetention()),
+			PruneInterval:   durationOrZero(rollups.GetPruneInterval()),
+			PruneBatchSize:  rollups.GetPruneBatchSize(),
+		}
+	}
+	if trending := cfg.GetTrending(); trending != nil {
+		out.Trending = TrendingConfig{
+			Interval:    durationOrZero(trending.GetInterval()),
+			Window:      durationOrZero(trending.GetWindow()),
+			HalfLife:    durationOrZero(trending.GetHalfLife()),
+			LikeWeight:  trending.GetLikeWeight(),
+			ViewWeight:  trending.GetViewWeight(),
+			MaxVideos:   trending.GetMaxVideos(),
+			SnapshotTTL: durationOrZero(trending.GetSnapshotTtl()),
+		}
+	}
+	if purge := cfg.GetPurge(); purge != nil {
+		out.Purge = PurgeConfig{
+			Interval:  durationOrZero(purge.GetInterval()),
+			BatchSize: purge.GetBatchSize(),
+		}
+	}
+	if counters := cfg.GetCounters(); counters != nil {
+		out.Counters = CountersConfig{
+			Shards:           counters.GetShards(),
+			CompactInterval:  durationOrZero(counters.GetCompactInterval()),
+			CompactBatchSize: counters.GetCompactBatchSize(),
+		}
+	}
+	if batch := cfg.GetBatch(); batch != nil {
+		out.Batch = BatchConfig{
+			MaxEvents: batch.GetMaxEvents(),
+			MaxWait:   durationOrZero(batch.GetMaxWait()),
+		}
+	}
+	return out
+}
+
 func pubsubFromProto(pb *configpb.PubSub) PubSubConfig {
 	if pb == nil {
 		return PubSubConfig{}
@@ -241,6 +347,36 @@ func pubsubFromProto(pb *configpb.PubSub) PubSubConfig {
 			MaxExtensionPeriod:     durationOrZero(r.GetMaxExtensionPeriod()),
 		}
 	}
+	cfg.Sink = strings.ToLower(strings.TrimSpace(pb.GetSink()))
+	for _, eventType := range pb.GetOutboxEventTypes() {
+		if eventType = strings.TrimSpace(eventType); eventType != "" {
+			cfg.OutboxEventTypes = append(cfg.OutboxEventTypes, eventType)
+		}
+	}
+	if k := pb.GetKafka(); k != nil {
+		cfg.Kafka = KafkaSinkConfig{
+			Brokers:      append([]string(nil), k.GetBrokers()...),
+			ClientID:     k.GetClientId(),
+			RequiredAcks: strings.ToLower(strings.TrimSpace(k.GetRequiredAcks())),
+			WriteTimeout: durationOrZero(k.GetWriteTimeout()),
+		}
+	}
+	if n := pb.GetNats(); n != nil {
+		cfg.NATS = NATSSinkConfig{
+			URL:             n.GetUrl(),
+			Stream:          n.GetStream(),
+			CredentialsFile: n.GetCredentialsFile(),
+		}
+	}
+	for _, ep := range pb.GetWebhook().GetEndpoints() {
+		cfg.Webhook.Endpoints = append(cfg.Webhook.Endpoints, WebhookEndpointConfig{
+			Name:    ep.GetName(),
+			URL:     ep.GetUrl(),
+			Secret:  ep.GetSecret(),
+			Timeout: durationOrZero(ep.GetTimeout()),
+			Headers: mapCopy(ep.GetHeaders()),
+		})
+	}
 	return cfg
 }
 
@@ -337,4 +473,105 @@ func fillDefaults(cfg *RuntimeConfig) {
 	if cfg.GCS.SignedURLTTL <= 0 {
 		cfg.GCS.SignedURLTTL = 15 * time.Minute
 	}
+	if cfg.UploadQuota.DefaultTier == "" && len(cfg.UploadQuota.Tiers) > 0 {
+		cfg.UploadQuota.DefaultTier = "free"
+	}
+	if cfg.Playback.Signer == "" {
+		cfg.Playback.Signer = "hmac"
+	}
+	if cfg.Playback.PlaylistTTL <= 0 {
+		cfg.Playback.PlaylistTTL = time.Hour
+	}
+	if cfg.Playback.ThumbnailTTL <= 0 {
+		cfg.Playback.ThumbnailTTL = 24 * time.Hour
+	}
+	if cfg.Engagement.Views.SessionWindow <= 0 {
+		cfg.Engagement.Views.SessionWindow = 30 * time.Minute
+	}
+	if cfg.Engagement.Rollups.HourlyRetention <= 0 {
+		cfg.Engagement.Rollups.HourlyRetention = 90 * 24 * time.Hour
+	}
+	if cfg.Engagement.Rollups.DailyRetention <= 0 {
+		cfg.Engagement.Rollups.DailyRetention = 730 * 24 * time.Hour
+	}
+	if cfg.Engagement.Rollups.PruneInterval <= 0 {
+		cfg.Engagement.Rollups.PruneInterval = time.Hour
+	}
+	if cfg.Engagement.Rollups.PruneBatchSize <= 0 {
+		cfg.Engagement.Rollups.PruneBatchSize = 1000
+	}
+	if cfg.Engagement.Trending.Interval <= 0 {
+		cfg.Engagement.Trending.Interval = 10 * time.Minute
+	}
+	if cfg.Engagement.Trending.Window <= 0 {
+		cfg.Engagement.Trending.Window = 72 * time.Hour
+	}
+	if cfg.Engagement.Trending.HalfLife <= 0 {
+		cfg.Engagement.Trending.HalfLife = 24 * time.Hour
+	}
+	if cfg.Engagement.Trending.LikeWeight <= 0 && cfg.Engagement.Trending.ViewWeight <= 0 {
+		cfg.Engagement.Trending.LikeWeight = 3
+		cfg.Engagement.Trending.ViewWeight = 1
+	}
+	if cfg.Engagement.Trending.MaxVideos <= 0 {
+		cfg.Engagement.Trending.MaxVideos = 1000
+	}
+	if cfg.Engagement.Trending.SnapshotTTL <= 0 {
+		cfg.Engagement.Trending.SnapshotTTL = time.Hour
+	}
+	if cfg.Engagement.Purge.Interval <= 0 {
+		cfg.Engagement.Purge.Interval = 30 * time.Second
+	}
+	if cfg.Engagement.Purge.BatchSize <= 0 {
+		cfg.Engagement.Purge.BatchSize = 500
+	}
+	if cfg.Engagement.Counters.CompactInterval <= 0 {
+		cfg.Engagement.Counters.CompactInterval = 10 * time.Second
+	}
+	if cfg.Engagement.Counters.CompactBatchSize <= 0 {
+		cfg.Engagement.Counters.CompactBatchSize = 1000
+	}
+	if cfg.Engagement.Batch.MaxWait <= 0 {
+		cfg.Engagement.Batch.MaxWait = 100 * time.Millisecond
+	}
+	if cfg.Messaging.Retention.OutboxPublishedRetention <= 0 {
+		cfg.Messaging.Retention.OutboxPublishedRetention = 7 * 24 * time.Hour
+	}
+	if cfg.Messaging.Retention.OutboxAbandonedRetention <= 0 {
+		cfg.Messaging.Retention.OutboxAbandonedRetention = 30 * 24 * time.Hour
+	}
+	if cfg.Messaging.Retention.InboxProcessedRetention <= 0 {
+		cfg.Messaging.Retention.InboxProcessedRetention = 30 * 24 * time.Hour
+	}
+	if cfg.Messaging.Retention.Interval <= 0 {
+		cfg.Messaging.Retention.Interval = time.Hour
+	}
+	if cfg.Messaging.Retention.BatchSize <= 0 {
+		cfg.Messaging.Retention.BatchSize = 1000
+	}
+	for key, topic := range cfg.Messaging.Topics {
+		cfg.Messaging.Topics[key] = fillSinkDefaults(topic)
+	}
+}
+
+func fillSinkDefaults(topic PubSubConfig) PubSubConfig {
+	if topic.Sink == "" {
+		topic.Sink = "pubsub"
+	}
+	if topic.Kafka.ClientID == "" {
+		topic.Kafka.ClientID = "catalog-outbox"
+	}
+	if topic.Kafka.RequiredAcks == "" {
+		topic.Kafka.RequiredAcks = "all"
+	}
+	if topic.Kafka.WriteTimeout <= 0 {
+		topic.Kafka.WriteTimeout = 10 * time.Second
+	}
+	for i := range topic.Webhook.Endpoints {
+		ep := &topic.Webhook.Endpoints[i]
+		if ep.Timeout <= 0 {
+			ep.Timeout = 5 * time.Second
+		}
+	}
+	return topic
 }
@@ -12,6 +12,9 @@ type RuntimeConfig struct {
 	Observability ObservabilityConfig
 	Messaging     MessagingConfig
 	GCS           GCSConfig
+	UploadQuota   UploadQuotaConfig
+	Playback      PlaybackConfig
+	Engagement    EngagementConfig
 }
 
 // ServiceInfo 描述服务标识与运行环境。
@@ -124,10 +127,11 @@ type MetricsConfig struct {
 
 // MessagingConfig 汇总消息系统相关配置。
 type MessagingConfig struct {
-	Schema  string
-	Topics  map[string]PubSubConfig
-	Outbox  OutboxPublisherConfig
-	Inboxes map[string]InboxConfig
+	Schema    string
+	Topics    map[string]PubSubConfig
+	Outbox    OutboxPublisherConfig
+	Inboxes   map[string]InboxConfig
+	Retention RetentionConfig
 }
 
 // GCSConfig 描述生成签名 URL 所需的 GCS 信息。
@@ -138,7 +142,87 @@ type GCSConfig struct {
 	SignedURLTTL         time.Duration
 }
 
-// PubSubConfig 提供与 GCP Pub/Sub 兼容的设置。
+// UploadQuotaConfig 描述按档位划分的上传配额。
+type UploadQuotaConfig struct {
+	DefaultTier string
+	Tiers       map[string]UploadQuotaTierConfig
+}
+
+// UploadQuotaTierConfig 描述单个档位的配额上限，0 表示不限。
+type UploadQuotaTierConfig struct {
+	DailySessions     int
+	ConcurrentUploads int
+	StorageBytes      int64
+}
+
+// PlaybackConfig 描述播放地址签名与 CDN 域名改写配置。
+type PlaybackConfig struct {
+	Signer            string
+	KeyName           string
+	Key               string
+	CDNHost           string
+	PlaylistTTL       time.Duration
+	ThumbnailTTL      time.Duration
+	SignedCookie      bool
+	AllowEphemeralKey bool // 仅本地开发：未配置 Key 时允许生成进程内临时密钥
+}
+
+// EngagementConfig 描述 Engagement 投影的计数规则。
+type EngagementConfig struct {
+	Views    ViewQualificationConfig
+	Rollups  RollupRetentionConfig
+	Trending TrendingConfig
+	Purge    PurgeConfig
+	Counters CountersConfig
+	Batch    BatchConfig
+}
+
+// ViewQualificationConfig 描述有效播放判定阈值与会话窗口。
+type ViewQualificationConfig struct {
+	MinWatchSeconds float64
+	MinWatchRatio   float64
+	SessionWindow   time.Duration
+}
+
+// RollupRetentionConfig 描述分时统计桶的保留期与清理周期。
+type RollupRetentionConfig struct {
+	HourlyRetention time.Duration
+	DailyRetention  time.Duration
+	PruneInterval   time.Duration
+	PruneBatchSize  int32
+}
+
+// TrendingConfig 描述热度榜的计算周期、衰减参数与快照保留期。
+type TrendingConfig struct {
+	Interval    time.Duration
+	Window      time.Duration
+	HalfLife    time.Duration
+	LikeWeight  float64
+	ViewWeight  float64
+	MaxVideos   int32
+	SnapshotTTL time.Duration
+}
+
+// PurgeConfig 描述视频删除/归档、用户删除后投影清理的扫描周期与批大小。
+type PurgeConfig struct {
+	Interval  time.Duration
+	BatchSize int32
+}
+
+// CountersConfig 描述累计统计的分片计数模式与分片压实节奏；Shards <= 1 表示直接更新主统计行。
+type CountersConfig struct {
+	Shards           int32
+	CompactInterval  time.Duration
+	CompactBatchSize int32
+}
+
+// BatchConfig 描述 Engagement 事件的批量消费参数；MaxEvents <= 1 表示逐条消费。
+type BatchConfig struct {
+	MaxEvents int32
+	MaxWait   time.Duration
+}
+
+// PubSubConfig 提供与 GCP Pub/Sub 兼容的设置；Sink 选择 Outbox 发布端，非 pubsub 时使用对应的 Kafka/NATS/Webhook 配置。
 type PubSubConfig struct {
 	ProjectID           string
 	TopicID             string
@@ -151,6 +235,41 @@ type PubSubConfig struct {
 	ExactlyOnceDelivery bool
 	DeadLetterTopicID   string
 	Receive             PubSubReceiveConfig
+	Sink                string
+	Kafka               KafkaSinkConfig
+	NATS                NATSSinkConfig
+	Webhook             WebhookSinkConfig
+	// OutboxEventTypes 非空时该 topic 作为 Outbox 的额外发布目标，只接收列出的事件类型（"*" 表示全部）。
+	OutboxEventTypes []string
+}
+
+// KafkaSinkConfig 描述 Kafka 发布端的连接与写入确认。
+type KafkaSinkConfig struct {
+	Brokers      []string
+	ClientID     string
+	RequiredAcks string
+	WriteTimeout time.Duration
+}
+
+// NATSSinkConfig 描述 NATS JetStream 发布端的连接与目标 stream。
+type NATSSinkConfig struct {
+	URL             string
+	Stream          string
+	CredentialsFile string
+}
+
+// WebhookSinkConfig 描述 Webhook 发布端的全部端点。
+type WebhookSinkConfig struct {
+	Endpoints []WebhookEndpointConfig
+}
+
+// WebhookEndpointConfig 描述单个 Webhook 端点的地址、签名密钥与请求超时。
+type WebhookEndpointConfig struct {
+	Name    string
+	URL     string
+	Secret  string
+	Timeout time.Duration
+	Headers map[string]string
 }
 
 // PubSubReceiveConfig 控制订阅者拉取行为。
@@ -176,6 +295,17 @@ type OutboxPublisherConfig struct {
 	MetricsEnabled *bool
 }
 
+// RetentionConfig 描述 Outbox/Inbox 表的保留期、清理节奏与归档方式。
+type RetentionConfig struct {
+	OutboxPublishedRetention time.Duration
+	OutboxAbandonedRetention time.Duration
+	InboxProcessedRetention  time.Duration
+	Interval                 time.Duration
+	BatchSize                int32
+	Archive                  bool
+	ExportDir                string
+}
+
 // InboxConfig 配置 Inbox 消费者的行为。
 type InboxConfig struct {
 	SourceService  string
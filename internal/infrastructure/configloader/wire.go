@@ -12,10 +12,11 @@ import (
     "github.com/bionicotaku/lingo-utils/pgxpoolx"
     txconfig "github.com/bionicotaku/lingo-utils/txmanager"
     "github.com/go-kratos/kratos/v2/log"
-    "github.com/google/uuid"
     "github.com/google/wire"
 
     "github.com/bionicotaku/lingo-services-catalog/internal/controllers"
+    "github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+    "github.com/bionicotaku/lingo-services-catalog/internal/services"
 )
 
 // EngagementPubSubConfig 包装 engagement 订阅所需的 gcpubsub.Config，避免与主 Pub/Sub 冲突。
@@ -30,6 +31,21 @@ type UploadPubSubConfig gcpubsub.Config
 // UploadSubscriber 标签化上传回调订阅者。
 type UploadSubscriber gcpubsub.Subscriber
 
+// VideoEventsPubSubConfig 包装 Engagement 清理订阅 catalog 自身视频事件的配置。
+type VideoEventsPubSubConfig gcpubsub.Config
+
+// VideoEventsSubscriber 标签化视频事件订阅者（消费 catalog.video.deleted / 归档事件）。
+type VideoEventsSubscriber gcpubsub.Subscriber
+
+// ProfileUserPubSubConfig 包装 Profile 用户事件订阅配置。
+type ProfileUserPubSubConfig gcpubsub.Config
+
+// ProfileUserSubscriber 标签化 Profile 用户事件订阅者（消费 profile.user.deleted）。
+type ProfileUserSubscriber gcpubsub.Subscriber
+
+// OutboxTopicConfig 包装 Outbox 发布使用的 topic（default）配置，由 eventsink 按 Sink 选择发布端。
+type OutboxTopicConfig PubSubConfig
+
 // ProviderSet 暴露配置加载相关的依赖注入入口。
 var ProviderSet = wire.NewSet(
 	LoadRuntimeConfig,
@@ -45,14 +61,30 @@ var ProviderSet = wire.NewSet(
 	ProvideClientConfig,
 	ProvideMessagingConfig,
 	ProvidePubSubConfig,
+	ProvideOutboxTopicConfig,
 	ProvideEngagementConfig,
 	ProvideUploadConfig,
 	ProvidePubSubDependencies,
 	ProvideEngagementSubscriber,
 	ProvideUploadSubscriber,
 	ProvideOutboxConfig,
+	ProvideRetentionConfig,
 	ProvideHandlerTimeouts,
 	ProvideGCSConfig,
+	ProvideUploadQuotaPolicy,
+	ProvidePlaybackConfig,
+	ProvidePlaybackPolicy,
+	ProvideViewQualificationConfig,
+	ProvideRollupRetentionConfig,
+	ProvideTrendingConfig,
+	ProvidePurgeConfig,
+	ProvideCountersConfig,
+	ProvideBatchConfig,
+	ProvideStatsShardPolicy,
+	ProvideVideoEventsConfig,
+	ProvideVideoEventsSubscriber,
+	ProvideProfileUserConfig,
+	ProvideProfileUserSubscriber,
 )
 
 // LoadRuntimeConfig 调用 Load 并供 Wire 使用。
@@ -221,10 +253,34 @@ func ProvideMessagingConfig(cfg RuntimeConfig) MessagingConfig {
 	return cfg.Messaging
 }
 
-// ProvidePubSubConfig 将 MessagingConfig 转换为 gcpubsub.Config。
+// ProvidePubSubConfig 将 MessagingConfig 转换为 gcpubsub.Config；Outbox 发布端不是 Pub/Sub 时返回空配置，不创建 Pub/Sub 客户端。
 func ProvidePubSubConfig(msg MessagingConfig) gcpubsub.Config {
 	cfg, _ := selectTopic(msg, "default")
-	return toGCPubSubConfig(cfg)
+	return PubSubConfigForTopic(OutboxTopicConfig(cfg))
+}
+
+// ProvideOutboxTopicConfig 返回 Outbox 发布使用的 topic 配置，包含发布端选择与 Kafka/NATS/Webhook 参数。
+func ProvideOutboxTopicConfig(msg MessagingConfig) OutboxTopicConfig {
+	cfg, _ := selectTopic(msg, "default")
+	return OutboxTopicConfig(cfg)
+}
+
+// TopicConfigFor 返回 messaging.topics 下指定键的发布配置，供事件回填等显式选择发布目标的场景使用。
+// 与 selectTopic 不同，未配置该键时返回错误而不回退到 default，避免事件被发往非预期的 topic。
+func TopicConfigFor(msg MessagingConfig, key string) (OutboxTopicConfig, error) {
+	cfg, ok := msg.Topics[key]
+	if !ok {
+		return OutboxTopicConfig{}, fmt.Errorf("messaging.topics.%s is not configured", key)
+	}
+	return OutboxTopicConfig(cfg), nil
+}
+
+// PubSubConfigForTopic 将发布配置转换为 gcpubsub.Config；发布端不是 Pub/Sub 时返回空配置，不创建 Pub/Sub 客户端。
+func PubSubConfigForTopic(topic OutboxTopicConfig) gcpubsub.Config {
+	if topic.Sink != "" && topic.Sink != "pubsub" {
+		return gcpubsub.Config{}
+	}
+	return toGCPubSubConfig(PubSubConfig(topic))
 }
 
 // ProvideEngagementConfig 返回 engagement Pub/Sub 配置包装。
@@ -245,6 +301,25 @@ func ProvideUploadConfig(msg MessagingConfig) UploadPubSubConfig {
 	return UploadPubSubConfig(toGCPubSubConfig(cfg))
 }
 
+// ProvideVideoEventsConfig 返回 Engagement 清理订阅视频事件的配置；未配置 video_events 时不订阅。
+// 不回退到 default：default 订阅属于其他消费者，共用会抢占其消息。
+func ProvideVideoEventsConfig(msg MessagingConfig) VideoEventsPubSubConfig {
+	cfg, ok := msg.Topics["video_events"]
+	if !ok {
+		return VideoEventsPubSubConfig(gcpubsub.Config{})
+	}
+	return VideoEventsPubSubConfig(toGCPubSubConfig(cfg))
+}
+
+// ProvideProfileUserConfig 返回 Profile 用户事件订阅配置；未配置 profile_users 时不订阅。
+func ProvideProfileUserConfig(msg MessagingConfig) ProfileUserPubSubConfig {
+	cfg, ok := msg.Topics["profile_users"]
+	if !ok {
+		return ProfileUserPubSubConfig(gcpubsub.Config{})
+	}
+	return ProfileUserPubSubConfig(toGCPubSubConfig(cfg))
+}
+
 func toGCPubSubConfig(cfg PubSubConfig) gcpubsub.Config {
 	if cfg.ProjectID == "" {
 		return gcpubsub.Config{}
@@ -288,7 +363,7 @@ func ProvideEngagementSubscriber(ctx context.Context, cfg EngagementPubSubConfig
 	return EngagementSubscriber(gcpubsub.ProvideSubscriber(comp)), cleanup, nil
 }
 
-// ProvideUploadSubscriber 构造上传回调订阅者，并补充所需的事件属性。
+// ProvideUploadSubscriber 构造上传回调订阅者；通知格式识别与事件属性补齐由 uploads Runner 负责。
 func ProvideUploadSubscriber(ctx context.Context, cfg UploadPubSubConfig, deps gcpubsub.Dependencies) (UploadSubscriber, func(), error) {
 	base := gcpubsub.Config(cfg)
 	if base.ProjectID == "" || base.SubscriptionID == "" {
@@ -298,8 +373,38 @@ func ProvideUploadSubscriber(ctx context.Context, cfg UploadPubSubConfig, deps g
 	if err != nil {
 		return UploadSubscriber(nil), cleanup, err
 	}
-	inner := gcpubsub.ProvideSubscriber(comp)
-	return UploadSubscriber(uploadSubscriberAdapter{inner: inner}), cleanup, nil
+	return UploadSubscriber(gcpubsub.ProvideSubscriber(comp)), cleanup, nil
+}
+
+// ProvideVideoEventsSubscriber 构造视频事件订阅者。
+func ProvideVideoEventsSubscriber(ctx context.Context, cfg VideoEventsPubSubConfig, deps gcpubsub.Dependencies) (VideoEventsSubscriber, func(), error) {
+	base := gcpubsub.Config(cfg)
+	if base.ProjectID == "" || base.SubscriptionID == "" {
+		return VideoEventsSubscriber(nil), func() {}, nil
+	}
+	comp, cleanup, err := gcpubsub.NewComponent(ctx, base, deps)
+	if err != nil {
+		return VideoEventsSubscriber(nil), cleanup, err
+	}
+	return VideoEventsSubscriber(gcpubsub.ProvideSubscriber(comp)), cleanup, nil
+}
+
+// ProvideProfileUserSubscriber 构造 Profile 用户事件订阅者。
+func ProvideProfileUserSubscriber(ctx context.Context, cfg ProfileUserPubSubConfig, deps gcpubsub.Dependencies) (ProfileUserSubscriber, func(), error) {
+	base := gcpubsub.Config(cfg)
+	if base.ProjectID == "" || base.SubscriptionID == "" {
+		return ProfileUserSubscriber(nil), func() {}, nil
+	}
+	comp, cleanup, err := gcpubsub.NewComponent(ctx, base, deps)
+	if err != nil {
+		return ProfileUserSubscriber(nil), cleanup, err
+	}
+	return ProfileUserSubscriber(gcpubsub.ProvideSubscriber(comp)), cleanup, nil
+}
+
+// ProvideRetentionConfig 暴露 Outbox/Inbox 保留期配置供 Retention Runner 使用。
+func ProvideRetentionConfig(msg MessagingConfig) RetentionConfig {
+	return msg.Retention
 }
 
 // ProvideOutboxConfig 构造 outboxcfg.Config。
@@ -340,55 +445,21 @@ func ProvideGCSConfig(cfg RuntimeConfig) GCSConfig {
 	return cfg.GCS
 }
 
-const defaultUploadEventType = "OBJECT_FINALIZE"
-
-type uploadSubscriberAdapter struct {
-	inner gcpubsub.Subscriber
-}
-
-func (a uploadSubscriberAdapter) Receive(ctx context.Context, handler func(context.Context, *gcpubsub.Message) error) error {
-	if a.inner == nil {
-		return nil
-	}
-	return a.inner.Receive(ctx, func(c context.Context, msg *gcpubsub.Message) error {
-		ensureUploadAttributes(msg)
-		return handler(c, msg)
-	})
-}
-
-func (a uploadSubscriberAdapter) Stop() {
-	if a.inner != nil {
-		a.inner.Stop()
-	}
-}
-
-func ensureUploadAttributes(msg *gcpubsub.Message) {
-	if msg == nil {
-		return
-	}
-	if msg.Attributes == nil {
-		msg.Attributes = make(map[string]string)
-	}
-	attrs := msg.Attributes
-	if _, ok := attrs["event_type"]; !ok {
-		if eventType := attrs["eventType"]; eventType != "" {
-			attrs["event_type"] = eventType
-		} else {
-			attrs["event_type"] = defaultUploadEventType
-		}
-	}
-	if _, ok := attrs["event_id"]; !ok {
-		bucket := attrs["bucketId"]
-		object := attrs["objectId"]
-		generation := attrs["objectGeneration"]
-		if bucket != "" && object != "" && generation != "" {
-			attrs["event_id"] = fmt.Sprintf("%s/%s#%s", bucket, object, generation)
-		} else if msg.ID != "" {
-			attrs["event_id"] = msg.ID
-		} else {
-			attrs["event_id"] = uuid.NewString()
+// ProvideUploadQuotaPolicy 将上传配额配置映射为服务层使用的配额策略。
+func ProvideUploadQuotaPolicy(cfg RuntimeConfig) services.UploadQuotaPolicy {
+	quota := cfg.UploadQuota
+	policy := services.UploadQuotaPolicy{DefaultTier: quota.DefaultTier}
+	if len(quota.Tiers) > 0 {
+		policy.Tiers = make(map[string]services.UploadQuotaTier, len(quota.Tiers))
+		for name, tier := range quota.Tiers {
+			policy.Tiers[name] = services.UploadQuotaTier{
+				DailySessions:     tier.DailySessions,
+				ConcurrentUploads: tier.ConcurrentUploads,
+				StorageBytes:      tier.StorageBytes,
+			}
 		}
 	}
+	return policy
 }
 
 func boolPtr(v bool) *bool {
@@ -426,3 +497,53 @@ func selectInbox(msg MessagingConfig, name string) InboxConfig {
 	}
 	return InboxConfig{}
 }
+
+// ProvidePlaybackConfig 暴露播放签名配置供签名器构造使用。
+func ProvidePlaybackConfig(cfg RuntimeConfig) PlaybackConfig {
+	return cfg.Playback
+}
+
+// ProvidePlaybackPolicy 将播放配置映射为服务层使用的有效期与 CDN 改写策略。
+func ProvidePlaybackPolicy(cfg PlaybackConfig) services.PlaybackPolicy {
+	return services.PlaybackPolicy{
+		CDNHost:      cfg.CDNHost,
+		PlaylistTTL:  cfg.PlaylistTTL,
+		ThumbnailTTL: cfg.ThumbnailTTL,
+		SignedCookie: cfg.SignedCookie,
+	}
+}
+
+// ProvideViewQualificationConfig 暴露有效播放判定配置供 Engagement 投影使用。
+func ProvideViewQualificationConfig(cfg RuntimeConfig) ViewQualificationConfig {
+	return cfg.Engagement.Views
+}
+
+// ProvideRollupRetentionConfig 暴露分时统计桶保留期配置供 Engagement Runner 清理使用。
+func ProvideRollupRetentionConfig(cfg RuntimeConfig) RollupRetentionConfig {
+	return cfg.Engagement.Rollups
+}
+
+// ProvideTrendingConfig 暴露热度榜计算配置供 Engagement Runner 使用。
+func ProvideTrendingConfig(cfg RuntimeConfig) TrendingConfig {
+	return cfg.Engagement.Trending
+}
+
+// ProvidePurgeConfig 暴露投影清理配置供 Engagement Runner 使用。
+func ProvidePurgeConfig(cfg RuntimeConfig) PurgeConfig {
+	return cfg.Engagement.Purge
+}
+
+// ProvideCountersConfig 暴露分片计数配置供 Engagement Runner 压实分片使用。
+func ProvideCountersConfig(cfg RuntimeConfig) CountersConfig {
+	return cfg.Engagement.Counters
+}
+
+// ProvideBatchConfig 暴露批量消费配置供 Engagement Runner 使用。
+func ProvideBatchConfig(cfg RuntimeConfig) BatchConfig {
+	return cfg.Engagement.Batch
+}
+
+// ProvideStatsShardPolicy 将分片计数配置映射为统计仓储的分片策略。
+func ProvideStatsShardPolicy(cfg CountersConfig) repositories.StatsShardPolicy {
+	return repositories.StatsShardPolicy{Shards: int(cfg.Shards)}
+}
@@ -0,0 +1,85 @@
+package eventsink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaConfig 描述 Kafka 发布端参数。
+type KafkaConfig struct {
+	Brokers      []string
+	Topic        string
+	ClientID     string
+	RequiredAcks string // all / one / none
+	WriteTimeout time.Duration
+}
+
+// KafkaSink 把事件写入 Kafka topic：key 为 aggregate_id，事件属性写入 record headers。
+type KafkaSink struct {
+	writer *kafka.Writer
+}
+
+// NewKafkaSink 构造 KafkaSink；连接在首次写入时建立。
+func NewKafkaSink(cfg KafkaConfig) (*KafkaSink, error) {
+	if len(cfg.Brokers) == 0 {
+		return nil, errors.New("eventsink: kafka brokers are required")
+	}
+	if cfg.Topic == "" {
+		return nil, errors.New("eventsink: kafka topic is required")
+	}
+	acks, err := parseRequiredAcks(cfg.RequiredAcks)
+	if err != nil {
+		return nil, err
+	}
+	writer := &kafka.Writer{
+		Addr:  kafka.TCP(cfg.Brokers...),
+		Topic: cfg.Topic,
+		// 与 Java 客户端默认分区器一致，其他语言的生产者按同一 key 写入时落在同一分区。
+		Balancer:     &kafka.Murmur2Balancer{},
+		RequiredAcks: acks,
+		WriteTimeout: cfg.WriteTimeout,
+		// 每次调用只写一条事件，并发由 Outbox Runner 的 workers 控制，不在客户端攒批等待。
+		BatchSize: 1,
+		Transport: &kafka.Transport{ClientID: cfg.ClientID},
+	}
+	return &KafkaSink{writer: writer}, nil
+}
+
+// Publish 同步写入单条事件，按 RequiredAcks 等待 broker 确认。
+func (s *KafkaSink) Publish(ctx context.Context, msg Message) error {
+	headers := make([]kafka.Header, 0, len(msg.Headers))
+	for key, value := range msg.Headers {
+		headers = append(headers, kafka.Header{Key: key, Value: []byte(value)})
+	}
+	record := kafka.Message{
+		Key:     []byte(msg.AggregateID.String()),
+		Value:   msg.Payload,
+		Headers: headers,
+	}
+	if err := s.writer.WriteMessages(ctx, record); err != nil {
+		return fmt.Errorf("kafka write: %w", err)
+	}
+	return nil
+}
+
+// Close 关闭底层 writer。
+func (s *KafkaSink) Close() error {
+	return s.writer.Close()
+}
+
+func parseRequiredAcks(raw string) (kafka.RequiredAcks, error) {
+	switch raw {
+	case "", "all":
+		return kafka.RequireAll, nil
+	case "one":
+		return kafka.RequireOne, nil
+	case "none":
+		return kafka.RequireNone, nil
+	default:
+		return kafka.RequireAll, fmt.Errorf("eventsink: unsupported kafka required_acks %q", raw)
+	}
+}
@@ -0,0 +1,89 @@
+package eventsink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// NATSConfig 描述 NATS JetStream 发布端参数。
+type NATSConfig struct {
+	URL             string
+	Subject         string
+	Stream          string // 非空时要求发布确认来自该 stream
+	CredentialsFile string
+}
+
+// NATSSink 把事件发布到 JetStream subject：event_id 作为 Nats-Msg-Id，Outbox 重试导致的重复发布在
+// stream 去重窗口内被丢弃；事件属性写入消息头。
+type NATSSink struct {
+	conn    *nats.Conn
+	js      jetstream.JetStream
+	subject string
+	stream  string
+}
+
+// NewNATSSink 连接 NATS 并构造 NATSSink；服务器暂不可用时在后台持续重连，期间发布失败由 Outbox Runner 重试。
+func NewNATSSink(cfg NATSConfig, logger log.Logger) (*NATSSink, error) {
+	if cfg.URL == "" {
+		return nil, errors.New("eventsink: nats url is required")
+	}
+	if cfg.Subject == "" {
+		return nil, errors.New("eventsink: nats subject is required")
+	}
+	helper := log.NewHelper(logger)
+	opts := []nats.Option{
+		nats.Name("catalog-outbox"),
+		nats.RetryOnFailedConnect(true),
+		nats.MaxReconnects(-1),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+			if err != nil {
+				helper.Warnf("nats event sink disconnected: %v", err)
+			}
+		}),
+		nats.ReconnectHandler(func(conn *nats.Conn) {
+			helper.Infof("nats event sink reconnected: url=%s", conn.ConnectedUrl())
+		}),
+	}
+	if cfg.CredentialsFile != "" {
+		opts = append(opts, nats.UserCredentials(cfg.CredentialsFile))
+	}
+	conn, err := nats.Connect(cfg.URL, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("eventsink: connect nats: %w", err)
+	}
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("eventsink: init jetstream: %w", err)
+	}
+	return &NATSSink{conn: conn, js: js, subject: cfg.Subject, stream: cfg.Stream}, nil
+}
+
+// Publish 发布单条事件并等待 JetStream 确认。
+func (s *NATSSink) Publish(ctx context.Context, msg Message) error {
+	out := nats.NewMsg(s.subject)
+	out.Data = msg.Payload
+	for key, value := range msg.Headers {
+		out.Header.Set(key, value)
+	}
+	opts := []jetstream.PublishOpt{jetstream.WithMsgID(msg.EventID.String())}
+	if s.stream != "" {
+		opts = append(opts, jetstream.WithExpectStream(s.stream))
+	}
+	if _, err := s.js.PublishMsg(ctx, out, opts...); err != nil {
+		return fmt.Errorf("jetstream publish: %w", err)
+	}
+	return nil
+}
+
+// Close 发送缓冲中的数据后关闭连接。
+func (s *NATSSink) Close() {
+	if err := s.conn.Drain(); err != nil {
+		s.conn.Close()
+	}
+}
@@ -0,0 +1,31 @@
+package eventsink
+
+import (
+	"context"
+
+	"github.com/bionicotaku/lingo-utils/gcpubsub"
+)
+
+// PubSubSink 通过 GCP Pub/Sub 发布事件，事件属性作为 message attributes。
+type PubSubSink struct {
+	publisher   gcpubsub.Publisher
+	orderingKey bool
+}
+
+// NewPubSubSink 包装 Pub/Sub 发布器；orderingKey 为 true 时以 aggregate_id 作为 ordering key，需与 Topic 配置一致。
+func NewPubSubSink(publisher gcpubsub.Publisher, orderingKey bool) *PubSubSink {
+	return &PubSubSink{publisher: publisher, orderingKey: orderingKey}
+}
+
+// Publish 发布单条事件并等待服务端确认。
+func (s *PubSubSink) Publish(ctx context.Context, msg Message) error {
+	out := gcpubsub.Message{
+		Data:       msg.Payload,
+		Attributes: msg.Headers,
+	}
+	if s.orderingKey {
+		out.OrderingKey = msg.AggregateID.String()
+	}
+	_, err := s.publisher.Publish(ctx, out)
+	return err
+}
@@ -0,0 +1,104 @@
+package eventsink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+)
+
+// AllEventTypes 表示 Route 接收全部事件类型。
+const AllEventTypes = "*"
+
+// Route 把一个 topic 的发布端与它接收的事件类型绑定。
+type Route struct {
+	Topic      string   // messaging.topics 下的键，用于日志、错误信息与投递记录
+	EventTypes []string // 包含 AllEventTypes 时接收全部事件
+	Sink       Sink
+}
+
+// TopicDeliveryLog 记录事件已被哪些 topic 接收。
+type TopicDeliveryLog interface {
+	MarkDelivered(ctx context.Context, sess txmanager.Session, eventID uuid.UUID, topic, eventType string) error
+	DeliveredTopics(ctx context.Context, sess txmanager.Session, eventID uuid.UUID) (map[string]struct{}, error)
+}
+
+var _ TopicDeliveryLog = (*repositories.OutboxTopicDeliveryRepository)(nil)
+
+// Router 把每条事件发布到所有匹配的 topic，并逐个 topic 记录接收成功。任一 topic 失败即返回错误，
+// 由 Outbox Runner 退避后重试；重新认领时跳过已接收的 topic，只补发失败的 topic。
+type Router struct {
+	routes     []Route
+	deliveries TopicDeliveryLog
+	log        *log.Helper
+}
+
+// NewRouter 构造 Router；每个 Route 都需要发布端与至少一个事件类型，topic 不可重复。
+// deliveries 为 nil 时不记录接收情况，重试会再次发布到已成功的 topic。
+func NewRouter(routes []Route, deliveries TopicDeliveryLog, logger log.Logger) (*Router, error) {
+	seen := make(map[string]struct{}, len(routes))
+	for _, route := range routes {
+		if route.Sink == nil {
+			return nil, fmt.Errorf("eventsink: topic %s has no sink", route.Topic)
+		}
+		if len(route.EventTypes) == 0 {
+			return nil, fmt.Errorf("eventsink: topic %s has no event types", route.Topic)
+		}
+		if _, dup := seen[route.Topic]; dup {
+			return nil, fmt.Errorf("eventsink: duplicate topic %s", route.Topic)
+		}
+		seen[route.Topic] = struct{}{}
+	}
+	if logger == nil {
+		logger = log.DefaultLogger
+	}
+	return &Router{routes: routes, deliveries: deliveries, log: log.NewHelper(logger)}, nil
+}
+
+// Publish 按 Route 顺序发布到尚未接收该事件的匹配 topic，汇总各 topic 的错误。
+func (r *Router) Publish(ctx context.Context, msg Message) error {
+	delivered := map[string]struct{}{}
+	if r.deliveries != nil {
+		var err error
+		delivered, err = r.deliveries.DeliveredTopics(ctx, nil, msg.EventID)
+		if err != nil {
+			return fmt.Errorf("load outbox topic deliveries: %w", err)
+		}
+	}
+
+	var errs []error
+	for _, route := range r.routes {
+		if !route.matches(msg.EventType) {
+			continue
+		}
+		if _, ok := delivered[route.Topic]; ok {
+			continue
+		}
+		if err := route.Sink.Publish(ctx, msg); err != nil {
+			errs = append(errs, fmt.Errorf("topic %s: %w", route.Topic, err))
+			continue
+		}
+		r.record(ctx, route.Topic, msg)
+	}
+	return errors.Join(errs...)
+}
+
+// record 写入接收记录；写入失败只告警，重试时该 topic 会再次收到事件。
+func (r *Router) record(ctx context.Context, topic string, msg Message) {
+	if r.deliveries == nil {
+		return
+	}
+	if err := r.deliveries.MarkDelivered(context.WithoutCancel(ctx), nil, msg.EventID, topic, msg.EventType); err != nil {
+		r.log.WithContext(ctx).Warnf("record outbox topic delivery failed: topic=%s event=%s err=%v", topic, msg.EventID, err)
+	}
+}
+
+func (route Route) matches(eventType string) bool {
+	return slices.Contains(route.EventTypes, AllEventTypes) || slices.Contains(route.EventTypes, eventType)
+}
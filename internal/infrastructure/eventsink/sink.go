@@ -0,0 +1,186 @@
+// Package eventsink 提供 Outbox 事件的发布端实现：GCP Pub/Sub、Kafka、NATS JetStream 与签名 HTTP Webhook。
+// 每个 topic 由 messaging.topics.<key>.sink 选择发布端，Outbox Runner 只依赖 Sink 接口。
+package eventsink
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/bionicotaku/lingo-utils/gcpubsub"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+
+	configloader "github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+)
+
+// 发布端类型，对应配置中的 sink 取值。
+const (
+	KindPubSub  = "pubsub"
+	KindKafka   = "kafka"
+	KindNATS    = "nats"
+	KindWebhook = "webhook"
+)
+
+// Message 是一条待发布的 Outbox 事件。
+type Message struct {
+	EventID     uuid.UUID
+	EventType   string
+	AggregateID uuid.UUID
+	Payload     []byte            // protobuf 编码的事件负载
+	Headers     map[string]string // 事件属性（event_id、event_type、schema_version 等）
+}
+
+// Sink 把单条事件投递到下游；返回 nil 表示下游已确认接收，错误由 Outbox Runner 退避后重试。
+type Sink interface {
+	Publish(ctx context.Context, msg Message) error
+}
+
+// ProvideSink 按 Outbox topic 配置选择发布端；pubsub 未配置 topic 时返回 nil，Outbox Runner 随之跳过初始化。
+func ProvideSink(
+	cfg configloader.OutboxTopicConfig,
+	publisher gcpubsub.Publisher,
+	deliveries *repositories.WebhookDeliveryRepository,
+	logger log.Logger,
+) (Sink, func(), error) {
+	helper := log.NewHelper(logger)
+	noop := func() {}
+
+	switch cfg.Sink {
+	case "", KindPubSub:
+		if cfg.TopicID == "" || publisher == nil {
+			return nil, noop, nil
+		}
+		return NewPubSubSink(publisher, cfg.OrderingKeyEnabled), noop, nil
+
+	case KindKafka:
+		sink, err := NewKafkaSink(KafkaConfig{
+			Brokers:      cfg.Kafka.Brokers,
+			Topic:        cfg.TopicID,
+			ClientID:     cfg.Kafka.ClientID,
+			RequiredAcks: cfg.Kafka.RequiredAcks,
+			WriteTimeout: cfg.Kafka.WriteTimeout,
+		})
+		if err != nil {
+			return nil, noop, err
+		}
+		helper.Infof("init kafka event sink: brokers=%v topic=%s acks=%s", cfg.Kafka.Brokers, cfg.TopicID, cfg.Kafka.RequiredAcks)
+		return sink, func() {
+			if err := sink.Close(); err != nil {
+				helper.Warnf("close kafka event sink: %v", err)
+			}
+		}, nil
+
+	case KindNATS:
+		sink, err := NewNATSSink(NATSConfig{
+			URL:             cfg.NATS.URL,
+			Subject:         cfg.TopicID,
+			Stream:          cfg.NATS.Stream,
+			CredentialsFile: cfg.NATS.CredentialsFile,
+		}, logger)
+		if err != nil {
+			return nil, noop, err
+		}
+		helper.Infof("init nats event sink: url=%s subject=%s stream=%s", cfg.NATS.URL, cfg.TopicID, cfg.NATS.Stream)
+		return sink, sink.Close, nil
+
+	case KindWebhook:
+		endpoints := make([]WebhookEndpoint, 0, len(cfg.Webhook.Endpoints))
+		for _, ep := range cfg.Webhook.Endpoints {
+			endpoints = append(endpoints, WebhookEndpoint{
+				Name:    ep.Name,
+				URL:     ep.URL,
+				Secret:  ep.Secret,
+				Timeout: ep.Timeout,
+				Headers: ep.Headers,
+			})
+		}
+		var deliveryLog DeliveryLog
+		if deliveries != nil {
+			deliveryLog = deliveries
+		}
+		sink, err := NewWebhookSink(endpoints, deliveryLog, logger)
+		if err != nil {
+			return nil, noop, err
+		}
+		helper.Infof("init webhook event sink: endpoints=%d", len(endpoints))
+		return sink, noop, nil
+
+	default:
+		return nil, noop, fmt.Errorf("eventsink: unsupported sink %q", cfg.Sink)
+	}
+}
+
+// ProvideOutboxSink 构造 Outbox Runner 的发布端：default 接收全部事件；其余配置了 outbox_event_types 的 topic
+// 按各自的 sink 接收匹配的事件，此时返回 Router，并把各 topic 的接收情况写入 topicDeliveries。
+// default 未配置发布端时返回 nil，Outbox Runner 随之跳过初始化。
+func ProvideOutboxSink(
+	ctx context.Context,
+	msg configloader.MessagingConfig,
+	publisher gcpubsub.Publisher,
+	deps gcpubsub.Dependencies,
+	deliveries *repositories.WebhookDeliveryRepository,
+	topicDeliveries *repositories.OutboxTopicDeliveryRepository,
+	logger log.Logger,
+) (Sink, func(), error) {
+	primary, cleanup, err := ProvideSink(configloader.ProvideOutboxTopicConfig(msg), publisher, deliveries, logger)
+	if err != nil || primary == nil {
+		return primary, cleanup, err
+	}
+
+	keys := make([]string, 0, len(msg.Topics))
+	for key, topic := range msg.Topics {
+		if key != "default" && len(topic.OutboxEventTypes) > 0 {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return primary, cleanup, nil
+	}
+	sort.Strings(keys)
+
+	cleanups := []func(){cleanup}
+	cleanupAll := func() {
+		for i := len(cleanups) - 1; i >= 0; i-- {
+			cleanups[i]()
+		}
+	}
+	routes := []Route{{Topic: "default", EventTypes: []string{AllEventTypes}, Sink: primary}}
+	for _, key := range keys {
+		topic := configloader.OutboxTopicConfig(msg.Topics[key])
+		var topicPublisher gcpubsub.Publisher
+		if pubsubCfg := configloader.PubSubConfigForTopic(topic); pubsubCfg.ProjectID != "" {
+			comp, compCleanup, err := gcpubsub.NewComponent(ctx, pubsubCfg, deps)
+			if err != nil {
+				cleanupAll()
+				return nil, func() {}, fmt.Errorf("eventsink: init pubsub for messaging.topics.%s: %w", key, err)
+			}
+			cleanups = append(cleanups, compCleanup)
+			topicPublisher = gcpubsub.ProvidePublisher(comp)
+		}
+		sink, sinkCleanup, err := ProvideSink(topic, topicPublisher, deliveries, logger)
+		if err != nil {
+			cleanupAll()
+			return nil, func() {}, fmt.Errorf("eventsink: messaging.topics.%s: %w", key, err)
+		}
+		cleanups = append(cleanups, sinkCleanup)
+		if sink == nil {
+			cleanupAll()
+			return nil, func() {}, fmt.Errorf("eventsink: messaging.topics.%s lists outbox_event_types but has no sink configured", key)
+		}
+		routes = append(routes, Route{Topic: key, EventTypes: topic.OutboxEventTypes, Sink: sink})
+	}
+
+	var topicLog TopicDeliveryLog
+	if topicDeliveries != nil {
+		topicLog = topicDeliveries
+	}
+	router, err := NewRouter(routes, topicLog, logger)
+	if err != nil {
+		cleanupAll()
+		return nil, func() {}, err
+	}
+	log.NewHelper(logger).Infof("init outbox event routes: topics=%v", append([]string{"default"}, keys...))
+	return router, cleanupAll, nil
+}
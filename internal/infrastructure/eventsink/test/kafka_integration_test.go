@@ -0,0 +1,116 @@
+package eventsink_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/eventsink"
+	"github.com/google/uuid"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestKafkaSink_KeysByAggregateID(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	broker := startRedpanda(ctx, t)
+	topic := "catalog.video.events"
+
+	client := &kafka.Client{Addr: kafka.TCP(broker), Timeout: 10 * time.Second}
+	resp, err := client.CreateTopics(ctx, &kafka.CreateTopicsRequest{
+		Topics: []kafka.TopicConfig{{Topic: topic, NumPartitions: 3, ReplicationFactor: 1}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, resp.Errors[topic])
+
+	sink, err := eventsink.NewKafkaSink(eventsink.KafkaConfig{
+		Brokers:      []string{broker},
+		Topic:        topic,
+		ClientID:     "catalog-outbox-test",
+		RequiredAcks: "all",
+		WriteTimeout: 10 * time.Second,
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, sink.Close()) }()
+
+	aggregate := uuid.New()
+	first, second, other := newMessage(), newMessage(), newMessage()
+	first.AggregateID, second.AggregateID = aggregate, aggregate
+	for _, msg := range []eventsink.Message{first, second, other} {
+		require.NoError(t, sink.Publish(ctx, msg))
+	}
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     []string{broker},
+		GroupID:     "catalog-outbox-test",
+		Topic:       topic,
+		StartOffset: kafka.FirstOffset,
+		MaxWait:     100 * time.Millisecond,
+	})
+	defer reader.Close()
+
+	readCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	byEvent := map[string]kafka.Message{}
+	for len(byEvent) < 3 {
+		record, err := reader.ReadMessage(readCtx)
+		require.NoError(t, err)
+		eventID := ""
+		for _, h := range record.Headers {
+			if h.Key == "event_id" {
+				eventID = string(h.Value)
+			}
+		}
+		require.NotEmpty(t, eventID)
+		byEvent[eventID] = record
+	}
+
+	gotFirst, gotSecond := byEvent[first.EventID.String()], byEvent[second.EventID.String()]
+	require.Equal(t, aggregate.String(), string(gotFirst.Key))
+	require.Equal(t, first.Payload, gotFirst.Value)
+	require.Equal(t, gotFirst.Partition, gotSecond.Partition, "same aggregate must land on the same partition")
+	require.Less(t, gotFirst.Offset, gotSecond.Offset)
+	require.Equal(t, other.AggregateID.String(), string(byEvent[other.EventID.String()].Key))
+}
+
+// startRedpanda 启动 Redpanda 作为本地 Kafka 替身；broker 需对外通告宿主机端口，因此预先选定固定端口映射。
+func startRedpanda(ctx context.Context, t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	hostPort := listener.Addr().(*net.TCPAddr).Port
+	require.NoError(t, listener.Close())
+
+	req := testcontainers.ContainerRequest{
+		Image:        "docker.redpanda.com/redpandadata/redpanda:v24.2.7",
+		ExposedPorts: []string{fmt.Sprintf("%d:9092/tcp", hostPort)},
+		Cmd: []string{
+			"redpanda", "start",
+			"--mode", "dev-container",
+			"--smp", "1",
+			"--kafka-addr", "PLAINTEXT://0.0.0.0:9092",
+			"--advertise-kafka-addr", fmt.Sprintf("PLAINTEXT://127.0.0.1:%d", hostPort),
+		},
+		WaitingFor: wait.ForLog("Successfully started Redpanda!").WithStartupTimeout(90 * time.Second),
+	}
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	if err != nil {
+		t.Skipf("skip kafka sink integration test: cannot start redpanda container: %v", err)
+	}
+	t.Cleanup(func() {
+		termCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = container.Terminate(termCtx)
+	})
+	return fmt.Sprintf("127.0.0.1:%d", hostPort)
+}
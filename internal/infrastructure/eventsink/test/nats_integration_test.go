@@ -0,0 +1,122 @@
+package eventsink_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/eventsink"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const natsSubject = "catalog.video.events"
+
+func TestNATSSink_PublishesToJetStreamWithDedupe(t *testing.T) {
+	t.Parallel()
+
+	url := startNATS(context.Background(), t)
+	js := jetStreamClient(t, url)
+	ctx := context.Background()
+	stream, err := js.CreateStream(ctx, jetstream.StreamConfig{
+		Name:       "CATALOG",
+		Subjects:   []string{natsSubject},
+		Duplicates: time.Minute,
+	})
+	require.NoError(t, err)
+
+	sink, err := eventsink.NewNATSSink(eventsink.NATSConfig{URL: url, Subject: natsSubject, Stream: "CATALOG"}, log.NewStdLogger(io.Discard))
+	require.NoError(t, err)
+	defer sink.Close()
+
+	msg := newMessage()
+	require.NoError(t, sink.Publish(ctx, msg))
+	// Outbox 重试导致的重复发布被 stream 按 Nats-Msg-Id 去重。
+	require.NoError(t, sink.Publish(ctx, msg))
+
+	info, err := stream.Info(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), info.State.Msgs)
+
+	stored, err := stream.GetMsg(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, natsSubject, stored.Subject)
+	require.Equal(t, msg.Payload, stored.Data)
+	require.Equal(t, msg.EventID.String(), stored.Header.Get(nats.MsgIdHdr))
+	require.Equal(t, "v1", stored.Header.Get("schema_version"))
+}
+
+func TestNATSSink_RejectsUnexpectedStream(t *testing.T) {
+	t.Parallel()
+
+	url := startNATS(context.Background(), t)
+	js := jetStreamClient(t, url)
+	ctx := context.Background()
+	_, err := js.CreateStream(ctx, jetstream.StreamConfig{Name: "CATALOG", Subjects: []string{natsSubject}})
+	require.NoError(t, err)
+
+	sink, err := eventsink.NewNATSSink(eventsink.NATSConfig{URL: url, Subject: natsSubject, Stream: "OTHER"}, log.NewStdLogger(io.Discard))
+	require.NoError(t, err)
+	defer sink.Close()
+
+	require.Error(t, sink.Publish(ctx, newMessage()))
+}
+
+func TestNATSSink_PublishFailsWhileServerUnavailable(t *testing.T) {
+	t.Parallel()
+
+	// 服务器不可用时构造仍成功并在后台重连，发布失败交由 Outbox Runner 退避重试。
+	sink, err := eventsink.NewNATSSink(eventsink.NATSConfig{URL: "nats://127.0.0.1:1", Subject: natsSubject}, log.NewStdLogger(io.Discard))
+	require.NoError(t, err)
+	defer sink.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	require.Error(t, sink.Publish(ctx, newMessage()))
+}
+
+// startNATS 启动开启 JetStream 的 NATS 容器作为本地替身，返回客户端连接地址。
+func startNATS(ctx context.Context, t *testing.T) string {
+	t.Helper()
+
+	req := testcontainers.ContainerRequest{
+		Image:        "nats:2.11-alpine",
+		ExposedPorts: []string{"4222/tcp"},
+		Cmd:          []string{"-js"},
+		WaitingFor:   wait.ForLog("Server is ready").WithStartupTimeout(60 * time.Second),
+	}
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	if err != nil {
+		t.Skipf("skip nats sink integration test: cannot start nats container: %v", err)
+	}
+	t.Cleanup(func() {
+		termCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = container.Terminate(termCtx)
+	})
+
+	host, err := container.Host(ctx)
+	require.NoError(t, err)
+	port, err := container.MappedPort(ctx, "4222")
+	require.NoError(t, err)
+	return fmt.Sprintf("nats://%s:%s", host, port.Port())
+}
+
+func jetStreamClient(t *testing.T, url string) jetstream.JetStream {
+	t.Helper()
+	conn, err := nats.Connect(url)
+	require.NoError(t, err)
+	t.Cleanup(conn.Close)
+	js, err := jetstream.New(conn)
+	require.NoError(t, err)
+	return js
+}
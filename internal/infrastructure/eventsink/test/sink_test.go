@@ -0,0 +1,213 @@
+package eventsink_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	configloader "github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
+	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/eventsink"
+	"github.com/bionicotaku/lingo-utils/gcpubsub"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvideSink_SelectsBySinkKind(t *testing.T) {
+	t.Parallel()
+
+	logger := log.NewStdLogger(io.Discard)
+	publisher := &publisherStub{}
+
+	sink, cleanup, err := eventsink.ProvideSink(configloader.OutboxTopicConfig{Sink: "pubsub"}, publisher, nil, logger)
+	require.NoError(t, err)
+	cleanup()
+	require.Nil(t, sink, "pubsub without topic disables the outbox runner")
+
+	sink, cleanup, err = eventsink.ProvideSink(configloader.OutboxTopicConfig{Sink: "pubsub", TopicID: "catalog.video.events"}, publisher, nil, logger)
+	require.NoError(t, err)
+	cleanup()
+	require.IsType(t, &eventsink.PubSubSink{}, sink)
+
+	sink, cleanup, err = eventsink.ProvideSink(configloader.OutboxTopicConfig{
+		Sink:    "webhook",
+		Webhook: configloader.WebhookSinkConfig{Endpoints: []configloader.WebhookEndpointConfig{{Name: "partner", URL: "https://partner.example.com/hooks", Secret: "s3cret"}}},
+	}, nil, nil, logger)
+	require.NoError(t, err)
+	cleanup()
+	require.IsType(t, &eventsink.WebhookSink{}, sink)
+
+	sink, cleanup, err = eventsink.ProvideSink(configloader.OutboxTopicConfig{
+		Sink:    "kafka",
+		TopicID: "catalog.video.events",
+		Kafka:   configloader.KafkaSinkConfig{Brokers: []string{"127.0.0.1:9092"}},
+	}, nil, nil, logger)
+	require.NoError(t, err)
+	cleanup()
+	require.IsType(t, &eventsink.KafkaSink{}, sink)
+
+	_, _, err = eventsink.ProvideSink(configloader.OutboxTopicConfig{Sink: "kafka", TopicID: "catalog.video.events"}, nil, nil, logger)
+	require.ErrorContains(t, err, "kafka brokers are required")
+
+	_, _, err = eventsink.ProvideSink(configloader.OutboxTopicConfig{Sink: "sqs"}, nil, nil, logger)
+	require.ErrorContains(t, err, "unsupported sink")
+}
+
+func TestPubSubSink_SetsOrderingKey(t *testing.T) {
+	t.Parallel()
+
+	publisher := &publisherStub{}
+	msg := newMessage()
+
+	require.NoError(t, eventsink.NewPubSubSink(publisher, true).Publish(context.Background(), msg))
+	require.NoError(t, eventsink.NewPubSubSink(publisher, false).Publish(context.Background(), msg))
+
+	require.Len(t, publisher.messages, 2)
+	require.Equal(t, msg.AggregateID.String(), publisher.messages[0].OrderingKey)
+	require.Empty(t, publisher.messages[1].OrderingKey)
+	require.Equal(t, msg.Payload, publisher.messages[0].Data)
+	require.Equal(t, msg.Headers, publisher.messages[0].Attributes)
+}
+
+func TestProvideOutboxSink_RoutesExtraTopicsByEventType(t *testing.T) {
+	t.Parallel()
+
+	logger := log.NewStdLogger(io.Discard)
+	publisher := &publisherStub{}
+	msg := configloader.MessagingConfig{Topics: map[string]configloader.PubSubConfig{
+		"default": {Sink: "pubsub", TopicID: "catalog.video.events"},
+		"partners": {
+			Sink:             "webhook",
+			OutboxEventTypes: []string{"catalog.video.created"},
+			Webhook:          configloader.WebhookSinkConfig{Endpoints: []configloader.WebhookEndpointConfig{{Name: "partner", URL: "https://partner.example.com/hooks", Secret: "s3cret"}}},
+		},
+		"engagement": {TopicID: "profile.engagement.events", SubscriptionID: "catalog.profile-engagement.consumer"},
+	}}
+
+	sink, cleanup, err := eventsink.ProvideOutboxSink(context.Background(), msg, publisher, gcpubsub.Dependencies{Logger: logger}, nil, nil, logger)
+	require.NoError(t, err)
+	defer cleanup()
+	require.IsType(t, &eventsink.Router{}, sink)
+
+	// 只有 default 时不包一层 Router。
+	delete(msg.Topics, "partners")
+	sink, cleanup, err = eventsink.ProvideOutboxSink(context.Background(), msg, publisher, gcpubsub.Dependencies{Logger: logger}, nil, nil, logger)
+	require.NoError(t, err)
+	defer cleanup()
+	require.IsType(t, &eventsink.PubSubSink{}, sink)
+
+	msg.Topics["mirror"] = configloader.PubSubConfig{Sink: "kafka", OutboxEventTypes: []string{"*"}}
+	_, _, err = eventsink.ProvideOutboxSink(context.Background(), msg, publisher, gcpubsub.Dependencies{Logger: logger}, nil, nil, logger)
+	require.ErrorContains(t, err, "messaging.topics.mirror")
+}
+
+func TestRouter_PublishesToMatchingTopics(t *testing.T) {
+	t.Parallel()
+
+	all, created, failing := &recordingSink{}, &recordingSink{}, &recordingSink{err: errors.New("unavailable")}
+	router, err := eventsink.NewRouter([]eventsink.Route{
+		{Topic: "default", EventTypes: []string{eventsink.AllEventTypes}, Sink: all},
+		{Topic: "created", EventTypes: []string{"catalog.video.created"}, Sink: created},
+		{Topic: "deleted", EventTypes: []string{"catalog.video.deleted"}, Sink: failing},
+	}, newFakeTopicDeliveryLog(), log.NewStdLogger(io.Discard))
+	require.NoError(t, err)
+
+	msg := newMessage()
+	require.NoError(t, router.Publish(context.Background(), msg))
+	require.Len(t, all.messages, 1)
+	require.Len(t, created.messages, 1)
+	require.Empty(t, failing.messages)
+
+	_, err = eventsink.NewRouter([]eventsink.Route{{Topic: "empty", Sink: all}}, nil, nil)
+	require.ErrorContains(t, err, "no event types")
+
+	_, err = eventsink.NewRouter([]eventsink.Route{
+		{Topic: "default", EventTypes: []string{eventsink.AllEventTypes}, Sink: all},
+		{Topic: "default", EventTypes: []string{"catalog.video.created"}, Sink: created},
+	}, nil, nil)
+	require.ErrorContains(t, err, "duplicate topic")
+}
+
+func TestRouter_RetrySkipsTopicsAlreadyDelivered(t *testing.T) {
+	t.Parallel()
+
+	all, failing := &recordingSink{}, &recordingSink{err: errors.New("unavailable")}
+	deliveries := newFakeTopicDeliveryLog()
+	router, err := eventsink.NewRouter([]eventsink.Route{
+		{Topic: "default", EventTypes: []string{eventsink.AllEventTypes}, Sink: all},
+		{Topic: "deleted", EventTypes: []string{"catalog.video.deleted"}, Sink: failing},
+	}, deliveries, log.NewStdLogger(io.Discard))
+	require.NoError(t, err)
+
+	msg := newMessage()
+	msg.EventType = "catalog.video.deleted"
+	err = router.Publish(context.Background(), msg)
+	require.ErrorContains(t, err, "topic deleted")
+	require.NotContains(t, err.Error(), "topic default")
+	require.Len(t, all.messages, 1)
+
+	// Outbox 重新认领后再次发布：已接收的 topic 不再收到同一事件。
+	failing.err = nil
+	require.NoError(t, router.Publish(context.Background(), msg))
+	require.Len(t, all.messages, 1)
+	require.Len(t, failing.messages, 1)
+
+	delivered, err := deliveries.DeliveredTopics(context.Background(), nil, msg.EventID)
+	require.NoError(t, err)
+	require.Equal(t, map[string]struct{}{"default": {}, "deleted": {}}, delivered)
+}
+
+// fakeTopicDeliveryLog 在内存中模拟 catalog.outbox_topic_deliveries。
+type fakeTopicDeliveryLog struct {
+	mu        sync.Mutex
+	delivered map[uuid.UUID]map[string]struct{}
+}
+
+func newFakeTopicDeliveryLog() *fakeTopicDeliveryLog {
+	return &fakeTopicDeliveryLog{delivered: map[uuid.UUID]map[string]struct{}{}}
+}
+
+func (f *fakeTopicDeliveryLog) MarkDelivered(_ context.Context, _ txmanager.Session, eventID uuid.UUID, topic, _ string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.delivered[eventID] == nil {
+		f.delivered[eventID] = map[string]struct{}{}
+	}
+	f.delivered[eventID][topic] = struct{}{}
+	return nil
+}
+
+func (f *fakeTopicDeliveryLog) DeliveredTopics(_ context.Context, _ txmanager.Session, eventID uuid.UUID) (map[string]struct{}, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := make(map[string]struct{}, len(f.delivered[eventID]))
+	for topic := range f.delivered[eventID] {
+		out[topic] = struct{}{}
+	}
+	return out, nil
+}
+
+type recordingSink struct {
+	messages []eventsink.Message
+	err      error
+}
+
+func (s *recordingSink) Publish(_ context.Context, msg eventsink.Message) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.messages = append(s.messages, msg)
+	return nil
+}
+
+type publisherStub struct {
+	messages []gcpubsub.Message
+}
+
+func (p *publisherStub) Publish(_ context.Context, msg gcpubsub.Message) (string, error) {
+	p.messages = append(p.messages, msg)
+	return "id", nil
+}
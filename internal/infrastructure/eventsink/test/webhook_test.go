@@ -0,0 +1,245 @@
+package eventsink_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/eventsink"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookSink_SignsAndRecordsDelivery(t *testing.T) {
+	t.Parallel()
+
+	type captured struct {
+		header http.Header
+		body   []byte
+	}
+	received := make(chan captured, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- captured{header: r.Header.Clone(), body: body}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	deliveries := newFakeDeliveryLog()
+	sink, err := eventsink.NewWebhookSink([]eventsink.WebhookEndpoint{{
+		Name:    "partner",
+		URL:     server.URL,
+		Secret:  "s3cret",
+		Headers: map[string]string{"Authorization": "Bearer partner-token"},
+	}}, deliveries, log.NewStdLogger(io.Discard))
+	require.NoError(t, err)
+
+	msg := newMessage()
+	require.NoError(t, sink.Publish(context.Background(), msg))
+
+	got := <-received
+	require.Equal(t, msg.Payload, got.body)
+	require.Equal(t, "application/x-protobuf", got.header.Get("Content-Type"))
+	require.Equal(t, "Bearer partner-token", got.header.Get("Authorization"))
+	require.Equal(t, msg.EventID.String(), got.header.Get(eventsink.HeaderEventID))
+	require.Equal(t, msg.EventType, got.header.Get(eventsink.HeaderEventType))
+	require.Equal(t, msg.AggregateID.String(), got.header.Get(eventsink.HeaderAggregateID))
+	require.Equal(t, "v1", got.header.Get(eventsink.HeaderAttributePrefix+"schema_version"))
+
+	timestamp := got.header.Get(eventsink.HeaderTimestamp)
+	require.NotEmpty(t, timestamp)
+	signature := got.header.Get(eventsink.HeaderSignature)
+	require.True(t, eventsink.VerifyWebhook("s3cret", timestamp, got.body, signature))
+	require.False(t, eventsink.VerifyWebhook("other", timestamp, got.body, signature))
+	require.False(t, eventsink.VerifyWebhook("s3cret", timestamp, append(got.body, 'x'), signature))
+
+	attempts := deliveries.attemptsFor("partner")
+	require.Len(t, attempts, 1)
+	require.Equal(t, http.StatusAccepted, attempts[0].StatusCode)
+	require.NoError(t, attempts[0].Err)
+}
+
+func TestWebhookSink_AttemptsEachEndpointOncePerPublish(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	deliveries := newFakeDeliveryLog()
+	sink, err := eventsink.NewWebhookSink([]eventsink.WebhookEndpoint{{
+		Name:   "flaky",
+		URL:    server.URL,
+		Secret: "s3cret",
+	}}, deliveries, log.NewStdLogger(io.Discard))
+	require.NoError(t, err)
+
+	// 失败不在发布内重试，交给 Outbox 退避后重新认领。
+	msg := newMessage()
+	err = sink.Publish(context.Background(), msg)
+	require.ErrorContains(t, err, "webhook flaky: unexpected status 503")
+	require.Equal(t, int32(1), calls.Load())
+
+	require.NoError(t, sink.Publish(context.Background(), msg))
+	require.Equal(t, int32(2), calls.Load())
+
+	attempts := deliveries.attemptsFor("flaky")
+	require.Len(t, attempts, 2)
+	require.Equal(t, http.StatusServiceUnavailable, attempts[0].StatusCode)
+	require.Error(t, attempts[0].Err)
+	require.NoError(t, attempts[1].Err)
+}
+
+func TestWebhookSink_SkipsEndpointsAlreadyDelivered(t *testing.T) {
+	t.Parallel()
+
+	var okCalls, failingCalls atomic.Int32
+	var failing atomic.Bool
+	failing.Store(true)
+	okServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		okCalls.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer okServer.Close()
+	failingServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		failingCalls.Add(1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer failingServer.Close()
+
+	deliveries := newFakeDeliveryLog()
+	sink, err := eventsink.NewWebhookSink([]eventsink.WebhookEndpoint{
+		{Name: "ok", URL: okServer.URL, Secret: "s3cret"},
+		{Name: "down", URL: failingServer.URL, Secret: "s3cret"},
+	}, deliveries, log.NewStdLogger(io.Discard))
+	require.NoError(t, err)
+
+	msg := newMessage()
+	err = sink.Publish(context.Background(), msg)
+	require.ErrorContains(t, err, "webhook down")
+	require.NotContains(t, err.Error(), "webhook ok")
+
+	// Outbox 重新认领后再次发布：已成功的端点不再投递。
+	failing.Store(false)
+	require.NoError(t, sink.Publish(context.Background(), msg))
+	require.Equal(t, int32(1), okCalls.Load())
+	require.Equal(t, int32(2), failingCalls.Load())
+}
+
+func TestWebhookSink_BoundsSlowEndpointByTimeout(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	sink, err := eventsink.NewWebhookSink([]eventsink.WebhookEndpoint{{
+		Name:    "slow",
+		URL:     server.URL,
+		Secret:  "s3cret",
+		Timeout: 100 * time.Millisecond,
+	}}, nil, log.NewStdLogger(io.Discard))
+	require.NoError(t, err)
+
+	start := time.Now()
+	require.ErrorContains(t, sink.Publish(context.Background(), newMessage()), "webhook slow")
+	require.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestNewWebhookSink_ValidatesEndpoints(t *testing.T) {
+	t.Parallel()
+
+	logger := log.NewStdLogger(io.Discard)
+	cases := map[string][]eventsink.WebhookEndpoint{
+		"empty":     nil,
+		"no name":   {{URL: "https://example.com/hook", Secret: "s"}},
+		"no secret": {{Name: "a", URL: "https://example.com/hook"}},
+		"bad url":   {{Name: "a", URL: "ftp://example.com", Secret: "s"}},
+		"no host":   {{Name: "a", URL: "https://", Secret: "s"}},
+		"duplicate": {{Name: "a", URL: "https://example.com/1", Secret: "s"}, {Name: "a", URL: "https://example.com/2", Secret: "s"}},
+	}
+	for name, endpoints := range cases {
+		_, err := eventsink.NewWebhookSink(endpoints, nil, logger)
+		require.Error(t, err, name)
+	}
+}
+
+func newMessage() eventsink.Message {
+	eventID := uuid.New()
+	return eventsink.Message{
+		EventID:     eventID,
+		EventType:   "catalog.video.created",
+		AggregateID: uuid.New(),
+		Payload:     []byte{0x0a, 0x03, 'a', 'b', 'c'},
+		Headers: map[string]string{
+			"event_id":       eventID.String(),
+			"schema_version": "v1",
+		},
+	}
+}
+
+// fakeDeliveryLog 在内存中模拟 catalog.webhook_deliveries。
+type fakeDeliveryLog struct {
+	mu       sync.Mutex
+	attempts []repositories.WebhookDeliveryAttempt
+}
+
+func newFakeDeliveryLog() *fakeDeliveryLog {
+	return &fakeDeliveryLog{}
+}
+
+func (f *fakeDeliveryLog) Record(_ context.Context, _ txmanager.Session, attempt repositories.WebhookDeliveryAttempt) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.attempts = append(f.attempts, attempt)
+	return nil
+}
+
+func (f *fakeDeliveryLog) DeliveredEndpoints(_ context.Context, _ txmanager.Session, eventID uuid.UUID) (map[string]struct{}, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delivered := map[string]struct{}{}
+	for _, attempt := range f.attempts {
+		if attempt.EventID == eventID && attempt.Err == nil {
+			delivered[attempt.Endpoint] = struct{}{}
+		}
+	}
+	return delivered, nil
+}
+
+func (f *fakeDeliveryLog) attemptsFor(endpoint string) []repositories.WebhookDeliveryAttempt {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []repositories.WebhookDeliveryAttempt
+	for _, attempt := range f.attempts {
+		if attempt.Endpoint == endpoint {
+			out = append(out, attempt)
+		}
+	}
+	return out
+}
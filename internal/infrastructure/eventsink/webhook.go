@@ -0,0 +1,214 @@
+package eventsink
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+)
+
+// Webhook 请求头。签名为 "v1=" 加 HMAC-SHA256(secret, timestamp + "." + body) 的十六进制编码，
+// 接收方应校验签名并拒绝时间戳过旧的请求以防重放。
+const (
+	HeaderEventID     = "X-Catalog-Event-Id"
+	HeaderEventType   = "X-Catalog-Event-Type"
+	HeaderAggregateID = "X-Catalog-Aggregate-Id"
+	HeaderTimestamp   = "X-Catalog-Timestamp"
+	HeaderSignature   = "X-Catalog-Signature"
+	// HeaderAttributePrefix 之后拼接事件属性名，例如 X-Catalog-Attr-schema_version。
+	HeaderAttributePrefix = "X-Catalog-Attr-"
+
+	webhookContentType   = "application/x-protobuf"
+	webhookSignatureV1   = "v1="
+	webhookMaxDrainBytes = 64 << 10
+)
+
+// WebhookEndpoint 描述单个 Webhook 端点及其签名密钥。
+type WebhookEndpoint struct {
+	Name    string
+	URL     string
+	Secret  string
+	Timeout time.Duration
+	Headers map[string]string
+}
+
+// DeliveryLog 记录 Webhook 投递尝试，并返回事件已投递成功的端点。
+type DeliveryLog interface {
+	Record(ctx context.Context, sess txmanager.Session, attempt repositories.WebhookDeliveryAttempt) error
+	DeliveredEndpoints(ctx context.Context, sess txmanager.Session, eventID uuid.UUID) (map[string]struct{}, error)
+}
+
+var _ DeliveryLog = (*repositories.WebhookDeliveryRepository)(nil)
+
+// WebhookSink 把事件以签名的 HTTP POST 并发投递到全部端点，全部端点成功才视为发布成功。
+// 每次发布对每个端点只尝试一次，不在发布内等待重试，慢或故障的端点不会长期占用 Outbox worker；
+// 失败由 Outbox 按 next_retry_at 退避后重新认领。每次尝试写入投递日志，重新认领时跳过已投递成功的端点。
+type WebhookSink struct {
+	endpoints  []WebhookEndpoint
+	client     *http.Client
+	deliveries DeliveryLog
+	log        *log.Helper
+	now        func() time.Time
+}
+
+// NewWebhookSink 校验端点配置并构造 WebhookSink；deliveries 为 nil 时不记录投递日志，重新认领会重复投递已成功的端点。
+func NewWebhookSink(endpoints []WebhookEndpoint, deliveries DeliveryLog, logger log.Logger) (*WebhookSink, error) {
+	if len(endpoints) == 0 {
+		return nil, errors.New("eventsink: at least one webhook endpoint is required")
+	}
+	if logger == nil {
+		logger = log.DefaultLogger
+	}
+	seen := make(map[string]struct{}, len(endpoints))
+	normalized := make([]WebhookEndpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		if ep.Name == "" {
+			return nil, errors.New("eventsink: webhook endpoint name is required")
+		}
+		if ep.Secret == "" {
+			return nil, fmt.Errorf("eventsink: webhook endpoint %q requires a secret", ep.Name)
+		}
+		if _, dup := seen[ep.Name]; dup {
+			return nil, fmt.Errorf("eventsink: duplicate webhook endpoint %q", ep.Name)
+		}
+		seen[ep.Name] = struct{}{}
+		parsed, err := url.Parse(ep.URL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return nil, fmt.Errorf("eventsink: webhook endpoint %q has invalid url %q", ep.Name, ep.URL)
+		}
+		if ep.Timeout <= 0 {
+			ep.Timeout = 5 * time.Second
+		}
+		normalized = append(normalized, ep)
+	}
+	return &WebhookSink{
+		endpoints:  normalized,
+		client:     &http.Client{},
+		deliveries: deliveries,
+		log:        log.NewHelper(logger),
+		now:        time.Now,
+	}, nil
+}
+
+// Publish 并发向尚未成功的端点各投递一次，返回所有失败端点的错误。
+func (s *WebhookSink) Publish(ctx context.Context, msg Message) error {
+	delivered := map[string]struct{}{}
+	if s.deliveries != nil {
+		var err error
+		delivered, err = s.deliveries.DeliveredEndpoints(ctx, nil, msg.EventID)
+		if err != nil {
+			return fmt.Errorf("load webhook deliveries: %w", err)
+		}
+	}
+
+	errs := make([]error, len(s.endpoints))
+	var wg sync.WaitGroup
+	for i, ep := range s.endpoints {
+		if _, ok := delivered[ep.Name]; ok {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, ep WebhookEndpoint) {
+			defer wg.Done()
+			errs[i] = s.deliver(ctx, ep, msg)
+		}(i, ep)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// deliver 向端点投递一次并写入投递日志。
+func (s *WebhookSink) deliver(ctx context.Context, ep WebhookEndpoint, msg Message) error {
+	start := time.Now()
+	status, err := s.send(ctx, ep, msg)
+	s.record(ctx, ep, msg, status, err, time.Since(start))
+	if err != nil {
+		return fmt.Errorf("webhook %s: %w", ep.Name, err)
+	}
+	return nil
+}
+
+// send 发送一次签名请求，返回 HTTP 状态码（未得到响应时为 0）。
+func (s *WebhookSink) send(ctx context.Context, ep WebhookEndpoint, msg Message) (int, error) {
+	reqCtx, cancel := context.WithTimeout(ctx, ep.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, ep.URL, bytes.NewReader(msg.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("build request: %w", err)
+	}
+	for key, value := range ep.Headers {
+		req.Header.Set(key, value)
+	}
+	for key, value := range msg.Headers {
+		req.Header.Set(HeaderAttributePrefix+key, value)
+	}
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+	req.Header.Set("Content-Type", webhookContentType)
+	req.Header.Set(HeaderEventID, msg.EventID.String())
+	req.Header.Set(HeaderEventType, msg.EventType)
+	req.Header.Set(HeaderAggregateID, msg.AggregateID.String())
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, SignWebhook(ep.Secret, timestamp, msg.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, webhookMaxDrainBytes))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// record 写入投递日志；日志写入失败只告警，不影响投递结果。
+func (s *WebhookSink) record(ctx context.Context, ep WebhookEndpoint, msg Message, status int, sendErr error, elapsed time.Duration) {
+	if sendErr != nil {
+		s.log.WithContext(ctx).Warnf("webhook delivery failed: endpoint=%s event=%s status=%d err=%v", ep.Name, msg.EventID, status, sendErr)
+	}
+	if s.deliveries == nil {
+		return
+	}
+	err := s.deliveries.Record(context.WithoutCancel(ctx), nil, repositories.WebhookDeliveryAttempt{
+		EventID:    msg.EventID,
+		Endpoint:   ep.Name,
+		EventType:  msg.EventType,
+		StatusCode: status,
+		Err:        sendErr,
+		Duration:   elapsed,
+	})
+	if err != nil {
+		s.log.WithContext(ctx).Warnf("record webhook delivery failed: endpoint=%s event=%s err=%v", ep.Name, msg.EventID, err)
+	}
+}
+
+// SignWebhook 计算 Webhook 签名头的取值。
+func SignWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return webhookSignatureV1 + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhook 以常量时间比较校验签名，供接收方与测试使用。
+func VerifyWebhook(secret, timestamp string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(SignWebhook(secret, timestamp, body)), []byte(signature))
+}
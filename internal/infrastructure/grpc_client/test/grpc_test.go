@@ -65,6 +65,10 @@ func (outboxRepoStub) Enqueue(context.Context, txmanager.Session, repositories.O
 	return nil
 }
 
+func (outboxRepoStub) Notify(context.Context, txmanager.Session, string) error {
+	return nil
+}
+
 type noopTxManager struct{}
 
 func (noopTxManager) WithinTx(ctx context.Context, _ txmanager.TxOptions, fn func(context.Context, txmanager.Session) error) error {
@@ -93,13 +97,13 @@ func startVideoServer(t *testing.T) (addr string, stop func()) {
 		services.NewVisibilityService(writer, repo),
 	)
 	lifecycleHandler := controllers.NewLifecycleHandler(lifecycleSvc, base)
-	queryHandler := controllers.NewVideoQueryHandler(querySvc, base)
+	queryHandler := controllers.NewVideoQueryHandler(querySvc, nil, base)
 
 	cfg := configloader.ServerConfig{
 		Address:      "127.0.0.1:0",
 		MetadataKeys: []string{"x-apigateway-api-userinfo", "x-md-", "x-md-idempotency-key", "x-md-if-match", "x-md-if-none-match"},
 	}
-	grpcSrv := grpcserver.NewGRPCServer(cfg, metricsCfg, nil, lifecycleHandler, queryHandler, nil, logger)
+	grpcSrv := grpcserver.NewGRPCServer(cfg, metricsCfg, nil, lifecycleHandler, queryHandler, nil, nil, logger)
 
 	endpointURL, err := grpcSrv.Endpoint()
 	if err != nil {
@@ -67,6 +67,10 @@ func (outboxRepoStub) Enqueue(context.Context, txmanager.Session, repositories.O
 	return nil
 }
 
+func (outboxRepoStub) Notify(context.Context, txmanager.Session, string) error {
+	return nil
+}
+
 type noopTxManager struct{}
 
 func (noopTxManager) WithinTx(ctx context.Context, _ txmanager.TxOptions, fn func(context.Context, txmanager.Session) error) error {
@@ -93,7 +97,7 @@ func newVideoHandlers(t *testing.T) (*controllers.LifecycleHandler, *controllers
 		services.NewVisibilityService(writer, repo),
 	)
 	base := controllers.NewBaseHandler(controllers.HandlerTimeouts{})
-	return controllers.NewLifecycleHandler(lifecycleSvc, base), controllers.NewVideoQueryHandler(querySvc, base)
+	return controllers.NewLifecycleHandler(lifecycleSvc, base), controllers.NewVideoQueryHandler(querySvc, nil, base)
 }
 
 func startServer(t *testing.T) (string, func()) {
@@ -105,7 +109,7 @@ func startServer(t *testing.T) (string, func()) {
 	}
 	logger := log.NewStdLogger(io.Discard)
 	metricsCfg := &observability.MetricsConfig{GRPCEnabled: true, GRPCIncludeHealth: false}
-	srv := grpcserver.NewGRPCServer(cfg, metricsCfg, nil, lifecycleHandler, queryHandler, nil, logger)
+	srv := grpcserver.NewGRPCServer(cfg, metricsCfg, nil, lifecycleHandler, queryHandler, nil, nil, logger)
 
 	// Force endpoint initialization to retrieve the bound address.
 	endpointURL, err := srv.Endpoint()
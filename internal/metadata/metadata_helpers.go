@@ -11,12 +11,17 @@ import (
 	"github.com/google/uuid"
 )
 
+// RoleAdmin 是运维管理接口（如 Outbox 处置）要求的授权角色；userinfo 角色声明包含该值时 HandlerMetadata.IsAdmin 为 true。
+const RoleAdmin = "admin"
+
 // HandlerMetadata 描述从请求头或上游链路解析出的上下文信息。
 type HandlerMetadata struct {
 	IdempotencyKey  string
 	IfMatch         string
 	IfNoneMatch     string
 	UserID          string
+	UserTier        string
+	IsAdmin         bool
 	RawUserInfo     string
 	InvalidUserInfo bool
 }
@@ -27,6 +32,8 @@ func (m HandlerMetadata) IsZero() bool {
 		m.IfMatch == "" &&
 		m.IfNoneMatch == "" &&
 		m.UserID == "" &&
+		m.UserTier == "" &&
+		!m.IsAdmin &&
 		m.RawUserInfo == "" &&
 		!m.InvalidUserInfo
 }
@@ -68,14 +75,10 @@ func ExtractUserIDFromUserInfo(raw string) (string, error) {
 	if raw == "" {
 		return "", nil
 	}
-	payload, err := decodeUserInfo(raw)
+	claims, err := decodeUserInfoClaims(raw)
 	if err != nil {
 		return "", err
 	}
-	var claims map[string]any
-	if err := json.Unmarshal(payload, &claims); err != nil {
-		return "", err
-	}
 	if sub, ok := claims["sub"].(string); ok && strings.TrimSpace(sub) != "" {
 		return sub, nil
 	}
@@ -88,6 +91,79 @@ func ExtractUserIDFromUserInfo(raw string) (string, error) {
 	return "", nil
 }
 
+// ExtractUserTierFromUserInfo 尝试从 X-Apigateway-Api-Userinfo 头中解析用户档位（tier/plan 声明），用于上传配额。
+func ExtractUserTierFromUserInfo(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", nil
+	}
+	claims, err := decodeUserInfoClaims(raw)
+	if err != nil {
+		return "", err
+	}
+	for _, key := range []string{"tier", "plan"} {
+		if tier, ok := claims[key].(string); ok && strings.TrimSpace(tier) != "" {
+			return strings.ToLower(strings.TrimSpace(tier)), nil
+		}
+	}
+	return "", nil
+}
+
+// ExtractUserRolesFromUserInfo 尝试从 X-Apigateway-Api-Userinfo 头中解析用户角色，
+// 支持顶层 roles 数组、role 字符串以及 app_metadata 下的同名声明；结果统一小写并去重。
+func ExtractUserRolesFromUserInfo(raw string) ([]string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+	claims, err := decodeUserInfoClaims(raw)
+	if err != nil {
+		return nil, err
+	}
+	var roles []string
+	seen := make(map[string]struct{})
+	add := func(value any) {
+		role, ok := value.(string)
+		if !ok {
+			return
+		}
+		role = strings.ToLower(strings.TrimSpace(role))
+		if role == "" {
+			return
+		}
+		if _, dup := seen[role]; dup {
+			return
+		}
+		seen[role] = struct{}{}
+		roles = append(roles, role)
+	}
+	collect := func(source map[string]any) {
+		add(source["role"])
+		if values, ok := source["roles"].([]any); ok {
+			for _, value := range values {
+				add(value)
+			}
+		}
+	}
+	collect(claims)
+	if appMeta, ok := claims["app_metadata"].(map[string]any); ok {
+		collect(appMeta)
+	}
+	return roles, nil
+}
+
+func decodeUserInfoClaims(raw string) (map[string]any, error) {
+	payload, err := decodeUserInfo(raw)
+	if err != nil {
+		return nil, err
+	}
+	var claims map[string]any
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
 func decodeUserInfo(raw string) ([]byte, error) {
 	decoders := []func(string) ([]byte, error){
 		func(s string) ([]byte, error) { return base64.RawURLEncoding.DecodeString(s) },
@@ -48,3 +48,63 @@ func TestExtractUserIDFromUserInfo_UserIDFallback(t *testing.T) {
 		t.Fatalf("expected fallback user_id %q, got %q", claims["user_id"], userID)
 	}
 }
+
+func TestExtractUserTierFromUserInfo(t *testing.T) {
+	cases := []struct {
+		name   string
+		claims map[string]any
+		want   string
+	}{
+		{name: "tier claim", claims: map[string]any{"sub": "u1", "tier": " Pro "}, want: "pro"},
+		{name: "plan fallback", claims: map[string]any{"sub": "u1", "plan": "creator"}, want: "creator"},
+		{name: "missing", claims: map[string]any{"sub": "u1"}, want: ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			payload, err := json.Marshal(tc.claims)
+			if err != nil {
+				t.Fatalf("marshal claims: %v", err)
+			}
+			tier, err := metadata.ExtractUserTierFromUserInfo(base64.RawURLEncoding.EncodeToString(payload))
+			if err != nil {
+				t.Fatalf("extract tier: %v", err)
+			}
+			if tier != tc.want {
+				t.Fatalf("expected tier %q, got %q", tc.want, tier)
+			}
+		})
+	}
+}
+
+func TestExtractUserRolesFromUserInfo(t *testing.T) {
+	cases := []struct {
+		name   string
+		claims map[string]any
+		want   []string
+	}{
+		{name: "roles array", claims: map[string]any{"sub": "u1", "roles": []any{"Admin", "editor"}}, want: []string{"admin", "editor"}},
+		{name: "role string", claims: map[string]any{"sub": "u1", "role": "authenticated"}, want: []string{"authenticated"}},
+		{name: "app metadata", claims: map[string]any{"sub": "u1", "role": "authenticated", "app_metadata": map[string]any{"roles": []any{"admin", "authenticated"}}}, want: []string{"authenticated", "admin"}},
+		{name: "missing", claims: map[string]any{"sub": "u1"}, want: nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			payload, err := json.Marshal(tc.claims)
+			if err != nil {
+				t.Fatalf("marshal claims: %v", err)
+			}
+			roles, err := metadata.ExtractUserRolesFromUserInfo(base64.RawURLEncoding.EncodeToString(payload))
+			if err != nil {
+				t.Fatalf("extract roles: %v", err)
+			}
+			if len(roles) != len(tc.want) {
+				t.Fatalf("expected roles %v, got %v", tc.want, roles)
+			}
+			for i := range roles {
+				if roles[i] != tc.want[i] {
+					t.Fatalf("expected roles %v, got %v", tc.want, roles)
+				}
+			}
+		})
+	}
+}
@@ -20,6 +20,8 @@ const (
 	KindVideoUpdated
 	// KindVideoDeleted 表示视频删除事件。
 	KindVideoDeleted
+	// KindVideoSnapshot 表示回填产生的视频全量快照事件。
+	KindVideoSnapshot
 )
 
 func (k Kind) String() string {
@@ -30,6 +32,8 @@ func (k Kind) String() string {
 		return "catalog.video.updated"
 	case KindVideoDeleted:
 		return "catalog.video.deleted"
+	case KindVideoSnapshot:
+		return "catalog.video.snapshot"
 	default:
 		return "catalog.video.unknown"
 	}
@@ -76,6 +80,7 @@ type VideoUpdated struct {
 	RawSubtitleURL    *string
 	VisibilityStatus  *string
 	PublishedAt       *time.Time
+	RawMissing        *bool
 }
 
 // VideoDeleted 描述视频删除事件的业务载荷。
@@ -85,13 +90,41 @@ type VideoDeleted struct {
 	Reason    *string
 }
 
-// 仅保留 Created/Updated/Deleted 三类事件载荷，其余场景统一通过 VideoUpdated 传达变更。
+// VideoSnapshot 描述视频全量快照的业务载荷，仅由事件回填产生，不经过 Outbox。
+type VideoSnapshot struct {
+	VideoID           uuid.UUID
+	UploaderID        uuid.UUID
+	Title             string
+	Description       *string
+	DurationMicros    *int64
+	Status            string
+	MediaStatus       string
+	AnalysisStatus    string
+	ThumbnailURL      *string
+	HLSMasterPlaylist *string
+	Difficulty        *string
+	Summary           *string
+	Tags              []string
+	RawSubtitleURL    *string
+	VisibilityStatus  string
+	PublishedAt       *time.Time
+	RawMissing        bool
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	BackfillID        string
+	SnapshotAt        time.Time
+}
+
+// 实时变更仅使用 Created/Updated/Deleted 三类事件载荷，其余场景统一通过 VideoUpdated 传达变更；
+// Snapshot 只用于回填，消费者可据事件类型或 backfill_id 属性区分。
 
 const (
 	// AggregateTypeVideo 标识视频聚合类型，供 Outbox headers / attributes 使用。
 	AggregateTypeVideo = "video"
 	// SchemaVersionV1 描述事件载荷的当前 schema 版本。
 	SchemaVersionV1 = "v1"
+	// AttributeBackfillID 为回填事件额外携带的属性名，取值为回填任务标识；实时事件不含该属性。
+	AttributeBackfillID = "backfill_id"
 )
 
 var (
@@ -29,6 +29,8 @@ func ToProto(evt *DomainEvent) (*videov1.Event, error) {
 		pb.Payload = &videov1.Event_Updated{Updated: encodeVideoUpdated(evt, payload)}
 	case *VideoDeleted:
 		pb.Payload = &videov1.Event_Deleted{Deleted: encodeVideoDeleted(evt, payload)}
+	case *VideoSnapshot:
+		pb.Payload = &videov1.Event_Snapshot{Snapshot: encodeVideoSnapshot(evt, payload)}
 	default:
 		return nil, fmt.Errorf("events: unsupported payload type %T", payload)
 	}
@@ -107,6 +109,9 @@ func encodeVideoUpdated(evt *DomainEvent, payload *VideoUpdated) *videov1.Event_
 		publishedAt := payload.PublishedAt.UTC().Format(time.RFC3339Nano)
 		updated.PublishedAt = &publishedAt
 	}
+	if payload.RawMissing != nil {
+		updated.RawMissing = payload.RawMissing
+	}
 	return updated
 }
 
@@ -126,6 +131,38 @@ func encodeVideoDeleted(evt *DomainEvent, payload *VideoDeleted) *videov1.Event_
 	return deleted
 }
 
+func encodeVideoSnapshot(evt *DomainEvent, payload *VideoSnapshot) *videov1.Event_VideoSnapshot {
+	snapshot := &videov1.Event_VideoSnapshot{
+		VideoId:           payload.VideoID.String(),
+		UploaderId:        payload.UploaderID.String(),
+		Title:             payload.Title,
+		Description:       payload.Description,
+		DurationMicros:    payload.DurationMicros,
+		Status:            payload.Status,
+		MediaStatus:       payload.MediaStatus,
+		AnalysisStatus:    payload.AnalysisStatus,
+		ThumbnailUrl:      payload.ThumbnailURL,
+		HlsMasterPlaylist: payload.HLSMasterPlaylist,
+		Difficulty:        payload.Difficulty,
+		Summary:           payload.Summary,
+		Tags:              payload.Tags,
+		RawSubtitleUrl:    payload.RawSubtitleURL,
+		VisibilityStatus:  payload.VisibilityStatus,
+		RawMissing:        payload.RawMissing,
+		CreatedAt:         payload.CreatedAt.UTC().Format(time.RFC3339Nano),
+		UpdatedAt:         payload.UpdatedAt.UTC().Format(time.RFC3339Nano),
+		Version:           evt.Version,
+		OccurredAt:        evt.OccurredAt.UTC().Format(time.RFC3339Nano),
+		BackfillId:        payload.BackfillID,
+		SnapshotAt:        payload.SnapshotAt.UTC().Format(time.RFC3339Nano),
+	}
+	if payload.PublishedAt != nil {
+		publishedAt := payload.PublishedAt.UTC().Format(time.RFC3339Nano)
+		snapshot.PublishedAt = &publishedAt
+	}
+	return snapshot
+}
+
 func kindToProto(kind Kind) videov1.EventType {
 	switch kind {
 	case KindVideoCreated:
@@ -134,6 +171,8 @@ func kindToProto(kind Kind) videov1.EventType {
 		return videov1.EventType_EVENT_TYPE_VIDEO_UPDATED
 	case KindVideoDeleted:
 		return videov1.EventType_EVENT_TYPE_VIDEO_DELETED
+	case KindVideoSnapshot:
+		return videov1.EventType_EVENT_TYPE_VIDEO_SNAPSHOT
 	default:
 		return videov1.EventType_EVENT_TYPE_UNSPECIFIED
 	}
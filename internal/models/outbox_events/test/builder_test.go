@@ -5,6 +5,7 @@ import (
     "testing"
     "time"
 
+    videov1 "github.com/bionicotaku/lingo-services-catalog/api/video/v1"
     outboxevents "github.com/bionicotaku/lingo-services-catalog/internal/models/outbox_events"
     "github.com/bionicotaku/lingo-services-catalog/internal/models/po"
     "github.com/google/uuid"
@@ -154,3 +155,61 @@ func TestNewVideoUpdatedEvent_EmptyChanges(t *testing.T) {
         t.Fatalf("expected ErrEmptyUpdatePayload, got %v", err)
     }
 }
+
+func TestNewVideoSnapshotEvent(t *testing.T) {
+    updatedAt := time.Date(2025, 10, 24, 12, 0, 0, 0, time.UTC)
+    snapshotAt := updatedAt.Add(48 * time.Hour)
+    publishAt := updatedAt.Add(-time.Hour)
+    thumbnail := "https://cdn.example.com/thumb.jpg"
+    video := &po.Video{
+        VideoID:          uuid.New(),
+        UploadUserID:     uuid.New(),
+        CreatedAt:        updatedAt.Add(-24 * time.Hour),
+        UpdatedAt:        updatedAt,
+        Title:            "Snapshot",
+        Status:           po.VideoStatusPublished,
+        MediaStatus:      po.StageReady,
+        AnalysisStatus:   po.StageReady,
+        ThumbnailURL:     &thumbnail,
+        Tags:             []string{"go", "grpc"},
+        VisibilityStatus: po.VisibilityPublic,
+        PublishAt:        &publishAt,
+    }
+
+    evt, err := outboxevents.NewVideoSnapshotEvent(video, uuid.New(), "search-index", snapshotAt)
+    if err != nil {
+        t.Fatalf("unexpected error: %v", err)
+    }
+    if evt.Kind != outboxevents.KindVideoSnapshot || evt.Kind.String() != "catalog.video.snapshot" {
+        t.Fatalf("unexpected event kind: %v", evt.Kind)
+    }
+    // 版本号与发生时间对应视频最近一次更新，而非回填执行时间。
+    if !evt.OccurredAt.Equal(updatedAt) || evt.Version != outboxevents.VersionFromTime(updatedAt) || evt.Version != outboxevents.SnapshotVersion(video) {
+        t.Fatalf("version should follow updated_at: occurred_at=%s version=%d", evt.OccurredAt, evt.Version)
+    }
+
+    pb, err := outboxevents.ToProto(evt)
+    if err != nil {
+        t.Fatalf("to proto: %v", err)
+    }
+    if pb.GetEventType() != videov1.EventType_EVENT_TYPE_VIDEO_SNAPSHOT {
+        t.Fatalf("unexpected proto event type: %v", pb.GetEventType())
+    }
+    snapshot := pb.GetSnapshot()
+    if snapshot == nil {
+        t.Fatalf("snapshot payload missing")
+    }
+    if snapshot.GetBackfillId() != "search-index" || snapshot.GetThumbnailUrl() != thumbnail || len(snapshot.GetTags()) != 2 {
+        t.Fatalf("snapshot payload mismatch: %+v", snapshot)
+    }
+    if snapshot.GetSnapshotAt() != snapshotAt.Format(time.RFC3339Nano) || snapshot.GetPublishedAt() != publishAt.Format(time.RFC3339Nano) {
+        t.Fatalf("snapshot timestamps mismatch: %+v", snapshot)
+    }
+}
+
+func TestNewVideoSnapshotEvent_RequiresBackfillID(t *testing.T) {
+    _, err := outboxevents.NewVideoSnapshotEvent(&po.Video{VideoID: uuid.New()}, uuid.New(), "", time.Now())
+    if !errors.Is(err, outboxevents.ErrInvalidBackfillID) {
+        t.Fatalf("expected ErrInvalidBackfillID, got %v", err)
+    }
+}
@@ -0,0 +1,87 @@
+package outboxevents
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidBackfillID 表示构建快照事件时未提供回填任务标识。
+var ErrInvalidBackfillID = errors.New("event builder: backfill id is required")
+
+// NewVideoSnapshotEvent 基于视频当前完整状态构建快照事件。
+// 版本号与发生时间取视频最近一次更新时间（见 SnapshotVersion），与实时事件同按 VersionFromTime 计算。
+// 并非每次 updated_at 变化都伴随实时事件，快照版本号不一定等于某条实时事件的版本号；
+// 消费者按版本号比较新旧即可，版本号不高于已应用版本的快照可忽略。
+func NewVideoSnapshotEvent(video *po.Video, eventID uuid.UUID, backfillID string, snapshotAt time.Time) (*DomainEvent, error) {
+	if video == nil {
+		return nil, ErrNilVideo
+	}
+	if eventID == uuid.Nil {
+		return nil, ErrInvalidEventID
+	}
+	if backfillID == "" {
+		return nil, ErrInvalidBackfillID
+	}
+	if snapshotAt.IsZero() {
+		snapshotAt = time.Now()
+	}
+
+	occurredAt := snapshotTime(video)
+	if occurredAt.IsZero() {
+		occurredAt = snapshotAt
+	}
+	occurredAt = occurredAt.UTC()
+
+	payload := &VideoSnapshot{
+		VideoID:           video.VideoID,
+		UploaderID:        video.UploadUserID,
+		Title:             video.Title,
+		Description:       video.Description,
+		DurationMicros:    video.DurationMicros,
+		Status:            string(video.Status),
+		MediaStatus:       string(video.MediaStatus),
+		AnalysisStatus:    string(video.AnalysisStatus),
+		ThumbnailURL:      video.ThumbnailURL,
+		HLSMasterPlaylist: video.HLSMasterPlaylist,
+		Difficulty:        video.Difficulty,
+		Summary:           video.Summary,
+		Tags:              append([]string(nil), video.Tags...),
+		RawSubtitleURL:    video.RawSubtitleURL,
+		VisibilityStatus:  video.VisibilityStatus,
+		PublishedAt:       cloneTime(video.PublishAt),
+		RawMissing:        video.RawMissing,
+		CreatedAt:         video.CreatedAt.UTC(),
+		UpdatedAt:         video.UpdatedAt.UTC(),
+		BackfillID:        backfillID,
+		SnapshotAt:        snapshotAt.UTC(),
+	}
+
+	event := &DomainEvent{
+		EventID:       eventID,
+		Kind:          KindVideoSnapshot,
+		AggregateID:   video.VideoID,
+		AggregateType: AggregateTypeVideo,
+		Version:       SnapshotVersion(video),
+		OccurredAt:    occurredAt,
+		Payload:       payload,
+	}
+	return event, nil
+}
+
+// SnapshotVersion 返回视频快照的版本号：取 updated_at，缺失时取 created_at，两者皆空时为 0。
+func SnapshotVersion(video *po.Video) int64 {
+	if video == nil {
+		return 0
+	}
+	return VersionFromTime(snapshotTime(video))
+}
+
+func snapshotTime(video *po.Video) time.Time {
+	if video.UpdatedAt.IsZero() {
+		return video.CreatedAt
+	}
+	return video.UpdatedAt
+}
@@ -27,6 +27,7 @@ type VideoUpdateChanges struct {
 	VisibilityStatus  *string
 	PublishAt         *time.Time
 	RawSubtitleURL    *string
+	RawMissing        *bool
 }
 
 // NewVideoUpdatedEvent 基于更新后的实体与变更集构建领域事件。
@@ -113,6 +114,11 @@ func NewVideoUpdatedEvent(video *po.Video, changes VideoUpdateChanges, eventID u
 		payload.PublishedAt = cloneTime(changes.PublishAt)
 		hasChange = true
 	}
+	if changes.RawMissing != nil {
+		value := *changes.RawMissing
+		payload.RawMissing = &value
+		hasChange = true
+	}
 
 	if !hasChange {
 		return nil, ErrEmptyUpdatePayload
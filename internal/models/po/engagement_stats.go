@@ -16,6 +16,17 @@ type VideoEngagementStatsProjection struct {
 	FirstWatchAt   *time.Time
 	LastWatchAt    *time.Time
 	UpdatedAt      time.Time
+	// TotalWatchSeconds 为累计观看秒数。
+	TotalWatchSeconds float64
+	// PositionSumSeconds 为各用户最大播放位置之和，与 PositionViewers 一起计算完播率。
+	PositionSumSeconds float64
+	PositionViewers    int64
+	// ShareCount 为累计分享次数。
+	ShareCount int64
+	// RatingCount/RatingSum 为当前有效评分的人数与星级之和，RatingDistribution[i] 为评 i+1 星的人数。
+	RatingCount        int64
+	RatingSum          int64
+	RatingDistribution [5]int64
 }
 
 // VideoWatcherRecord 记录已计入 unique_watchers 的用户。
@@ -26,3 +37,140 @@ type VideoWatcherRecord struct {
 	LastWatchedAt  time.Time
 	Inserted       bool
 }
+
+// VideoViewSession 表示 catalog.video_view_sessions 记录：用户-视频当前播放会话。
+type VideoViewSession struct {
+	VideoID              uuid.UUID
+	UserID               uuid.UUID
+	SessionStartedAt     time.Time
+	LastProgressAt       time.Time
+	BaselineWatchSeconds float64
+	LastWatchSeconds     float64
+	CountedAt            *time.Time
+	UpdatedAt            time.Time
+	// MaxPositionSeconds 为该用户到达过的最大播放位置，跨会话保留。
+	MaxPositionSeconds float64
+	// LastPositionSeconds 为最近一条进度事件上报的播放位置，用于继续观看。
+	LastPositionSeconds float64
+	// PositionTracked 表示该用户已计入 position_viewers；位置统计上线前遗留的会话为 false。
+	PositionTracked bool
+}
+
+// VideoWatchProgress 表示用户在某视频上的最近播放位置。
+type VideoWatchProgress struct {
+	VideoID             uuid.UUID
+	UserID              uuid.UUID
+	LastPositionSeconds float64
+	LastWatchedAt       time.Time
+}
+
+// WatchHistoryEntry 表示用户观看历史中的视频条目。
+type WatchHistoryEntry struct {
+	VideoID             uuid.UUID
+	Title               string
+	Status              VideoStatus
+	MediaStatus         StageStatus
+	AnalysisStatus      StageStatus
+	DurationMicros      *int64
+	LastPositionSeconds float64
+	LastWatchedAt       time.Time
+}
+
+// StatsGranularity 表示分时统计的桶粒度。
+type StatsGranularity string
+
+const (
+	// StatsGranularityHour 对应 catalog.video_engagement_stats_hourly。
+	StatsGranularityHour StatsGranularity = "hour"
+	// StatsGranularityDay 对应 catalog.video_engagement_stats_daily。
+	StatsGranularityDay StatsGranularity = "day"
+)
+
+// VideoStatsBucket 表示一个分时统计桶；BucketStart 为桶起点（UTC）。
+type VideoStatsBucket struct {
+	BucketStart    time.Time
+	LikeDelta      int64
+	BookmarkDelta  int64
+	WatchCount     int64
+	UniqueWatchers int64
+}
+
+// VideoTrendingSnapshot 表示 catalog.video_trending_snapshots 记录：一次热度计算的结果集。
+type VideoTrendingSnapshot struct {
+	SnapshotID      int64
+	ComputedAt      time.Time
+	WindowStart     time.Time
+	HalfLifeSeconds float64
+	MaxVideos       int32
+}
+
+// TrendingVideoEntry 表示热度快照中的一条视频记录。
+type TrendingVideoEntry struct {
+	VideoID        uuid.UUID
+	Rank           int32
+	Score          float64
+	Title          string
+	DurationMicros *int64
+	Difficulty     *string
+	Tags           []string
+	CreatedAt      time.Time
+}
+
+// InboxEventRecord 表示重放时读取的 catalog.inbox_events 历史记录。
+type InboxEventRecord struct {
+	EventID    uuid.UUID
+	EventType  string
+	Payload    []byte
+	ReceivedAt time.Time
+	// OccurredAt 为回写到 Inbox 的事件发生时间，未标注时为零值。
+	OccurredAt time.Time
+}
+
+// EngagementReplayCheckpoint 表示 catalog.engagement_replay_checkpoints 记录。
+type EngagementReplayCheckpoint struct {
+	ReplayID       string
+	ScopeUserID    *uuid.UUID
+	ScopeVideoID   *uuid.UUID
+	LastOccurredAt *time.Time
+	LastEventID    *uuid.UUID
+	AppliedCount   int64
+	SkippedCount   int64
+	StartedAt      time.Time
+	UpdatedAt      time.Time
+	CompletedAt    *time.Time
+	LastReceivedAt *time.Time
+}
+
+// VideoEngagementStatsDrift 表示对账时某视频统计的存量计数与按明细重算的计数。
+type VideoEngagementStatsDrift struct {
+	VideoID              uuid.UUID
+	StoredLikeCount      int64
+	StoredBookmarkCount  int64
+	StoredUniqueWatchers int64
+	ActualLikeCount      int64
+	ActualBookmarkCount  int64
+	ActualUniqueWatchers int64
+}
+
+// EngagementPurgeScope 表示 Engagement 投影清理的范围。
+type EngagementPurgeScope string
+
+const (
+	// EngagementPurgeScopeVideo 清理某视频的全部投影（用户状态、观看记录、会话、统计与分时统计桶）。
+	EngagementPurgeScopeVideo EngagementPurgeScope = "video"
+	// EngagementPurgeScopeUser 清理某用户的逐用户投影，并从视频累计统计中扣除其点赞/收藏/唯一观看。
+	EngagementPurgeScopeUser EngagementPurgeScope = "user"
+)
+
+// EngagementPurge 表示 catalog.engagement_purges 记录。
+type EngagementPurge struct {
+	Scope         EngagementPurgeScope
+	SubjectID     uuid.UUID
+	Reason        string
+	RequestedAt   time.Time
+	SourceEventID *uuid.UUID
+	RowsRemoved   int64
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	CompletedAt   *time.Time
+}
@@ -0,0 +1,47 @@
+package po
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InboxQuarantineResolution 表示隔离事件的处置结果。
+type InboxQuarantineResolution string
+
+const (
+	InboxQuarantineReplayed  InboxQuarantineResolution = "replayed"  // 人工重放成功
+	InboxQuarantineDiscarded InboxQuarantineResolution = "discarded" // 人工丢弃
+)
+
+// InboxQuarantineEntry 表示 catalog.inbox_quarantine 记录：因永久性错误被隔离的 Inbox 事件。
+type InboxQuarantineEntry struct {
+	EventID        uuid.UUID
+	Consumer       string
+	SourceService  string
+	EventType      string
+	AggregateType  *string
+	AggregateID    *string
+	Payload        []byte
+	ReceivedAt     time.Time
+	ErrorClass     string
+	LastError      string
+	QuarantinedAt  time.Time
+	ReplayAttempts int32
+	ResolvedAt     *time.Time
+	Resolution     *InboxQuarantineResolution
+	ResolvedNote   *string
+}
+
+// InboxEntry 表示 catalog.inbox_events 记录，供保留期清理归档与导出使用。
+type InboxEntry struct {
+	EventID       uuid.UUID
+	SourceService string
+	EventType     string
+	AggregateType *string
+	AggregateID   *string
+	Payload       []byte
+	ReceivedAt    time.Time
+	ProcessedAt   *time.Time
+	LastError     *string
+}
@@ -0,0 +1,54 @@
+package po
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxEntry 表示 catalog.outbox_events 记录，供运维查看与处置未发布的事件。
+type OutboxEntry struct {
+	EventID          uuid.UUID
+	AggregateType    string
+	AggregateID      uuid.UUID
+	EventType        string
+	Payload          []byte
+	Headers          []byte
+	OccurredAt       time.Time
+	AvailableAt      *time.Time // 已放弃的事件 available_at 为 infinity，此时为 nil
+	PublishedAt      *time.Time
+	DeliveryAttempts int32
+	LastError        *string
+	LockToken        *string
+	LockedAt         *time.Time
+	AbandonedAt      *time.Time
+	AbandonedReason  *string
+}
+
+// WebhookDelivery 表示 catalog.webhook_deliveries 记录，即一次 Outbox 事件到 Webhook 端点的投递尝试。
+type WebhookDelivery struct {
+	ID          int64
+	EventID     uuid.UUID
+	Endpoint    string
+	EventType   string
+	Attempt     int32
+	StatusCode  *int32 // 请求未得到响应时为 nil
+	Error       *string
+	DurationMs  int32
+	Succeeded   bool
+	AttemptedAt time.Time
+}
+
+// EventBackfillCheckpoint 表示 catalog.event_backfill_checkpoints 记录，即一次事件回填的范围与进度。
+type EventBackfillCheckpoint struct {
+	BackfillID      string
+	Target          string
+	ScopeStatuses   []VideoStatus
+	ScopeVisibility *string
+	ScopeUploaderID *uuid.UUID
+	LastVideoID     *uuid.UUID
+	PublishedCount  int64
+	StartedAt       time.Time
+	UpdatedAt       time.Time
+	CompletedAt     *time.Time
+}
@@ -25,9 +25,11 @@ type UploadSession struct {
 	Bucket             string
 	ObjectName         string
 	ContentType        *string
+	SniffedContentType *string
 	ExpectedSize       int64
 	SizeBytes          int64
 	ContentMD5         string
+	ContentSHA256      *string
 	Title              string
 	Description        string
 	SignedURL          *string
@@ -39,6 +41,41 @@ type UploadSession struct {
 	CRC32C             *string
 	ErrorCode          *string
 	ErrorMessage       *string
+	AssetID            *uuid.UUID
+	RawMissing         bool
+	RawMissingAt       *time.Time
 	CreatedAt          time.Time
 	UpdatedAt          time.Time
 }
+
+// UploadUsage 描述 catalog.upload_usage 中对账后的用户存储用量。
+type UploadUsage struct {
+	UserID           uuid.UUID
+	StoredBytes      int64
+	CompletedUploads int64
+	ReconciledAt     time.Time
+	UpdatedAt        time.Time
+}
+
+// UploadQuotaUsage 汇总配额校验所需的实时用量。
+type UploadQuotaUsage struct {
+	DailySessions           int64
+	ConcurrentUploads       int64
+	EarliestUploadExpiresAt *time.Time
+	StoredBytes             int64
+}
+
+// RawAsset 描述 catalog.raw_assets 中按内容 SHA-256 去重的原始资产。
+type RawAsset struct {
+	AssetID       uuid.UUID
+	ContentSHA256 string
+	ContentMD5    string
+	SizeBytes     int64
+	Bucket        string
+	ObjectName    string
+	ContentType   *string
+	RefCount      int64
+	CreatedBy     uuid.UUID
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
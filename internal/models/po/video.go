@@ -99,6 +99,8 @@ type Video struct {
 	PublishAt         *time.Time  // 发布时间（UTC）
 	RawSubtitleURL    *string     // 原始字幕/ASR 输出
 	ErrorMessage      *string     // 最近一次失败/拒绝原因
+	AssetID           *uuid.UUID  // 引用的原始资产（catalog.raw_assets）
+	RawMissing        bool        // 原始对象已被删除/归档
 }
 
 // VideoReadyView 表示从 catalog.videos 主表读取的只读视图。
@@ -115,6 +117,19 @@ type VideoReadyView struct {
 	PublishAt        *time.Time
 }
 
+// VideoPlaybackSource 表示签发播放地址所需的可见性与媒体产物字段。
+type VideoPlaybackSource struct {
+	VideoID           uuid.UUID
+	UploadUserID      uuid.UUID
+	Status            VideoStatus
+	MediaStatus       StageStatus
+	VisibilityStatus  string
+	PublishAt         *time.Time
+	DurationMicros    *int64
+	ThumbnailURL      *string
+	HLSMasterPlaylist *string
+}
+
 // VideoUserState 表示用户与视频的互动状态投影。
 // 数据来源：catalog.video_user_engagements_projection 表，由 Engagement 投影消费者维护。
 type VideoUserState struct {
@@ -124,9 +139,26 @@ type VideoUserState struct {
 	HasBookmarked        bool       // 是否收藏
 	LikedOccurredAt      *time.Time // 最近一次点赞事件时间
 	BookmarkedOccurredAt *time.Time // 最近一次收藏事件时间
+	LikedSequence        *int64     // 最近一次应用的点赞事件序号，事件未携带序号时为空
+	BookmarkedSequence   *int64     // 最近一次应用的收藏事件序号，事件未携带序号时为空
+	Rating               *int32     // 用户当前评分（1-5 星），未评分或已撤销时为空
+	RatedOccurredAt      *time.Time // 最近一次评分事件时间
+	RatedSequence        *int64     // 最近一次应用的评分事件序号，事件未携带序号时为空
 	UpdatedAt            time.Time  // 最后一次更新的时间
 }
 
+// SavedVideoEntry 表示用户点赞/收藏列表中的视频条目，SavedAt 为对应的点赞或收藏时间。
+type SavedVideoEntry struct {
+	VideoID        uuid.UUID
+	Title          string
+	Status         VideoStatus
+	MediaStatus    StageStatus
+	AnalysisStatus StageStatus
+	DurationMicros *int64
+	CreatedAt      time.Time
+	SavedAt        time.Time
+}
+
 // VideoListEntry 表示来自主表的视频条目。
 type VideoListEntry struct {
 	VideoID          uuid.UUID
@@ -152,4 +184,5 @@ type MyUploadEntry struct {
 	UpdatedAt        time.Time
 	VisibilityStatus string
 	PublishAt        *time.Time
+	RawMissing       bool
 }
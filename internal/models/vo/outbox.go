@@ -0,0 +1,86 @@
+package vo
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/google/uuid"
+)
+
+// OutboxEventStatus 描述 Outbox 事件的处置状态。
+type OutboxEventStatus string
+
+const (
+	OutboxEventPending   OutboxEventStatus = "pending"   // 等待发布，尚未投递失败
+	OutboxEventFailed    OutboxEventStatus = "failed"    // 等待发布，至少投递失败过一次
+	OutboxEventAbandoned OutboxEventStatus = "abandoned" // 已被人工放弃
+	OutboxEventPublished OutboxEventStatus = "published" // 已发布
+)
+
+// OutboxEvent 是 Outbox 事件的投递状态快照，供运维 RPC 与 catalogctl 输出。
+type OutboxEvent struct {
+	EventID          uuid.UUID         `json:"event_id"`
+	AggregateType    string            `json:"aggregate_type"`
+	AggregateID      uuid.UUID         `json:"aggregate_id"`
+	EventType        string            `json:"event_type"`
+	Status           OutboxEventStatus `json:"status"`
+	OccurredAt       time.Time         `json:"occurred_at"`
+	AvailableAt      *time.Time        `json:"available_at,omitempty"`
+	PublishedAt      *time.Time        `json:"published_at,omitempty"`
+	DeliveryAttempts int32             `json:"delivery_attempts"`
+	LastError        string            `json:"last_error,omitempty"`
+	LockToken        string            `json:"lock_token,omitempty"`
+	LockedAt         *time.Time        `json:"locked_at,omitempty"`
+	AbandonedAt      *time.Time        `json:"abandoned_at,omitempty"`
+	AbandonedReason  string            `json:"abandoned_reason,omitempty"`
+	PayloadBytes     int               `json:"payload_bytes"`
+}
+
+// OutboxEventDetail 在快照之外附带事件头部与解码后的 video.v1.Event 负载（protojson）。
+type OutboxEventDetail struct {
+	OutboxEvent
+	Headers     json.RawMessage `json:"headers,omitempty"`
+	Payload     json.RawMessage `json:"payload,omitempty"`
+	DecodeError string          `json:"decode_error,omitempty"`
+}
+
+// NewOutboxEvent 从持久化记录构造 OutboxEvent 并推导处置状态。
+func NewOutboxEvent(entry *po.OutboxEntry) *OutboxEvent {
+	if entry == nil {
+		return nil
+	}
+	event := &OutboxEvent{
+		EventID:          entry.EventID,
+		AggregateType:    entry.AggregateType,
+		AggregateID:      entry.AggregateID,
+		EventType:        entry.EventType,
+		OccurredAt:       entry.OccurredAt,
+		AvailableAt:      entry.AvailableAt,
+		PublishedAt:      entry.PublishedAt,
+		DeliveryAttempts: entry.DeliveryAttempts,
+		LockedAt:         entry.LockedAt,
+		AbandonedAt:      entry.AbandonedAt,
+		PayloadBytes:     len(entry.Payload),
+	}
+	if entry.LastError != nil {
+		event.LastError = *entry.LastError
+	}
+	if entry.LockToken != nil {
+		event.LockToken = *entry.LockToken
+	}
+	if entry.AbandonedReason != nil {
+		event.AbandonedReason = *entry.AbandonedReason
+	}
+	switch {
+	case entry.PublishedAt != nil:
+		event.Status = OutboxEventPublished
+	case entry.AbandonedAt != nil:
+		event.Status = OutboxEventAbandoned
+	case entry.DeliveryAttempts > 0:
+		event.Status = OutboxEventFailed
+	default:
+		event.Status = OutboxEventPending
+	}
+	return event
+}
@@ -0,0 +1,27 @@
+package vo
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PlaybackInfo 封装按可见性策略签发的限时播放地址。
+// 用于 GetPlaybackInfo RPC 响应。
+type PlaybackInfo struct {
+	VideoID              uuid.UUID     `json:"video_id"`
+	HLSMasterPlaylistURL string        `json:"hls_master_playlist_url"`
+	ThumbnailURL         string        `json:"thumbnail_url"`
+	ExpiresAt            time.Time     `json:"expires_at"`
+	DurationMicros       int64         `json:"duration_micros"`
+	SignedCookie         *SignedCookie `json:"signed_cookie,omitempty"`
+}
+
+// SignedCookie 描述覆盖 HLS 目录前缀的签名 Cookie。
+type SignedCookie struct {
+	Name      string    `json:"name"`
+	Value     string    `json:"value"`
+	Domain    string    `json:"domain"`
+	Path      string    `json:"path"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
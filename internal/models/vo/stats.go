@@ -0,0 +1,128 @@
+package vo
+
+import (
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/google/uuid"
+)
+
+// VideoStatsBucket 表示时间序列中的一个统计桶。
+type VideoStatsBucket struct {
+	BucketStart    time.Time `json:"bucket_start"`
+	LikeDelta      int64     `json:"like_delta"`
+	BookmarkDelta  int64     `json:"bookmark_delta"`
+	WatchCount     int64     `json:"watch_count"`
+	UniqueWatchers int64     `json:"unique_watchers"`
+}
+
+// VideoStatsTimeseries 封装 GetVideoStatsTimeseries 的响应：连续、按时间升序、无数据的桶补零。
+type VideoStatsTimeseries struct {
+	VideoID         uuid.UUID          `json:"video_id"`
+	Granularity     string             `json:"granularity"`
+	TZOffsetMinutes int32              `json:"tz_offset_minutes"`
+	TimeZone        string             `json:"time_zone"`
+	Buckets         []VideoStatsBucket `json:"buckets"`
+}
+
+// StatsZone 描述时间序列的时区：TimeZone 为 IANA 名称（含夏令时），否则为固定偏移 OffsetMinutes。
+type StatsZone struct {
+	TimeZone      string
+	OffsetMinutes int32
+	Location      *time.Location
+}
+
+// UTCStatsZone 返回 UTC 时区。
+func UTCStatsZone() StatsZone {
+	return StatsZone{Location: time.UTC}
+}
+
+// IsUTC 报告该时区是否恒为 UTC；只有 UTC 天级序列可以直接读取天级桶。
+func (z StatsZone) IsUTC() bool {
+	return z.Location == nil || z.Location == time.UTC
+}
+
+// StatsWindow 描述按粒度与时区对齐后的查询窗口 [Start, End)。
+// 统计按 UTC 整点小时存储：小时桶始终对齐 UTC 整点（以请求时区展示），天级桶对齐当地零点。
+type StatsWindow struct {
+	Granularity po.StatsGranularity
+	Zone        StatsZone
+	Start       time.Time
+	End         time.Time
+}
+
+// NewStatsWindow 将 [from, to) 向外对齐到 zone 时区下的桶边界。
+func NewStatsWindow(granularity po.StatsGranularity, from, to time.Time, zone StatsZone) StatsWindow {
+	if zone.Location == nil {
+		zone = UTCStatsZone()
+	}
+	w := StatsWindow{Granularity: granularity, Zone: zone}
+	w.Start = w.floor(from)
+	w.End = w.floor(to)
+	if w.End.Before(to) {
+		w.End = w.next(w.End)
+	}
+	return w
+}
+
+// BucketCount 返回窗口内的桶数量；夏令时切换日的天级桶为 23 或 25 小时，按天四舍五入抵消。
+func (w StatsWindow) BucketCount() int {
+	span := w.End.Sub(w.Start)
+	if w.Granularity == po.StatsGranularityDay {
+		return int((span + 12*time.Hour) / (24 * time.Hour))
+	}
+	return int(span / time.Hour)
+}
+
+// floor 返回 t 所在桶的起点（位于窗口时区）。
+func (w StatsWindow) floor(t time.Time) time.Time {
+	if w.Granularity == po.StatsGranularityDay {
+		local := t.In(w.Zone.Location)
+		return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, w.Zone.Location)
+	}
+	return t.Truncate(time.Hour).In(w.Zone.Location)
+}
+
+func (w StatsWindow) next(t time.Time) time.Time {
+	if w.Granularity == po.StatsGranularityDay {
+		return time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, w.Zone.Location)
+	}
+	return t.Add(time.Hour)
+}
+
+// NewVideoStatsTimeseries 将存储桶按窗口时区归并到目标粒度并补齐空桶。
+// rows 可以是更细粒度的桶（如按非 UTC 时区汇总天级数据时传入小时桶）。
+func NewVideoStatsTimeseries(videoID uuid.UUID, window StatsWindow, rows []po.VideoStatsBucket) *VideoStatsTimeseries {
+	folded := make(map[int64]*VideoStatsBucket, len(rows))
+	for _, row := range rows {
+		key := window.floor(row.BucketStart).Unix()
+		bucket, ok := folded[key]
+		if !ok {
+			bucket = &VideoStatsBucket{}
+			folded[key] = bucket
+		}
+		bucket.LikeDelta += row.LikeDelta
+		bucket.BookmarkDelta += row.BookmarkDelta
+		bucket.WatchCount += row.WatchCount
+		bucket.UniqueWatchers += row.UniqueWatchers
+	}
+
+	series := &VideoStatsTimeseries{
+		VideoID:         videoID,
+		Granularity:     string(window.Granularity),
+		TZOffsetMinutes: window.Zone.OffsetMinutes,
+		TimeZone:        window.Zone.TimeZone,
+		Buckets:         make([]VideoStatsBucket, 0, window.BucketCount()),
+	}
+	for t := window.Start; t.Before(window.End); t = window.next(t) {
+		bucket := VideoStatsBucket{BucketStart: t}
+		if found, ok := folded[t.Unix()]; ok {
+			bucket.LikeDelta = found.LikeDelta
+			bucket.BookmarkDelta = found.BookmarkDelta
+			bucket.WatchCount = found.WatchCount
+			bucket.UniqueWatchers = found.UniqueWatchers
+		}
+		series.Buckets = append(series.Buckets, bucket)
+	}
+	return series
+}
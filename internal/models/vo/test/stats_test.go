@@ -0,0 +1,102 @@
+package vo_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/vo"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStatsWindowAlignsToBucketBoundaries(t *testing.T) {
+	from := time.Date(2025, time.March, 1, 10, 30, 0, 0, time.UTC)
+	to := time.Date(2025, time.March, 1, 13, 0, 0, 0, time.UTC)
+
+	hourly := vo.NewStatsWindow(po.StatsGranularityHour, from, to, vo.UTCStatsZone())
+	assert.Equal(t, time.Date(2025, time.March, 1, 10, 0, 0, 0, time.UTC), hourly.Start.UTC())
+	assert.Equal(t, to, hourly.End.UTC())
+	assert.Equal(t, 3, hourly.BucketCount())
+
+	// -5 时区：UTC 10:30 为当地 05:30，天级窗口对齐到当地零点（UTC 05:00）。
+	daily := vo.NewStatsWindow(po.StatsGranularityDay, from, to, fixedZone(-300))
+	assert.Equal(t, time.Date(2025, time.March, 1, 5, 0, 0, 0, time.UTC), daily.Start.UTC())
+	assert.Equal(t, time.Date(2025, time.March, 2, 5, 0, 0, 0, time.UTC), daily.End.UTC())
+	assert.Equal(t, 1, daily.BucketCount())
+}
+
+func TestNewVideoStatsTimeseriesFoldsAndFillsGaps(t *testing.T) {
+	videoID := uuid.New()
+	from := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, time.March, 4, 0, 0, 0, 0, time.UTC)
+	window := vo.NewStatsWindow(po.StatsGranularityDay, from, to, fixedZone(60))
+
+	// +1 时区：UTC 2 月 28 日 23:00 属于当地 3 月 1 日，UTC 3 月 1 日 22:00 与 23:00 分属当地 1 日与 2 日。
+	rows := []po.VideoStatsBucket{
+		{BucketStart: time.Date(2025, time.February, 28, 23, 0, 0, 0, time.UTC), WatchCount: 1, UniqueWatchers: 1},
+		{BucketStart: time.Date(2025, time.March, 1, 22, 0, 0, 0, time.UTC), LikeDelta: 2},
+		{BucketStart: time.Date(2025, time.March, 1, 23, 0, 0, 0, time.UTC), LikeDelta: -1, BookmarkDelta: 1},
+	}
+	series := vo.NewVideoStatsTimeseries(videoID, window, rows)
+
+	require.Len(t, series.Buckets, 4)
+	assert.Equal(t, "day", series.Granularity)
+	assert.Equal(t, int32(60), series.TZOffsetMinutes)
+	assert.Equal(t, "2025-03-01T00:00:00+01:00", series.Buckets[0].BucketStart.Format(time.RFC3339))
+	assert.Equal(t, vo.VideoStatsBucket{BucketStart: series.Buckets[0].BucketStart, LikeDelta: 2, WatchCount: 1, UniqueWatchers: 1}, series.Buckets[0])
+	assert.Equal(t, int64(-1), series.Buckets[1].LikeDelta)
+	assert.Equal(t, int64(1), series.Buckets[1].BookmarkDelta)
+	assert.Zero(t, series.Buckets[2].WatchCount)
+	assert.Zero(t, series.Buckets[3].LikeDelta)
+}
+
+func TestStatsWindowFollowsDaylightSaving(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	zone := vo.StatsZone{TimeZone: "America/New_York", Location: loc}
+
+	// 2025-03-09 当地凌晨 2 点进入夏令时，该日只有 23 小时。
+	from := time.Date(2025, time.March, 8, 12, 0, 0, 0, loc)
+	to := time.Date(2025, time.March, 10, 12, 0, 0, 0, loc)
+	window := vo.NewStatsWindow(po.StatsGranularityDay, from, to, zone)
+	require.Equal(t, 3, window.BucketCount())
+
+	rows := []po.VideoStatsBucket{
+		{BucketStart: time.Date(2025, time.March, 9, 4, 0, 0, 0, time.UTC), WatchCount: 1},  // 当地 3 月 8 日 23:00（EST）
+		{BucketStart: time.Date(2025, time.March, 10, 3, 0, 0, 0, time.UTC), WatchCount: 2}, // 当地 3 月 9 日 23:00（EDT）
+		{BucketStart: time.Date(2025, time.March, 10, 4, 0, 0, 0, time.UTC), WatchCount: 4}, // 当地 3 月 10 日 00:00（EDT）
+	}
+	series := vo.NewVideoStatsTimeseries(uuid.New(), window, rows)
+	require.Len(t, series.Buckets, 3)
+	assert.Equal(t, "America/New_York", series.TimeZone)
+	assert.Equal(t, "2025-03-09T00:00:00-05:00", series.Buckets[1].BucketStart.Format(time.RFC3339))
+	assert.Equal(t, "2025-03-10T00:00:00-04:00", series.Buckets[2].BucketStart.Format(time.RFC3339))
+	assert.EqualValues(t, 1, series.Buckets[0].WatchCount)
+	assert.EqualValues(t, 2, series.Buckets[1].WatchCount)
+	assert.EqualValues(t, 4, series.Buckets[2].WatchCount)
+}
+
+func TestStatsWindowHalfHourOffset(t *testing.T) {
+	// +05:30：小时桶对齐 UTC 整点；天级桶按小时桶起点的当地日期归并。
+	from := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	hourly := vo.NewStatsWindow(po.StatsGranularityHour, from, from.Add(2*time.Hour), fixedZone(330))
+	assert.Equal(t, "2025-03-01T05:30:00+05:30", hourly.Start.Format(time.RFC3339))
+	assert.Equal(t, 2, hourly.BucketCount())
+
+	daily := vo.NewStatsWindow(po.StatsGranularityDay, from, from.Add(time.Hour), fixedZone(330))
+	rows := []po.VideoStatsBucket{
+		{BucketStart: time.Date(2025, time.February, 28, 18, 0, 0, 0, time.UTC), LikeDelta: 1}, // 当地 2 月 28 日 23:30
+		{BucketStart: time.Date(2025, time.February, 28, 19, 0, 0, 0, time.UTC), LikeDelta: 2}, // 当地 3 月 1 日 00:30
+	}
+	series := vo.NewVideoStatsTimeseries(uuid.New(), daily, rows)
+	require.Len(t, series.Buckets, 1)
+	assert.Equal(t, "2025-03-01T00:00:00+05:30", series.Buckets[0].BucketStart.Format(time.RFC3339))
+	assert.EqualValues(t, 2, series.Buckets[0].LikeDelta)
+}
+
+func fixedZone(offsetMinutes int32) vo.StatsZone {
+	return vo.StatsZone{OffsetMinutes: offsetMinutes, Location: time.FixedZone("", int(offsetMinutes)*60)}
+}
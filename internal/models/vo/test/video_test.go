@@ -152,3 +152,32 @@ func TestNewVideoDeleted(t *testing.T) {
 	assert.Equal(t, int64(99), deleted.Version)
 	assert.Equal(t, now, deleted.OccurredAt)
 }
+
+func TestCompletionRate(t *testing.T) {
+	stats := &po.VideoEngagementStatsProjection{PositionSumSeconds: 90, PositionViewers: 2}
+
+	assert.InDelta(t, 0.75, vo.CompletionRate(stats, 60_000_000), 1e-9)
+	assert.Zero(t, vo.CompletionRate(stats, 0), "unknown duration")
+	assert.Zero(t, vo.CompletionRate(&po.VideoEngagementStatsProjection{}, 60_000_000), "no progress reported")
+	assert.Zero(t, vo.CompletionRate(nil, 60_000_000))
+
+	// 播放位置上报可能略超时长（片尾误差），结果截断为 1。
+	overshoot := &po.VideoEngagementStatsProjection{PositionSumSeconds: 61, PositionViewers: 1}
+	assert.Equal(t, 1.0, vo.CompletionRate(overshoot, 60_000_000))
+}
+
+func TestAverageViewDuration(t *testing.T) {
+	stats := &po.VideoEngagementStatsProjection{TotalWatchSeconds: 150, PositionViewers: 4}
+
+	assert.InDelta(t, 37.5, vo.AverageViewDuration(stats), 1e-9)
+	assert.Zero(t, vo.AverageViewDuration(&po.VideoEngagementStatsProjection{TotalWatchSeconds: 10}), "no progress reported")
+	assert.Zero(t, vo.AverageViewDuration(nil))
+}
+
+func TestAverageRating(t *testing.T) {
+	stats := &po.VideoEngagementStatsProjection{RatingCount: 4, RatingSum: 14}
+
+	assert.InDelta(t, 3.5, vo.AverageRating(stats), 1e-9)
+	assert.Zero(t, vo.AverageRating(&po.VideoEngagementStatsProjection{}), "no ratings")
+	assert.Zero(t, vo.AverageRating(nil))
+}
@@ -0,0 +1,33 @@
+package vo
+
+import "time"
+
+// UploadQuotaLimit 描述单项上传配额的上限与当前用量。
+type UploadQuotaLimit struct {
+	Limit     int64 `json:"limit"`
+	Used      int64 `json:"used"`
+	Remaining int64 `json:"remaining"`
+	Unlimited bool  `json:"unlimited"`
+}
+
+// UploadQuota 汇总用户所属档位的上传配额及剩余额度。
+// 用于 GetMyUploadQuota RPC 响应。
+type UploadQuota struct {
+	Tier              string           `json:"tier"`
+	DailySessions     UploadQuotaLimit `json:"daily_sessions"`
+	ConcurrentUploads UploadQuotaLimit `json:"concurrent_uploads"`
+	StorageBytes      UploadQuotaLimit `json:"storage_bytes"`
+	DailyResetAt      time.Time        `json:"daily_reset_at"`
+}
+
+// NewUploadQuotaLimit 根据上限与用量计算剩余额度；limit<=0 视为不限。
+func NewUploadQuotaLimit(limit, used int64) UploadQuotaLimit {
+	if limit <= 0 {
+		return UploadQuotaLimit{Used: used, Unlimited: true}
+	}
+	remaining := limit - used
+	if remaining < 0 {
+		remaining = 0
+	}
+	return UploadQuotaLimit{Limit: limit, Used: used, Remaining: remaining}
+}
@@ -57,6 +57,16 @@ type VideoDetail struct {
 	BookmarkCount  int64     `json:"bookmark_count"`
 	WatchCount     int64     `json:"watch_count"`
 	UniqueWatchers int64     `json:"unique_watchers"`
+
+	TotalWatchSeconds    float64 `json:"total_watch_seconds"`
+	CompletionRate       float64 `json:"completion_rate"`
+	ResumePositionMicros int64   `json:"resume_position_micros"`
+	ShareCount           int64   `json:"share_count"`
+	RatingCount          int64   `json:"rating_count"`
+	AverageRating        float64 `json:"average_rating"`
+	MyRating             int32   `json:"my_rating"`
+
+	AverageViewDurationSeconds float64 `json:"average_view_duration_seconds"`
 }
 
 // NewVideoDetail 从只读视图实体构造 VO。
@@ -94,6 +104,13 @@ type VideoMetadata struct {
 	LikeCount         int64     `json:"like_count"`
 	BookmarkCount     int64     `json:"bookmark_count"`
 	WatchCount        int64     `json:"watch_count"`
+	TotalWatchSeconds float64   `json:"total_watch_seconds"`
+	CompletionRate    float64   `json:"completion_rate"`
+	ShareCount        int64     `json:"share_count"`
+	RatingCount       int64     `json:"rating_count"`
+	AverageRating     float64   `json:"average_rating"`
+
+	AverageViewDurationSeconds float64 `json:"average_view_duration_seconds"`
 }
 
 // NewVideoMetadataFromPO 将持久层元数据转换为 VO。
@@ -159,6 +176,108 @@ type MyUploadListItem struct {
 	Version        int64
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
+	RawMissing     bool
+}
+
+// WatchHistoryItem 表示用户观看历史中的项。
+type WatchHistoryItem struct {
+	VideoID              uuid.UUID
+	Title                string
+	Status               string
+	MediaStatus          string
+	AnalysisStatus       string
+	DurationMicros       int64
+	ResumePositionMicros int64
+	LastWatchedAt        time.Time
+}
+
+// NewWatchHistoryItem 从观看历史条目构造 VO。
+func NewWatchHistoryItem(entry po.WatchHistoryEntry) WatchHistoryItem {
+	item := WatchHistoryItem{
+		VideoID:              entry.VideoID,
+		Title:                entry.Title,
+		Status:               string(entry.Status),
+		MediaStatus:          string(entry.MediaStatus),
+		AnalysisStatus:       string(entry.AnalysisStatus),
+		ResumePositionMicros: SecondsToMicros(entry.LastPositionSeconds),
+		LastWatchedAt:        entry.LastWatchedAt,
+	}
+	if entry.DurationMicros != nil {
+		item.DurationMicros = *entry.DurationMicros
+	}
+	return item
+}
+
+// SavedVideoItem 表示用户点赞/收藏列表中的项。
+type SavedVideoItem struct {
+	VideoID        uuid.UUID
+	Title          string
+	Status         string
+	MediaStatus    string
+	AnalysisStatus string
+	DurationMicros int64
+	CreatedAt      time.Time
+	SavedAt        time.Time
+}
+
+// NewSavedVideoItem 从点赞/收藏条目构造 VO。
+func NewSavedVideoItem(entry po.SavedVideoEntry) SavedVideoItem {
+	item := SavedVideoItem{
+		VideoID:        entry.VideoID,
+		Title:          entry.Title,
+		Status:         string(entry.Status),
+		MediaStatus:    string(entry.MediaStatus),
+		AnalysisStatus: string(entry.AnalysisStatus),
+		CreatedAt:      entry.CreatedAt,
+		SavedAt:        entry.SavedAt,
+	}
+	if entry.DurationMicros != nil {
+		item.DurationMicros = *entry.DurationMicros
+	}
+	return item
+}
+
+// TrendingVideoItem 表示热度榜中的项。
+type TrendingVideoItem struct {
+	VideoID        uuid.UUID
+	Title          string
+	DurationMicros int64
+	Difficulty     string
+	Tags           []string
+	CreatedAt      time.Time
+	Rank           int32
+	Score          float64
+}
+
+// NewTrendingVideoItem 从热度快照条目构造 VO。
+func NewTrendingVideoItem(entry po.TrendingVideoEntry) TrendingVideoItem {
+	item := TrendingVideoItem{
+		VideoID:   entry.VideoID,
+		Title:     entry.Title,
+		Tags:      entry.Tags,
+		CreatedAt: entry.CreatedAt,
+		Rank:      entry.Rank,
+		Score:     entry.Score,
+	}
+	if entry.DurationMicros != nil {
+		item.DurationMicros = *entry.DurationMicros
+	}
+	if entry.Difficulty != nil {
+		item.Difficulty = *entry.Difficulty
+	}
+	return item
+}
+
+// TrendingVideoPage 封装热度榜的一页结果；ComputedAt 为快照计算时间，尚无快照时为零值。
+type TrendingVideoPage struct {
+	Items         []TrendingVideoItem
+	ComputedAt    time.Time
+	NextPageToken string
+}
+
+// SecondsToMicros 将进度事件中的秒数换算为微秒。
+func SecondsToMicros(seconds float64) int64 {
+	return int64(seconds * float64(time.Second/time.Microsecond))
 }
 
 // VideoUpdated 封装视频更新后的响应信息。
@@ -209,3 +328,30 @@ func NewVideoDeleted(videoID uuid.UUID, deletedAt time.Time, eventID uuid.UUID,
 		OccurredAt: occurredAt,
 	}
 }
+
+// CompletionRate 以各观看用户最大播放位置之和除以（观看人数 × 视频时长）计算完播率，结果截断到 [0, 1]。
+// 视频时长未知或尚无进度上报时返回 0。
+func CompletionRate(stats *po.VideoEngagementStatsProjection, durationMicros int64) float64 {
+	if stats == nil || stats.PositionViewers <= 0 || durationMicros <= 0 {
+		return 0
+	}
+	durationSeconds := float64(durationMicros) / float64(time.Second/time.Microsecond)
+	rate := stats.PositionSumSeconds / (float64(stats.PositionViewers) * durationSeconds)
+	return min(max(rate, 0), 1)
+}
+
+// AverageViewDuration 以累计观看秒数除以上报过播放进度的用户数计算平均观看时长（秒），尚无进度上报时返回 0。
+func AverageViewDuration(stats *po.VideoEngagementStatsProjection) float64 {
+	if stats == nil || stats.PositionViewers <= 0 {
+		return 0
+	}
+	return stats.TotalWatchSeconds / float64(stats.PositionViewers)
+}
+
+// AverageRating 以星级之和除以评分人数计算平均星级，尚无评分时返回 0。
+func AverageRating(stats *po.VideoEngagementStatsProjection) float64 {
+	if stats == nil || stats.RatingCount <= 0 {
+		return 0
+	}
+	return float64(stats.RatingSum) / float64(stats.RatingCount)
+}
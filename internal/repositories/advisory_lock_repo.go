@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	catalogsql "github.com/bionicotaku/lingo-services-catalog/internal/repositories/sqlc"
+
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AdvisoryLockRepository 基于 PostgreSQL advisory lock 提供跨实例互斥，锁名在数据库内哈希为 bigint 键。
+type AdvisoryLockRepository struct {
+	db      *pgxpool.Pool
+	queries *catalogsql.Queries
+	log     *log.Helper
+}
+
+// NewAdvisoryLockRepository 构造 AdvisoryLockRepository。
+func NewAdvisoryLockRepository(db *pgxpool.Pool, logger log.Logger) *AdvisoryLockRepository {
+	return &AdvisoryLockRepository{
+		db:      db,
+		queries: catalogsql.New(db),
+		log:     log.NewHelper(logger),
+	}
+}
+
+// Lock 在独占连接上获取会话级排他锁，阻塞直至获取或 ctx 取消；返回的 release 释放锁并归还连接。
+func (r *AdvisoryLockRepository) Lock(ctx context.Context, name string) (func(), error) {
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("acquire lock connection: %w", err)
+	}
+	if err := catalogsql.New(conn).AcquireAdvisoryLock(ctx, name); err != nil {
+		conn.Release()
+		r.log.WithContext(ctx).Errorf("acquire advisory lock failed: name=%s err=%v", name, err)
+		return nil, fmt.Errorf("acquire advisory lock %s: %w", name, err)
+	}
+	return r.releaser(conn, name), nil
+}
+
+// TryLock 非阻塞获取会话级排他锁；锁被其他会话持有时返回 ok=false。
+func (r *AdvisoryLockRepository) TryLock(ctx context.Context, name string) (func(), bool, error) {
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("acquire lock connection: %w", err)
+	}
+	acquired, err := catalogsql.New(conn).TryAdvisoryLock(ctx, name)
+	if err != nil {
+		conn.Release()
+		r.log.WithContext(ctx).Errorf("try advisory lock failed: name=%s err=%v", name, err)
+		return nil, false, fmt.Errorf("try advisory lock %s: %w", name, err)
+	}
+	if !acquired {
+		conn.Release()
+		return nil, false, nil
+	}
+	return r.releaser(conn, name), true, nil
+}
+
+// LockTx 在事务内获取排他锁，阻塞直至获取；锁随事务提交或回滚释放。
+func (r *AdvisoryLockRepository) LockTx(ctx context.Context, sess txmanager.Session, name string) error {
+	if err := r.queries.WithTx(sess.Tx()).AcquireAdvisoryXactLock(ctx, name); err != nil {
+		r.log.WithContext(ctx).Errorf("acquire advisory xact lock failed: name=%s err=%v", name, err)
+		return fmt.Errorf("acquire advisory xact lock %s: %w", name, err)
+	}
+	return nil
+}
+
+// TryLockSharedTx 在事务内非阻塞获取共享锁；同名排他锁被持有（或正在等待）时返回 false。
+func (r *AdvisoryLockRepository) TryLockSharedTx(ctx context.Context, sess txmanager.Session, name string) (bool, error) {
+	acquired, err := r.queries.WithTx(sess.Tx()).TryAdvisoryXactLockShared(ctx, name)
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("try advisory shared lock failed: name=%s err=%v", name, err)
+		return false, fmt.Errorf("try advisory shared lock %s: %w", name, err)
+	}
+	return acquired, nil
+}
+
+// releaser 返回释放会话级锁的函数；解锁失败时关闭连接，避免锁随连接回到连接池。
+func (r *AdvisoryLockRepository) releaser(conn *pgxpool.Conn, name string) func() {
+	return func() {
+		ctx := context.Background()
+		if _, err := catalogsql.New(conn).ReleaseAdvisoryLock(ctx, name); err != nil {
+			r.log.Warnf("release advisory lock failed, closing connection: name=%s err=%v", name, err)
+			_ = conn.Conn().Close(ctx)
+		}
+		conn.Release()
+	}
+}
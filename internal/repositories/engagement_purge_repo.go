@@ -0,0 +1,213 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories/mappers"
+	catalogsql "github.com/bionicotaku/lingo-services-catalog/internal/repositories/sqlc"
+
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// EngagementPurgeRepository 提供 Engagement 投影清理请求的登记、墓碑查询与分批删除。
+type EngagementPurgeRepository struct {
+	db      *pgxpool.Pool
+	queries *catalogsql.Queries
+	log     *log.Helper
+}
+
+// NewEngagementPurgeRepository 构造 EngagementPurgeRepository。
+func NewEngagementPurgeRepository(db *pgxpool.Pool, logger log.Logger) *EngagementPurgeRepository {
+	return &EngagementPurgeRepository{
+		db:      db,
+		queries: catalogsql.New(db),
+		log:     log.NewHelper(logger),
+	}
+}
+
+// RequestPurgeInput 描述一次清理请求。
+type RequestPurgeInput struct {
+	Scope         po.EngagementPurgeScope
+	SubjectID     uuid.UUID
+	Reason        string
+	RequestedAt   time.Time
+	SourceEventID *uuid.UUID
+}
+
+// PurgedRows 记录一批清理中某张表删除的行数。
+type PurgedRows struct {
+	Table string
+	Rows  int64
+}
+
+// RequestPurge 登记（或重新打开）清理请求；同一对象重复登记时保留较晚的触发时间。
+func (r *EngagementPurgeRepository) RequestPurge(ctx context.Context, sess txmanager.Session, input RequestPurgeInput) error {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	if err := queries.UpsertEngagementPurge(ctx, catalogsql.UpsertEngagementPurgeParams{
+		Scope:         string(input.Scope),
+		SubjectID:     input.SubjectID,
+		Reason:        input.Reason,
+		RequestedAt:   mappers.ToPgTimestamptz(&input.RequestedAt),
+		SourceEventID: mappers.ToPgUUID(input.SourceEventID),
+	}); err != nil {
+		r.log.WithContext(ctx).Errorf("request engagement purge failed: scope=%s subject=%s err=%v", input.Scope, input.SubjectID, err)
+		return fmt.Errorf("request engagement purge: %w", err)
+	}
+	return nil
+}
+
+// GetPurgeCutoff 返回用户或视频已登记清理的最晚触发时间；两者均未登记时返回 nil。
+func (r *EngagementPurgeRepository) GetPurgeCutoff(ctx context.Context, sess txmanager.Session, userID, videoID uuid.UUID) (*time.Time, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	cutoff, err := queries.GetEngagementPurgeCutoff(ctx, catalogsql.GetEngagementPurgeCutoffParams{
+		VideoID: videoID,
+		UserID:  userID,
+	})
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("get engagement purge cutoff failed: user=%s video=%s err=%v", userID, videoID, err)
+		return nil, fmt.Errorf("get engagement purge cutoff: %w", err)
+	}
+	if !cutoff.Valid {
+		return nil, nil
+	}
+	at := cutoff.Time.UTC()
+	return &at, nil
+}
+
+// ListPendingPurges 按登记顺序返回待处理的清理请求。
+func (r *EngagementPurgeRepository) ListPendingPurges(ctx context.Context, sess txmanager.Session, limit int) ([]*po.EngagementPurge, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	rows, err := queries.ListPendingEngagementPurges(ctx, int32(limit))
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("list pending engagement purges failed: err=%v", err)
+		return nil, fmt.Errorf("list pending engagement purges: %w", err)
+	}
+	purges := make([]*po.EngagementPurge, 0, len(rows))
+	for _, row := range rows {
+		purges = append(purges, mappers.EngagementPurgeFromCatalog(row))
+	}
+	return purges, nil
+}
+
+// PurgeBatch 对清理请求涉及的每张表各删除至多 batchSize 行，返回各表删除行数；全部为 0 表示已清理干净。
+// 按用户清理时在同一语句内把被删除的点赞/收藏/评分/唯一观看写为分片负增量，与未压实的分片正增量一起累加，保证对账不产生偏差。
+func (r *EngagementPurgeRepository) PurgeBatch(ctx context.Context, sess txmanager.Session, purge *po.EngagementPurge, batchSize int) ([]PurgedRows, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	limit := int32(batchSize)
+	subject := purge.SubjectID
+	type step struct {
+		table string
+		run   func() (int64, error)
+	}
+	var steps []step
+	switch purge.Scope {
+	case po.EngagementPurgeScopeVideo:
+		steps = []step{
+			{"video_user_engagements_projection", func() (int64, error) {
+				return queries.PurgeVideoUserEngagementsByVideo(ctx, catalogsql.PurgeVideoUserEngagementsByVideoParams{VideoID: subject, BatchSize: limit})
+			}},
+			{"video_engagement_watchers", func() (int64, error) {
+				return queries.PurgeVideoWatchersByVideo(ctx, catalogsql.PurgeVideoWatchersByVideoParams{VideoID: subject, BatchSize: limit})
+			}},
+			{"video_view_sessions", func() (int64, error) {
+				return queries.PurgeVideoViewSessionsByVideo(ctx, catalogsql.PurgeVideoViewSessionsByVideoParams{VideoID: subject, BatchSize: limit})
+			}},
+			{"video_engagement_stats_hourly", func() (int64, error) {
+				return queries.PurgeVideoEngagementStatsHourlyByVideo(ctx, catalogsql.PurgeVideoEngagementStatsHourlyByVideoParams{VideoID: subject, BatchSize: limit})
+			}},
+			{"video_engagement_stats_daily", func() (int64, error) {
+				return queries.PurgeVideoEngagementStatsDailyByVideo(ctx, catalogsql.PurgeVideoEngagementStatsDailyByVideoParams{VideoID: subject, BatchSize: limit})
+			}},
+			{"video_engagement_stats_projection", func() (int64, error) {
+				return queries.DeleteVideoEngagementStatsProjection(ctx, subject)
+			}},
+			{"video_engagement_stats_shards", func() (int64, error) {
+				return queries.DeleteVideoEngagementStatsShards(ctx, subject)
+			}},
+		}
+	case po.EngagementPurgeScopeUser:
+		steps = []step{
+			{"video_user_engagements_projection", func() (int64, error) {
+				return queries.PurgeUserEngagementsByUser(ctx, catalogsql.PurgeUserEngagementsByUserParams{UserID: subject, BatchSize: limit})
+			}},
+			{"video_engagement_watchers", func() (int64, error) {
+				return queries.PurgeUserWatchersByUser(ctx, catalogsql.PurgeUserWatchersByUserParams{UserID: subject, BatchSize: limit})
+			}},
+			{"video_view_sessions", func() (int64, error) {
+				return queries.PurgeUserViewSessionsByUser(ctx, catalogsql.PurgeUserViewSessionsByUserParams{UserID: subject, BatchSize: limit})
+			}},
+		}
+	default:
+		return nil, fmt.Errorf("purge engagement batch: unsupported scope %q", purge.Scope)
+	}
+
+	removed := make([]PurgedRows, 0, len(steps))
+	for _, s := range steps {
+		rows, err := s.run()
+		if err != nil {
+			r.log.WithContext(ctx).Errorf("purge %s failed: scope=%s subject=%s err=%v", s.table, purge.Scope, subject, err)
+			return nil, fmt.Errorf("purge %s: %w", s.table, err)
+		}
+		removed = append(removed, PurgedRows{Table: s.table, Rows: rows})
+	}
+	return removed, nil
+}
+
+// AddPurgeProgress 累计清理请求已删除的行数。
+func (r *EngagementPurgeRepository) AddPurgeProgress(ctx context.Context, sess txmanager.Session, purge *po.EngagementPurge, rows int64) error {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	if err := queries.AddEngagementPurgeRowsRemoved(ctx, catalogsql.AddEngagementPurgeRowsRemovedParams{
+		Rows:      rows,
+		Scope:     string(purge.Scope),
+		SubjectID: purge.SubjectID,
+	}); err != nil {
+		r.log.WithContext(ctx).Errorf("record engagement purge progress failed: scope=%s subject=%s err=%v", purge.Scope, purge.SubjectID, err)
+		return fmt.Errorf("record engagement purge progress: %w", err)
+	}
+	return nil
+}
+
+// CompletePurge 标记清理完成；处理期间被重新登记的请求保持待处理并返回 false。
+func (r *EngagementPurgeRepository) CompletePurge(ctx context.Context, sess txmanager.Session, purge *po.EngagementPurge) (bool, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	affected, err := queries.CompleteEngagementPurge(ctx, catalogsql.CompleteEngagementPurgeParams{
+		Scope:       string(purge.Scope),
+		SubjectID:   purge.SubjectID,
+		RequestedAt: mappers.ToPgTimestamptz(&purge.RequestedAt),
+	})
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("complete engagement purge failed: scope=%s subject=%s err=%v", purge.Scope, purge.SubjectID, err)
+		return false, fmt.Errorf("complete engagement purge: %w", err)
+	}
+	return affected > 0, nil
+}
@@ -0,0 +1,313 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories/mappers"
+	catalogsql "github.com/bionicotaku/lingo-services-catalog/internal/repositories/sqlc"
+
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrReplayCheckpointNotFound 表示重放检查点不存在。
+var ErrReplayCheckpointNotFound = errors.New("engagement replay checkpoint not found")
+
+// EngagementReplayRepository 提供 Engagement 投影重放所需的 Inbox 历史读取、范围清理与检查点读写。
+type EngagementReplayRepository struct {
+	db      *pgxpool.Pool
+	queries *catalogsql.Queries
+	log     *log.Helper
+}
+
+// NewEngagementReplayRepository 构造 EngagementReplayRepository。
+func NewEngagementReplayRepository(db *pgxpool.Pool, logger log.Logger) *EngagementReplayRepository {
+	return &EngagementReplayRepository{
+		db:      db,
+		queries: catalogsql.New(db),
+		log:     log.NewHelper(logger),
+	}
+}
+
+// ReplayScope 限定重放范围；两个字段均为空表示全量重放。
+type ReplayScope struct {
+	UserID  *uuid.UUID
+	VideoID *uuid.UUID
+}
+
+// InboxCursor 表示按 (occurred_at, event_id) 扫描 Inbox 的键集游标。
+type InboxCursor struct {
+	OccurredAt time.Time
+	EventID    uuid.UUID
+}
+
+// InboxOccurrence 表示一条待回写发生时间的 Inbox 事件。
+type InboxOccurrence struct {
+	EventID    uuid.UUID
+	OccurredAt time.Time
+}
+
+// ListInboxEvents 按 (occurred_at, event_id) 升序分页扫描指定类型、已处理完成且已标注发生时间的 Inbox 事件；after 为空时从头开始。
+func (r *EngagementReplayRepository) ListInboxEvents(ctx context.Context, sess txmanager.Session, eventTypes []string, after *InboxCursor, limit int) ([]*po.InboxEventRecord, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	params := catalogsql.ListInboxEventsByTypeParams{
+		EventTypes: eventTypes,
+		Limit:      int32(limit),
+	}
+	if after != nil {
+		params.AfterOccurredAt = pgtype.Timestamptz{Time: after.OccurredAt.UTC(), Valid: true}
+		params.AfterEventID = after.EventID
+	}
+
+	rows, err := queries.ListInboxEventsByType(ctx, params)
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("list inbox events failed: types=%v err=%v", eventTypes, err)
+		return nil, fmt.Errorf("list inbox events: %w", err)
+	}
+	records := make([]*po.InboxEventRecord, 0, len(rows))
+	for _, row := range rows {
+		records = append(records, &po.InboxEventRecord{
+			EventID:    row.EventID,
+			EventType:  row.EventType,
+			Payload:    row.Payload,
+			ReceivedAt: row.ReceivedAt.Time,
+			OccurredAt: row.OccurredAt.Time,
+		})
+	}
+	return records, nil
+}
+
+// ListUnstampedInboxEvents 按接收顺序读取尚未标注发生时间的已处理 Inbox 事件，返回记录的 OccurredAt 为零值。
+func (r *EngagementReplayRepository) ListUnstampedInboxEvents(ctx context.Context, sess txmanager.Session, eventTypes []string, limit int) ([]*po.InboxEventRecord, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	rows, err := queries.ListUnstampedInboxEvents(ctx, catalogsql.ListUnstampedInboxEventsParams{
+		EventTypes: eventTypes,
+		Limit:      int32(limit),
+	})
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("list unstamped inbox events failed: types=%v err=%v", eventTypes, err)
+		return nil, fmt.Errorf("list unstamped inbox events: %w", err)
+	}
+	records := make([]*po.InboxEventRecord, 0, len(rows))
+	for _, row := range rows {
+		records = append(records, &po.InboxEventRecord{
+			EventID:    row.EventID,
+			EventType:  row.EventType,
+			Payload:    row.Payload,
+			ReceivedAt: row.ReceivedAt.Time,
+		})
+	}
+	return records, nil
+}
+
+// StampOccurredAt 批量回写 Inbox 事件的发生时间，已标注的事件保持不变。
+func (r *EngagementReplayRepository) StampOccurredAt(ctx context.Context, sess txmanager.Session, stamps []InboxOccurrence) error {
+	if len(stamps) == 0 {
+		return nil
+	}
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	params := catalogsql.StampInboxEventsOccurredAtParams{
+		EventIds:    make([]uuid.UUID, 0, len(stamps)),
+		OccurredAts: make([]pgtype.Timestamptz, 0, len(stamps)),
+	}
+	for _, stamp := range stamps {
+		params.EventIds = append(params.EventIds, stamp.EventID)
+		params.OccurredAts = append(params.OccurredAts, pgtype.Timestamptz{Time: stamp.OccurredAt.UTC(), Valid: true})
+	}
+	if err := queries.StampInboxEventsOccurredAt(ctx, params); err != nil {
+		r.log.WithContext(ctx).Errorf("stamp inbox occurred_at failed: count=%d err=%v", len(stamps), err)
+		return fmt.Errorf("stamp inbox occurred_at: %w", err)
+	}
+	return nil
+}
+
+// GetCheckpoint 读取重放检查点，不存在时返回 ErrReplayCheckpointNotFound。
+func (r *EngagementReplayRepository) GetCheckpoint(ctx context.Context, sess txmanager.Session, replayID string) (*po.EngagementReplayCheckpoint, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	row, err := queries.GetEngagementReplayCheckpoint(ctx, replayID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrReplayCheckpointNotFound
+		}
+		r.log.WithContext(ctx).Errorf("get replay checkpoint failed: replay_id=%s err=%v", replayID, err)
+		return nil, fmt.Errorf("get replay checkpoint: %w", err)
+	}
+	return mappers.EngagementReplayCheckpointFromCatalog(row), nil
+}
+
+// CreateCheckpoint 登记新的重放任务。
+func (r *EngagementReplayRepository) CreateCheckpoint(ctx context.Context, sess txmanager.Session, replayID string, scope ReplayScope) error {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	if err := queries.CreateEngagementReplayCheckpoint(ctx, catalogsql.CreateEngagementReplayCheckpointParams{
+		ReplayID:     replayID,
+		ScopeUserID:  mappers.ToPgUUID(scope.UserID),
+		ScopeVideoID: mappers.ToPgUUID(scope.VideoID),
+	}); err != nil {
+		r.log.WithContext(ctx).Errorf("create replay checkpoint failed: replay_id=%s err=%v", replayID, err)
+		return fmt.Errorf("create replay checkpoint: %w", err)
+	}
+	return nil
+}
+
+// AdvanceCheckpoint 推进检查点至最后一条已重放事件，cursor 即续跑游标。
+func (r *EngagementReplayRepository) AdvanceCheckpoint(ctx context.Context, sess txmanager.Session, replayID string, cursor InboxCursor, applied, skipped int64) error {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	if err := queries.AdvanceEngagementReplayCheckpoint(ctx, catalogsql.AdvanceEngagementReplayCheckpointParams{
+		ReplayID:       replayID,
+		LastOccurredAt: mappers.ToPgTimestamptz(&cursor.OccurredAt),
+		LastEventID:    mappers.ToPgUUID(&cursor.EventID),
+		AppliedCount:   applied,
+		SkippedCount:   skipped,
+	}); err != nil {
+		r.log.WithContext(ctx).Errorf("advance replay checkpoint failed: replay_id=%s err=%v", replayID, err)
+		return fmt.Errorf("advance replay checkpoint: %w", err)
+	}
+	return nil
+}
+
+// CompleteCheckpoint 标记重放完成。
+func (r *EngagementReplayRepository) CompleteCheckpoint(ctx context.Context, sess txmanager.Session, replayID string) error {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	if err := queries.CompleteEngagementReplayCheckpoint(ctx, replayID); err != nil {
+		r.log.WithContext(ctx).Errorf("complete replay checkpoint failed: replay_id=%s err=%v", replayID, err)
+		return fmt.Errorf("complete replay checkpoint: %w", err)
+	}
+	return nil
+}
+
+// DeleteCheckpoint 删除重放检查点，用于强制从头重放。
+func (r *EngagementReplayRepository) DeleteCheckpoint(ctx context.Context, sess txmanager.Session, replayID string) error {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	if err := queries.DeleteEngagementReplayCheckpoint(ctx, replayID); err != nil {
+		r.log.WithContext(ctx).Errorf("delete replay checkpoint failed: replay_id=%s err=%v", replayID, err)
+		return fmt.Errorf("delete replay checkpoint: %w", err)
+	}
+	return nil
+}
+
+// ResetProjection 清空范围内的用户互动状态；includeStats 为 true 时同时清空视频统计（含分片计数）、唯一观看者、播放会话与分时统计桶。
+// 统计按视频聚合，仅在全量或按视频重放时才能安全重建。
+func (r *EngagementReplayRepository) ResetProjection(ctx context.Context, sess txmanager.Session, scope ReplayScope, includeStats bool) error {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	if err := queries.DeleteVideoUserStatesInScope(ctx, catalogsql.DeleteVideoUserStatesInScopeParams{
+		UserID:  mappers.ToPgUUID(scope.UserID),
+		VideoID: mappers.ToPgUUID(scope.VideoID),
+	}); err != nil {
+		r.log.WithContext(ctx).Errorf("reset video_user_states failed: err=%v", err)
+		return fmt.Errorf("reset video_user_states: %w", err)
+	}
+	if !includeStats {
+		return nil
+	}
+	videoID := mappers.ToPgUUID(scope.VideoID)
+	if err := queries.DeleteVideoEngagementStatsInScope(ctx, videoID); err != nil {
+		r.log.WithContext(ctx).Errorf("reset video_engagement_stats failed: err=%v", err)
+		return fmt.Errorf("reset video_engagement_stats: %w", err)
+	}
+	if err := queries.DeleteVideoEngagementStatsShardsInScope(ctx, videoID); err != nil {
+		r.log.WithContext(ctx).Errorf("reset video_engagement_stats_shards failed: err=%v", err)
+		return fmt.Errorf("reset video_engagement_stats_shards: %w", err)
+	}
+	if err := queries.DeleteVideoEngagementWatchersInScope(ctx, videoID); err != nil {
+		r.log.WithContext(ctx).Errorf("reset video_engagement_watchers failed: err=%v", err)
+		return fmt.Errorf("reset video_engagement_watchers: %w", err)
+	}
+	if err := queries.DeleteVideoViewSessionsInScope(ctx, videoID); err != nil {
+		r.log.WithContext(ctx).Errorf("reset video_view_sessions failed: err=%v", err)
+		return fmt.Errorf("reset video_view_sessions: %w", err)
+	}
+	if err := queries.DeleteVideoEngagementStatsHourlyInScope(ctx, videoID); err != nil {
+		r.log.WithContext(ctx).Errorf("reset video_engagement_stats_hourly failed: err=%v", err)
+		return fmt.Errorf("reset video_engagement_stats_hourly: %w", err)
+	}
+	if err := queries.DeleteVideoEngagementStatsDailyInScope(ctx, videoID); err != nil {
+		r.log.WithContext(ctx).Errorf("reset video_engagement_stats_daily failed: err=%v", err)
+		return fmt.Errorf("reset video_engagement_stats_daily: %w", err)
+	}
+	return nil
+}
+
+// ListUserStates 返回范围内的用户互动状态，用于 dry-run 差异对比。
+func (r *EngagementReplayRepository) ListUserStates(ctx context.Context, sess txmanager.Session, scope ReplayScope) ([]*po.VideoUserState, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	rows, err := queries.ListVideoUserStatesInScope(ctx, catalogsql.ListVideoUserStatesInScopeParams{
+		UserID:  mappers.ToPgUUID(scope.UserID),
+		VideoID: mappers.ToPgUUID(scope.VideoID),
+	})
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("list video_user_states failed: err=%v", err)
+		return nil, fmt.Errorf("list video_user_states: %w", err)
+	}
+	states := make([]*po.VideoUserState, 0, len(rows))
+	for _, row := range rows {
+		states = append(states, mappers.VideoUserStateFromCatalog(row))
+	}
+	return states, nil
+}
+
+// ListStats 返回范围内的视频统计，用于 dry-run 差异对比。
+func (r *EngagementReplayRepository) ListStats(ctx context.Context, sess txmanager.Session, videoID *uuid.UUID) ([]*po.VideoEngagementStatsProjection, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	rows, err := queries.ListVideoEngagementStatsInScope(ctx, mappers.ToPgUUID(videoID))
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("list video_engagement_stats failed: err=%v", err)
+		return nil, fmt.Errorf("list video_engagement_stats: %w", err)
+	}
+	stats := make([]*po.VideoEngagementStatsProjection, 0, len(rows))
+	for _, row := range rows {
+		stats = append(stats, mappers.VideoEngagementStatsFromRow(catalogsql.CatalogVideoEngagementStatsProjection(row)))
+	}
+	return stats, nil
+}
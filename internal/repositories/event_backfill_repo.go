@@ -0,0 +1,161 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories/mappers"
+	catalogsql "github.com/bionicotaku/lingo-services-catalog/internal/repositories/sqlc"
+
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrBackfillCheckpointNotFound 表示事件回填检查点不存在。
+var ErrBackfillCheckpointNotFound = errors.New("event backfill checkpoint not found")
+
+// EventBackfillRepository 提供事件回填所需的视频键集扫描与检查点读写。
+type EventBackfillRepository struct {
+	db      *pgxpool.Pool
+	queries *catalogsql.Queries
+	log     *log.Helper
+}
+
+// NewEventBackfillRepository 构造 EventBackfillRepository。
+func NewEventBackfillRepository(db *pgxpool.Pool, logger log.Logger) *EventBackfillRepository {
+	return &EventBackfillRepository{
+		db:      db,
+		queries: catalogsql.New(db),
+		log:     log.NewHelper(logger),
+	}
+}
+
+// BackfillScope 限定回填的视频范围；字段均为空表示全量回填。
+type BackfillScope struct {
+	Statuses         []po.VideoStatus
+	VisibilityStatus *string
+	UploaderID       *uuid.UUID
+}
+
+// ListVideos 按 video_id 升序分页读取范围内视频的完整快照；after 为空时从头开始。
+func (r *EventBackfillRepository) ListVideos(ctx context.Context, sess txmanager.Session, scope BackfillScope, after *uuid.UUID, limit int) ([]*po.Video, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	rows, err := queries.ListVideosForBackfill(ctx, catalogsql.ListVideosForBackfillParams{
+		AfterVideoID:     mappers.ToPgUUID(after),
+		Statuses:         statusStrings(scope.Statuses),
+		VisibilityStatus: mappers.ToPgText(scope.VisibilityStatus),
+		UploadUserID:     mappers.ToPgUUID(scope.UploaderID),
+		Limit:            int32(limit),
+	})
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("list videos for backfill failed: after=%v err=%v", after, err)
+		return nil, fmt.Errorf("list videos for backfill: %w", err)
+	}
+	videos := make([]*po.Video, 0, len(rows))
+	for _, row := range rows {
+		videos = append(videos, mappers.VideoFromCatalog(row))
+	}
+	return videos, nil
+}
+
+// GetCheckpoint 读取回填检查点，不存在时返回 ErrBackfillCheckpointNotFound。
+func (r *EventBackfillRepository) GetCheckpoint(ctx context.Context, sess txmanager.Session, backfillID string) (*po.EventBackfillCheckpoint, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	row, err := queries.GetEventBackfillCheckpoint(ctx, backfillID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBackfillCheckpointNotFound
+		}
+		r.log.WithContext(ctx).Errorf("get backfill checkpoint failed: backfill_id=%s err=%v", backfillID, err)
+		return nil, fmt.Errorf("get backfill checkpoint: %w", err)
+	}
+	return mappers.EventBackfillCheckpointFromCatalog(row), nil
+}
+
+// CreateCheckpoint 登记新的回填任务并返回检查点。
+func (r *EventBackfillRepository) CreateCheckpoint(ctx context.Context, sess txmanager.Session, backfillID, target string, scope BackfillScope) (*po.EventBackfillCheckpoint, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	row, err := queries.CreateEventBackfillCheckpoint(ctx, catalogsql.CreateEventBackfillCheckpointParams{
+		BackfillID:      backfillID,
+		Target:          target,
+		ScopeStatuses:   statusStrings(scope.Statuses),
+		ScopeVisibility: mappers.ToPgText(scope.VisibilityStatus),
+		ScopeUploaderID: mappers.ToPgUUID(scope.UploaderID),
+	})
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("create backfill checkpoint failed: backfill_id=%s err=%v", backfillID, err)
+		return nil, fmt.Errorf("create backfill checkpoint: %w", err)
+	}
+	return mappers.EventBackfillCheckpointFromCatalog(row), nil
+}
+
+// AdvanceCheckpoint 推进检查点至最后一个已发布快照的视频。
+func (r *EventBackfillRepository) AdvanceCheckpoint(ctx context.Context, sess txmanager.Session, backfillID string, lastVideoID uuid.UUID, published int64) error {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	if err := queries.AdvanceEventBackfillCheckpoint(ctx, catalogsql.AdvanceEventBackfillCheckpointParams{
+		BackfillID:     backfillID,
+		LastVideoID:    mappers.ToPgUUID(&lastVideoID),
+		PublishedCount: published,
+	}); err != nil {
+		r.log.WithContext(ctx).Errorf("advance backfill checkpoint failed: backfill_id=%s err=%v", backfillID, err)
+		return fmt.Errorf("advance backfill checkpoint: %w", err)
+	}
+	return nil
+}
+
+// CompleteCheckpoint 标记回填完成。
+func (r *EventBackfillRepository) CompleteCheckpoint(ctx context.Context, sess txmanager.Session, backfillID string) error {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	if err := queries.CompleteEventBackfillCheckpoint(ctx, backfillID); err != nil {
+		r.log.WithContext(ctx).Errorf("complete backfill checkpoint failed: backfill_id=%s err=%v", backfillID, err)
+		return fmt.Errorf("complete backfill checkpoint: %w", err)
+	}
+	return nil
+}
+
+// DeleteCheckpoint 删除回填检查点，用于强制从头回填。
+func (r *EventBackfillRepository) DeleteCheckpoint(ctx context.Context, sess txmanager.Session, backfillID string) error {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	if err := queries.DeleteEventBackfillCheckpoint(ctx, backfillID); err != nil {
+		r.log.WithContext(ctx).Errorf("delete backfill checkpoint failed: backfill_id=%s err=%v", backfillID, err)
+		return fmt.Errorf("delete backfill checkpoint: %w", err)
+	}
+	return nil
+}
+
+func statusStrings(statuses []po.VideoStatus) []string {
+	out := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		out = append(out, string(status))
+	}
+	return out
+}
@@ -0,0 +1,171 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories/mappers"
+	catalogsql "github.com/bionicotaku/lingo-services-catalog/internal/repositories/sqlc"
+
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrInboxQuarantineNotFound 表示隔离事件不存在，或属于其他消费者。
+var ErrInboxQuarantineNotFound = errors.New("inbox quarantine entry not found")
+
+// ErrInboxQuarantineResolved 表示隔离事件已处置（重放或丢弃）。
+var ErrInboxQuarantineResolved = errors.New("inbox quarantine entry already resolved")
+
+// InboxQuarantineRepository 读写 catalog.inbox_quarantine，记录 Inbox 消费者隔离的毒消息及其处置结果。
+type InboxQuarantineRepository struct {
+	db      *pgxpool.Pool
+	queries *catalogsql.Queries
+	log     *log.Helper
+}
+
+// NewInboxQuarantineRepository 构造 InboxQuarantineRepository。
+func NewInboxQuarantineRepository(db *pgxpool.Pool, logger log.Logger) *InboxQuarantineRepository {
+	return &InboxQuarantineRepository{
+		db:      db,
+		queries: catalogsql.New(db),
+		log:     log.NewHelper(logger),
+	}
+}
+
+// QuarantineInboxEventInput 描述一次隔离登记。
+type QuarantineInboxEventInput struct {
+	Consumer   string
+	Event      InboxEvent
+	ErrorClass string
+	LastError  string
+}
+
+// InboxQuarantineFilter 限定隔离事件列表；ErrorClass 为空表示不过滤，IncludeResolved 为 false 时仅返回待处置记录。
+type InboxQuarantineFilter struct {
+	Consumer        string
+	ErrorClass      *string
+	IncludeResolved bool
+	Limit           int
+}
+
+// Quarantine 在事务内登记隔离事件；同一事件再次隔离时刷新错误信息并重新置为待处置。
+func (r *InboxQuarantineRepository) Quarantine(ctx context.Context, sess txmanager.Session, input QuarantineInboxEventInput) error {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	receivedAt := input.Event.ReceivedAt
+	if err := queries.QuarantineInboxEvent(ctx, catalogsql.QuarantineInboxEventParams{
+		EventID:       input.Event.EventID,
+		Consumer:      input.Consumer,
+		SourceService: input.Event.SourceService,
+		EventType:     input.Event.EventType,
+		AggregateType: mappers.ToPgText(input.Event.AggregateType),
+		AggregateID:   mappers.ToPgText(input.Event.AggregateID),
+		Payload:       input.Event.Payload,
+		ReceivedAt:    mappers.ToPgTimestamptz(&receivedAt),
+		ErrorClass:    input.ErrorClass,
+		LastError:     input.LastError,
+	}); err != nil {
+		r.log.WithContext(ctx).Errorf("quarantine inbox event failed: event=%s err=%v", input.Event.EventID, err)
+		return fmt.Errorf("quarantine inbox event: %w", err)
+	}
+	return nil
+}
+
+// List 按隔离时间升序列出消费者的隔离事件。
+func (r *InboxQuarantineRepository) List(ctx context.Context, sess txmanager.Session, filter InboxQuarantineFilter) ([]*po.InboxQuarantineEntry, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	rows, err := queries.ListInboxQuarantine(ctx, catalogsql.ListInboxQuarantineParams{
+		Consumer:        filter.Consumer,
+		ErrorClass:      mappers.ToPgText(filter.ErrorClass),
+		IncludeResolved: filter.IncludeResolved,
+		Limit:           int32(filter.Limit),
+	})
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("list inbox quarantine failed: consumer=%s err=%v", filter.Consumer, err)
+		return nil, fmt.Errorf("list inbox quarantine: %w", err)
+	}
+	entries := make([]*po.InboxQuarantineEntry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, mappers.InboxQuarantineEntryFromCatalog(row))
+	}
+	return entries, nil
+}
+
+// Get 读取并锁定隔离事件，不存在时返回 ErrInboxQuarantineNotFound。
+func (r *InboxQuarantineRepository) Get(ctx context.Context, sess txmanager.Session, consumer string, eventID uuid.UUID) (*po.InboxQuarantineEntry, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	row, err := queries.GetInboxQuarantine(ctx, catalogsql.GetInboxQuarantineParams{
+		EventID:  eventID,
+		Consumer: consumer,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrInboxQuarantineNotFound
+		}
+		r.log.WithContext(ctx).Errorf("get inbox quarantine failed: event=%s err=%v", eventID, err)
+		return nil, fmt.Errorf("get inbox quarantine: %w", err)
+	}
+	return mappers.InboxQuarantineEntryFromCatalog(row), nil
+}
+
+// Resolve 将待处置的隔离事件标记为已重放或已丢弃；记录不存在或已处置时返回 ErrInboxQuarantineResolved。
+func (r *InboxQuarantineRepository) Resolve(ctx context.Context, sess txmanager.Session, consumer string, eventID uuid.UUID, resolution po.InboxQuarantineResolution, note string) error {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	params := catalogsql.ResolveInboxQuarantineParams{
+		EventID:    eventID,
+		Consumer:   consumer,
+		Resolution: pgtype.Text{String: string(resolution), Valid: true},
+	}
+	if note != "" {
+		params.ResolvedNote = pgtype.Text{String: note, Valid: true}
+	}
+	rows, err := queries.ResolveInboxQuarantine(ctx, params)
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("resolve inbox quarantine failed: event=%s err=%v", eventID, err)
+		return fmt.Errorf("resolve inbox quarantine: %w", err)
+	}
+	if rows == 0 {
+		return ErrInboxQuarantineResolved
+	}
+	return nil
+}
+
+// RecordReplayFailure 累加人工重放失败次数并记录最近一次错误。
+func (r *InboxQuarantineRepository) RecordReplayFailure(ctx context.Context, sess txmanager.Session, consumer string, eventID uuid.UUID, lastErr string) error {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	if err := queries.RecordInboxQuarantineReplayFailure(ctx, catalogsql.RecordInboxQuarantineReplayFailureParams{
+		EventID:   eventID,
+		Consumer:  consumer,
+		LastError: lastErr,
+	}); err != nil {
+		r.log.WithContext(ctx).Errorf("record inbox quarantine replay failure failed: event=%s err=%v", eventID, err)
+		return fmt.Errorf("record inbox quarantine replay failure: %w", err)
+	}
+	return nil
+}
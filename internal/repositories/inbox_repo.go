@@ -2,8 +2,11 @@ package repositories
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	catalogsql "github.com/bionicotaku/lingo-services-catalog/internal/repositories/sqlc"
+
 	outboxpkg "github.com/bionicotaku/lingo-utils/outbox"
 	outboxcfg "github.com/bionicotaku/lingo-utils/outbox/config"
 	"github.com/bionicotaku/lingo-utils/outbox/store"
@@ -22,6 +25,7 @@ type InboxEvent = store.InboxEvent
 // InboxRepository 封装共享 Inbox 仓储实现。
 type InboxRepository struct {
 	delegate *store.Repository
+	queries  *catalogsql.Queries
 }
 
 // NewInboxRepository 构建 Inbox 仓储，内部复用 lingo-utils/outbox 仓储。
@@ -29,9 +33,9 @@ func NewInboxRepository(db *pgxpool.Pool, logger log.Logger, cfg outboxcfg.Confi
 	storeRepo, err := outboxpkg.NewRepository(db, logger, outboxpkg.RepositoryOptions{Schema: cfg.Schema})
 	if err != nil {
 		log.NewHelper(logger).Errorw("msg", "init inbox repository failed", "error", err)
-		return &InboxRepository{delegate: store.NewRepository(db, logger)}
+		return &InboxRepository{delegate: store.NewRepository(db, logger), queries: catalogsql.New(db)}
 	}
-	return &InboxRepository{delegate: storeRepo}
+	return &InboxRepository{delegate: storeRepo, queries: catalogsql.New(db)}
 }
 
 // Insert 在事务内记录 Inbox 事件。
@@ -49,6 +53,81 @@ func (r *InboxRepository) RecordError(ctx context.Context, sess txmanager.Sessio
 	return r.delegate.RecordInboxError(ctx, sess, eventID, lastErr)
 }
 
+// InsertBatch 在事务内以单条多行语句登记一批 Inbox 事件，已存在的事件保持不变。
+func (r *InboxRepository) InsertBatch(ctx context.Context, sess txmanager.Session, sourceService string, events []InboxMessage) error {
+	if len(events) == 0 {
+		return nil
+	}
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	params := catalogsql.RecordInboxEventsParams{
+		EventIds:       make([]uuid.UUID, 0, len(events)),
+		SourceService:  sourceService,
+		EventTypes:     make([]string, 0, len(events)),
+		AggregateTypes: make([]string, 0, len(events)),
+		AggregateIds:   make([]string, 0, len(events)),
+		Payloads:       make([][]byte, 0, len(events)),
+	}
+	for _, evt := range events {
+		params.EventIds = append(params.EventIds, evt.EventID)
+		params.EventTypes = append(params.EventTypes, evt.EventType)
+		var aggregateType, aggregateID string
+		if evt.AggregateType != nil {
+			aggregateType = *evt.AggregateType
+		}
+		if evt.AggregateID != nil {
+			aggregateID = *evt.AggregateID
+		}
+		params.AggregateTypes = append(params.AggregateTypes, aggregateType)
+		params.AggregateIds = append(params.AggregateIds, aggregateID)
+		params.Payloads = append(params.Payloads, evt.Payload)
+	}
+	if err := queries.RecordInboxEvents(ctx, params); err != nil {
+		return fmt.Errorf("record inbox events: %w", err)
+	}
+	return nil
+}
+
+// LockUnprocessed 锁定给定事件中尚未处理成功的记录，返回其接收时间；未返回的事件已处理过，属于重复投递。
+func (r *InboxRepository) LockUnprocessed(ctx context.Context, sess txmanager.Session, eventIDs []uuid.UUID) (map[uuid.UUID]time.Time, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	rows, err := queries.LockUnprocessedInboxEvents(ctx, eventIDs)
+	if err != nil {
+		return nil, fmt.Errorf("lock unprocessed inbox events: %w", err)
+	}
+	pending := make(map[uuid.UUID]time.Time, len(rows))
+	for _, row := range rows {
+		pending[row.EventID] = row.ReceivedAt.Time.UTC()
+	}
+	return pending, nil
+}
+
+// MarkBatchProcessed 批量标记事件处理成功。
+func (r *InboxRepository) MarkBatchProcessed(ctx context.Context, sess txmanager.Session, eventIDs []uuid.UUID, processedAt time.Time) error {
+	if len(eventIDs) == 0 {
+		return nil
+	}
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	if err := queries.MarkInboxEventsProcessed(ctx, catalogsql.MarkInboxEventsProcessedParams{
+		ProcessedAt: toPgTimestamptz(&processedAt),
+		EventIds:    eventIDs,
+	}); err != nil {
+		return fmt.Errorf("mark inbox events processed: %w", err)
+	}
+	return nil
+}
+
 // Shared 暴露底层共享仓储，供 inbox runner 使用。
 func (r *InboxRepository) Shared() *store.Repository {
 	return r.delegate
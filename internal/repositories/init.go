@@ -11,4 +11,13 @@ var ProviderSet = wire.NewSet(
 	NewVideoUserStatesRepository,
 	NewVideoEngagementStatsRepository,
 	NewUploadRepository,
+	NewRawAssetRepository,
+	NewEngagementReplayRepository,
+	NewEngagementPurgeRepository,
+	NewInboxQuarantineRepository,
+	NewRetentionRepository,
+	NewWebhookDeliveryRepository,
+	NewOutboxTopicDeliveryRepository,
+	NewEventBackfillRepository,
+	NewAdvisoryLockRepository,
 )
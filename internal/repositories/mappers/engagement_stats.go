@@ -16,6 +16,21 @@ func VideoEngagementStatsFromRow(row catalogsql.CatalogVideoEngagementStatsProje
 		FirstWatchAt:   timestampPtr(row.FirstWatchAt),
 		LastWatchAt:    timestampPtr(row.LastWatchAt),
 		UpdatedAt:      mustTimestamp(row.UpdatedAt),
+
+		TotalWatchSeconds:  row.TotalWatchSeconds,
+		PositionSumSeconds: row.PositionSumSeconds,
+		PositionViewers:    row.PositionViewers,
+
+		ShareCount:  row.ShareCount,
+		RatingCount: row.RatingCount,
+		RatingSum:   row.RatingSum,
+		RatingDistribution: [5]int64{
+			row.Rating1Count,
+			row.Rating2Count,
+			row.Rating3Count,
+			row.Rating4Count,
+			row.Rating5Count,
+		},
 	}
 }
 
@@ -29,3 +44,154 @@ func VideoWatcherRecordFromRow(row catalogsql.UpsertVideoWatcherRow) *po.VideoWa
 		Inserted:       row.Inserted,
 	}
 }
+
+// VideoViewSessionFromLockRow 转换加锁读取的播放会话记录。
+func VideoViewSessionFromLockRow(row catalogsql.LockVideoViewSessionRow) *po.VideoViewSession {
+	return &po.VideoViewSession{
+		VideoID:              row.VideoID,
+		UserID:               row.UserID,
+		SessionStartedAt:     mustTimestamp(row.SessionStartedAt),
+		LastProgressAt:       mustTimestamp(row.LastProgressAt),
+		BaselineWatchSeconds: row.BaselineWatchSeconds,
+		LastWatchSeconds:     row.LastWatchSeconds,
+		CountedAt:            timestampPtr(row.CountedAt),
+		UpdatedAt:            mustTimestamp(row.UpdatedAt),
+		MaxPositionSeconds:   row.MaxPositionSeconds,
+		LastPositionSeconds:  row.LastPositionSeconds,
+		PositionTracked:      row.PositionTracked,
+	}
+}
+
+// VideoWatchProgressFromRow 转换最近播放位置查询结果。
+func VideoWatchProgressFromRow(row catalogsql.GetVideoWatchProgressRow) *po.VideoWatchProgress {
+	return &po.VideoWatchProgress{
+		VideoID:             row.VideoID,
+		UserID:              row.UserID,
+		LastPositionSeconds: row.LastPositionSeconds,
+		LastWatchedAt:       mustTimestamp(row.LastProgressAt),
+	}
+}
+
+// WatchHistoryEntryFromRow 转换观看历史查询结果。
+func WatchHistoryEntryFromRow(row catalogsql.ListUserWatchHistoryRow) po.WatchHistoryEntry {
+	return po.WatchHistoryEntry{
+		VideoID:             row.VideoID,
+		Title:               row.Title,
+		Status:              row.Status,
+		MediaStatus:         row.MediaStatus,
+		AnalysisStatus:      row.AnalysisStatus,
+		DurationMicros:      int8Ptr(row.DurationMicros),
+		LastPositionSeconds: row.LastPositionSeconds,
+		LastWatchedAt:       mustTimestamp(row.LastProgressAt),
+	}
+}
+
+// VideoStatsBucketFromHourlyRow 将小时统计桶行转换为 po.VideoStatsBucket。
+func VideoStatsBucketFromHourlyRow(row catalogsql.ListVideoEngagementStatsHourlyRow) po.VideoStatsBucket {
+	return po.VideoStatsBucket{
+		BucketStart:    mustTimestamp(row.BucketStart).UTC(),
+		LikeDelta:      row.LikeDelta,
+		BookmarkDelta:  row.BookmarkDelta,
+		WatchCount:     row.WatchCount,
+		UniqueWatchers: row.UniqueWatchers,
+	}
+}
+
+// VideoStatsBucketFromDailyRow 将天级统计桶行转换为 po.VideoStatsBucket。
+func VideoStatsBucketFromDailyRow(row catalogsql.ListVideoEngagementStatsDailyRow) po.VideoStatsBucket {
+	return po.VideoStatsBucket{
+		BucketStart:    mustTimestamp(row.BucketStart).UTC(),
+		LikeDelta:      row.LikeDelta,
+		BookmarkDelta:  row.BookmarkDelta,
+		WatchCount:     row.WatchCount,
+		UniqueWatchers: row.UniqueWatchers,
+	}
+}
+
+// VideoTrendingSnapshotFromCatalog 转换热度快照记录。
+func VideoTrendingSnapshotFromCatalog(row catalogsql.CatalogVideoTrendingSnapshot) *po.VideoTrendingSnapshot {
+	return &po.VideoTrendingSnapshot{
+		SnapshotID:      row.SnapshotID,
+		ComputedAt:      mustTimestamp(row.ComputedAt),
+		WindowStart:     mustTimestamp(row.WindowStart),
+		HalfLifeSeconds: row.HalfLifeSeconds,
+		MaxVideos:       row.MaxVideos,
+	}
+}
+
+// TrendingVideoEntryFromRow 转换热度榜分页查询结果。
+func TrendingVideoEntryFromRow(row catalogsql.ListVideoTrendingScoresRow) po.TrendingVideoEntry {
+	return po.TrendingVideoEntry{
+		VideoID:        row.VideoID,
+		Rank:           row.Rank,
+		Score:          row.Score,
+		Title:          row.Title,
+		DurationMicros: int8Ptr(row.DurationMicros),
+		Difficulty:     textPtr(row.Difficulty),
+		Tags:           row.Tags,
+		CreatedAt:      mustTimestamp(row.CreatedAt),
+	}
+}
+
+// VideoUserStateFromCatalog 转换用户互动状态投影行。
+func VideoUserStateFromCatalog(row catalogsql.CatalogVideoUserEngagementsProjection) *po.VideoUserState {
+	return &po.VideoUserState{
+		UserID:               row.UserID,
+		VideoID:              row.VideoID,
+		HasLiked:             row.HasLiked,
+		HasBookmarked:        row.HasBookmarked,
+		LikedOccurredAt:      timestampPtr(row.LikedOccurredAt),
+		BookmarkedOccurredAt: timestampPtr(row.BookmarkedOccurredAt),
+		LikedSequence:        int8Ptr(row.LikedSequence),
+		BookmarkedSequence:   int8Ptr(row.BookmarkedSequence),
+		Rating:               int4Ptr(row.Rating),
+		RatedOccurredAt:      timestampPtr(row.RatedOccurredAt),
+		RatedSequence:        int8Ptr(row.RatedSequence),
+		UpdatedAt:            mustTimestamp(row.UpdatedAt),
+	}
+}
+
+// EngagementReplayCheckpointFromCatalog 转换重放检查点记录。
+func EngagementReplayCheckpointFromCatalog(row catalogsql.CatalogEngagementReplayCheckpoint) *po.EngagementReplayCheckpoint {
+	return &po.EngagementReplayCheckpoint{
+		ReplayID:       row.ReplayID,
+		ScopeUserID:    uuidPtr(row.ScopeUserID),
+		ScopeVideoID:   uuidPtr(row.ScopeVideoID),
+		LastOccurredAt: timestampPtr(row.LastOccurredAt),
+		LastEventID:    uuidPtr(row.LastEventID),
+		AppliedCount:   row.AppliedCount,
+		SkippedCount:   row.SkippedCount,
+		StartedAt:      mustTimestamp(row.StartedAt),
+		UpdatedAt:      mustTimestamp(row.UpdatedAt),
+		CompletedAt:    timestampPtr(row.CompletedAt),
+		LastReceivedAt: timestampPtr(row.LastReceivedAt),
+	}
+}
+
+// VideoEngagementStatsDriftFromRow 转换对账扫描结果。
+func VideoEngagementStatsDriftFromRow(row catalogsql.ListEngagementStatsDriftRow) *po.VideoEngagementStatsDrift {
+	return &po.VideoEngagementStatsDrift{
+		VideoID:              row.VideoID,
+		StoredLikeCount:      row.StoredLikeCount,
+		StoredBookmarkCount:  row.StoredBookmarkCount,
+		StoredUniqueWatchers: row.StoredUniqueWatchers,
+		ActualLikeCount:      row.ActualLikeCount,
+		ActualBookmarkCount:  row.ActualBookmarkCount,
+		ActualUniqueWatchers: row.ActualUniqueWatchers,
+	}
+}
+
+// EngagementPurgeFromCatalog 转换清理请求记录。
+func EngagementPurgeFromCatalog(row catalogsql.CatalogEngagementPurge) *po.EngagementPurge {
+	return &po.EngagementPurge{
+		Scope:         po.EngagementPurgeScope(row.Scope),
+		SubjectID:     row.SubjectID,
+		Reason:        row.Reason,
+		RequestedAt:   mustTimestamp(row.RequestedAt),
+		SourceEventID: uuidPtr(row.SourceEventID),
+		RowsRemoved:   row.RowsRemoved,
+		CreatedAt:     mustTimestamp(row.CreatedAt),
+		UpdatedAt:     mustTimestamp(row.UpdatedAt),
+		CompletedAt:   timestampPtr(row.CompletedAt),
+	}
+}
@@ -0,0 +1,46 @@
+package mappers
+
+import (
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	catalogsql "github.com/bionicotaku/lingo-services-catalog/internal/repositories/sqlc"
+)
+
+// InboxQuarantineEntryFromCatalog 转换隔离事件记录。
+func InboxQuarantineEntryFromCatalog(row catalogsql.CatalogInboxQuarantine) *po.InboxQuarantineEntry {
+	entry := &po.InboxQuarantineEntry{
+		EventID:        row.EventID,
+		Consumer:       row.Consumer,
+		SourceService:  row.SourceService,
+		EventType:      row.EventType,
+		AggregateType:  textPtr(row.AggregateType),
+		AggregateID:    textPtr(row.AggregateID),
+		Payload:        row.Payload,
+		ReceivedAt:     mustTimestamp(row.ReceivedAt),
+		ErrorClass:     row.ErrorClass,
+		LastError:      row.LastError,
+		QuarantinedAt:  mustTimestamp(row.QuarantinedAt),
+		ReplayAttempts: row.ReplayAttempts,
+		ResolvedAt:     timestampPtr(row.ResolvedAt),
+		ResolvedNote:   textPtr(row.ResolvedNote),
+	}
+	if row.Resolution.Valid {
+		resolution := po.InboxQuarantineResolution(row.Resolution.String)
+		entry.Resolution = &resolution
+	}
+	return entry
+}
+
+// InboxEntryFromPruneRow 转换保留期清理删除的 Inbox 行。
+func InboxEntryFromPruneRow(row catalogsql.PruneInboxEventsRow) *po.InboxEntry {
+	return &po.InboxEntry{
+		EventID:       row.EventID,
+		SourceService: row.SourceService,
+		EventType:     row.EventType,
+		AggregateType: textPtr(row.AggregateType),
+		AggregateID:   textPtr(row.AggregateID),
+		Payload:       row.Payload,
+		ReceivedAt:    mustTimestamp(row.ReceivedAt),
+		ProcessedAt:   timestampPtr(row.ProcessedAt),
+		LastError:     textPtr(row.LastError),
+	}
+}
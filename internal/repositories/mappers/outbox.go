@@ -0,0 +1,89 @@
+package mappers
+
+import (
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	catalogsql "github.com/bionicotaku/lingo-services-catalog/internal/repositories/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// OutboxEntryFromCatalog 转换 Outbox 事件记录；available_at 为 infinity（已放弃）时 AvailableAt 为 nil。
+func OutboxEntryFromCatalog(row catalogsql.CatalogOutboxEvent) *po.OutboxEntry {
+	entry := &po.OutboxEntry{
+		EventID:          row.EventID,
+		AggregateType:    row.AggregateType,
+		AggregateID:      row.AggregateID,
+		EventType:        row.EventType,
+		Payload:          row.Payload,
+		Headers:          row.Headers,
+		OccurredAt:       mustTimestamp(row.OccurredAt),
+		PublishedAt:      timestampPtr(row.PublishedAt),
+		DeliveryAttempts: row.DeliveryAttempts,
+		LastError:        textPtr(row.LastError),
+		LockToken:        textPtr(row.LockToken),
+		LockedAt:         timestampPtr(row.LockedAt),
+		AbandonedAt:      timestampPtr(row.AbandonedAt),
+		AbandonedReason:  textPtr(row.AbandonedReason),
+	}
+	if row.AvailableAt.InfinityModifier == pgtype.Finite {
+		entry.AvailableAt = timestampPtr(row.AvailableAt)
+	}
+	return entry
+}
+
+// OutboxEntryFromPruneRow 转换保留期清理删除的 Outbox 行；已删除的行不再持有租约，LockToken/LockedAt 为空。
+func OutboxEntryFromPruneRow(row catalogsql.PruneOutboxEventsRow) *po.OutboxEntry {
+	entry := &po.OutboxEntry{
+		EventID:          row.EventID,
+		AggregateType:    row.AggregateType,
+		AggregateID:      row.AggregateID,
+		EventType:        row.EventType,
+		Payload:          row.Payload,
+		Headers:          row.Headers,
+		OccurredAt:       mustTimestamp(row.OccurredAt),
+		PublishedAt:      timestampPtr(row.PublishedAt),
+		DeliveryAttempts: row.DeliveryAttempts,
+		LastError:        textPtr(row.LastError),
+		AbandonedAt:      timestampPtr(row.AbandonedAt),
+		AbandonedReason:  textPtr(row.AbandonedReason),
+	}
+	if row.AvailableAt.InfinityModifier == pgtype.Finite {
+		entry.AvailableAt = timestampPtr(row.AvailableAt)
+	}
+	return entry
+}
+
+// WebhookDeliveryFromCatalog 将 sqlc 生成的投递日志行转换为 po.WebhookDelivery。
+func WebhookDeliveryFromCatalog(row catalogsql.CatalogWebhookDelivery) *po.WebhookDelivery {
+	return &po.WebhookDelivery{
+		ID:          row.ID,
+		EventID:     row.EventID,
+		Endpoint:    row.Endpoint,
+		EventType:   row.EventType,
+		Attempt:     row.Attempt,
+		StatusCode:  int4Ptr(row.StatusCode),
+		Error:       textPtr(row.Error),
+		DurationMs:  row.DurationMs,
+		Succeeded:   row.Succeeded,
+		AttemptedAt: mustTimestamp(row.AttemptedAt),
+	}
+}
+
+// EventBackfillCheckpointFromCatalog 转换事件回填检查点记录。
+func EventBackfillCheckpointFromCatalog(row catalogsql.CatalogEventBackfillCheckpoint) *po.EventBackfillCheckpoint {
+	statuses := make([]po.VideoStatus, 0, len(row.ScopeStatuses))
+	for _, status := range row.ScopeStatuses {
+		statuses = append(statuses, po.VideoStatus(status))
+	}
+	return &po.EventBackfillCheckpoint{
+		BackfillID:      row.BackfillID,
+		Target:          row.Target,
+		ScopeStatuses:   statuses,
+		ScopeVisibility: textPtr(row.ScopeVisibility),
+		ScopeUploaderID: uuidPtr(row.ScopeUploaderID),
+		LastVideoID:     uuidPtr(row.LastVideoID),
+		PublishedCount:  row.PublishedCount,
+		StartedAt:       mustTimestamp(row.StartedAt),
+		UpdatedAt:       mustTimestamp(row.UpdatedAt),
+		CompletedAt:     timestampPtr(row.CompletedAt),
+	}
+}
@@ -78,6 +78,7 @@ func TestBuildUpdateVideoParams(t *testing.T) {
 		now := time.Now().UTC()
 		visibilityStatus := "public"
 		publishAt := now
+		rawMissing := true
 
 		params := mappers.BuildUpdateVideoParams(
 			videoID,
@@ -93,9 +94,12 @@ func TestBuildUpdateVideoParams(t *testing.T) {
 			tags,
 			&visibilityStatus,
 			&publishAt,
+			&rawMissing,
 		)
 
 		assert.Equal(t, videoID, params.VideoID)
+		assert.True(t, params.RawMissing.Valid)
+		assert.True(t, params.RawMissing.Bool)
 		assert.True(t, params.Title.Valid)
 		assert.Equal(t, title, params.Title.String)
 		assert.True(t, params.Description.Valid)
@@ -165,9 +169,11 @@ func TestBuildUpdateVideoParams(t *testing.T) {
 			emptyTags,
 			strPtr,
 			timePtr,
+			nil,
 		)
 
 		assert.Equal(t, videoID, params.VideoID)
+		assert.False(t, params.RawMissing.Valid)
 		assert.False(t, params.Title.Valid)
 		assert.False(t, params.Description.Valid)
 		assert.False(t, params.Status.Valid)
@@ -221,6 +227,7 @@ func TestBuildUpdateVideoParams(t *testing.T) {
 			emptyTags,
 			strPtr,
 			timePtr,
+			nil,
 		)
 
 		assert.Equal(t, videoID, params.VideoID)
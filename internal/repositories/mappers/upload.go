@@ -17,9 +17,11 @@ func UploadSessionFromCatalog(row catalogsql.CatalogUpload) *po.UploadSession {
 		Bucket:             row.Bucket,
 		ObjectName:         row.ObjectName,
 		ContentType:        textPtr(row.ContentType),
+		SniffedContentType: textPtr(row.SniffedContentType),
 		ExpectedSize:       row.ExpectedSize,
 		SizeBytes:          row.SizeBytes,
 		ContentMD5:         row.ContentMd5,
+		ContentSHA256:      textPtr(row.ContentSha256),
 		Title:              row.Title,
 		Description:        row.Description,
 		SignedURL:          textPtr(row.SignedUrl),
@@ -31,6 +33,9 @@ func UploadSessionFromCatalog(row catalogsql.CatalogUpload) *po.UploadSession {
 		CRC32C:             textPtr(row.Crc32c),
 		ErrorCode:          textPtr(row.ErrorCode),
 		ErrorMessage:       textPtr(row.ErrorMessage),
+		AssetID:            uuidPtr(row.AssetID),
+		RawMissing:         row.RawMissing,
+		RawMissingAt:       timestampPtr(row.RawMissingAt),
 		CreatedAt:          mustTimestamp(row.CreatedAt),
 		UpdatedAt:          mustTimestamp(row.UpdatedAt),
 	}
@@ -44,9 +49,11 @@ func UploadSessionFromUpsertRow(row catalogsql.UpsertUploadRow) (*po.UploadSessi
 		Bucket:             row.Bucket,
 		ObjectName:         row.ObjectName,
 		ContentType:        textPtr(row.ContentType),
+		SniffedContentType: textPtr(row.SniffedContentType),
 		ExpectedSize:       row.ExpectedSize,
 		SizeBytes:          row.SizeBytes,
 		ContentMD5:         row.ContentMd5,
+		ContentSHA256:      textPtr(row.ContentSha256),
 		Title:              row.Title,
 		Description:        row.Description,
 		SignedURL:          textPtr(row.SignedUrl),
@@ -58,6 +65,9 @@ func UploadSessionFromUpsertRow(row catalogsql.UpsertUploadRow) (*po.UploadSessi
 		CRC32C:             textPtr(row.Crc32c),
 		ErrorCode:          textPtr(row.ErrorCode),
 		ErrorMessage:       textPtr(row.ErrorMessage),
+		AssetID:            uuidPtr(row.AssetID),
+		RawMissing:         row.RawMissing,
+		RawMissingAt:       timestampPtr(row.RawMissingAt),
 		CreatedAt:          mustTimestamp(row.CreatedAt),
 		UpdatedAt:          mustTimestamp(row.UpdatedAt),
 	}
@@ -73,6 +83,7 @@ func BuildUpsertUploadParams(
 	contentType *string,
 	expectedSize int64,
 	contentMD5 string,
+	contentSHA256 *string,
 	title string,
 	description string,
 	signedURL *string,
@@ -91,6 +102,7 @@ func BuildUpsertUploadParams(
 		SignedUrl:          ToPgText(signedURL),
 		SignedUrlExpiresAt: ToPgTimestamptz(signedURLExpiresAt),
 		Status:             string(po.UploadStatusUploading),
+		ContentSha256:      ToPgText(contentSHA256),
 	}
 }
 
@@ -103,23 +115,84 @@ func BuildMarkUploadCompletedParams(
 	gcsGeneration *string,
 	gcsEtag *string,
 	contentType *string,
+	sniffedContentType *string,
+	assetID *uuid.UUID,
 ) catalogsql.MarkUploadCompletedParams {
 	return catalogsql.MarkUploadCompletedParams{
-		SizeBytes:     sizeBytes,
-		Md5Hash:       ToPgText(md5Hash),
-		Crc32c:        ToPgText(crc32c),
-		GcsGeneration: ToPgText(gcsGeneration),
-		GcsEtag:       ToPgText(gcsEtag),
-		ContentType:   ToPgText(contentType),
-		VideoID:       videoID,
+		SizeBytes:          sizeBytes,
+		Md5Hash:            ToPgText(md5Hash),
+		Crc32c:             ToPgText(crc32c),
+		GcsGeneration:      ToPgText(gcsGeneration),
+		GcsEtag:            ToPgText(gcsEtag),
+		ContentType:        ToPgText(contentType),
+		SniffedContentType: ToPgText(sniffedContentType),
+		AssetID:            ToPgUUID(assetID),
+		VideoID:            videoID,
 	}
 }
 
 // BuildMarkUploadFailedParams 构造 MarkUploadFailed 的参数。
-func BuildMarkUploadFailedParams(videoID uuid.UUID, errorCode, errorMessage *string) catalogsql.MarkUploadFailedParams {
+func BuildMarkUploadFailedParams(videoID uuid.UUID, errorCode, errorMessage, sniffedContentType *string) catalogsql.MarkUploadFailedParams {
 	return catalogsql.MarkUploadFailedParams{
-		ErrorCode:    ToPgText(errorCode),
-		ErrorMessage: ToPgText(errorMessage),
-		VideoID:      videoID,
+		ErrorCode:          ToPgText(errorCode),
+		ErrorMessage:       ToPgText(errorMessage),
+		SniffedContentType: ToPgText(sniffedContentType),
+		VideoID:            videoID,
+	}
+}
+
+// UploadUsageFromCatalog 将 CatalogUploadUsage 转换为领域实体。
+func UploadUsageFromCatalog(row catalogsql.CatalogUploadUsage) *po.UploadUsage {
+	return &po.UploadUsage{
+		UserID:           row.UserID,
+		StoredBytes:      row.StoredBytes,
+		CompletedUploads: row.CompletedUploads,
+		ReconciledAt:     mustTimestamp(row.ReconciledAt),
+		UpdatedAt:        mustTimestamp(row.UpdatedAt),
+	}
+}
+
+// UploadQuotaUsageFromRow 将 GetUploadQuotaUsageRow 转换为配额用量。
+func UploadQuotaUsageFromRow(row catalogsql.GetUploadQuotaUsageRow) *po.UploadQuotaUsage {
+	return &po.UploadQuotaUsage{
+		DailySessions:           row.DailySessions,
+		ConcurrentUploads:       row.ConcurrentUploads,
+		EarliestUploadExpiresAt: timestampPtr(row.EarliestUploadExpiresAt),
+		StoredBytes:             row.StoredBytes,
+	}
+}
+
+// RawAssetFromCatalog 将 CatalogRawAsset 转换为领域实体。
+func RawAssetFromCatalog(row catalogsql.CatalogRawAsset) *po.RawAsset {
+	return &po.RawAsset{
+		AssetID:       row.AssetID,
+		ContentSHA256: row.ContentSha256,
+		ContentMD5:    row.ContentMd5,
+		SizeBytes:     row.SizeBytes,
+		Bucket:        row.Bucket,
+		ObjectName:    row.ObjectName,
+		ContentType:   textPtr(row.ContentType),
+		RefCount:      row.RefCount,
+		CreatedBy:     row.CreatedBy,
+		CreatedAt:     mustTimestamp(row.CreatedAt),
+		UpdatedAt:     mustTimestamp(row.UpdatedAt),
+	}
+}
+
+// RawAssetFromAcquireRow 将 AcquireRawAssetRow 转换为领域实体并返回是否新登记的标记。
+func RawAssetFromAcquireRow(row catalogsql.AcquireRawAssetRow) (*po.RawAsset, bool) {
+	asset := &po.RawAsset{
+		AssetID:       row.AssetID,
+		ContentSHA256: row.ContentSha256,
+		ContentMD5:    row.ContentMd5,
+		SizeBytes:     row.SizeBytes,
+		Bucket:        row.Bucket,
+		ObjectName:    row.ObjectName,
+		ContentType:   textPtr(row.ContentType),
+		RefCount:      row.RefCount,
+		CreatedBy:     row.CreatedBy,
+		CreatedAt:     mustTimestamp(row.CreatedAt),
+		UpdatedAt:     mustTimestamp(row.UpdatedAt),
 	}
+	return asset, row.Inserted
 }
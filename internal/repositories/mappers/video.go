@@ -24,7 +24,7 @@ func BuildCreateVideoParams(uploadUserID uuid.UUID, title, rawFileReference stri
 }
 
 // BuildCreateVideoWithIDParams 构造显式指定 video_id 的插入参数。
-func BuildCreateVideoWithIDParams(videoID uuid.UUID, uploadUserID uuid.UUID, title, rawFileReference string, description, visibilityStatus *string, publishAt *time.Time) catalogsql.CreateVideoWithIDParams {
+func BuildCreateVideoWithIDParams(videoID uuid.UUID, uploadUserID uuid.UUID, title, rawFileReference string, description, visibilityStatus *string, publishAt *time.Time, assetID *uuid.UUID) catalogsql.CreateVideoWithIDParams {
 	return catalogsql.CreateVideoWithIDParams{
 		VideoID:          videoID,
 		UploadUserID:     uploadUserID,
@@ -33,6 +33,7 @@ func BuildCreateVideoWithIDParams(videoID uuid.UUID, uploadUserID uuid.UUID, tit
 		Description:      textFromPtr(description),
 		VisibilityStatus: ToPgText(visibilityStatus),
 		PublishAt:        ToPgTimestamptz(publishAt),
+		AssetID:          ToPgUUID(assetID),
 	}
 }
 
@@ -53,6 +54,7 @@ func BuildUpdateVideoParams(
 	tags []string,
 	visibilityStatus *string,
 	publishAt *time.Time,
+	rawMissing *bool,
 ) catalogsql.UpdateVideoParams {
 	return catalogsql.UpdateVideoParams{
 		Title:             ToPgText(title),
@@ -79,6 +81,7 @@ func BuildUpdateVideoParams(
 		MediaEmittedAt:    ToPgTimestamptz(mediaEmittedAt),
 		AnalysisJobID:     ToPgText(analysisJobID),
 		AnalysisEmittedAt: ToPgTimestamptz(analysisEmittedAt),
+		RawMissing:        ToPgBool(rawMissing),
 		VideoID:           videoID,
 	}
 }
@@ -116,6 +119,8 @@ func VideoFromCatalog(v catalogsql.CatalogVideo) *po.Video {
 		PublishAt:         timestampPtr(v.PublishAt),
 		RawSubtitleURL:    textPtr(v.RawSubtitleUrl),
 		ErrorMessage:      textPtr(v.ErrorMessage),
+		AssetID:           uuidPtr(v.AssetID),
+		RawMissing:        v.RawMissing,
 	}
 }
 
@@ -157,6 +162,21 @@ func VideoReadyViewFromFindRow(v catalogsql.FindPublishedVideoRow) *po.VideoRead
 	}
 }
 
+// VideoPlaybackSourceFromRow 将播放源查询结果转换为 po.VideoPlaybackSource。
+func VideoPlaybackSourceFromRow(v catalogsql.GetVideoPlaybackSourceRow) *po.VideoPlaybackSource {
+	return &po.VideoPlaybackSource{
+		VideoID:           v.VideoID,
+		UploadUserID:      v.UploadUserID,
+		Status:            po.VideoStatus(v.Status),
+		MediaStatus:       po.StageStatus(v.MediaStatus),
+		VisibilityStatus:  v.VisibilityStatus,
+		PublishAt:         timestampPtr(v.PublishAt),
+		DurationMicros:    int8Ptr(v.DurationMicros),
+		ThumbnailURL:      textPtr(v.ThumbnailUrl),
+		HLSMasterPlaylist: textPtr(v.HlsMasterPlaylist),
+	}
+}
+
 func mustTimestamp(ts pgtype.Timestamptz) time.Time {
 	if !ts.Valid {
 		return time.Time{}
@@ -193,6 +213,14 @@ func int4Ptr(i pgtype.Int4) *int32 {
 	return &i.Int32
 }
 
+func uuidPtr(id pgtype.UUID) *uuid.UUID {
+	if !id.Valid {
+		return nil
+	}
+	v := uuid.UUID(id.Bytes)
+	return &v
+}
+
 func textFromPtr(value *string) pgtype.Text {
 	if value == nil {
 		return pgtype.Text{}
@@ -230,6 +258,28 @@ func ToPgInt4(value *int32) pgtype.Int4 {
 	}
 }
 
+// ToPgBool 将 bool 指针转换为 pgtype.Bool。
+func ToPgBool(value *bool) pgtype.Bool {
+	if value == nil {
+		return pgtype.Bool{}
+	}
+	return pgtype.Bool{
+		Bool:  *value,
+		Valid: true,
+	}
+}
+
+// ToPgUUID 将 uuid 指针转换为 pgtype.UUID。
+func ToPgUUID(value *uuid.UUID) pgtype.UUID {
+	if value == nil {
+		return pgtype.UUID{}
+	}
+	return pgtype.UUID{
+		Bytes: *value,
+		Valid: true,
+	}
+}
+
 // ToNullVideoStatus 将领域视频状态转换为 sqlc NullCatalogVideoStatus。
 func ToNullVideoStatus(value *po.VideoStatus) catalogsql.NullCatalogVideoStatus {
 	if value == nil {
@@ -271,6 +321,11 @@ func BuildUpsertVideoUserStateParams(
 	hasBookmarked bool,
 	likedOccurredAt *time.Time,
 	bookmarkedOccurredAt *time.Time,
+	likedSequence *int64,
+	bookmarkedSequence *int64,
+	rating *int32,
+	ratedOccurredAt *time.Time,
+	ratedSequence *int64,
 ) catalogsql.UpsertVideoUserStateParams {
 	return catalogsql.UpsertVideoUserStateParams{
 		UserID:               userID,
@@ -279,5 +334,38 @@ func BuildUpsertVideoUserStateParams(
 		HasBookmarked:        hasBookmarked,
 		LikedOccurredAt:      ToPgTimestamptz(likedOccurredAt),
 		BookmarkedOccurredAt: ToPgTimestamptz(bookmarkedOccurredAt),
+		LikedSequence:        ToPgInt8(likedSequence),
+		BookmarkedSequence:   ToPgInt8(bookmarkedSequence),
+		Rating:               ToPgInt4(rating),
+		RatedOccurredAt:      ToPgTimestamptz(ratedOccurredAt),
+		RatedSequence:        ToPgInt8(ratedSequence),
+	}
+}
+
+// SavedVideoEntryFromLikedRow 转换点赞列表查询结果。
+func SavedVideoEntryFromLikedRow(row catalogsql.ListUserLikedVideosRow) po.SavedVideoEntry {
+	return po.SavedVideoEntry{
+		VideoID:        row.VideoID,
+		Title:          row.Title,
+		Status:         row.Status,
+		MediaStatus:    row.MediaStatus,
+		AnalysisStatus: row.AnalysisStatus,
+		DurationMicros: int8Ptr(row.DurationMicros),
+		CreatedAt:      mustTimestamp(row.CreatedAt),
+		SavedAt:        mustTimestamp(row.LikedOccurredAt),
+	}
+}
+
+// SavedVideoEntryFromBookmarkedRow 转换收藏列表查询结果。
+func SavedVideoEntryFromBookmarkedRow(row catalogsql.ListUserBookmarkedVideosRow) po.SavedVideoEntry {
+	return po.SavedVideoEntry{
+		VideoID:        row.VideoID,
+		Title:          row.Title,
+		Status:         row.Status,
+		MediaStatus:    row.MediaStatus,
+		AnalysisStatus: row.AnalysisStatus,
+		DurationMicros: int8Ptr(row.DurationMicros),
+		CreatedAt:      mustTimestamp(row.CreatedAt),
+		SavedAt:        mustTimestamp(row.BookmarkedOccurredAt),
 	}
 }
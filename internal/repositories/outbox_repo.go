@@ -2,14 +2,22 @@ package repositories
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories/mappers"
+	catalogsql "github.com/bionicotaku/lingo-services-catalog/internal/repositories/sqlc"
+
 	outboxpkg "github.com/bionicotaku/lingo-utils/outbox"
 	outboxcfg "github.com/bionicotaku/lingo-utils/outbox/config"
 	"github.com/bionicotaku/lingo-utils/outbox/store"
 	"github.com/bionicotaku/lingo-utils/txmanager"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -19,19 +27,53 @@ type OutboxMessage = store.Message
 // OutboxEvent 表示从数据库读取的待发布事件。
 type OutboxEvent = store.Event
 
-// OutboxRepository 封装共享仓储实现，维持原有依赖注入接口。
+// OutboxNotifyChannel 是 Outbox 写入通知使用的 Postgres NOTIFY 通道，发布器 LISTEN 该通道以便立即认领新事件。
+const OutboxNotifyChannel = "catalog_outbox"
+
+// ErrOutboxEventNotFound 表示 Outbox 事件不存在。
+var ErrOutboxEventNotFound = errors.New("outbox event not found")
+
+// ErrOutboxEventNotPending 表示 Outbox 事件已发布、（放弃时）已被放弃，或（重试时）仍被发布器租用，不能再处置。
+var ErrOutboxEventNotPending = errors.New("outbox event is not pending")
+
+// OutboxRepository 封装共享仓储实现，维持原有依赖注入接口；运维处置（列表、重试、放弃）基于 sqlc 查询。
 type OutboxRepository struct {
 	delegate *store.Repository
+	queries  *catalogsql.Queries
+	log      *log.Helper
+	lockTTL  time.Duration
+}
+
+// defaultOutboxLockTTL 与发布器未配置 lock_ttl 时的默认租约时长一致。
+const defaultOutboxLockTTL = 2 * time.Minute
+
+// OutboxEventFilter 限定运维列出的未发布事件；Abandoned 为 true 时仅列已放弃事件，否则仅列仍待发布事件。
+type OutboxEventFilter struct {
+	Abandoned   bool
+	AggregateID *uuid.UUID
+	EventType   *string
+	MinAttempts int32
+	Limit       int
 }
 
 // NewOutboxRepository 构建 Outbox 仓储，内部复用 lingo-utils/outbox/repository。
 func NewOutboxRepository(db *pgxpool.Pool, logger log.Logger, cfg outboxcfg.Config) *OutboxRepository {
+	helper := log.NewHelper(logger)
 	storeRepo, err := outboxpkg.NewRepository(db, logger, outboxpkg.RepositoryOptions{Schema: cfg.Schema})
 	if err != nil {
-		log.NewHelper(logger).Errorw("msg", "init outbox repository failed", "error", err)
-		return &OutboxRepository{delegate: store.NewRepository(db, logger)}
+		helper.Errorw("msg", "init outbox repository failed", "error", err)
+		storeRepo = store.NewRepository(db, logger)
+	}
+	lockTTL := cfg.Publisher.LockTTL
+	if lockTTL <= 0 {
+		lockTTL = defaultOutboxLockTTL
+	}
+	return &OutboxRepository{
+		delegate: storeRepo,
+		queries:  catalogsql.New(db),
+		log:      helper,
+		lockTTL:  lockTTL,
 	}
-	return &OutboxRepository{delegate: storeRepo}
 }
 
 // Enqueue 在事务内插入 Outbox 事件。
@@ -39,6 +81,20 @@ func (r *OutboxRepository) Enqueue(ctx context.Context, sess txmanager.Session,
 	return r.delegate.Enqueue(ctx, sess, msg)
 }
 
+// Notify 在事务内发出 catalog_outbox 通知，事务提交后唤醒正在 LISTEN 的发布器；payload 为事件类型，
+// 同一事务内相同的通知会被 Postgres 合并。
+func (r *OutboxRepository) Notify(ctx context.Context, sess txmanager.Session, eventType string) error {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+	if err := queries.NotifyOutbox(ctx, catalogsql.NotifyOutboxParams{Channel: OutboxNotifyChannel, Payload: eventType}); err != nil {
+		r.log.WithContext(ctx).Errorf("notify outbox failed: event_type=%s err=%v", eventType, err)
+		return fmt.Errorf("notify outbox: %w", err)
+	}
+	return nil
+}
+
 // ClaimPending 返回一批待发布的 Outbox 事件。
 func (r *OutboxRepository) ClaimPending(ctx context.Context, availableBefore, staleBefore time.Time, limit int, lockToken string) ([]OutboxEvent, error) {
 	return r.delegate.ClaimPending(ctx, availableBefore, staleBefore, limit, lockToken)
@@ -54,12 +110,104 @@ func (r *OutboxRepository) Reschedule(ctx context.Context, sess txmanager.Sessio
 	return r.delegate.Reschedule(ctx, sess, eventID, lockToken, nextAvailable, lastErr)
 }
 
-// CountPending 返回当前未发布的 Outbox 事件数量。
+// CountPending 返回当前待发布的 Outbox 事件数量，已放弃的事件不计入。
 func (r *OutboxRepository) CountPending(ctx context.Context) (int64, error) {
-	return r.delegate.CountPending(ctx)
+	count, err := r.queries.CountPendingOutboxEvents(ctx)
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("count pending outbox events failed: err=%v", err)
+		return 0, fmt.Errorf("count pending outbox events: %w", err)
+	}
+	return count, nil
 }
 
 // Shared 返回底层通用实现，供共享任务使用。
 func (r *OutboxRepository) Shared() *store.Repository {
 	return r.delegate
 }
+
+// ListEvents 按产生时间升序列出未发布的 Outbox 事件。
+func (r *OutboxRepository) ListEvents(ctx context.Context, sess txmanager.Session, filter OutboxEventFilter) ([]*po.OutboxEntry, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	rows, err := queries.ListOutboxEvents(ctx, catalogsql.ListOutboxEventsParams{
+		Abandoned:   filter.Abandoned,
+		AggregateID: mappers.ToPgUUID(filter.AggregateID),
+		EventType:   mappers.ToPgText(filter.EventType),
+		MinAttempts: filter.MinAttempts,
+		Limit:       int32(filter.Limit),
+	})
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("list outbox events failed: err=%v", err)
+		return nil, fmt.Errorf("list outbox events: %w", err)
+	}
+	entries := make([]*po.OutboxEntry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, mappers.OutboxEntryFromCatalog(row))
+	}
+	return entries, nil
+}
+
+// GetEvent 读取单条 Outbox 事件，不存在时返回 ErrOutboxEventNotFound。
+func (r *OutboxRepository) GetEvent(ctx context.Context, sess txmanager.Session, eventID uuid.UUID) (*po.OutboxEntry, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	row, err := queries.GetOutboxEvent(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOutboxEventNotFound
+		}
+		r.log.WithContext(ctx).Errorf("get outbox event failed: event=%s err=%v", eventID, err)
+		return nil, fmt.Errorf("get outbox event: %w", err)
+	}
+	return mappers.OutboxEntryFromCatalog(row), nil
+}
+
+// ResetForRetry 清零投递次数与错误、释放租约并将 available_at 置为当前时间，已放弃的事件同时恢复为待发布；
+// 事件已发布、不存在或发布器持有的租约未超过 lock_ttl 时返回 ErrOutboxEventNotPending。
+func (r *OutboxRepository) ResetForRetry(ctx context.Context, sess txmanager.Session, eventID uuid.UUID) error {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	rows, err := queries.ResetOutboxEventForRetry(ctx, catalogsql.ResetOutboxEventForRetryParams{
+		EventID:        eventID,
+		LockTtlSeconds: r.lockTTL.Seconds(),
+	})
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("reset outbox event failed: event=%s err=%v", eventID, err)
+		return fmt.Errorf("reset outbox event: %w", err)
+	}
+	if rows == 0 {
+		return ErrOutboxEventNotPending
+	}
+	return nil
+}
+
+// Abandon 放弃待发布事件，发布器此后不再认领；事件已发布、已放弃或不存在时返回 ErrOutboxEventNotPending。
+func (r *OutboxRepository) Abandon(ctx context.Context, sess txmanager.Session, eventID uuid.UUID, reason string) error {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	params := catalogsql.AbandonOutboxEventParams{EventID: eventID}
+	if reason != "" {
+		params.AbandonedReason = pgtype.Text{String: reason, Valid: true}
+	}
+	rows, err := queries.AbandonOutboxEvent(ctx, params)
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("abandon outbox event failed: event=%s err=%v", eventID, err)
+		return fmt.Errorf("abandon outbox event: %w", err)
+	}
+	if rows == 0 {
+		return ErrOutboxEventNotPending
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	catalogsql "github.com/bionicotaku/lingo-services-catalog/internal/repositories/sqlc"
+
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// OutboxTopicDeliveryRepository 读写 catalog.outbox_topic_deliveries，记录 Outbox 事件已被哪些 topic 接收。
+type OutboxTopicDeliveryRepository struct {
+	queries *catalogsql.Queries
+	log     *log.Helper
+}
+
+// NewOutboxTopicDeliveryRepository 构造 OutboxTopicDeliveryRepository。
+func NewOutboxTopicDeliveryRepository(db *pgxpool.Pool, logger log.Logger) *OutboxTopicDeliveryRepository {
+	return &OutboxTopicDeliveryRepository{
+		queries: catalogsql.New(db),
+		log:     log.NewHelper(logger),
+	}
+}
+
+// MarkDelivered 记录事件已被 topic 接收；重复记录忽略。
+func (r *OutboxTopicDeliveryRepository) MarkDelivered(ctx context.Context, sess txmanager.Session, eventID uuid.UUID, topic, eventType string) error {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	err := queries.InsertOutboxTopicDelivery(ctx, catalogsql.InsertOutboxTopicDeliveryParams{
+		EventID:   eventID,
+		Topic:     topic,
+		EventType: eventType,
+	})
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("record outbox topic delivery failed: event=%s topic=%s err=%v", eventID, topic, err)
+		return fmt.Errorf("record outbox topic delivery: %w", err)
+	}
+	return nil
+}
+
+// DeliveredTopics 返回事件已被接收的 topic 集合。
+func (r *OutboxTopicDeliveryRepository) DeliveredTopics(ctx context.Context, sess txmanager.Session, eventID uuid.UUID) (map[string]struct{}, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	topics, err := queries.ListDeliveredOutboxTopics(ctx, eventID)
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("list delivered outbox topics failed: event=%s err=%v", eventID, err)
+		return nil, fmt.Errorf("list delivered outbox topics: %w", err)
+	}
+	delivered := make(map[string]struct{}, len(topics))
+	for _, topic := range topics {
+		delivered[topic] = struct{}{}
+	}
+	return delivered, nil
+}
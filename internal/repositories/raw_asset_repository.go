@@ -0,0 +1,144 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories/mappers"
+	catalogsql "github.com/bionicotaku/lingo-services-catalog/internal/repositories/sqlc"
+
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrRawAssetNotFound 表示原始资产不存在。
+var ErrRawAssetNotFound = errors.New("raw asset not found")
+
+// RawAssetRepository 封装 catalog.raw_assets 表的访问逻辑。
+type RawAssetRepository struct {
+	db      *pgxpool.Pool
+	queries *catalogsql.Queries
+	log     *log.Helper
+}
+
+// NewRawAssetRepository 构造 RawAssetRepository。
+func NewRawAssetRepository(db *pgxpool.Pool, logger log.Logger) *RawAssetRepository {
+	return &RawAssetRepository{
+		db:      db,
+		queries: catalogsql.New(db),
+		log:     log.NewHelper(logger),
+	}
+}
+
+// AcquireRawAssetInput 描述登记或复用原始资产所需的字段。
+type AcquireRawAssetInput struct {
+	ContentSHA256 string
+	ContentMD5    string
+	SizeBytes     int64
+	Bucket        string
+	ObjectName    string
+	ContentType   *string
+	CreatedBy     uuid.UUID
+}
+
+// GetBySHA256 查询指定内容哈希的原始资产。
+func (r *RawAssetRepository) GetBySHA256(ctx context.Context, sess txmanager.Session, contentSHA256 string) (*po.RawAsset, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	record, err := queries.GetRawAssetBySha256(ctx, contentSHA256)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrRawAssetNotFound
+		}
+		r.log.WithContext(ctx).Errorf("get raw asset by sha256 failed: sha256=%s err=%v", contentSHA256, err)
+		return nil, fmt.Errorf("get raw asset by sha256: %w", err)
+	}
+	return mappers.RawAssetFromCatalog(record), nil
+}
+
+// GetByObject 查询以指定对象为规范对象的原始资产。
+func (r *RawAssetRepository) GetByObject(ctx context.Context, sess txmanager.Session, bucket, objectName string) (*po.RawAsset, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	record, err := queries.GetRawAssetByObject(ctx, catalogsql.GetRawAssetByObjectParams{
+		Bucket:     bucket,
+		ObjectName: objectName,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrRawAssetNotFound
+		}
+		r.log.WithContext(ctx).Errorf("get raw asset by object failed: bucket=%s object=%s err=%v", bucket, objectName, err)
+		return nil, fmt.Errorf("get raw asset by object: %w", err)
+	}
+	return mappers.RawAssetFromCatalog(record), nil
+}
+
+// ListVideoIDs 返回引用指定资产的全部视频 ID。
+func (r *RawAssetRepository) ListVideoIDs(ctx context.Context, sess txmanager.Session, assetID uuid.UUID) ([]uuid.UUID, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	ids, err := queries.ListVideoIDsByAsset(ctx, mappers.ToPgUUID(&assetID))
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("list videos by raw asset failed: asset_id=%s err=%v", assetID, err)
+		return nil, fmt.Errorf("list videos by raw asset: %w", err)
+	}
+	return ids, nil
+}
+
+// Acquire 登记或复用原始资产并递增引用计数，返回资产及是否新登记的标记。
+func (r *RawAssetRepository) Acquire(ctx context.Context, sess txmanager.Session, input AcquireRawAssetInput) (*po.RawAsset, bool, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	row, err := queries.AcquireRawAsset(ctx, catalogsql.AcquireRawAssetParams{
+		ContentSha256: input.ContentSHA256,
+		ContentMd5:    input.ContentMD5,
+		SizeBytes:     input.SizeBytes,
+		Bucket:        input.Bucket,
+		ObjectName:    input.ObjectName,
+		ContentType:   mappers.ToPgText(input.ContentType),
+		CreatedBy:     input.CreatedBy,
+	})
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("acquire raw asset failed: sha256=%s err=%v", input.ContentSHA256, err)
+		return nil, false, fmt.Errorf("acquire raw asset: %w", err)
+	}
+
+	asset, inserted := mappers.RawAssetFromAcquireRow(row)
+	return asset, inserted, nil
+}
+
+// Release 递减原始资产的引用计数，计数不会低于 0。
+func (r *RawAssetRepository) Release(ctx context.Context, sess txmanager.Session, assetID uuid.UUID) (*po.RawAsset, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	record, err := queries.ReleaseRawAsset(ctx, assetID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrRawAssetNotFound
+		}
+		r.log.WithContext(ctx).Errorf("release raw asset failed: asset_id=%s err=%v", assetID, err)
+		return nil, fmt.Errorf("release raw asset: %w", err)
+	}
+	return mappers.RawAssetFromCatalog(record), nil
+}
@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories/mappers"
+	catalogsql "github.com/bionicotaku/lingo-services-catalog/internal/repositories/sqlc"
+
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// RetentionRepository 按保留期分批删除（可选归档）catalog.outbox_events 中已发布或已放弃的过期行与 catalog.inbox_events 中的过期行。
+type RetentionRepository struct {
+	queries *catalogsql.Queries
+	log     *log.Helper
+}
+
+// NewRetentionRepository 构造 RetentionRepository。
+func NewRetentionRepository(db *pgxpool.Pool, logger log.Logger) *RetentionRepository {
+	return &RetentionRepository{
+		queries: catalogsql.New(db),
+		log:     log.NewHelper(logger),
+	}
+}
+
+// PruneOutbox 删除至多 limit 条发布早于 before 的 Outbox 行并返回被删除的行；archive 为 true 时同时移入归档表。
+// 被其他事务锁定的行本批跳过，留待下一批处理。
+func (r *RetentionRepository) PruneOutbox(ctx context.Context, sess txmanager.Session, before time.Time, limit int, archive bool) ([]*po.OutboxEntry, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	rows, err := queries.PruneOutboxEvents(ctx, catalogsql.PruneOutboxEventsParams{
+		PublishedBefore: pgtype.Timestamptz{Time: before.UTC(), Valid: true},
+		BatchSize:       int32(limit),
+		Archive:         archive,
+	})
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("prune outbox events failed: before=%s err=%v", before.Format(time.RFC3339), err)
+		return nil, fmt.Errorf("prune outbox events: %w", err)
+	}
+	entries := make([]*po.OutboxEntry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, mappers.OutboxEntryFromPruneRow(row))
+	}
+	return entries, nil
+}
+
+// PruneAbandonedOutbox 删除至多 limit 条放弃早于 before 且仍未发布的 Outbox 行并返回被删除的行；archive 为 true 时同时移入归档表。
+func (r *RetentionRepository) PruneAbandonedOutbox(ctx context.Context, sess txmanager.Session, before time.Time, limit int, archive bool) ([]*po.OutboxEntry, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	rows, err := queries.PruneAbandonedOutboxEvents(ctx, catalogsql.PruneAbandonedOutboxEventsParams{
+		AbandonedBefore: pgtype.Timestamptz{Time: before.UTC(), Valid: true},
+		BatchSize:       int32(limit),
+		Archive:         archive,
+	})
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("prune abandoned outbox events failed: before=%s err=%v", before.Format(time.RFC3339), err)
+		return nil, fmt.Errorf("prune abandoned outbox events: %w", err)
+	}
+	entries := make([]*po.OutboxEntry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, mappers.OutboxEntryFromPruneRow(catalogsql.PruneOutboxEventsRow(row)))
+	}
+	return entries, nil
+}
+
+// PruneInbox 删除至多 limit 条处理完成早于 before 的 Inbox 行并返回被删除的行；archive 为 true 时同时移入归档表。
+// 未处理完成（processed_at 为空）的行与 keepEventTypes 中的事件类型不会被删除。
+func (r *RetentionRepository) PruneInbox(ctx context.Context, sess txmanager.Session, before time.Time, limit int, archive bool, keepEventTypes []string) ([]*po.InboxEntry, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+	if keepEventTypes == nil {
+		// NULL 数组会让 NOT (event_type = ANY(...)) 恒为 NULL，从而一行都不删除。
+		keepEventTypes = []string{}
+	}
+
+	rows, err := queries.PruneInboxEvents(ctx, catalogsql.PruneInboxEventsParams{
+		ProcessedBefore: pgtype.Timestamptz{Time: before.UTC(), Valid: true},
+		KeepEventTypes:  keepEventTypes,
+		BatchSize:       int32(limit),
+		Archive:         archive,
+	})
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("prune inbox events failed: before=%s err=%v", before.Format(time.RFC3339), err)
+		return nil, fmt.Errorf("prune inbox events: %w", err)
+	}
+	entries := make([]*po.InboxEntry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, mappers.InboxEntryFromPruneRow(row))
+	}
+	return entries, nil
+}
@@ -0,0 +1,20 @@
+-- 跨实例互斥的 advisory 锁：锁名经 hashtextextended 映射为 bigint 键
+
+-- 会话级排他锁，阻塞直至获取；须在固定连接上执行，并在同一连接上释放
+-- name: AcquireAdvisoryLock :exec
+SELECT pg_advisory_lock(hashtextextended(sqlc.arg('lock_name')::text, 0));
+
+-- name: ReleaseAdvisoryLock :one
+SELECT pg_advisory_unlock(hashtextextended(sqlc.arg('lock_name')::text, 0))::boolean AS released;
+
+-- 事务级排他锁，事务结束时自动释放
+-- name: AcquireAdvisoryXactLock :exec
+SELECT pg_advisory_xact_lock(hashtextextended(sqlc.arg('lock_name')::text, 0));
+
+-- 会话级排他锁（非阻塞），未获取时返回 false
+-- name: TryAdvisoryLock :one
+SELECT pg_try_advisory_lock(hashtextextended(sqlc.arg('lock_name')::text, 0))::boolean AS acquired;
+
+-- 事务级共享锁（非阻塞），与同名排他锁互斥
+-- name: TryAdvisoryXactLockShared :one
+SELECT pg_try_advisory_xact_lock_shared(hashtextextended(sqlc.arg('lock_name')::text, 0))::boolean AS acquired;
@@ -47,7 +47,9 @@ RETURNING
     visibility_status,
     publish_at,
     raw_subtitle_url,
-    error_message;
+    error_message,
+    asset_id,
+    raw_missing;
 
 -- name: CreateVideoWithID :one
 INSERT INTO catalog.videos (
@@ -57,7 +59,8 @@ INSERT INTO catalog.videos (
     description,
     raw_file_reference,
     visibility_status,
-    publish_at
+    publish_at,
+    asset_id
 ) VALUES (
     $1,
     $2,
@@ -65,7 +68,8 @@ INSERT INTO catalog.videos (
     sqlc.narg('description'),
     $4,
     COALESCE(sqlc.narg('visibility_status')::text, 'public'),
-    sqlc.narg('publish_at')
+    sqlc.narg('publish_at'),
+    sqlc.narg('asset_id')
 )
 ON CONFLICT DO NOTHING
 RETURNING
@@ -98,7 +102,9 @@ RETURNING
     visibility_status,
     publish_at,
     raw_subtitle_url,
-    error_message;
+    error_message,
+    asset_id,
+    raw_missing;
 
 -- name: UpdateVideo :one
 UPDATE catalog.videos
@@ -127,6 +133,7 @@ SET
     publish_at = COALESCE(sqlc.narg('publish_at'), publish_at),
     raw_subtitle_url = COALESCE(sqlc.narg('raw_subtitle_url'), raw_subtitle_url),
     error_message = COALESCE(sqlc.narg('error_message'), error_message),
+    raw_missing = COALESCE(sqlc.narg('raw_missing'), raw_missing),
     version = version + 1
 WHERE video_id = sqlc.arg('video_id')
 RETURNING
@@ -159,7 +166,9 @@ RETURNING
     visibility_status,
     publish_at,
     raw_subtitle_url,
-    error_message;
+    error_message,
+    asset_id,
+    raw_missing;
 
 -- name: DeleteVideo :one
 DELETE FROM catalog.videos
@@ -194,4 +203,6 @@ RETURNING
     visibility_status,
     publish_at,
     raw_subtitle_url,
-    error_message;
+    error_message,
+    asset_id,
+    raw_missing;
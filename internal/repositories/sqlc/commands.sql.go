@@ -59,7 +59,9 @@ RETURNING
     visibility_status,
     publish_at,
     raw_subtitle_url,
-    error_message
+    error_message,
+    asset_id,
+    raw_missing
 `
 
 type CreateVideoParams struct {
@@ -114,6 +116,8 @@ func (q *Queries) CreateVideo(ctx context.Context, arg CreateVideoParams) (Catal
 		&i.PublishAt,
 		&i.RawSubtitleUrl,
 		&i.ErrorMessage,
+		&i.AssetID,
+		&i.RawMissing,
 	)
 	return i, err
 }
@@ -126,7 +130,8 @@ INSERT INTO catalog.videos (
     description,
     raw_file_reference,
     visibility_status,
-    publish_at
+    publish_at,
+    asset_id
 ) VALUES (
     $1,
     $2,
@@ -134,7 +139,8 @@ INSERT INTO catalog.videos (
     $5,
     $4,
     COALESCE($6::text, 'public'),
-    $7
+    $7,
+    $8
 )
 ON CONFLICT DO NOTHING
 RETURNING
@@ -167,7 +173,9 @@ RETURNING
     visibility_status,
     publish_at,
     raw_subtitle_url,
-    error_message
+    error_message,
+    asset_id,
+    raw_missing
 `
 
 type CreateVideoWithIDParams struct {
@@ -178,6 +186,7 @@ type CreateVideoWithIDParams struct {
 	Description      pgtype.Text        `json:"description"`
 	VisibilityStatus pgtype.Text        `json:"visibility_status"`
 	PublishAt        pgtype.Timestamptz `json:"publish_at"`
+	AssetID          pgtype.UUID        `json:"asset_id"`
 }
 
 func (q *Queries) CreateVideoWithID(ctx context.Context, arg CreateVideoWithIDParams) (CatalogVideo, error) {
@@ -189,6 +198,7 @@ func (q *Queries) CreateVideoWithID(ctx context.Context, arg CreateVideoWithIDPa
 		arg.Description,
 		arg.VisibilityStatus,
 		arg.PublishAt,
+		arg.AssetID,
 	)
 	var i CatalogVideo
 	err := row.Scan(
@@ -222,6 +232,8 @@ func (q *Queries) CreateVideoWithID(ctx context.Context, arg CreateVideoWithIDPa
 		&i.PublishAt,
 		&i.RawSubtitleUrl,
 		&i.ErrorMessage,
+		&i.AssetID,
+		&i.RawMissing,
 	)
 	return i, err
 }
@@ -259,7 +271,9 @@ RETURNING
     visibility_status,
     publish_at,
     raw_subtitle_url,
-    error_message
+    error_message,
+    asset_id,
+    raw_missing
 `
 
 func (q *Queries) DeleteVideo(ctx context.Context, videoID uuid.UUID) (CatalogVideo, error) {
@@ -296,6 +310,8 @@ func (q *Queries) DeleteVideo(ctx context.Context, videoID uuid.UUID) (CatalogVi
 		&i.PublishAt,
 		&i.RawSubtitleUrl,
 		&i.ErrorMessage,
+		&i.AssetID,
+		&i.RawMissing,
 	)
 	return i, err
 }
@@ -327,8 +343,9 @@ SET
     publish_at = COALESCE($22, publish_at),
     raw_subtitle_url = COALESCE($23, raw_subtitle_url),
     error_message = COALESCE($24, error_message),
+    raw_missing = COALESCE($25, raw_missing),
     version = version + 1
-WHERE video_id = $25
+WHERE video_id = $26
 RETURNING
     video_id,
     upload_user_id,
@@ -359,7 +376,9 @@ RETURNING
     visibility_status,
     publish_at,
     raw_subtitle_url,
-    error_message
+    error_message,
+    asset_id,
+    raw_missing
 `
 
 type UpdateVideoParams struct {
@@ -387,6 +406,7 @@ type UpdateVideoParams struct {
 	PublishAt         pgtype.Timestamptz     `json:"publish_at"`
 	RawSubtitleUrl    pgtype.Text            `json:"raw_subtitle_url"`
 	ErrorMessage      pgtype.Text            `json:"error_message"`
+	RawMissing        pgtype.Bool            `json:"raw_missing"`
 	VideoID           uuid.UUID              `json:"video_id"`
 }
 
@@ -416,6 +436,7 @@ func (q *Queries) UpdateVideo(ctx context.Context, arg UpdateVideoParams) (Catal
 		arg.PublishAt,
 		arg.RawSubtitleUrl,
 		arg.ErrorMessage,
+		arg.RawMissing,
 		arg.VideoID,
 	)
 	var i CatalogVideo
@@ -450,6 +471,8 @@ func (q *Queries) UpdateVideo(ctx context.Context, arg UpdateVideoParams) (Catal
 		&i.PublishAt,
 		&i.RawSubtitleUrl,
 		&i.ErrorMessage,
+		&i.AssetID,
+		&i.RawMissing,
 	)
 	return i, err
 }
@@ -0,0 +1,321 @@
+-- Engagement 批量消费相关 SQL：同一事务内批量登记 Inbox 事件，并以 pgx.Batch 写入合并后的投影变更
+
+-- 批量累加合并后的视频统计增量（语义同 IncrementVideoEngagementStats）
+-- name: BatchIncrementVideoEngagementStats :batchexec
+INSERT INTO catalog.video_engagement_stats_projection (
+    video_id,
+    like_count,
+    bookmark_count,
+    watch_count,
+    unique_watchers,
+    first_watch_at,
+    last_watch_at,
+    updated_at,
+    total_watch_seconds,
+    position_sum_seconds,
+    position_viewers,
+    share_count,
+    rating_count,
+    rating_sum,
+    rating_1_count,
+    rating_2_count,
+    rating_3_count,
+    rating_4_count,
+    rating_5_count
+) VALUES (
+    sqlc.arg('video_id'),
+    GREATEST(sqlc.arg('like_delta')::bigint, 0),
+    GREATEST(sqlc.arg('bookmark_delta')::bigint, 0),
+    GREATEST(sqlc.arg('watch_delta')::bigint, 0),
+    GREATEST(sqlc.arg('unique_watcher_delta')::bigint, 0),
+    sqlc.narg('first_watch_at'),
+    sqlc.narg('last_watch_at'),
+    now(),
+    GREATEST(sqlc.arg('watch_seconds_delta')::double precision, 0),
+    GREATEST(sqlc.arg('position_seconds_delta')::double precision, 0),
+    GREATEST(sqlc.arg('position_viewer_delta')::bigint, 0),
+    GREATEST(sqlc.arg('share_delta')::bigint, 0),
+    GREATEST(sqlc.arg('rating_count_delta')::bigint, 0),
+    GREATEST(sqlc.arg('rating_sum_delta')::bigint, 0),
+    GREATEST(sqlc.arg('rating_1_delta')::bigint, 0),
+    GREATEST(sqlc.arg('rating_2_delta')::bigint, 0),
+    GREATEST(sqlc.arg('rating_3_delta')::bigint, 0),
+    GREATEST(sqlc.arg('rating_4_delta')::bigint, 0),
+    GREATEST(sqlc.arg('rating_5_delta')::bigint, 0)
+)
+ON CONFLICT (video_id) DO UPDATE
+SET
+    like_count = GREATEST(0, catalog.video_engagement_stats_projection.like_count + sqlc.arg('like_delta')::bigint),
+    bookmark_count = GREATEST(0, catalog.video_engagement_stats_projection.bookmark_count + sqlc.arg('bookmark_delta')::bigint),
+    watch_count = GREATEST(0, catalog.video_engagement_stats_projection.watch_count + sqlc.arg('watch_delta')::bigint),
+    unique_watchers = GREATEST(0, catalog.video_engagement_stats_projection.unique_watchers + sqlc.arg('unique_watcher_delta')::bigint),
+    total_watch_seconds = GREATEST(0, catalog.video_engagement_stats_projection.total_watch_seconds + sqlc.arg('watch_seconds_delta')::double precision),
+    position_sum_seconds = GREATEST(0, catalog.video_engagement_stats_projection.position_sum_seconds + sqlc.arg('position_seconds_delta')::double precision),
+    position_viewers = GREATEST(0, catalog.video_engagement_stats_projection.position_viewers + sqlc.arg('position_viewer_delta')::bigint),
+    share_count = GREATEST(0, catalog.video_engagement_stats_projection.share_count + sqlc.arg('share_delta')::bigint),
+    rating_count = GREATEST(0, catalog.video_engagement_stats_projection.rating_count + sqlc.arg('rating_count_delta')::bigint),
+    rating_sum = GREATEST(0, catalog.video_engagement_stats_projection.rating_sum + sqlc.arg('rating_sum_delta')::bigint),
+    rating_1_count = GREATEST(0, catalog.video_engagement_stats_projection.rating_1_count + sqlc.arg('rating_1_delta')::bigint),
+    rating_2_count = GREATEST(0, catalog.video_engagement_stats_projection.rating_2_count + sqlc.arg('rating_2_delta')::bigint),
+    rating_3_count = GREATEST(0, catalog.video_engagement_stats_projection.rating_3_count + sqlc.arg('rating_3_delta')::bigint),
+    rating_4_count = GREATEST(0, catalog.video_engagement_stats_projection.rating_4_count + sqlc.arg('rating_4_delta')::bigint),
+    rating_5_count = GREATEST(0, catalog.video_engagement_stats_projection.rating_5_count + sqlc.arg('rating_5_delta')::bigint),
+    first_watch_at = CASE
+        WHEN sqlc.narg('first_watch_at') IS NULL THEN catalog.video_engagement_stats_projection.first_watch_at
+        WHEN catalog.video_engagement_stats_projection.first_watch_at IS NULL THEN sqlc.narg('first_watch_at')
+        ELSE LEAST(catalog.video_engagement_stats_projection.first_watch_at, sqlc.narg('first_watch_at'))
+    END,
+    last_watch_at = CASE
+        WHEN sqlc.narg('last_watch_at') IS NULL THEN catalog.video_engagement_stats_projection.last_watch_at
+        WHEN catalog.video_engagement_stats_projection.last_watch_at IS NULL THEN sqlc.narg('last_watch_at')
+        ELSE GREATEST(catalog.video_engagement_stats_projection.last_watch_at, sqlc.narg('last_watch_at'))
+    END,
+    updated_at = now();
+
+-- 分片计数模式下批量累加合并后的分片增量
+-- name: BatchIncrementVideoEngagementStatsShards :batchexec
+INSERT INTO catalog.video_engagement_stats_shards (
+    video_id,
+    shard,
+    like_delta,
+    bookmark_delta,
+    watch_delta,
+    unique_watcher_delta,
+    watch_seconds_delta,
+    position_seconds_delta,
+    position_viewer_delta,
+    first_watch_at,
+    last_watch_at,
+    updated_at,
+    share_delta,
+    rating_count_delta,
+    rating_sum_delta,
+    rating_1_delta,
+    rating_2_delta,
+    rating_3_delta,
+    rating_4_delta,
+    rating_5_delta
+) VALUES (
+    sqlc.arg('video_id'),
+    sqlc.arg('shard'),
+    sqlc.arg('like_delta'),
+    sqlc.arg('bookmark_delta'),
+    sqlc.arg('watch_delta'),
+    sqlc.arg('unique_watcher_delta'),
+    sqlc.arg('watch_seconds_delta'),
+    sqlc.arg('position_seconds_delta'),
+    sqlc.arg('position_viewer_delta'),
+    sqlc.narg('first_watch_at'),
+    sqlc.narg('last_watch_at'),
+    now(),
+    sqlc.arg('share_delta'),
+    sqlc.arg('rating_count_delta'),
+    sqlc.arg('rating_sum_delta'),
+    sqlc.arg('rating_1_delta'),
+    sqlc.arg('rating_2_delta'),
+    sqlc.arg('rating_3_delta'),
+    sqlc.arg('rating_4_delta'),
+    sqlc.arg('rating_5_delta')
+)
+ON CONFLICT (video_id, shard) DO UPDATE
+SET
+    like_delta = catalog.video_engagement_stats_shards.like_delta + EXCLUDED.like_delta,
+    bookmark_delta = catalog.video_engagement_stats_shards.bookmark_delta + EXCLUDED.bookmark_delta,
+    watch_delta = catalog.video_engagement_stats_shards.watch_delta + EXCLUDED.watch_delta,
+    unique_watcher_delta = catalog.video_engagement_stats_shards.unique_watcher_delta + EXCLUDED.unique_watcher_delta,
+    watch_seconds_delta = catalog.video_engagement_stats_shards.watch_seconds_delta + EXCLUDED.watch_seconds_delta,
+    position_seconds_delta = catalog.video_engagement_stats_shards.position_seconds_delta + EXCLUDED.position_seconds_delta,
+    position_viewer_delta = catalog.video_engagement_stats_shards.position_viewer_delta + EXCLUDED.position_viewer_delta,
+    first_watch_at = LEAST(catalog.video_engagement_stats_shards.first_watch_at, EXCLUDED.first_watch_at),
+    last_watch_at = GREATEST(catalog.video_engagement_stats_shards.last_watch_at, EXCLUDED.last_watch_at),
+    share_delta = catalog.video_engagement_stats_shards.share_delta + EXCLUDED.share_delta,
+    rating_count_delta = catalog.video_engagement_stats_shards.rating_count_delta + EXCLUDED.rating_count_delta,
+    rating_sum_delta = catalog.video_engagement_stats_shards.rating_sum_delta + EXCLUDED.rating_sum_delta,
+    rating_1_delta = catalog.video_engagement_stats_shards.rating_1_delta + EXCLUDED.rating_1_delta,
+    rating_2_delta = catalog.video_engagement_stats_shards.rating_2_delta + EXCLUDED.rating_2_delta,
+    rating_3_delta = catalog.video_engagement_stats_shards.rating_3_delta + EXCLUDED.rating_3_delta,
+    rating_4_delta = catalog.video_engagement_stats_shards.rating_4_delta + EXCLUDED.rating_4_delta,
+    rating_5_delta = catalog.video_engagement_stats_shards.rating_5_delta + EXCLUDED.rating_5_delta,
+    updated_at = now();
+
+-- 批量累加天级统计桶
+-- name: BatchIncrementVideoEngagementStatsDaily :batchexec
+INSERT INTO catalog.video_engagement_stats_daily (
+    video_id,
+    bucket_start,
+    like_delta,
+    bookmark_delta,
+    watch_count,
+    unique_watchers,
+    updated_at,
+    shard
+) VALUES (
+    sqlc.arg('video_id'),
+    sqlc.arg('bucket_start'),
+    sqlc.arg('like_delta')::bigint,
+    sqlc.arg('bookmark_delta')::bigint,
+    GREATEST(sqlc.arg('watch_delta')::bigint, 0),
+    GREATEST(sqlc.arg('unique_watcher_delta')::bigint, 0),
+    now(),
+    sqlc.arg('shard')
+)
+ON CONFLICT (video_id, bucket_start, shard) DO UPDATE
+SET
+    like_delta = catalog.video_engagement_stats_daily.like_delta + EXCLUDED.like_delta,
+    bookmark_delta = catalog.video_engagement_stats_daily.bookmark_delta + EXCLUDED.bookmark_delta,
+    watch_count = catalog.video_engagement_stats_daily.watch_count + EXCLUDED.watch_count,
+    unique_watchers = catalog.video_engagement_stats_daily.unique_watchers + EXCLUDED.unique_watchers,
+    updated_at = now();
+
+-- 批量累加小时统计桶
+-- name: BatchIncrementVideoEngagementStatsHourly :batchexec
+INSERT INTO catalog.video_engagement_stats_hourly (
+    video_id,
+    bucket_start,
+    like_delta,
+    bookmark_delta,
+    watch_count,
+    unique_watchers,
+    updated_at,
+    shard
+) VALUES (
+    sqlc.arg('video_id'),
+    sqlc.arg('bucket_start'),
+    sqlc.arg('like_delta')::bigint,
+    sqlc.arg('bookmark_delta')::bigint,
+    GREATEST(sqlc.arg('watch_delta')::bigint, 0),
+    GREATEST(sqlc.arg('unique_watcher_delta')::bigint, 0),
+    now(),
+    sqlc.arg('shard')
+)
+ON CONFLICT (video_id, bucket_start, shard) DO UPDATE
+SET
+    like_delta = catalog.video_engagement_stats_hourly.like_delta + EXCLUDED.like_delta,
+    bookmark_delta = catalog.video_engagement_stats_hourly.bookmark_delta + EXCLUDED.bookmark_delta,
+    watch_count = catalog.video_engagement_stats_hourly.watch_count + EXCLUDED.watch_count,
+    unique_watchers = catalog.video_engagement_stats_hourly.unique_watchers + EXCLUDED.unique_watchers,
+    updated_at = now();
+
+-- 批量推进已存在观看者的最近观看时间
+-- name: BatchTouchVideoWatchers :batchexec
+UPDATE catalog.video_engagement_watchers
+SET last_watched_at = GREATEST(last_watched_at, sqlc.arg('last_watched_at')::timestamptz)
+WHERE video_id = sqlc.arg('video_id')
+  AND user_id = sqlc.arg('user_id');
+
+-- 批量写入用户互动状态（语义同 UpsertVideoUserState）
+-- name: BatchUpsertVideoUserStates :batchexec
+INSERT INTO catalog.video_user_engagements_projection (
+    user_id,
+    video_id,
+    has_liked,
+    has_bookmarked,
+    liked_occurred_at,
+    bookmarked_occurred_at,
+    liked_sequence,
+    bookmarked_sequence,
+    rating,
+    rated_occurred_at,
+    rated_sequence,
+    updated_at
+) VALUES (
+    sqlc.arg('user_id'),
+    sqlc.arg('video_id'),
+    sqlc.arg('has_liked'),
+    sqlc.arg('has_bookmarked'),
+    sqlc.narg('liked_occurred_at'),
+    sqlc.narg('bookmarked_occurred_at'),
+    sqlc.narg('liked_sequence'),
+    sqlc.narg('bookmarked_sequence'),
+    sqlc.narg('rating'),
+    sqlc.narg('rated_occurred_at'),
+    sqlc.narg('rated_sequence'),
+    now()
+)
+ON CONFLICT (user_id, video_id) DO UPDATE
+SET has_liked = EXCLUDED.has_liked,
+    has_bookmarked = EXCLUDED.has_bookmarked,
+    liked_occurred_at = EXCLUDED.liked_occurred_at,
+    bookmarked_occurred_at = EXCLUDED.bookmarked_occurred_at,
+    liked_sequence = EXCLUDED.liked_sequence,
+    bookmarked_sequence = EXCLUDED.bookmarked_sequence,
+    rating = EXCLUDED.rating,
+    rated_occurred_at = EXCLUDED.rated_occurred_at,
+    rated_sequence = EXCLUDED.rated_sequence,
+    updated_at = now();
+
+-- 批量写入播放会话状态
+-- name: BatchUpsertVideoViewSessions :batchexec
+INSERT INTO catalog.video_view_sessions (
+    video_id,
+    user_id,
+    session_started_at,
+    last_progress_at,
+    baseline_watch_seconds,
+    last_watch_seconds,
+    counted_at,
+    updated_at,
+    max_position_seconds,
+    last_position_seconds,
+    position_tracked
+) VALUES (
+    sqlc.arg('video_id'),
+    sqlc.arg('user_id'),
+    sqlc.arg('session_started_at'),
+    sqlc.arg('last_progress_at'),
+    sqlc.arg('baseline_watch_seconds'),
+    sqlc.arg('last_watch_seconds'),
+    sqlc.narg('counted_at'),
+    now(),
+    sqlc.arg('max_position_seconds'),
+    sqlc.arg('last_position_seconds'),
+    true
+)
+ON CONFLICT (video_id, user_id) DO UPDATE
+SET
+    session_started_at = EXCLUDED.session_started_at,
+    last_progress_at = EXCLUDED.last_progress_at,
+    baseline_watch_seconds = EXCLUDED.baseline_watch_seconds,
+    last_watch_seconds = EXCLUDED.last_watch_seconds,
+    counted_at = EXCLUDED.counted_at,
+    max_position_seconds = EXCLUDED.max_position_seconds,
+    last_position_seconds = EXCLUDED.last_position_seconds,
+    position_tracked = true,
+    updated_at = now();
+
+-- 锁定一批事件中尚未处理成功的 Inbox 记录；已处理的事件视为重复投递
+-- name: LockUnprocessedInboxEvents :many
+SELECT
+    event_id,
+    received_at
+FROM catalog.inbox_events
+WHERE event_id = ANY(sqlc.arg('event_ids')::uuid[])
+  AND processed_at IS NULL
+ORDER BY event_id
+FOR UPDATE;
+
+-- 批量标记 Inbox 事件处理成功
+-- name: MarkInboxEventsProcessed :exec
+UPDATE catalog.inbox_events
+SET processed_at = sqlc.arg('processed_at'),
+    last_error = NULL
+WHERE event_id = ANY(sqlc.arg('event_ids')::uuid[]);
+
+-- 多行登记 Inbox 事件，已存在的事件保持不变
+-- name: RecordInboxEvents :exec
+INSERT INTO catalog.inbox_events (
+    event_id,
+    source_service,
+    event_type,
+    aggregate_type,
+    aggregate_id,
+    payload
+)
+SELECT
+    unnest(sqlc.arg('event_ids')::uuid[]),
+    sqlc.arg('source_service')::text,
+    unnest(sqlc.arg('event_types')::text[]),
+    NULLIF(unnest(sqlc.arg('aggregate_types')::text[]), ''),
+    NULLIF(unnest(sqlc.arg('aggregate_ids')::text[]), ''),
+    unnest(sqlc.arg('payloads')::bytea[])
+ON CONFLICT (event_id) DO NOTHING;
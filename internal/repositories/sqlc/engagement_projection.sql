@@ -8,6 +8,11 @@ INSERT INTO catalog.video_user_engagements_projection (
     has_bookmarked,
     liked_occurred_at,
     bookmarked_occurred_at,
+    liked_sequence,
+    bookmarked_sequence,
+    rating,
+    rated_occurred_at,
+    rated_sequence,
     updated_at
 ) VALUES (
     $1,
@@ -16,6 +21,11 @@ INSERT INTO catalog.video_user_engagements_projection (
     $4,
     $5,
     $6,
+    $7,
+    $8,
+    $9,
+    $10,
+    $11,
     now()
 )
 ON CONFLICT (user_id, video_id) DO UPDATE
@@ -23,6 +33,11 @@ SET has_liked = EXCLUDED.has_liked,
     has_bookmarked = EXCLUDED.has_bookmarked,
     liked_occurred_at = EXCLUDED.liked_occurred_at,
     bookmarked_occurred_at = EXCLUDED.bookmarked_occurred_at,
+    liked_sequence = EXCLUDED.liked_sequence,
+    bookmarked_sequence = EXCLUDED.bookmarked_sequence,
+    rating = EXCLUDED.rating,
+    rated_occurred_at = EXCLUDED.rated_occurred_at,
+    rated_sequence = EXCLUDED.rated_sequence,
     updated_at = now();
 
 -- name: DeleteVideoUserState :exec
@@ -38,7 +53,76 @@ SELECT
     has_bookmarked,
     liked_occurred_at,
     bookmarked_occurred_at,
-    updated_at
+    updated_at,
+    liked_sequence,
+    bookmarked_sequence,
+    rating,
+    rated_occurred_at,
+    rated_sequence
 FROM catalog.video_user_engagements_projection
 WHERE user_id = $1
   AND video_id = $2;
+
+-- 按点赞时间倒序列出用户点赞的视频，仅包含调用方仍可见的视频
+-- name: ListUserLikedVideos :many
+SELECT
+    e.video_id,
+    v.title,
+    v.status,
+    v.media_status,
+    v.analysis_status,
+    v.duration_micros,
+    v.created_at,
+    e.liked_occurred_at
+FROM catalog.video_user_engagements_projection e
+JOIN catalog.videos v ON v.video_id = e.video_id
+WHERE e.user_id = sqlc.arg('user_id')
+  AND e.has_liked
+  AND e.liked_occurred_at IS NOT NULL
+  AND (
+        v.upload_user_id = sqlc.arg('user_id')
+        OR (
+            v.status IN ('ready', 'published')
+            AND v.visibility_status <> 'private'
+            AND (v.publish_at IS NULL OR v.publish_at <= now())
+        )
+      )
+  AND (
+        sqlc.narg('cursor_occurred_at')::timestamptz IS NULL
+        OR e.liked_occurred_at < sqlc.narg('cursor_occurred_at')::timestamptz
+        OR (e.liked_occurred_at = sqlc.narg('cursor_occurred_at')::timestamptz AND e.video_id < sqlc.narg('cursor_video_id')::uuid)
+      )
+ORDER BY e.liked_occurred_at DESC, e.video_id DESC
+LIMIT sqlc.arg('limit');
+
+-- 按收藏时间倒序列出用户收藏的视频，仅包含调用方仍可见的视频
+-- name: ListUserBookmarkedVideos :many
+SELECT
+    e.video_id,
+    v.title,
+    v.status,
+    v.media_status,
+    v.analysis_status,
+    v.duration_micros,
+    v.created_at,
+    e.bookmarked_occurred_at
+FROM catalog.video_user_engagements_projection e
+JOIN catalog.videos v ON v.video_id = e.video_id
+WHERE e.user_id = sqlc.arg('user_id')
+  AND e.has_bookmarked
+  AND e.bookmarked_occurred_at IS NOT NULL
+  AND (
+        v.upload_user_id = sqlc.arg('user_id')
+        OR (
+            v.status IN ('ready', 'published')
+            AND v.visibility_status <> 'private'
+            AND (v.publish_at IS NULL OR v.publish_at <= now())
+        )
+      )
+  AND (
+        sqlc.narg('cursor_occurred_at')::timestamptz IS NULL
+        OR e.bookmarked_occurred_at < sqlc.narg('cursor_occurred_at')::timestamptz
+        OR (e.bookmarked_occurred_at = sqlc.narg('cursor_occurred_at')::timestamptz AND e.video_id < sqlc.narg('cursor_video_id')::uuid)
+      )
+ORDER BY e.bookmarked_occurred_at DESC, e.video_id DESC
+LIMIT sqlc.arg('limit');
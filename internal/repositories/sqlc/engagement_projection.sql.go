@@ -8,6 +8,7 @@ package catalogsql
 import (
 	"context"
 
+	po "github.com/bionicotaku/lingo-services-catalog/internal/models/po"
 	uuid "github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 )
@@ -36,7 +37,12 @@ SELECT
     has_bookmarked,
     liked_occurred_at,
     bookmarked_occurred_at,
-    updated_at
+    updated_at,
+    liked_sequence,
+    bookmarked_sequence,
+    rating,
+    rated_occurred_at,
+    rated_sequence
 FROM catalog.video_user_engagements_projection
 WHERE user_id = $1
   AND video_id = $2
@@ -58,10 +64,185 @@ func (q *Queries) GetVideoUserState(ctx context.Context, arg GetVideoUserStatePa
 		&i.LikedOccurredAt,
 		&i.BookmarkedOccurredAt,
 		&i.UpdatedAt,
+		&i.LikedSequence,
+		&i.BookmarkedSequence,
+		&i.Rating,
+		&i.RatedOccurredAt,
+		&i.RatedSequence,
 	)
 	return i, err
 }
 
+const listUserBookmarkedVideos = `-- name: ListUserBookmarkedVideos :many
+SELECT
+    e.video_id,
+    v.title,
+    v.status,
+    v.media_status,
+    v.analysis_status,
+    v.duration_micros,
+    v.created_at,
+    e.bookmarked_occurred_at
+FROM catalog.video_user_engagements_projection e
+JOIN catalog.videos v ON v.video_id = e.video_id
+WHERE e.user_id = $1
+  AND e.has_bookmarked
+  AND e.bookmarked_occurred_at IS NOT NULL
+  AND (
+        v.upload_user_id = $1
+        OR (
+            v.status IN ('ready', 'published')
+            AND v.visibility_status <> 'private'
+            AND (v.publish_at IS NULL OR v.publish_at <= now())
+        )
+      )
+  AND (
+        $2::timestamptz IS NULL
+        OR e.bookmarked_occurred_at < $2::timestamptz
+        OR (e.bookmarked_occurred_at = $2::timestamptz AND e.video_id < $3::uuid)
+      )
+ORDER BY e.bookmarked_occurred_at DESC, e.video_id DESC
+LIMIT $4
+`
+
+type ListUserBookmarkedVideosParams struct {
+	UserID           uuid.UUID          `json:"user_id"`
+	CursorOccurredAt pgtype.Timestamptz `json:"cursor_occurred_at"`
+	CursorVideoID    pgtype.UUID        `json:"cursor_video_id"`
+	Limit            int32              `json:"limit"`
+}
+
+type ListUserBookmarkedVideosRow struct {
+	VideoID              uuid.UUID          `json:"video_id"`
+	Title                string             `json:"title"`
+	Status               po.VideoStatus     `json:"status"`
+	MediaStatus          po.StageStatus     `json:"media_status"`
+	AnalysisStatus       po.StageStatus     `json:"analysis_status"`
+	DurationMicros       pgtype.Int8        `json:"duration_micros"`
+	CreatedAt            pgtype.Timestamptz `json:"created_at"`
+	BookmarkedOccurredAt pgtype.Timestamptz `json:"bookmarked_occurred_at"`
+}
+
+// 按收藏时间倒序列出用户收藏的视频，仅包含调用方仍可见的视频
+func (q *Queries) ListUserBookmarkedVideos(ctx context.Context, arg ListUserBookmarkedVideosParams) ([]ListUserBookmarkedVideosRow, error) {
+	rows, err := q.db.Query(ctx, listUserBookmarkedVideos,
+		arg.UserID,
+		arg.CursorOccurredAt,
+		arg.CursorVideoID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListUserBookmarkedVideosRow{}
+	for rows.Next() {
+		var i ListUserBookmarkedVideosRow
+		if err := rows.Scan(
+			&i.VideoID,
+			&i.Title,
+			&i.Status,
+			&i.MediaStatus,
+			&i.AnalysisStatus,
+			&i.DurationMicros,
+			&i.CreatedAt,
+			&i.BookmarkedOccurredAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserLikedVideos = `-- name: ListUserLikedVideos :many
+SELECT
+    e.video_id,
+    v.title,
+    v.status,
+    v.media_status,
+    v.analysis_status,
+    v.duration_micros,
+    v.created_at,
+    e.liked_occurred_at
+FROM catalog.video_user_engagements_projection e
+JOIN catalog.videos v ON v.video_id = e.video_id
+WHERE e.user_id = $1
+  AND e.has_liked
+  AND e.liked_occurred_at IS NOT NULL
+  AND (
+        v.upload_user_id = $1
+        OR (
+            v.status IN ('ready', 'published')
+            AND v.visibility_status <> 'private'
+            AND (v.publish_at IS NULL OR v.publish_at <= now())
+        )
+      )
+  AND (
+        $2::timestamptz IS NULL
+        OR e.liked_occurred_at < $2::timestamptz
+        OR (e.liked_occurred_at = $2::timestamptz AND e.video_id < $3::uuid)
+      )
+ORDER BY e.liked_occurred_at DESC, e.video_id DESC
+LIMIT $4
+`
+
+type ListUserLikedVideosParams struct {
+	UserID           uuid.UUID          `json:"user_id"`
+	CursorOccurredAt pgtype.Timestamptz `json:"cursor_occurred_at"`
+	CursorVideoID    pgtype.UUID        `json:"cursor_video_id"`
+	Limit            int32              `json:"limit"`
+}
+
+type ListUserLikedVideosRow struct {
+	VideoID         uuid.UUID          `json:"video_id"`
+	Title           string             `json:"title"`
+	Status          po.VideoStatus     `json:"status"`
+	MediaStatus     po.StageStatus     `json:"media_status"`
+	AnalysisStatus  po.StageStatus     `json:"analysis_status"`
+	DurationMicros  pgtype.Int8        `json:"duration_micros"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	LikedOccurredAt pgtype.Timestamptz `json:"liked_occurred_at"`
+}
+
+// 按点赞时间倒序列出用户点赞的视频，仅包含调用方仍可见的视频
+func (q *Queries) ListUserLikedVideos(ctx context.Context, arg ListUserLikedVideosParams) ([]ListUserLikedVideosRow, error) {
+	rows, err := q.db.Query(ctx, listUserLikedVideos,
+		arg.UserID,
+		arg.CursorOccurredAt,
+		arg.CursorVideoID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListUserLikedVideosRow{}
+	for rows.Next() {
+		var i ListUserLikedVideosRow
+		if err := rows.Scan(
+			&i.VideoID,
+			&i.Title,
+			&i.Status,
+			&i.MediaStatus,
+			&i.AnalysisStatus,
+			&i.DurationMicros,
+			&i.CreatedAt,
+			&i.LikedOccurredAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const upsertVideoUserState = `-- name: UpsertVideoUserState :exec
 
 INSERT INTO catalog.video_user_engagements_projection (
@@ -71,6 +252,11 @@ INSERT INTO catalog.video_user_engagements_projection (
     has_bookmarked,
     liked_occurred_at,
     bookmarked_occurred_at,
+    liked_sequence,
+    bookmarked_sequence,
+    rating,
+    rated_occurred_at,
+    rated_sequence,
     updated_at
 ) VALUES (
     $1,
@@ -79,6 +265,11 @@ INSERT INTO catalog.video_user_engagements_projection (
     $4,
     $5,
     $6,
+    $7,
+    $8,
+    $9,
+    $10,
+    $11,
     now()
 )
 ON CONFLICT (user_id, video_id) DO UPDATE
@@ -86,6 +277,11 @@ SET has_liked = EXCLUDED.has_liked,
     has_bookmarked = EXCLUDED.has_bookmarked,
     liked_occurred_at = EXCLUDED.liked_occurred_at,
     bookmarked_occurred_at = EXCLUDED.bookmarked_occurred_at,
+    liked_sequence = EXCLUDED.liked_sequence,
+    bookmarked_sequence = EXCLUDED.bookmarked_sequence,
+    rating = EXCLUDED.rating,
+    rated_occurred_at = EXCLUDED.rated_occurred_at,
+    rated_sequence = EXCLUDED.rated_sequence,
     updated_at = now()
 `
 
@@ -96,6 +292,11 @@ type UpsertVideoUserStateParams struct {
 	HasBookmarked        bool               `json:"has_bookmarked"`
 	LikedOccurredAt      pgtype.Timestamptz `json:"liked_occurred_at"`
 	BookmarkedOccurredAt pgtype.Timestamptz `json:"bookmarked_occurred_at"`
+	LikedSequence        pgtype.Int8        `json:"liked_sequence"`
+	BookmarkedSequence   pgtype.Int8        `json:"bookmarked_sequence"`
+	Rating               pgtype.Int4        `json:"rating"`
+	RatedOccurredAt      pgtype.Timestamptz `json:"rated_occurred_at"`
+	RatedSequence        pgtype.Int8        `json:"rated_sequence"`
 }
 
 // Video 用户态投影相关 SQL
@@ -107,6 +308,11 @@ func (q *Queries) UpsertVideoUserState(ctx context.Context, arg UpsertVideoUserS
 		arg.HasBookmarked,
 		arg.LikedOccurredAt,
 		arg.BookmarkedOccurredAt,
+		arg.LikedSequence,
+		arg.BookmarkedSequence,
+		arg.Rating,
+		arg.RatedOccurredAt,
+		arg.RatedSequence,
 	)
 	return err
 }
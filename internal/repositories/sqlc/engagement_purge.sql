@@ -0,0 +1,241 @@
+-- 登记清理请求；同一对象重复登记时取较晚的触发时间并重新打开请求
+-- name: UpsertEngagementPurge :exec
+INSERT INTO catalog.engagement_purges (
+    scope,
+    subject_id,
+    reason,
+    requested_at,
+    source_event_id
+) VALUES (
+    sqlc.arg('scope'),
+    sqlc.arg('subject_id'),
+    sqlc.arg('reason'),
+    sqlc.arg('requested_at'),
+    sqlc.narg('source_event_id')
+)
+ON CONFLICT (scope, subject_id) DO UPDATE
+SET reason = CASE
+        WHEN EXCLUDED.requested_at >= catalog.engagement_purges.requested_at THEN EXCLUDED.reason
+        ELSE catalog.engagement_purges.reason
+    END,
+    requested_at = GREATEST(catalog.engagement_purges.requested_at, EXCLUDED.requested_at),
+    source_event_id = COALESCE(EXCLUDED.source_event_id, catalog.engagement_purges.source_event_id),
+    completed_at = NULL,
+    updated_at = now();
+
+-- 读取视频或用户的清理截止时间（两者取较晚者），无清理请求时返回 NULL
+-- name: GetEngagementPurgeCutoff :one
+SELECT max(requested_at)::timestamptz AS requested_at
+FROM catalog.engagement_purges
+WHERE (scope = 'video' AND subject_id = sqlc.arg('video_id'))
+   OR (scope = 'user' AND subject_id = sqlc.arg('user_id'));
+
+-- 按登记顺序列出待处理的清理请求
+-- name: ListPendingEngagementPurges :many
+SELECT
+    scope,
+    subject_id,
+    reason,
+    requested_at,
+    source_event_id,
+    rows_removed,
+    created_at,
+    updated_at,
+    completed_at
+FROM catalog.engagement_purges
+WHERE completed_at IS NULL
+ORDER BY created_at, scope, subject_id
+LIMIT sqlc.arg('limit');
+
+-- 累计清理请求已删除的行数
+-- name: AddEngagementPurgeRowsRemoved :exec
+UPDATE catalog.engagement_purges
+SET rows_removed = rows_removed + sqlc.arg('rows')::bigint,
+    updated_at = now()
+WHERE scope = sqlc.arg('scope')
+  AND subject_id = sqlc.arg('subject_id');
+
+-- 标记清理完成；处理期间被重新登记（requested_at 变化）的请求保持待处理
+-- name: CompleteEngagementPurge :execrows
+UPDATE catalog.engagement_purges
+SET completed_at = now(),
+    updated_at = now()
+WHERE scope = sqlc.arg('scope')
+  AND subject_id = sqlc.arg('subject_id')
+  AND requested_at = sqlc.arg('requested_at')
+  AND completed_at IS NULL;
+
+-- 分批删除视频的用户互动状态
+-- name: PurgeVideoUserEngagementsByVideo :execrows
+DELETE FROM catalog.video_user_engagements_projection
+WHERE video_id = sqlc.arg('video_id')
+  AND user_id IN (
+      SELECT user_id
+      FROM catalog.video_user_engagements_projection
+      WHERE video_id = sqlc.arg('video_id')
+      LIMIT sqlc.arg('batch_size')
+  );
+
+-- 分批删除视频的唯一观看记录
+-- name: PurgeVideoWatchersByVideo :execrows
+DELETE FROM catalog.video_engagement_watchers
+WHERE video_id = sqlc.arg('video_id')
+  AND user_id IN (
+      SELECT user_id
+      FROM catalog.video_engagement_watchers
+      WHERE video_id = sqlc.arg('video_id')
+      LIMIT sqlc.arg('batch_size')
+  );
+
+-- 分批删除视频的观看会话
+-- name: PurgeVideoViewSessionsByVideo :execrows
+DELETE FROM catalog.video_view_sessions
+WHERE video_id = sqlc.arg('video_id')
+  AND user_id IN (
+      SELECT user_id
+      FROM catalog.video_view_sessions
+      WHERE video_id = sqlc.arg('video_id')
+      LIMIT sqlc.arg('batch_size')
+  );
+
+-- 分批删除视频的小时统计桶
+-- name: PurgeVideoEngagementStatsHourlyByVideo :execrows
+DELETE FROM catalog.video_engagement_stats_hourly
+WHERE video_id = sqlc.arg('video_id')
+  AND bucket_start IN (
+      SELECT bucket_start
+      FROM catalog.video_engagement_stats_hourly
+      WHERE video_id = sqlc.arg('video_id')
+      LIMIT sqlc.arg('batch_size')
+  );
+
+-- 分批删除视频的天统计桶
+-- name: PurgeVideoEngagementStatsDailyByVideo :execrows
+DELETE FROM catalog.video_engagement_stats_daily
+WHERE video_id = sqlc.arg('video_id')
+  AND bucket_start IN (
+      SELECT bucket_start
+      FROM catalog.video_engagement_stats_daily
+      WHERE video_id = sqlc.arg('video_id')
+      LIMIT sqlc.arg('batch_size')
+  );
+
+-- 删除视频的累计统计行
+-- name: DeleteVideoEngagementStatsProjection :execrows
+DELETE FROM catalog.video_engagement_stats_projection
+WHERE video_id = sqlc.arg('video_id');
+
+-- 删除视频尚未压实的分片计数行
+-- name: DeleteVideoEngagementStatsShards :execrows
+DELETE FROM catalog.video_engagement_stats_shards
+WHERE video_id = sqlc.arg('video_id');
+
+-- 分批删除用户的互动状态，并将对应视频的点赞/收藏/评分扣减写为分片 0 的负增量：
+-- 主行可能尚未包含分片中的正增量，直接在主行上扣减并截断到 0 会丢失扣减，经分片由读取与压实统一累加；
+-- 写分片前按 video_id 顺序获取视频统计的共享 advisory lock，与 Repair 互斥
+-- name: PurgeUserEngagementsByUser :one
+WITH removed AS (
+    DELETE FROM catalog.video_user_engagements_projection
+    WHERE user_id = sqlc.arg('user_id')
+      AND video_id IN (
+          SELECT video_id
+          FROM catalog.video_user_engagements_projection
+          WHERE user_id = sqlc.arg('user_id')
+          LIMIT sqlc.arg('batch_size')
+      )
+    RETURNING video_id, has_liked, has_bookmarked, rating
+), locked AS (
+    SELECT v.video_id
+    FROM (SELECT DISTINCT video_id FROM removed ORDER BY video_id) v
+    CROSS JOIN LATERAL (SELECT pg_advisory_xact_lock_shared(hashtextextended('catalog.engagement.stats:' || v.video_id::text, 0))) l
+), adjusted AS (
+    INSERT INTO catalog.video_engagement_stats_shards (
+        video_id,
+        shard,
+        like_delta,
+        bookmark_delta,
+        rating_count_delta,
+        rating_sum_delta,
+        rating_1_delta,
+        rating_2_delta,
+        rating_3_delta,
+        rating_4_delta,
+        rating_5_delta,
+        updated_at
+    )
+    SELECT
+        r.video_id,
+        0,
+        -count(*) FILTER (WHERE r.has_liked),
+        -count(*) FILTER (WHERE r.has_bookmarked),
+        -count(r.rating),
+        -COALESCE(SUM(r.rating), 0),
+        -count(*) FILTER (WHERE r.rating = 1),
+        -count(*) FILTER (WHERE r.rating = 2),
+        -count(*) FILTER (WHERE r.rating = 3),
+        -count(*) FILTER (WHERE r.rating = 4),
+        -count(*) FILTER (WHERE r.rating = 5),
+        now()
+    FROM removed r
+    WHERE (r.has_liked OR r.has_bookmarked OR r.rating IS NOT NULL)
+      AND r.video_id IN (SELECT video_id FROM locked)
+    GROUP BY r.video_id
+    ON CONFLICT (video_id, shard) DO UPDATE
+    SET like_delta = catalog.video_engagement_stats_shards.like_delta + EXCLUDED.like_delta,
+        bookmark_delta = catalog.video_engagement_stats_shards.bookmark_delta + EXCLUDED.bookmark_delta,
+        rating_count_delta = catalog.video_engagement_stats_shards.rating_count_delta + EXCLUDED.rating_count_delta,
+        rating_sum_delta = catalog.video_engagement_stats_shards.rating_sum_delta + EXCLUDED.rating_sum_delta,
+        rating_1_delta = catalog.video_engagement_stats_shards.rating_1_delta + EXCLUDED.rating_1_delta,
+        rating_2_delta = catalog.video_engagement_stats_shards.rating_2_delta + EXCLUDED.rating_2_delta,
+        rating_3_delta = catalog.video_engagement_stats_shards.rating_3_delta + EXCLUDED.rating_3_delta,
+        rating_4_delta = catalog.video_engagement_stats_shards.rating_4_delta + EXCLUDED.rating_4_delta,
+        rating_5_delta = catalog.video_engagement_stats_shards.rating_5_delta + EXCLUDED.rating_5_delta,
+        updated_at = now()
+    RETURNING video_id
+)
+SELECT count(*) FROM removed;
+
+-- 分批删除用户的唯一观看记录，并将对应视频的唯一观看人数扣减写为分片 0 的负增量（同样先获取共享锁）
+-- name: PurgeUserWatchersByUser :one
+WITH removed AS (
+    DELETE FROM catalog.video_engagement_watchers
+    WHERE user_id = sqlc.arg('user_id')
+      AND video_id IN (
+          SELECT video_id
+          FROM catalog.video_engagement_watchers
+          WHERE user_id = sqlc.arg('user_id')
+          LIMIT sqlc.arg('batch_size')
+      )
+    RETURNING video_id
+), locked AS (
+    SELECT v.video_id
+    FROM (SELECT DISTINCT video_id FROM removed ORDER BY video_id) v
+    CROSS JOIN LATERAL (SELECT pg_advisory_xact_lock_shared(hashtextextended('catalog.engagement.stats:' || v.video_id::text, 0))) l
+), adjusted AS (
+    INSERT INTO catalog.video_engagement_stats_shards (
+        video_id,
+        shard,
+        unique_watcher_delta,
+        updated_at
+    )
+    SELECT r.video_id, 0, -count(*), now()
+    FROM removed r
+    WHERE r.video_id IN (SELECT video_id FROM locked)
+    GROUP BY r.video_id
+    ON CONFLICT (video_id, shard) DO UPDATE
+    SET unique_watcher_delta = catalog.video_engagement_stats_shards.unique_watcher_delta + EXCLUDED.unique_watcher_delta,
+        updated_at = now()
+    RETURNING video_id
+)
+SELECT count(*) FROM removed;
+
+-- 分批删除用户的观看会话（含续播位置）
+-- name: PurgeUserViewSessionsByUser :execrows
+DELETE FROM catalog.video_view_sessions
+WHERE user_id = sqlc.arg('user_id')
+  AND video_id IN (
+      SELECT video_id
+      FROM catalog.video_view_sessions
+      WHERE user_id = sqlc.arg('user_id')
+      LIMIT sqlc.arg('batch_size')
+  );
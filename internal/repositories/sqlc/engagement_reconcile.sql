@@ -0,0 +1,118 @@
+-- Engagement 统计对账相关 SQL
+
+-- 按 video_id 键集分页扫描候选视频，返回存量计数（主行与未压实分片之和）与按明细表重算的计数。
+-- 候选集为统计表、用户互动表、观看者表中出现过的视频；since 按各表的更新时间筛选。
+-- name: ListEngagementStatsDrift :many
+WITH candidates AS (
+    (
+        SELECT s.video_id
+        FROM catalog.video_engagement_stats_projection s
+        WHERE s.video_id > sqlc.arg('after_video_id')::uuid
+          AND (sqlc.narg('video_id')::uuid IS NULL OR s.video_id = sqlc.narg('video_id')::uuid)
+          AND (sqlc.narg('since')::timestamptz IS NULL OR s.updated_at >= sqlc.narg('since')::timestamptz)
+        ORDER BY s.video_id
+        LIMIT sqlc.arg('limit')
+    )
+    UNION
+    (
+        SELECT DISTINCT e.video_id
+        FROM catalog.video_user_engagements_projection e
+        WHERE e.video_id > sqlc.arg('after_video_id')::uuid
+          AND (sqlc.narg('video_id')::uuid IS NULL OR e.video_id = sqlc.narg('video_id')::uuid)
+          AND (sqlc.narg('since')::timestamptz IS NULL OR e.updated_at >= sqlc.narg('since')::timestamptz)
+        ORDER BY e.video_id
+        LIMIT sqlc.arg('limit')
+    )
+    UNION
+    (
+        SELECT DISTINCT w.video_id
+        FROM catalog.video_engagement_watchers w
+        WHERE w.video_id > sqlc.arg('after_video_id')::uuid
+          AND (sqlc.narg('video_id')::uuid IS NULL OR w.video_id = sqlc.narg('video_id')::uuid)
+          AND (sqlc.narg('since')::timestamptz IS NULL OR w.last_watched_at >= sqlc.narg('since')::timestamptz)
+        ORDER BY w.video_id
+        LIMIT sqlc.arg('limit')
+    )
+), page AS (
+    SELECT c.video_id
+    FROM candidates c
+    ORDER BY c.video_id
+    LIMIT sqlc.arg('limit')
+)
+SELECT
+    p.video_id,
+    GREATEST(0, COALESCE(s.like_count, 0) + sh.like_delta)::bigint AS stored_like_count,
+    GREATEST(0, COALESCE(s.bookmark_count, 0) + sh.bookmark_delta)::bigint AS stored_bookmark_count,
+    GREATEST(0, COALESCE(s.unique_watchers, 0) + sh.unique_watcher_delta)::bigint AS stored_unique_watchers,
+    e.like_count::bigint AS actual_like_count,
+    e.bookmark_count::bigint AS actual_bookmark_count,
+    w.unique_watchers::bigint AS actual_unique_watchers
+FROM page p
+LEFT JOIN catalog.video_engagement_stats_projection s ON s.video_id = p.video_id
+CROSS JOIN LATERAL (
+    SELECT
+        COALESCE(SUM(vs.like_delta), 0) AS like_delta,
+        COALESCE(SUM(vs.bookmark_delta), 0) AS bookmark_delta,
+        COALESCE(SUM(vs.unique_watcher_delta), 0) AS unique_watcher_delta
+    FROM catalog.video_engagement_stats_shards vs
+    WHERE vs.video_id = p.video_id
+) sh
+CROSS JOIN LATERAL (
+    SELECT
+        count(*) FILTER (WHERE ue.has_liked) AS like_count,
+        count(*) FILTER (WHERE ue.has_bookmarked) AS bookmark_count
+    FROM catalog.video_user_engagements_projection ue
+    WHERE ue.video_id = p.video_id
+) e
+CROSS JOIN LATERAL (
+    SELECT count(*) AS unique_watchers
+    FROM catalog.video_engagement_watchers vw
+    WHERE vw.video_id = p.video_id
+) w
+ORDER BY p.video_id;
+
+-- 锁定待修复的统计行，使随后的重算语句读取到并发增量提交后的明细
+-- name: LockVideoEngagementStats :exec
+SELECT video_id
+FROM catalog.video_engagement_stats_projection
+WHERE video_id = ANY(sqlc.arg('video_ids')::uuid[])
+ORDER BY video_id
+FOR UPDATE;
+
+-- 修复前获取视频统计的排他 advisory lock（按 video_id 排序加锁），等待持有共享锁的分片写入与压实事务提交，
+-- 并阻止新的分片写入直至修复提交
+-- name: LockVideoEngagementStatsExclusive :exec
+SELECT pg_advisory_xact_lock(hashtextextended('catalog.engagement.stats:' || video_id::text, 0))
+FROM (
+    SELECT DISTINCT video_id
+    FROM unnest(sqlc.arg('video_ids')::uuid[]) AS ids(video_id)
+    ORDER BY video_id
+) locked;
+
+-- 按明细表重算并覆盖 like_count/bookmark_count/unique_watchers；watch_count 无明细来源，保持不变
+-- name: RepairVideoEngagementStats :many
+INSERT INTO catalog.video_engagement_stats_projection (
+    video_id,
+    like_count,
+    bookmark_count,
+    unique_watchers,
+    updated_at
+)
+SELECT
+    v.video_id,
+    (SELECT count(*) FROM catalog.video_user_engagements_projection ue WHERE ue.video_id = v.video_id AND ue.has_liked),
+    (SELECT count(*) FROM catalog.video_user_engagements_projection ue WHERE ue.video_id = v.video_id AND ue.has_bookmarked),
+    (SELECT count(*) FROM catalog.video_engagement_watchers vw WHERE vw.video_id = v.video_id),
+    now()
+FROM unnest(sqlc.arg('video_ids')::uuid[]) AS v(video_id)
+ON CONFLICT (video_id) DO UPDATE
+SET
+    like_count = EXCLUDED.like_count,
+    bookmark_count = EXCLUDED.bookmark_count,
+    unique_watchers = EXCLUDED.unique_watchers,
+    updated_at = now()
+RETURNING
+    video_id,
+    like_count,
+    bookmark_count,
+    unique_watchers;
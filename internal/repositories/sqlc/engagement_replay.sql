@@ -0,0 +1,199 @@
+-- Engagement 投影重放相关 SQL
+
+-- 按 (occurred_at, event_id) 键集分页扫描指定类型、已处理完成且已标注发生时间的 Inbox 事件；未处理的事件仍由线上 Runner 负责
+-- name: ListInboxEventsByType :many
+SELECT
+    event_id,
+    event_type,
+    payload,
+    received_at,
+    occurred_at::timestamptz AS occurred_at
+FROM catalog.inbox_events
+WHERE event_type = ANY(sqlc.arg('event_types')::text[])
+  AND processed_at IS NOT NULL
+  AND occurred_at IS NOT NULL
+  AND (
+        sqlc.narg('after_occurred_at')::timestamptz IS NULL
+        OR (occurred_at, event_id) > (sqlc.narg('after_occurred_at')::timestamptz, sqlc.arg('after_event_id')::uuid)
+      )
+ORDER BY occurred_at, event_id
+LIMIT sqlc.arg('limit');
+
+-- 按接收顺序读取尚未标注发生时间的已处理 Inbox 事件，供重放前解码负载回写 occurred_at
+-- name: ListUnstampedInboxEvents :many
+SELECT
+    event_id,
+    event_type,
+    payload,
+    received_at
+FROM catalog.inbox_events
+WHERE event_type = ANY(sqlc.arg('event_types')::text[])
+  AND processed_at IS NOT NULL
+  AND occurred_at IS NULL
+ORDER BY received_at, event_id
+LIMIT sqlc.arg('limit');
+
+-- 批量回写事件发生时间；已标注的事件保持不变
+-- name: StampInboxEventsOccurredAt :exec
+UPDATE catalog.inbox_events AS e
+SET occurred_at = v.occurred_at
+FROM (
+    SELECT
+        unnest(sqlc.arg('event_ids')::uuid[]) AS event_id,
+        unnest(sqlc.arg('occurred_ats')::timestamptz[]) AS occurred_at
+) AS v
+WHERE e.event_id = v.event_id
+  AND e.occurred_at IS NULL;
+
+-- name: GetEngagementReplayCheckpoint :one
+SELECT
+    replay_id,
+    scope_user_id,
+    scope_video_id,
+    last_occurred_at,
+    last_event_id,
+    applied_count,
+    skipped_count,
+    started_at,
+    updated_at,
+    completed_at,
+    last_received_at
+FROM catalog.engagement_replay_checkpoints
+WHERE replay_id = $1;
+
+-- name: CreateEngagementReplayCheckpoint :exec
+INSERT INTO catalog.engagement_replay_checkpoints (
+    replay_id,
+    scope_user_id,
+    scope_video_id
+) VALUES (
+    $1,
+    $2,
+    $3
+);
+
+-- 推进检查点，与本批次投影写入处于同一事务
+-- name: AdvanceEngagementReplayCheckpoint :exec
+UPDATE catalog.engagement_replay_checkpoints
+SET last_occurred_at = $2,
+    last_event_id = $3,
+    applied_count = $4,
+    skipped_count = $5,
+    updated_at = now()
+WHERE replay_id = $1;
+
+-- name: CompleteEngagementReplayCheckpoint :exec
+UPDATE catalog.engagement_replay_checkpoints
+SET completed_at = now(),
+    updated_at = now()
+WHERE replay_id = $1;
+
+-- name: DeleteEngagementReplayCheckpoint :exec
+DELETE FROM catalog.engagement_replay_checkpoints
+WHERE replay_id = $1;
+
+-- 清空重放范围内的用户互动状态；参数均为 NULL 时清空全表
+-- name: DeleteVideoUserStatesInScope :exec
+DELETE FROM catalog.video_user_engagements_projection
+WHERE (sqlc.narg('user_id')::uuid IS NULL OR user_id = sqlc.narg('user_id')::uuid)
+  AND (sqlc.narg('video_id')::uuid IS NULL OR video_id = sqlc.narg('video_id')::uuid);
+
+-- 清空重放范围内的视频统计；video_id 为 NULL 时清空全表
+-- name: DeleteVideoEngagementStatsInScope :exec
+DELETE FROM catalog.video_engagement_stats_projection
+WHERE (sqlc.narg('video_id')::uuid IS NULL OR video_id = sqlc.narg('video_id')::uuid);
+
+-- name: DeleteVideoEngagementStatsShardsInScope :exec
+DELETE FROM catalog.video_engagement_stats_shards
+WHERE (sqlc.narg('video_id')::uuid IS NULL OR video_id = sqlc.narg('video_id')::uuid);
+
+-- name: DeleteVideoEngagementWatchersInScope :exec
+DELETE FROM catalog.video_engagement_watchers
+WHERE (sqlc.narg('video_id')::uuid IS NULL OR video_id = sqlc.narg('video_id')::uuid);
+
+-- name: DeleteVideoViewSessionsInScope :exec
+DELETE FROM catalog.video_view_sessions
+WHERE (sqlc.narg('video_id')::uuid IS NULL OR video_id = sqlc.narg('video_id')::uuid);
+
+-- 清空重放范围内的分时统计桶，避免重放后重复累加
+-- name: DeleteVideoEngagementStatsHourlyInScope :exec
+DELETE FROM catalog.video_engagement_stats_hourly
+WHERE (sqlc.narg('video_id')::uuid IS NULL OR video_id = sqlc.narg('video_id')::uuid);
+
+-- name: DeleteVideoEngagementStatsDailyInScope :exec
+DELETE FROM catalog.video_engagement_stats_daily
+WHERE (sqlc.narg('video_id')::uuid IS NULL OR video_id = sqlc.narg('video_id')::uuid);
+
+-- name: ListVideoUserStatesInScope :many
+SELECT
+    user_id,
+    video_id,
+    has_liked,
+    has_bookmarked,
+    liked_occurred_at,
+    bookmarked_occurred_at,
+    updated_at,
+    liked_sequence,
+    bookmarked_sequence,
+    rating,
+    rated_occurred_at,
+    rated_sequence
+FROM catalog.video_user_engagements_projection
+WHERE (sqlc.narg('user_id')::uuid IS NULL OR user_id = sqlc.narg('user_id')::uuid)
+  AND (sqlc.narg('video_id')::uuid IS NULL OR video_id = sqlc.narg('video_id')::uuid)
+ORDER BY user_id, video_id;
+
+-- 累加主行与未压实的分片增量，与 GetVideoEngagementStats 口径一致
+-- name: ListVideoEngagementStatsInScope :many
+WITH shards AS (
+    SELECT
+        video_id,
+        SUM(like_delta) AS like_delta,
+        SUM(bookmark_delta) AS bookmark_delta,
+        SUM(watch_delta) AS watch_delta,
+        SUM(unique_watcher_delta) AS unique_watcher_delta,
+        SUM(watch_seconds_delta) AS watch_seconds_delta,
+        SUM(position_seconds_delta) AS position_seconds_delta,
+        SUM(position_viewer_delta) AS position_viewer_delta,
+        MIN(first_watch_at) AS first_watch_at,
+        MAX(last_watch_at) AS last_watch_at,
+        MAX(updated_at) AS updated_at,
+        SUM(share_delta) AS share_delta,
+        SUM(rating_count_delta) AS rating_count_delta,
+        SUM(rating_sum_delta) AS rating_sum_delta,
+        SUM(rating_1_delta) AS rating_1_delta,
+        SUM(rating_2_delta) AS rating_2_delta,
+        SUM(rating_3_delta) AS rating_3_delta,
+        SUM(rating_4_delta) AS rating_4_delta,
+        SUM(rating_5_delta) AS rating_5_delta
+    FROM catalog.video_engagement_stats_shards
+    WHERE (sqlc.narg('video_id')::uuid IS NULL OR video_id = sqlc.narg('video_id')::uuid)
+    GROUP BY video_id
+)
+SELECT
+    COALESCE(s.video_id, sh.video_id)::uuid AS video_id,
+    GREATEST(0, COALESCE(s.like_count, 0) + COALESCE(sh.like_delta, 0))::bigint AS like_count,
+    GREATEST(0, COALESCE(s.bookmark_count, 0) + COALESCE(sh.bookmark_delta, 0))::bigint AS bookmark_count,
+    GREATEST(0, COALESCE(s.watch_count, 0) + COALESCE(sh.watch_delta, 0))::bigint AS watch_count,
+    GREATEST(0, COALESCE(s.unique_watchers, 0) + COALESCE(sh.unique_watcher_delta, 0))::bigint AS unique_watchers,
+    LEAST(s.first_watch_at, sh.first_watch_at)::timestamptz AS first_watch_at,
+    GREATEST(s.last_watch_at, sh.last_watch_at)::timestamptz AS last_watch_at,
+    GREATEST(s.updated_at, sh.updated_at)::timestamptz AS updated_at,
+    GREATEST(0, COALESCE(s.total_watch_seconds, 0) + COALESCE(sh.watch_seconds_delta, 0))::double precision AS total_watch_seconds,
+    GREATEST(0, COALESCE(s.position_sum_seconds, 0) + COALESCE(sh.position_seconds_delta, 0))::double precision AS position_sum_seconds,
+    GREATEST(0, COALESCE(s.position_viewers, 0) + COALESCE(sh.position_viewer_delta, 0))::bigint AS position_viewers,
+    GREATEST(0, COALESCE(s.share_count, 0) + COALESCE(sh.share_delta, 0))::bigint AS share_count,
+    GREATEST(0, COALESCE(s.rating_count, 0) + COALESCE(sh.rating_count_delta, 0))::bigint AS rating_count,
+    GREATEST(0, COALESCE(s.rating_sum, 0) + COALESCE(sh.rating_sum_delta, 0))::bigint AS rating_sum,
+    GREATEST(0, COALESCE(s.rating_1_count, 0) + COALESCE(sh.rating_1_delta, 0))::bigint AS rating_1_count,
+    GREATEST(0, COALESCE(s.rating_2_count, 0) + COALESCE(sh.rating_2_delta, 0))::bigint AS rating_2_count,
+    GREATEST(0, COALESCE(s.rating_3_count, 0) + COALESCE(sh.rating_3_delta, 0))::bigint AS rating_3_count,
+    GREATEST(0, COALESCE(s.rating_4_count, 0) + COALESCE(sh.rating_4_delta, 0))::bigint AS rating_4_count,
+    GREATEST(0, COALESCE(s.rating_5_count, 0) + COALESCE(sh.rating_5_delta, 0))::bigint AS rating_5_count
+FROM (
+    SELECT *
+    FROM catalog.video_engagement_stats_projection
+    WHERE (sqlc.narg('video_id')::uuid IS NULL OR video_id = sqlc.narg('video_id')::uuid)
+) s
+FULL JOIN shards sh ON sh.video_id = s.video_id
+ORDER BY 1;
@@ -0,0 +1,119 @@
+-- 累加小时统计桶；分片计数模式下写入 shard 对应的分片桶
+-- name: IncrementVideoEngagementStatsHourly :exec
+INSERT INTO catalog.video_engagement_stats_hourly (
+    video_id,
+    bucket_start,
+    like_delta,
+    bookmark_delta,
+    watch_count,
+    unique_watchers,
+    updated_at,
+    shard
+) VALUES (
+    sqlc.arg('video_id'),
+    sqlc.arg('bucket_start'),
+    sqlc.arg('like_delta')::bigint,
+    sqlc.arg('bookmark_delta')::bigint,
+    GREATEST(sqlc.arg('watch_delta')::bigint, 0),
+    GREATEST(sqlc.arg('unique_watcher_delta')::bigint, 0),
+    now(),
+    sqlc.arg('shard')
+)
+ON CONFLICT (video_id, bucket_start, shard) DO UPDATE
+SET
+    like_delta = catalog.video_engagement_stats_hourly.like_delta + EXCLUDED.like_delta,
+    bookmark_delta = catalog.video_engagement_stats_hourly.bookmark_delta + EXCLUDED.bookmark_delta,
+    watch_count = catalog.video_engagement_stats_hourly.watch_count + EXCLUDED.watch_count,
+    unique_watchers = catalog.video_engagement_stats_hourly.unique_watchers + EXCLUDED.unique_watchers,
+    updated_at = now();
+
+-- 累加天级统计桶；分片计数模式下写入 shard 对应的分片桶
+-- name: IncrementVideoEngagementStatsDaily :exec
+INSERT INTO catalog.video_engagement_stats_daily (
+    video_id,
+    bucket_start,
+    like_delta,
+    bookmark_delta,
+    watch_count,
+    unique_watchers,
+    updated_at,
+    shard
+) VALUES (
+    sqlc.arg('video_id'),
+    sqlc.arg('bucket_start'),
+    sqlc.arg('like_delta')::bigint,
+    sqlc.arg('bookmark_delta')::bigint,
+    GREATEST(sqlc.arg('watch_delta')::bigint, 0),
+    GREATEST(sqlc.arg('unique_watcher_delta')::bigint, 0),
+    now(),
+    sqlc.arg('shard')
+)
+ON CONFLICT (video_id, bucket_start, shard) DO UPDATE
+SET
+    like_delta = catalog.video_engagement_stats_daily.like_delta + EXCLUDED.like_delta,
+    bookmark_delta = catalog.video_engagement_stats_daily.bookmark_delta + EXCLUDED.bookmark_delta,
+    watch_count = catalog.video_engagement_stats_daily.watch_count + EXCLUDED.watch_count,
+    unique_watchers = catalog.video_engagement_stats_daily.unique_watchers + EXCLUDED.unique_watchers,
+    updated_at = now();
+
+-- 读取时间范围内的小时统计桶（左闭右开），同一桶的分片合并返回
+-- name: ListVideoEngagementStatsHourly :many
+SELECT
+    bucket_start,
+    SUM(like_delta)::bigint AS like_delta,
+    SUM(bookmark_delta)::bigint AS bookmark_delta,
+    SUM(watch_count)::bigint AS watch_count,
+    SUM(unique_watchers)::bigint AS unique_watchers
+FROM catalog.video_engagement_stats_hourly
+WHERE video_id = sqlc.arg('video_id')
+  AND bucket_start >= sqlc.arg('from_time')
+  AND bucket_start < sqlc.arg('to_time')
+GROUP BY bucket_start
+ORDER BY bucket_start;
+
+-- 读取时间范围内的天级统计桶（左闭右开），同一桶的分片合并返回
+-- name: ListVideoEngagementStatsDaily :many
+SELECT
+    bucket_start,
+    SUM(like_delta)::bigint AS like_delta,
+    SUM(bookmark_delta)::bigint AS bookmark_delta,
+    SUM(watch_count)::bigint AS watch_count,
+    SUM(unique_watchers)::bigint AS unique_watchers
+FROM catalog.video_engagement_stats_daily
+WHERE video_id = sqlc.arg('video_id')
+  AND bucket_start >= sqlc.arg('from_time')
+  AND bucket_start < sqlc.arg('to_time')
+GROUP BY bucket_start
+ORDER BY bucket_start;
+
+-- 删除超过保留期的小时统计桶（至多 batch_size 行），SKIP LOCKED 避免与并发写入或其他清理实例互相阻塞
+-- name: PruneVideoEngagementStatsHourly :execrows
+WITH expired AS (
+    SELECT video_id, bucket_start, shard
+    FROM catalog.video_engagement_stats_hourly
+    WHERE bucket_start < sqlc.arg('before')
+    ORDER BY bucket_start
+    LIMIT sqlc.arg('batch_size')::integer
+    FOR UPDATE SKIP LOCKED
+)
+DELETE FROM catalog.video_engagement_stats_hourly b
+USING expired
+WHERE b.video_id = expired.video_id
+  AND b.bucket_start = expired.bucket_start
+  AND b.shard = expired.shard;
+
+-- 删除超过保留期的天级统计桶（至多 batch_size 行），SKIP LOCKED 避免与并发写入或其他清理实例互相阻塞
+-- name: PruneVideoEngagementStatsDaily :execrows
+WITH expired AS (
+    SELECT video_id, bucket_start, shard
+    FROM catalog.video_engagement_stats_daily
+    WHERE bucket_start < sqlc.arg('before')
+    ORDER BY bucket_start
+    LIMIT sqlc.arg('batch_size')::integer
+    FOR UPDATE SKIP LOCKED
+)
+DELETE FROM catalog.video_engagement_stats_daily b
+USING expired
+WHERE b.video_id = expired.video_id
+  AND b.bucket_start = expired.bucket_start
+  AND b.shard = expired.shard;
@@ -1,18 +1,58 @@
--- 统计投影读取
+-- 统计投影读取：累加主行与尚未压实的分片增量，计数不低于 0；两者均不存在时无结果
 -- name: GetVideoEngagementStats :one
+WITH shards AS (
+    SELECT
+        video_id,
+        SUM(like_delta) AS like_delta,
+        SUM(bookmark_delta) AS bookmark_delta,
+        SUM(watch_delta) AS watch_delta,
+        SUM(unique_watcher_delta) AS unique_watcher_delta,
+        SUM(watch_seconds_delta) AS watch_seconds_delta,
+        SUM(position_seconds_delta) AS position_seconds_delta,
+        SUM(position_viewer_delta) AS position_viewer_delta,
+        MIN(first_watch_at) AS first_watch_at,
+        MAX(last_watch_at) AS last_watch_at,
+        MAX(updated_at) AS updated_at,
+        SUM(share_delta) AS share_delta,
+        SUM(rating_count_delta) AS rating_count_delta,
+        SUM(rating_sum_delta) AS rating_sum_delta,
+        SUM(rating_1_delta) AS rating_1_delta,
+        SUM(rating_2_delta) AS rating_2_delta,
+        SUM(rating_3_delta) AS rating_3_delta,
+        SUM(rating_4_delta) AS rating_4_delta,
+        SUM(rating_5_delta) AS rating_5_delta
+    FROM catalog.video_engagement_stats_shards
+    WHERE video_id = sqlc.arg('video_id')
+    GROUP BY video_id
+)
 SELECT
-    video_id,
-    like_count,
-    bookmark_count,
-    watch_count,
-    unique_watchers,
-    first_watch_at,
-    last_watch_at,
-    updated_at
-FROM catalog.video_engagement_stats_projection
-WHERE video_id = $1;
+    COALESCE(s.video_id, sh.video_id)::uuid AS video_id,
+    GREATEST(0, COALESCE(s.like_count, 0) + COALESCE(sh.like_delta, 0))::bigint AS like_count,
+    GREATEST(0, COALESCE(s.bookmark_count, 0) + COALESCE(sh.bookmark_delta, 0))::bigint AS bookmark_count,
+    GREATEST(0, COALESCE(s.watch_count, 0) + COALESCE(sh.watch_delta, 0))::bigint AS watch_count,
+    GREATEST(0, COALESCE(s.unique_watchers, 0) + COALESCE(sh.unique_watcher_delta, 0))::bigint AS unique_watchers,
+    LEAST(s.first_watch_at, sh.first_watch_at)::timestamptz AS first_watch_at,
+    GREATEST(s.last_watch_at, sh.last_watch_at)::timestamptz AS last_watch_at,
+    GREATEST(s.updated_at, sh.updated_at)::timestamptz AS updated_at,
+    GREATEST(0, COALESCE(s.total_watch_seconds, 0) + COALESCE(sh.watch_seconds_delta, 0))::double precision AS total_watch_seconds,
+    GREATEST(0, COALESCE(s.position_sum_seconds, 0) + COALESCE(sh.position_seconds_delta, 0))::double precision AS position_sum_seconds,
+    GREATEST(0, COALESCE(s.position_viewers, 0) + COALESCE(sh.position_viewer_delta, 0))::bigint AS position_viewers,
+    GREATEST(0, COALESCE(s.share_count, 0) + COALESCE(sh.share_delta, 0))::bigint AS share_count,
+    GREATEST(0, COALESCE(s.rating_count, 0) + COALESCE(sh.rating_count_delta, 0))::bigint AS rating_count,
+    GREATEST(0, COALESCE(s.rating_sum, 0) + COALESCE(sh.rating_sum_delta, 0))::bigint AS rating_sum,
+    GREATEST(0, COALESCE(s.rating_1_count, 0) + COALESCE(sh.rating_1_delta, 0))::bigint AS rating_1_count,
+    GREATEST(0, COALESCE(s.rating_2_count, 0) + COALESCE(sh.rating_2_delta, 0))::bigint AS rating_2_count,
+    GREATEST(0, COALESCE(s.rating_3_count, 0) + COALESCE(sh.rating_3_delta, 0))::bigint AS rating_3_count,
+    GREATEST(0, COALESCE(s.rating_4_count, 0) + COALESCE(sh.rating_4_delta, 0))::bigint AS rating_4_count,
+    GREATEST(0, COALESCE(s.rating_5_count, 0) + COALESCE(sh.rating_5_delta, 0))::bigint AS rating_5_count
+FROM (
+    SELECT *
+    FROM catalog.video_engagement_stats_projection
+    WHERE video_id = sqlc.arg('video_id')
+) s
+FULL JOIN shards sh ON sh.video_id = s.video_id;
 
--- 增量更新统计计数
+-- 增量更新统计计数与观看时长累计值
 -- name: IncrementVideoEngagementStats :one
 INSERT INTO catalog.video_engagement_stats_projection (
     video_id,
@@ -22,7 +62,18 @@ INSERT INTO catalog.video_engagement_stats_projection (
     unique_watchers,
     first_watch_at,
     last_watch_at,
-    updated_at
+    updated_at,
+    total_watch_seconds,
+    position_sum_seconds,
+    position_viewers,
+    share_count,
+    rating_count,
+    rating_sum,
+    rating_1_count,
+    rating_2_count,
+    rating_3_count,
+    rating_4_count,
+    rating_5_count
 ) VALUES (
     sqlc.arg('video_id'),
     GREATEST(sqlc.arg('like_delta')::bigint, 0),
@@ -31,7 +82,18 @@ INSERT INTO catalog.video_engagement_stats_projection (
     GREATEST(sqlc.arg('unique_watcher_delta')::bigint, 0),
     sqlc.narg('first_watch_at'),
     sqlc.narg('last_watch_at'),
-    now()
+    now(),
+    GREATEST(sqlc.arg('watch_seconds_delta')::double precision, 0),
+    GREATEST(sqlc.arg('position_seconds_delta')::double precision, 0),
+    GREATEST(sqlc.arg('position_viewer_delta')::bigint, 0),
+    GREATEST(sqlc.arg('share_delta')::bigint, 0),
+    GREATEST(sqlc.arg('rating_count_delta')::bigint, 0),
+    GREATEST(sqlc.arg('rating_sum_delta')::bigint, 0),
+    GREATEST(sqlc.arg('rating_1_delta')::bigint, 0),
+    GREATEST(sqlc.arg('rating_2_delta')::bigint, 0),
+    GREATEST(sqlc.arg('rating_3_delta')::bigint, 0),
+    GREATEST(sqlc.arg('rating_4_delta')::bigint, 0),
+    GREATEST(sqlc.arg('rating_5_delta')::bigint, 0)
 )
 ON CONFLICT (video_id) DO UPDATE
 SET
@@ -39,6 +101,17 @@ SET
     bookmark_count = GREATEST(0, catalog.video_engagement_stats_projection.bookmark_count + sqlc.arg('bookmark_delta')::bigint),
     watch_count = GREATEST(0, catalog.video_engagement_stats_projection.watch_count + sqlc.arg('watch_delta')::bigint),
     unique_watchers = GREATEST(0, catalog.video_engagement_stats_projection.unique_watchers + sqlc.arg('unique_watcher_delta')::bigint),
+    total_watch_seconds = GREATEST(0, catalog.video_engagement_stats_projection.total_watch_seconds + sqlc.arg('watch_seconds_delta')::double precision),
+    position_sum_seconds = GREATEST(0, catalog.video_engagement_stats_projection.position_sum_seconds + sqlc.arg('position_seconds_delta')::double precision),
+    position_viewers = GREATEST(0, catalog.video_engagement_stats_projection.position_viewers + sqlc.arg('position_viewer_delta')::bigint),
+    share_count = GREATEST(0, catalog.video_engagement_stats_projection.share_count + sqlc.arg('share_delta')::bigint),
+    rating_count = GREATEST(0, catalog.video_engagement_stats_projection.rating_count + sqlc.arg('rating_count_delta')::bigint),
+    rating_sum = GREATEST(0, catalog.video_engagement_stats_projection.rating_sum + sqlc.arg('rating_sum_delta')::bigint),
+    rating_1_count = GREATEST(0, catalog.video_engagement_stats_projection.rating_1_count + sqlc.arg('rating_1_delta')::bigint),
+    rating_2_count = GREATEST(0, catalog.video_engagement_stats_projection.rating_2_count + sqlc.arg('rating_2_delta')::bigint),
+    rating_3_count = GREATEST(0, catalog.video_engagement_stats_projection.rating_3_count + sqlc.arg('rating_3_delta')::bigint),
+    rating_4_count = GREATEST(0, catalog.video_engagement_stats_projection.rating_4_count + sqlc.arg('rating_4_delta')::bigint),
+    rating_5_count = GREATEST(0, catalog.video_engagement_stats_projection.rating_5_count + sqlc.arg('rating_5_delta')::bigint),
     first_watch_at = CASE
         WHEN sqlc.narg('first_watch_at') IS NULL THEN catalog.video_engagement_stats_projection.first_watch_at
         WHEN catalog.video_engagement_stats_projection.first_watch_at IS NULL THEN sqlc.narg('first_watch_at')
@@ -58,7 +131,166 @@ RETURNING
     unique_watchers,
     first_watch_at,
     last_watch_at,
-    updated_at;
+    updated_at,
+    total_watch_seconds,
+    position_sum_seconds,
+    position_viewers,
+    share_count,
+    rating_count,
+    rating_sum,
+    rating_1_count,
+    rating_2_count,
+    rating_3_count,
+    rating_4_count,
+    rating_5_count;
+
+-- 分片计数模式：将增量累加到分片行，热门视频的并发事件分散到不同行上，不再在主行上排队
+-- name: IncrementVideoEngagementStatsShard :exec
+INSERT INTO catalog.video_engagement_stats_shards (
+    video_id,
+    shard,
+    like_delta,
+    bookmark_delta,
+    watch_delta,
+    unique_watcher_delta,
+    watch_seconds_delta,
+    position_seconds_delta,
+    position_viewer_delta,
+    first_watch_at,
+    last_watch_at,
+    updated_at,
+    share_delta,
+    rating_count_delta,
+    rating_sum_delta,
+    rating_1_delta,
+    rating_2_delta,
+    rating_3_delta,
+    rating_4_delta,
+    rating_5_delta
+) VALUES (
+    sqlc.arg('video_id'),
+    sqlc.arg('shard'),
+    sqlc.arg('like_delta'),
+    sqlc.arg('bookmark_delta'),
+    sqlc.arg('watch_delta'),
+    sqlc.arg('unique_watcher_delta'),
+    sqlc.arg('watch_seconds_delta'),
+    sqlc.arg('position_seconds_delta'),
+    sqlc.arg('position_viewer_delta'),
+    sqlc.narg('first_watch_at'),
+    sqlc.narg('last_watch_at'),
+    now(),
+    sqlc.arg('share_delta'),
+    sqlc.arg('rating_count_delta'),
+    sqlc.arg('rating_sum_delta'),
+    sqlc.arg('rating_1_delta'),
+    sqlc.arg('rating_2_delta'),
+    sqlc.arg('rating_3_delta'),
+    sqlc.arg('rating_4_delta'),
+    sqlc.arg('rating_5_delta')
+)
+ON CONFLICT (video_id, shard) DO UPDATE
+SET
+    like_delta = catalog.video_engagement_stats_shards.like_delta + EXCLUDED.like_delta,
+    bookmark_delta = catalog.video_engagement_stats_shards.bookmark_delta + EXCLUDED.bookmark_delta,
+    watch_delta = catalog.video_engagement_stats_shards.watch_delta + EXCLUDED.watch_delta,
+    unique_watcher_delta = catalog.video_engagement_stats_shards.unique_watcher_delta + EXCLUDED.unique_watcher_delta,
+    watch_seconds_delta = catalog.video_engagement_stats_shards.watch_seconds_delta + EXCLUDED.watch_seconds_delta,
+    position_seconds_delta = catalog.video_engagement_stats_shards.position_seconds_delta + EXCLUDED.position_seconds_delta,
+    position_viewer_delta = catalog.video_engagement_stats_shards.position_viewer_delta + EXCLUDED.position_viewer_delta,
+    first_watch_at = LEAST(catalog.video_engagement_stats_shards.first_watch_at, EXCLUDED.first_watch_at),
+    last_watch_at = GREATEST(catalog.video_engagement_stats_shards.last_watch_at, EXCLUDED.last_watch_at),
+    share_delta = catalog.video_engagement_stats_shards.share_delta + EXCLUDED.share_delta,
+    rating_count_delta = catalog.video_engagement_stats_shards.rating_count_delta + EXCLUDED.rating_count_delta,
+    rating_sum_delta = catalog.video_engagement_stats_shards.rating_sum_delta + EXCLUDED.rating_sum_delta,
+    rating_1_delta = catalog.video_engagement_stats_shards.rating_1_delta + EXCLUDED.rating_1_delta,
+    rating_2_delta = catalog.video_engagement_stats_shards.rating_2_delta + EXCLUDED.rating_2_delta,
+    rating_3_delta = catalog.video_engagement_stats_shards.rating_3_delta + EXCLUDED.rating_3_delta,
+    rating_4_delta = catalog.video_engagement_stats_shards.rating_4_delta + EXCLUDED.rating_4_delta,
+    rating_5_delta = catalog.video_engagement_stats_shards.rating_5_delta + EXCLUDED.rating_5_delta,
+    updated_at = now();
+
+-- 分片计数写入方在事务内获取视频统计的共享 advisory lock（按 video_id 排序加锁），与 Repair 的排他锁互斥：
+-- Repair 等待已在写分片的事务提交后再折叠与重算，之后的写入在 Repair 提交后才写分片
+-- name: LockVideoEngagementStatsShared :exec
+SELECT pg_advisory_xact_lock_shared(hashtextextended('catalog.engagement.stats:' || video_id::text, 0))
+FROM (
+    SELECT DISTINCT video_id
+    FROM unnest(sqlc.arg('video_ids')::uuid[]) AS ids(video_id)
+    ORDER BY video_id
+) locked;
+
+-- 压实循环非阻塞获取单个视频统计的共享锁；视频正被 Repair 持有排他锁时返回 false，本轮跳过该视频
+-- name: TryLockVideoEngagementStatsShared :one
+SELECT pg_try_advisory_xact_lock_shared(hashtextextended('catalog.engagement.stats:' || sqlc.arg('video_id')::uuid::text, 0))::boolean AS acquired;
+
+-- 按分片行最早写入时间列出待压实的视频
+-- name: ListVideoEngagementStatsShardVideos :many
+SELECT video_id
+FROM catalog.video_engagement_stats_shards
+GROUP BY video_id
+ORDER BY min(updated_at), video_id
+LIMIT sqlc.arg('limit');
+
+-- 压实：取出并删除一批分片行（跳过正被并发事务写入的行），按视频汇总增量，由调用方在同一事务内累加回主行；
+-- video_ids 为 NULL 时不限视频。调用方须已持有这些视频的统计 advisory lock（共享或排他）
+-- name: TakeVideoEngagementStatsShards :many
+WITH picked AS (
+    SELECT video_id, shard
+    FROM catalog.video_engagement_stats_shards
+    WHERE sqlc.narg('video_ids')::uuid[] IS NULL
+       OR video_id = ANY(sqlc.narg('video_ids')::uuid[])
+    ORDER BY updated_at
+    LIMIT sqlc.arg('limit')
+    FOR UPDATE SKIP LOCKED
+), taken AS (
+    DELETE FROM catalog.video_engagement_stats_shards s
+    USING picked p
+    WHERE s.video_id = p.video_id
+      AND s.shard = p.shard
+    RETURNING
+        s.video_id,
+        s.like_delta,
+        s.bookmark_delta,
+        s.watch_delta,
+        s.unique_watcher_delta,
+        s.watch_seconds_delta,
+        s.position_seconds_delta,
+        s.position_viewer_delta,
+        s.first_watch_at,
+        s.last_watch_at,
+        s.share_delta,
+        s.rating_count_delta,
+        s.rating_sum_delta,
+        s.rating_1_delta,
+        s.rating_2_delta,
+        s.rating_3_delta,
+        s.rating_4_delta,
+        s.rating_5_delta
+)
+SELECT
+    video_id,
+    SUM(like_delta)::bigint AS like_delta,
+    SUM(bookmark_delta)::bigint AS bookmark_delta,
+    SUM(watch_delta)::bigint AS watch_delta,
+    SUM(unique_watcher_delta)::bigint AS unique_watcher_delta,
+    SUM(watch_seconds_delta)::double precision AS watch_seconds_delta,
+    SUM(position_seconds_delta)::double precision AS position_seconds_delta,
+    SUM(position_viewer_delta)::bigint AS position_viewer_delta,
+    MIN(first_watch_at)::timestamptz AS first_watch_at,
+    MAX(last_watch_at)::timestamptz AS last_watch_at,
+    count(*)::bigint AS shard_rows,
+    SUM(share_delta)::bigint AS share_delta,
+    SUM(rating_count_delta)::bigint AS rating_count_delta,
+    SUM(rating_sum_delta)::bigint AS rating_sum_delta,
+    SUM(rating_1_delta)::bigint AS rating_1_delta,
+    SUM(rating_2_delta)::bigint AS rating_2_delta,
+    SUM(rating_3_delta)::bigint AS rating_3_delta,
+    SUM(rating_4_delta)::bigint AS rating_4_delta,
+    SUM(rating_5_delta)::bigint AS rating_5_delta
+FROM taken
+GROUP BY video_id
+ORDER BY video_id;
 
 -- 记录唯一观看者
 -- name: UpsertVideoWatcher :one
@@ -81,3 +313,72 @@ RETURNING
     first_watched_at,
     last_watched_at,
     (xmax = 0) AS inserted;
+
+-- 锁定用户-视频的当前播放会话；会话不存在时先插入占位行再加锁，避免并发首条进度各自按新会话处理。
+-- inserted 为 true 表示占位行由本次调用插入，调用方须在同一事务内以 UpsertVideoViewSession 覆盖。
+-- name: LockVideoViewSession :one
+INSERT INTO catalog.video_view_sessions (
+    video_id,
+    user_id,
+    session_started_at,
+    last_progress_at
+) VALUES (
+    sqlc.arg('video_id'),
+    sqlc.arg('user_id'),
+    now(),
+    now()
+)
+ON CONFLICT (video_id, user_id) DO UPDATE
+SET video_id = EXCLUDED.video_id
+RETURNING
+    video_id,
+    user_id,
+    session_started_at,
+    last_progress_at,
+    baseline_watch_seconds,
+    last_watch_seconds,
+    counted_at,
+    updated_at,
+    max_position_seconds,
+    last_position_seconds,
+    position_tracked,
+    (xmax = 0)::bool AS inserted;
+
+-- 写入播放会话状态
+-- name: UpsertVideoViewSession :exec
+INSERT INTO catalog.video_view_sessions (
+    video_id,
+    user_id,
+    session_started_at,
+    last_progress_at,
+    baseline_watch_seconds,
+    last_watch_seconds,
+    counted_at,
+    updated_at,
+    max_position_seconds,
+    last_position_seconds,
+    position_tracked
+) VALUES (
+    sqlc.arg('video_id'),
+    sqlc.arg('user_id'),
+    sqlc.arg('session_started_at'),
+    sqlc.arg('last_progress_at'),
+    sqlc.arg('baseline_watch_seconds'),
+    sqlc.arg('last_watch_seconds'),
+    sqlc.narg('counted_at'),
+    now(),
+    sqlc.arg('max_position_seconds'),
+    sqlc.arg('last_position_seconds'),
+    true
+)
+ON CONFLICT (video_id, user_id) DO UPDATE
+SET
+    session_started_at = EXCLUDED.session_started_at,
+    last_progress_at = EXCLUDED.last_progress_at,
+    baseline_watch_seconds = EXCLUDED.baseline_watch_seconds,
+    last_watch_seconds = EXCLUDED.last_watch_seconds,
+    counted_at = EXCLUDED.counted_at,
+    max_position_seconds = EXCLUDED.max_position_seconds,
+    last_position_seconds = EXCLUDED.last_position_seconds,
+    position_tracked = true,
+    updated_at = now();
@@ -13,23 +13,85 @@ import (
 )
 
 const getVideoEngagementStats = `-- name: GetVideoEngagementStats :one
+WITH shards AS (
+    SELECT
+        video_id,
+        SUM(like_delta) AS like_delta,
+        SUM(bookmark_delta) AS bookmark_delta,
+        SUM(watch_delta) AS watch_delta,
+        SUM(unique_watcher_delta) AS unique_watcher_delta,
+        SUM(watch_seconds_delta) AS watch_seconds_delta,
+        SUM(position_seconds_delta) AS position_seconds_delta,
+        SUM(position_viewer_delta) AS position_viewer_delta,
+        MIN(first_watch_at) AS first_watch_at,
+        MAX(last_watch_at) AS last_watch_at,
+        MAX(updated_at) AS updated_at,
+        SUM(share_delta) AS share_delta,
+        SUM(rating_count_delta) AS rating_count_delta,
+        SUM(rating_sum_delta) AS rating_sum_delta,
+        SUM(rating_1_delta) AS rating_1_delta,
+        SUM(rating_2_delta) AS rating_2_delta,
+        SUM(rating_3_delta) AS rating_3_delta,
+        SUM(rating_4_delta) AS rating_4_delta,
+        SUM(rating_5_delta) AS rating_5_delta
+    FROM catalog.video_engagement_stats_shards
+    WHERE video_id = $1
+    GROUP BY video_id
+)
 SELECT
-    video_id,
-    like_count,
-    bookmark_count,
-    watch_count,
-    unique_watchers,
-    first_watch_at,
-    last_watch_at,
-    updated_at
-FROM catalog.video_engagement_stats_projection
-WHERE video_id = $1
+    COALESCE(s.video_id, sh.video_id)::uuid AS video_id,
+    GREATEST(0, COALESCE(s.like_count, 0) + COALESCE(sh.like_delta, 0))::bigint AS like_count,
+    GREATEST(0, COALESCE(s.bookmark_count, 0) + COALESCE(sh.bookmark_delta, 0))::bigint AS bookmark_count,
+    GREATEST(0, COALESCE(s.watch_count, 0) + COALESCE(sh.watch_delta, 0))::bigint AS watch_count,
+    GREATEST(0, COALESCE(s.unique_watchers, 0) + COALESCE(sh.unique_watcher_delta, 0))::bigint AS unique_watchers,
+    LEAST(s.first_watch_at, sh.first_watch_at)::timestamptz AS first_watch_at,
+    GREATEST(s.last_watch_at, sh.last_watch_at)::timestamptz AS last_watch_at,
+    GREATEST(s.updated_at, sh.updated_at)::timestamptz AS updated_at,
+    GREATEST(0, COALESCE(s.total_watch_seconds, 0) + COALESCE(sh.watch_seconds_delta, 0))::double precision AS total_watch_seconds,
+    GREATEST(0, COALESCE(s.position_sum_seconds, 0) + COALESCE(sh.position_seconds_delta, 0))::double precision AS position_sum_seconds,
+    GREATEST(0, COALESCE(s.position_viewers, 0) + COALESCE(sh.position_viewer_delta, 0))::bigint AS position_viewers,
+    GREATEST(0, COALESCE(s.share_count, 0) + COALESCE(sh.share_delta, 0))::bigint AS share_count,
+    GREATEST(0, COALESCE(s.rating_count, 0) + COALESCE(sh.rating_count_delta, 0))::bigint AS rating_count,
+    GREATEST(0, COALESCE(s.rating_sum, 0) + COALESCE(sh.rating_sum_delta, 0))::bigint AS rating_sum,
+    GREATEST(0, COALESCE(s.rating_1_count, 0) + COALESCE(sh.rating_1_delta, 0))::bigint AS rating_1_count,
+    GREATEST(0, COALESCE(s.rating_2_count, 0) + COALESCE(sh.rating_2_delta, 0))::bigint AS rating_2_count,
+    GREATEST(0, COALESCE(s.rating_3_count, 0) + COALESCE(sh.rating_3_delta, 0))::bigint AS rating_3_count,
+    GREATEST(0, COALESCE(s.rating_4_count, 0) + COALESCE(sh.rating_4_delta, 0))::bigint AS rating_4_count,
+    GREATEST(0, COALESCE(s.rating_5_count, 0) + COALESCE(sh.rating_5_delta, 0))::bigint AS rating_5_count
+FROM (
+    SELECT *
+    FROM catalog.video_engagement_stats_projection
+    WHERE video_id = $1
+) s
+FULL JOIN shards sh ON sh.video_id = s.video_id
 `
 
-// 统计投影读取
-func (q *Queries) GetVideoEngagementStats(ctx context.Context, videoID uuid.UUID) (CatalogVideoEngagementStatsProjection, error) {
+type GetVideoEngagementStatsRow struct {
+	VideoID            uuid.UUID          `json:"video_id"`
+	LikeCount          int64              `json:"like_count"`
+	BookmarkCount      int64              `json:"bookmark_count"`
+	WatchCount         int64              `json:"watch_count"`
+	UniqueWatchers     int64              `json:"unique_watchers"`
+	FirstWatchAt       pgtype.Timestamptz `json:"first_watch_at"`
+	LastWatchAt        pgtype.Timestamptz `json:"last_watch_at"`
+	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
+	TotalWatchSeconds  float64            `json:"total_watch_seconds"`
+	PositionSumSeconds float64            `json:"position_sum_seconds"`
+	PositionViewers    int64              `json:"position_viewers"`
+	ShareCount         int64              `json:"share_count"`
+	RatingCount        int64              `json:"rating_count"`
+	RatingSum          int64              `json:"rating_sum"`
+	Rating1Count       int64              `json:"rating_1_count"`
+	Rating2Count       int64              `json:"rating_2_count"`
+	Rating3Count       int64              `json:"rating_3_count"`
+	Rating4Count       int64              `json:"rating_4_count"`
+	Rating5Count       int64              `json:"rating_5_count"`
+}
+
+// 统计投影读取：累加主行与尚未压实的分片增量，计数不低于 0；两者均不存在时无结果
+func (q *Queries) GetVideoEngagementStats(ctx context.Context, videoID uuid.UUID) (GetVideoEngagementStatsRow, error) {
 	row := q.db.QueryRow(ctx, getVideoEngagementStats, videoID)
-	var i CatalogVideoEngagementStatsProjection
+	var i GetVideoEngagementStatsRow
 	err := row.Scan(
 		&i.VideoID,
 		&i.LikeCount,
@@ -39,6 +101,17 @@ func (q *Queries) GetVideoEngagementStats(ctx context.Context, videoID uuid.UUID
 		&i.FirstWatchAt,
 		&i.LastWatchAt,
 		&i.UpdatedAt,
+		&i.TotalWatchSeconds,
+		&i.PositionSumSeconds,
+		&i.PositionViewers,
+		&i.ShareCount,
+		&i.RatingCount,
+		&i.RatingSum,
+		&i.Rating1Count,
+		&i.Rating2Count,
+		&i.Rating3Count,
+		&i.Rating4Count,
+		&i.Rating5Count,
 	)
 	return i, err
 }
@@ -52,7 +125,18 @@ INSERT INTO catalog.video_engagement_stats_projection (
     unique_watchers,
     first_watch_at,
     last_watch_at,
-    updated_at
+    updated_at,
+    total_watch_seconds,
+    position_sum_seconds,
+    position_viewers,
+    share_count,
+    rating_count,
+    rating_sum,
+    rating_1_count,
+    rating_2_count,
+    rating_3_count,
+    rating_4_count,
+    rating_5_count
 ) VALUES (
     $1,
     GREATEST($2::bigint, 0),
@@ -61,7 +145,18 @@ INSERT INTO catalog.video_engagement_stats_projection (
     GREATEST($5::bigint, 0),
     $6,
     $7,
-    now()
+    now(),
+    GREATEST($8::double precision, 0),
+    GREATEST($9::double precision, 0),
+    GREATEST($10::bigint, 0),
+    GREATEST($11::bigint, 0),
+    GREATEST($12::bigint, 0),
+    GREATEST($13::bigint, 0),
+    GREATEST($14::bigint, 0),
+    GREATEST($15::bigint, 0),
+    GREATEST($16::bigint, 0),
+    GREATEST($17::bigint, 0),
+    GREATEST($18::bigint, 0)
 )
 ON CONFLICT (video_id) DO UPDATE
 SET
@@ -69,6 +164,17 @@ SET
     bookmark_count = GREATEST(0, catalog.video_engagement_stats_projection.bookmark_count + $3::bigint),
     watch_count = GREATEST(0, catalog.video_engagement_stats_projection.watch_count + $4::bigint),
     unique_watchers = GREATEST(0, catalog.video_engagement_stats_projection.unique_watchers + $5::bigint),
+    total_watch_seconds = GREATEST(0, catalog.video_engagement_stats_projection.total_watch_seconds + $8::double precision),
+    position_sum_seconds = GREATEST(0, catalog.video_engagement_stats_projection.position_sum_seconds + $9::double precision),
+    position_viewers = GREATEST(0, catalog.video_engagement_stats_projection.position_viewers + $10::bigint),
+    share_count = GREATEST(0, catalog.video_engagement_stats_projection.share_count + $11::bigint),
+    rating_count = GREATEST(0, catalog.video_engagement_stats_projection.rating_count + $12::bigint),
+    rating_sum = GREATEST(0, catalog.video_engagement_stats_projection.rating_sum + $13::bigint),
+    rating_1_count = GREATEST(0, catalog.video_engagement_stats_projection.rating_1_count + $14::bigint),
+    rating_2_count = GREATEST(0, catalog.video_engagement_stats_projection.rating_2_count + $15::bigint),
+    rating_3_count = GREATEST(0, catalog.video_engagement_stats_projection.rating_3_count + $16::bigint),
+    rating_4_count = GREATEST(0, catalog.video_engagement_stats_projection.rating_4_count + $17::bigint),
+    rating_5_count = GREATEST(0, catalog.video_engagement_stats_projection.rating_5_count + $18::bigint),
     first_watch_at = CASE
         WHEN $6 IS NULL THEN catalog.video_engagement_stats_projection.first_watch_at
         WHEN catalog.video_engagement_stats_projection.first_watch_at IS NULL THEN $6
@@ -88,20 +194,42 @@ RETURNING
     unique_watchers,
     first_watch_at,
     last_watch_at,
-    updated_at
+    updated_at,
+    total_watch_seconds,
+    position_sum_seconds,
+    position_viewers,
+    share_count,
+    rating_count,
+    rating_sum,
+    rating_1_count,
+    rating_2_count,
+    rating_3_count,
+    rating_4_count,
+    rating_5_count
 `
 
 type IncrementVideoEngagementStatsParams struct {
-	VideoID            uuid.UUID          `json:"video_id"`
-	LikeDelta          int64              `json:"like_delta"`
-	BookmarkDelta      int64              `json:"bookmark_delta"`
-	WatchDelta         int64              `json:"watch_delta"`
-	UniqueWatcherDelta int64              `json:"unique_watcher_delta"`
-	FirstWatchAt       pgtype.Timestamptz `json:"first_watch_at"`
-	LastWatchAt        pgtype.Timestamptz `json:"last_watch_at"`
+	VideoID              uuid.UUID          `json:"video_id"`
+	LikeDelta            int64              `json:"like_delta"`
+	BookmarkDelta        int64              `json:"bookmark_delta"`
+	WatchDelta           int64              `json:"watch_delta"`
+	UniqueWatcherDelta   int64              `json:"unique_watcher_delta"`
+	FirstWatchAt         pgtype.Timestamptz `json:"first_watch_at"`
+	LastWatchAt          pgtype.Timestamptz `json:"last_watch_at"`
+	WatchSecondsDelta    float64            `json:"watch_seconds_delta"`
+	PositionSecondsDelta float64            `json:"position_seconds_delta"`
+	PositionViewerDelta  int64              `json:"position_viewer_delta"`
+	ShareDelta           int64              `json:"share_delta"`
+	RatingCountDelta     int64              `json:"rating_count_delta"`
+	RatingSumDelta       int64              `json:"rating_sum_delta"`
+	Rating1Delta         int64              `json:"rating_1_delta"`
+	Rating2Delta         int64              `json:"rating_2_delta"`
+	Rating3Delta         int64              `json:"rating_3_delta"`
+	Rating4Delta         int64              `json:"rating_4_delta"`
+	Rating5Delta         int64              `json:"rating_5_delta"`
 }
 
-// 增量更新统计计数
+// 增量更新统计计数与观看时长累计值
 func (q *Queries) IncrementVideoEngagementStats(ctx context.Context, arg IncrementVideoEngagementStatsParams) (CatalogVideoEngagementStatsProjection, error) {
 	row := q.db.QueryRow(ctx, incrementVideoEngagementStats,
 		arg.VideoID,
@@ -111,6 +239,17 @@ func (q *Queries) IncrementVideoEngagementStats(ctx context.Context, arg Increme
 		arg.UniqueWatcherDelta,
 		arg.FirstWatchAt,
 		arg.LastWatchAt,
+		arg.WatchSecondsDelta,
+		arg.PositionSecondsDelta,
+		arg.PositionViewerDelta,
+		arg.ShareDelta,
+		arg.RatingCountDelta,
+		arg.RatingSumDelta,
+		arg.Rating1Delta,
+		arg.Rating2Delta,
+		arg.Rating3Delta,
+		arg.Rating4Delta,
+		arg.Rating5Delta,
 	)
 	var i CatalogVideoEngagementStatsProjection
 	err := row.Scan(
@@ -122,10 +261,458 @@ func (q *Queries) IncrementVideoEngagementStats(ctx context.Context, arg Increme
 		&i.FirstWatchAt,
 		&i.LastWatchAt,
 		&i.UpdatedAt,
+		&i.TotalWatchSeconds,
+		&i.PositionSumSeconds,
+		&i.PositionViewers,
+		&i.ShareCount,
+		&i.RatingCount,
+		&i.RatingSum,
+		&i.Rating1Count,
+		&i.Rating2Count,
+		&i.Rating3Count,
+		&i.Rating4Count,
+		&i.Rating5Count,
 	)
 	return i, err
 }
 
+const incrementVideoEngagementStatsShard = `-- name: IncrementVideoEngagementStatsShard :exec
+INSERT INTO catalog.video_engagement_stats_shards (
+    video_id,
+    shard,
+    like_delta,
+    bookmark_delta,
+    watch_delta,
+    unique_watcher_delta,
+    watch_seconds_delta,
+    position_seconds_delta,
+    position_viewer_delta,
+    first_watch_at,
+    last_watch_at,
+    updated_at,
+    share_delta,
+    rating_count_delta,
+    rating_sum_delta,
+    rating_1_delta,
+    rating_2_delta,
+    rating_3_delta,
+    rating_4_delta,
+    rating_5_delta
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8,
+    $9,
+    $10,
+    $11,
+    now(),
+    $12,
+    $13,
+    $14,
+    $15,
+    $16,
+    $17,
+    $18,
+    $19
+)
+ON CONFLICT (video_id, shard) DO UPDATE
+SET
+    like_delta = catalog.video_engagement_stats_shards.like_delta + EXCLUDED.like_delta,
+    bookmark_delta = catalog.video_engagement_stats_shards.bookmark_delta + EXCLUDED.bookmark_delta,
+    watch_delta = catalog.video_engagement_stats_shards.watch_delta + EXCLUDED.watch_delta,
+    unique_watcher_delta = catalog.video_engagement_stats_shards.unique_watcher_delta + EXCLUDED.unique_watcher_delta,
+    watch_seconds_delta = catalog.video_engagement_stats_shards.watch_seconds_delta + EXCLUDED.watch_seconds_delta,
+    position_seconds_delta = catalog.video_engagement_stats_shards.position_seconds_delta + EXCLUDED.position_seconds_delta,
+    position_viewer_delta = catalog.video_engagement_stats_shards.position_viewer_delta + EXCLUDED.position_viewer_delta,
+    first_watch_at = LEAST(catalog.video_engagement_stats_shards.first_watch_at, EXCLUDED.first_watch_at),
+    last_watch_at = GREATEST(catalog.video_engagement_stats_shards.last_watch_at, EXCLUDED.last_watch_at),
+    share_delta = catalog.video_engagement_stats_shards.share_delta + EXCLUDED.share_delta,
+    rating_count_delta = catalog.video_engagement_stats_shards.rating_count_delta + EXCLUDED.rating_count_delta,
+    rating_sum_delta = catalog.video_engagement_stats_shards.rating_sum_delta + EXCLUDED.rating_sum_delta,
+    rating_1_delta = catalog.video_engagement_stats_shards.rating_1_delta + EXCLUDED.rating_1_delta,
+    rating_2_delta = catalog.video_engagement_stats_shards.rating_2_delta + EXCLUDED.rating_2_delta,
+    rating_3_delta = catalog.video_engagement_stats_shards.rating_3_delta + EXCLUDED.rating_3_delta,
+    rating_4_delta = catalog.video_engagement_stats_shards.rating_4_delta + EXCLUDED.rating_4_delta,
+    rating_5_delta = catalog.video_engagement_stats_shards.rating_5_delta + EXCLUDED.rating_5_delta,
+    updated_at = now()
+`
+
+type IncrementVideoEngagementStatsShardParams struct {
+	VideoID              uuid.UUID          `json:"video_id"`
+	Shard                int16              `json:"shard"`
+	LikeDelta            int64              `json:"like_delta"`
+	BookmarkDelta        int64              `json:"bookmark_delta"`
+	WatchDelta           int64              `json:"watch_delta"`
+	UniqueWatcherDelta   int64              `json:"unique_watcher_delta"`
+	WatchSecondsDelta    float64            `json:"watch_seconds_delta"`
+	PositionSecondsDelta float64            `json:"position_seconds_delta"`
+	PositionViewerDelta  int64              `json:"position_viewer_delta"`
+	FirstWatchAt         pgtype.Timestamptz `json:"first_watch_at"`
+	LastWatchAt          pgtype.Timestamptz `json:"last_watch_at"`
+	ShareDelta           int64              `json:"share_delta"`
+	RatingCountDelta     int64              `json:"rating_count_delta"`
+	RatingSumDelta       int64              `json:"rating_sum_delta"`
+	Rating1Delta         int64              `json:"rating_1_delta"`
+	Rating2Delta         int64              `json:"rating_2_delta"`
+	Rating3Delta         int64              `json:"rating_3_delta"`
+	Rating4Delta         int64              `json:"rating_4_delta"`
+	Rating5Delta         int64              `json:"rating_5_delta"`
+}
+
+// 分片计数模式：将增量累加到分片行，热门视频的并发事件分散到不同行上，不再在主行上排队
+func (q *Queries) IncrementVideoEngagementStatsShard(ctx context.Context, arg IncrementVideoEngagementStatsShardParams) error {
+	_, err := q.db.Exec(ctx, incrementVideoEngagementStatsShard,
+		arg.VideoID,
+		arg.Shard,
+		arg.LikeDelta,
+		arg.BookmarkDelta,
+		arg.WatchDelta,
+		arg.UniqueWatcherDelta,
+		arg.WatchSecondsDelta,
+		arg.PositionSecondsDelta,
+		arg.PositionViewerDelta,
+		arg.FirstWatchAt,
+		arg.LastWatchAt,
+		arg.ShareDelta,
+		arg.RatingCountDelta,
+		arg.RatingSumDelta,
+		arg.Rating1Delta,
+		arg.Rating2Delta,
+		arg.Rating3Delta,
+		arg.Rating4Delta,
+		arg.Rating5Delta,
+	)
+	return err
+}
+
+const listVideoEngagementStatsShardVideos = `-- name: ListVideoEngagementStatsShardVideos :many
+SELECT video_id
+FROM catalog.video_engagement_stats_shards
+GROUP BY video_id
+ORDER BY min(updated_at), video_id
+LIMIT $1
+`
+
+// 按分片行最早写入时间列出待压实的视频
+func (q *Queries) ListVideoEngagementStatsShardVideos(ctx context.Context, limit int32) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, listVideoEngagementStatsShardVideos, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var video_id uuid.UUID
+		if err := rows.Scan(&video_id); err != nil {
+			return nil, err
+		}
+		items = append(items, video_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockVideoEngagementStatsShared = `-- name: LockVideoEngagementStatsShared :exec
+SELECT pg_advisory_xact_lock_shared(hashtextextended('catalog.engagement.stats:' || video_id::text, 0))
+FROM (
+    SELECT DISTINCT video_id
+    FROM unnest($1::uuid[]) AS ids(video_id)
+    ORDER BY video_id
+) locked
+`
+
+// 分片计数写入方在事务内获取视频统计的共享 advisory lock（按 video_id 排序加锁），与 Repair 的排他锁互斥：
+// Repair 等待已在写分片的事务提交后再折叠与重算，之后的写入在 Repair 提交后才写分片
+func (q *Queries) LockVideoEngagementStatsShared(ctx context.Context, videoIds []uuid.UUID) error {
+	_, err := q.db.Exec(ctx, lockVideoEngagementStatsShared, videoIds)
+	return err
+}
+
+const lockVideoViewSession = `-- name: LockVideoViewSession :one
+INSERT INTO catalog.video_view_sessions (
+    video_id,
+    user_id,
+    session_started_at,
+    last_progress_at
+) VALUES (
+    $1,
+    $2,
+    now(),
+    now()
+)
+ON CONFLICT (video_id, user_id) DO UPDATE
+SET video_id = EXCLUDED.video_id
+RETURNING
+    video_id,
+    user_id,
+    session_started_at,
+    last_progress_at,
+    baseline_watch_seconds,
+    last_watch_seconds,
+    counted_at,
+    updated_at,
+    max_position_seconds,
+    last_position_seconds,
+    position_tracked,
+    (xmax = 0)::bool AS inserted
+`
+
+type LockVideoViewSessionParams struct {
+	VideoID uuid.UUID `json:"video_id"`
+	UserID  uuid.UUID `json:"user_id"`
+}
+
+type LockVideoViewSessionRow struct {
+	VideoID              uuid.UUID          `json:"video_id"`
+	UserID               uuid.UUID          `json:"user_id"`
+	SessionStartedAt     pgtype.Timestamptz `json:"session_started_at"`
+	LastProgressAt       pgtype.Timestamptz `json:"last_progress_at"`
+	BaselineWatchSeconds float64            `json:"baseline_watch_seconds"`
+	LastWatchSeconds     float64            `json:"last_watch_seconds"`
+	CountedAt            pgtype.Timestamptz `json:"counted_at"`
+	UpdatedAt            pgtype.Timestamptz `json:"updated_at"`
+	MaxPositionSeconds   float64            `json:"max_position_seconds"`
+	LastPositionSeconds  float64            `json:"last_position_seconds"`
+	PositionTracked      bool               `json:"position_tracked"`
+	Inserted             bool               `json:"inserted"`
+}
+
+// 锁定用户-视频的当前播放会话；会话不存在时先插入占位行再加锁，避免并发首条进度各自按新会话处理。
+// inserted 为 true 表示占位行由本次调用插入，调用方须在同一事务内以 UpsertVideoViewSession 覆盖。
+func (q *Queries) LockVideoViewSession(ctx context.Context, arg LockVideoViewSessionParams) (LockVideoViewSessionRow, error) {
+	row := q.db.QueryRow(ctx, lockVideoViewSession, arg.VideoID, arg.UserID)
+	var i LockVideoViewSessionRow
+	err := row.Scan(
+		&i.VideoID,
+		&i.UserID,
+		&i.SessionStartedAt,
+		&i.LastProgressAt,
+		&i.BaselineWatchSeconds,
+		&i.LastWatchSeconds,
+		&i.CountedAt,
+		&i.UpdatedAt,
+		&i.MaxPositionSeconds,
+		&i.LastPositionSeconds,
+		&i.PositionTracked,
+		&i.Inserted,
+	)
+	return i, err
+}
+
+const takeVideoEngagementStatsShards = `-- name: TakeVideoEngagementStatsShards :many
+WITH picked AS (
+    SELECT video_id, shard
+    FROM catalog.video_engagement_stats_shards
+    WHERE $1::uuid[] IS NULL
+       OR video_id = ANY($1::uuid[])
+    ORDER BY updated_at
+    LIMIT $2
+    FOR UPDATE SKIP LOCKED
+), taken AS (
+    DELETE FROM catalog.video_engagement_stats_shards s
+    USING picked p
+    WHERE s.video_id = p.video_id
+      AND s.shard = p.shard
+    RETURNING
+        s.video_id,
+        s.like_delta,
+        s.bookmark_delta,
+        s.watch_delta,
+        s.unique_watcher_delta,
+        s.watch_seconds_delta,
+        s.position_seconds_delta,
+        s.position_viewer_delta,
+        s.first_watch_at,
+        s.last_watch_at,
+        s.share_delta,
+        s.rating_count_delta,
+        s.rating_sum_delta,
+        s.rating_1_delta,
+        s.rating_2_delta,
+        s.rating_3_delta,
+        s.rating_4_delta,
+        s.rating_5_delta
+)
+SELECT
+    video_id,
+    SUM(like_delta)::bigint AS like_delta,
+    SUM(bookmark_delta)::bigint AS bookmark_delta,
+    SUM(watch_delta)::bigint AS watch_delta,
+    SUM(unique_watcher_delta)::bigint AS unique_watcher_delta,
+    SUM(watch_seconds_delta)::double precision AS watch_seconds_delta,
+    SUM(position_seconds_delta)::double precision AS position_seconds_delta,
+    SUM(position_viewer_delta)::bigint AS position_viewer_delta,
+    MIN(first_watch_at)::timestamptz AS first_watch_at,
+    MAX(last_watch_at)::timestamptz AS last_watch_at,
+    count(*)::bigint AS shard_rows,
+    SUM(share_delta)::bigint AS share_delta,
+    SUM(rating_count_delta)::bigint AS rating_count_delta,
+    SUM(rating_sum_delta)::bigint AS rating_sum_delta,
+    SUM(rating_1_delta)::bigint AS rating_1_delta,
+    SUM(rating_2_delta)::bigint AS rating_2_delta,
+    SUM(rating_3_delta)::bigint AS rating_3_delta,
+    SUM(rating_4_delta)::bigint AS rating_4_delta,
+    SUM(rating_5_delta)::bigint AS rating_5_delta
+FROM taken
+GROUP BY video_id
+ORDER BY video_id
+`
+
+type TakeVideoEngagementStatsShardsParams struct {
+	VideoIds []uuid.UUID `json:"video_ids"`
+	Limit    int32       `json:"limit"`
+}
+
+type TakeVideoEngagementStatsShardsRow struct {
+	VideoID              uuid.UUID          `json:"video_id"`
+	LikeDelta            int64              `json:"like_delta"`
+	BookmarkDelta        int64              `json:"bookmark_delta"`
+	WatchDelta           int64              `json:"watch_delta"`
+	UniqueWatcherDelta   int64              `json:"unique_watcher_delta"`
+	WatchSecondsDelta    float64            `json:"watch_seconds_delta"`
+	PositionSecondsDelta float64            `json:"position_seconds_delta"`
+	PositionViewerDelta  int64              `json:"position_viewer_delta"`
+	FirstWatchAt         pgtype.Timestamptz `json:"first_watch_at"`
+	LastWatchAt          pgtype.Timestamptz `json:"last_watch_at"`
+	ShardRows            int64              `json:"shard_rows"`
+	ShareDelta           int64              `json:"share_delta"`
+	RatingCountDelta     int64              `json:"rating_count_delta"`
+	RatingSumDelta       int64              `json:"rating_sum_delta"`
+	Rating1Delta         int64              `json:"rating_1_delta"`
+	Rating2Delta         int64              `json:"rating_2_delta"`
+	Rating3Delta         int64              `json:"rating_3_delta"`
+	Rating4Delta         int64              `json:"rating_4_delta"`
+	Rating5Delta         int64              `json:"rating_5_delta"`
+}
+
+// 压实：取出并删除一批分片行（跳过正被并发事务写入的行），按视频汇总增量，由调用方在同一事务内累加回主行；
+// video_ids 为 NULL 时不限视频。调用方须已持有这些视频的统计 advisory lock（共享或排他）
+func (q *Queries) TakeVideoEngagementStatsShards(ctx context.Context, arg TakeVideoEngagementStatsShardsParams) ([]TakeVideoEngagementStatsShardsRow, error) {
+	rows, err := q.db.Query(ctx, takeVideoEngagementStatsShards, arg.VideoIds, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TakeVideoEngagementStatsShardsRow{}
+	for rows.Next() {
+		var i TakeVideoEngagementStatsShardsRow
+		if err := rows.Scan(
+			&i.VideoID,
+			&i.LikeDelta,
+			&i.BookmarkDelta,
+			&i.WatchDelta,
+			&i.UniqueWatcherDelta,
+			&i.WatchSecondsDelta,
+			&i.PositionSecondsDelta,
+			&i.PositionViewerDelta,
+			&i.FirstWatchAt,
+			&i.LastWatchAt,
+			&i.ShardRows,
+			&i.ShareDelta,
+			&i.RatingCountDelta,
+			&i.RatingSumDelta,
+			&i.Rating1Delta,
+			&i.Rating2Delta,
+			&i.Rating3Delta,
+			&i.Rating4Delta,
+			&i.Rating5Delta,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const tryLockVideoEngagementStatsShared = `-- name: TryLockVideoEngagementStatsShared :one
+SELECT pg_try_advisory_xact_lock_shared(hashtextextended('catalog.engagement.stats:' || $1::uuid::text, 0))::boolean AS acquired
+`
+
+// 压实循环非阻塞获取单个视频统计的共享锁；视频正被 Repair 持有排他锁时返回 false，本轮跳过该视频
+func (q *Queries) TryLockVideoEngagementStatsShared(ctx context.Context, videoID uuid.UUID) (bool, error) {
+	row := q.db.QueryRow(ctx, tryLockVideoEngagementStatsShared, videoID)
+	var acquired bool
+	err := row.Scan(&acquired)
+	return acquired, err
+}
+
+const upsertVideoViewSession = `-- name: UpsertVideoViewSession :exec
+INSERT INTO catalog.video_view_sessions (
+    video_id,
+    user_id,
+    session_started_at,
+    last_progress_at,
+    baseline_watch_seconds,
+    last_watch_seconds,
+    counted_at,
+    updated_at,
+    max_position_seconds,
+    last_position_seconds,
+    position_tracked
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    now(),
+    $8,
+    $9,
+    true
+)
+ON CONFLICT (video_id, user_id) DO UPDATE
+SET
+    session_started_at = EXCLUDED.session_started_at,
+    last_progress_at = EXCLUDED.last_progress_at,
+    baseline_watch_seconds = EXCLUDED.baseline_watch_seconds,
+    last_watch_seconds = EXCLUDED.last_watch_seconds,
+    counted_at = EXCLUDED.counted_at,
+    max_position_seconds = EXCLUDED.max_position_seconds,
+    last_position_seconds = EXCLUDED.last_position_seconds,
+    position_tracked = true,
+    updated_at = now()
+`
+
+type UpsertVideoViewSessionParams struct {
+	VideoID              uuid.UUID          `json:"video_id"`
+	UserID               uuid.UUID          `json:"user_id"`
+	SessionStartedAt     pgtype.Timestamptz `json:"session_started_at"`
+	LastProgressAt       pgtype.Timestamptz `json:"last_progress_at"`
+	BaselineWatchSeconds float64            `json:"baseline_watch_seconds"`
+	LastWatchSeconds     float64            `json:"last_watch_seconds"`
+	CountedAt            pgtype.Timestamptz `json:"counted_at"`
+	MaxPositionSeconds   float64            `json:"max_position_seconds"`
+	LastPositionSeconds  float64            `json:"last_position_seconds"`
+}
+
+// 写入播放会话状态
+func (q *Queries) UpsertVideoViewSession(ctx context.Context, arg UpsertVideoViewSessionParams) error {
+	_, err := q.db.Exec(ctx, upsertVideoViewSession,
+		arg.VideoID,
+		arg.UserID,
+		arg.SessionStartedAt,
+		arg.LastProgressAt,
+		arg.BaselineWatchSeconds,
+		arg.LastWatchSeconds,
+		arg.CountedAt,
+		arg.MaxPositionSeconds,
+		arg.LastPositionSeconds,
+	)
+	return err
+}
+
 const upsertVideoWatcher = `-- name: UpsertVideoWatcher :one
 INSERT INTO catalog.video_engagement_watchers (
     video_id,
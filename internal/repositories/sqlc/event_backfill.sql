@@ -0,0 +1,104 @@
+-- 事件回填相关 SQL
+
+-- 按 video_id 键集分页扫描回填范围内的视频完整快照；过滤参数为空（或空数组）时不限
+-- name: ListVideosForBackfill :many
+SELECT
+    video_id,
+    upload_user_id,
+    created_at,
+    updated_at,
+    title,
+    description,
+    raw_file_reference,
+    status,
+    version,
+    media_status,
+    analysis_status,
+    media_job_id,
+    media_emitted_at,
+    analysis_job_id,
+    analysis_emitted_at,
+    raw_file_size,
+    raw_resolution,
+    raw_bitrate,
+    duration_micros,
+    encoded_resolution,
+    encoded_bitrate,
+    thumbnail_url,
+    hls_master_playlist,
+    difficulty,
+    summary,
+    tags,
+    visibility_status,
+    publish_at,
+    raw_subtitle_url,
+    error_message,
+    asset_id,
+    raw_missing
+FROM catalog.videos
+WHERE (sqlc.narg('after_video_id')::uuid IS NULL OR video_id > sqlc.narg('after_video_id')::uuid)
+  AND (cardinality(sqlc.arg('statuses')::text[]) = 0 OR status::text = ANY(sqlc.arg('statuses')::text[]))
+  AND (sqlc.narg('visibility_status')::text IS NULL OR visibility_status = sqlc.narg('visibility_status')::text)
+  AND (sqlc.narg('upload_user_id')::uuid IS NULL OR upload_user_id = sqlc.narg('upload_user_id')::uuid)
+ORDER BY video_id
+LIMIT sqlc.arg('limit');
+
+-- name: GetEventBackfillCheckpoint :one
+SELECT
+    backfill_id,
+    target,
+    scope_statuses,
+    scope_visibility,
+    scope_uploader_id,
+    last_video_id,
+    published_count,
+    started_at,
+    updated_at,
+    completed_at
+FROM catalog.event_backfill_checkpoints
+WHERE backfill_id = $1;
+
+-- 登记新的回填任务，返回含 started_at 的检查点
+-- name: CreateEventBackfillCheckpoint :one
+INSERT INTO catalog.event_backfill_checkpoints (
+    backfill_id,
+    target,
+    scope_statuses,
+    scope_visibility,
+    scope_uploader_id
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5
+)
+RETURNING
+    backfill_id,
+    target,
+    scope_statuses,
+    scope_visibility,
+    scope_uploader_id,
+    last_video_id,
+    published_count,
+    started_at,
+    updated_at,
+    completed_at;
+
+-- 推进检查点至本页最后一个已发布的视频
+-- name: AdvanceEventBackfillCheckpoint :exec
+UPDATE catalog.event_backfill_checkpoints
+SET last_video_id = $2,
+    published_count = $3,
+    updated_at = now()
+WHERE backfill_id = $1;
+
+-- name: CompleteEventBackfillCheckpoint :exec
+UPDATE catalog.event_backfill_checkpoints
+SET completed_at = now(),
+    updated_at = now()
+WHERE backfill_id = $1;
+
+-- name: DeleteEventBackfillCheckpoint :exec
+DELETE FROM catalog.event_backfill_checkpoints
+WHERE backfill_id = $1;
@@ -0,0 +1,100 @@
+-- Inbox 毒消息隔离相关 SQL
+
+-- 登记隔离事件；同一事件再次隔离时刷新错误信息并重新置为待处置
+-- name: QuarantineInboxEvent :exec
+INSERT INTO catalog.inbox_quarantine (
+    event_id,
+    consumer,
+    source_service,
+    event_type,
+    aggregate_type,
+    aggregate_id,
+    payload,
+    received_at,
+    error_class,
+    last_error
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8,
+    $9,
+    $10
+)
+ON CONFLICT (event_id) DO UPDATE
+SET consumer = EXCLUDED.consumer,
+    error_class = EXCLUDED.error_class,
+    last_error = EXCLUDED.last_error,
+    quarantined_at = now(),
+    resolved_at = NULL,
+    resolution = NULL,
+    resolved_note = NULL;
+
+-- 按隔离时间列出消费者的隔离事件；默认仅返回待处置记录
+-- name: ListInboxQuarantine :many
+SELECT
+    event_id,
+    consumer,
+    source_service,
+    event_type,
+    aggregate_type,
+    aggregate_id,
+    payload,
+    received_at,
+    error_class,
+    last_error,
+    quarantined_at,
+    replay_attempts,
+    resolved_at,
+    resolution,
+    resolved_note
+FROM catalog.inbox_quarantine
+WHERE consumer = sqlc.arg('consumer')
+  AND (sqlc.narg('error_class')::text IS NULL OR error_class = sqlc.narg('error_class')::text)
+  AND (sqlc.arg('include_resolved')::boolean OR resolved_at IS NULL)
+ORDER BY quarantined_at, event_id
+LIMIT sqlc.arg('limit');
+
+-- name: GetInboxQuarantine :one
+SELECT
+    event_id,
+    consumer,
+    source_service,
+    event_type,
+    aggregate_type,
+    aggregate_id,
+    payload,
+    received_at,
+    error_class,
+    last_error,
+    quarantined_at,
+    replay_attempts,
+    resolved_at,
+    resolution,
+    resolved_note
+FROM catalog.inbox_quarantine
+WHERE event_id = $1
+  AND consumer = $2
+FOR UPDATE;
+
+-- 处置待处置的隔离事件；已处置的记录保持不变
+-- name: ResolveInboxQuarantine :execrows
+UPDATE catalog.inbox_quarantine
+SET resolved_at = now(),
+    resolution = $3,
+    resolved_note = $4
+WHERE event_id = $1
+  AND consumer = $2
+  AND resolved_at IS NULL;
+
+-- 记录一次失败的人工重放
+-- name: RecordInboxQuarantineReplayFailure :exec
+UPDATE catalog.inbox_quarantine
+SET replay_attempts = replay_attempts + 1,
+    last_error = $3
+WHERE event_id = $1
+  AND consumer = $2;
@@ -104,6 +104,145 @@ func (ns NullCatalogVideoStatus) Value() (driver.Value, error) {
 	return string(ns.CatalogVideoStatus), nil
 }
 
+type CatalogEngagementPurge struct {
+	Scope         string             `json:"scope"`
+	SubjectID     uuid.UUID          `json:"subject_id"`
+	Reason        string             `json:"reason"`
+	RequestedAt   pgtype.Timestamptz `json:"requested_at"`
+	SourceEventID pgtype.UUID        `json:"source_event_id"`
+	RowsRemoved   int64              `json:"rows_removed"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+	CompletedAt   pgtype.Timestamptz `json:"completed_at"`
+}
+
+type CatalogEngagementReplayCheckpoint struct {
+	ReplayID       string             `json:"replay_id"`
+	ScopeUserID    pgtype.UUID        `json:"scope_user_id"`
+	ScopeVideoID   pgtype.UUID        `json:"scope_video_id"`
+	LastOccurredAt pgtype.Timestamptz `json:"last_occurred_at"`
+	LastEventID    pgtype.UUID        `json:"last_event_id"`
+	AppliedCount   int64              `json:"applied_count"`
+	SkippedCount   int64              `json:"skipped_count"`
+	StartedAt      pgtype.Timestamptz `json:"started_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	CompletedAt    pgtype.Timestamptz `json:"completed_at"`
+	LastReceivedAt pgtype.Timestamptz `json:"last_received_at"`
+}
+
+type CatalogEventBackfillCheckpoint struct {
+	BackfillID      string             `json:"backfill_id"`
+	Target          string             `json:"target"`
+	ScopeStatuses   []string           `json:"scope_statuses"`
+	ScopeVisibility pgtype.Text        `json:"scope_visibility"`
+	ScopeUploaderID pgtype.UUID        `json:"scope_uploader_id"`
+	LastVideoID     pgtype.UUID        `json:"last_video_id"`
+	PublishedCount  int64              `json:"published_count"`
+	StartedAt       pgtype.Timestamptz `json:"started_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+	CompletedAt     pgtype.Timestamptz `json:"completed_at"`
+}
+
+type CatalogInboxEvent struct {
+	EventID       uuid.UUID          `json:"event_id"`
+	SourceService string             `json:"source_service"`
+	EventType     string             `json:"event_type"`
+	AggregateType pgtype.Text        `json:"aggregate_type"`
+	AggregateID   pgtype.Text        `json:"aggregate_id"`
+	Payload       []byte             `json:"payload"`
+	ReceivedAt    pgtype.Timestamptz `json:"received_at"`
+	ProcessedAt   pgtype.Timestamptz `json:"processed_at"`
+	LastError     pgtype.Text        `json:"last_error"`
+	OccurredAt    pgtype.Timestamptz `json:"occurred_at"`
+}
+
+type CatalogInboxEventsArchive struct {
+	EventID       uuid.UUID          `json:"event_id"`
+	SourceService string             `json:"source_service"`
+	EventType     string             `json:"event_type"`
+	AggregateType pgtype.Text        `json:"aggregate_type"`
+	AggregateID   pgtype.Text        `json:"aggregate_id"`
+	Payload       []byte             `json:"payload"`
+	ReceivedAt    pgtype.Timestamptz `json:"received_at"`
+	ProcessedAt   pgtype.Timestamptz `json:"processed_at"`
+	LastError     pgtype.Text        `json:"last_error"`
+	ArchivedAt    pgtype.Timestamptz `json:"archived_at"`
+}
+
+type CatalogInboxQuarantine struct {
+	EventID        uuid.UUID          `json:"event_id"`
+	Consumer       string             `json:"consumer"`
+	SourceService  string             `json:"source_service"`
+	EventType      string             `json:"event_type"`
+	AggregateType  pgtype.Text        `json:"aggregate_type"`
+	AggregateID    pgtype.Text        `json:"aggregate_id"`
+	Payload        []byte             `json:"payload"`
+	ReceivedAt     pgtype.Timestamptz `json:"received_at"`
+	ErrorClass     string             `json:"error_class"`
+	LastError      string             `json:"last_error"`
+	QuarantinedAt  pgtype.Timestamptz `json:"quarantined_at"`
+	ReplayAttempts int32              `json:"replay_attempts"`
+	ResolvedAt     pgtype.Timestamptz `json:"resolved_at"`
+	Resolution     pgtype.Text        `json:"resolution"`
+	ResolvedNote   pgtype.Text        `json:"resolved_note"`
+}
+
+type CatalogOutboxEvent struct {
+	EventID          uuid.UUID          `json:"event_id"`
+	AggregateType    string             `json:"aggregate_type"`
+	AggregateID      uuid.UUID          `json:"aggregate_id"`
+	EventType        string             `json:"event_type"`
+	Payload          []byte             `json:"payload"`
+	Headers          []byte             `json:"headers"`
+	OccurredAt       pgtype.Timestamptz `json:"occurred_at"`
+	AvailableAt      pgtype.Timestamptz `json:"available_at"`
+	PublishedAt      pgtype.Timestamptz `json:"published_at"`
+	DeliveryAttempts int32              `json:"delivery_attempts"`
+	LastError        pgtype.Text        `json:"last_error"`
+	LockToken        pgtype.Text        `json:"lock_token"`
+	LockedAt         pgtype.Timestamptz `json:"locked_at"`
+	AbandonedAt      pgtype.Timestamptz `json:"abandoned_at"`
+	AbandonedReason  pgtype.Text        `json:"abandoned_reason"`
+}
+
+type CatalogOutboxEventsArchive struct {
+	EventID          uuid.UUID          `json:"event_id"`
+	AggregateType    string             `json:"aggregate_type"`
+	AggregateID      uuid.UUID          `json:"aggregate_id"`
+	EventType        string             `json:"event_type"`
+	Payload          []byte             `json:"payload"`
+	Headers          []byte             `json:"headers"`
+	OccurredAt       pgtype.Timestamptz `json:"occurred_at"`
+	AvailableAt      pgtype.Timestamptz `json:"available_at"`
+	PublishedAt      pgtype.Timestamptz `json:"published_at"`
+	DeliveryAttempts int32              `json:"delivery_attempts"`
+	LastError        pgtype.Text        `json:"last_error"`
+	AbandonedAt      pgtype.Timestamptz `json:"abandoned_at"`
+	AbandonedReason  pgtype.Text        `json:"abandoned_reason"`
+	ArchivedAt       pgtype.Timestamptz `json:"archived_at"`
+}
+
+type CatalogOutboxTopicDelivery struct {
+	EventID     uuid.UUID          `json:"event_id"`
+	Topic       string             `json:"topic"`
+	EventType   string             `json:"event_type"`
+	DeliveredAt pgtype.Timestamptz `json:"delivered_at"`
+}
+
+type CatalogRawAsset struct {
+	AssetID       uuid.UUID          `json:"asset_id"`
+	ContentSha256 string             `json:"content_sha256"`
+	ContentMd5    string             `json:"content_md5"`
+	SizeBytes     int64              `json:"size_bytes"`
+	Bucket        string             `json:"bucket"`
+	ObjectName    string             `json:"object_name"`
+	ContentType   pgtype.Text        `json:"content_type"`
+	RefCount      int64              `json:"ref_count"`
+	CreatedBy     uuid.UUID          `json:"created_by"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+}
+
 type CatalogUpload struct {
 	VideoID            uuid.UUID          `json:"video_id"`
 	UserID             uuid.UUID          `json:"user_id"`
@@ -126,6 +265,19 @@ type CatalogUpload struct {
 	ErrorMessage       pgtype.Text        `json:"error_message"`
 	CreatedAt          pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
+	SniffedContentType pgtype.Text        `json:"sniffed_content_type"`
+	ContentSha256      pgtype.Text        `json:"content_sha256"`
+	AssetID            pgtype.UUID        `json:"asset_id"`
+	RawMissing         bool               `json:"raw_missing"`
+	RawMissingAt       pgtype.Timestamptz `json:"raw_missing_at"`
+}
+
+type CatalogUploadUsage struct {
+	UserID           uuid.UUID          `json:"user_id"`
+	StoredBytes      int64              `json:"stored_bytes"`
+	CompletedUploads int64              `json:"completed_uploads"`
+	ReconciledAt     pgtype.Timestamptz `json:"reconciled_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
 }
 
 type CatalogVideo struct {
@@ -159,17 +311,75 @@ type CatalogVideo struct {
 	PublishAt         pgtype.Timestamptz `json:"publish_at"`
 	RawSubtitleUrl    pgtype.Text        `json:"raw_subtitle_url"`
 	ErrorMessage      pgtype.Text        `json:"error_message"`
+	AssetID           pgtype.UUID        `json:"asset_id"`
+	RawMissing        bool               `json:"raw_missing"`
 }
 
-type CatalogVideoEngagementStatsProjection struct {
+type CatalogVideoEngagementStatsDaily struct {
+	VideoID        uuid.UUID          `json:"video_id"`
+	BucketStart    pgtype.Timestamptz `json:"bucket_start"`
+	LikeDelta      int64              `json:"like_delta"`
+	BookmarkDelta  int64              `json:"bookmark_delta"`
+	WatchCount     int64              `json:"watch_count"`
+	UniqueWatchers int64              `json:"unique_watchers"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	Shard          int16              `json:"shard"`
+}
+
+type CatalogVideoEngagementStatsHourly struct {
 	VideoID        uuid.UUID          `json:"video_id"`
-	LikeCount      int64              `json:"like_count"`
-	BookmarkCount  int64              `json:"bookmark_count"`
+	BucketStart    pgtype.Timestamptz `json:"bucket_start"`
+	LikeDelta      int64              `json:"like_delta"`
+	BookmarkDelta  int64              `json:"bookmark_delta"`
 	WatchCount     int64              `json:"watch_count"`
 	UniqueWatchers int64              `json:"unique_watchers"`
-	FirstWatchAt   pgtype.Timestamptz `json:"first_watch_at"`
-	LastWatchAt    pgtype.Timestamptz `json:"last_watch_at"`
 	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	Shard          int16              `json:"shard"`
+}
+
+type CatalogVideoEngagementStatsProjection struct {
+	VideoID            uuid.UUID          `json:"video_id"`
+	LikeCount          int64              `json:"like_count"`
+	BookmarkCount      int64              `json:"bookmark_count"`
+	WatchCount         int64              `json:"watch_count"`
+	UniqueWatchers     int64              `json:"unique_watchers"`
+	FirstWatchAt       pgtype.Timestamptz `json:"first_watch_at"`
+	LastWatchAt        pgtype.Timestamptz `json:"last_watch_at"`
+	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
+	TotalWatchSeconds  float64            `json:"total_watch_seconds"`
+	PositionSumSeconds float64            `json:"position_sum_seconds"`
+	PositionViewers    int64              `json:"position_viewers"`
+	ShareCount         int64              `json:"share_count"`
+	RatingCount        int64              `json:"rating_count"`
+	RatingSum          int64              `json:"rating_sum"`
+	Rating1Count       int64              `json:"rating_1_count"`
+	Rating2Count       int64              `json:"rating_2_count"`
+	Rating3Count       int64              `json:"rating_3_count"`
+	Rating4Count       int64              `json:"rating_4_count"`
+	Rating5Count       int64              `json:"rating_5_count"`
+}
+
+type CatalogVideoEngagementStatsShard struct {
+	VideoID              uuid.UUID          `json:"video_id"`
+	Shard                int16              `json:"shard"`
+	LikeDelta            int64              `json:"like_delta"`
+	BookmarkDelta        int64              `json:"bookmark_delta"`
+	WatchDelta           int64              `json:"watch_delta"`
+	UniqueWatcherDelta   int64              `json:"unique_watcher_delta"`
+	WatchSecondsDelta    float64            `json:"watch_seconds_delta"`
+	PositionSecondsDelta float64            `json:"position_seconds_delta"`
+	PositionViewerDelta  int64              `json:"position_viewer_delta"`
+	FirstWatchAt         pgtype.Timestamptz `json:"first_watch_at"`
+	LastWatchAt          pgtype.Timestamptz `json:"last_watch_at"`
+	UpdatedAt            pgtype.Timestamptz `json:"updated_at"`
+	ShareDelta           int64              `json:"share_delta"`
+	RatingCountDelta     int64              `json:"rating_count_delta"`
+	RatingSumDelta       int64              `json:"rating_sum_delta"`
+	Rating1Delta         int64              `json:"rating_1_delta"`
+	Rating2Delta         int64              `json:"rating_2_delta"`
+	Rating3Delta         int64              `json:"rating_3_delta"`
+	Rating4Delta         int64              `json:"rating_4_delta"`
+	Rating5Delta         int64              `json:"rating_5_delta"`
 }
 
 type CatalogVideoEngagementWatcher struct {
@@ -179,6 +389,23 @@ type CatalogVideoEngagementWatcher struct {
 	LastWatchedAt  pgtype.Timestamptz `json:"last_watched_at"`
 }
 
+type CatalogVideoTrendingScore struct {
+	SnapshotID int64     `json:"snapshot_id"`
+	VideoID    uuid.UUID `json:"video_id"`
+	Rank       int32     `json:"rank"`
+	Score      float64   `json:"score"`
+	LikeDelta  int64     `json:"like_delta"`
+	WatchCount int64     `json:"watch_count"`
+}
+
+type CatalogVideoTrendingSnapshot struct {
+	SnapshotID      int64              `json:"snapshot_id"`
+	ComputedAt      pgtype.Timestamptz `json:"computed_at"`
+	WindowStart     pgtype.Timestamptz `json:"window_start"`
+	HalfLifeSeconds float64            `json:"half_life_seconds"`
+	MaxVideos       int32              `json:"max_videos"`
+}
+
 type CatalogVideoUserEngagementsProjection struct {
 	UserID               uuid.UUID          `json:"user_id"`
 	VideoID              uuid.UUID          `json:"video_id"`
@@ -187,4 +414,36 @@ type CatalogVideoUserEngagementsProjection struct {
 	LikedOccurredAt      pgtype.Timestamptz `json:"liked_occurred_at"`
 	BookmarkedOccurredAt pgtype.Timestamptz `json:"bookmarked_occurred_at"`
 	UpdatedAt            pgtype.Timestamptz `json:"updated_at"`
+	LikedSequence        pgtype.Int8        `json:"liked_sequence"`
+	BookmarkedSequence   pgtype.Int8        `json:"bookmarked_sequence"`
+	Rating               pgtype.Int4        `json:"rating"`
+	RatedOccurredAt      pgtype.Timestamptz `json:"rated_occurred_at"`
+	RatedSequence        pgtype.Int8        `json:"rated_sequence"`
+}
+
+type CatalogVideoViewSession struct {
+	VideoID              uuid.UUID          `json:"video_id"`
+	UserID               uuid.UUID          `json:"user_id"`
+	SessionStartedAt     pgtype.Timestamptz `json:"session_started_at"`
+	LastProgressAt       pgtype.Timestamptz `json:"last_progress_at"`
+	BaselineWatchSeconds float64            `json:"baseline_watch_seconds"`
+	LastWatchSeconds     float64            `json:"last_watch_seconds"`
+	CountedAt            pgtype.Timestamptz `json:"counted_at"`
+	UpdatedAt            pgtype.Timestamptz `json:"updated_at"`
+	MaxPositionSeconds   float64            `json:"max_position_seconds"`
+	LastPositionSeconds  float64            `json:"last_position_seconds"`
+	PositionTracked      bool               `json:"position_tracked"`
+}
+
+type CatalogWebhookDelivery struct {
+	ID          int64              `json:"id"`
+	EventID     uuid.UUID          `json:"event_id"`
+	Endpoint    string             `json:"endpoint"`
+	EventType   string             `json:"event_type"`
+	Attempt     int32              `json:"attempt"`
+	StatusCode  pgtype.Int4        `json:"status_code"`
+	Error       pgtype.Text        `json:"error"`
+	DurationMs  int32              `json:"duration_ms"`
+	Succeeded   bool               `json:"succeeded"`
+	AttemptedAt pgtype.Timestamptz `json:"attempted_at"`
 }
@@ -0,0 +1,82 @@
+-- Outbox 运维处置相关 SQL（列表、查看、强制重试、放弃、积压统计）
+
+-- 列出未发布的事件；abandoned 为 true 时仅返回已放弃的事件，否则仅返回仍待发布的事件
+-- name: ListOutboxEvents :many
+SELECT
+    event_id,
+    aggregate_type,
+    aggregate_id,
+    event_type,
+    payload,
+    headers,
+    occurred_at,
+    available_at,
+    published_at,
+    delivery_attempts,
+    last_error,
+    lock_token,
+    locked_at,
+    abandoned_at,
+    abandoned_reason
+FROM catalog.outbox_events
+WHERE published_at IS NULL
+  AND (abandoned_at IS NOT NULL) = sqlc.arg('abandoned')::boolean
+  AND (sqlc.narg('aggregate_id')::uuid IS NULL OR aggregate_id = sqlc.narg('aggregate_id')::uuid)
+  AND (sqlc.narg('event_type')::text IS NULL OR event_type = sqlc.narg('event_type')::text)
+  AND delivery_attempts >= sqlc.arg('min_attempts')::integer
+ORDER BY occurred_at, event_id
+LIMIT sqlc.arg('limit');
+
+-- name: GetOutboxEvent :one
+SELECT
+    event_id,
+    aggregate_type,
+    aggregate_id,
+    event_type,
+    payload,
+    headers,
+    occurred_at,
+    available_at,
+    published_at,
+    delivery_attempts,
+    last_error,
+    lock_token,
+    locked_at,
+    abandoned_at,
+    abandoned_reason
+FROM catalog.outbox_events
+WHERE event_id = $1;
+
+-- 重置未发布事件的投递状态，使其立即可被发布器认领；已放弃的事件同时恢复为待发布。
+-- 发布器仍持有未过期租约时不重置，以免与正在进行的投递重复发布
+-- name: ResetOutboxEventForRetry :execrows
+UPDATE catalog.outbox_events
+SET available_at = now(),
+    delivery_attempts = 0,
+    last_error = NULL,
+    lock_token = NULL,
+    locked_at = NULL,
+    abandoned_at = NULL,
+    abandoned_reason = NULL
+WHERE event_id = sqlc.arg('event_id')
+  AND published_at IS NULL
+  AND (locked_at IS NULL OR locked_at < now() - make_interval(secs => sqlc.arg('lock_ttl_seconds')::double precision));
+
+-- 放弃待发布事件：available_at 置为 infinity，发布器不再认领
+-- name: AbandonOutboxEvent :execrows
+UPDATE catalog.outbox_events
+SET available_at = 'infinity',
+    abandoned_at = now(),
+    abandoned_reason = $2,
+    lock_token = NULL,
+    locked_at = NULL
+WHERE event_id = $1
+  AND published_at IS NULL
+  AND abandoned_at IS NULL;
+
+-- 统计待发布积压：已放弃的事件不再投递，不计入
+-- name: CountPendingOutboxEvents :one
+SELECT count(*)
+FROM catalog.outbox_events
+WHERE published_at IS NULL
+  AND abandoned_at IS NULL;
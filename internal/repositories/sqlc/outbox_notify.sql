@@ -0,0 +1,4 @@
+-- Outbox 写入通知：与事件写入同一事务发出，提交后才会投递给 LISTEN 的发布器
+
+-- name: NotifyOutbox :exec
+SELECT pg_notify(sqlc.arg('channel')::text, sqlc.arg('payload')::text);
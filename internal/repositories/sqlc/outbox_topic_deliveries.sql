@@ -0,0 +1,20 @@
+-- Outbox 多 topic 发布记录相关 SQL
+
+-- 记录事件已被 topic 接收，重复记录忽略
+-- name: InsertOutboxTopicDelivery :exec
+INSERT INTO catalog.outbox_topic_deliveries (
+    event_id,
+    topic,
+    event_type
+) VALUES (
+    $1,
+    $2,
+    $3
+)
+ON CONFLICT (event_id, topic) DO NOTHING;
+
+-- 返回事件已被接收的 topic
+-- name: ListDeliveredOutboxTopics :many
+SELECT topic
+FROM catalog.outbox_topic_deliveries
+WHERE event_id = $1;
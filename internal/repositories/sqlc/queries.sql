@@ -30,7 +30,9 @@ SELECT
     visibility_status,
     publish_at,
     raw_subtitle_url,
-    error_message
+    error_message,
+    asset_id,
+    raw_missing
 FROM catalog.videos
 WHERE video_id = $1;
 
@@ -72,6 +74,21 @@ SELECT
 FROM catalog.videos
 WHERE video_id = $1;
 
+-- 读取播放签名所需的可见性与媒体产物字段，可见性判定在服务层完成
+-- name: GetVideoPlaybackSource :one
+SELECT
+    video_id,
+    upload_user_id,
+    status,
+    media_status,
+    visibility_status,
+    publish_at,
+    duration_micros,
+    thumbnail_url,
+    hls_master_playlist
+FROM catalog.videos
+WHERE video_id = $1;
+
 -- name: ListPublicVideos :many
 SELECT
     video_id,
@@ -104,7 +121,8 @@ SELECT
     visibility_status,
     publish_at,
     created_at,
-    updated_at
+    updated_at,
+    raw_missing
 FROM catalog.videos
 WHERE upload_user_id = sqlc.arg('upload_user_id')
   AND (
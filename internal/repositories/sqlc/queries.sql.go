@@ -90,7 +90,9 @@ SELECT
     visibility_status,
     publish_at,
     raw_subtitle_url,
-    error_message
+    error_message,
+    asset_id,
+    raw_missing
 FROM catalog.videos
 WHERE video_id = $1
 `
@@ -130,6 +132,8 @@ func (q *Queries) GetVideoLifecycleSnapshot(ctx context.Context, videoID uuid.UU
 		&i.PublishAt,
 		&i.RawSubtitleUrl,
 		&i.ErrorMessage,
+		&i.AssetID,
+		&i.RawMissing,
 	)
 	return i, err
 }
@@ -202,6 +206,51 @@ func (q *Queries) GetVideoMetadata(ctx context.Context, videoID uuid.UUID) (GetV
 	return i, err
 }
 
+const getVideoPlaybackSource = `-- name: GetVideoPlaybackSource :one
+SELECT
+    video_id,
+    upload_user_id,
+    status,
+    media_status,
+    visibility_status,
+    publish_at,
+    duration_micros,
+    thumbnail_url,
+    hls_master_playlist
+FROM catalog.videos
+WHERE video_id = $1
+`
+
+type GetVideoPlaybackSourceRow struct {
+	VideoID           uuid.UUID          `json:"video_id"`
+	UploadUserID      uuid.UUID          `json:"upload_user_id"`
+	Status            po.VideoStatus     `json:"status"`
+	MediaStatus       po.StageStatus     `json:"media_status"`
+	VisibilityStatus  string             `json:"visibility_status"`
+	PublishAt         pgtype.Timestamptz `json:"publish_at"`
+	DurationMicros    pgtype.Int8        `json:"duration_micros"`
+	ThumbnailUrl      pgtype.Text        `json:"thumbnail_url"`
+	HlsMasterPlaylist pgtype.Text        `json:"hls_master_playlist"`
+}
+
+// 读取播放签名所需的可见性与媒体产物字段，可见性判定在服务层完成
+func (q *Queries) GetVideoPlaybackSource(ctx context.Context, videoID uuid.UUID) (GetVideoPlaybackSourceRow, error) {
+	row := q.db.QueryRow(ctx, getVideoPlaybackSource, videoID)
+	var i GetVideoPlaybackSourceRow
+	err := row.Scan(
+		&i.VideoID,
+		&i.UploadUserID,
+		&i.Status,
+		&i.MediaStatus,
+		&i.VisibilityStatus,
+		&i.PublishAt,
+		&i.DurationMicros,
+		&i.ThumbnailUrl,
+		&i.HlsMasterPlaylist,
+	)
+	return i, err
+}
+
 const listPublicVideos = `-- name: ListPublicVideos :many
 SELECT
     video_id,
@@ -283,7 +332,8 @@ SELECT
     visibility_status,
     publish_at,
     created_at,
-    updated_at
+    updated_at,
+    raw_missing
 FROM catalog.videos
 WHERE upload_user_id = $1
   AND (
@@ -326,6 +376,7 @@ type ListUserUploadsRow struct {
 	PublishAt        pgtype.Timestamptz `json:"publish_at"`
 	CreatedAt        pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+	RawMissing       bool               `json:"raw_missing"`
 }
 
 func (q *Queries) ListUserUploads(ctx context.Context, arg ListUserUploadsParams) ([]ListUserUploadsRow, error) {
@@ -355,6 +406,7 @@ func (q *Queries) ListUserUploads(ctx context.Context, arg ListUserUploadsParams
 			&i.PublishAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.RawMissing,
 		); err != nil {
 			return nil, err
 		}
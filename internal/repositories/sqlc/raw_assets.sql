@@ -0,0 +1,71 @@
+-- 原始资产（按内容 SHA-256 去重）
+-- name: GetRawAssetBySha256 :one
+SELECT *
+FROM catalog.raw_assets
+WHERE content_sha256 = $1
+LIMIT 1;
+
+-- 获取资产引用：不存在时以当前对象登记为规范资产，存在时仅递增引用计数
+-- name: AcquireRawAsset :one
+WITH acquired AS (
+  INSERT INTO catalog.raw_assets AS a (
+    content_sha256,
+    content_md5,
+    size_bytes,
+    bucket,
+    object_name,
+    content_type,
+    ref_count,
+    created_by
+  )
+  VALUES (
+    sqlc.arg('content_sha256'),
+    sqlc.arg('content_md5'),
+    sqlc.arg('size_bytes'),
+    sqlc.arg('bucket'),
+    sqlc.arg('object_name'),
+    sqlc.narg('content_type'),
+    1,
+    sqlc.arg('created_by')
+  )
+  ON CONFLICT (content_sha256)
+  DO UPDATE
+  SET ref_count = a.ref_count + 1,
+      updated_at = now()
+  RETURNING a.asset_id,
+            a.content_sha256,
+            a.content_md5,
+            a.size_bytes,
+            a.bucket,
+            a.object_name,
+            a.content_type,
+            a.ref_count,
+            a.created_by,
+            a.created_at,
+            a.updated_at,
+            (xmax = 0)::bool AS inserted
+)
+SELECT * FROM acquired;
+
+-- 释放资产引用，引用计数不低于 0
+-- name: ReleaseRawAsset :one
+UPDATE catalog.raw_assets
+SET ref_count = GREATEST(ref_count - 1, 0),
+    updated_at = now()
+WHERE asset_id = $1
+RETURNING *;
+
+-- 按规范对象位置查找资产，判断删除/归档通知是否命中被多条视频共享的对象
+-- name: GetRawAssetByObject :one
+SELECT *
+FROM catalog.raw_assets
+WHERE bucket = $1
+  AND object_name = $2
+LIMIT 1;
+
+-- 列出引用指定资产的视频
+-- name: ListVideoIDsByAsset :many
+SELECT video_id
+FROM catalog.videos
+WHERE asset_id = $1
+ORDER BY video_id;
@@ -0,0 +1,136 @@
+-- Outbox/Inbox 保留与归档相关 SQL
+-- 每批以 FOR UPDATE SKIP LOCKED 选取过期行，与并发的清理实例或运维操作互不阻塞；
+-- archive 为 true 时同一语句把删除的行移入归档表，返回的行供调用方在提交前导出。
+
+-- 删除发布早于 published_before 的 Outbox 行（至多 batch_size 行）
+-- name: PruneOutboxEvents :many
+WITH expired AS (
+    SELECT event_id
+    FROM catalog.outbox_events
+    WHERE published_at < sqlc.arg('published_before')::timestamptz
+    ORDER BY published_at
+    LIMIT sqlc.arg('batch_size')::integer
+    FOR UPDATE SKIP LOCKED
+), deleted AS (
+    DELETE FROM catalog.outbox_events o
+    USING expired
+    WHERE o.event_id = expired.event_id
+    RETURNING o.event_id, o.aggregate_type, o.aggregate_id, o.event_type, o.payload, o.headers,
+              o.occurred_at, o.available_at, o.published_at, o.delivery_attempts, o.last_error,
+              o.abandoned_at, o.abandoned_reason
+), archived AS (
+    INSERT INTO catalog.outbox_events_archive (
+        event_id, aggregate_type, aggregate_id, event_type, payload, headers,
+        occurred_at, available_at, published_at, delivery_attempts, last_error,
+        abandoned_at, abandoned_reason
+    )
+    SELECT event_id, aggregate_type, aggregate_id, event_type, payload, headers,
+           occurred_at, available_at, published_at, delivery_attempts, last_error,
+           abandoned_at, abandoned_reason
+    FROM deleted
+    WHERE sqlc.arg('archive')::boolean
+    ON CONFLICT (event_id) DO NOTHING
+)
+SELECT
+    event_id,
+    aggregate_type,
+    aggregate_id,
+    event_type,
+    payload,
+    headers,
+    occurred_at,
+    available_at,
+    published_at,
+    delivery_attempts,
+    last_error,
+    abandoned_at,
+    abandoned_reason
+FROM deleted
+ORDER BY published_at, event_id;
+
+-- 删除放弃早于 abandoned_before 且仍未发布的 Outbox 行（至多 batch_size 行）；返回列与 PruneOutboxEvents 相同
+-- name: PruneAbandonedOutboxEvents :many
+WITH expired AS (
+    SELECT event_id
+    FROM catalog.outbox_events
+    WHERE published_at IS NULL
+      AND abandoned_at < sqlc.arg('abandoned_before')::timestamptz
+    ORDER BY abandoned_at
+    LIMIT sqlc.arg('batch_size')::integer
+    FOR UPDATE SKIP LOCKED
+), deleted AS (
+    DELETE FROM catalog.outbox_events o
+    USING expired
+    WHERE o.event_id = expired.event_id
+    RETURNING o.event_id, o.aggregate_type, o.aggregate_id, o.event_type, o.payload, o.headers,
+              o.occurred_at, o.available_at, o.published_at, o.delivery_attempts, o.last_error,
+              o.abandoned_at, o.abandoned_reason
+), archived AS (
+    INSERT INTO catalog.outbox_events_archive (
+        event_id, aggregate_type, aggregate_id, event_type, payload, headers,
+        occurred_at, available_at, published_at, delivery_attempts, last_error,
+        abandoned_at, abandoned_reason
+    )
+    SELECT event_id, aggregate_type, aggregate_id, event_type, payload, headers,
+           occurred_at, available_at, published_at, delivery_attempts, last_error,
+           abandoned_at, abandoned_reason
+    FROM deleted
+    WHERE sqlc.arg('archive')::boolean
+    ON CONFLICT (event_id) DO NOTHING
+)
+SELECT
+    event_id,
+    aggregate_type,
+    aggregate_id,
+    event_type,
+    payload,
+    headers,
+    occurred_at,
+    available_at,
+    published_at,
+    delivery_attempts,
+    last_error,
+    abandoned_at,
+    abandoned_reason
+FROM deleted
+ORDER BY abandoned_at, event_id;
+
+-- 删除处理完成早于 processed_before 的 Inbox 行（至多 batch_size 行）；未处理的行与 keep_event_types 中的事件类型不受影响
+-- name: PruneInboxEvents :many
+WITH expired AS (
+    SELECT event_id
+    FROM catalog.inbox_events
+    WHERE processed_at < sqlc.arg('processed_before')::timestamptz
+      AND NOT (event_type = ANY(sqlc.arg('keep_event_types')::text[]))
+    ORDER BY processed_at
+    LIMIT sqlc.arg('batch_size')::integer
+    FOR UPDATE SKIP LOCKED
+), deleted AS (
+    DELETE FROM catalog.inbox_events i
+    USING expired
+    WHERE i.event_id = expired.event_id
+    RETURNING i.event_id, i.source_service, i.event_type, i.aggregate_type, i.aggregate_id,
+              i.payload, i.received_at, i.processed_at, i.last_error
+), archived AS (
+    INSERT INTO catalog.inbox_events_archive (
+        event_id, source_service, event_type, aggregate_type, aggregate_id,
+        payload, received_at, processed_at, last_error
+    )
+    SELECT event_id, source_service, event_type, aggregate_type, aggregate_id,
+           payload, received_at, processed_at, last_error
+    FROM deleted
+    WHERE sqlc.arg('archive')::boolean
+    ON CONFLICT (event_id) DO NOTHING
+)
+SELECT
+    event_id,
+    source_service,
+    event_type,
+    aggregate_type,
+    aggregate_id,
+    payload,
+    received_at,
+    processed_at,
+    last_error
+FROM deleted
+ORDER BY processed_at, event_id;
@@ -0,0 +1,119 @@
+-- 创建热度快照，返回快照 ID
+-- name: CreateVideoTrendingSnapshot :one
+INSERT INTO catalog.video_trending_snapshots (
+    computed_at,
+    window_start,
+    half_life_seconds,
+    max_videos
+) VALUES (
+    sqlc.arg('computed_at'),
+    sqlc.arg('window_start'),
+    sqlc.arg('half_life_seconds')::double precision,
+    sqlc.arg('max_videos')::integer
+)
+RETURNING snapshot_id;
+
+-- 从小时统计桶计算时间衰减热度并写入快照；仅包含已发布的公开视频，得分不大于 0 的视频不入榜。
+-- 不在此处截断：max_videos 在读取时作用于过滤后的结果
+-- name: InsertVideoTrendingScores :execrows
+INSERT INTO catalog.video_trending_scores (
+    snapshot_id,
+    video_id,
+    rank,
+    score,
+    like_delta,
+    watch_count
+)
+SELECT
+    sqlc.arg('snapshot_id')::bigint,
+    s.video_id,
+    (row_number() OVER (ORDER BY s.score DESC, s.video_id))::integer,
+    s.score,
+    s.like_delta,
+    s.watch_count
+FROM (
+    SELECT
+        h.video_id,
+        SUM(
+            (sqlc.arg('like_weight')::double precision * h.like_delta + sqlc.arg('view_weight')::double precision * h.watch_count)
+            * power(0.5, EXTRACT(EPOCH FROM (sqlc.arg('computed_at')::timestamptz - h.bucket_start))::double precision / sqlc.arg('half_life_seconds')::double precision)
+        )::double precision AS score,
+        SUM(h.like_delta)::bigint AS like_delta,
+        SUM(h.watch_count)::bigint AS watch_count
+    FROM catalog.video_engagement_stats_hourly h
+    JOIN catalog.videos v ON v.video_id = h.video_id
+    WHERE h.bucket_start >= sqlc.arg('window_start')::timestamptz
+      AND h.bucket_start <= sqlc.arg('computed_at')::timestamptz
+      AND v.status = 'published'
+      AND v.visibility_status = 'public'
+      AND (v.publish_at IS NULL OR v.publish_at <= sqlc.arg('computed_at')::timestamptz)
+    GROUP BY h.video_id
+) s
+WHERE s.score > 0;
+
+-- 读取当前（最新）热度快照
+-- name: GetLatestVideoTrendingSnapshot :one
+SELECT
+    snapshot_id,
+    computed_at,
+    window_start,
+    half_life_seconds,
+    max_videos
+FROM catalog.video_trending_snapshots
+ORDER BY snapshot_id DESC
+LIMIT 1;
+
+-- 按 ID 读取热度快照
+-- name: GetVideoTrendingSnapshot :one
+SELECT
+    snapshot_id,
+    computed_at,
+    window_start,
+    half_life_seconds,
+    max_videos
+FROM catalog.video_trending_snapshots
+WHERE snapshot_id = sqlc.arg('snapshot_id');
+
+-- 按名次分页读取快照内的视频：先按当前可见性与难度/标签过滤，再在过滤结果中截取快照的前 max_videos 名
+-- name: ListVideoTrendingScores :many
+WITH filtered AS (
+    SELECT
+        t.video_id,
+        t.rank,
+        t.score,
+        v.title,
+        v.duration_micros,
+        v.difficulty,
+        v.tags,
+        v.created_at,
+        row_number() OVER (ORDER BY t.rank) AS position
+    FROM catalog.video_trending_scores t
+    JOIN catalog.videos v ON v.video_id = t.video_id
+    WHERE t.snapshot_id = sqlc.arg('snapshot_id')
+      AND v.status = 'published'
+      AND v.visibility_status = 'public'
+      AND (v.publish_at IS NULL OR v.publish_at <= now())
+      AND (sqlc.narg('difficulty')::text IS NULL OR v.difficulty = sqlc.narg('difficulty')::text)
+      AND (COALESCE(cardinality(sqlc.arg('tags')::text[]), 0) = 0 OR v.tags @> sqlc.arg('tags')::text[])
+)
+SELECT
+    f.video_id,
+    f.rank,
+    f.score,
+    f.title,
+    f.duration_micros,
+    f.difficulty,
+    f.tags,
+    f.created_at
+FROM filtered f
+JOIN catalog.video_trending_snapshots s ON s.snapshot_id = sqlc.arg('snapshot_id')
+WHERE f.rank > sqlc.arg('after_rank')
+  AND (s.max_videos = 0 OR f.position <= s.max_videos)
+ORDER BY f.rank
+LIMIT sqlc.arg('limit');
+
+-- 删除早于截止时间的热度快照（得分级联删除），始终保留最新快照
+-- name: PruneVideoTrendingSnapshots :execrows
+DELETE FROM catalog.video_trending_snapshots
+WHERE computed_at < sqlc.arg('before')
+  AND snapshot_id < (SELECT max(snapshot_id) FROM catalog.video_trending_snapshots);
@@ -12,7 +12,8 @@ WITH upsert AS (
     description,
     signed_url,
     signed_url_expires_at,
-    status
+    status,
+    content_sha256
   )
   VALUES (
     $1,
@@ -26,7 +27,8 @@ WITH upsert AS (
     $9,
     $10,
     $11,
-    $12
+    $12,
+    $13
   )
   ON CONFLICT (user_id, content_md5)
   DO UPDATE
@@ -38,6 +40,7 @@ WITH upsert AS (
       description = EXCLUDED.description,
       signed_url = EXCLUDED.signed_url,
       signed_url_expires_at = EXCLUDED.signed_url_expires_at,
+      content_sha256 = EXCLUDED.content_sha256,
       status = CASE
         WHEN catalog.uploads.status = 'completed' THEN catalog.uploads.status
         ELSE EXCLUDED.status
@@ -64,6 +67,11 @@ WITH upsert AS (
             u.error_message,
             u.created_at,
             u.updated_at,
+            u.sniffed_content_type,
+            u.content_sha256,
+            u.asset_id,
+            u.raw_missing,
+            u.raw_missing_at,
             (xmax = 0)::bool AS inserted
 )
 SELECT * FROM upsert;
@@ -97,6 +105,10 @@ SET status = 'completed',
     gcs_generation = sqlc.arg(gcs_generation),
     gcs_etag = sqlc.arg(gcs_etag),
     content_type = COALESCE(sqlc.narg(content_type), content_type),
+    sniffed_content_type = COALESCE(sqlc.narg(sniffed_content_type), sniffed_content_type),
+    asset_id = COALESCE(sqlc.narg(asset_id), asset_id),
+    raw_missing = false,
+    raw_missing_at = NULL,
     signed_url = NULL,
     signed_url_expires_at = NULL,
     error_code = NULL,
@@ -110,6 +122,15 @@ UPDATE catalog.uploads
 SET status = 'failed',
     error_code = sqlc.arg(error_code),
     error_message = sqlc.arg(error_message),
+    sniffed_content_type = COALESCE(sqlc.narg(sniffed_content_type), sniffed_content_type),
+    updated_at = now()
+WHERE video_id = sqlc.arg(video_id)
+RETURNING *;
+
+-- name: MarkUploadRawMissing :one
+UPDATE catalog.uploads
+SET raw_missing = true,
+    raw_missing_at = now(),
     updated_at = now()
 WHERE video_id = sqlc.arg(video_id)
 RETURNING *;
@@ -122,3 +143,62 @@ WHERE status = 'uploading'
   AND signed_url_expires_at < sqlc.arg('cutoff')
 ORDER BY signed_url_expires_at ASC
 LIMIT sqlc.arg('limit');
+
+-- name: GetUploadQuotaUsage :one
+SELECT
+  (
+    SELECT COUNT(*)
+    FROM catalog.uploads u
+    WHERE u.user_id = sqlc.arg('user_id')
+      AND u.created_at >= sqlc.arg('since')
+  )::bigint AS daily_sessions,
+  (
+    SELECT COUNT(*)
+    FROM catalog.uploads u
+    WHERE u.user_id = sqlc.arg('user_id')
+      AND u.status = 'uploading'
+      AND u.signed_url_expires_at > sqlc.arg('now')
+  )::bigint AS concurrent_uploads,
+  (
+    SELECT MIN(u.signed_url_expires_at)
+    FROM catalog.uploads u
+    WHERE u.user_id = sqlc.arg('user_id')
+      AND u.status = 'uploading'
+      AND u.signed_url_expires_at > sqlc.arg('now')
+  )::timestamptz AS earliest_upload_expires_at,
+  COALESCE((
+    SELECT s.stored_bytes
+    FROM catalog.upload_usage s
+    WHERE s.user_id = sqlc.arg('user_id')
+  ), 0)::bigint AS stored_bytes;
+
+-- name: LockUploadUsage :exec
+-- 锁定用户的配额用量行（不存在时按零用量补建），串行化同一用户的配额校验与会话创建。
+INSERT INTO catalog.upload_usage (user_id)
+VALUES (sqlc.arg('user_id'))
+ON CONFLICT (user_id) DO UPDATE
+SET user_id = EXCLUDED.user_id;
+
+-- name: ReconcileUploadUsage :one
+INSERT INTO catalog.upload_usage (
+  user_id,
+  stored_bytes,
+  completed_uploads,
+  reconciled_at,
+  updated_at
+)
+SELECT
+  sqlc.arg('user_id')::uuid,
+  COALESCE(SUM(u.size_bytes), 0)::bigint,
+  COUNT(*)::bigint,
+  now(),
+  now()
+FROM catalog.uploads u
+WHERE u.user_id = sqlc.arg('user_id')
+  AND u.status = 'completed'
+ON CONFLICT (user_id) DO UPDATE
+SET stored_bytes = EXCLUDED.stored_bytes,
+    completed_uploads = EXCLUDED.completed_uploads,
+    reconciled_at = EXCLUDED.reconciled_at,
+    updated_at = now()
+RETURNING user_id, stored_bytes, completed_uploads, reconciled_at, updated_at;
@@ -13,7 +13,7 @@ import (
 )
 
 const getUploadByObject = `-- name: GetUploadByObject :one
-SELECT video_id, user_id, bucket, object_name, content_type, expected_size, size_bytes, content_md5, title, description, signed_url, signed_url_expires_at, status, gcs_generation, gcs_etag, md5_hash, crc32c, error_code, error_message, created_at, updated_at
+SELECT video_id, user_id, bucket, object_name, content_type, expected_size, size_bytes, content_md5, title, description, signed_url, signed_url_expires_at, status, gcs_generation, gcs_etag, md5_hash, crc32c, error_code, error_message, created_at, updated_at, sniffed_content_type, content_sha256, asset_id, raw_missing, raw_missing_at
 FROM catalog.uploads
 WHERE bucket = $1
   AND object_name = $2
@@ -50,12 +50,17 @@ func (q *Queries) GetUploadByObject(ctx context.Context, arg GetUploadByObjectPa
 		&i.ErrorMessage,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SniffedContentType,
+		&i.ContentSha256,
+		&i.AssetID,
+		&i.RawMissing,
+		&i.RawMissingAt,
 	)
 	return i, err
 }
 
 const getUploadByUserMd5 = `-- name: GetUploadByUserMd5 :one
-SELECT video_id, user_id, bucket, object_name, content_type, expected_size, size_bytes, content_md5, title, description, signed_url, signed_url_expires_at, status, gcs_generation, gcs_etag, md5_hash, crc32c, error_code, error_message, created_at, updated_at
+SELECT video_id, user_id, bucket, object_name, content_type, expected_size, size_bytes, content_md5, title, description, signed_url, signed_url_expires_at, status, gcs_generation, gcs_etag, md5_hash, crc32c, error_code, error_message, created_at, updated_at, sniffed_content_type, content_sha256, asset_id, raw_missing, raw_missing_at
 FROM catalog.uploads
 WHERE user_id = $1
   AND content_md5 = $2
@@ -92,12 +97,17 @@ func (q *Queries) GetUploadByUserMd5(ctx context.Context, arg GetUploadByUserMd5
 		&i.ErrorMessage,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SniffedContentType,
+		&i.ContentSha256,
+		&i.AssetID,
+		&i.RawMissing,
+		&i.RawMissingAt,
 	)
 	return i, err
 }
 
 const getUploadByVideoID = `-- name: GetUploadByVideoID :one
-SELECT video_id, user_id, bucket, object_name, content_type, expected_size, size_bytes, content_md5, title, description, signed_url, signed_url_expires_at, status, gcs_generation, gcs_etag, md5_hash, crc32c, error_code, error_message, created_at, updated_at
+SELECT video_id, user_id, bucket, object_name, content_type, expected_size, size_bytes, content_md5, title, description, signed_url, signed_url_expires_at, status, gcs_generation, gcs_etag, md5_hash, crc32c, error_code, error_message, created_at, updated_at, sniffed_content_type, content_sha256, asset_id, raw_missing, raw_missing_at
 FROM catalog.uploads
 WHERE video_id = $1
 LIMIT 1
@@ -128,12 +138,71 @@ func (q *Queries) GetUploadByVideoID(ctx context.Context, videoID uuid.UUID) (Ca
 		&i.ErrorMessage,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SniffedContentType,
+		&i.ContentSha256,
+		&i.AssetID,
+		&i.RawMissing,
+		&i.RawMissingAt,
+	)
+	return i, err
+}
+
+const getUploadQuotaUsage = `-- name: GetUploadQuotaUsage :one
+SELECT
+  (
+    SELECT COUNT(*)
+    FROM catalog.uploads u
+    WHERE u.user_id = $1
+      AND u.created_at >= $2
+  )::bigint AS daily_sessions,
+  (
+    SELECT COUNT(*)
+    FROM catalog.uploads u
+    WHERE u.user_id = $1
+      AND u.status = 'uploading'
+      AND u.signed_url_expires_at > $3
+  )::bigint AS concurrent_uploads,
+  (
+    SELECT MIN(u.signed_url_expires_at)
+    FROM catalog.uploads u
+    WHERE u.user_id = $1
+      AND u.status = 'uploading'
+      AND u.signed_url_expires_at > $3
+  )::timestamptz AS earliest_upload_expires_at,
+  COALESCE((
+    SELECT s.stored_bytes
+    FROM catalog.upload_usage s
+    WHERE s.user_id = $1
+  ), 0)::bigint AS stored_bytes
+`
+
+type GetUploadQuotaUsageParams struct {
+	UserID uuid.UUID          `json:"user_id"`
+	Since  pgtype.Timestamptz `json:"since"`
+	Now    pgtype.Timestamptz `json:"now"`
+}
+
+type GetUploadQuotaUsageRow struct {
+	DailySessions           int64              `json:"daily_sessions"`
+	ConcurrentUploads       int64              `json:"concurrent_uploads"`
+	EarliestUploadExpiresAt pgtype.Timestamptz `json:"earliest_upload_expires_at"`
+	StoredBytes             int64              `json:"stored_bytes"`
+}
+
+func (q *Queries) GetUploadQuotaUsage(ctx context.Context, arg GetUploadQuotaUsageParams) (GetUploadQuotaUsageRow, error) {
+	row := q.db.QueryRow(ctx, getUploadQuotaUsage, arg.UserID, arg.Since, arg.Now)
+	var i GetUploadQuotaUsageRow
+	err := row.Scan(
+		&i.DailySessions,
+		&i.ConcurrentUploads,
+		&i.EarliestUploadExpiresAt,
+		&i.StoredBytes,
 	)
 	return i, err
 }
 
 const listExpiredUploads = `-- name: ListExpiredUploads :many
-SELECT video_id, user_id, bucket, object_name, content_type, expected_size, size_bytes, content_md5, title, description, signed_url, signed_url_expires_at, status, gcs_generation, gcs_etag, md5_hash, crc32c, error_code, error_message, created_at, updated_at
+SELECT video_id, user_id, bucket, object_name, content_type, expected_size, size_bytes, content_md5, title, description, signed_url, signed_url_expires_at, status, gcs_generation, gcs_etag, md5_hash, crc32c, error_code, error_message, created_at, updated_at, sniffed_content_type, content_sha256, asset_id, raw_missing, raw_missing_at
 FROM catalog.uploads
 WHERE status = 'uploading'
   AND signed_url_expires_at IS NOT NULL
@@ -178,6 +247,11 @@ func (q *Queries) ListExpiredUploads(ctx context.Context, arg ListExpiredUploads
 			&i.ErrorMessage,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.SniffedContentType,
+			&i.ContentSha256,
+			&i.AssetID,
+			&i.RawMissing,
+			&i.RawMissingAt,
 		); err != nil {
 			return nil, err
 		}
@@ -189,6 +263,19 @@ func (q *Queries) ListExpiredUploads(ctx context.Context, arg ListExpiredUploads
 	return items, nil
 }
 
+const lockUploadUsage = `-- name: LockUploadUsage :exec
+INSERT INTO catalog.upload_usage (user_id)
+VALUES ($1)
+ON CONFLICT (user_id) DO UPDATE
+SET user_id = EXCLUDED.user_id
+`
+
+// 锁定用户的配额用量行（不存在时按零用量补建），串行化同一用户的配额校验与会话创建。
+func (q *Queries) LockUploadUsage(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, lockUploadUsage, userID)
+	return err
+}
+
 const markUploadCompleted = `-- name: MarkUploadCompleted :one
 UPDATE catalog.uploads
 SET status = 'completed',
@@ -198,23 +285,29 @@ SET status = 'completed',
     gcs_generation = $4,
     gcs_etag = $5,
     content_type = COALESCE($6, content_type),
+    sniffed_content_type = COALESCE($7, sniffed_content_type),
+    asset_id = COALESCE($8, asset_id),
+    raw_missing = false,
+    raw_missing_at = NULL,
     signed_url = NULL,
     signed_url_expires_at = NULL,
     error_code = NULL,
     error_message = NULL,
     updated_at = now()
-WHERE video_id = $7
-RETURNING video_id, user_id, bucket, object_name, content_type, expected_size, size_bytes, content_md5, title, description, signed_url, signed_url_expires_at, status, gcs_generation, gcs_etag, md5_hash, crc32c, error_code, error_message, created_at, updated_at
+WHERE video_id = $9
+RETURNING video_id, user_id, bucket, object_name, content_type, expected_size, size_bytes, content_md5, title, description, signed_url, signed_url_expires_at, status, gcs_generation, gcs_etag, md5_hash, crc32c, error_code, error_message, created_at, updated_at, sniffed_content_type, content_sha256, asset_id, raw_missing, raw_missing_at
 `
 
 type MarkUploadCompletedParams struct {
-	SizeBytes     int64       `json:"size_bytes"`
-	Md5Hash       pgtype.Text `json:"md5_hash"`
-	Crc32c        pgtype.Text `json:"crc32c"`
-	GcsGeneration pgtype.Text `json:"gcs_generation"`
-	GcsEtag       pgtype.Text `json:"gcs_etag"`
-	ContentType   pgtype.Text `json:"content_type"`
-	VideoID       uuid.UUID   `json:"video_id"`
+	SizeBytes          int64       `json:"size_bytes"`
+	Md5Hash            pgtype.Text `json:"md5_hash"`
+	Crc32c             pgtype.Text `json:"crc32c"`
+	GcsGeneration      pgtype.Text `json:"gcs_generation"`
+	GcsEtag            pgtype.Text `json:"gcs_etag"`
+	ContentType        pgtype.Text `json:"content_type"`
+	SniffedContentType pgtype.Text `json:"sniffed_content_type"`
+	AssetID            pgtype.UUID `json:"asset_id"`
+	VideoID            uuid.UUID   `json:"video_id"`
 }
 
 func (q *Queries) MarkUploadCompleted(ctx context.Context, arg MarkUploadCompletedParams) (CatalogUpload, error) {
@@ -225,6 +318,8 @@ func (q *Queries) MarkUploadCompleted(ctx context.Context, arg MarkUploadComplet
 		arg.GcsGeneration,
 		arg.GcsEtag,
 		arg.ContentType,
+		arg.SniffedContentType,
+		arg.AssetID,
 		arg.VideoID,
 	)
 	var i CatalogUpload
@@ -250,6 +345,11 @@ func (q *Queries) MarkUploadCompleted(ctx context.Context, arg MarkUploadComplet
 		&i.ErrorMessage,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SniffedContentType,
+		&i.ContentSha256,
+		&i.AssetID,
+		&i.RawMissing,
+		&i.RawMissingAt,
 	)
 	return i, err
 }
@@ -259,19 +359,69 @@ UPDATE catalog.uploads
 SET status = 'failed',
     error_code = $1,
     error_message = $2,
+    sniffed_content_type = COALESCE($3, sniffed_content_type),
     updated_at = now()
-WHERE video_id = $3
-RETURNING video_id, user_id, bucket, object_name, content_type, expected_size, size_bytes, content_md5, title, description, signed_url, signed_url_expires_at, status, gcs_generation, gcs_etag, md5_hash, crc32c, error_code, error_message, created_at, updated_at
+WHERE video_id = $4
+RETURNING video_id, user_id, bucket, object_name, content_type, expected_size, size_bytes, content_md5, title, description, signed_url, signed_url_expires_at, status, gcs_generation, gcs_etag, md5_hash, crc32c, error_code, error_message, created_at, updated_at, sniffed_content_type, content_sha256, asset_id, raw_missing, raw_missing_at
 `
 
 type MarkUploadFailedParams struct {
-	ErrorCode    pgtype.Text `json:"error_code"`
-	ErrorMessage pgtype.Text `json:"error_message"`
-	VideoID      uuid.UUID   `json:"video_id"`
+	ErrorCode          pgtype.Text `json:"error_code"`
+	ErrorMessage       pgtype.Text `json:"error_message"`
+	SniffedContentType pgtype.Text `json:"sniffed_content_type"`
+	VideoID            uuid.UUID   `json:"video_id"`
 }
 
 func (q *Queries) MarkUploadFailed(ctx context.Context, arg MarkUploadFailedParams) (CatalogUpload, error) {
-	row := q.db.QueryRow(ctx, markUploadFailed, arg.ErrorCode, arg.ErrorMessage, arg.VideoID)
+	row := q.db.QueryRow(ctx, markUploadFailed,
+		arg.ErrorCode,
+		arg.ErrorMessage,
+		arg.SniffedContentType,
+		arg.VideoID,
+	)
+	var i CatalogUpload
+	err := row.Scan(
+		&i.VideoID,
+		&i.UserID,
+		&i.Bucket,
+		&i.ObjectName,
+		&i.ContentType,
+		&i.ExpectedSize,
+		&i.SizeBytes,
+		&i.ContentMd5,
+		&i.Title,
+		&i.Description,
+		&i.SignedUrl,
+		&i.SignedUrlExpiresAt,
+		&i.Status,
+		&i.GcsGeneration,
+		&i.GcsEtag,
+		&i.Md5Hash,
+		&i.Crc32c,
+		&i.ErrorCode,
+		&i.ErrorMessage,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SniffedContentType,
+		&i.ContentSha256,
+		&i.AssetID,
+		&i.RawMissing,
+		&i.RawMissingAt,
+	)
+	return i, err
+}
+
+const markUploadRawMissing = `-- name: MarkUploadRawMissing :one
+UPDATE catalog.uploads
+SET raw_missing = true,
+    raw_missing_at = now(),
+    updated_at = now()
+WHERE video_id = $1
+RETURNING video_id, user_id, bucket, object_name, content_type, expected_size, size_bytes, content_md5, title, description, signed_url, signed_url_expires_at, status, gcs_generation, gcs_etag, md5_hash, crc32c, error_code, error_message, created_at, updated_at, sniffed_content_type, content_sha256, asset_id, raw_missing, raw_missing_at
+`
+
+func (q *Queries) MarkUploadRawMissing(ctx context.Context, videoID uuid.UUID) (CatalogUpload, error) {
+	row := q.db.QueryRow(ctx, markUploadRawMissing, videoID)
 	var i CatalogUpload
 	err := row.Scan(
 		&i.VideoID,
@@ -295,6 +445,49 @@ func (q *Queries) MarkUploadFailed(ctx context.Context, arg MarkUploadFailedPara
 		&i.ErrorMessage,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SniffedContentType,
+		&i.ContentSha256,
+		&i.AssetID,
+		&i.RawMissing,
+		&i.RawMissingAt,
+	)
+	return i, err
+}
+
+const reconcileUploadUsage = `-- name: ReconcileUploadUsage :one
+INSERT INTO catalog.upload_usage (
+  user_id,
+  stored_bytes,
+  completed_uploads,
+  reconciled_at,
+  updated_at
+)
+SELECT
+  $1::uuid,
+  COALESCE(SUM(u.size_bytes), 0)::bigint,
+  COUNT(*)::bigint,
+  now(),
+  now()
+FROM catalog.uploads u
+WHERE u.user_id = $1
+  AND u.status = 'completed'
+ON CONFLICT (user_id) DO UPDATE
+SET stored_bytes = EXCLUDED.stored_bytes,
+    completed_uploads = EXCLUDED.completed_uploads,
+    reconciled_at = EXCLUDED.reconciled_at,
+    updated_at = now()
+RETURNING user_id, stored_bytes, completed_uploads, reconciled_at, updated_at
+`
+
+func (q *Queries) ReconcileUploadUsage(ctx context.Context, userID uuid.UUID) (CatalogUploadUsage, error) {
+	row := q.db.QueryRow(ctx, reconcileUploadUsage, userID)
+	var i CatalogUploadUsage
+	err := row.Scan(
+		&i.UserID,
+		&i.StoredBytes,
+		&i.CompletedUploads,
+		&i.ReconciledAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
@@ -313,7 +506,8 @@ WITH upsert AS (
     description,
     signed_url,
     signed_url_expires_at,
-    status
+    status,
+    content_sha256
   )
   VALUES (
     $1,
@@ -327,7 +521,8 @@ WITH upsert AS (
     $9,
     $10,
     $11,
-    $12
+    $12,
+    $13
   )
   ON CONFLICT (user_id, content_md5)
   DO UPDATE
@@ -339,6 +534,7 @@ WITH upsert AS (
       description = EXCLUDED.description,
       signed_url = EXCLUDED.signed_url,
       signed_url_expires_at = EXCLUDED.signed_url_expires_at,
+      content_sha256 = EXCLUDED.content_sha256,
       status = CASE
         WHEN catalog.uploads.status = 'completed' THEN catalog.uploads.status
         ELSE EXCLUDED.status
@@ -365,9 +561,14 @@ WITH upsert AS (
             u.error_message,
             u.created_at,
             u.updated_at,
+            u.sniffed_content_type,
+            u.content_sha256,
+            u.asset_id,
+            u.raw_missing,
+            u.raw_missing_at,
             (xmax = 0)::bool AS inserted
 )
-SELECT video_id, user_id, bucket, object_name, content_type, expected_size, size_bytes, content_md5, title, description, signed_url, signed_url_expires_at, status, gcs_generation, gcs_etag, md5_hash, crc32c, error_code, error_message, created_at, updated_at, inserted FROM upsert
+SELECT video_id, user_id, bucket, object_name, content_type, expected_size, size_bytes, content_md5, title, description, signed_url, signed_url_expires_at, status, gcs_generation, gcs_etag, md5_hash, crc32c, error_code, error_message, created_at, updated_at, sniffed_content_type, content_sha256, asset_id, raw_missing, raw_missing_at, inserted FROM upsert
 `
 
 type UpsertUploadParams struct {
@@ -383,6 +584,7 @@ type UpsertUploadParams struct {
 	SignedUrl          pgtype.Text        `json:"signed_url"`
 	SignedUrlExpiresAt pgtype.Timestamptz `json:"signed_url_expires_at"`
 	Status             string             `json:"status"`
+	ContentSha256      pgtype.Text        `json:"content_sha256"`
 }
 
 type UpsertUploadRow struct {
@@ -407,6 +609,11 @@ type UpsertUploadRow struct {
 	ErrorMessage       pgtype.Text        `json:"error_message"`
 	CreatedAt          pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
+	SniffedContentType pgtype.Text        `json:"sniffed_content_type"`
+	ContentSha256      pgtype.Text        `json:"content_sha256"`
+	AssetID            pgtype.UUID        `json:"asset_id"`
+	RawMissing         bool               `json:"raw_missing"`
+	RawMissingAt       pgtype.Timestamptz `json:"raw_missing_at"`
 	Inserted           bool               `json:"inserted"`
 }
 
@@ -424,6 +631,7 @@ func (q *Queries) UpsertUpload(ctx context.Context, arg UpsertUploadParams) (Ups
 		arg.SignedUrl,
 		arg.SignedUrlExpiresAt,
 		arg.Status,
+		arg.ContentSha256,
 	)
 	var i UpsertUploadRow
 	err := row.Scan(
@@ -448,6 +656,11 @@ func (q *Queries) UpsertUpload(ctx context.Context, arg UpsertUploadParams) (Ups
 		&i.ErrorMessage,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SniffedContentType,
+		&i.ContentSha256,
+		&i.AssetID,
+		&i.RawMissing,
+		&i.RawMissingAt,
 		&i.Inserted,
 	)
 	return i, err
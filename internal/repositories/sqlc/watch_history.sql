@@ -0,0 +1,36 @@
+-- 继续观看 / 观看历史相关 SQL
+
+-- 读取用户在某视频上的最近播放位置
+-- name: GetVideoWatchProgress :one
+SELECT
+    video_id,
+    user_id,
+    last_position_seconds,
+    last_progress_at
+FROM catalog.video_view_sessions
+WHERE user_id = sqlc.arg('user_id')
+  AND video_id = sqlc.arg('video_id');
+
+-- 按最近观看时间倒序列出用户观看历史，仅包含 ready/published 且非 private 的视频
+-- name: ListUserWatchHistory :many
+SELECT
+    s.video_id,
+    v.title,
+    v.status,
+    v.media_status,
+    v.analysis_status,
+    v.duration_micros,
+    s.last_position_seconds,
+    s.last_progress_at
+FROM catalog.video_view_sessions s
+JOIN catalog.videos v ON v.video_id = s.video_id
+WHERE s.user_id = sqlc.arg('user_id')
+  AND v.status IN ('ready', 'published')
+  AND v.visibility_status <> 'private'
+  AND (
+        sqlc.narg('cursor_watched_at')::timestamptz IS NULL
+        OR s.last_progress_at < sqlc.narg('cursor_watched_at')::timestamptz
+        OR (s.last_progress_at = sqlc.narg('cursor_watched_at')::timestamptz AND s.video_id < sqlc.narg('cursor_video_id')::uuid)
+      )
+ORDER BY s.last_progress_at DESC, s.video_id DESC
+LIMIT sqlc.arg('limit');
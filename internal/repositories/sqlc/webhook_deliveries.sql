@@ -0,0 +1,50 @@
+-- Webhook 投递日志相关 SQL
+
+-- 追加一次投递尝试，attempt 取该事件在该端点上已有尝试数加一
+-- name: InsertWebhookDelivery :exec
+INSERT INTO catalog.webhook_deliveries (
+    event_id,
+    endpoint,
+    event_type,
+    attempt,
+    status_code,
+    error,
+    duration_ms,
+    succeeded
+)
+SELECT
+    sqlc.arg('event_id')::uuid,
+    sqlc.arg('endpoint')::text,
+    sqlc.arg('event_type')::text,
+    COALESCE(MAX(d.attempt), 0) + 1,
+    sqlc.narg('status_code')::integer,
+    sqlc.narg('error')::text,
+    sqlc.arg('duration_ms')::integer,
+    sqlc.arg('succeeded')::boolean
+FROM catalog.webhook_deliveries d
+WHERE d.event_id = sqlc.arg('event_id')::uuid
+  AND d.endpoint = sqlc.arg('endpoint')::text;
+
+-- 返回事件已投递成功的端点名
+-- name: ListDeliveredWebhookEndpoints :many
+SELECT DISTINCT endpoint
+FROM catalog.webhook_deliveries
+WHERE event_id = $1
+  AND succeeded;
+
+-- 按尝试顺序列出事件的投递记录
+-- name: ListWebhookDeliveries :many
+SELECT
+    id,
+    event_id,
+    endpoint,
+    event_type,
+    attempt,
+    status_code,
+    error,
+    duration_ms,
+    succeeded,
+    attempted_at
+FROM catalog.webhook_deliveries
+WHERE event_id = $1
+ORDER BY attempted_at, id;
@@ -0,0 +1,137 @@
+package repositories_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventBackfillRepositoryIntegration(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dsn, terminate := startPostgres(ctx, t)
+	defer terminate()
+
+	pool, err := pgxpool.New(ctx, dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { pool.Close() })
+
+	ensureAuthSchema(ctx, t, pool)
+	applyMigrations(ctx, t, pool)
+
+	repo := repositories.NewEventBackfillRepository(pool, log.NewStdLogger(io.Discard))
+
+	uploader, other := uuid.New(), uuid.New()
+	insertAuthUser(ctx, t, pool, uploader, "backfill-a@example.com")
+	insertAuthUser(ctx, t, pool, other, "backfill-b@example.com")
+
+	base := time.Date(2025, 10, 20, 12, 0, 0, 0, time.UTC)
+	var published []uuid.UUID
+	for i := 0; i < 3; i++ {
+		seed := insertVideo(ctx, t, pool, videoSeed{
+			VideoID:        uuid.New(),
+			UploadUserID:   uploader,
+			Title:          "Published",
+			Status:         po.VideoStatusPublished,
+			MediaStatus:    po.StageReady,
+			AnalysisStatus: po.StageReady,
+			CreatedAt:      base.Add(time.Duration(i) * time.Hour),
+			Version:        1,
+		})
+		published = append(published, seed.VideoID)
+	}
+	processing := insertVideo(ctx, t, pool, videoSeed{
+		VideoID:        uuid.New(),
+		UploadUserID:   other,
+		Title:          "Processing",
+		Status:         po.VideoStatusProcessing,
+		MediaStatus:    po.StageProcessing,
+		AnalysisStatus: po.StagePending,
+		CreatedAt:      base,
+		Version:        1,
+	})
+	slices.SortFunc(published, func(a, b uuid.UUID) int { return bytes.Compare(a[:], b[:]) })
+
+	t.Run("keyset pagination over scope", func(t *testing.T) {
+		scope := repositories.BackfillScope{Statuses: []po.VideoStatus{po.VideoStatusPublished}}
+		first, err := repo.ListVideos(ctx, nil, scope, nil, 2)
+		require.NoError(t, err)
+		require.Equal(t, published[:2], videoIDsOf(first))
+
+		last := first[len(first)-1].VideoID
+		second, err := repo.ListVideos(ctx, nil, scope, &last, 2)
+		require.NoError(t, err)
+		require.Equal(t, published[2:], videoIDsOf(second))
+		require.Equal(t, "Published", second[0].Title)
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		all, err := repo.ListVideos(ctx, nil, repositories.BackfillScope{}, nil, 10)
+		require.NoError(t, err)
+		require.Len(t, all, 4)
+
+		byUploader, err := repo.ListVideos(ctx, nil, repositories.BackfillScope{UploaderID: &other}, nil, 10)
+		require.NoError(t, err)
+		require.Equal(t, []uuid.UUID{processing.VideoID}, videoIDsOf(byUploader))
+
+		private := po.VisibilityPrivate
+		none, err := repo.ListVideos(ctx, nil, repositories.BackfillScope{VisibilityStatus: &private}, nil, 10)
+		require.NoError(t, err)
+		require.Empty(t, none)
+	})
+
+	t.Run("checkpoint lifecycle", func(t *testing.T) {
+		_, err := repo.GetCheckpoint(ctx, nil, "search-index")
+		require.ErrorIs(t, err, repositories.ErrBackfillCheckpointNotFound)
+
+		visibility := po.VisibilityPublic
+		scope := repositories.BackfillScope{
+			Statuses:         []po.VideoStatus{po.VideoStatusPublished, po.VideoStatusReady},
+			VisibilityStatus: &visibility,
+			UploaderID:       &uploader,
+		}
+		created, err := repo.CreateCheckpoint(ctx, nil, "search-index", "search", scope)
+		require.NoError(t, err)
+		require.False(t, created.StartedAt.IsZero())
+		require.Nil(t, created.LastVideoID)
+
+		require.NoError(t, repo.AdvanceCheckpoint(ctx, nil, "search-index", published[1], 2))
+		cp, err := repo.GetCheckpoint(ctx, nil, "search-index")
+		require.NoError(t, err)
+		require.Equal(t, "search", cp.Target)
+		require.Equal(t, scope.Statuses, cp.ScopeStatuses)
+		require.Equal(t, visibility, *cp.ScopeVisibility)
+		require.Equal(t, uploader, *cp.ScopeUploaderID)
+		require.Equal(t, published[1], *cp.LastVideoID)
+		require.Equal(t, int64(2), cp.PublishedCount)
+		require.Nil(t, cp.CompletedAt)
+
+		require.NoError(t, repo.CompleteCheckpoint(ctx, nil, "search-index"))
+		cp, err = repo.GetCheckpoint(ctx, nil, "search-index")
+		require.NoError(t, err)
+		require.NotNil(t, cp.CompletedAt)
+
+		require.NoError(t, repo.DeleteCheckpoint(ctx, nil, "search-index"))
+		_, err = repo.GetCheckpoint(ctx, nil, "search-index")
+		require.ErrorIs(t, err, repositories.ErrBackfillCheckpointNotFound)
+	})
+}
+
+func videoIDsOf(videos []*po.Video) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(videos))
+	for _, video := range videos {
+		ids = append(ids, video.VideoID)
+	}
+	return ids
+}
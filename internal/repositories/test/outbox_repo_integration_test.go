@@ -92,6 +92,58 @@ func TestOutboxRepositoryIntegration(t *testing.T) {
 	require.Equal(t, int64(0), count)
 }
 
+func TestOutboxRepositoryRetryRespectsLease(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dsn, terminate := startPostgres(ctx, t)
+	defer terminate()
+
+	pool, err := pgxpool.New(ctx, dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { pool.Close() })
+
+	applyMigrations(ctx, t, pool)
+
+	repo := repositories.NewOutboxRepository(pool, log.NewStdLogger(io.Discard), outboxcfg.Config{
+		Schema:    "catalog",
+		Publisher: outboxcfg.PublisherConfig{LockTTL: time.Minute},
+	})
+
+	leased, abandoned := uuid.New(), uuid.New()
+	for _, eventID := range []uuid.UUID{leased, abandoned} {
+		require.NoError(t, repo.Enqueue(ctx, nil, repositories.OutboxMessage{
+			EventID:       eventID,
+			AggregateType: "video",
+			AggregateID:   uuid.New(),
+			EventType:     "catalog.video.updated",
+			Payload:       []byte(`{}`),
+			AvailableAt:   time.Now().UTC(),
+		}))
+	}
+	require.NoError(t, repo.Abandon(ctx, nil, abandoned, "obsolete"))
+
+	count, err := repo.CountPending(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count, "abandoned events are not backlog")
+
+	now := time.Now().UTC()
+	claimed, err := repo.ClaimPending(ctx, now, now.Add(-time.Minute), 8, uuid.NewString())
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+
+	require.ErrorIs(t, repo.ResetForRetry(ctx, nil, leased), repositories.ErrOutboxEventNotPending)
+
+	_, err = pool.Exec(ctx, `UPDATE catalog.outbox_events SET locked_at = now() - interval '2 minutes' WHERE event_id = $1`, leased)
+	require.NoError(t, err)
+	require.NoError(t, repo.ResetForRetry(ctx, nil, leased))
+	require.NoError(t, repo.ResetForRetry(ctx, nil, abandoned))
+
+	count, err = repo.CountPending(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+}
+
 func startPostgres(ctx context.Context, t *testing.T) (string, func()) {
 	t.Helper()
 
@@ -0,0 +1,43 @@
+package repositories_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboxTopicDeliveryRepositoryIntegration(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dsn, terminate := startPostgres(ctx, t)
+	defer terminate()
+
+	pool, err := pgxpool.New(ctx, dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { pool.Close() })
+
+	applyMigrations(ctx, t, pool)
+
+	repo := repositories.NewOutboxTopicDeliveryRepository(pool, log.NewStdLogger(io.Discard))
+	eventID := uuid.New()
+
+	require.NoError(t, repo.MarkDelivered(ctx, nil, eventID, "default", "catalog.video.created"))
+	require.NoError(t, repo.MarkDelivered(ctx, nil, eventID, "partners", "catalog.video.created"))
+	// 重复记录（例如回写前进程重启后重发成功）不报错。
+	require.NoError(t, repo.MarkDelivered(ctx, nil, eventID, "default", "catalog.video.created"))
+
+	delivered, err := repo.DeliveredTopics(ctx, nil, eventID)
+	require.NoError(t, err)
+	require.Equal(t, map[string]struct{}{"default": {}, "partners": {}}, delivered)
+
+	none, err := repo.DeliveredTopics(ctx, nil, uuid.New())
+	require.NoError(t, err)
+	require.Empty(t, none)
+}
@@ -0,0 +1,88 @@
+package repositories_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVideoDeleteReleasesRawAsset(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dsn, terminate := startPostgres(ctx, t)
+	defer terminate()
+
+	pool, err := pgxpool.New(ctx, dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { pool.Close() })
+
+	ensureAuthSchema(ctx, t, pool)
+	applyMigrations(ctx, t, pool)
+
+	logger := log.NewStdLogger(io.Discard)
+	assets := repositories.NewRawAssetRepository(pool, logger)
+	videos := repositories.NewVideoRepository(pool, logger)
+
+	uploader := uuid.New()
+	insertAuthUser(ctx, t, pool, uploader, "raw-asset@example.com")
+
+	input := repositories.AcquireRawAssetInput{
+		ContentSHA256: strings.Repeat("ab", 32),
+		ContentMD5:    strings.Repeat("c", 32),
+		SizeBytes:     4096,
+		Bucket:        "media-test",
+		ObjectName:    "raw_videos/canonical",
+		CreatedBy:     uploader,
+	}
+	asset, inserted, err := assets.Acquire(ctx, nil, input)
+	require.NoError(t, err)
+	require.True(t, inserted)
+	_, inserted, err = assets.Acquire(ctx, nil, input)
+	require.NoError(t, err)
+	require.False(t, inserted)
+
+	base := time.Date(2025, 10, 20, 12, 0, 0, 0, time.UTC)
+	var videoIDs []uuid.UUID
+	for i := 0; i < 2; i++ {
+		seed := insertVideo(ctx, t, pool, videoSeed{
+			VideoID:        uuid.New(),
+			UploadUserID:   uploader,
+			Title:          "Shared Asset",
+			Status:         po.VideoStatusReady,
+			MediaStatus:    po.StageReady,
+			AnalysisStatus: po.StageReady,
+			CreatedAt:      base.Add(time.Duration(i) * time.Hour),
+			Version:        1,
+		})
+		_, err := pool.Exec(ctx, `update catalog.videos set asset_id = $2 where video_id = $1`, seed.VideoID, asset.AssetID)
+		require.NoError(t, err)
+		videoIDs = append(videoIDs, seed.VideoID)
+	}
+
+	deleted, err := videos.Delete(ctx, nil, videoIDs[0])
+	require.NoError(t, err)
+	require.Equal(t, asset.AssetID, *deleted.AssetID)
+	require.Equal(t, int64(1), refCountOf(ctx, t, pool, asset.AssetID))
+
+	_, err = videos.Delete(ctx, nil, videoIDs[1])
+	require.NoError(t, err)
+	require.Equal(t, int64(0), refCountOf(ctx, t, pool, asset.AssetID))
+}
+
+func refCountOf(ctx context.Context, t *testing.T, pool *pgxpool.Pool, assetID uuid.UUID) int64 {
+	t.Helper()
+
+	var refCount int64
+	require.NoError(t, pool.QueryRow(ctx, `select ref_count from catalog.raw_assets where asset_id = $1`, assetID).Scan(&refCount))
+	return refCount
+}
@@ -0,0 +1,133 @@
+package repositories_test
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	outboxcfg "github.com/bionicotaku/lingo-utils/outbox/config"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRetentionRepositoryPrunesAndArchives 覆盖 DELETE ... RETURNING → 归档 INSERT 的 CTE：
+// 过期行从源表删除并按 archive 决定是否移入归档表，未过期、未发布且未放弃、未处理的行保持不动。
+func TestRetentionRepositoryPrunesAndArchives(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dsn, terminate := startPostgres(ctx, t)
+	defer terminate()
+
+	pool, err := pgxpool.New(ctx, dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { pool.Close() })
+
+	applyMigrations(ctx, t, pool)
+
+	logger := log.NewStdLogger(io.Discard)
+	outboxRepo := repositories.NewOutboxRepository(pool, logger, outboxcfg.Config{Schema: "catalog"})
+	inboxRepo := repositories.NewInboxRepository(pool, logger, outboxcfg.Config{Schema: "catalog"})
+	repo := repositories.NewRetentionRepository(pool, logger)
+
+	now := time.Now().UTC()
+	old := now.Add(-48 * time.Hour)
+	cutoff := now.Add(-24 * time.Hour)
+
+	published, publishedNoArchive, recent, pending := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	abandoned, recentAbandoned := uuid.New(), uuid.New()
+	for _, eventID := range []uuid.UUID{published, publishedNoArchive, recent, pending, abandoned, recentAbandoned} {
+		require.NoError(t, outboxRepo.Enqueue(ctx, nil, repositories.OutboxMessage{
+			EventID:       eventID,
+			AggregateType: "video",
+			AggregateID:   uuid.New(),
+			EventType:     "catalog.video.updated",
+			Payload:       []byte(`{}`),
+			AvailableAt:   now,
+		}))
+	}
+	_, err = pool.Exec(ctx, `UPDATE catalog.outbox_events SET published_at = $2 WHERE event_id = $1`, published, old)
+	require.NoError(t, err)
+	_, err = pool.Exec(ctx, `UPDATE catalog.outbox_events SET published_at = $2 WHERE event_id = $1`, publishedNoArchive, old.Add(time.Minute))
+	require.NoError(t, err)
+	_, err = pool.Exec(ctx, `UPDATE catalog.outbox_events SET published_at = $2 WHERE event_id = $1`, recent, now)
+	require.NoError(t, err)
+	require.NoError(t, outboxRepo.Abandon(ctx, nil, abandoned, "obsolete"))
+	require.NoError(t, outboxRepo.Abandon(ctx, nil, recentAbandoned, "obsolete"))
+	_, err = pool.Exec(ctx, `UPDATE catalog.outbox_events SET abandoned_at = $2 WHERE event_id = $1`, abandoned, old)
+	require.NoError(t, err)
+
+	// 已发布：limit=1 只删最早的一行并归档；第二批 archive=false 删除但不归档；未过期的行保留。
+	pruned, err := repo.PruneOutbox(ctx, nil, cutoff, 1, true)
+	require.NoError(t, err)
+	require.Len(t, pruned, 1)
+	require.Equal(t, published, pruned[0].EventID)
+	require.NotNil(t, pruned[0].PublishedAt)
+
+	pruned, err = repo.PruneOutbox(ctx, nil, cutoff, 10, false)
+	require.NoError(t, err)
+	require.Len(t, pruned, 1)
+	require.Equal(t, publishedNoArchive, pruned[0].EventID)
+
+	// 已放弃：published_at 为空的行同样可以归档。
+	prunedAbandoned, err := repo.PruneAbandonedOutbox(ctx, nil, cutoff, 10, true)
+	require.NoError(t, err)
+	require.Len(t, prunedAbandoned, 1)
+	require.Equal(t, abandoned, prunedAbandoned[0].EventID)
+	require.Nil(t, prunedAbandoned[0].PublishedAt)
+	require.NotNil(t, prunedAbandoned[0].AbandonedReason)
+	require.Equal(t, "obsolete", *prunedAbandoned[0].AbandonedReason)
+
+	require.ElementsMatch(t, []uuid.UUID{recent, pending, recentAbandoned}, tableEventIDs(ctx, t, pool, "catalog.outbox_events"))
+	require.ElementsMatch(t, []uuid.UUID{published, abandoned}, tableEventIDs(ctx, t, pool, "catalog.outbox_events_archive"))
+
+	var archivedPublishedAt *time.Time
+	require.NoError(t, pool.QueryRow(ctx, `SELECT published_at FROM catalog.outbox_events_archive WHERE event_id = $1`, abandoned).Scan(&archivedPublishedAt))
+	require.Nil(t, archivedPublishedAt)
+
+	// Inbox：只有处理完成且早于 cutoff 的行被删除并归档，未处理的行与保留类型的行不动。
+	processed, unprocessed, kept := uuid.New(), uuid.New(), uuid.New()
+	for eventID, eventType := range map[uuid.UUID]string{
+		processed:   "media.video.ready",
+		unprocessed: "media.video.ready",
+		kept:        "profile.engagement.added",
+	} {
+		require.NoError(t, inboxRepo.Insert(ctx, nil, repositories.InboxMessage{
+			EventID:       eventID,
+			SourceService: "profile",
+			EventType:     eventType,
+			Payload:       []byte(`{}`),
+		}))
+	}
+	require.NoError(t, inboxRepo.MarkProcessed(ctx, nil, processed, old))
+	require.NoError(t, inboxRepo.MarkProcessed(ctx, nil, kept, old))
+
+	prunedInbox, err := repo.PruneInbox(ctx, nil, cutoff, 10, true, []string{"profile.engagement.added"})
+	require.NoError(t, err)
+	require.Len(t, prunedInbox, 1)
+	require.Equal(t, processed, prunedInbox[0].EventID)
+
+	require.ElementsMatch(t, []uuid.UUID{unprocessed, kept}, tableEventIDs(ctx, t, pool, "catalog.inbox_events"))
+	require.ElementsMatch(t, []uuid.UUID{processed}, tableEventIDs(ctx, t, pool, "catalog.inbox_events_archive"))
+}
+
+func tableEventIDs(ctx context.Context, t *testing.T, pool *pgxpool.Pool, table string) []uuid.UUID {
+	t.Helper()
+
+	rows, err := pool.Query(ctx, `SELECT event_id FROM `+table)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		require.NoError(t, rows.Scan(&id))
+		ids = append(ids, id)
+	}
+	require.NoError(t, rows.Err())
+	return ids
+}
@@ -27,7 +27,7 @@ func TestVideoEngagementStatsRepositoryIntegration(t *testing.T) {
 	applyMigrations(ctx, t, pool)
 
 	logger := log.NewStdLogger(io.Discard)
-	repo := repositories.NewVideoEngagementStatsRepository(pool, logger)
+	repo := repositories.NewVideoEngagementStatsRepository(pool, logger, repositories.StatsShardPolicy{})
 
 	videoID := uuid.New()
 	now := time.Now().UTC().Truncate(time.Second)
@@ -123,3 +123,64 @@ func TestVideoEngagementStatsRepositoryIntegration(t *testing.T) {
 	require.EqualValues(t, 0, empty.WatchCount)
 	require.EqualValues(t, 0, empty.UniqueWatchers)
 }
+
+func TestVideoEngagementStatsRepositoryDriftAndRepair(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dsn, terminate := startPostgres(ctx, t)
+	defer terminate()
+
+	pool, err := pgxpool.New(ctx, dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { pool.Close() })
+
+	applyMigrations(ctx, t, pool)
+
+	logger := log.NewStdLogger(io.Discard)
+	repo := repositories.NewVideoEngagementStatsRepository(pool, logger, repositories.StatsShardPolicy{})
+	userRepo := repositories.NewVideoUserStatesRepository(pool, logger)
+
+	videoID := uuid.New()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	// 明细：两个点赞、一个收藏、一个观看者；统计表中 like_count 多记一次且缺少观看者。
+	for i := 0; i < 2; i++ {
+		require.NoError(t, userRepo.Upsert(ctx, nil, repositories.UpsertVideoUserStateInput{
+			UserID:          uuid.New(),
+			VideoID:         videoID,
+			HasLiked:        true,
+			HasBookmarked:   i == 0,
+			LikedOccurredAt: &now,
+		}))
+	}
+	_, err = repo.MarkWatcher(ctx, nil, videoID, uuid.New(), now)
+	require.NoError(t, err)
+	_, err = repo.Increment(ctx, nil, videoID, repositories.StatsDelta{LikeDelta: 3, BookmarkDelta: 1, WatchDelta: 4})
+	require.NoError(t, err)
+
+	drifts, err := repo.ListDrift(ctx, nil, repositories.StatsDriftFilter{VideoID: &videoID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, drifts, 1)
+	require.EqualValues(t, 3, drifts[0].StoredLikeCount)
+	require.EqualValues(t, 2, drifts[0].ActualLikeCount)
+	require.EqualValues(t, 1, drifts[0].ActualBookmarkCount)
+	require.EqualValues(t, 0, drifts[0].StoredUniqueWatchers)
+	require.EqualValues(t, 1, drifts[0].ActualUniqueWatchers)
+
+	future := now.Add(time.Hour)
+	drifts, err = repo.ListDrift(ctx, nil, repositories.StatsDriftFilter{VideoID: &videoID, Since: &future, Limit: 10})
+	require.NoError(t, err)
+	require.Empty(t, drifts)
+
+	repaired, err := repo.Repair(ctx, nil, []uuid.UUID{videoID})
+	require.NoError(t, err)
+	require.Len(t, repaired, 1)
+
+	stats, err := repo.Get(ctx, nil, videoID)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, stats.LikeCount)
+	require.EqualValues(t, 1, stats.BookmarkCount)
+	require.EqualValues(t, 1, stats.UniqueWatchers)
+	require.EqualValues(t, 4, stats.WatchCount, "watch_count has no detail source and must be preserved")
+}
@@ -0,0 +1,82 @@
+package repositories_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookDeliveryRepositoryIntegration(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dsn, terminate := startPostgres(ctx, t)
+	defer terminate()
+
+	pool, err := pgxpool.New(ctx, dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { pool.Close() })
+
+	applyMigrations(ctx, t, pool)
+
+	repo := repositories.NewWebhookDeliveryRepository(pool, log.NewStdLogger(io.Discard))
+	eventID := uuid.New()
+	attempt := func(endpoint string, status int, err error) repositories.WebhookDeliveryAttempt {
+		return repositories.WebhookDeliveryAttempt{
+			EventID:    eventID,
+			Endpoint:   endpoint,
+			EventType:  "catalog.video.created",
+			StatusCode: status,
+			Err:        err,
+			Duration:   42 * time.Millisecond,
+		}
+	}
+
+	require.NoError(t, repo.Record(ctx, nil, attempt("partner", 0, errors.New("connection refused"))))
+	require.NoError(t, repo.Record(ctx, nil, attempt("partner", 503, errors.New("unexpected status 503"))))
+	require.NoError(t, repo.Record(ctx, nil, attempt("partner", 200, nil)))
+	require.NoError(t, repo.Record(ctx, nil, attempt("audit", 500, errors.New("unexpected status 500"))))
+
+	delivered, err := repo.DeliveredEndpoints(ctx, nil, eventID)
+	require.NoError(t, err)
+	require.Equal(t, map[string]struct{}{"partner": {}}, delivered)
+
+	none, err := repo.DeliveredEndpoints(ctx, nil, uuid.New())
+	require.NoError(t, err)
+	require.Empty(t, none)
+
+	history, err := repo.List(ctx, nil, eventID)
+	require.NoError(t, err)
+	require.Len(t, history, 4)
+
+	var partner []int32
+	for _, d := range history {
+		require.Equal(t, "catalog.video.created", d.EventType)
+		require.Equal(t, int32(42), d.DurationMs)
+		if d.Endpoint == "partner" {
+			partner = append(partner, d.Attempt)
+		}
+	}
+	require.Equal(t, []int32{1, 2, 3}, partner)
+
+	require.Nil(t, history[0].StatusCode)
+	require.NotNil(t, history[0].Error)
+	require.Equal(t, "connection refused", *history[0].Error)
+	require.False(t, history[0].Succeeded)
+
+	require.NotNil(t, history[2].StatusCode)
+	require.Equal(t, int32(200), *history[2].StatusCode)
+	require.Nil(t, history[2].Error)
+	require.True(t, history[2].Succeeded)
+
+	require.Equal(t, "audit", history[3].Endpoint)
+	require.Equal(t, int32(1), history[3].Attempt)
+}
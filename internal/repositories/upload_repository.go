@@ -45,6 +45,7 @@ type UpsertUploadInput struct {
 	ContentType        *string
 	ExpectedSize       int64
 	ContentMD5         string
+	ContentSHA256      *string
 	Title              string
 	Description        string
 	SignedURL          *string
@@ -66,6 +67,7 @@ func (r *UploadRepository) Upsert(ctx context.Context, sess txmanager.Session, i
 		input.ContentType,
 		input.ExpectedSize,
 		input.ContentMD5,
+		input.ContentSHA256,
 		input.Title,
 		input.Description,
 		input.SignedURL,
@@ -160,6 +162,8 @@ func (r *UploadRepository) MarkCompleted(ctx context.Context, sess txmanager.Ses
 		input.GCSGeneration,
 		input.GCSEtag,
 		input.ContentType,
+		input.SniffedContentType,
+		input.AssetID,
 	)
 
 	record, err := queries.MarkUploadCompleted(ctx, params)
@@ -176,13 +180,15 @@ func (r *UploadRepository) MarkCompleted(ctx context.Context, sess txmanager.Ses
 
 // MarkUploadCompletedInput 描述回调写入成功时的字段。
 type MarkUploadCompletedInput struct {
-	VideoID       uuid.UUID
-	SizeBytes     int64
-	MD5Hash       *string
-	CRC32C        *string
-	GCSGeneration *string
-	GCSEtag       *string
-	ContentType   *string
+	VideoID            uuid.UUID
+	SizeBytes          int64
+	MD5Hash            *string
+	CRC32C             *string
+	GCSGeneration      *string
+	GCSEtag            *string
+	ContentType        *string
+	SniffedContentType *string
+	AssetID            *uuid.UUID
 }
 
 // MarkFailed 将上传会话标记为失败并记录原因。
@@ -192,7 +198,7 @@ func (r *UploadRepository) MarkFailed(ctx context.Context, sess txmanager.Sessio
 		queries = queries.WithTx(sess.Tx())
 	}
 
-	params := mappers.BuildMarkUploadFailedParams(input.VideoID, input.ErrorCode, input.ErrorMessage)
+	params := mappers.BuildMarkUploadFailedParams(input.VideoID, input.ErrorCode, input.ErrorMessage, input.SniffedContentType)
 
 	record, err := queries.MarkUploadFailed(ctx, params)
 	if err != nil {
@@ -208,9 +214,79 @@ func (r *UploadRepository) MarkFailed(ctx context.Context, sess txmanager.Sessio
 
 // MarkUploadFailedInput 描述写失败时的参数。
 type MarkUploadFailedInput struct {
-	VideoID      uuid.UUID
-	ErrorCode    *string
-	ErrorMessage *string
+	VideoID            uuid.UUID
+	ErrorCode          *string
+	ErrorMessage       *string
+	SniffedContentType *string
+}
+
+// MarkRawMissing 标记上传会话的原始对象已被删除或归档。
+func (r *UploadRepository) MarkRawMissing(ctx context.Context, sess txmanager.Session, videoID uuid.UUID) (*po.UploadSession, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	record, err := queries.MarkUploadRawMissing(ctx, videoID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUploadNotFound
+		}
+		r.log.WithContext(ctx).Errorf("mark upload raw missing failed: video_id=%s err=%v", videoID, err)
+		return nil, fmt.Errorf("mark upload raw missing: %w", err)
+	}
+
+	return mappers.UploadSessionFromCatalog(record), nil
+}
+
+// GetQuotaUsage 统计用户自 since 起创建的会话数、now 时仍有效的 uploading 会话数及已对账的存储用量。
+func (r *UploadRepository) GetQuotaUsage(ctx context.Context, sess txmanager.Session, userID uuid.UUID, since, now time.Time) (*po.UploadQuotaUsage, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	row, err := queries.GetUploadQuotaUsage(ctx, catalogsql.GetUploadQuotaUsageParams{
+		UserID: userID,
+		Since:  mappers.ToPgTimestamptz(&since),
+		Now:    mappers.ToPgTimestamptz(&now),
+	})
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("get upload quota usage failed: user_id=%s err=%v", userID, err)
+		return nil, fmt.Errorf("get upload quota usage: %w", err)
+	}
+
+	return mappers.UploadQuotaUsageFromRow(row), nil
+}
+
+// LockQuotaUsage 在事务内锁定用户的配额用量行，须与 GetQuotaUsage 及会话写入处于同一事务。
+func (r *UploadRepository) LockQuotaUsage(ctx context.Context, sess txmanager.Session, userID uuid.UUID) error {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	if err := queries.LockUploadUsage(ctx, userID); err != nil {
+		r.log.WithContext(ctx).Errorf("lock upload usage failed: user_id=%s err=%v", userID, err)
+		return fmt.Errorf("lock upload usage: %w", err)
+	}
+	return nil
+}
+
+// ReconcileUsage 依据 catalog.uploads.size_bytes 重新汇总用户的已完成上传用量。
+func (r *UploadRepository) ReconcileUsage(ctx context.Context, sess txmanager.Session, userID uuid.UUID) (*po.UploadUsage, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	row, err := queries.ReconcileUploadUsage(ctx, userID)
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("reconcile upload usage failed: user_id=%s err=%v", userID, err)
+		return nil, fmt.Errorf("reconcile upload usage: %w", err)
+	}
+
+	return mappers.UploadUsageFromCatalog(row), nil
 }
 
 // ListExpiredUploads 返回已过期但仍处于 uploading 状态的会话列表。
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"hash/fnv"
 	"time"
 
 	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
@@ -23,14 +24,22 @@ type VideoEngagementStatsRepository struct {
 	db      *pgxpool.Pool
 	queries *catalogsql.Queries
 	log     *log.Helper
+	shards  int
+}
+
+// StatsShardPolicy 控制累计统计的分片计数模式：Shards > 1 时增量写入按 ShardKey 哈希选中的分片行，
+// 读取时累加主行与全部分片，由后台压实循环折叠回主行；Shards <= 1 时直接更新主行。
+type StatsShardPolicy struct {
+	Shards int
 }
 
 // NewVideoEngagementStatsRepository 构造仓储。
-func NewVideoEngagementStatsRepository(db *pgxpool.Pool, logger log.Logger) *VideoEngagementStatsRepository {
+func NewVideoEngagementStatsRepository(db *pgxpool.Pool, logger log.Logger, policy StatsShardPolicy) *VideoEngagementStatsRepository {
 	return &VideoEngagementStatsRepository{
 		db:      db,
 		queries: catalogsql.New(db),
 		log:     log.NewHelper(logger),
+		shards:  policy.Shards,
 	}
 }
 
@@ -42,15 +51,59 @@ type StatsDelta struct {
 	UniqueWatcherDelta int64
 	FirstWatchAt       *time.Time
 	LastWatchAt        *time.Time
+	// WatchSecondsDelta 为新增的观看秒数。
+	WatchSecondsDelta float64
+	// PositionSecondsDelta 为用户最大播放位置的增长量；PositionViewerDelta 在用户首次上报进度时为 1。
+	PositionSecondsDelta float64
+	PositionViewerDelta  int64
+	// ShareDelta 为新增分享次数；RatingCountDelta/RatingSumDelta 为评分人数与星级之和的变化，
+	// RatingDistributionDelta[i] 为评 i+1 星人数的变化（改分时旧星级 -1、新星级 +1）。
+	ShareDelta              int64
+	RatingCountDelta        int64
+	RatingSumDelta          int64
+	RatingDistributionDelta [5]int64
+	// ShardKey 在分片计数模式下选择写入的分片（通常为触发事件的用户 ID），不参与计数。
+	ShardKey uuid.UUID
 }
 
-// Increment 应用计数增量，返回最新投影。
+// Increment 应用计数增量，返回最新投影。分片计数模式下先获取视频统计的共享锁（与 Repair 互斥），
+// 再将增量写入分片行并返回 nil：汇总主行与全部分片需要额外读取，调用方需要最新值时另行调用 Get。
 func (r *VideoEngagementStatsRepository) Increment(ctx context.Context, sess txmanager.Session, videoID uuid.UUID, delta StatsDelta) (*po.VideoEngagementStatsProjection, error) {
 	queries := r.queries
 	if sess != nil {
 		queries = queries.WithTx(sess.Tx())
 	}
 
+	if r.shards > 1 {
+		if err := queries.LockVideoEngagementStatsShared(ctx, []uuid.UUID{videoID}); err != nil {
+			return nil, fmt.Errorf("lock video engagement stats shared: %w", err)
+		}
+		if err := queries.IncrementVideoEngagementStatsShard(ctx, catalogsql.IncrementVideoEngagementStatsShardParams{
+			VideoID:              videoID,
+			Shard:                r.shardFor(delta.ShardKey),
+			LikeDelta:            delta.LikeDelta,
+			BookmarkDelta:        delta.BookmarkDelta,
+			WatchDelta:           delta.WatchDelta,
+			UniqueWatcherDelta:   delta.UniqueWatcherDelta,
+			WatchSecondsDelta:    delta.WatchSecondsDelta,
+			PositionSecondsDelta: delta.PositionSecondsDelta,
+			PositionViewerDelta:  delta.PositionViewerDelta,
+			FirstWatchAt:         toPgTimestamptz(delta.FirstWatchAt),
+			LastWatchAt:          toPgTimestamptz(delta.LastWatchAt),
+			ShareDelta:           delta.ShareDelta,
+			RatingCountDelta:     delta.RatingCountDelta,
+			RatingSumDelta:       delta.RatingSumDelta,
+			Rating1Delta:         delta.RatingDistributionDelta[0],
+			Rating2Delta:         delta.RatingDistributionDelta[1],
+			Rating3Delta:         delta.RatingDistributionDelta[2],
+			Rating4Delta:         delta.RatingDistributionDelta[3],
+			Rating5Delta:         delta.RatingDistributionDelta[4],
+		}); err != nil {
+			return nil, fmt.Errorf("increment video engagement stats shard: %w", err)
+		}
+		return nil, nil
+	}
+
 	result, err := queries.IncrementVideoEngagementStats(ctx, catalogsql.IncrementVideoEngagementStatsParams{
 		VideoID:            videoID,
 		LikeDelta:          delta.LikeDelta,
@@ -59,6 +112,19 @@ func (r *VideoEngagementStatsRepository) Increment(ctx context.Context, sess txm
 		UniqueWatcherDelta: delta.UniqueWatcherDelta,
 		FirstWatchAt:       toPgTimestamptz(delta.FirstWatchAt),
 		LastWatchAt:        toPgTimestamptz(delta.LastWatchAt),
+
+		WatchSecondsDelta:    delta.WatchSecondsDelta,
+		PositionSecondsDelta: delta.PositionSecondsDelta,
+		PositionViewerDelta:  delta.PositionViewerDelta,
+
+		ShareDelta:       delta.ShareDelta,
+		RatingCountDelta: delta.RatingCountDelta,
+		RatingSumDelta:   delta.RatingSumDelta,
+		Rating1Delta:     delta.RatingDistributionDelta[0],
+		Rating2Delta:     delta.RatingDistributionDelta[1],
+		Rating3Delta:     delta.RatingDistributionDelta[2],
+		Rating4Delta:     delta.RatingDistributionDelta[3],
+		Rating5Delta:     delta.RatingDistributionDelta[4],
 	})
 	if err != nil {
 		return nil, fmt.Errorf("increment video engagement stats: %w", err)
@@ -84,7 +150,287 @@ func (r *VideoEngagementStatsRepository) MarkWatcher(ctx context.Context, sess t
 	return mappers.VideoWatcherRecordFromRow(row), nil
 }
 
-// Get 返回指定视频的当前统计。
+// GetViewSession 读取并锁定用户-视频的当前播放会话，不存在时返回 nil。
+// 会话不存在时会插入占位行以持有行锁，调用方须在同一事务内通过 SaveViewSession 写入完整会话。
+func (r *VideoEngagementStatsRepository) GetViewSession(ctx context.Context, sess txmanager.Session, videoID, userID uuid.UUID) (*po.VideoViewSession, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	row, err := queries.LockVideoViewSession(ctx, catalogsql.LockVideoViewSessionParams{
+		VideoID: videoID,
+		UserID:  userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("lock video view session: %w", err)
+	}
+	if row.Inserted {
+		return nil, nil
+	}
+	return mappers.VideoViewSessionFromLockRow(row), nil
+}
+
+// SaveViewSession 写入播放会话状态。
+func (r *VideoEngagementStatsRepository) SaveViewSession(ctx context.Context, sess txmanager.Session, session *po.VideoViewSession) error {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	if err := queries.UpsertVideoViewSession(ctx, catalogsql.UpsertVideoViewSessionParams{
+		VideoID:              session.VideoID,
+		UserID:               session.UserID,
+		SessionStartedAt:     toPgTimestamptz(&session.SessionStartedAt),
+		LastProgressAt:       toPgTimestamptz(&session.LastProgressAt),
+		BaselineWatchSeconds: session.BaselineWatchSeconds,
+		LastWatchSeconds:     session.LastWatchSeconds,
+		CountedAt:            toPgTimestamptz(session.CountedAt),
+		MaxPositionSeconds:   session.MaxPositionSeconds,
+		LastPositionSeconds:  session.LastPositionSeconds,
+	}); err != nil {
+		return fmt.Errorf("upsert video view session: %w", err)
+	}
+	return nil
+}
+
+// VideoStatsDelta 表示一批事件在单个视频上合并后的增量；用于分时统计桶时 At 为桶内任一时刻。
+type VideoStatsDelta struct {
+	VideoID uuid.UUID
+	At      time.Time
+	Delta   StatsDelta
+}
+
+// StatsBatch 汇总一批事件对统计投影的合并写入。
+// Stats 每个视频至多一条，Rollups 每个 (视频, UTC 小时) 至多一条，Sessions 与 Watchers 每个 (用户, 视频) 至多一条；
+// Watchers 只推进已存在观看者的最近观看时间（首次观看由 MarkWatcher 写入）。
+type StatsBatch struct {
+	Stats    []VideoStatsDelta
+	Rollups  []VideoStatsDelta
+	Sessions []*po.VideoViewSession
+	Watchers []*po.VideoWatcherRecord
+}
+
+// ApplyBatch 以 pgx.Batch 写入一批合并后的统计变更，语义分别同 Increment、IncrementRollups、SaveViewSession 与 MarkWatcher；
+// 合并后的计数增量只在整批累加后按不低于 0 截断一次。调用方需在事务内执行。
+func (r *VideoEngagementStatsRepository) ApplyBatch(ctx context.Context, sess txmanager.Session, batch StatsBatch) error {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	if len(batch.Stats) > 0 {
+		var err error
+		if r.shards > 1 {
+			videoIDs := make([]uuid.UUID, 0, len(batch.Stats))
+			for _, item := range batch.Stats {
+				videoIDs = append(videoIDs, item.VideoID)
+			}
+			if err := queries.LockVideoEngagementStatsShared(ctx, videoIDs); err != nil {
+				return fmt.Errorf("lock video engagement stats shared: %w", err)
+			}
+			params := make([]catalogsql.BatchIncrementVideoEngagementStatsShardsParams, 0, len(batch.Stats))
+			for _, item := range batch.Stats {
+				params = append(params, catalogsql.BatchIncrementVideoEngagementStatsShardsParams{
+					VideoID:              item.VideoID,
+					Shard:                r.shardFor(item.Delta.ShardKey),
+					LikeDelta:            item.Delta.LikeDelta,
+					BookmarkDelta:        item.Delta.BookmarkDelta,
+					WatchDelta:           item.Delta.WatchDelta,
+					UniqueWatcherDelta:   item.Delta.UniqueWatcherDelta,
+					WatchSecondsDelta:    item.Delta.WatchSecondsDelta,
+					PositionSecondsDelta: item.Delta.PositionSecondsDelta,
+					PositionViewerDelta:  item.Delta.PositionViewerDelta,
+					FirstWatchAt:         toPgTimestamptz(item.Delta.FirstWatchAt),
+					LastWatchAt:          toPgTimestamptz(item.Delta.LastWatchAt),
+					ShareDelta:           item.Delta.ShareDelta,
+					RatingCountDelta:     item.Delta.RatingCountDelta,
+					RatingSumDelta:       item.Delta.RatingSumDelta,
+					Rating1Delta:         item.Delta.RatingDistributionDelta[0],
+					Rating2Delta:         item.Delta.RatingDistributionDelta[1],
+					Rating3Delta:         item.Delta.RatingDistributionDelta[2],
+					Rating4Delta:         item.Delta.RatingDistributionDelta[3],
+					Rating5Delta:         item.Delta.RatingDistributionDelta[4],
+				})
+			}
+			err = execBatch(queries.BatchIncrementVideoEngagementStatsShards(ctx, params))
+		} else {
+			params := make([]catalogsql.BatchIncrementVideoEngagementStatsParams, 0, len(batch.Stats))
+			for _, item := range batch.Stats {
+				params = append(params, catalogsql.BatchIncrementVideoEngagementStatsParams{
+					VideoID:            item.VideoID,
+					LikeDelta:          item.Delta.LikeDelta,
+					BookmarkDelta:      item.Delta.BookmarkDelta,
+					WatchDelta:         item.Delta.WatchDelta,
+					UniqueWatcherDelta: item.Delta.UniqueWatcherDelta,
+					FirstWatchAt:       toPgTimestamptz(item.Delta.FirstWatchAt),
+					LastWatchAt:        toPgTimestamptz(item.Delta.LastWatchAt),
+
+					WatchSecondsDelta:    item.Delta.WatchSecondsDelta,
+					PositionSecondsDelta: item.Delta.PositionSecondsDelta,
+					PositionViewerDelta:  item.Delta.PositionViewerDelta,
+
+					ShareDelta:       item.Delta.ShareDelta,
+					RatingCountDelta: item.Delta.RatingCountDelta,
+					RatingSumDelta:   item.Delta.RatingSumDelta,
+					Rating1Delta:     item.Delta.RatingDistributionDelta[0],
+					Rating2Delta:     item.Delta.RatingDistributionDelta[1],
+					Rating3Delta:     item.Delta.RatingDistributionDelta[2],
+					Rating4Delta:     item.Delta.RatingDistributionDelta[3],
+					Rating5Delta:     item.Delta.RatingDistributionDelta[4],
+				})
+			}
+			err = execBatch(queries.BatchIncrementVideoEngagementStats(ctx, params))
+		}
+		if err != nil {
+			return fmt.Errorf("batch increment video engagement stats: %w", err)
+		}
+	}
+
+	if len(batch.Rollups) > 0 {
+		hourly := make([]catalogsql.BatchIncrementVideoEngagementStatsHourlyParams, 0, len(batch.Rollups))
+		type dayKey struct {
+			videoID uuid.UUID
+			day     time.Time
+			shard   int16
+		}
+		daily := make(map[dayKey]int, len(batch.Rollups))
+		var dailyParams []catalogsql.BatchIncrementVideoEngagementStatsDailyParams
+		for _, item := range batch.Rollups {
+			at := item.At.UTC()
+			hour := at.Truncate(time.Hour)
+			day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+			var shard int16
+			if r.shards > 1 {
+				shard = r.shardFor(item.Delta.ShardKey)
+			}
+			hourly = append(hourly, catalogsql.BatchIncrementVideoEngagementStatsHourlyParams{
+				VideoID:            item.VideoID,
+				BucketStart:        toPgTimestamptz(&hour),
+				LikeDelta:          item.Delta.LikeDelta,
+				BookmarkDelta:      item.Delta.BookmarkDelta,
+				WatchDelta:         item.Delta.WatchDelta,
+				UniqueWatcherDelta: item.Delta.UniqueWatcherDelta,
+				Shard:              shard,
+			})
+			// 同一视频同一天的多个小时桶合并为一次天级累加。
+			key := dayKey{videoID: item.VideoID, day: day, shard: shard}
+			idx, ok := daily[key]
+			if !ok {
+				idx = len(dailyParams)
+				daily[key] = idx
+				dailyParams = append(dailyParams, catalogsql.BatchIncrementVideoEngagementStatsDailyParams{
+					VideoID:     item.VideoID,
+					BucketStart: toPgTimestamptz(&day),
+					Shard:       shard,
+				})
+			}
+			dailyParams[idx].LikeDelta += item.Delta.LikeDelta
+			dailyParams[idx].BookmarkDelta += item.Delta.BookmarkDelta
+			dailyParams[idx].WatchDelta += item.Delta.WatchDelta
+			dailyParams[idx].UniqueWatcherDelta += item.Delta.UniqueWatcherDelta
+		}
+		if err := execBatch(queries.BatchIncrementVideoEngagementStatsHourly(ctx, hourly)); err != nil {
+			return fmt.Errorf("batch increment video engagement stats hourly: %w", err)
+		}
+		if err := execBatch(queries.BatchIncrementVideoEngagementStatsDaily(ctx, dailyParams)); err != nil {
+			return fmt.Errorf("batch increment video engagement stats daily: %w", err)
+		}
+	}
+
+	if len(batch.Sessions) > 0 {
+		params := make([]catalogsql.BatchUpsertVideoViewSessionsParams, 0, len(batch.Sessions))
+		for _, session := range batch.Sessions {
+			params = append(params, catalogsql.BatchUpsertVideoViewSessionsParams{
+				VideoID:              session.VideoID,
+				UserID:               session.UserID,
+				SessionStartedAt:     toPgTimestamptz(&session.SessionStartedAt),
+				LastProgressAt:       toPgTimestamptz(&session.LastProgressAt),
+				BaselineWatchSeconds: session.BaselineWatchSeconds,
+				LastWatchSeconds:     session.LastWatchSeconds,
+				CountedAt:            toPgTimestamptz(session.CountedAt),
+				MaxPositionSeconds:   session.MaxPositionSeconds,
+				LastPositionSeconds:  session.LastPositionSeconds,
+			})
+		}
+		if err := execBatch(queries.BatchUpsertVideoViewSessions(ctx, params)); err != nil {
+			return fmt.Errorf("batch upsert video view sessions: %w", err)
+		}
+	}
+
+	if len(batch.Watchers) > 0 {
+		params := make([]catalogsql.BatchTouchVideoWatchersParams, 0, len(batch.Watchers))
+		for _, watcher := range batch.Watchers {
+			params = append(params, catalogsql.BatchTouchVideoWatchersParams{
+				LastWatchedAt: toPgTimestamptz(&watcher.LastWatchedAt),
+				VideoID:       watcher.VideoID,
+				UserID:        watcher.UserID,
+			})
+		}
+		if err := execBatch(queries.BatchTouchVideoWatchers(ctx, params)); err != nil {
+			return fmt.Errorf("batch touch video watchers: %w", err)
+		}
+	}
+	return nil
+}
+
+// ListWatchHistoryInput 定义观看历史分页参数，游标为上一页最后一条的 (last_watched_at, video_id)。
+type ListWatchHistoryInput struct {
+	UserID          uuid.UUID
+	CursorWatchedAt *time.Time
+	CursorVideoID   *uuid.UUID
+	Limit           int32
+}
+
+// GetWatchProgress 返回用户在视频上的最近播放位置，从未上报进度时返回 nil。
+func (r *VideoEngagementStatsRepository) GetWatchProgress(ctx context.Context, sess txmanager.Session, userID, videoID uuid.UUID) (*po.VideoWatchProgress, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	row, err := queries.GetVideoWatchProgress(ctx, catalogsql.GetVideoWatchProgressParams{
+		UserID:  userID,
+		VideoID: videoID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get video watch progress: %w", err)
+	}
+	return mappers.VideoWatchProgressFromRow(row), nil
+}
+
+// ListWatchHistory 按最近观看时间倒序返回用户观看历史。
+func (r *VideoEngagementStatsRepository) ListWatchHistory(ctx context.Context, sess txmanager.Session, input ListWatchHistoryInput) ([]po.WatchHistoryEntry, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	rows, err := queries.ListUserWatchHistory(ctx, catalogsql.ListUserWatchHistoryParams{
+		UserID:          input.UserID,
+		CursorWatchedAt: toPgTimestamptz(input.CursorWatchedAt),
+		CursorVideoID:   toPgUUID(input.CursorVideoID),
+		Limit:           limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list user watch history: %w", err)
+	}
+
+	items := make([]po.WatchHistoryEntry, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, mappers.WatchHistoryEntryFromRow(row))
+	}
+	return items, nil
+}
+
+// Get 返回指定视频的当前统计（主行与未压实分片之和）。
 func (r *VideoEngagementStatsRepository) Get(ctx context.Context, sess txmanager.Session, videoID uuid.UUID) (*po.VideoEngagementStatsProjection, error) {
 	queries := r.queries
 	if sess != nil {
@@ -98,7 +444,401 @@ func (r *VideoEngagementStatsRepository) Get(ctx context.Context, sess txmanager
 		}
 		return nil, fmt.Errorf("get video engagement stats: %w", err)
 	}
-	return mappers.VideoEngagementStatsFromRow(row), nil
+	return mappers.VideoEngagementStatsFromRow(catalogsql.CatalogVideoEngagementStatsProjection(row)), nil
+}
+
+// IncrementRollups 将计数增量累加到 at 所在的 UTC 小时桶与 UTC 天级桶；分片计数模式下写入与 Increment 相同的分片桶。
+// 观看时长类字段不进入分时统计；调用方需与 Increment 在同一事务内执行。
+func (r *VideoEngagementStatsRepository) IncrementRollups(ctx context.Context, sess txmanager.Session, videoID uuid.UUID, at time.Time, delta StatsDelta) error {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	at = at.UTC()
+	hour := at.Truncate(time.Hour)
+	day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+	var shard int16
+	if r.shards > 1 {
+		shard = r.shardFor(delta.ShardKey)
+	}
+	if err := queries.IncrementVideoEngagementStatsHourly(ctx, catalogsql.IncrementVideoEngagementStatsHourlyParams{
+		VideoID:            videoID,
+		BucketStart:        toPgTimestamptz(&hour),
+		LikeDelta:          delta.LikeDelta,
+		BookmarkDelta:      delta.BookmarkDelta,
+		WatchDelta:         delta.WatchDelta,
+		UniqueWatcherDelta: delta.UniqueWatcherDelta,
+		Shard:              shard,
+	}); err != nil {
+		return fmt.Errorf("increment hourly engagement stats: %w", err)
+	}
+	if err := queries.IncrementVideoEngagementStatsDaily(ctx, catalogsql.IncrementVideoEngagementStatsDailyParams{
+		VideoID:            videoID,
+		BucketStart:        toPgTimestamptz(&day),
+		LikeDelta:          delta.LikeDelta,
+		BookmarkDelta:      delta.BookmarkDelta,
+		WatchDelta:         delta.WatchDelta,
+		UniqueWatcherDelta: delta.UniqueWatcherDelta,
+		Shard:              shard,
+	}); err != nil {
+		return fmt.Errorf("increment daily engagement stats: %w", err)
+	}
+	return nil
+}
+
+// ListRollups 返回 [from, to) 内已有数据的统计桶，按 bucket_start 升序；空桶不返回。
+func (r *VideoEngagementStatsRepository) ListRollups(ctx context.Context, sess txmanager.Session, videoID uuid.UUID, granularity po.StatsGranularity, from, to time.Time) ([]po.VideoStatsBucket, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	switch granularity {
+	case po.StatsGranularityHour:
+		rows, err := queries.ListVideoEngagementStatsHourly(ctx, catalogsql.ListVideoEngagementStatsHourlyParams{
+			VideoID:  videoID,
+			FromTime: toPgTimestamptz(&from),
+			ToTime:   toPgTimestamptz(&to),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list hourly engagement stats: %w", err)
+		}
+		buckets := make([]po.VideoStatsBucket, 0, len(rows))
+		for _, row := range rows {
+			buckets = append(buckets, mappers.VideoStatsBucketFromHourlyRow(row))
+		}
+		return buckets, nil
+	case po.StatsGranularityDay:
+		rows, err := queries.ListVideoEngagementStatsDaily(ctx, catalogsql.ListVideoEngagementStatsDailyParams{
+			VideoID:  videoID,
+			FromTime: toPgTimestamptz(&from),
+			ToTime:   toPgTimestamptz(&to),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list daily engagement stats: %w", err)
+		}
+		buckets := make([]po.VideoStatsBucket, 0, len(rows))
+		for _, row := range rows {
+			buckets = append(buckets, mappers.VideoStatsBucketFromDailyRow(row))
+		}
+		return buckets, nil
+	default:
+		return nil, fmt.Errorf("list engagement stats rollups: unsupported granularity %q", granularity)
+	}
+}
+
+// PruneRollups 删除 bucket_start 早于各自截止时间的小时桶与天级桶，每张表至多删除 batchSize 行，返回删除行数。
+func (r *VideoEngagementStatsRepository) PruneRollups(ctx context.Context, sess txmanager.Session, hourlyBefore, dailyBefore time.Time, batchSize int) (hourly, daily int64, err error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	hourly, err = queries.PruneVideoEngagementStatsHourly(ctx, catalogsql.PruneVideoEngagementStatsHourlyParams{
+		Before:    toPgTimestamptz(&hourlyBefore),
+		BatchSize: int32(batchSize),
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("prune hourly engagement stats: %w", err)
+	}
+	daily, err = queries.PruneVideoEngagementStatsDaily(ctx, catalogsql.PruneVideoEngagementStatsDailyParams{
+		Before:    toPgTimestamptz(&dailyBefore),
+		BatchSize: int32(batchSize),
+	})
+	if err != nil {
+		return hourly, 0, fmt.Errorf("prune daily engagement stats: %w", err)
+	}
+	return hourly, daily, nil
+}
+
+// TrendingScoreParams 描述一次热度计算：以 ComputedAt 为参考时间，对 [ComputedAt-Window, ComputedAt] 内的小时桶按半衰期衰减加权求和。
+type TrendingScoreParams struct {
+	ComputedAt time.Time
+	Window     time.Duration
+	HalfLife   time.Duration
+	LikeWeight float64
+	ViewWeight float64
+	// MaxVideos 记录在快照上，读取时作用于过滤后的结果；0 表示不截断。
+	MaxVideos int32
+}
+
+// RefreshTrending 创建新的热度快照并写入得分，返回快照与入榜视频数。
+// 调用方需在事务内执行，使快照与其得分同时对读取方可见。
+func (r *VideoEngagementStatsRepository) RefreshTrending(ctx context.Context, sess txmanager.Session, params TrendingScoreParams) (*po.VideoTrendingSnapshot, int64, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	computedAt := params.ComputedAt.UTC()
+	windowStart := computedAt.Add(-params.Window)
+	halfLife := params.HalfLife.Seconds()
+	snapshotID, err := queries.CreateVideoTrendingSnapshot(ctx, catalogsql.CreateVideoTrendingSnapshotParams{
+		ComputedAt:      toPgTimestamptz(&computedAt),
+		WindowStart:     toPgTimestamptz(&windowStart),
+		HalfLifeSeconds: halfLife,
+		MaxVideos:       params.MaxVideos,
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("create video trending snapshot: %w", err)
+	}
+	count, err := queries.InsertVideoTrendingScores(ctx, catalogsql.InsertVideoTrendingScoresParams{
+		SnapshotID:      snapshotID,
+		LikeWeight:      params.LikeWeight,
+		ViewWeight:      params.ViewWeight,
+		ComputedAt:      toPgTimestamptz(&computedAt),
+		HalfLifeSeconds: halfLife,
+		WindowStart:     toPgTimestamptz(&windowStart),
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("insert video trending scores: %w", err)
+	}
+	return &po.VideoTrendingSnapshot{
+		SnapshotID:      snapshotID,
+		ComputedAt:      computedAt,
+		WindowStart:     windowStart,
+		HalfLifeSeconds: halfLife,
+		MaxVideos:       params.MaxVideos,
+	}, count, nil
+}
+
+// GetTrendingSnapshot 读取指定热度快照；snapshotID 为 0 时读取最新快照。快照不存在时返回 nil。
+func (r *VideoEngagementStatsRepository) GetTrendingSnapshot(ctx context.Context, sess txmanager.Session, snapshotID int64) (*po.VideoTrendingSnapshot, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	var (
+		row catalogsql.CatalogVideoTrendingSnapshot
+		err error
+	)
+	if snapshotID == 0 {
+		row, err = queries.GetLatestVideoTrendingSnapshot(ctx)
+	} else {
+		row, err = queries.GetVideoTrendingSnapshot(ctx, snapshotID)
+	}
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get video trending snapshot: %w", err)
+	}
+	return mappers.VideoTrendingSnapshotFromCatalog(row), nil
+}
+
+// ListTrendingInput 定义热度榜分页参数，游标为上一页最后一条的名次。
+type ListTrendingInput struct {
+	SnapshotID int64
+	AfterRank  int32
+	Difficulty *string
+	Tags       []string
+	Limit      int32
+}
+
+// ListTrending 按名次升序返回快照内当前仍为已发布公开状态、且满足难度/标签过滤的视频。
+func (r *VideoEngagementStatsRepository) ListTrending(ctx context.Context, sess txmanager.Session, input ListTrendingInput) ([]po.TrendingVideoEntry, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	rows, err := queries.ListVideoTrendingScores(ctx, catalogsql.ListVideoTrendingScoresParams{
+		SnapshotID: input.SnapshotID,
+		AfterRank:  input.AfterRank,
+		Difficulty: mappers.ToPgText(input.Difficulty),
+		Tags:       input.Tags,
+		Limit:      limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list video trending scores: %w", err)
+	}
+
+	items := make([]po.TrendingVideoEntry, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, mappers.TrendingVideoEntryFromRow(row))
+	}
+	return items, nil
+}
+
+// PruneTrendingSnapshots 删除计算时间早于 before 的热度快照及其得分，最新快照始终保留；返回删除的快照数。
+func (r *VideoEngagementStatsRepository) PruneTrendingSnapshots(ctx context.Context, sess txmanager.Session, before time.Time) (int64, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	deleted, err := queries.PruneVideoTrendingSnapshots(ctx, toPgTimestamptz(&before))
+	if err != nil {
+		return 0, fmt.Errorf("prune video trending snapshots: %w", err)
+	}
+	return deleted, nil
+}
+
+// StatsDriftFilter 限定统计对账的扫描范围与分页游标。
+type StatsDriftFilter struct {
+	// VideoID 仅对账单个视频。
+	VideoID *uuid.UUID
+	// Since 仅扫描统计、互动或观看明细在该时间之后有更新的视频。
+	Since *time.Time
+	// AfterVideoID 为上一页最后一个 video_id，零值表示从头开始。
+	AfterVideoID uuid.UUID
+	Limit        int
+}
+
+// ListDrift 按 video_id 分页返回候选视频的存量计数与按明细重算的计数。
+func (r *VideoEngagementStatsRepository) ListDrift(ctx context.Context, sess txmanager.Session, filter StatsDriftFilter) ([]*po.VideoEngagementStatsDrift, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	rows, err := queries.ListEngagementStatsDrift(ctx, catalogsql.ListEngagementStatsDriftParams{
+		AfterVideoID: filter.AfterVideoID,
+		VideoID:      mappers.ToPgUUID(filter.VideoID),
+		Since:        toPgTimestamptz(filter.Since),
+		Limit:        int32(filter.Limit),
+	})
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("list engagement stats drift failed: after=%s err=%v", filter.AfterVideoID, err)
+		return nil, fmt.Errorf("list engagement stats drift: %w", err)
+	}
+	drifts := make([]*po.VideoEngagementStatsDrift, 0, len(rows))
+	for _, row := range rows {
+		drifts = append(drifts, mappers.VideoEngagementStatsDriftFromRow(row))
+	}
+	return drifts, nil
+}
+
+// CompactShards 取出至多 limit 个分片行，按视频汇总后累加回主行，返回涉及的视频数与折叠的分片行数。
+// 先按最早写入时间选出待压实的视频并逐个尝试获取共享锁，正被 Repair 持有排他锁的视频留到下一轮；
+// 正被并发事务写入的分片行同样跳过。调用方需在事务内执行，使删除分片与累加主行同时生效。
+func (r *VideoEngagementStatsRepository) CompactShards(ctx context.Context, sess txmanager.Session, limit int) (int, int64, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	candidates, err := queries.ListVideoEngagementStatsShardVideos(ctx, int32(limit))
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("list video engagement stats shard videos failed: err=%v", err)
+		return 0, 0, fmt.Errorf("list video engagement stats shard videos: %w", err)
+	}
+	videoIDs := make([]uuid.UUID, 0, len(candidates))
+	for _, videoID := range candidates {
+		acquired, err := queries.TryLockVideoEngagementStatsShared(ctx, videoID)
+		if err != nil {
+			return 0, 0, fmt.Errorf("try lock video engagement stats shared: %w", err)
+		}
+		if acquired {
+			videoIDs = append(videoIDs, videoID)
+		}
+	}
+	if len(videoIDs) == 0 {
+		return 0, 0, nil
+	}
+	return r.compactShards(ctx, queries, videoIDs, limit)
+}
+
+func (r *VideoEngagementStatsRepository) compactShards(ctx context.Context, queries *catalogsql.Queries, videoIDs []uuid.UUID, limit int) (int, int64, error) {
+	rows, err := queries.TakeVideoEngagementStatsShards(ctx, catalogsql.TakeVideoEngagementStatsShardsParams{
+		VideoIds: videoIDs,
+		Limit:    int32(limit),
+	})
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("take video engagement stats shards failed: err=%v", err)
+		return 0, 0, fmt.Errorf("take video engagement stats shards: %w", err)
+	}
+	var folded int64
+	for _, row := range rows {
+		if _, err := queries.IncrementVideoEngagementStats(ctx, catalogsql.IncrementVideoEngagementStatsParams{
+			VideoID:            row.VideoID,
+			LikeDelta:          row.LikeDelta,
+			BookmarkDelta:      row.BookmarkDelta,
+			WatchDelta:         row.WatchDelta,
+			UniqueWatcherDelta: row.UniqueWatcherDelta,
+			FirstWatchAt:       row.FirstWatchAt,
+			LastWatchAt:        row.LastWatchAt,
+
+			WatchSecondsDelta:    row.WatchSecondsDelta,
+			PositionSecondsDelta: row.PositionSecondsDelta,
+			PositionViewerDelta:  row.PositionViewerDelta,
+
+			ShareDelta:       row.ShareDelta,
+			RatingCountDelta: row.RatingCountDelta,
+			RatingSumDelta:   row.RatingSumDelta,
+			Rating1Delta:     row.Rating1Delta,
+			Rating2Delta:     row.Rating2Delta,
+			Rating3Delta:     row.Rating3Delta,
+			Rating4Delta:     row.Rating4Delta,
+			Rating5Delta:     row.Rating5Delta,
+		}); err != nil {
+			r.log.WithContext(ctx).Errorf("fold video engagement stats shards failed: video=%s err=%v", row.VideoID, err)
+			return 0, 0, fmt.Errorf("fold video engagement stats shards: %w", err)
+		}
+		folded += row.ShardRows
+	}
+	return len(rows), folded, nil
+}
+
+// Repair 按明细表重算并覆盖指定视频的 like_count/bookmark_count/unique_watchers；调用方需在事务内执行。
+// 先获取这些视频统计的排他锁：所有分片写入方（Increment、ApplyBatch、用户清理）与压实循环都持有共享锁，
+// 排他锁等待它们提交并阻止新的分片写入，折叠时不会漏掉已提交但被锁住的分片行，避免重算后再次累加造成重复计数。
+// 随后锁定统计行并把分片增量折叠回主行再重算，避免覆盖并发事务中尚未提交的增量。
+func (r *VideoEngagementStatsRepository) Repair(ctx context.Context, sess txmanager.Session, videoIDs []uuid.UUID) ([]*po.VideoEngagementStatsProjection, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	if err := queries.LockVideoEngagementStatsExclusive(ctx, videoIDs); err != nil {
+		r.log.WithContext(ctx).Errorf("lock video engagement stats exclusive failed: count=%d err=%v", len(videoIDs), err)
+		return nil, fmt.Errorf("lock video engagement stats exclusive: %w", err)
+	}
+
+	if err := queries.LockVideoEngagementStats(ctx, videoIDs); err != nil {
+		r.log.WithContext(ctx).Errorf("lock video engagement stats failed: count=%d err=%v", len(videoIDs), err)
+		return nil, fmt.Errorf("lock video engagement stats: %w", err)
+	}
+	for {
+		_, folded, err := r.compactShards(ctx, queries, videoIDs, repairShardBatch)
+		if err != nil {
+			return nil, err
+		}
+		if folded < repairShardBatch {
+			break
+		}
+	}
+	rows, err := queries.RepairVideoEngagementStats(ctx, videoIDs)
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("repair video engagement stats failed: count=%d err=%v", len(videoIDs), err)
+		return nil, fmt.Errorf("repair video engagement stats: %w", err)
+	}
+	repaired := make([]*po.VideoEngagementStatsProjection, 0, len(rows))
+	for _, row := range rows {
+		repaired = append(repaired, &po.VideoEngagementStatsProjection{
+			VideoID:        row.VideoID,
+			LikeCount:      row.LikeCount,
+			BookmarkCount:  row.BookmarkCount,
+			UniqueWatchers: row.UniqueWatchers,
+		})
+	}
+	return repaired, nil
+}
+
+// repairShardBatch 为 Repair 折叠分片时每次取出的分片行数上限。
+const repairShardBatch = 1000
+
+// shardFor 按 FNV-1a 哈希将分片键映射到 [0, shards)。
+func (r *VideoEngagementStatsRepository) shardFor(key uuid.UUID) int16 {
+	h := fnv.New32a()
+	_, _ = h.Write(key[:])
+	return int16(h.Sum32() % uint32(r.shards))
 }
 
 func toPgTimestamptz(ts *time.Time) pgtype.Timestamptz {
@@ -111,8 +851,21 @@ func toPgTimestamptz(ts *time.Time) pgtype.Timestamptz {
 	}
 }
 
+// execBatch 执行 pgx.Batch 中的全部语句，返回第一个错误。
+func execBatch(results interface{ Exec(func(int, error)) }) error {
+	var first error
+	results.Exec(func(_ int, err error) {
+		if err != nil && first == nil {
+			first = err
+		}
+	})
+	return first
+}
+
 var _ interface {
 	Increment(context.Context, txmanager.Session, uuid.UUID, StatsDelta) (*po.VideoEngagementStatsProjection, error)
 	MarkWatcher(context.Context, txmanager.Session, uuid.UUID, uuid.UUID, time.Time) (*po.VideoWatcherRecord, error)
 	Get(context.Context, txmanager.Session, uuid.UUID) (*po.VideoEngagementStatsProjection, error)
+	GetViewSession(context.Context, txmanager.Session, uuid.UUID, uuid.UUID) (*po.VideoViewSession, error)
+	SaveViewSession(context.Context, txmanager.Session, *po.VideoViewSession) error
 } = (*VideoEngagementStatsRepository)(nil)
@@ -47,6 +47,7 @@ type CreateVideoInput struct {
 	RawFileReference string
 	VisibilityStatus *string
 	PublishAt        *time.Time
+	AssetID          *uuid.UUID
 }
 
 // UpdateVideoInput 表示可选更新字段的集合。
@@ -76,6 +77,7 @@ type UpdateVideoInput struct {
 	RawBitrate        *int32
 	VisibilityStatus  *string
 	PublishAt         *time.Time
+	RawMissing        *bool
 }
 
 // ListPublicVideosInput 描述公开视频分页查询参数。
@@ -111,6 +113,7 @@ func (r *VideoRepository) Create(ctx context.Context, sess txmanager.Session, in
 			input.Description,
 			input.VisibilityStatus,
 			input.PublishAt,
+			input.AssetID,
 		)
 
 		record, err := queries.CreateVideoWithID(ctx, params)
@@ -181,6 +184,7 @@ func (r *VideoRepository) Update(ctx context.Context, sess txmanager.Session, in
 		input.Tags,
 		input.VisibilityStatus,
 		input.PublishAt,
+		input.RawMissing,
 	))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -194,7 +198,7 @@ func (r *VideoRepository) Update(ctx context.Context, sess txmanager.Session, in
 	return mappers.VideoFromCatalog(record), nil
 }
 
-// Delete 删除视频记录并返回被删除的实体快照。
+// Delete 删除视频记录并返回被删除的实体快照；视频引用原始资产时在同一会话内释放该引用。
 func (r *VideoRepository) Delete(ctx context.Context, sess txmanager.Session, videoID uuid.UUID) (*po.Video, error) {
 	queries := r.queries
 	if sess != nil {
@@ -210,8 +214,17 @@ func (r *VideoRepository) Delete(ctx context.Context, sess txmanager.Session, vi
 		return nil, fmt.Errorf("delete video: %w", err)
 	}
 
+	video := mappers.VideoFromCatalog(record)
+	if video.AssetID != nil {
+		// 视频是原始资产的引用方之一，删除时同步释放引用，归零后的资产即可回收。
+		if _, err := queries.ReleaseRawAsset(ctx, *video.AssetID); err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			r.log.WithContext(ctx).Errorf("release raw asset failed: video_id=%s asset_id=%s err=%v", videoID, *video.AssetID, err)
+			return nil, fmt.Errorf("release raw asset: %w", err)
+		}
+	}
+
 	r.log.WithContext(ctx).Infof("video deleted: video_id=%s", record.VideoID)
-	return mappers.VideoFromCatalog(record), nil
+	return video, nil
 }
 
 // GetLifecycleSnapshot 返回生命周期服务需要的完整视频快照（不做状态过滤）。
@@ -338,6 +351,7 @@ func (r *VideoRepository) ListUserUploads(ctx context.Context, sess txmanager.Se
 			UpdatedAt:        row.UpdatedAt.Time,
 			VisibilityStatus: row.VisibilityStatus,
 			PublishAt:        timestamptzPtr(row.PublishAt),
+			RawMissing:       row.RawMissing,
 		})
 	}
 	return items, nil
@@ -361,6 +375,24 @@ func (r *VideoRepository) FindPublishedByID(ctx context.Context, sess txmanager.
 	return mappers.VideoReadyViewFromFindRow(record), nil
 }
 
+// GetPlaybackSource 读取签发播放地址所需的字段，不做可见性过滤。
+func (r *VideoRepository) GetPlaybackSource(ctx context.Context, sess txmanager.Session, videoID uuid.UUID) (*po.VideoPlaybackSource, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	record, err := queries.GetVideoPlaybackSource(ctx, videoID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrVideoNotFound
+		}
+		r.log.WithContext(ctx).Errorf("get video playback source failed: video_id=%s err=%v", videoID, err)
+		return nil, fmt.Errorf("get video playback source: %w", err)
+	}
+	return mappers.VideoPlaybackSourceFromRow(record), nil
+}
+
 func toPgUUID(id *uuid.UUID) pgtype.UUID {
 	if id == nil {
 		return pgtype.UUID{}
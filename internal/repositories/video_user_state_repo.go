@@ -42,6 +42,11 @@ type UpsertVideoUserStateInput struct {
 	HasBookmarked        bool
 	LikedOccurredAt      *time.Time
 	BookmarkedOccurredAt *time.Time
+	LikedSequence        *int64
+	BookmarkedSequence   *int64
+	Rating               *int32
+	RatedOccurredAt      *time.Time
+	RatedSequence        *int64
 }
 
 // Upsert 插入或更新用户互动状态，幂等覆盖最新状态。
@@ -62,6 +67,11 @@ func (r *VideoUserStatesRepository) Upsert(ctx context.Context, sess txmanager.S
 		input.HasBookmarked,
 		input.LikedOccurredAt,
 		input.BookmarkedOccurredAt,
+		input.LikedSequence,
+		input.BookmarkedSequence,
+		input.Rating,
+		input.RatedOccurredAt,
+		input.RatedSequence,
 	)
 
 	if err := queries.UpsertVideoUserState(ctx, params); err != nil {
@@ -71,6 +81,42 @@ func (r *VideoUserStatesRepository) Upsert(ctx context.Context, sess txmanager.S
 	return nil
 }
 
+// UpsertBatch 以 pgx.Batch 一次写入多条用户互动状态，语义同 Upsert；调用方需保证同一 (user, video) 只出现一次。
+func (r *VideoUserStatesRepository) UpsertBatch(ctx context.Context, sess txmanager.Session, inputs []UpsertVideoUserStateInput) error {
+	if len(inputs) == 0 {
+		return nil
+	}
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	params := make([]catalogsql.BatchUpsertVideoUserStatesParams, 0, len(inputs))
+	for _, input := range inputs {
+		if input.UserID == uuid.Nil || input.VideoID == uuid.Nil {
+			return fmt.Errorf("batch upsert video_user_state: nil identifiers")
+		}
+		params = append(params, catalogsql.BatchUpsertVideoUserStatesParams(mappers.BuildUpsertVideoUserStateParams(
+			input.UserID,
+			input.VideoID,
+			input.HasLiked,
+			input.HasBookmarked,
+			input.LikedOccurredAt,
+			input.BookmarkedOccurredAt,
+			input.LikedSequence,
+			input.BookmarkedSequence,
+			input.Rating,
+			input.RatedOccurredAt,
+			input.RatedSequence,
+		)))
+	}
+	if err := execBatch(queries.BatchUpsertVideoUserStates(ctx, params)); err != nil {
+		r.log.WithContext(ctx).Errorf("batch upsert video_user_state failed: rows=%d err=%v", len(params), err)
+		return fmt.Errorf("batch upsert video_user_state: %w", err)
+	}
+	return nil
+}
+
 // Delete 移除一条用户互动状态记录。
 func (r *VideoUserStatesRepository) Delete(ctx context.Context, sess txmanager.Session, userID, videoID uuid.UUID) error {
 	if userID == uuid.Nil || videoID == uuid.Nil {
@@ -114,30 +160,68 @@ func (r *VideoUserStatesRepository) Get(ctx context.Context, sess txmanager.Sess
 		r.log.WithContext(ctx).Errorf("get video_user_state failed: user=%s video=%s err=%v", userID, videoID, err)
 		return nil, fmt.Errorf("get video_user_state: %w", err)
 	}
+	return mappers.VideoUserStateFromCatalog(record), nil
+}
 
-	updatedAt := record.UpdatedAt.Time
-	if !record.UpdatedAt.Valid {
-		updatedAt = time.Time{}
+// ListSavedVideosInput 定义点赞/收藏列表分页参数，游标为上一页最后一条的 (occurred_at, video_id)。
+type ListSavedVideosInput struct {
+	UserID           uuid.UUID
+	CursorOccurredAt *time.Time
+	CursorVideoID    *uuid.UUID
+	Limit            int32
+}
+
+// ListLiked 按点赞时间倒序返回用户点赞且仍可见的视频。
+func (r *VideoUserStatesRepository) ListLiked(ctx context.Context, sess txmanager.Session, input ListSavedVideosInput) ([]po.SavedVideoEntry, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	rows, err := queries.ListUserLikedVideos(ctx, catalogsql.ListUserLikedVideosParams{
+		UserID:           input.UserID,
+		CursorOccurredAt: mappers.ToPgTimestamptz(input.CursorOccurredAt),
+		CursorVideoID:    mappers.ToPgUUID(input.CursorVideoID),
+		Limit:            savedVideosLimit(input.Limit),
+	})
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("list liked videos failed: user=%s err=%v", input.UserID, err)
+		return nil, fmt.Errorf("list liked videos: %w", err)
+	}
+	items := make([]po.SavedVideoEntry, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, mappers.SavedVideoEntryFromLikedRow(row))
+	}
+	return items, nil
+}
+
+// ListBookmarked 按收藏时间倒序返回用户收藏且仍可见的视频。
+func (r *VideoUserStatesRepository) ListBookmarked(ctx context.Context, sess txmanager.Session, input ListSavedVideosInput) ([]po.SavedVideoEntry, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
 	}
 
-	var likedAtPtr *time.Time
-	if record.LikedOccurredAt.Valid {
-		value := record.LikedOccurredAt.Time
-		likedAtPtr = &value
+	rows, err := queries.ListUserBookmarkedVideos(ctx, catalogsql.ListUserBookmarkedVideosParams{
+		UserID:           input.UserID,
+		CursorOccurredAt: mappers.ToPgTimestamptz(input.CursorOccurredAt),
+		CursorVideoID:    mappers.ToPgUUID(input.CursorVideoID),
+		Limit:            savedVideosLimit(input.Limit),
+	})
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("list bookmarked videos failed: user=%s err=%v", input.UserID, err)
+		return nil, fmt.Errorf("list bookmarked videos: %w", err)
 	}
-	var bookmarkedAtPtr *time.Time
-	if record.BookmarkedOccurredAt.Valid {
-		value := record.BookmarkedOccurredAt.Time
-		bookmarkedAtPtr = &value
+	items := make([]po.SavedVideoEntry, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, mappers.SavedVideoEntryFromBookmarkedRow(row))
 	}
+	return items, nil
+}
 
-	return &po.VideoUserState{
-		UserID:               record.UserID,
-		VideoID:              record.VideoID,
-		HasLiked:             record.HasLiked,
-		HasBookmarked:        record.HasBookmarked,
-		LikedOccurredAt:      likedAtPtr,
-		BookmarkedOccurredAt: bookmarkedAtPtr,
-		UpdatedAt:            updatedAt,
-	}, nil
+func savedVideosLimit(limit int32) int32 {
+	if limit <= 0 {
+		return 20
+	}
+	return limit
 }
@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories/mappers"
+	catalogsql "github.com/bionicotaku/lingo-services-catalog/internal/repositories/sqlc"
+
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// WebhookDeliveryRepository 读写 catalog.webhook_deliveries，记录 Outbox 事件到 Webhook 端点的每次投递尝试。
+type WebhookDeliveryRepository struct {
+	queries *catalogsql.Queries
+	log     *log.Helper
+}
+
+// NewWebhookDeliveryRepository 构造 WebhookDeliveryRepository。
+func NewWebhookDeliveryRepository(db *pgxpool.Pool, logger log.Logger) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{
+		queries: catalogsql.New(db),
+		log:     log.NewHelper(logger),
+	}
+}
+
+// WebhookDeliveryAttempt 描述一次投递尝试的结果；StatusCode 为 0 表示请求未得到响应。
+type WebhookDeliveryAttempt struct {
+	EventID    uuid.UUID
+	Endpoint   string
+	EventType  string
+	StatusCode int
+	Err        error
+	Duration   time.Duration
+}
+
+// Record 追加一条投递记录；Err 为空视为投递成功。
+func (r *WebhookDeliveryRepository) Record(ctx context.Context, sess txmanager.Session, attempt WebhookDeliveryAttempt) error {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	params := catalogsql.InsertWebhookDeliveryParams{
+		EventID:    attempt.EventID,
+		Endpoint:   attempt.Endpoint,
+		EventType:  attempt.EventType,
+		DurationMs: int32(attempt.Duration.Milliseconds()),
+		Succeeded:  attempt.Err == nil,
+	}
+	if attempt.StatusCode > 0 {
+		params.StatusCode = pgtype.Int4{Int32: int32(attempt.StatusCode), Valid: true}
+	}
+	if attempt.Err != nil {
+		params.Error = pgtype.Text{String: attempt.Err.Error(), Valid: true}
+	}
+	if err := queries.InsertWebhookDelivery(ctx, params); err != nil {
+		r.log.WithContext(ctx).Errorf("record webhook delivery failed: event=%s endpoint=%s err=%v", attempt.EventID, attempt.Endpoint, err)
+		return fmt.Errorf("record webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// DeliveredEndpoints 返回事件已投递成功的端点名集合。
+func (r *WebhookDeliveryRepository) DeliveredEndpoints(ctx context.Context, sess txmanager.Session, eventID uuid.UUID) (map[string]struct{}, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	names, err := queries.ListDeliveredWebhookEndpoints(ctx, eventID)
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("list delivered webhook endpoints failed: event=%s err=%v", eventID, err)
+		return nil, fmt.Errorf("list delivered webhook endpoints: %w", err)
+	}
+	delivered := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		delivered[name] = struct{}{}
+	}
+	return delivered, nil
+}
+
+// List 按尝试顺序返回事件的全部投递记录。
+func (r *WebhookDeliveryRepository) List(ctx context.Context, sess txmanager.Session, eventID uuid.UUID) ([]*po.WebhookDelivery, error) {
+	queries := r.queries
+	if sess != nil {
+		queries = queries.WithTx(sess.Tx())
+	}
+
+	rows, err := queries.ListWebhookDeliveries(ctx, eventID)
+	if err != nil {
+		r.log.WithContext(ctx).Errorf("list webhook deliveries failed: event=%s err=%v", eventID, err)
+		return nil, fmt.Errorf("list webhook deliveries: %w", err)
+	}
+	deliveries := make([]*po.WebhookDelivery, 0, len(rows))
+	for _, row := range rows {
+		deliveries = append(deliveries, mappers.WebhookDeliveryFromCatalog(row))
+	}
+	return deliveries, nil
+}
@@ -16,5 +16,8 @@ var ProviderSet = wire.NewSet(
 	NewAIAttributesService,
 	NewVisibilityService,
 	NewLifecycleService,
+	NewUploadQuota,
 	NewUploadService,
+	NewPlaybackService,
+	NewOutboxAdminService,
 )
@@ -24,9 +24,10 @@ type LifecycleRepo interface {
 	Update(ctx context.Context, sess txmanager.Session, input repositories.UpdateVideoInput) (*po.Video, error)
 }
 
-// LifecycleOutboxWriter 定义写 Outbox 的接口。
+// LifecycleOutboxWriter 定义写 Outbox 并唤醒发布器的接口。
 type LifecycleOutboxWriter interface {
 	Enqueue(ctx context.Context, sess txmanager.Session, msg repositories.OutboxMessage) error
+	Notify(ctx context.Context, sess txmanager.Session, eventType string) error
 }
 
 // LifecycleWriter 负责在事务内执行写模型操作并写入 Outbox。
@@ -60,6 +61,7 @@ type CreateVideoInput struct {
 	RawFileReference string
 	VisibilityStatus *string
 	PublishAt        *time.Time
+	AssetID          *uuid.UUID
 	IdempotencyKey   string
 }
 
@@ -90,6 +92,7 @@ type UpdateVideoInput struct {
 	MediaEmittedAt    *time.Time
 	AnalysisJobID     *string
 	AnalysisEmittedAt *time.Time
+	RawMissing        *bool
 	ExpectedVersion   *int64
 	IdempotencyKey    string
 }
@@ -134,6 +137,7 @@ func (w *LifecycleWriter) CreateVideo(ctx context.Context, input CreateVideoInpu
 			RawFileReference: input.RawFileReference,
 			VisibilityStatus: input.VisibilityStatus,
 			PublishAt:        input.PublishAt,
+			AssetID:          input.AssetID,
 		})
 		if repoErr != nil {
 			return repoErr
@@ -243,6 +247,7 @@ func (w *LifecycleWriter) UpdateVideo(ctx context.Context, input UpdateVideoInpu
 			RawBitrate:        input.RawBitrate,
 			VisibilityStatus:  input.VisibilityStatus,
 			PublishAt:         input.PublishAt,
+			RawMissing:        input.RawMissing,
 		})
 		if repoErr != nil {
 			return repoErr
@@ -268,6 +273,7 @@ func (w *LifecycleWriter) UpdateVideo(ctx context.Context, input UpdateVideoInpu
 			VisibilityStatus:  input.VisibilityStatus,
 			PublishAt:         input.PublishAt,
 			RawSubtitleURL:    input.RawSubtitleURL,
+			RawMissing:        input.RawMissing,
 		}, eventID, occurredAt)
 		if buildErr != nil {
 			if !stdErrors.Is(buildErr, outboxevents.ErrEmptyUpdatePayload) {
@@ -352,7 +358,8 @@ func hasUpdateFields(input UpdateVideoInput) bool {
 		input.AnalysisJobID != nil ||
 		input.AnalysisEmittedAt != nil ||
 		input.VisibilityStatus != nil ||
-		input.PublishAt != nil
+		input.PublishAt != nil ||
+		input.RawMissing != nil
 }
 
 func (w *LifecycleWriter) enqueueOutbox(ctx context.Context, sess txmanager.Session, event *outboxevents.DomainEvent, availableAt time.Time, meta operationMetadata) error {
@@ -386,5 +393,11 @@ func (w *LifecycleWriter) enqueueOutbox(ctx context.Context, sess txmanager.Sess
 	if err := w.outbox.Enqueue(ctx, sess, msg); err != nil {
 		return fmt.Errorf("enqueue outbox: %w", err)
 	}
+	// 延迟投递的事件到期前无需唤醒发布器，交由 tick 轮询认领。
+	if !availableAt.After(time.Now()) {
+		if err := w.outbox.Notify(ctx, sess, msg.EventType); err != nil {
+			return fmt.Errorf("notify outbox: %w", err)
+		}
+	}
 	return nil
 }
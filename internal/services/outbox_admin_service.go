@@ -0,0 +1,248 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	stdErrors "errors"
+	"fmt"
+	"strings"
+
+	videov1 "github.com/bionicotaku/lingo-services-catalog/api/video/v1"
+	"github.com/bionicotaku/lingo-services-catalog/internal/metadata"
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/vo"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultOutboxAdminLimit = 50
+	maxOutboxAdminLimit     = 500
+)
+
+// OutboxAdminRepo 定义运维处置 Outbox 事件所需的仓储接口。
+type OutboxAdminRepo interface {
+	ListEvents(ctx context.Context, sess txmanager.Session, filter repositories.OutboxEventFilter) ([]*po.OutboxEntry, error)
+	GetEvent(ctx context.Context, sess txmanager.Session, eventID uuid.UUID) (*po.OutboxEntry, error)
+	ResetForRetry(ctx context.Context, sess txmanager.Session, eventID uuid.UUID) error
+	Abandon(ctx context.Context, sess txmanager.Session, eventID uuid.UUID, reason string) error
+	Notify(ctx context.Context, sess txmanager.Session, eventType string) error
+}
+
+// ListOutboxEventsInput 限定运维列出的未发布事件；Status 为空时按 pending 处理，Limit <= 0 时取默认值。
+type ListOutboxEventsInput struct {
+	Status      vo.OutboxEventStatus
+	AggregateID *uuid.UUID
+	EventType   string
+	MinAttempts int32
+	Limit       int
+}
+
+// OutboxAdminService 供运维查看卡住的 Outbox 事件、强制重试或放弃；所有方法要求调用方具备 admin 角色。
+type OutboxAdminService struct {
+	repo      OutboxAdminRepo
+	txManager txmanager.Manager
+	log       *log.Helper
+}
+
+// NewOutboxAdminService 构造 OutboxAdminService。
+func NewOutboxAdminService(repo OutboxAdminRepo, tx txmanager.Manager, logger log.Logger) *OutboxAdminService {
+	return &OutboxAdminService{
+		repo:      repo,
+		txManager: tx,
+		log:       log.NewHelper(logger),
+	}
+}
+
+// ListOutboxEvents 按产生时间升序列出未发布事件；failed 表示至少投递失败过一次的待发布事件。
+func (s *OutboxAdminService) ListOutboxEvents(ctx context.Context, input ListOutboxEventsInput) ([]*vo.OutboxEvent, error) {
+	if _, err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if input.MinAttempts < 0 {
+		return nil, errors.BadRequest(videov1.ErrorReason_ERROR_REASON_OUTBOX_ADMIN_INVALID.String(), "min_attempts must be non-negative")
+	}
+	filter := repositories.OutboxEventFilter{
+		AggregateID: input.AggregateID,
+		MinAttempts: input.MinAttempts,
+		Limit:       input.Limit,
+	}
+	switch input.Status {
+	case "", vo.OutboxEventPending:
+	case vo.OutboxEventFailed:
+		if filter.MinAttempts < 1 {
+			filter.MinAttempts = 1
+		}
+	case vo.OutboxEventAbandoned:
+		filter.Abandoned = true
+	default:
+		return nil, errors.BadRequest(videov1.ErrorReason_ERROR_REASON_OUTBOX_ADMIN_INVALID.String(), fmt.Sprintf("unsupported status: %s", input.Status))
+	}
+	if eventType := strings.TrimSpace(input.EventType); eventType != "" {
+		filter.EventType = &eventType
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultOutboxAdminLimit
+	}
+	if filter.Limit > maxOutboxAdminLimit {
+		filter.Limit = maxOutboxAdminLimit
+	}
+
+	var entries []*po.OutboxEntry
+	err := s.txManager.WithinReadOnlyTx(ctx, txmanager.TxOptions{}, func(txCtx context.Context, sess txmanager.Session) error {
+		var repoErr error
+		entries, repoErr = s.repo.ListEvents(txCtx, sess, filter)
+		return repoErr
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list outbox events: %w", err)
+	}
+	events := make([]*vo.OutboxEvent, 0, len(entries))
+	for _, entry := range entries {
+		events = append(events, vo.NewOutboxEvent(entry))
+	}
+	return events, nil
+}
+
+// GetOutboxEvent 返回单条事件及其头部，并将负载解码为 video.v1.Event；解码失败时记录在 DecodeError 中。
+func (s *OutboxAdminService) GetOutboxEvent(ctx context.Context, eventID uuid.UUID) (*vo.OutboxEventDetail, error) {
+	if _, err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	var entry *po.OutboxEntry
+	err := s.txManager.WithinReadOnlyTx(ctx, txmanager.TxOptions{}, func(txCtx context.Context, sess txmanager.Session) error {
+		var repoErr error
+		entry, repoErr = s.repo.GetEvent(txCtx, sess, eventID)
+		return repoErr
+	})
+	if err != nil {
+		return nil, s.mapOutboxError(eventID, err)
+	}
+
+	detail := &vo.OutboxEventDetail{OutboxEvent: *vo.NewOutboxEvent(entry)}
+	if len(entry.Headers) > 0 && json.Valid(entry.Headers) {
+		detail.Headers = json.RawMessage(entry.Headers)
+	}
+	payload, decodeErr := decodeOutboxPayload(entry.Payload)
+	if decodeErr != nil {
+		detail.DecodeError = decodeErr.Error()
+	} else {
+		detail.Payload = payload
+	}
+	return detail, nil
+}
+
+// RetryOutboxEvent 清零投递次数与错误、释放过期租约并立即重新开放发布，已放弃的事件同时恢复为待发布；
+// 发布器仍持有租约时返回 Conflict。提交后经 catalog_outbox 通知唤醒发布器。
+func (s *OutboxAdminService) RetryOutboxEvent(ctx context.Context, eventID uuid.UUID) (*vo.OutboxEvent, error) {
+	meta, err := requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var entry *po.OutboxEntry
+	err = s.txManager.WithinTx(ctx, txmanager.TxOptions{}, func(txCtx context.Context, sess txmanager.Session) error {
+		if err := s.repo.ResetForRetry(txCtx, sess, eventID); err != nil {
+			if stdErrors.Is(err, repositories.ErrOutboxEventNotPending) {
+				return s.notPendingError(txCtx, sess, eventID)
+			}
+			return err
+		}
+		var repoErr error
+		entry, repoErr = s.repo.GetEvent(txCtx, sess, eventID)
+		if repoErr != nil {
+			return repoErr
+		}
+		return s.repo.Notify(txCtx, sess, entry.EventType)
+	})
+	if err != nil {
+		return nil, s.mapOutboxError(eventID, err)
+	}
+	s.log.WithContext(ctx).Infof("outbox event reset for retry: event=%s type=%s by=%s", eventID, entry.EventType, meta.UserID)
+	return vo.NewOutboxEvent(entry), nil
+}
+
+// AbandonOutboxEvent 放弃待发布事件，发布器此后不再认领；reason 必填，记入审计字段。
+func (s *OutboxAdminService) AbandonOutboxEvent(ctx context.Context, eventID uuid.UUID, reason string) (*vo.OutboxEvent, error) {
+	meta, err := requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, errors.BadRequest(videov1.ErrorReason_ERROR_REASON_OUTBOX_ADMIN_INVALID.String(), "reason is required")
+	}
+	var entry *po.OutboxEntry
+	err = s.txManager.WithinTx(ctx, txmanager.TxOptions{}, func(txCtx context.Context, sess txmanager.Session) error {
+		if err := s.repo.Abandon(txCtx, sess, eventID, reason); err != nil {
+			if stdErrors.Is(err, repositories.ErrOutboxEventNotPending) {
+				return s.notPendingError(txCtx, sess, eventID)
+			}
+			return err
+		}
+		var repoErr error
+		entry, repoErr = s.repo.GetEvent(txCtx, sess, eventID)
+		return repoErr
+	})
+	if err != nil {
+		return nil, s.mapOutboxError(eventID, err)
+	}
+	s.log.WithContext(ctx).Warnf("outbox event abandoned: event=%s type=%s by=%s reason=%q", eventID, entry.EventType, meta.UserID, reason)
+	return vo.NewOutboxEvent(entry), nil
+}
+
+// mapOutboxError 将仓储错误映射为对外错误，已映射的 Kratos 错误原样返回。
+func (s *OutboxAdminService) mapOutboxError(eventID uuid.UUID, err error) error {
+	if stdErrors.Is(err, repositories.ErrOutboxEventNotFound) {
+		return errors.NotFound(videov1.ErrorReason_ERROR_REASON_OUTBOX_EVENT_NOT_FOUND.String(), "outbox event not found")
+	}
+	var kerr *errors.Error
+	if stdErrors.As(err, &kerr) {
+		return kerr
+	}
+	return fmt.Errorf("outbox event %s: %w", eventID, err)
+}
+
+// notPendingError 在条件更新未命中时回查事件，区分不存在、已发布、已放弃与仍被发布器租用。
+func (s *OutboxAdminService) notPendingError(ctx context.Context, sess txmanager.Session, eventID uuid.UUID) error {
+	entry, err := s.repo.GetEvent(ctx, sess, eventID)
+	if err != nil {
+		return err
+	}
+	if entry.PublishedAt == nil && entry.AbandonedAt != nil {
+		return errors.Conflict(videov1.ErrorReason_ERROR_REASON_OUTBOX_ADMIN_INVALID.String(), "outbox event already abandoned")
+	}
+	if entry.PublishedAt == nil && entry.LockedAt != nil {
+		return errors.Conflict(videov1.ErrorReason_ERROR_REASON_OUTBOX_ADMIN_INVALID.String(), "outbox event is leased by a publisher; retry after lock_ttl")
+	}
+	return errors.Conflict(videov1.ErrorReason_ERROR_REASON_OUTBOX_ADMIN_INVALID.String(), "outbox event already published")
+}
+
+// requireAdmin 校验调用方具备 admin 角色：缺少身份返回 Unauthorized，角色不足返回 Forbidden。
+func requireAdmin(ctx context.Context) (metadata.HandlerMetadata, error) {
+	meta, ok := metadata.FromContext(ctx)
+	if !ok || strings.TrimSpace(meta.UserID) == "" {
+		return metadata.HandlerMetadata{}, errors.Unauthorized(videov1.ErrorReason_ERROR_REASON_ADMIN_ROLE_REQUIRED.String(), "user metadata is required")
+	}
+	if !meta.IsAdmin {
+		return metadata.HandlerMetadata{}, errors.Forbidden(videov1.ErrorReason_ERROR_REASON_ADMIN_ROLE_REQUIRED.String(), "admin role is required")
+	}
+	return meta, nil
+}
+
+func decodeOutboxPayload(payload []byte) (json.RawMessage, error) {
+	var event videov1.Event
+	if err := proto.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("decode video.v1.Event: %w", err)
+	}
+	data, err := protojson.Marshal(&event)
+	if err != nil {
+		return nil, fmt.Errorf("encode video.v1.Event json: %w", err)
+	}
+	return data, nil
+}
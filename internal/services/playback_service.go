@@ -0,0 +1,233 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	videov1 "github.com/bionicotaku/lingo-services-catalog/api/video/v1"
+	"github.com/bionicotaku/lingo-services-catalog/internal/metadata"
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/vo"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+)
+
+// PlaybackSigner 抽象播放地址签名能力。
+type PlaybackSigner interface {
+	SignURL(ctx context.Context, rawURL string, expiresAt time.Time) (string, error)
+	SignCookie(ctx context.Context, urlPrefix string, expiresAt time.Time) (name, value string, err error)
+}
+
+// PlaybackRepo 定义签发播放地址所需的读取接口。
+type PlaybackRepo interface {
+	GetPlaybackSource(ctx context.Context, sess txmanager.Session, videoID uuid.UUID) (*po.VideoPlaybackSource, error)
+}
+
+// PlaybackPolicy 描述播放地址的有效期与 CDN 域名改写策略。
+type PlaybackPolicy struct {
+	CDNHost      string
+	PlaylistTTL  time.Duration
+	ThumbnailTTL time.Duration
+	SignedCookie bool
+}
+
+// PlaybackService 按调用方可见性策略签发限时播放地址。
+type PlaybackService struct {
+	repo      PlaybackRepo
+	signer    PlaybackSigner
+	policy    PlaybackPolicy
+	txManager txmanager.Manager
+	log       *log.Helper
+	now       func() time.Time
+}
+
+// NewPlaybackService 构造 PlaybackService；CDNHost 未带协议时默认补全为 https。
+func NewPlaybackService(repo PlaybackRepo, signer PlaybackSigner, policy PlaybackPolicy, tx txmanager.Manager, logger log.Logger) *PlaybackService {
+	host := strings.TrimRight(strings.TrimSpace(policy.CDNHost), "/")
+	if host != "" && !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+	policy.CDNHost = host
+	return &PlaybackService{
+		repo:      repo,
+		signer:    signer,
+		policy:    policy,
+		txManager: tx,
+		log:       log.NewHelper(logger),
+		now:       time.Now,
+	}
+}
+
+// GetPlaybackInfo 校验调用方对视频的可见性并返回签名后的主清单、封面地址及可选的目录签名 Cookie。
+// 不可见的视频统一返回 NotFound，避免泄露私有视频是否存在。
+func (s *PlaybackService) GetPlaybackInfo(ctx context.Context, videoID uuid.UUID) (*vo.PlaybackInfo, error) {
+	if s == nil || s.signer == nil {
+		return nil, errors.ServiceUnavailable(videov1.ErrorReason_ERROR_REASON_PLAYBACK_UNAVAILABLE.String(), "playback signing not configured")
+	}
+
+	var userID *uuid.UUID
+	if meta, ok := metadata.FromContext(ctx); ok {
+		if meta.InvalidUserInfo {
+			return nil, errors.BadRequest(videov1.ErrorReason_ERROR_REASON_VIDEO_UPDATE_INVALID.String(), "invalid user info metadata")
+		}
+		if parsed, ok := meta.UserUUID(); ok {
+			userID = &parsed
+		} else if strings.TrimSpace(meta.UserID) != "" {
+			return nil, errors.BadRequest(videov1.ErrorReason_ERROR_REASON_VIDEO_ID_INVALID.String(), "invalid user id metadata")
+		}
+	}
+
+	var source *po.VideoPlaybackSource
+	err := s.txManager.WithinReadOnlyTx(ctx, txmanager.TxOptions{}, func(txCtx context.Context, sess txmanager.Session) error {
+		var repoErr error
+		source, repoErr = s.repo.GetPlaybackSource(txCtx, sess, videoID)
+		return repoErr
+	})
+	if err != nil {
+		if errors.Is(err, repositories.ErrVideoNotFound) {
+			return nil, ErrVideoNotFound
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			s.log.WithContext(ctx).Warnf("get playback info timeout: video_id=%s", videoID)
+			return nil, errors.GatewayTimeout(videov1.ErrorReason_ERROR_REASON_QUERY_TIMEOUT.String(), "query timeout")
+		}
+		s.log.WithContext(ctx).Errorf("get playback info failed: video_id=%s err=%v", videoID, err)
+		return nil, errors.InternalServer(videov1.ErrorReason_ERROR_REASON_QUERY_VIDEO_FAILED.String(), "failed to query video").WithCause(fmt.Errorf("get playback source: %w", err))
+	}
+
+	now := s.now().UTC()
+	if !canPlay(source, userID, now) {
+		return nil, ErrVideoNotFound
+	}
+	if source.MediaStatus != po.StageReady || source.HLSMasterPlaylist == nil || strings.TrimSpace(*source.HLSMasterPlaylist) == "" {
+		return nil, errors.Conflict(videov1.ErrorReason_ERROR_REASON_PLAYBACK_UNAVAILABLE.String(), "video media not ready")
+	}
+
+	info, err := s.sign(ctx, source, now)
+	if err != nil {
+		s.log.WithContext(ctx).Errorf("sign playback urls failed: video_id=%s err=%v", videoID, err)
+		return nil, errors.InternalServer(videov1.ErrorReason_ERROR_REASON_PLAYBACK_UNAVAILABLE.String(), "failed to sign playback urls").WithCause(err)
+	}
+	return info, nil
+}
+
+func (s *PlaybackService) sign(ctx context.Context, source *po.VideoPlaybackSource, now time.Time) (*vo.PlaybackInfo, error) {
+	playlistURL, err := s.playbackURL(*source.HLSMasterPlaylist)
+	if err != nil {
+		return nil, err
+	}
+	expiresAt := now.Add(s.policy.PlaylistTTL)
+	signedPlaylist, err := s.signer.SignURL(ctx, playlistURL, expiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("sign playlist url: %w", err)
+	}
+
+	info := &vo.PlaybackInfo{
+		VideoID:              source.VideoID,
+		HLSMasterPlaylistURL: signedPlaylist,
+		ExpiresAt:            expiresAt,
+	}
+	if source.DurationMicros != nil {
+		info.DurationMicros = *source.DurationMicros
+	}
+
+	if source.ThumbnailURL != nil && strings.TrimSpace(*source.ThumbnailURL) != "" {
+		thumbnailURL, err := s.playbackURL(*source.ThumbnailURL)
+		if err != nil {
+			return nil, err
+		}
+		info.ThumbnailURL, err = s.signer.SignURL(ctx, thumbnailURL, now.Add(s.policy.ThumbnailTTL))
+		if err != nil {
+			return nil, fmt.Errorf("sign thumbnail url: %w", err)
+		}
+	}
+
+	if s.policy.SignedCookie {
+		// HLS 分片与子清单位于主清单同级目录，Cookie 覆盖该目录前缀即可。
+		parsed, err := url.Parse(playlistURL)
+		if err != nil {
+			return nil, fmt.Errorf("parse playlist url: %w", err)
+		}
+		dir := path.Dir(parsed.Path)
+		if !strings.HasSuffix(dir, "/") {
+			dir += "/"
+		}
+		prefix := parsed.Scheme + "://" + parsed.Host + dir
+		name, value, err := s.signer.SignCookie(ctx, prefix, expiresAt)
+		if err != nil {
+			return nil, fmt.Errorf("sign playback cookie: %w", err)
+		}
+		info.SignedCookie = &vo.SignedCookie{
+			Name:      name,
+			Value:     value,
+			Domain:    parsed.Hostname(),
+			Path:      dir,
+			ExpiresAt: expiresAt,
+		}
+	}
+	return info, nil
+}
+
+// playbackURL 将存储路径改写为播放地址：gs://bucket/object 映射到 CDN 域名下的 /object，
+// 未配置 CDN 时回退到 storage.googleapis.com；http(s) 地址在配置 CDN 时仅替换协议与域名。
+func (s *PlaybackService) playbackURL(stored string) (string, error) {
+	stored = strings.TrimSpace(stored)
+	parsed, err := url.Parse(stored)
+	if err != nil {
+		return "", fmt.Errorf("parse stored url %q: %w", stored, err)
+	}
+	switch parsed.Scheme {
+	case "gs":
+		if s.policy.CDNHost != "" {
+			return s.policy.CDNHost + parsed.EscapedPath(), nil
+		}
+		return "https://storage.googleapis.com/" + parsed.Host + parsed.EscapedPath(), nil
+	case "http", "https":
+		if s.policy.CDNHost == "" {
+			return stored, nil
+		}
+		rewritten := s.policy.CDNHost + parsed.EscapedPath()
+		if parsed.RawQuery != "" {
+			rewritten += "?" + parsed.RawQuery
+		}
+		return rewritten, nil
+	case "":
+		if s.policy.CDNHost == "" {
+			return "", fmt.Errorf("relative media path %q requires cdn host", stored)
+		}
+		return s.policy.CDNHost + "/" + strings.TrimLeft(parsed.EscapedPath(), "/"), nil
+	default:
+		return "", fmt.Errorf("unsupported media url scheme %q", parsed.Scheme)
+	}
+}
+
+// canPlay 判断调用方是否可播放：上传者本人可预览处理中、ready/published 的视频，不受可见性与发布时间限制；
+// 其他用户仅可播放 ready/published、非 private 且已到发布时间的视频（unlisted 凭 video_id 可播放）。
+// failed/rejected/archived 等不可播放状态对任何人都不可见。
+func canPlay(source *po.VideoPlaybackSource, userID *uuid.UUID, now time.Time) bool {
+	switch source.Status {
+	case po.VideoStatusReady, po.VideoStatusPublished:
+	case po.VideoStatusProcessing:
+		return userID != nil && *userID == source.UploadUserID
+	default:
+		return false
+	}
+	if userID != nil && *userID == source.UploadUserID {
+		return true
+	}
+	if source.VisibilityStatus == po.VisibilityPrivate {
+		return false
+	}
+	if source.PublishAt != nil && source.PublishAt.After(now) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,258 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	videov1 "github.com/bionicotaku/lingo-services-catalog/api/video/v1"
+	"github.com/bionicotaku/lingo-services-catalog/internal/metadata"
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/vo"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-services-catalog/internal/services"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+)
+
+type outboxAdminRepoStub struct {
+	entries    map[uuid.UUID]*po.OutboxEntry
+	lastFilter repositories.OutboxEventFilter
+	notified   []string
+}
+
+func (s *outboxAdminRepoStub) ListEvents(_ context.Context, _ txmanager.Session, filter repositories.OutboxEventFilter) ([]*po.OutboxEntry, error) {
+	s.lastFilter = filter
+	var out []*po.OutboxEntry
+	for _, entry := range s.entries {
+		if entry.PublishedAt != nil || (entry.AbandonedAt != nil) != filter.Abandoned || entry.DeliveryAttempts < filter.MinAttempts {
+			continue
+		}
+		out = append(out, entry)
+	}
+	return out, nil
+}
+
+func (s *outboxAdminRepoStub) GetEvent(_ context.Context, _ txmanager.Session, eventID uuid.UUID) (*po.OutboxEntry, error) {
+	entry, ok := s.entries[eventID]
+	if !ok {
+		return nil, repositories.ErrOutboxEventNotFound
+	}
+	clone := *entry
+	return &clone, nil
+}
+
+func (s *outboxAdminRepoStub) ResetForRetry(_ context.Context, _ txmanager.Session, eventID uuid.UUID) error {
+	entry, ok := s.entries[eventID]
+	if !ok || entry.PublishedAt != nil || (entry.LockedAt != nil && time.Since(*entry.LockedAt) < 2*time.Minute) {
+		return repositories.ErrOutboxEventNotPending
+	}
+	entry.LockToken = nil
+	entry.LockedAt = nil
+	now := time.Now()
+	entry.AvailableAt = &now
+	entry.DeliveryAttempts = 0
+	entry.LastError = nil
+	entry.AbandonedAt = nil
+	entry.AbandonedReason = nil
+	return nil
+}
+
+func (s *outboxAdminRepoStub) Notify(_ context.Context, _ txmanager.Session, eventType string) error {
+	s.notified = append(s.notified, eventType)
+	return nil
+}
+
+func (s *outboxAdminRepoStub) Abandon(_ context.Context, _ txmanager.Session, eventID uuid.UUID, reason string) error {
+	entry, ok := s.entries[eventID]
+	if !ok || entry.PublishedAt != nil || entry.AbandonedAt != nil {
+		return repositories.ErrOutboxEventNotPending
+	}
+	now := time.Now()
+	entry.AvailableAt = nil
+	entry.AbandonedAt = &now
+	entry.AbandonedReason = &reason
+	return nil
+}
+
+func newOutboxEntry(t *testing.T, attempts int32) *po.OutboxEntry {
+	t.Helper()
+	videoID := uuid.New()
+	payload, err := proto.Marshal(&videov1.Event{
+		EventId:       uuid.NewString(),
+		EventType:     videov1.EventType_EVENT_TYPE_VIDEO_DELETED,
+		AggregateId:   videoID.String(),
+		AggregateType: "video",
+		Version:       3,
+		Payload:       &videov1.Event_Deleted{Deleted: &videov1.Event_VideoDeleted{VideoId: videoID.String()}},
+	})
+	if err != nil {
+		t.Fatalf("marshal event: %v", err)
+	}
+	now := time.Now()
+	entry := &po.OutboxEntry{
+		EventID:          uuid.New(),
+		AggregateType:    "video",
+		AggregateID:      videoID,
+		EventType:        "catalog.video.deleted",
+		Payload:          payload,
+		Headers:          []byte(`{"schema_version":"v1"}`),
+		OccurredAt:       now.Add(-time.Hour),
+		AvailableAt:      &now,
+		DeliveryAttempts: attempts,
+	}
+	if attempts > 0 {
+		lastErr := "pubsub: deadline exceeded"
+		entry.LastError = &lastErr
+	}
+	return entry
+}
+
+func withAdmin() context.Context {
+	return metadata.Inject(context.Background(), metadata.HandlerMetadata{UserID: uuid.NewString(), IsAdmin: true})
+}
+
+func newOutboxAdminService(entries ...*po.OutboxEntry) (*services.OutboxAdminService, *outboxAdminRepoStub) {
+	repo := &outboxAdminRepoStub{entries: make(map[uuid.UUID]*po.OutboxEntry)}
+	for _, entry := range entries {
+		repo.entries[entry.EventID] = entry
+	}
+	return services.NewOutboxAdminService(repo, noopTxManager{}, log.NewStdLogger(io.Discard)), repo
+}
+
+func TestOutboxAdminRequiresAdminRole(t *testing.T) {
+	svc, _ := newOutboxAdminService()
+
+	_, err := svc.ListOutboxEvents(context.Background(), services.ListOutboxEventsInput{})
+	if !errors.IsUnauthorized(err) {
+		t.Fatalf("expected unauthorized without metadata, got %v", err)
+	}
+
+	ctx := metadata.Inject(context.Background(), metadata.HandlerMetadata{UserID: uuid.NewString()})
+	_, err = svc.RetryOutboxEvent(ctx, uuid.New())
+	if !errors.IsForbidden(err) {
+		t.Fatalf("expected forbidden without admin role, got %v", err)
+	}
+	if reason := errors.Reason(err); reason != videov1.ErrorReason_ERROR_REASON_ADMIN_ROLE_REQUIRED.String() {
+		t.Fatalf("unexpected reason %q", reason)
+	}
+}
+
+func TestOutboxAdminListFailedRaisesMinAttempts(t *testing.T) {
+	fresh := newOutboxEntry(t, 0)
+	failing := newOutboxEntry(t, 4)
+	svc, repo := newOutboxAdminService(fresh, failing)
+
+	events, err := svc.ListOutboxEvents(withAdmin(), services.ListOutboxEventsInput{Status: vo.OutboxEventFailed, Limit: 10_000})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if repo.lastFilter.MinAttempts != 1 || repo.lastFilter.Limit != 500 || repo.lastFilter.Abandoned {
+		t.Fatalf("unexpected filter: %+v", repo.lastFilter)
+	}
+	if len(events) != 1 || events[0].EventID != failing.EventID || events[0].Status != vo.OutboxEventFailed {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+	if events[0].LastError == "" || events[0].DeliveryAttempts != 4 {
+		t.Fatalf("expected delivery diagnostics, got %+v", events[0])
+	}
+
+	if _, err := svc.ListOutboxEvents(withAdmin(), services.ListOutboxEventsInput{Status: "published"}); !errors.IsBadRequest(err) {
+		t.Fatalf("expected bad request for unsupported status, got %v", err)
+	}
+}
+
+func TestOutboxAdminGetDecodesPayload(t *testing.T) {
+	entry := newOutboxEntry(t, 1)
+	svc, _ := newOutboxAdminService(entry)
+
+	detail, err := svc.GetOutboxEvent(withAdmin(), entry.EventID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if detail.DecodeError != "" {
+		t.Fatalf("unexpected decode error: %s", detail.DecodeError)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(detail.Payload, &decoded); err != nil {
+		t.Fatalf("payload is not json: %v", err)
+	}
+	if decoded["eventType"] != "EVENT_TYPE_VIDEO_DELETED" || decoded["deleted"] == nil {
+		t.Fatalf("unexpected decoded payload: %v", decoded)
+	}
+	if string(detail.Headers) != `{"schema_version":"v1"}` {
+		t.Fatalf("unexpected headers: %s", detail.Headers)
+	}
+
+	entry.Payload = []byte{0xff, 0xff}
+	detail, err = svc.GetOutboxEvent(withAdmin(), entry.EventID)
+	if err != nil {
+		t.Fatalf("get corrupt payload: %v", err)
+	}
+	if detail.DecodeError == "" || detail.Payload != nil {
+		t.Fatalf("expected decode error, got %+v", detail)
+	}
+
+	if _, err := svc.GetOutboxEvent(withAdmin(), uuid.New()); !errors.IsNotFound(err) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
+
+func TestOutboxAdminRetryAndAbandon(t *testing.T) {
+	entry := newOutboxEntry(t, 7)
+	svc, repo := newOutboxAdminService(entry)
+	ctx := withAdmin()
+
+	if _, err := svc.AbandonOutboxEvent(ctx, entry.EventID, "  "); !errors.IsBadRequest(err) {
+		t.Fatalf("expected bad request without reason, got %v", err)
+	}
+	abandoned, err := svc.AbandonOutboxEvent(ctx, entry.EventID, "video purged upstream")
+	if err != nil {
+		t.Fatalf("abandon: %v", err)
+	}
+	if abandoned.Status != vo.OutboxEventAbandoned || abandoned.AbandonedReason != "video purged upstream" || abandoned.AvailableAt != nil {
+		t.Fatalf("unexpected abandoned event: %+v", abandoned)
+	}
+	_, err = svc.AbandonOutboxEvent(ctx, entry.EventID, "again")
+	if !errors.IsConflict(err) || errors.FromError(err).Message != "outbox event already abandoned" {
+		t.Fatalf("expected already abandoned conflict, got %v", err)
+	}
+
+	retried, err := svc.RetryOutboxEvent(ctx, entry.EventID)
+	if err != nil {
+		t.Fatalf("retry: %v", err)
+	}
+	if retried.Status != vo.OutboxEventPending || retried.DeliveryAttempts != 0 || retried.LastError != "" || retried.AvailableAt == nil {
+		t.Fatalf("unexpected retried event: %+v", retried)
+	}
+	if len(repo.notified) != 1 || repo.notified[0] != entry.EventType {
+		t.Fatalf("expected retry to notify publishers, got %v", repo.notified)
+	}
+
+	lockedAt := time.Now()
+	entry.LockedAt = &lockedAt
+	_, err = svc.RetryOutboxEvent(ctx, entry.EventID)
+	if !errors.IsConflict(err) || errors.FromError(err).Message != "outbox event is leased by a publisher; retry after lock_ttl" {
+		t.Fatalf("expected leased conflict, got %v", err)
+	}
+	expired := time.Now().Add(-time.Hour)
+	entry.LockedAt = &expired
+	if _, err := svc.RetryOutboxEvent(ctx, entry.EventID); err != nil {
+		t.Fatalf("retry after lease expiry: %v", err)
+	}
+
+	publishedAt := time.Now()
+	entry.PublishedAt = &publishedAt
+	_, err = svc.RetryOutboxEvent(ctx, entry.EventID)
+	if !errors.IsConflict(err) || errors.FromError(err).Message != "outbox event already published" {
+		t.Fatalf("expected already published conflict, got %v", err)
+	}
+	if _, err := svc.RetryOutboxEvent(ctx, uuid.New()); !errors.IsNotFound(err) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
@@ -0,0 +1,187 @@
+package services_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/cdn"
+	"github.com/bionicotaku/lingo-services-catalog/internal/metadata"
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-services-catalog/internal/services"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+)
+
+type playbackRepoStub struct {
+	source *po.VideoPlaybackSource
+}
+
+func (s *playbackRepoStub) GetPlaybackSource(_ context.Context, _ txmanager.Session, videoID uuid.UUID) (*po.VideoPlaybackSource, error) {
+	if s.source == nil || s.source.VideoID != videoID {
+		return nil, repositories.ErrVideoNotFound
+	}
+	return s.source, nil
+}
+
+func newPlaybackSource(owner uuid.UUID, visibility string) *po.VideoPlaybackSource {
+	hls := "gs://media-test/hls/v1/master.m3u8"
+	thumb := "gs://media-test/thumbnails/v1.jpg"
+	duration := int64(90_000_000)
+	return &po.VideoPlaybackSource{
+		VideoID:           uuid.New(),
+		UploadUserID:      owner,
+		Status:            po.VideoStatusPublished,
+		MediaStatus:       po.StageReady,
+		VisibilityStatus:  visibility,
+		DurationMicros:    &duration,
+		ThumbnailURL:      &thumb,
+		HLSMasterPlaylist: &hls,
+	}
+}
+
+func newPlaybackService(t *testing.T, source *po.VideoPlaybackSource, policy services.PlaybackPolicy) (*services.PlaybackService, *cdn.HMACSigner) {
+	t.Helper()
+	signer, err := cdn.NewHMACSigner("test-key", []byte("0123456789abcdef"))
+	if err != nil {
+		t.Fatalf("new signer: %v", err)
+	}
+	svc := services.NewPlaybackService(&playbackRepoStub{source: source}, signer, policy, noopTxManager{}, log.NewStdLogger(io.Discard))
+	return svc, signer
+}
+
+func withUser(userID uuid.UUID) context.Context {
+	return metadata.Inject(context.Background(), metadata.HandlerMetadata{UserID: userID.String()})
+}
+
+func TestPlaybackService_OwnerCanPlayPrivateVideo(t *testing.T) {
+	owner := uuid.New()
+	source := newPlaybackSource(owner, po.VisibilityPrivate)
+	source.Status = po.VideoStatusProcessing
+	svc, signer := newPlaybackService(t, source, services.PlaybackPolicy{
+		CDNHost:      "media.example.com",
+		PlaylistTTL:  time.Hour,
+		ThumbnailTTL: 24 * time.Hour,
+	})
+
+	info, err := svc.GetPlaybackInfo(withUser(owner), source.VideoID)
+	if err != nil {
+		t.Fatalf("GetPlaybackInfo: %v", err)
+	}
+	if !strings.HasPrefix(info.HLSMasterPlaylistURL, "https://media.example.com/hls/v1/master.m3u8?Expires=") {
+		t.Fatalf("unexpected playlist url: %s", info.HLSMasterPlaylistURL)
+	}
+	if err := signer.VerifyURL(info.HLSMasterPlaylistURL, time.Now()); err != nil {
+		t.Fatalf("playlist signature invalid: %v", err)
+	}
+	if err := signer.VerifyURL(info.ThumbnailURL, time.Now().Add(2*time.Hour)); err != nil {
+		t.Fatalf("thumbnail should outlive playlist ttl: %v", err)
+	}
+	if info.DurationMicros != 90_000_000 {
+		t.Fatalf("unexpected duration: %d", info.DurationMicros)
+	}
+	if info.SignedCookie != nil {
+		t.Fatalf("signed cookie should be disabled")
+	}
+}
+
+func TestPlaybackService_PrivateHiddenFromOthers(t *testing.T) {
+	source := newPlaybackSource(uuid.New(), po.VisibilityPrivate)
+	svc, _ := newPlaybackService(t, source, services.PlaybackPolicy{PlaylistTTL: time.Hour})
+
+	for name, ctx := range map[string]context.Context{
+		"anonymous": context.Background(),
+		"other":     withUser(uuid.New()),
+	} {
+		_, err := svc.GetPlaybackInfo(ctx, source.VideoID)
+		if !errors.Is(err, services.ErrVideoNotFound) {
+			t.Fatalf("%s: expected not found, got %v", name, err)
+		}
+	}
+}
+
+func TestPlaybackService_UnplayableStatusHiddenFromOwner(t *testing.T) {
+	owner := uuid.New()
+	for _, status := range []po.VideoStatus{po.VideoStatusFailed, po.VideoStatusRejected, po.VideoStatusArchived, po.VideoStatusPendingUpload} {
+		source := newPlaybackSource(owner, po.VisibilityPublic)
+		source.Status = status
+		svc, _ := newPlaybackService(t, source, services.PlaybackPolicy{PlaylistTTL: time.Hour})
+
+		if _, err := svc.GetPlaybackInfo(withUser(owner), source.VideoID); !errors.Is(err, services.ErrVideoNotFound) {
+			t.Fatalf("%s: expected not found for owner, got %v", status, err)
+		}
+	}
+}
+
+func TestPlaybackService_UnlistedAndScheduledVisibility(t *testing.T) {
+	source := newPlaybackSource(uuid.New(), po.VisibilityUnlisted)
+	svc, _ := newPlaybackService(t, source, services.PlaybackPolicy{PlaylistTTL: time.Hour})
+
+	info, err := svc.GetPlaybackInfo(context.Background(), source.VideoID)
+	if err != nil {
+		t.Fatalf("unlisted video should be playable: %v", err)
+	}
+	if !strings.HasPrefix(info.HLSMasterPlaylistURL, "https://storage.googleapis.com/media-test/hls/v1/master.m3u8?") {
+		t.Fatalf("expected gcs fallback url, got %s", info.HLSMasterPlaylistURL)
+	}
+
+	future := time.Now().Add(time.Hour)
+	source.PublishAt = &future
+	if _, err := svc.GetPlaybackInfo(context.Background(), source.VideoID); !errors.Is(err, services.ErrVideoNotFound) {
+		t.Fatalf("scheduled video should be hidden, got %v", err)
+	}
+}
+
+func TestPlaybackService_SignedCookieCoversHLSFolder(t *testing.T) {
+	source := newPlaybackSource(uuid.New(), po.VisibilityPublic)
+	svc, signer := newPlaybackService(t, source, services.PlaybackPolicy{
+		CDNHost:      "https://media.example.com/",
+		PlaylistTTL:  time.Hour,
+		SignedCookie: true,
+	})
+
+	info, err := svc.GetPlaybackInfo(context.Background(), source.VideoID)
+	if err != nil {
+		t.Fatalf("GetPlaybackInfo: %v", err)
+	}
+	cookie := info.SignedCookie
+	if cookie == nil {
+		t.Fatalf("expected signed cookie")
+	}
+	if cookie.Name != cdn.CookieName || cookie.Domain != "media.example.com" || cookie.Path != "/hls/v1/" {
+		t.Fatalf("unexpected cookie: %+v", cookie)
+	}
+	if err := signer.VerifyCookie(cookie.Value, "https://media.example.com/hls/v1/720p/seg-001.ts", time.Now()); err != nil {
+		t.Fatalf("cookie should cover segments: %v", err)
+	}
+	if err := signer.VerifyCookie(cookie.Value, "https://media.example.com/hls/v2/master.m3u8", time.Now()); err == nil {
+		t.Fatalf("cookie must not cover other videos")
+	}
+}
+
+func TestPlaybackService_MediaNotReady(t *testing.T) {
+	source := newPlaybackSource(uuid.New(), po.VisibilityPublic)
+	source.MediaStatus = po.StageProcessing
+	svc, _ := newPlaybackService(t, source, services.PlaybackPolicy{PlaylistTTL: time.Hour})
+
+	_, err := svc.GetPlaybackInfo(context.Background(), source.VideoID)
+	if err == nil {
+		t.Fatalf("expected error when media not ready")
+	}
+	if e := errors.FromError(err); e.Code != 409 || e.Reason != "ERROR_REASON_PLAYBACK_UNAVAILABLE" {
+		t.Fatalf("unexpected error: %d %s", e.Code, e.Reason)
+	}
+}
+
+func TestPlaybackService_NilServiceUnavailable(t *testing.T) {
+	var svc *services.PlaybackService
+	_, err := svc.GetPlaybackInfo(context.Background(), uuid.New())
+	if e := errors.FromError(err); e.Code != 503 {
+		t.Fatalf("expected http 503, got %d", e.Code)
+	}
+}
@@ -0,0 +1,205 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	videov1 "github.com/bionicotaku/lingo-services-catalog/api/video/v1"
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/services"
+
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+)
+
+type stubQuotaRepo struct {
+	usage  po.UploadQuotaUsage
+	calls  int
+	locked bool
+	// lockedUsage 非空时模拟加锁后读到并发请求已写入的会话。
+	lockedUsage *po.UploadQuotaUsage
+}
+
+func (s *stubQuotaRepo) LockQuotaUsage(context.Context, txmanager.Session, uuid.UUID) error {
+	s.locked = true
+	return nil
+}
+
+func (s *stubQuotaRepo) GetQuotaUsage(_ context.Context, sess txmanager.Session, _ uuid.UUID, _, _ time.Time) (*po.UploadQuotaUsage, error) {
+	s.calls++
+	usage := s.usage
+	if sess != nil && s.locked && s.lockedUsage != nil {
+		usage = *s.lockedUsage
+	}
+	return &usage, nil
+}
+
+var testQuotaPolicy = services.UploadQuotaPolicy{
+	DefaultTier: "free",
+	Tiers: map[string]services.UploadQuotaTier{
+		"free": {DailySessions: 3, ConcurrentUploads: 1, StorageBytes: 10_000},
+		"pro":  {DailySessions: 100, ConcurrentUploads: 5},
+	},
+}
+
+func TestUploadService_QuotaExceeded(t *testing.T) {
+	expiresAt := time.Now().Add(90 * time.Second)
+	cases := []struct {
+		name       string
+		tier       string
+		usage      po.UploadQuotaUsage
+		size       int64
+		quota      string
+		retryAfter bool
+	}{
+		{
+			name:       "daily sessions",
+			usage:      po.UploadQuotaUsage{DailySessions: 3},
+			quota:      "daily_sessions",
+			retryAfter: true,
+		},
+		{
+			name:       "concurrent uploads",
+			tier:       "unknown",
+			usage:      po.UploadQuotaUsage{DailySessions: 1, ConcurrentUploads: 1, EarliestUploadExpiresAt: &expiresAt},
+			quota:      "concurrent_uploads",
+			retryAfter: true,
+		},
+		{
+			name:  "storage bytes",
+			usage: po.UploadQuotaUsage{StoredBytes: 9_000},
+			size:  2_000,
+			quota: "storage_bytes",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &stubUploadRepo{}
+			signer := &stubSigner{url: "https://signed.example", expires: time.Now().Add(10 * time.Minute)}
+			quotaRepo := &stubQuotaRepo{usage: tc.usage}
+			svc := newQuotaUploadService(t, repo, signer, quotaRepo)
+
+			_, err := svc.InitResumableUpload(context.Background(), quotaUploadInput(tc.tier, tc.size))
+			if !errors.Is(err, services.ErrUploadQuotaExceeded) {
+				t.Fatalf("expected quota exceeded, got %v", err)
+			}
+			ke := kerrors.FromError(err)
+			if ke.Code != 429 || ke.Reason != videov1.ErrorReason_ERROR_REASON_UPLOAD_QUOTA_EXCEEDED.String() {
+				t.Fatalf("unexpected error: code=%d reason=%s", ke.Code, ke.Reason)
+			}
+			if ke.Metadata["quota"] != tc.quota || ke.Metadata["tier"] != "free" {
+				t.Fatalf("unexpected metadata: %v", ke.Metadata)
+			}
+			retry, ok := ke.Metadata["retry_after_seconds"]
+			if ok != tc.retryAfter {
+				t.Fatalf("unexpected retry_after_seconds presence: %v", ke.Metadata)
+			}
+			if ok {
+				seconds, convErr := strconv.Atoi(retry)
+				if convErr != nil || seconds <= 0 || seconds > 86400 {
+					t.Fatalf("invalid retry_after_seconds %q", retry)
+				}
+			}
+			if signer.calls != 0 || repo.upsert.VideoID != uuid.Nil {
+				t.Fatalf("expected no session to be created")
+			}
+		})
+	}
+}
+
+func TestUploadService_QuotaRecheckedUnderLock(t *testing.T) {
+	repo := &stubUploadRepo{}
+	signer := &stubSigner{url: "https://signed.example", expires: time.Now().Add(10 * time.Minute)}
+	quotaRepo := &stubQuotaRepo{
+		usage:       po.UploadQuotaUsage{DailySessions: 2},
+		lockedUsage: &po.UploadQuotaUsage{DailySessions: 3},
+	}
+	svc := newQuotaUploadService(t, repo, signer, quotaRepo)
+
+	_, err := svc.InitResumableUpload(context.Background(), quotaUploadInput("", 0))
+	if !errors.Is(err, services.ErrUploadQuotaExceeded) {
+		t.Fatalf("expected quota exceeded after locked recheck, got %v", err)
+	}
+	if !quotaRepo.locked || quotaRepo.calls != 2 {
+		t.Fatalf("expected pre-check plus locked recheck, locked=%v calls=%d", quotaRepo.locked, quotaRepo.calls)
+	}
+	if repo.upsert.VideoID != uuid.Nil {
+		t.Fatalf("expected no session to be created")
+	}
+}
+
+func TestUploadService_QuotaSkippedForExistingSession(t *testing.T) {
+	userID := uuid.New()
+	existing := &po.UploadSession{
+		VideoID:    uuid.New(),
+		UserID:     userID,
+		Bucket:     "bucket",
+		ObjectName: "raw_videos/user/video",
+		Status:     po.UploadStatusFailed,
+	}
+	repo := &stubUploadRepo{existing: existing}
+	signer := &stubSigner{url: "https://signed.example", expires: time.Now().Add(10 * time.Minute)}
+	quotaRepo := &stubQuotaRepo{usage: po.UploadQuotaUsage{DailySessions: 99}}
+	svc := newQuotaUploadService(t, repo, signer, quotaRepo)
+
+	input := quotaUploadInput("", 0)
+	input.UserID = userID
+	if _, err := svc.InitResumableUpload(context.Background(), input); err != nil {
+		t.Fatalf("InitResumableUpload: %v", err)
+	}
+	if quotaRepo.calls != 0 {
+		t.Fatalf("expected quota check to be skipped for existing session")
+	}
+}
+
+func TestUploadService_GetUploadQuota(t *testing.T) {
+	quotaRepo := &stubQuotaRepo{usage: po.UploadQuotaUsage{DailySessions: 4, ConcurrentUploads: 2, StoredBytes: 1 << 20}}
+	svc := newQuotaUploadService(t, &stubUploadRepo{}, &stubSigner{}, quotaRepo)
+
+	quota, err := svc.GetUploadQuota(context.Background(), uuid.New(), "PRO")
+	if err != nil {
+		t.Fatalf("GetUploadQuota: %v", err)
+	}
+	if quota.Tier != "pro" {
+		t.Fatalf("expected pro tier, got %s", quota.Tier)
+	}
+	if quota.DailySessions.Remaining != 96 || quota.ConcurrentUploads.Remaining != 3 {
+		t.Fatalf("unexpected remaining: %+v %+v", quota.DailySessions, quota.ConcurrentUploads)
+	}
+	if !quota.StorageBytes.Unlimited || quota.StorageBytes.Used != 1<<20 {
+		t.Fatalf("expected unlimited storage with usage, got %+v", quota.StorageBytes)
+	}
+	if !quota.DailyResetAt.After(time.Now()) || quota.DailyResetAt.Hour() != 0 {
+		t.Fatalf("unexpected reset time %s", quota.DailyResetAt)
+	}
+}
+
+func newQuotaUploadService(t *testing.T, repo *stubUploadRepo, signer services.UploadSigner, quotaRepo *stubQuotaRepo) *services.UploadService {
+	t.Helper()
+	quota := services.NewUploadQuota(quotaRepo, noopTxManager{}, testQuotaPolicy, log.NewStdLogger(ioDiscard{}))
+	svc, err := services.NewUploadService(repo, signer, quota, "bucket", 5*time.Minute, log.NewStdLogger(ioDiscard{}))
+	if err != nil {
+		t.Fatalf("NewUploadService: %v", err)
+	}
+	return svc
+}
+
+func quotaUploadInput(tier string, size int64) services.InitResumableUploadInput {
+	return services.InitResumableUploadInput{
+		UserID:          uuid.New(),
+		Tier:            tier,
+		ContentType:     "video/mp4",
+		ContentMD5Hex:   strings.Repeat("c", 32),
+		Title:           "Title",
+		Description:     "Description",
+		DurationSeconds: 30,
+		SizeBytes:       size,
+	}
+}
@@ -9,8 +9,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
 	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
 	"github.com/bionicotaku/lingo-services-catalog/internal/services"
+	"github.com/bionicotaku/lingo-utils/txmanager"
 	kerrors "github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/google/uuid"
@@ -37,7 +39,7 @@ func TestUploadServiceIntegration_InitAndRefresh(t *testing.T) {
 		url:     "https://signed.example/initial",
 		expires: time.Now().Add(20 * time.Minute).UTC(),
 	}
-	svc, err := services.NewUploadService(repo, signer, "catalog-media", 15*time.Minute, log.NewStdLogger(io.Discard))
+	svc, err := services.NewUploadService(repo, signer, nil, "catalog-media", 15*time.Minute, log.NewStdLogger(io.Discard))
 	require.NoError(t, err)
 
 	userID := uuid.New()
@@ -116,7 +118,7 @@ func TestUploadServiceIntegration_ConcurrentInitSingleSession(t *testing.T) {
 	repo := repositories.NewUploadRepository(pool, log.NewStdLogger(io.Discard))
 
 	signer := &sequentialSigner{ttl: 15 * time.Minute}
-	svc, err := services.NewUploadService(repo, signer, "catalog-media", 10*time.Minute, log.NewStdLogger(io.Discard))
+	svc, err := services.NewUploadService(repo, signer, nil, "catalog-media", 10*time.Minute, log.NewStdLogger(io.Discard))
 	require.NoError(t, err)
 
 	userID := uuid.New()
@@ -172,6 +174,70 @@ func TestUploadServiceIntegration_ConcurrentInitSingleSession(t *testing.T) {
 	require.NotNil(t, finalSession.SignedURL)
 }
 
+func TestUploadServiceIntegration_ConcurrentInitRespectsQuota(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dsn, terminate := startPostgres(ctx, t)
+	defer terminate()
+
+	pool, err := pgxpool.New(ctx, dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { pool.Close() })
+
+	applyAllMigrations(ctx, t, pool)
+
+	logger := log.NewStdLogger(io.Discard)
+	txMgrComponent, cleanupTx, err := txmanager.NewComponent(configloader.ProvideTxConfig(configloader.RuntimeConfig{}), pool, logger)
+	require.NoError(t, err)
+	t.Cleanup(cleanupTx)
+
+	repo := repositories.NewUploadRepository(pool, logger)
+	quota := services.NewUploadQuota(repo, txmanager.ProvideManager(txMgrComponent), services.UploadQuotaPolicy{
+		DefaultTier: "free",
+		Tiers:       map[string]services.UploadQuotaTier{"free": {ConcurrentUploads: 1}},
+	}, logger)
+	svc, err := services.NewUploadService(repo, &sequentialSigner{ttl: 15 * time.Minute}, quota, "catalog-media", 10*time.Minute, logger)
+	require.NoError(t, err)
+
+	userID := uuid.New()
+	const workers = 5
+	errs := make([]error, workers)
+
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func(idx int) {
+			defer wg.Done()
+			<-start
+			_, errs[idx] = svc.InitResumableUpload(ctx, services.InitResumableUploadInput{
+				UserID:        userID,
+				SizeBytes:     1024,
+				ContentType:   "video/mp4",
+				ContentMD5Hex: fmt.Sprintf("%032x", idx+1),
+				Title:         "Quota",
+			})
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	var succeeded int
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		require.ErrorIs(t, err, services.ErrUploadQuotaExceeded)
+	}
+	require.Equal(t, 1, succeeded)
+
+	var total int
+	require.NoError(t, pool.QueryRow(ctx, `select count(*) from catalog.uploads where user_id = $1`, userID).Scan(&total))
+	require.Equal(t, 1, total)
+}
+
 func TestUploadServiceIntegration_DifferentUsersSameMD5(t *testing.T) {
 	t.Parallel()
 
@@ -187,7 +253,7 @@ func TestUploadServiceIntegration_DifferentUsersSameMD5(t *testing.T) {
 
 	repo := repositories.NewUploadRepository(pool, log.NewStdLogger(io.Discard))
 	signer := &spySigner{url: "https://signed.example", expires: time.Now().Add(10 * time.Minute).UTC()}
-	svc, err := services.NewUploadService(repo, signer, "catalog-media", 10*time.Minute, log.NewStdLogger(io.Discard))
+	svc, err := services.NewUploadService(repo, signer, nil, "catalog-media", 10*time.Minute, log.NewStdLogger(io.Discard))
 	require.NoError(t, err)
 
 	md5Hex := strings.Repeat("c", 32)
@@ -233,7 +299,7 @@ func TestUploadServiceIntegration_CompletedSessionConflicts(t *testing.T) {
 
 	repo := repositories.NewUploadRepository(pool, log.NewStdLogger(io.Discard))
 	signer := &spySigner{url: "https://signed.example", expires: time.Now().Add(10 * time.Minute).UTC()}
-	svc, err := services.NewUploadService(repo, signer, "catalog-media", 10*time.Minute, log.NewStdLogger(io.Discard))
+	svc, err := services.NewUploadService(repo, signer, nil, "catalog-media", 10*time.Minute, log.NewStdLogger(io.Discard))
 	require.NoError(t, err)
 
 	userID := uuid.New()
@@ -252,7 +252,7 @@ func TestUploadService_SignerFailureStopsUpsert(t *testing.T) {
 
 func newUploadService(t *testing.T, repo *stubUploadRepo, signer services.UploadSigner) *services.UploadService {
 	t.Helper()
-	svc, err := services.NewUploadService(repo, signer, "bucket", 5*time.Minute, log.NewStdLogger(ioDiscard{}))
+	svc, err := services.NewUploadService(repo, signer, nil, "bucket", 5*time.Minute, log.NewStdLogger(ioDiscard{}))
 	if err != nil {
 		t.Fatalf("NewUploadService: %v", err)
 	}
@@ -12,6 +12,8 @@ import (
 
 	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
 	"github.com/bionicotaku/lingo-services-catalog/internal/metadata"
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/vo"
 	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
 	"github.com/bionicotaku/lingo-services-catalog/internal/services"
 	"github.com/bionicotaku/lingo-utils/txmanager"
@@ -41,7 +43,7 @@ func TestVideoQueryService_GetVideoDetail_WithStats(t *testing.T) {
 	logger := log.NewStdLogger(io.Discard)
 	videoRepo := repositories.NewVideoRepository(pool, logger)
 	userStateRepo := repositories.NewVideoUserStatesRepository(pool, logger)
-	statsRepo := repositories.NewVideoEngagementStatsRepository(pool, logger)
+	statsRepo := repositories.NewVideoEngagementStatsRepository(pool, logger, repositories.StatsShardPolicy{})
 
 	txCfg := configloader.ProvideTxConfig(configloader.RuntimeConfig{
 		Database: configloader.DatabaseConfig{
@@ -62,10 +64,10 @@ func TestVideoQueryService_GetVideoDetail_WithStats(t *testing.T) {
 	_, err = pool.Exec(ctx, `
         INSERT INTO catalog.videos (
             video_id, upload_user_id, title, raw_file_reference,
-            status, media_status, analysis_status,
+            status, media_status, analysis_status, duration_micros,
             created_at, updated_at, version
         ) VALUES ($1, $2, $3, 'gs://bucket/test.mp4',
-                  'published', 'ready', 'ready',
+                  'published', 'ready', 'ready', 100000000,
                   $4, $4, 1)
     `, videoID, uploadUserID, "Stats Integration Video", now)
 	require.NoError(t, err)
@@ -81,8 +83,9 @@ func TestVideoQueryService_GetVideoDetail_WithStats(t *testing.T) {
 	_, err = pool.Exec(ctx, `
         INSERT INTO catalog.video_engagement_stats_projection (
             video_id, like_count, bookmark_count, watch_count, unique_watchers,
-            first_watch_at, last_watch_at, updated_at
-        ) VALUES ($1, 5, 2, 7, 3, $2, $2, $2)
+            first_watch_at, last_watch_at, updated_at,
+            total_watch_seconds, position_sum_seconds, position_viewers
+        ) VALUES ($1, 5, 2, 7, 3, $2, $2, $2, 420, 150, 3)
         ON CONFLICT (video_id)
         DO UPDATE SET like_count = EXCLUDED.like_count
     `, videoID, now)
@@ -98,6 +101,8 @@ func TestVideoQueryService_GetVideoDetail_WithStats(t *testing.T) {
 	require.EqualValues(t, 2, detail.BookmarkCount)
 	require.EqualValues(t, 7, detail.WatchCount)
 	require.EqualValues(t, 3, detail.UniqueWatchers)
+	require.InDelta(t, 420, detail.TotalWatchSeconds, 1e-9)
+	require.InDelta(t, 0.5, detail.CompletionRate, 1e-9)
 	require.NotNil(t, detailMeta)
 	require.EqualValues(t, 5, detailMeta.LikeCount)
 	require.EqualValues(t, 2, detailMeta.BookmarkCount)
@@ -108,6 +113,370 @@ func TestVideoQueryService_GetVideoDetail_WithStats(t *testing.T) {
 	require.EqualValues(t, 5, metaOnly.LikeCount)
 	require.EqualValues(t, 2, metaOnly.BookmarkCount)
 	require.EqualValues(t, 7, metaOnly.WatchCount)
+	require.InDelta(t, 0.5, metaOnly.CompletionRate, 1e-9)
+}
+
+func TestVideoQueryService_WatchHistoryAndResume(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dsn, terminate := startPostgres(ctx, t)
+	defer terminate()
+
+	pool, err := pgxpool.New(ctx, dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { pool.Close() })
+
+	applyAllMigrations(ctx, t, pool)
+	ensureAuthSchema(ctx, t, pool)
+
+	logger := log.NewStdLogger(io.Discard)
+	txMgrComponent, cleanupTx, err := txmanager.NewComponent(configloader.ProvideTxConfig(configloader.RuntimeConfig{}), pool, logger)
+	require.NoError(t, err)
+	t.Cleanup(cleanupTx)
+	service := services.NewVideoQueryService(
+		repositories.NewVideoRepository(pool, logger),
+		repositories.NewVideoUserStatesRepository(pool, logger),
+		repositories.NewVideoEngagementStatsRepository(pool, logger, repositories.StatsShardPolicy{}),
+		txmanager.ProvideManager(txMgrComponent),
+		logger,
+	)
+
+	userID := uuid.New()
+	now := time.Now().UTC().Truncate(time.Second)
+	insertVideo := func(status, visibility string) uuid.UUID {
+		videoID := uuid.New()
+		_, err := pool.Exec(ctx, `
+            INSERT INTO catalog.videos (
+                video_id, upload_user_id, title, raw_file_reference,
+                status, media_status, analysis_status, visibility_status, duration_micros,
+                created_at, updated_at, version
+            ) VALUES ($1, $2, 'History Video', 'gs://bucket/test.mp4',
+                      $3, 'ready', 'ready', $4, 600000000,
+                      $5, $5, 1)
+        `, videoID, uuid.New(), status, visibility, now)
+		require.NoError(t, err)
+		return videoID
+	}
+	watch := func(videoID uuid.UUID, at time.Time, position float64) {
+		_, err := pool.Exec(ctx, `
+            INSERT INTO catalog.video_view_sessions (
+                video_id, user_id, session_started_at, last_progress_at, last_position_seconds
+            ) VALUES ($1, $2, $3, $3, $4)
+        `, videoID, userID, at, position)
+		require.NoError(t, err)
+	}
+
+	older := insertVideo("published", "public")
+	newer := insertVideo("published", "unlisted")
+	private := insertVideo("published", "private")
+	archived := insertVideo("archived", "public")
+	unwatched := insertVideo("published", "public")
+	watch(older, now.Add(-2*time.Hour), 12.5)
+	watch(newer, now.Add(-time.Hour), 90)
+	watch(private, now, 30)
+	watch(archived, now, 30)
+
+	userCtx := metadata.Inject(ctx, metadata.HandlerMetadata{UserID: userID.String()})
+	detail, _, err := service.GetVideoDetail(userCtx, older)
+	require.NoError(t, err)
+	require.True(t, detail.HasWatched)
+	require.EqualValues(t, 12_500_000, detail.ResumePositionMicros)
+
+	detail, _, err = service.GetVideoDetail(userCtx, unwatched)
+	require.NoError(t, err)
+	require.False(t, detail.HasWatched)
+	require.Zero(t, detail.ResumePositionMicros)
+
+	// private 与已下架视频不出现在历史中；按最近观看倒序分页。
+	page, token, err := service.ListMyWatchHistory(userCtx, 1, "")
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	require.Equal(t, newer, page[0].VideoID)
+	require.EqualValues(t, 90_000_000, page[0].ResumePositionMicros)
+	require.EqualValues(t, 600_000_000, page[0].DurationMicros)
+	require.NotEmpty(t, token)
+
+	page, token, err = service.ListMyWatchHistory(userCtx, 1, token)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	require.Equal(t, older, page[0].VideoID)
+	require.Empty(t, token)
+}
+
+func TestVideoQueryService_ListMySavedVideos(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dsn, terminate := startPostgres(ctx, t)
+	defer terminate()
+
+	pool, err := pgxpool.New(ctx, dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { pool.Close() })
+
+	applyAllMigrations(ctx, t, pool)
+	ensureAuthSchema(ctx, t, pool)
+
+	logger := log.NewStdLogger(io.Discard)
+	txMgrComponent, cleanupTx, err := txmanager.NewComponent(configloader.ProvideTxConfig(configloader.RuntimeConfig{}), pool, logger)
+	require.NoError(t, err)
+	t.Cleanup(cleanupTx)
+	service := services.NewVideoQueryService(
+		repositories.NewVideoRepository(pool, logger),
+		repositories.NewVideoUserStatesRepository(pool, logger),
+		repositories.NewVideoEngagementStatsRepository(pool, logger, repositories.StatsShardPolicy{}),
+		txmanager.ProvideManager(txMgrComponent),
+		logger,
+	)
+
+	userID := uuid.New()
+	now := time.Now().UTC().Truncate(time.Second)
+	insertVideo := func(uploader uuid.UUID, status, visibility string, publishAt *time.Time) uuid.UUID {
+		videoID := uuid.New()
+		_, err := pool.Exec(ctx, `
+            INSERT INTO catalog.videos (
+                video_id, upload_user_id, title, raw_file_reference,
+                status, media_status, analysis_status, visibility_status, publish_at,
+                created_at, updated_at, version
+            ) VALUES ($1, $2, 'Saved Video', 'gs://bucket/test.mp4',
+                      $3, 'ready', 'ready', $4, $5,
+                      $6, $6, 1)
+        `, videoID, uploader, status, visibility, publishAt, now)
+		require.NoError(t, err)
+		return videoID
+	}
+	save := func(videoID uuid.UUID, liked, bookmarked bool, at time.Time) {
+		_, err := pool.Exec(ctx, `
+            INSERT INTO catalog.video_user_engagements_projection (
+                user_id, video_id, has_liked, has_bookmarked, liked_occurred_at, bookmarked_occurred_at
+            ) VALUES ($1, $2, $3, $4, $5, $5)
+        `, userID, videoID, liked, bookmarked, at)
+		require.NoError(t, err)
+	}
+
+	future := now.Add(24 * time.Hour)
+	first := insertVideo(uuid.New(), "published", "public", nil)
+	second := insertVideo(uuid.New(), "published", "unlisted", nil)
+	ownPrivate := insertVideo(userID, "processing", "private", nil)
+	othersPrivate := insertVideo(uuid.New(), "published", "private", nil)
+	scheduled := insertVideo(uuid.New(), "published", "public", &future)
+	unliked := insertVideo(uuid.New(), "published", "public", nil)
+	save(first, true, true, now.Add(-3*time.Hour))
+	save(second, true, false, now.Add(-2*time.Hour))
+	save(ownPrivate, true, true, now.Add(-time.Hour))
+	save(othersPrivate, true, true, now)
+	save(scheduled, true, true, now)
+	save(unliked, false, true, now.Add(-4*time.Hour))
+
+	userCtx := metadata.Inject(ctx, metadata.HandlerMetadata{UserID: userID.String()})
+	page, token, err := service.ListMyLikedVideos(userCtx, 2, "")
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	require.Equal(t, ownPrivate, page[0].VideoID, "uploader always sees own videos")
+	require.Equal(t, second, page[1].VideoID)
+	require.NotEmpty(t, token)
+
+	page, token, err = service.ListMyLikedVideos(userCtx, 2, token)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	require.Equal(t, first, page[0].VideoID)
+	require.Empty(t, token)
+
+	bookmarked, _, err := service.ListMyBookmarkedVideos(userCtx, 10, "")
+	require.NoError(t, err)
+	ids := make([]uuid.UUID, 0, len(bookmarked))
+	for _, item := range bookmarked {
+		ids = append(ids, item.VideoID)
+	}
+	require.Equal(t, []uuid.UUID{ownPrivate, first, unliked}, ids)
+}
+
+func TestVideoQueryService_GetVideoStatsTimeseries(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dsn, terminate := startPostgres(ctx, t)
+	defer terminate()
+
+	pool, err := pgxpool.New(ctx, dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { pool.Close() })
+
+	applyAllMigrations(ctx, t, pool)
+	ensureAuthSchema(ctx, t, pool)
+
+	logger := log.NewStdLogger(io.Discard)
+	txMgrComponent, cleanupTx, err := txmanager.NewComponent(configloader.ProvideTxConfig(configloader.RuntimeConfig{}), pool, logger)
+	require.NoError(t, err)
+	t.Cleanup(cleanupTx)
+	statsRepo := repositories.NewVideoEngagementStatsRepository(pool, logger, repositories.StatsShardPolicy{})
+	service := services.NewVideoQueryService(
+		repositories.NewVideoRepository(pool, logger),
+		repositories.NewVideoUserStatesRepository(pool, logger),
+		statsRepo,
+		txmanager.ProvideManager(txMgrComponent),
+		logger,
+	)
+
+	ownerID := uuid.New()
+	videoID := uuid.New()
+	_, err = pool.Exec(ctx, `
+        INSERT INTO catalog.videos (
+            video_id, upload_user_id, title, raw_file_reference,
+            status, media_status, analysis_status, created_at, updated_at, version
+        ) VALUES ($1, $2, 'Stats Video', 'gs://bucket/test.mp4',
+                  'published', 'ready', 'ready', now(), now(), 1)
+    `, videoID, ownerID)
+	require.NoError(t, err)
+
+	day := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	record := func(at time.Time, delta repositories.StatsDelta) {
+		require.NoError(t, statsRepo.IncrementRollups(ctx, nil, videoID, at, delta))
+	}
+	record(day.Add(1*time.Hour+10*time.Minute), repositories.StatsDelta{WatchDelta: 1, UniqueWatcherDelta: 1})
+	record(day.Add(1*time.Hour+50*time.Minute), repositories.StatsDelta{WatchDelta: 1, LikeDelta: 1})
+	record(day.Add(22*time.Hour), repositories.StatsDelta{BookmarkDelta: 1})
+	record(day.Add(26*time.Hour), repositories.StatsDelta{LikeDelta: -1})
+
+	ownerCtx := metadata.Inject(ctx, metadata.HandlerMetadata{UserID: ownerID.String()})
+	hourly, err := service.GetVideoStatsTimeseries(ownerCtx, videoID, po.StatsGranularityHour, day, day.Add(3*time.Hour), vo.UTCStatsZone())
+	require.NoError(t, err)
+	require.Len(t, hourly.Buckets, 3)
+	require.Zero(t, hourly.Buckets[0].WatchCount)
+	require.EqualValues(t, 2, hourly.Buckets[1].WatchCount)
+	require.EqualValues(t, 1, hourly.Buckets[1].UniqueWatchers)
+	require.EqualValues(t, 1, hourly.Buckets[1].LikeDelta)
+
+	utcDaily, err := service.GetVideoStatsTimeseries(ownerCtx, videoID, po.StatsGranularityDay, day, day.AddDate(0, 0, 2), vo.UTCStatsZone())
+	require.NoError(t, err)
+	require.Len(t, utcDaily.Buckets, 2)
+	require.EqualValues(t, 1, utcDaily.Buckets[0].BookmarkDelta)
+	require.EqualValues(t, -1, utcDaily.Buckets[1].LikeDelta)
+
+	// +3 时区：UTC 22:00 的收藏落在当地次日。
+	shifted, err := service.GetVideoStatsTimeseries(ownerCtx, videoID, po.StatsGranularityDay, day, day.AddDate(0, 0, 2), vo.StatsZone{OffsetMinutes: 180, Location: time.FixedZone("", 3*3600)})
+	require.NoError(t, err)
+	require.Len(t, shifted.Buckets, 3)
+	require.EqualValues(t, 2, shifted.Buckets[0].WatchCount)
+	require.Zero(t, shifted.Buckets[0].BookmarkDelta)
+	require.EqualValues(t, 1, shifted.Buckets[1].BookmarkDelta)
+	require.EqualValues(t, -1, shifted.Buckets[1].LikeDelta)
+
+	strangerCtx := metadata.Inject(ctx, metadata.HandlerMetadata{UserID: uuid.NewString()})
+	_, err = service.GetVideoStatsTimeseries(strangerCtx, videoID, po.StatsGranularityDay, day, day.AddDate(0, 0, 2), vo.UTCStatsZone())
+	require.ErrorIs(t, err, services.ErrVideoNotFound)
+
+	hourlyPruned, dailyPruned, err := statsRepo.PruneRollups(ctx, nil, day.Add(2*time.Hour), time.Time{}, 100)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, hourlyPruned)
+	require.Zero(t, dailyPruned)
+}
+
+func TestVideoQueryService_ListTrendingVideos(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dsn, terminate := startPostgres(ctx, t)
+	defer terminate()
+
+	pool, err := pgxpool.New(ctx, dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { pool.Close() })
+
+	applyAllMigrations(ctx, t, pool)
+	ensureAuthSchema(ctx, t, pool)
+
+	logger := log.NewStdLogger(io.Discard)
+	txMgrComponent, cleanupTx, err := txmanager.NewComponent(configloader.ProvideTxConfig(configloader.RuntimeConfig{}), pool, logger)
+	require.NoError(t, err)
+	t.Cleanup(cleanupTx)
+	statsRepo := repositories.NewVideoEngagementStatsRepository(pool, logger, repositories.StatsShardPolicy{})
+	service := services.NewVideoQueryService(
+		repositories.NewVideoRepository(pool, logger),
+		repositories.NewVideoUserStatesRepository(pool, logger),
+		statsRepo,
+		txmanager.ProvideManager(txMgrComponent),
+		logger,
+	)
+
+	insertVideo := func(title, visibility, difficulty string, tags []string) uuid.UUID {
+		videoID := uuid.New()
+		_, err := pool.Exec(ctx, `
+            INSERT INTO catalog.videos (
+                video_id, upload_user_id, title, raw_file_reference,
+                status, media_status, analysis_status, visibility_status, difficulty, tags,
+                created_at, updated_at, version
+            ) VALUES ($1, $2, $3, 'gs://bucket/test.mp4',
+                      'published', 'ready', 'ready', $4, $5, $6, now(), now(), 1)
+        `, videoID, uuid.New(), title, visibility, difficulty, tags)
+		require.NoError(t, err)
+		return videoID
+	}
+	hot := insertVideo("Hot", "public", "beginner", []string{"travel", "food"})
+	warm := insertVideo("Warm", "public", "advanced", []string{"travel"})
+	hidden := insertVideo("Hidden", "private", "beginner", []string{"travel"})
+	stale := insertVideo("Stale", "public", "beginner", nil)
+
+	now := time.Now().UTC()
+	record := func(videoID uuid.UUID, at time.Time, delta repositories.StatsDelta) {
+		require.NoError(t, statsRepo.IncrementRollups(ctx, nil, videoID, at, delta))
+	}
+	record(hot, now.Add(-time.Hour), repositories.StatsDelta{LikeDelta: 5, WatchDelta: 10})
+	record(warm, now.Add(-time.Hour), repositories.StatsDelta{WatchDelta: 4})
+	record(hidden, now.Add(-time.Hour), repositories.StatsDelta{WatchDelta: 100})
+	record(stale, now.Add(-10*24*time.Hour), repositories.StatsDelta{WatchDelta: 100})
+
+	params := repositories.TrendingScoreParams{
+		ComputedAt: now,
+		Window:     72 * time.Hour,
+		HalfLife:   24 * time.Hour,
+		LikeWeight: 3,
+		ViewWeight: 1,
+		MaxVideos:  100,
+	}
+	first, count, err := statsRepo.RefreshTrending(ctx, nil, params)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, count, "private and out-of-window videos are not ranked")
+
+	page1, err := service.ListTrendingVideos(ctx, 1, "", nil, nil)
+	require.NoError(t, err)
+	require.Len(t, page1.Items, 1)
+	require.Equal(t, hot, page1.Items[0].VideoID)
+	require.EqualValues(t, 1, page1.Items[0].Rank)
+	require.Equal(t, "beginner", page1.Items[0].Difficulty)
+	require.WithinDuration(t, first.ComputedAt, page1.ComputedAt, time.Millisecond)
+	require.NotEmpty(t, page1.NextPageToken)
+
+	// 新快照中 warm 反超 hot，但已有游标继续读取旧快照。
+	record(warm, now, repositories.StatsDelta{WatchDelta: 1000})
+	params.ComputedAt = now.Add(time.Minute)
+	_, _, err = statsRepo.RefreshTrending(ctx, nil, params)
+	require.NoError(t, err)
+
+	page2, err := service.ListTrendingVideos(ctx, 1, page1.NextPageToken, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, page2.Items, 1)
+	require.Equal(t, warm, page2.Items[0].VideoID)
+	require.EqualValues(t, 2, page2.Items[0].Rank)
+	require.Empty(t, page2.NextPageToken)
+
+	fresh, err := service.ListTrendingVideos(ctx, 10, "", nil, nil)
+	require.NoError(t, err)
+	require.Len(t, fresh.Items, 2)
+	require.Equal(t, warm, fresh.Items[0].VideoID)
+
+	difficulty := "beginner"
+	filtered, err := service.ListTrendingVideos(ctx, 10, "", &difficulty, []string{"travel", "food"})
+	require.NoError(t, err)
+	require.Len(t, filtered.Items, 1)
+	require.Equal(t, hot, filtered.Items[0].VideoID)
+
+	pruned, err := statsRepo.PruneTrendingSnapshots(ctx, nil, now.Add(30*time.Second))
+	require.NoError(t, err)
+	require.EqualValues(t, 1, pruned)
+	_, err = service.ListTrendingVideos(ctx, 1, page1.NextPageToken, nil, nil)
+	require.Error(t, err, "cursor bound to a pruned snapshot is rejected")
 }
 
 func startPostgres(ctx context.Context, t *testing.T) (string, func()) {
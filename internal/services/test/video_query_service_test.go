@@ -8,6 +8,7 @@ import (
 
 	"github.com/bionicotaku/lingo-services-catalog/internal/metadata"
 	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/vo"
 	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
 	"github.com/bionicotaku/lingo-services-catalog/internal/services"
 	"github.com/bionicotaku/lingo-utils/txmanager"
@@ -30,6 +31,90 @@ func TestVideoQueryService_ListMyUploadsRequiresUserID(t *testing.T) {
 	}
 }
 
+func TestVideoQueryService_ListMyWatchHistoryRequiresUserID(t *testing.T) {
+	logger := log.NewStdLogger(io.Discard)
+	svc := services.NewVideoQueryService(&videoRepoStub{}, nil, nil, noopTxManager{}, logger)
+
+	_, _, err := svc.ListMyWatchHistory(context.Background(), 10, "")
+	if err == nil {
+		t.Fatalf("expected error when user metadata missing")
+	}
+	if e := errors.FromError(err); e.Code != 401 {
+		t.Fatalf("expected http 401, got %d (%s)", e.Code, e.Message)
+	}
+
+	ctx := metadata.Inject(context.Background(), metadata.HandlerMetadata{UserID: uuid.NewString()})
+	items, token, err := svc.ListMyWatchHistory(ctx, 10, "")
+	if err != nil {
+		t.Fatalf("expected empty history without stats repository, got %v", err)
+	}
+	if len(items) != 0 || token != "" {
+		t.Fatalf("expected empty page, got %d items token=%q", len(items), token)
+	}
+}
+
+func TestVideoQueryService_ListMySavedVideosRequiresUserID(t *testing.T) {
+	logger := log.NewStdLogger(io.Discard)
+	svc := services.NewVideoQueryService(&videoRepoStub{}, nil, nil, noopTxManager{}, logger)
+
+	if _, _, err := svc.ListMyLikedVideos(context.Background(), 10, ""); errors.FromError(err).Code != 401 {
+		t.Fatalf("expected http 401 for liked videos, got %v", err)
+	}
+	if _, _, err := svc.ListMyBookmarkedVideos(context.Background(), 10, ""); errors.FromError(err).Code != 401 {
+		t.Fatalf("expected http 401 for bookmarked videos, got %v", err)
+	}
+
+	ctx := metadata.Inject(context.Background(), metadata.HandlerMetadata{UserID: uuid.NewString()})
+	if _, _, err := svc.ListMyLikedVideos(ctx, 10, "%%%"); errors.FromError(err).Code != 400 {
+		t.Fatalf("expected http 400 for invalid page token, got %v", err)
+	}
+}
+
+func TestVideoQueryService_GetVideoStatsTimeseriesOwnerOnly(t *testing.T) {
+	logger := log.NewStdLogger(io.Discard)
+	ownerID := uuid.New()
+	videoID := uuid.New()
+	repo := &queryRepoStub{video: &po.Video{VideoID: videoID, UploadUserID: ownerID}}
+	svc := services.NewVideoQueryService(repo, nil, nil, noopTxManager{}, logger)
+	from := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 3)
+
+	if _, err := svc.GetVideoStatsTimeseries(context.Background(), videoID, po.StatsGranularityDay, from, to, vo.UTCStatsZone()); errors.FromError(err).Code != 401 {
+		t.Fatalf("expected http 401 without user, got %v", err)
+	}
+
+	strangerCtx := metadata.Inject(context.Background(), metadata.HandlerMetadata{UserID: uuid.NewString()})
+	if _, err := svc.GetVideoStatsTimeseries(strangerCtx, videoID, po.StatsGranularityDay, from, to, vo.UTCStatsZone()); errors.FromError(err).Code != 404 {
+		t.Fatalf("expected http 404 for non-owner, got %v", err)
+	}
+
+	ownerCtx := metadata.Inject(context.Background(), metadata.HandlerMetadata{UserID: ownerID.String()})
+	if _, err := svc.GetVideoStatsTimeseries(ownerCtx, videoID, po.StatsGranularityHour, from, from.AddDate(0, 2, 0), vo.UTCStatsZone()); errors.FromError(err).Code != 400 {
+		t.Fatalf("expected http 400 for too many buckets, got %v", err)
+	}
+
+	// 未配置统计仓储时返回全零的连续桶；+8 时区下 UTC 零点落在当地 08:00，对齐到当地零点后多出一个桶。
+	series, err := svc.GetVideoStatsTimeseries(ownerCtx, videoID, po.StatsGranularityDay, from, to, vo.StatsZone{OffsetMinutes: 480, Location: time.FixedZone("", 8*3600)})
+	if err != nil {
+		t.Fatalf("GetVideoStatsTimeseries returned error: %v", err)
+	}
+	if len(series.Buckets) != 4 {
+		t.Fatalf("expected 4 zero-filled buckets, got %d", len(series.Buckets))
+	}
+	if got := series.Buckets[0].BucketStart.Format(time.RFC3339); got != "2025-03-01T00:00:00+08:00" {
+		t.Fatalf("unexpected first bucket start: %s", got)
+	}
+
+	// +05:30 时区：小时桶仍对齐 UTC 整点，以当地时间展示。
+	hourly, err := svc.GetVideoStatsTimeseries(ownerCtx, videoID, po.StatsGranularityHour, from, from.Add(2*time.Hour), vo.StatsZone{OffsetMinutes: 330, Location: time.FixedZone("", 330*60)})
+	if err != nil {
+		t.Fatalf("GetVideoStatsTimeseries returned error: %v", err)
+	}
+	if len(hourly.Buckets) != 2 || hourly.Buckets[0].BucketStart.Format(time.RFC3339) != "2025-03-01T05:30:00+05:30" {
+		t.Fatalf("unexpected half-hour offset buckets: %+v", hourly.Buckets)
+	}
+}
+
 func TestVideoQueryService_ListMyUploadsInvalidUserID(t *testing.T) {
 	logger := log.NewStdLogger(io.Discard)
 	svc := services.NewVideoQueryService(&videoRepoStub{}, nil, nil, noopTxManager{}, logger)
@@ -220,6 +305,7 @@ func TestVideoQueryService_ListMyUploadsAppliesFilters(t *testing.T) {
 }
 
 type queryRepoStub struct {
+	video            *po.Video
 	detail           *po.VideoReadyView
 	metadata         *po.VideoMetadata
 	uploads          []po.MyUploadEntry
@@ -228,6 +314,13 @@ type queryRepoStub struct {
 	lastUploadsInput repositories.ListUserUploadsInput
 }
 
+func (q *queryRepoStub) GetLifecycleSnapshot(context.Context, txmanager.Session, uuid.UUID) (*po.Video, error) {
+	if q.video == nil {
+		return nil, repositories.ErrVideoNotFound
+	}
+	return q.video, nil
+}
+
 func (q *queryRepoStub) FindPublishedByID(context.Context, txmanager.Session, uuid.UUID) (*po.VideoReadyView, error) {
 	q.detailCalls++
 	if q.detail == nil {
@@ -46,6 +46,9 @@ func TestCreateVideoEnqueuesOutbox(t *testing.T) {
 	if outbox.messages[0].EventType != "catalog.video.created" {
 		t.Fatalf("unexpected event type: %s", outbox.messages[0].EventType)
 	}
+	if len(outbox.notified) != 1 || outbox.notified[0] != "catalog.video.created" {
+		t.Fatalf("expected outbox notify in the same transaction, got %v", outbox.notified)
+	}
 }
 
 func TestCreateVideoRepoError(t *testing.T) {
@@ -187,6 +190,16 @@ func (s *videoRepoStub) Delete(_ context.Context, _ txmanager.Session, _ uuid.UU
 	return s.deleteVideo, nil
 }
 
+func (s *videoRepoStub) GetLifecycleSnapshot(_ context.Context, _ txmanager.Session, _ uuid.UUID) (*po.Video, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	if s.video == nil {
+		return nil, repositories.ErrVideoNotFound
+	}
+	return s.video, nil
+}
+
 func (s *videoRepoStub) FindPublishedByID(_ context.Context, _ txmanager.Session, _ uuid.UUID) (*po.VideoReadyView, error) {
 	return nil, repositories.ErrVideoNotFound
 }
@@ -205,6 +218,7 @@ func (s *videoRepoStub) ListUserUploads(_ context.Context, _ txmanager.Session,
 
 type outboxRepoStub struct {
 	messages []repositories.OutboxMessage
+	notified []string
 	err      error
 }
 
@@ -216,6 +230,11 @@ func (s *outboxRepoStub) Enqueue(_ context.Context, _ txmanager.Session, msg rep
 	return nil
 }
 
+func (s *outboxRepoStub) Notify(_ context.Context, _ txmanager.Session, eventType string) error {
+	s.notified = append(s.notified, eventType)
+	return nil
+}
+
 type noopTxManager struct{}
 
 type noopSession struct{}
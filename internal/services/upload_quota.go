@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	videov1 "github.com/bionicotaku/lingo-services-catalog/api/video/v1"
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/vo"
+
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+)
+
+// ErrUploadQuotaExceeded 在用户超出上传配额时返回。
+var ErrUploadQuotaExceeded = errors.New("upload quota exceeded")
+
+const (
+	quotaDailySessions     = "daily_sessions"
+	quotaConcurrentUploads = "concurrent_uploads"
+	quotaStorageBytes      = "storage_bytes"
+
+	// statusTooManyRequests 对应 gRPC ResourceExhausted。
+	statusTooManyRequests = 429
+)
+
+// UploadQuotaTier 描述单个档位的配额上限，0 表示不限。
+type UploadQuotaTier struct {
+	DailySessions     int
+	ConcurrentUploads int
+	StorageBytes      int64
+}
+
+// UploadQuotaPolicy 描述按档位划分的上传配额策略。
+type UploadQuotaPolicy struct {
+	DefaultTier string
+	Tiers       map[string]UploadQuotaTier
+}
+
+// UploadQuotaRepo 抽象配额用量的加锁与读取。
+type UploadQuotaRepo interface {
+	LockQuotaUsage(ctx context.Context, sess txmanager.Session, userID uuid.UUID) error
+	GetQuotaUsage(ctx context.Context, sess txmanager.Session, userID uuid.UUID, since, now time.Time) (*po.UploadQuotaUsage, error)
+}
+
+// UploadQuota 负责解析用户档位并校验上传配额。
+type UploadQuota struct {
+	repo   UploadQuotaRepo
+	tx     txmanager.Manager
+	policy UploadQuotaPolicy
+	log    *log.Helper
+	now    func() time.Time
+}
+
+// NewUploadQuota 构造 UploadQuota。
+func NewUploadQuota(repo UploadQuotaRepo, tx txmanager.Manager, policy UploadQuotaPolicy, logger log.Logger) *UploadQuota {
+	return &UploadQuota{
+		repo:   repo,
+		tx:     tx,
+		policy: policy,
+		log:    log.NewHelper(logger),
+		now:    time.Now,
+	}
+}
+
+// resolveTier 返回用户实际生效的档位名称与上限；未配置的档位回退到默认档位。
+func (q *UploadQuota) resolveTier(tier string) (string, UploadQuotaTier, bool) {
+	if q == nil || len(q.policy.Tiers) == 0 {
+		return "", UploadQuotaTier{}, false
+	}
+	name := strings.ToLower(strings.TrimSpace(tier))
+	if limits, ok := q.policy.Tiers[name]; ok && name != "" {
+		return name, limits, true
+	}
+	name = strings.ToLower(strings.TrimSpace(q.policy.DefaultTier))
+	limits, ok := q.policy.Tiers[name]
+	return name, limits, ok
+}
+
+// Check 在签发上传 URL 前做一次不加锁的预检，超限时尽早返回 429 ERROR_REASON_UPLOAD_QUOTA_EXCEEDED；
+// 并发请求可能同时通过预检，最终以 Admit 的加锁校验为准。
+func (q *UploadQuota) Check(ctx context.Context, userID uuid.UUID, tier string, sizeBytes int64, ttl time.Duration) error {
+	tierName, limits, ok := q.resolveTier(tier)
+	if !ok || (limits.DailySessions <= 0 && limits.ConcurrentUploads <= 0 && limits.StorageBytes <= 0) {
+		return nil
+	}
+	return q.check(ctx, nil, userID, tierName, limits, sizeBytes, ttl)
+}
+
+// Admit 在同一事务内锁定用户配额用量、校验配额并执行 create 创建会话，
+// 同一用户的并发初始化因此串行化，不会同时通过校验而超出配额。
+// 超限时返回 429 ERROR_REASON_UPLOAD_QUOTA_EXCEEDED 并附带 retry_after_seconds，create 不会执行。
+func (q *UploadQuota) Admit(ctx context.Context, userID uuid.UUID, tier string, sizeBytes int64, ttl time.Duration, create func(context.Context, txmanager.Session) error) error {
+	tierName, limits, ok := q.resolveTier(tier)
+	if !ok || (limits.DailySessions <= 0 && limits.ConcurrentUploads <= 0 && limits.StorageBytes <= 0) {
+		return create(ctx, nil)
+	}
+
+	return q.tx.WithinTx(ctx, txmanager.TxOptions{}, func(txCtx context.Context, sess txmanager.Session) error {
+		if err := q.repo.LockQuotaUsage(txCtx, sess, userID); err != nil {
+			return fmt.Errorf("lock upload quota usage: %w", err)
+		}
+		if err := q.check(txCtx, sess, userID, tierName, limits, sizeBytes, ttl); err != nil {
+			return err
+		}
+		return create(txCtx, sess)
+	})
+}
+
+// check 基于 sess 内读取的用量校验各项配额。
+func (q *UploadQuota) check(ctx context.Context, sess txmanager.Session, userID uuid.UUID, tierName string, limits UploadQuotaTier, sizeBytes int64, ttl time.Duration) error {
+	now := q.now().UTC()
+	dayStart := startOfDay(now)
+	usage, err := q.repo.GetQuotaUsage(ctx, sess, userID, dayStart, now)
+	if err != nil {
+		return fmt.Errorf("load upload quota usage: %w", err)
+	}
+
+	if limits.StorageBytes > 0 && usage.StoredBytes+sizeBytes > limits.StorageBytes {
+		return quotaExceeded(tierName, quotaStorageBytes, limits.StorageBytes, 0,
+			fmt.Sprintf("storage quota exceeded: %d of %d bytes used", usage.StoredBytes, limits.StorageBytes))
+	}
+	if limits.DailySessions > 0 && usage.DailySessions >= int64(limits.DailySessions) {
+		retryAfter := dayStart.Add(24 * time.Hour).Sub(now)
+		return quotaExceeded(tierName, quotaDailySessions, int64(limits.DailySessions), retryAfter,
+			fmt.Sprintf("daily upload session limit %d reached", limits.DailySessions))
+	}
+	if limits.ConcurrentUploads > 0 && usage.ConcurrentUploads >= int64(limits.ConcurrentUploads) {
+		retryAfter := ttl
+		if usage.EarliestUploadExpiresAt != nil {
+			retryAfter = usage.EarliestUploadExpiresAt.Sub(now)
+		}
+		return quotaExceeded(tierName, quotaConcurrentUploads, int64(limits.ConcurrentUploads), retryAfter,
+			fmt.Sprintf("concurrent upload limit %d reached", limits.ConcurrentUploads))
+	}
+	return nil
+}
+
+// Snapshot 返回用户当前档位的配额与用量，用于 GetMyUploadQuota。
+func (q *UploadQuota) Snapshot(ctx context.Context, userID uuid.UUID, tier string) (*vo.UploadQuota, error) {
+	tierName, limits, _ := q.resolveTier(tier)
+
+	now := q.now().UTC()
+	dayStart := startOfDay(now)
+	usage, err := q.repo.GetQuotaUsage(ctx, nil, userID, dayStart, now)
+	if err != nil {
+		return nil, fmt.Errorf("load upload quota usage: %w", err)
+	}
+
+	return &vo.UploadQuota{
+		Tier:              tierName,
+		DailySessions:     vo.NewUploadQuotaLimit(int64(limits.DailySessions), usage.DailySessions),
+		ConcurrentUploads: vo.NewUploadQuotaLimit(int64(limits.ConcurrentUploads), usage.ConcurrentUploads),
+		StorageBytes:      vo.NewUploadQuotaLimit(limits.StorageBytes, usage.StoredBytes),
+		DailyResetAt:      dayStart.Add(24 * time.Hour),
+	}, nil
+}
+
+func quotaExceeded(tier, quota string, limit int64, retryAfter time.Duration, message string) error {
+	md := map[string]string{
+		"tier":  tier,
+		"quota": quota,
+		"limit": strconv.FormatInt(limit, 10),
+	}
+	if retryAfter > 0 {
+		md["retry_after_seconds"] = strconv.FormatInt(int64(math.Ceil(retryAfter.Seconds())), 10)
+	}
+	return kerrors.New(statusTooManyRequests, videov1.ErrorReason_ERROR_REASON_UPLOAD_QUOTA_EXCEEDED.String(), message).
+		WithMetadata(md).
+		WithCause(ErrUploadQuotaExceeded)
+}
+
+// startOfDay 返回 UTC 当日零点，每日会话配额按 UTC 自然日重置。
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
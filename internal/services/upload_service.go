@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"strings"
@@ -9,6 +10,7 @@ import (
 
 	videov1 "github.com/bionicotaku/lingo-services-catalog/api/video/v1"
 	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/vo"
 	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
 
 	"github.com/bionicotaku/lingo-utils/txmanager"
@@ -30,14 +32,16 @@ type UploadRepositoryContract interface {
 
 // InitResumableUploadInput 为服务层输入。
 type InitResumableUploadInput struct {
-	UserID          uuid.UUID
-	SizeBytes       int64
-	ContentType     string
-	ContentMD5Hex   string
-	DurationSeconds int32
-	Title           string
-	Description     string
-	IdempotencyKey  string
+	UserID           uuid.UUID
+	Tier             string
+	SizeBytes        int64
+	ContentType      string
+	ContentMD5Hex    string
+	ContentSHA256Hex string
+	DurationSeconds  int32
+	Title            string
+	Description      string
+	IdempotencyKey   string
 }
 
 // InitResumableUploadResult 为服务层输出。
@@ -52,6 +56,7 @@ type InitResumableUploadResult struct {
 type UploadService struct {
 	repo        UploadRepositoryContract
 	signer      UploadSigner
+	quota       *UploadQuota
 	bucket      string
 	ttl         time.Duration
 	log         *log.Helper
@@ -59,8 +64,8 @@ type UploadService struct {
 	allowedMIME map[string]struct{}
 }
 
-// NewUploadService 创建 UploadService。
-func NewUploadService(repo UploadRepositoryContract, signer UploadSigner, bucket string, ttl time.Duration, logger log.Logger) (*UploadService, error) {
+// NewUploadService 创建 UploadService；quota 为 nil 时不限制上传配额。
+func NewUploadService(repo UploadRepositoryContract, signer UploadSigner, quota *UploadQuota, bucket string, ttl time.Duration, logger log.Logger) (*UploadService, error) {
 	switch {
 	case repo == nil:
 		return nil, errors.New("upload service: repository is required")
@@ -75,6 +80,7 @@ func NewUploadService(repo UploadRepositoryContract, signer UploadSigner, bucket
 	svc := &UploadService{
 		repo:   repo,
 		signer: signer,
+		quota:  quota,
 		bucket: bucket,
 		ttl:    ttl,
 		now:    time.Now,
@@ -99,6 +105,7 @@ func (s *UploadService) InitResumableUpload(ctx context.Context, input InitResum
 	}
 
 	md5Hex := strings.ToLower(input.ContentMD5Hex)
+	sha256Hex := strings.ToLower(input.ContentSHA256Hex)
 	contentType := strings.ToLower(input.ContentType)
 
 	existing, err := s.repo.GetByUserMD5(ctx, nil, input.UserID, md5Hex)
@@ -133,7 +140,14 @@ func (s *UploadService) InitResumableUpload(ctx context.Context, input InitResum
 		}
 	}
 
-	if videoID == uuid.Nil {
+	newSession := videoID == uuid.Nil
+	if newSession {
+		// 仅在创建新会话时校验配额，复用既有会话不占用额外额度。
+		if s.quota != nil {
+			if err := s.quota.Check(ctx, input.UserID, input.Tier, input.SizeBytes, s.ttl); err != nil {
+				return nil, err
+			}
+		}
 		videoID = uuid.New()
 		objectName = fmt.Sprintf("raw_videos/%s/%s", input.UserID.String(), videoID.String())
 	}
@@ -153,15 +167,33 @@ func (s *UploadService) InitResumableUpload(ctx context.Context, input InitResum
 		ContentType:        nullableString(contentType),
 		ExpectedSize:       input.SizeBytes,
 		ContentMD5:         md5Hex,
+		ContentSHA256:      nullableString(sha256Hex),
 		Title:              input.Title,
 		Description:        input.Description,
 		SignedURL:          &signedURL,
 		SignedURLExpiresAt: &expiresAt,
 	}
 
-	session, inserted, err := s.repo.Upsert(ctx, nil, upsertInput)
+	var (
+		session  *po.UploadSession
+		inserted bool
+	)
+	persist := func(ctx context.Context, sess txmanager.Session) error {
+		var err error
+		session, inserted, err = s.repo.Upsert(ctx, sess, upsertInput)
+		if err != nil {
+			return fmt.Errorf("persist upload session: %w", err)
+		}
+		return nil
+	}
+	// 新会话在同一事务内锁定用量、复核配额并写入，避免并发初始化同时通过预检而超出配额。
+	if newSession && s.quota != nil {
+		err = s.quota.Admit(ctx, input.UserID, input.Tier, input.SizeBytes, s.ttl, persist)
+	} else {
+		err = persist(ctx, nil)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("persist upload session: %w", err)
+		return nil, err
 	}
 
 	// 以持久化后的数据为准，防止时区差异。
@@ -183,6 +215,21 @@ func (s *UploadService) InitResumableUpload(ctx context.Context, input InitResum
 	}, nil
 }
 
+// GetUploadQuota 返回用户当前档位的上传配额与剩余额度。
+func (s *UploadService) GetUploadQuota(ctx context.Context, userID uuid.UUID, tier string) (*vo.UploadQuota, error) {
+	if userID == uuid.Nil {
+		return nil, kerrors.Unauthorized(videov1.ErrorReason_ERROR_REASON_UPLOAD_INVALID.String(), "user metadata is required")
+	}
+	if s.quota == nil {
+		return nil, kerrors.InternalServer(videov1.ErrorReason_ERROR_REASON_UPLOAD_INVALID.String(), "upload quota not configured")
+	}
+	quota, err := s.quota.Snapshot(ctx, userID, tier)
+	if err != nil {
+		return nil, fmt.Errorf("get upload quota: %w", err)
+	}
+	return quota, nil
+}
+
 // ErrUploadAlreadyCompleted 在重复上传时返回。
 var ErrUploadAlreadyCompleted = errors.New("upload already completed")
 
@@ -196,6 +243,9 @@ func (s *UploadService) validateInput(input InitResumableUploadInput) error {
 	if len(input.ContentMD5Hex) != 32 {
 		return kerrors.BadRequest(videov1.ErrorReason_ERROR_REASON_UPLOAD_INVALID.String(), "content_md5_hex must be 32 hex characters")
 	}
+	if input.ContentSHA256Hex != "" && !isHexString(input.ContentSHA256Hex, 64) {
+		return kerrors.BadRequest(videov1.ErrorReason_ERROR_REASON_UPLOAD_INVALID.String(), "content_sha256_hex must be 64 hex characters")
+	}
 	if input.Title == "" {
 		return kerrors.BadRequest(videov1.ErrorReason_ERROR_REASON_UPLOAD_INVALID.String(), "title is required")
 	}
@@ -227,6 +277,14 @@ func (s *UploadService) shouldReuseSignedURL(session *po.UploadSession, now time
 	return session.SignedURLExpiresAt.After(now.Add(30 * time.Second))
 }
 
+func isHexString(value string, length int) bool {
+	if len(value) != length {
+		return false
+	}
+	_, err := hex.DecodeString(value)
+	return err == nil
+}
+
 func nullableString(value string) *string {
 	if value == "" {
 		return nil
@@ -22,6 +22,7 @@ import (
 
 // VideoQueryRepo 定义读模型所需的访问接口。
 type VideoQueryRepo interface {
+	VideoLookupRepo
 	FindPublishedByID(ctx context.Context, sess txmanager.Session, videoID uuid.UUID) (*po.VideoReadyView, error)
 	GetMetadata(ctx context.Context, sess txmanager.Session, videoID uuid.UUID) (*po.VideoMetadata, error)
 	ListPublicVideos(ctx context.Context, sess txmanager.Session, input repositories.ListPublicVideosInput) ([]po.VideoListEntry, error)
@@ -88,6 +89,12 @@ func (s *VideoQueryService) GetVideoMetadata(ctx context.Context, videoID uuid.U
 		metadata.LikeCount = statsRow.LikeCount
 		metadata.BookmarkCount = statsRow.BookmarkCount
 		metadata.WatchCount = statsRow.WatchCount
+		metadata.TotalWatchSeconds = statsRow.TotalWatchSeconds
+		metadata.CompletionRate = vo.CompletionRate(statsRow, metadata.DurationMicros)
+		metadata.ShareCount = statsRow.ShareCount
+		metadata.RatingCount = statsRow.RatingCount
+		metadata.AverageRating = vo.AverageRating(statsRow)
+		metadata.AverageViewDurationSeconds = vo.AverageViewDuration(statsRow)
 	}
 	return metadata, nil
 }
@@ -99,6 +106,7 @@ func (s *VideoQueryService) GetVideoDetail(ctx context.Context, videoID uuid.UUI
 		state       *po.VideoUserState
 		metadataRow *po.VideoMetadata
 		statsRow    *po.VideoEngagementStatsProjection
+		progress    *po.VideoWatchProgress
 	)
 	var userID *uuid.UUID
 	if meta, ok := metadata.FromContext(ctx); ok {
@@ -134,6 +142,12 @@ func (s *VideoQueryService) GetVideoDetail(ctx context.Context, videoID uuid.UUI
 			if err != nil {
 				return err
 			}
+			if userID != nil {
+				progress, err = s.stats.GetWatchProgress(txCtx, sess, *userID, videoID)
+				if err != nil {
+					return err
+				}
+			}
 		}
 		return nil
 	})
@@ -157,18 +171,37 @@ func (s *VideoQueryService) GetVideoDetail(ctx context.Context, videoID uuid.UUI
 	if state != nil {
 		detail.HasLiked = state.HasLiked
 		detail.HasBookmarked = state.HasBookmarked
+		if state.Rating != nil {
+			detail.MyRating = *state.Rating
+		}
+	}
+	if progress != nil {
+		detail.HasWatched = true
+		detail.ResumePositionMicros = vo.SecondsToMicros(progress.LastPositionSeconds)
 	}
 	if statsRow != nil {
 		detail.LikeCount = statsRow.LikeCount
 		detail.BookmarkCount = statsRow.BookmarkCount
 		detail.WatchCount = statsRow.WatchCount
 		detail.UniqueWatchers = statsRow.UniqueWatchers
+		detail.TotalWatchSeconds = statsRow.TotalWatchSeconds
+		detail.ShareCount = statsRow.ShareCount
+		detail.RatingCount = statsRow.RatingCount
+		detail.AverageRating = vo.AverageRating(statsRow)
+		detail.AverageViewDurationSeconds = vo.AverageViewDuration(statsRow)
 	}
 	meta := vo.NewVideoMetadataFromPO(metadataRow)
 	if meta != nil && statsRow != nil {
 		meta.LikeCount = statsRow.LikeCount
 		meta.BookmarkCount = statsRow.BookmarkCount
 		meta.WatchCount = statsRow.WatchCount
+		meta.TotalWatchSeconds = statsRow.TotalWatchSeconds
+		meta.CompletionRate = vo.CompletionRate(statsRow, meta.DurationMicros)
+		meta.ShareCount = statsRow.ShareCount
+		meta.RatingCount = statsRow.RatingCount
+		meta.AverageRating = detail.AverageRating
+		meta.AverageViewDurationSeconds = detail.AverageViewDurationSeconds
+		detail.CompletionRate = meta.CompletionRate
 	}
 	return detail, meta, nil
 }
@@ -227,17 +260,9 @@ func (s *VideoQueryService) ListUserPublicVideos(ctx context.Context, pageSize i
 
 // ListMyUploads 返回用户上传列表。
 func (s *VideoQueryService) ListMyUploads(ctx context.Context, pageSize int32, pageToken string, statusFilter []po.VideoStatus, stageFilter []po.StageStatus) ([]vo.MyUploadListItem, string, error) {
-	meta, _ := metadata.FromContext(ctx)
-	if meta.InvalidUserInfo {
-		return nil, "", errors.BadRequest(videov1.ErrorReason_ERROR_REASON_VIDEO_UPDATE_INVALID.String(), "invalid user info metadata")
-	}
-	rawUserID := strings.TrimSpace(meta.UserID)
-	if rawUserID == "" {
-		return nil, "", errors.Unauthorized(videov1.ErrorReason_ERROR_REASON_VIDEO_UPDATE_INVALID.String(), "user_id required")
-	}
-	userID, err := uuid.Parse(rawUserID)
+	userID, err := requireUserID(ctx)
 	if err != nil {
-		return nil, "", errors.BadRequest(videov1.ErrorReason_ERROR_REASON_VIDEO_ID_INVALID.String(), "invalid user id")
+		return nil, "", err
 	}
 	limit := clampPageSize(pageSize)
 	cursor, err := decodeCursor(pageToken)
@@ -288,11 +313,283 @@ func (s *VideoQueryService) ListMyUploads(ctx context.Context, pageSize int32, p
 			Version:        row.Version,
 			CreatedAt:      row.CreatedAt,
 			UpdatedAt:      row.UpdatedAt,
+			RawMissing:     row.RawMissing,
 		})
 	}
 	return voItems, nextToken, nil
 }
 
+// ListMyWatchHistory 按最近观看时间倒序返回当前用户的观看历史，用于“继续观看”。
+func (s *VideoQueryService) ListMyWatchHistory(ctx context.Context, pageSize int32, pageToken string) ([]vo.WatchHistoryItem, string, error) {
+	userID, err := requireUserID(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	limit := clampPageSize(pageSize)
+	cursor, err := decodeCursor(pageToken)
+	if err != nil {
+		return nil, "", errors.BadRequest(videov1.ErrorReason_ERROR_REASON_VIDEO_UPDATE_INVALID.String(), "invalid page_token")
+	}
+	if s.stats == nil {
+		return []vo.WatchHistoryItem{}, "", nil
+	}
+
+	input := repositories.ListWatchHistoryInput{
+		UserID: userID,
+		Limit:  limit + 1,
+	}
+	if cursor != nil {
+		input.CursorWatchedAt = &cursor.CreatedAt
+		input.CursorVideoID = &cursor.VideoID
+	}
+
+	var rows []po.WatchHistoryEntry
+	err = s.txManager.WithinReadOnlyTx(ctx, txmanager.TxOptions{}, func(txCtx context.Context, sess txmanager.Session) error {
+		var repoErr error
+		rows, repoErr = s.stats.ListWatchHistory(txCtx, sess, input)
+		return repoErr
+	})
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			s.log.WithContext(ctx).Warnf("list my watch history timeout")
+			return nil, "", errors.GatewayTimeout(videov1.ErrorReason_ERROR_REASON_QUERY_TIMEOUT.String(), "query timeout")
+		}
+		return nil, "", errors.InternalServer(videov1.ErrorReason_ERROR_REASON_QUERY_VIDEO_FAILED.String(), fmt.Sprintf("list my watch history: %v", err))
+	}
+
+	// 游标复用 (created_at, video_id) 结构，此处 CreatedAt 承载 last_watched_at。
+	var nextToken string
+	if len(rows) > int(limit) {
+		last := rows[limit]
+		nextToken = encodeCursor(last.LastWatchedAt, last.VideoID)
+		rows = rows[:limit]
+	}
+
+	items := make([]vo.WatchHistoryItem, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, vo.NewWatchHistoryItem(row))
+	}
+	return items, nextToken, nil
+}
+
+// ListMyLikedVideos 按点赞时间倒序返回当前用户点赞且仍可见的视频。
+func (s *VideoQueryService) ListMyLikedVideos(ctx context.Context, pageSize int32, pageToken string) ([]vo.SavedVideoItem, string, error) {
+	return s.listSavedVideos(ctx, savedKindLiked, pageSize, pageToken)
+}
+
+// ListMyBookmarkedVideos 按收藏时间倒序返回当前用户收藏且仍可见的视频。
+func (s *VideoQueryService) ListMyBookmarkedVideos(ctx context.Context, pageSize int32, pageToken string) ([]vo.SavedVideoItem, string, error) {
+	return s.listSavedVideos(ctx, savedKindBookmarked, pageSize, pageToken)
+}
+
+type savedKind string
+
+const (
+	savedKindLiked      savedKind = "liked"
+	savedKindBookmarked savedKind = "bookmarked"
+)
+
+func (s *VideoQueryService) listSavedVideos(ctx context.Context, kind savedKind, pageSize int32, pageToken string) ([]vo.SavedVideoItem, string, error) {
+	userID, err := requireUserID(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	limit := clampPageSize(pageSize)
+	cursor, err := decodeCursor(pageToken)
+	if err != nil {
+		return nil, "", errors.BadRequest(videov1.ErrorReason_ERROR_REASON_VIDEO_UPDATE_INVALID.String(), "invalid page_token")
+	}
+	if s.userState == nil {
+		return []vo.SavedVideoItem{}, "", nil
+	}
+	list := s.userState.ListLiked
+	if kind == savedKindBookmarked {
+		list = s.userState.ListBookmarked
+	}
+
+	input := repositories.ListSavedVideosInput{
+		UserID: userID,
+		Limit:  limit + 1,
+	}
+	if cursor != nil {
+		input.CursorOccurredAt = &cursor.CreatedAt
+		input.CursorVideoID = &cursor.VideoID
+	}
+
+	var rows []po.SavedVideoEntry
+	err = s.txManager.WithinReadOnlyTx(ctx, txmanager.TxOptions{}, func(txCtx context.Context, sess txmanager.Session) error {
+		var repoErr error
+		rows, repoErr = list(txCtx, sess, input)
+		return repoErr
+	})
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			s.log.WithContext(ctx).Warnf("list my %s videos timeout", kind)
+			return nil, "", errors.GatewayTimeout(videov1.ErrorReason_ERROR_REASON_QUERY_TIMEOUT.String(), "query timeout")
+		}
+		return nil, "", errors.InternalServer(videov1.ErrorReason_ERROR_REASON_QUERY_VIDEO_FAILED.String(), fmt.Sprintf("list my %s videos: %v", kind, err))
+	}
+
+	// 游标复用 (created_at, video_id) 结构，此处 CreatedAt 承载点赞/收藏时间。
+	var nextToken string
+	if len(rows) > int(limit) {
+		last := rows[limit]
+		nextToken = encodeCursor(last.SavedAt, last.VideoID)
+		rows = rows[:limit]
+	}
+
+	items := make([]vo.SavedVideoItem, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, vo.NewSavedVideoItem(row))
+	}
+	return items, nextToken, nil
+}
+
+const (
+	maxHourlyStatsBuckets = 24 * 31
+	maxDailyStatsBuckets  = 366
+)
+
+// GetVideoStatsTimeseries 返回视频在 [from, to) 内按小时或按天的统计时间序列，仅视频上传者可查询。
+// 时间范围按 zone 时区向外对齐到桶边界，无数据的桶补零；非上传者统一返回 NotFound。
+// UTC 天级序列读取天级桶；其他时区的天级序列由小时桶按当地日期归并，因此受小时桶保留期限制。
+func (s *VideoQueryService) GetVideoStatsTimeseries(ctx context.Context, videoID uuid.UUID, granularity po.StatsGranularity, from, to time.Time, zone vo.StatsZone) (*vo.VideoStatsTimeseries, error) {
+	userID, err := requireUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var maxBuckets int
+	switch granularity {
+	case po.StatsGranularityHour:
+		maxBuckets = maxHourlyStatsBuckets
+	case po.StatsGranularityDay:
+		maxBuckets = maxDailyStatsBuckets
+	default:
+		return nil, errors.BadRequest(videov1.ErrorReason_ERROR_REASON_VIDEO_UPDATE_INVALID.String(), "granularity must be hour or day")
+	}
+	if !from.Before(to) {
+		return nil, errors.BadRequest(videov1.ErrorReason_ERROR_REASON_VIDEO_UPDATE_INVALID.String(), "from must be before to")
+	}
+	window := vo.NewStatsWindow(granularity, from, to, zone)
+	if window.BucketCount() > maxBuckets {
+		return nil, errors.BadRequest(videov1.ErrorReason_ERROR_REASON_VIDEO_UPDATE_INVALID.String(), fmt.Sprintf("time range exceeds %d %s buckets", maxBuckets, granularity))
+	}
+	stored := granularity
+	if granularity == po.StatsGranularityDay && !window.Zone.IsUTC() {
+		stored = po.StatsGranularityHour
+	}
+
+	var rows []po.VideoStatsBucket
+	err = s.txManager.WithinReadOnlyTx(ctx, txmanager.TxOptions{}, func(txCtx context.Context, sess txmanager.Session) error {
+		video, repoErr := s.repo.GetLifecycleSnapshot(txCtx, sess, videoID)
+		if repoErr != nil {
+			return repoErr
+		}
+		if video.UploadUserID != userID {
+			return repositories.ErrVideoNotFound
+		}
+		if s.stats == nil {
+			return nil
+		}
+		rows, repoErr = s.stats.ListRollups(txCtx, sess, videoID, stored, window.Start.UTC(), window.End.UTC())
+		return repoErr
+	})
+	if err != nil {
+		if errors.Is(err, repositories.ErrVideoNotFound) {
+			return nil, ErrVideoNotFound
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			s.log.WithContext(ctx).Warnf("get video stats timeseries timeout: video_id=%s", videoID)
+			return nil, errors.GatewayTimeout(videov1.ErrorReason_ERROR_REASON_QUERY_TIMEOUT.String(), "query timeout")
+		}
+		s.log.WithContext(ctx).Errorf("get video stats timeseries failed: video_id=%s err=%v", videoID, err)
+		return nil, errors.InternalServer(videov1.ErrorReason_ERROR_REASON_QUERY_VIDEO_FAILED.String(), "failed to query video stats").WithCause(fmt.Errorf("list stats rollups: %w", err))
+	}
+	return vo.NewVideoStatsTimeseries(videoID, window, rows), nil
+}
+
+// ListTrendingVideos 按热度名次返回已发布的公开视频，可按难度与标签过滤。
+// 首页读取最新热度快照，page_token 记录快照 ID 与上一页最后的名次，翻页期间快照刷新不影响后续页；
+// 游标指向的快照已被清理时返回 BadRequest，调用方应从首页重新拉取。
+func (s *VideoQueryService) ListTrendingVideos(ctx context.Context, pageSize int32, pageToken string, difficulty *string, tags []string) (*vo.TrendingVideoPage, error) {
+	limit := clampPageSize(pageSize)
+	cursor, err := decodeTrendingCursor(pageToken)
+	if err != nil {
+		return nil, errors.BadRequest(videov1.ErrorReason_ERROR_REASON_VIDEO_UPDATE_INVALID.String(), "invalid page_token")
+	}
+	page := &vo.TrendingVideoPage{Items: []vo.TrendingVideoItem{}}
+	if s.stats == nil {
+		return page, nil
+	}
+
+	var (
+		snapshot *po.VideoTrendingSnapshot
+		rows     []po.TrendingVideoEntry
+	)
+	err = s.txManager.WithinReadOnlyTx(ctx, txmanager.TxOptions{}, func(txCtx context.Context, sess txmanager.Session) error {
+		var snapshotID int64
+		if cursor != nil {
+			snapshotID = cursor.SnapshotID
+		}
+		var repoErr error
+		snapshot, repoErr = s.stats.GetTrendingSnapshot(txCtx, sess, snapshotID)
+		if repoErr != nil || snapshot == nil {
+			return repoErr
+		}
+		input := repositories.ListTrendingInput{
+			SnapshotID: snapshot.SnapshotID,
+			Difficulty: difficulty,
+			Tags:       tags,
+			Limit:      limit + 1,
+		}
+		if cursor != nil {
+			input.AfterRank = cursor.Rank
+		}
+		rows, repoErr = s.stats.ListTrending(txCtx, sess, input)
+		return repoErr
+	})
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			s.log.WithContext(ctx).Warnf("list trending videos timeout")
+			return nil, errors.GatewayTimeout(videov1.ErrorReason_ERROR_REASON_QUERY_TIMEOUT.String(), "query timeout")
+		}
+		return nil, errors.InternalServer(videov1.ErrorReason_ERROR_REASON_QUERY_VIDEO_FAILED.String(), fmt.Sprintf("list trending videos: %v", err))
+	}
+	if snapshot == nil {
+		if cursor != nil {
+			return nil, errors.BadRequest(videov1.ErrorReason_ERROR_REASON_VIDEO_UPDATE_INVALID.String(), "page_token expired")
+		}
+		return page, nil
+	}
+
+	page.ComputedAt = snapshot.ComputedAt
+	if len(rows) > int(limit) {
+		rows = rows[:limit]
+		page.NextPageToken = encodeTrendingCursor(snapshot.SnapshotID, rows[limit-1].Rank)
+	}
+	for _, row := range rows {
+		page.Items = append(page.Items, vo.NewTrendingVideoItem(row))
+	}
+	return page, nil
+}
+
+// requireUserID 从 metadata 解析当前用户 ID，缺失时返回 Unauthorized。
+func requireUserID(ctx context.Context) (uuid.UUID, error) {
+	meta, _ := metadata.FromContext(ctx)
+	if meta.InvalidUserInfo {
+		return uuid.Nil, errors.BadRequest(videov1.ErrorReason_ERROR_REASON_VIDEO_UPDATE_INVALID.String(), "invalid user info metadata")
+	}
+	rawUserID := strings.TrimSpace(meta.UserID)
+	if rawUserID == "" {
+		return uuid.Nil, errors.Unauthorized(videov1.ErrorReason_ERROR_REASON_VIDEO_UPDATE_INVALID.String(), "user_id required")
+	}
+	userID, err := uuid.Parse(rawUserID)
+	if err != nil {
+		return uuid.Nil, errors.BadRequest(videov1.ErrorReason_ERROR_REASON_VIDEO_ID_INVALID.String(), "invalid user id")
+	}
+	return userID, nil
+}
+
 func clampPageSize(size int32) int32 {
 	if size <= 0 {
 		return 20
@@ -327,3 +624,32 @@ func decodeCursor(token string) (*pageCursor, error) {
 	}
 	return &cursor, nil
 }
+
+// trendingCursor 记录热度榜分页所绑定的快照与上一页最后一条的名次。
+type trendingCursor struct {
+	SnapshotID int64 `json:"snapshot_id"`
+	Rank       int32 `json:"rank"`
+}
+
+func encodeTrendingCursor(snapshotID int64, rank int32) string {
+	payload, _ := json.Marshal(trendingCursor{SnapshotID: snapshotID, Rank: rank})
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+func decodeTrendingCursor(token string) (*trendingCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+	var cursor trendingCursor
+	if err := json.Unmarshal(decoded, &cursor); err != nil {
+		return nil, err
+	}
+	if cursor.SnapshotID <= 0 || cursor.Rank <= 0 {
+		return nil, fmt.Errorf("invalid trending cursor")
+	}
+	return &cursor, nil
+}
@@ -31,6 +31,7 @@ type VideoRevision struct {
 	UpdatedAt      time.Time
 	EventID        uuid.UUID
 	OccurredAt     time.Time
+	RawMissing     bool
 }
 
 // NewVideoRevision 根据领域实体与事件构造 VideoRevision。
@@ -59,5 +60,6 @@ func NewVideoRevision(video *po.Video, event *outboxevents.DomainEvent, occurred
 			return uuid.Nil
 		}(),
 		OccurredAt: occurredAt.UTC(),
+		RawMissing: video.RawMissing,
 	}
 }
@@ -0,0 +1,407 @@
+// Package backfill 为新接入的下游消费者回填视频全量快照：按 video_id 键集分页扫描 catalog.videos，
+// 为每个视频发布 catalog.video.snapshot 事件到指定发布端，限速并按页推进检查点，中断后可续跑。
+//
+// 快照事件不经过 Outbox，直接交给 eventsink.Sink 发布；事件携带 backfill_id 属性，
+// 消费者据此（或事件类型）区分回填快照与实时变更。
+package backfill
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/eventsink"
+	outboxevents "github.com/bionicotaku/lingo-services-catalog/internal/models/outbox_events"
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"golang.org/x/time/rate"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultBackfillID   = "catalog-backfill"
+	defaultPageSize     = 500
+	defaultConcurrency  = 8
+	defaultMaxAttempts  = 5
+	defaultRetryBackoff = 500 * time.Millisecond
+	maxRetryBackoff     = 10 * time.Second
+)
+
+// lockNamePrefix 为回填任务 advisory 锁名前缀，锁名后接 BackfillID，同一回填任务同一时刻只允许一个实例执行。
+const lockNamePrefix = "catalog.event_backfill:"
+
+// snapshotEventNamespace 为派生快照 event_id 的 UUIDv5 命名空间。
+var snapshotEventNamespace = uuid.MustParse("d8393472-8e43-4044-b5b3-5704aef3d980")
+
+// Options 控制一次回填任务。
+type Options struct {
+	// BackfillID 标识检查点并作为快照事件的 backfill_id 属性；同一 BackfillID 中断后再次执行会从检查点续跑。
+	BackfillID string
+	// Target 为发布目标的 topic 键，仅用于记录与续跑时校验，实际发布端由调用方注入。
+	Target string
+	// Scope 限定回填的视频范围。
+	Scope repositories.BackfillScope
+	// PageSize 为每页扫描的视频数，亦是检查点推进的粒度。
+	PageSize int
+	// Rate 为每秒最多发布的事件数；不大于 0 表示不限速。
+	Rate float64
+	// Concurrency 为单页内并发发布的事件数。
+	Concurrency int
+	// MaxAttempts 为单个事件的最大发布尝试次数，用尽后中止回填，保留检查点待续跑。
+	MaxAttempts int
+	// DryRun 只统计范围内的视频数，不发布也不写检查点。
+	DryRun bool
+	// Restart 丢弃已有检查点并从头回填。
+	Restart bool
+}
+
+// Report 汇总一次回填的执行结果。
+type Report struct {
+	BackfillID string `json:"backfill_id"`
+	Target     string `json:"target"`
+	DryRun     bool   `json:"dry_run"`
+	Resumed    bool   `json:"resumed"`
+	Completed  bool   `json:"completed"`
+	// Scanned 为本次执行扫描的视频数（续跑时不含此前已完成的页）。
+	Scanned int `json:"scanned"`
+	// Published 为该回填任务累计发布的快照数，含续跑前的进度。
+	Published int64 `json:"published"`
+}
+
+// backfillStore 定义回填所需的视频扫描与检查点访问接口。
+type backfillStore interface {
+	ListVideos(ctx context.Context, sess txmanager.Session, scope repositories.BackfillScope, after *uuid.UUID, limit int) ([]*po.Video, error)
+	GetCheckpoint(ctx context.Context, sess txmanager.Session, backfillID string) (*po.EventBackfillCheckpoint, error)
+	CreateCheckpoint(ctx context.Context, sess txmanager.Session, backfillID, target string, scope repositories.BackfillScope) (*po.EventBackfillCheckpoint, error)
+	AdvanceCheckpoint(ctx context.Context, sess txmanager.Session, backfillID string, lastVideoID uuid.UUID, published int64) error
+	CompleteCheckpoint(ctx context.Context, sess txmanager.Session, backfillID string) error
+	DeleteCheckpoint(ctx context.Context, sess txmanager.Session, backfillID string) error
+}
+
+var _ backfillStore = (*repositories.EventBackfillRepository)(nil)
+
+// backfillLocker 提供跨实例的会话级排他锁，回填期间持有以阻止同一 BackfillID 并发执行。
+type backfillLocker interface {
+	TryLock(ctx context.Context, name string) (func(), bool, error)
+}
+
+var _ backfillLocker = (*repositories.AdvisoryLockRepository)(nil)
+
+// Backfiller 将 catalog.videos 的当前状态作为快照事件发布到指定发布端。
+type Backfiller struct {
+	store  backfillStore
+	sink   eventsink.Sink
+	locker backfillLocker
+	log    *log.Helper
+	now    func() time.Time
+	// retryBackoff 为发布失败后的首次重试间隔，测试中可缩短。
+	retryBackoff time.Duration
+}
+
+// Params 注入 Backfiller 所需依赖。
+type Params struct {
+	Store backfillStore
+	// Sink 为快照事件的发布端；DryRun 时可为空。
+	Sink eventsink.Sink
+	// Locker 可选；配置后非 dry-run 回填全程持有该 BackfillID 的排他锁，锁已被持有时直接失败。
+	Locker       backfillLocker
+	Logger       log.Logger
+	Clock        func() time.Time
+	RetryBackoff time.Duration
+}
+
+// NewBackfiller 构造 Backfiller。
+func NewBackfiller(params Params) (*Backfiller, error) {
+	if params.Store == nil {
+		return nil, fmt.Errorf("event backfill: store is required")
+	}
+	logger := params.Logger
+	if logger == nil {
+		logger = log.DefaultLogger
+	}
+	clock := params.Clock
+	if clock == nil {
+		clock = time.Now
+	}
+	backoff := params.RetryBackoff
+	if backoff <= 0 {
+		backoff = defaultRetryBackoff
+	}
+	return &Backfiller{
+		store:        params.Store,
+		sink:         params.Sink,
+		locker:       params.Locker,
+		log:          log.NewHelper(logger),
+		now:          clock,
+		retryBackoff: backoff,
+	}, nil
+}
+
+// Run 执行回填：逐页读取视频并发布快照，每页全部发布成功后推进检查点。
+// 中断或发布失败后以相同 BackfillID 重新执行，从最后一个完成的页之后续跑；未完成页内已发布的快照会以相同 event_id 重发。
+// 配置 Locker 时，同一 BackfillID 同一时刻只允许一个实例执行，避免两个实例交替推进同一检查点。
+func (b *Backfiller) Run(ctx context.Context, opts Options) (*Report, error) {
+	if opts.BackfillID = strings.TrimSpace(opts.BackfillID); opts.BackfillID == "" {
+		opts.BackfillID = defaultBackfillID
+	}
+	if opts.PageSize <= 0 {
+		opts.PageSize = defaultPageSize
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = defaultConcurrency
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = defaultMaxAttempts
+	}
+	// 状态过滤按集合语义比较，排序去重后再写入/比对检查点。
+	opts.Scope.Statuses = slices.Compact(slices.Sorted(slices.Values(opts.Scope.Statuses)))
+
+	report := &Report{BackfillID: opts.BackfillID, Target: opts.Target, DryRun: opts.DryRun}
+	if opts.DryRun {
+		return report, b.count(ctx, opts, report)
+	}
+	if b.sink == nil {
+		return report, fmt.Errorf("event backfill: event sink not configured")
+	}
+
+	if b.locker != nil {
+		release, acquired, err := b.locker.TryLock(ctx, lockNamePrefix+opts.BackfillID)
+		if err != nil {
+			return report, fmt.Errorf("event backfill: lock %s: %w", opts.BackfillID, err)
+		}
+		if !acquired {
+			return report, fmt.Errorf("event backfill: %q is already running in another process", opts.BackfillID)
+		}
+		defer release()
+	}
+
+	checkpoint, err := b.prepareCheckpoint(ctx, opts, report)
+	if err != nil || report.Completed {
+		return report, err
+	}
+
+	limiter := rate.NewLimiter(rate.Inf, opts.Concurrency)
+	if opts.Rate > 0 {
+		limiter = rate.NewLimiter(rate.Limit(opts.Rate), max(1, min(opts.Concurrency, int(opts.Rate))))
+	}
+
+	after := checkpoint.LastVideoID
+	for {
+		videos, err := b.store.ListVideos(ctx, nil, opts.Scope, after, opts.PageSize)
+		if err != nil {
+			return report, fmt.Errorf("event backfill: scan videos: %w", err)
+		}
+		if len(videos) == 0 {
+			break
+		}
+		report.Scanned += len(videos)
+
+		if err := b.publishPage(ctx, opts, checkpoint.StartedAt, limiter, videos); err != nil {
+			return report, fmt.Errorf("event backfill: publish page after %s: %w", formatCursor(after), err)
+		}
+		last := videos[len(videos)-1].VideoID
+		if err := b.store.AdvanceCheckpoint(ctx, nil, opts.BackfillID, last, report.Published+int64(len(videos))); err != nil {
+			return report, fmt.Errorf("event backfill: advance checkpoint: %w", err)
+		}
+		report.Published += int64(len(videos))
+		after = &last
+		b.log.WithContext(ctx).Infof("event backfill: %s progress published=%d last_video=%s", opts.BackfillID, report.Published, last)
+
+		if len(videos) < opts.PageSize {
+			break
+		}
+	}
+
+	if err := b.store.CompleteCheckpoint(ctx, nil, opts.BackfillID); err != nil {
+		return report, fmt.Errorf("event backfill: complete checkpoint: %w", err)
+	}
+	report.Completed = true
+	return report, nil
+}
+
+// prepareCheckpoint 续用或新建检查点；已完成的检查点直接返回并标记 report.Completed。
+func (b *Backfiller) prepareCheckpoint(ctx context.Context, opts Options, report *Report) (*po.EventBackfillCheckpoint, error) {
+	checkpoint, err := b.store.GetCheckpoint(ctx, nil, opts.BackfillID)
+	switch {
+	case errors.Is(err, repositories.ErrBackfillCheckpointNotFound):
+		checkpoint = nil
+	case err != nil:
+		return nil, fmt.Errorf("event backfill: load checkpoint: %w", err)
+	}
+
+	if checkpoint != nil && !opts.Restart {
+		if checkpoint.Target != opts.Target || !sameScope(checkpoint, opts.Scope) {
+			return nil, fmt.Errorf("event backfill: checkpoint %q was created for a different target or scope, rerun with -restart", opts.BackfillID)
+		}
+		report.Resumed = true
+		report.Published = checkpoint.PublishedCount
+		if checkpoint.CompletedAt != nil {
+			report.Completed = true
+			b.log.WithContext(ctx).Infof("event backfill: %s already completed at %s", opts.BackfillID, checkpoint.CompletedAt.Format(time.RFC3339))
+		}
+		return checkpoint, nil
+	}
+
+	if checkpoint != nil {
+		if err := b.store.DeleteCheckpoint(ctx, nil, opts.BackfillID); err != nil {
+			return nil, fmt.Errorf("event backfill: reset checkpoint: %w", err)
+		}
+	}
+	checkpoint, err = b.store.CreateCheckpoint(ctx, nil, opts.BackfillID, opts.Target, opts.Scope)
+	if err != nil {
+		return nil, fmt.Errorf("event backfill: create checkpoint: %w", err)
+	}
+	return checkpoint, nil
+}
+
+// count 在 dry-run 模式下统计范围内的视频数。
+func (b *Backfiller) count(ctx context.Context, opts Options, report *Report) error {
+	var after *uuid.UUID
+	for {
+		videos, err := b.store.ListVideos(ctx, nil, opts.Scope, after, opts.PageSize)
+		if err != nil {
+			return fmt.Errorf("event backfill: scan videos: %w", err)
+		}
+		report.Scanned += len(videos)
+		if len(videos) < opts.PageSize {
+			break
+		}
+		last := videos[len(videos)-1].VideoID
+		after = &last
+	}
+	report.Completed = true
+	return nil
+}
+
+// publishPage 以 Concurrency 个并发发布一页快照；任一事件用尽重试即取消本页剩余发布并返回错误。
+func (b *Backfiller) publishPage(ctx context.Context, opts Options, startedAt time.Time, limiter *rate.Limiter, videos []*po.Video) error {
+	pageCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan *po.Video)
+	errs := make([]error, opts.Concurrency)
+	var wg sync.WaitGroup
+	for w := 0; w < opts.Concurrency; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for video := range jobs {
+				if err := b.publishVideo(pageCtx, opts, startedAt, limiter, video); err != nil {
+					if errs[w] == nil {
+						errs[w] = err
+					}
+					cancel()
+				}
+			}
+		}(w)
+	}
+
+	for _, video := range videos {
+		if pageCtx.Err() != nil {
+			break
+		}
+		jobs <- video
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+	return ctx.Err()
+}
+
+func (b *Backfiller) publishVideo(ctx context.Context, opts Options, startedAt time.Time, limiter *rate.Limiter, video *po.Video) error {
+	msg, err := b.buildMessage(ctx, opts.BackfillID, startedAt, video)
+	if err != nil {
+		return fmt.Errorf("video %s: %w", video.VideoID, err)
+	}
+
+	delay := b.retryBackoff
+	for attempt := 1; ; attempt++ {
+		if err := limiter.Wait(ctx); err != nil {
+			return fmt.Errorf("video %s: %w", video.VideoID, err)
+		}
+		pubErr := b.sink.Publish(ctx, msg)
+		if pubErr == nil {
+			return nil
+		}
+		if attempt >= opts.MaxAttempts || ctx.Err() != nil {
+			return fmt.Errorf("video %s: publish after %d attempts: %w", video.VideoID, attempt, pubErr)
+		}
+		b.log.WithContext(ctx).Warnf("event backfill: publish failed: video=%s attempt=%d retry_in=%s err=%v", video.VideoID, attempt, delay, pubErr)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("video %s: %w", video.VideoID, pubErr)
+		case <-timer.C:
+		}
+		delay = min(delay*2, maxRetryBackoff)
+	}
+}
+
+// buildMessage 构造快照事件；event_id 由回填任务、其开始时间、视频与快照版本号派生，续跑重发同一快照时保持不变，
+// 便于下游按 event_id 去重，而 -restart 后的新一轮回填产生新的 event_id。
+func (b *Backfiller) buildMessage(ctx context.Context, backfillID string, startedAt time.Time, video *po.Video) (eventsink.Message, error) {
+	seed := fmt.Sprintf("%s/%d/%s/%d", backfillID, startedAt.UTC().UnixMicro(), video.VideoID, outboxevents.SnapshotVersion(video))
+	eventID := uuid.NewSHA1(snapshotEventNamespace, []byte(seed))
+
+	event, err := outboxevents.NewVideoSnapshotEvent(video, eventID, backfillID, b.now())
+	if err != nil {
+		return eventsink.Message{}, fmt.Errorf("build snapshot event: %w", err)
+	}
+	protoEvent, err := outboxevents.ToProto(event)
+	if err != nil {
+		return eventsink.Message{}, fmt.Errorf("convert event to proto: %w", err)
+	}
+	payload, err := proto.Marshal(protoEvent)
+	if err != nil {
+		return eventsink.Message{}, fmt.Errorf("marshal snapshot event: %w", err)
+	}
+
+	headers := outboxevents.BuildAttributes(event, outboxevents.SchemaVersionV1, outboxevents.TraceIDFromContext(ctx))
+	headers[outboxevents.AttributeBackfillID] = backfillID
+	return eventsink.Message{
+		EventID:     event.EventID,
+		EventType:   outboxevents.FormatEventType(event.Kind),
+		AggregateID: event.AggregateID,
+		Payload:     payload,
+		Headers:     headers,
+	}, nil
+}
+
+func sameScope(checkpoint *po.EventBackfillCheckpoint, scope repositories.BackfillScope) bool {
+	return slices.Equal(checkpoint.ScopeStatuses, scope.Statuses) &&
+		equalStringPtr(checkpoint.ScopeVisibility, scope.VisibilityStatus) &&
+		equalUUIDPtr(checkpoint.ScopeUploaderID, scope.UploaderID)
+}
+
+func equalStringPtr(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func equalUUIDPtr(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func formatCursor(after *uuid.UUID) string {
+	if after == nil {
+		return "start"
+	}
+	return after.String()
+}
@@ -0,0 +1,465 @@
+package backfill_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	videov1 "github.com/bionicotaku/lingo-services-catalog/api/video/v1"
+	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/eventsink"
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/backfill"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestBackfillerPublishesSnapshotsForEveryVideo(t *testing.T) {
+	store := newFakeBackfillStore()
+	for i := 0; i < 5; i++ {
+		store.addVideo(po.VideoStatusPublished, po.VisibilityPublic)
+	}
+	sink := newFakeSink()
+	backfiller := newTestBackfiller(t, store, sink)
+
+	report, err := backfiller.Run(context.Background(), backfill.Options{
+		BackfillID: "search-index",
+		Target:     "search",
+		PageSize:   2,
+	})
+	require.NoError(t, err)
+	require.True(t, report.Completed)
+	require.False(t, report.Resumed)
+	require.Equal(t, 5, report.Scanned)
+	require.Equal(t, int64(5), report.Published)
+
+	msgs := sink.published()
+	require.Len(t, msgs, 5)
+	require.ElementsMatch(t, store.videoIDs(), aggregateIDs(msgs))
+	for _, msg := range msgs {
+		require.Equal(t, "catalog.video.snapshot", msg.EventType)
+		require.Equal(t, "search-index", msg.Headers["backfill_id"])
+		require.Equal(t, msg.EventID.String(), msg.Headers["event_id"])
+
+		var event videov1.Event
+		require.NoError(t, proto.Unmarshal(msg.Payload, &event))
+		require.Equal(t, videov1.EventType_EVENT_TYPE_VIDEO_SNAPSHOT, event.GetEventType())
+		require.Equal(t, msg.AggregateID.String(), event.GetSnapshot().GetVideoId())
+		require.Equal(t, "search-index", event.GetSnapshot().GetBackfillId())
+	}
+
+	cp := store.checkpoints["search-index"]
+	require.NotNil(t, cp.CompletedAt)
+	require.Equal(t, int64(5), cp.PublishedCount)
+	require.Equal(t, "search", cp.Target)
+}
+
+func TestBackfillerAppliesScope(t *testing.T) {
+	store := newFakeBackfillStore()
+	published := store.addVideo(po.VideoStatusPublished, po.VisibilityPublic)
+	store.addVideo(po.VideoStatusPublished, po.VisibilityPrivate)
+	store.addVideo(po.VideoStatusProcessing, po.VisibilityPublic)
+	sink := newFakeSink()
+	backfiller := newTestBackfiller(t, store, sink)
+
+	visibility := po.VisibilityPublic
+	report, err := backfiller.Run(context.Background(), backfill.Options{
+		BackfillID: "public-only",
+		Target:     "search",
+		Scope: repositories.BackfillScope{
+			Statuses:         []po.VideoStatus{po.VideoStatusReady, po.VideoStatusPublished},
+			VisibilityStatus: &visibility,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), report.Published)
+	require.Equal(t, []uuid.UUID{published}, aggregateIDs(sink.published()))
+}
+
+func TestBackfillerResumesFromCheckpointWithStableEventIDs(t *testing.T) {
+	store := newFakeBackfillStore()
+	for i := 0; i < 4; i++ {
+		store.addVideo(po.VideoStatusPublished, po.VisibilityPublic)
+	}
+	ids := store.videoIDs()
+
+	// 第三个视频首次发布失败，回填在第二页中止。
+	sink := newFakeSink()
+	sink.failFor(ids[2], 1)
+	backfiller := newTestBackfiller(t, store, sink)
+	opts := backfill.Options{BackfillID: "resume", Target: "search", PageSize: 2, MaxAttempts: 1, Concurrency: 1}
+
+	report, err := backfiller.Run(context.Background(), opts)
+	require.Error(t, err)
+	require.False(t, report.Completed)
+	require.Equal(t, int64(2), report.Published)
+	require.Equal(t, ids[1], *store.checkpoints["resume"].LastVideoID)
+	firstRun := sink.published()
+
+	report, err = backfiller.Run(context.Background(), opts)
+	require.NoError(t, err)
+	require.True(t, report.Resumed)
+	require.True(t, report.Completed)
+	require.Equal(t, 2, report.Scanned, "resumed run only scans pages after the checkpoint")
+	require.Equal(t, int64(4), report.Published)
+
+	secondRun := sink.published()[len(firstRun):]
+	require.ElementsMatch(t, ids[2:], aggregateIDs(secondRun))
+
+	// 同一回填任务内同一快照的 event_id 稳定；-restart 后开启新一轮，event_id 随之改变。
+	again, err := backfiller.Run(context.Background(), opts)
+	require.NoError(t, err)
+	require.True(t, again.Completed)
+	require.Len(t, sink.published(), len(firstRun)+len(secondRun), "completed backfill publishes nothing")
+
+	store.advanceClock(time.Second)
+	opts.Restart = true
+	_, err = backfiller.Run(context.Background(), opts)
+	require.NoError(t, err)
+	restarted := sink.published()[len(firstRun)+len(secondRun):]
+	require.Len(t, restarted, 4)
+	require.NotEqual(t, eventIDFor(firstRun, ids[0]), eventIDFor(restarted, ids[0]))
+}
+
+func TestBackfillerRetriesFailedPublish(t *testing.T) {
+	store := newFakeBackfillStore()
+	videoID := store.addVideo(po.VideoStatusReady, po.VisibilityPublic)
+	sink := newFakeSink()
+	sink.failFor(videoID, 2)
+	backfiller := newTestBackfiller(t, store, sink)
+
+	report, err := backfiller.Run(context.Background(), backfill.Options{BackfillID: "retry", Target: "search", MaxAttempts: 3})
+	require.NoError(t, err)
+	require.True(t, report.Completed)
+	require.Equal(t, 3, sink.attemptsFor(videoID))
+
+	msgs := sink.published()
+	require.Len(t, msgs, 1)
+}
+
+func TestBackfillerRejectsCheckpointForDifferentScope(t *testing.T) {
+	store := newFakeBackfillStore()
+	store.addVideo(po.VideoStatusPublished, po.VisibilityPublic)
+	sink := newFakeSink()
+	sink.failFor(store.videoIDs()[0], 1)
+	backfiller := newTestBackfiller(t, store, sink)
+
+	_, err := backfiller.Run(context.Background(), backfill.Options{BackfillID: "scoped", Target: "search", MaxAttempts: 1})
+	require.Error(t, err)
+
+	_, err = backfiller.Run(context.Background(), backfill.Options{BackfillID: "scoped", Target: "recommendations"})
+	require.ErrorContains(t, err, "different target or scope")
+}
+
+func TestBackfillerRateLimitsPublishing(t *testing.T) {
+	store := newFakeBackfillStore()
+	for i := 0; i < 5; i++ {
+		store.addVideo(po.VideoStatusPublished, po.VisibilityPublic)
+	}
+	sink := newFakeSink()
+	backfiller := newTestBackfiller(t, store, sink)
+
+	start := time.Now()
+	_, err := backfiller.Run(context.Background(), backfill.Options{BackfillID: "slow", Target: "search", Rate: 20, Concurrency: 1})
+	require.NoError(t, err)
+	// 20/s、突发 1：5 个事件至少需要 4 个间隔（200ms）。
+	require.GreaterOrEqual(t, time.Since(start), 180*time.Millisecond)
+	require.Len(t, sink.published(), 5)
+}
+
+func TestBackfillerDryRunOnlyCounts(t *testing.T) {
+	store := newFakeBackfillStore()
+	for i := 0; i < 3; i++ {
+		store.addVideo(po.VideoStatusPublished, po.VisibilityPublic)
+	}
+	backfiller, err := backfill.NewBackfiller(backfill.Params{Store: store, Logger: log.NewStdLogger(io.Discard)})
+	require.NoError(t, err)
+
+	report, err := backfiller.Run(context.Background(), backfill.Options{BackfillID: "count", DryRun: true, PageSize: 2})
+	require.NoError(t, err)
+	require.True(t, report.DryRun)
+	require.Equal(t, 3, report.Scanned)
+	require.Empty(t, store.checkpoints)
+}
+
+func TestBackfillerRefusesConcurrentRunOfSameID(t *testing.T) {
+	store := newFakeBackfillStore()
+	store.addVideo(po.VideoStatusPublished, po.VisibilityPublic)
+	sink := newFakeSink()
+	locker := newFakeLocker()
+	backfiller, err := backfill.NewBackfiller(backfill.Params{
+		Store:  store,
+		Sink:   sink,
+		Locker: locker,
+		Logger: log.NewStdLogger(io.Discard),
+	})
+	require.NoError(t, err)
+
+	// 另一实例持有同一 BackfillID 的锁：本次执行直接失败，不写检查点也不发布。
+	locker.hold("catalog.event_backfill:search")
+	_, err = backfiller.Run(context.Background(), backfill.Options{BackfillID: "search", Target: "search"})
+	require.ErrorContains(t, err, "already running")
+	require.Empty(t, store.checkpoints)
+	require.Empty(t, sink.published())
+
+	// dry-run 不取锁；其他 BackfillID 不受影响，执行结束后释放锁。
+	_, err = backfiller.Run(context.Background(), backfill.Options{BackfillID: "search", DryRun: true})
+	require.NoError(t, err)
+	report, err := backfiller.Run(context.Background(), backfill.Options{BackfillID: "recommend", Target: "recommend"})
+	require.NoError(t, err)
+	require.True(t, report.Completed)
+	require.Equal(t, []string{"catalog.event_backfill:search"}, locker.held())
+}
+
+func newTestBackfiller(t *testing.T, store *fakeBackfillStore, sink eventsink.Sink) *backfill.Backfiller {
+	t.Helper()
+	backfiller, err := backfill.NewBackfiller(backfill.Params{
+		Store:        store,
+		Sink:         sink,
+		Logger:       log.NewStdLogger(io.Discard),
+		RetryBackoff: time.Millisecond,
+	})
+	require.NoError(t, err)
+	return backfiller
+}
+
+func aggregateIDs(msgs []eventsink.Message) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(msgs))
+	for _, msg := range msgs {
+		ids = append(ids, msg.AggregateID)
+	}
+	return ids
+}
+
+func eventIDFor(msgs []eventsink.Message, videoID uuid.UUID) uuid.UUID {
+	for _, msg := range msgs {
+		if msg.AggregateID == videoID {
+			return msg.EventID
+		}
+	}
+	return uuid.Nil
+}
+
+// fakeBackfillStore 在内存中模拟 catalog.videos 与 catalog.event_backfill_checkpoints。
+type fakeBackfillStore struct {
+	mu          sync.Mutex
+	videos      []*po.Video
+	checkpoints map[string]*po.EventBackfillCheckpoint
+	clock       time.Time
+}
+
+func newFakeBackfillStore() *fakeBackfillStore {
+	return &fakeBackfillStore{
+		checkpoints: map[string]*po.EventBackfillCheckpoint{},
+		clock:       time.Date(2025, time.March, 1, 8, 0, 0, 0, time.UTC),
+	}
+}
+
+func (s *fakeBackfillStore) addVideo(status po.VideoStatus, visibility string) uuid.UUID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	video := &po.Video{
+		VideoID:          uuid.New(),
+		UploadUserID:     uuid.New(),
+		CreatedAt:        s.clock,
+		UpdatedAt:        s.clock.Add(time.Minute),
+		Title:            "video",
+		Status:           status,
+		MediaStatus:      po.StageReady,
+		AnalysisStatus:   po.StageReady,
+		VisibilityStatus: visibility,
+		Version:          1,
+	}
+	s.videos = append(s.videos, video)
+	slices.SortFunc(s.videos, func(a, b *po.Video) int {
+		return bytes.Compare(a.VideoID[:], b.VideoID[:])
+	})
+	return video.VideoID
+}
+
+func (s *fakeBackfillStore) videoIDs() []uuid.UUID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]uuid.UUID, 0, len(s.videos))
+	for _, video := range s.videos {
+		ids = append(ids, video.VideoID)
+	}
+	return ids
+}
+
+func (s *fakeBackfillStore) advanceClock(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clock = s.clock.Add(d)
+}
+
+func (s *fakeBackfillStore) ListVideos(_ context.Context, _ txmanager.Session, scope repositories.BackfillScope, after *uuid.UUID, limit int) ([]*po.Video, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []*po.Video
+	for _, video := range s.videos {
+		if after != nil && bytes.Compare(video.VideoID[:], after[:]) <= 0 {
+			continue
+		}
+		if len(scope.Statuses) > 0 && !slices.Contains(scope.Statuses, video.Status) {
+			continue
+		}
+		if scope.VisibilityStatus != nil && *scope.VisibilityStatus != video.VisibilityStatus {
+			continue
+		}
+		if scope.UploaderID != nil && *scope.UploaderID != video.UploadUserID {
+			continue
+		}
+		out = append(out, video)
+		if len(out) == limit {
+			break
+		}
+	}
+	return out, nil
+}
+
+func (s *fakeBackfillStore) GetCheckpoint(_ context.Context, _ txmanager.Session, backfillID string) (*po.EventBackfillCheckpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cp, ok := s.checkpoints[backfillID]
+	if !ok {
+		return nil, repositories.ErrBackfillCheckpointNotFound
+	}
+	clone := *cp
+	return &clone, nil
+}
+
+func (s *fakeBackfillStore) CreateCheckpoint(_ context.Context, _ txmanager.Session, backfillID, target string, scope repositories.BackfillScope) (*po.EventBackfillCheckpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.checkpoints[backfillID]; ok {
+		return nil, errors.New("duplicate checkpoint")
+	}
+	cp := &po.EventBackfillCheckpoint{
+		BackfillID:      backfillID,
+		Target:          target,
+		ScopeStatuses:   append([]po.VideoStatus{}, scope.Statuses...),
+		ScopeVisibility: scope.VisibilityStatus,
+		ScopeUploaderID: scope.UploaderID,
+		StartedAt:       s.clock,
+		UpdatedAt:       s.clock,
+	}
+	s.checkpoints[backfillID] = cp
+	clone := *cp
+	return &clone, nil
+}
+
+func (s *fakeBackfillStore) AdvanceCheckpoint(_ context.Context, _ txmanager.Session, backfillID string, lastVideoID uuid.UUID, published int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cp := s.checkpoints[backfillID]
+	cp.LastVideoID = &lastVideoID
+	cp.PublishedCount = published
+	return nil
+}
+
+func (s *fakeBackfillStore) CompleteCheckpoint(_ context.Context, _ txmanager.Session, backfillID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.clock
+	s.checkpoints[backfillID].CompletedAt = &now
+	return nil
+}
+
+func (s *fakeBackfillStore) DeleteCheckpoint(_ context.Context, _ txmanager.Session, backfillID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.checkpoints, backfillID)
+	return nil
+}
+
+// fakeLocker 在内存中模拟会话级 advisory 锁。
+type fakeLocker struct {
+	mu    sync.Mutex
+	locks map[string]bool
+}
+
+func newFakeLocker() *fakeLocker {
+	return &fakeLocker{locks: map[string]bool{}}
+}
+
+func (l *fakeLocker) hold(name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.locks[name] = true
+}
+
+func (l *fakeLocker) held() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	names := make([]string, 0, len(l.locks))
+	for name := range l.locks {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func (l *fakeLocker) TryLock(_ context.Context, name string) (func(), bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.locks[name] {
+		return nil, false, nil
+	}
+	l.locks[name] = true
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		delete(l.locks, name)
+	}, true, nil
+}
+
+// fakeSink 记录发布成功的消息，并可让指定视频的前若干次发布失败。
+type fakeSink struct {
+	mu       sync.Mutex
+	messages []eventsink.Message
+	failures map[uuid.UUID]int
+	attempts map[uuid.UUID]int
+}
+
+func newFakeSink() *fakeSink {
+	return &fakeSink{failures: map[uuid.UUID]int{}, attempts: map[uuid.UUID]int{}}
+}
+
+func (s *fakeSink) failFor(videoID uuid.UUID, times int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[videoID] = times
+}
+
+func (s *fakeSink) Publish(_ context.Context, msg eventsink.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attempts[msg.AggregateID]++
+	if s.failures[msg.AggregateID] > 0 {
+		s.failures[msg.AggregateID]--
+		return errors.New("sink unavailable")
+	}
+	s.messages = append(s.messages, msg)
+	return nil
+}
+
+func (s *fakeSink) published() []eventsink.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]eventsink.Message(nil), s.messages...)
+}
+
+func (s *fakeSink) attemptsFor(videoID uuid.UUID) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.attempts[videoID]
+}
@@ -0,0 +1,405 @@
+package engagement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/quarantine"
+	"github.com/bionicotaku/lingo-utils/gcpubsub"
+	"github.com/bionicotaku/lingo-utils/outbox/inbox"
+	"github.com/bionicotaku/lingo-utils/outbox/store"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+)
+
+// BatchPolicy 描述批量消费：每批最多 MaxEvents 条事件，首条事件到达后最多等待 MaxWait；MaxEvents <= 1 表示逐条消费。
+type BatchPolicy struct {
+	MaxEvents int
+	MaxWait   time.Duration
+}
+
+// NewBatchPolicy 将配置映射为 BatchPolicy。
+func NewBatchPolicy(cfg configloader.BatchConfig) BatchPolicy {
+	return BatchPolicy{
+		MaxEvents: int(cfg.MaxEvents),
+		MaxWait:   cfg.MaxWait,
+	}
+}
+
+// Enabled 报告是否开启批量消费。
+func (p BatchPolicy) Enabled() bool {
+	return p.MaxEvents > 1
+}
+
+// batchInboxStore 定义批量消费登记与去重 Inbox 事件所需的接口。
+type batchInboxStore interface {
+	InsertBatch(ctx context.Context, sess txmanager.Session, sourceService string, events []repositories.InboxMessage) error
+	LockUnprocessed(ctx context.Context, sess txmanager.Session, eventIDs []uuid.UUID) (map[uuid.UUID]time.Time, error)
+	MarkBatchProcessed(ctx context.Context, sess txmanager.Session, eventIDs []uuid.UUID, processedAt time.Time) error
+	RecordError(ctx context.Context, sess txmanager.Session, eventID uuid.UUID, lastErr string) error
+}
+
+var _ batchInboxStore = (*repositories.InboxRepository)(nil)
+
+// batchItem 是一条等待批量应用的消息；done 在所属批次提交（或失败）后收到结果，决定消息 ack 还是 nack。
+type batchItem struct {
+	inbox repositories.InboxMessage
+	event *Event
+	done  chan error
+}
+
+// BatchConsumer 将 Pub/Sub 消息攒批后在同一事务内应用：批量登记 Inbox，跳过已处理的重复事件，
+// 经写缓冲投影合并 (user, video) 与 video 维度的变更后以 pgx.Batch 落库，再批量标记事件已处理；
+// 事务提交后才确认整批消息。整批失败时退回逐条处理，只让出错的事件 nack 并记录错误；
+// 配置隔离仓储时，逐条处理返回永久错误的事件改为隔离后确认。无法解析的消息不进入批次，隔离（或记录日志）后直接确认。
+type BatchConsumer struct {
+	subscriber gcpubsub.Subscriber
+	inbox      batchInboxStore
+	txManager  txmanager.Manager
+	decoder    *eventDecoder
+	source     string
+	policy     BatchPolicy
+	projection *batchProjection
+	batched    *EventHandler
+	direct     inbox.Handler[Event]
+	quarantine quarantine.Store
+	gate       projectionGate
+	log        *log.Helper
+	metrics    *metrics
+}
+
+// BatchConsumerParams 注入 BatchConsumer 所需依赖。
+type BatchConsumerParams struct {
+	Subscriber gcpubsub.Subscriber
+	Inbox      batchInboxStore
+	UserRepo   batchUserStatesStore
+	StatsRepo  batchStatsStore
+	Purges     engagementPurgeStore
+	Quarantine quarantine.Store
+	// Gate 可选；批量与逐条事务均先获取投影共享锁，重放期间整批放弃并等待重投。
+	Gate          projectionGate
+	Views         ViewPolicy
+	TxManager     txmanager.Manager
+	SourceService string
+	Policy        BatchPolicy
+	Logger        log.Logger
+	Metrics       *metrics
+}
+
+// NewBatchConsumer 构造 BatchConsumer。
+func NewBatchConsumer(params BatchConsumerParams) (*BatchConsumer, error) {
+	if params.Subscriber == nil {
+		return nil, fmt.Errorf("engagement batch: subscriber is required")
+	}
+	if params.Inbox == nil {
+		return nil, fmt.Errorf("engagement batch: inbox repository is required")
+	}
+	if params.UserRepo == nil || params.StatsRepo == nil {
+		return nil, fmt.Errorf("engagement batch: user state and stats repositories are required")
+	}
+	if params.TxManager == nil {
+		return nil, fmt.Errorf("engagement batch: tx manager is required")
+	}
+	if !params.Policy.Enabled() {
+		return nil, fmt.Errorf("engagement batch: max_events must be greater than 1")
+	}
+	if params.Policy.MaxWait <= 0 {
+		return nil, fmt.Errorf("engagement batch: max_wait must be positive")
+	}
+	logger := params.Logger
+	if logger == nil {
+		logger = log.DefaultLogger
+	}
+	projection := newBatchProjection(params.UserRepo, params.StatsRepo)
+	var direct inbox.Handler[Event] = NewEventHandler(params.UserRepo, params.StatsRepo, params.Purges, params.Views, logger, params.Metrics)
+	if params.Quarantine != nil {
+		direct = quarantine.NewHandler[Event](QuarantineConsumer, direct, params.Quarantine, logger)
+	}
+	if params.Gate != nil {
+		direct = gatedHandler{inner: direct, gate: params.Gate}
+	}
+	return &BatchConsumer{
+		subscriber: params.Subscriber,
+		inbox:      params.Inbox,
+		txManager:  params.TxManager,
+		decoder:    newEventDecoder(),
+		source:     params.SourceService,
+		policy:     params.Policy,
+		projection: projection,
+		batched:    NewEventHandler(projection, projection, params.Purges, params.Views, logger, params.Metrics),
+		direct:     direct,
+		quarantine: params.Quarantine,
+		gate:       params.Gate,
+		log:        log.NewHelper(logger),
+		metrics:    params.Metrics,
+	}, nil
+}
+
+// Run 消费消息直到 ctx 结束；消息处理函数阻塞到所属批次提交后才返回，以保证提交后再确认。
+func (c *BatchConsumer) Run(ctx context.Context) error {
+	items := make(chan *batchItem)
+	loopCtx, cancel := context.WithCancel(ctx)
+	loopDone := make(chan struct{})
+	go func() {
+		defer close(loopDone)
+		c.loop(loopCtx, items)
+	}()
+	defer func() {
+		cancel()
+		<-loopDone
+	}()
+
+	return c.subscriber.Receive(ctx, func(msgCtx context.Context, msg *gcpubsub.Message) error {
+		item, err := c.prepare(msg)
+		if err != nil {
+			return c.reject(msgCtx, msg, err)
+		}
+		select {
+		case items <- item:
+		case <-msgCtx.Done():
+			return msgCtx.Err()
+		case <-loopDone:
+			return context.Canceled
+		}
+		select {
+		case err := <-item.done:
+			return err
+		case <-msgCtx.Done():
+			return msgCtx.Err()
+		}
+	})
+}
+
+// prepare 解析消息属性与负载，构造待登记的 Inbox 记录。
+func (c *BatchConsumer) prepare(msg *gcpubsub.Message) (*batchItem, error) {
+	eventID, err := uuid.Parse(strings.TrimSpace(msg.Attributes["event_id"]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid event_id attribute: %w", err)
+	}
+	evt, err := c.decoder.Decode(msg.Data)
+	if err != nil {
+		return nil, err
+	}
+	inbox := repositories.InboxMessage{
+		EventID:       eventID,
+		SourceService: c.source,
+		EventType:     strings.TrimSpace(msg.Attributes["event_type"]),
+		Payload:       evt.Payload,
+	}
+	if aggregateType := msg.Attributes["aggregate_type"]; aggregateType != "" {
+		inbox.AggregateType = &aggregateType
+	}
+	if aggregateID := msg.Attributes["aggregate_id"]; aggregateID != "" {
+		inbox.AggregateID = &aggregateID
+	}
+	return &batchItem{inbox: inbox, event: evt, done: make(chan error, 1)}, nil
+}
+
+// reject 处理无法解析的消息：重投也无法解析，因此不 nack。event_id 有效且配置了隔离仓储时登记隔离记录后确认，
+// 登记失败才 nack 等待重投；否则只记录日志与失败指标后确认。
+func (c *BatchConsumer) reject(ctx context.Context, msg *gcpubsub.Message, cause error) error {
+	if c.metrics != nil {
+		c.metrics.recordFailure(ctx)
+	}
+	eventID, err := uuid.Parse(strings.TrimSpace(msg.Attributes["event_id"]))
+	if err != nil || c.quarantine == nil {
+		c.log.WithContext(ctx).Errorf("engagement batch: drop undecodable message %s: %v", msg.ID, cause)
+		return nil
+	}
+	evt := store.InboxEvent{
+		EventID:       eventID,
+		SourceService: c.source,
+		EventType:     strings.TrimSpace(msg.Attributes["event_type"]),
+		Payload:       msg.Data,
+		ReceivedAt:    time.Now().UTC(),
+	}
+	if aggregateType := msg.Attributes["aggregate_type"]; aggregateType != "" {
+		evt.AggregateType = &aggregateType
+	}
+	if aggregateID := msg.Attributes["aggregate_id"]; aggregateID != "" {
+		evt.AggregateID = &aggregateID
+	}
+	err = c.txManager.WithinTx(ctx, txmanager.TxOptions{}, func(txCtx context.Context, sess txmanager.Session) error {
+		return c.quarantine.Quarantine(txCtx, sess, repositories.QuarantineInboxEventInput{
+			Consumer:   QuarantineConsumer,
+			Event:      evt,
+			ErrorClass: classInvalidPayload,
+			LastError:  cause.Error(),
+		})
+	})
+	if err != nil {
+		c.log.WithContext(ctx).Warnf("engagement batch: quarantine undecodable message %s failed: %v", msg.ID, err)
+		return err
+	}
+	c.log.WithContext(ctx).Warnf("engagement batch: undecodable message quarantined: event=%s err=%v", eventID, cause)
+	return nil
+}
+
+// loop 攒批：达到 MaxEvents 或首条事件等待满 MaxWait 时应用当前批次；ctx 结束时未应用的事件全部 nack。
+func (c *BatchConsumer) loop(ctx context.Context, items <-chan *batchItem) {
+	var (
+		pending []*batchItem
+		timer   *time.Timer
+		timeout <-chan time.Time
+	)
+	flush := func() {
+		if timer != nil {
+			timer.Stop()
+			timer, timeout = nil, nil
+		}
+		c.apply(ctx, pending)
+		pending = nil
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			for _, item := range pending {
+				item.done <- ctx.Err()
+			}
+			return
+		case item := <-items:
+			pending = append(pending, item)
+			if len(pending) == 1 {
+				timer = time.NewTimer(c.policy.MaxWait)
+				timeout = timer.C
+			}
+			if len(pending) >= c.policy.MaxEvents {
+				flush()
+			}
+		case <-timeout:
+			flush()
+		}
+	}
+}
+
+// apply 在单个事务内应用一批事件；失败时逐条重试，隔离出错的事件。投影正在重放时整批 nack。
+func (c *BatchConsumer) apply(ctx context.Context, items []*batchItem) {
+	if len(items) == 0 {
+		return
+	}
+	started := time.Now()
+	applied, err := c.applyBatch(ctx, items)
+	if err == nil {
+		for _, item := range items {
+			item.done <- nil
+		}
+		if c.metrics != nil {
+			c.metrics.recordBatch(ctx, len(items), applied, time.Since(started))
+		}
+		return
+	}
+	if errors.Is(err, errReplayInProgress) {
+		for _, item := range items {
+			item.done <- err
+		}
+		return
+	}
+	if !errors.Is(err, context.Canceled) {
+		c.log.WithContext(ctx).Warnf("engagement batch failed, retry events one by one: events=%d err=%v", len(items), err)
+	}
+	for _, item := range items {
+		item.done <- c.applyOne(ctx, item)
+	}
+}
+
+// applyBatch 返回本批实际应用（非重复）的事件数。
+func (c *BatchConsumer) applyBatch(ctx context.Context, items []*batchItem) (int, error) {
+	messages := make([]repositories.InboxMessage, 0, len(items))
+	eventIDs := make([]uuid.UUID, 0, len(items))
+	seen := make(map[uuid.UUID]struct{}, len(items))
+	unique := make([]*batchItem, 0, len(items))
+	for _, item := range items {
+		if _, ok := seen[item.inbox.EventID]; ok {
+			continue
+		}
+		seen[item.inbox.EventID] = struct{}{}
+		messages = append(messages, item.inbox)
+		eventIDs = append(eventIDs, item.inbox.EventID)
+		unique = append(unique, item)
+	}
+
+	var applied []uuid.UUID
+	err := c.txManager.WithinTx(ctx, txmanager.TxOptions{}, func(txCtx context.Context, sess txmanager.Session) error {
+		applied = applied[:0]
+		c.projection.reset()
+		if err := enterProjection(txCtx, sess, c.gate); err != nil {
+			return err
+		}
+		if err := c.inbox.InsertBatch(txCtx, sess, c.source, messages); err != nil {
+			return err
+		}
+		pending, err := c.inbox.LockUnprocessed(txCtx, sess, eventIDs)
+		if err != nil {
+			return err
+		}
+		for _, item := range unique {
+			receivedAt, ok := pending[item.inbox.EventID]
+			if !ok {
+				continue
+			}
+			if err := c.batched.Handle(txCtx, sess, item.event, inboxEvent(item.inbox, receivedAt)); err != nil {
+				return fmt.Errorf("event %s: %w", item.inbox.EventID, err)
+			}
+			applied = append(applied, item.inbox.EventID)
+		}
+		if err := c.projection.flush(txCtx, sess); err != nil {
+			return err
+		}
+		return c.inbox.MarkBatchProcessed(txCtx, sess, applied, time.Now())
+	})
+	return len(applied), err
+}
+
+// applyOne 在独立事务内应用单条事件，语义与 Inbox Runner 一致：重复事件直接确认，失败时记录错误并 nack。
+func (c *BatchConsumer) applyOne(ctx context.Context, item *batchItem) error {
+	eventID := item.inbox.EventID
+	err := c.txManager.WithinTx(ctx, txmanager.TxOptions{}, func(txCtx context.Context, sess txmanager.Session) error {
+		if err := c.inbox.InsertBatch(txCtx, sess, c.source, []repositories.InboxMessage{item.inbox}); err != nil {
+			return err
+		}
+		pending, err := c.inbox.LockUnprocessed(txCtx, sess, []uuid.UUID{eventID})
+		if err != nil {
+			return err
+		}
+		receivedAt, ok := pending[eventID]
+		if !ok {
+			return nil
+		}
+		if err := c.direct.Handle(txCtx, sess, item.event, inboxEvent(item.inbox, receivedAt)); err != nil {
+			return err
+		}
+		return c.inbox.MarkBatchProcessed(txCtx, sess, []uuid.UUID{eventID}, time.Now())
+	})
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, errReplayInProgress) {
+		return err
+	}
+	c.log.WithContext(ctx).Errorf("engagement event failed: event=%s type=%s err=%v", eventID, item.inbox.EventType, err)
+	recordErr := c.txManager.WithinTx(ctx, txmanager.TxOptions{}, func(txCtx context.Context, sess txmanager.Session) error {
+		if err := c.inbox.InsertBatch(txCtx, sess, c.source, []repositories.InboxMessage{item.inbox}); err != nil {
+			return err
+		}
+		return c.inbox.RecordError(txCtx, sess, eventID, err.Error())
+	})
+	if recordErr != nil {
+		c.log.WithContext(ctx).Warnf("engagement record inbox error failed: event=%s err=%v", eventID, recordErr)
+	}
+	return err
+}
+
+func inboxEvent(msg repositories.InboxMessage, receivedAt time.Time) *store.InboxEvent {
+	return &store.InboxEvent{
+		EventID:       msg.EventID,
+		SourceService: msg.SourceService,
+		EventType:     msg.EventType,
+		AggregateType: msg.AggregateType,
+		AggregateID:   msg.AggregateID,
+		Payload:       msg.Payload,
+		ReceivedAt:    receivedAt,
+	}
+}
@@ -0,0 +1,280 @@
+package engagement
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/google/uuid"
+)
+
+// batchUserStatesStore 在 videoUserStatesStore 基础上增加批量写入。
+type batchUserStatesStore interface {
+	videoUserStatesStore
+	UpsertBatch(ctx context.Context, sess txmanager.Session, inputs []repositories.UpsertVideoUserStateInput) error
+}
+
+var _ batchUserStatesStore = (*repositories.VideoUserStatesRepository)(nil)
+
+// batchStatsStore 在 videoEngagementStatsStore 基础上增加批量写入。
+type batchStatsStore interface {
+	videoEngagementStatsStore
+	ApplyBatch(ctx context.Context, sess txmanager.Session, batch repositories.StatsBatch) error
+}
+
+var _ batchStatsStore = (*repositories.VideoEngagementStatsRepository)(nil)
+
+type rollupKey struct {
+	VideoID uuid.UUID
+	Hour    time.Time
+}
+
+// batchProjection 是一批事件期间的写缓冲投影：读取优先命中本批已写入的状态，未命中时在同一事务内读取仓储；
+// 写入只在内存中合并，flush 时按 (user, video) 与 video 各写一次。
+// 首次出现的观看者仍立即写入仓储，以便得到是否首次观看。
+type batchProjection struct {
+	users batchUserStatesStore
+	stats batchStatsStore
+
+	states        map[userVideoKey]*po.VideoUserState
+	dirtyStates   map[userVideoKey]struct{}
+	sessions      map[userVideoKey]*po.VideoViewSession
+	dirtySessions map[userVideoKey]struct{}
+	watchers      map[userVideoKey]*po.VideoWatcherRecord
+	touched       map[userVideoKey]struct{}
+	totals        map[uuid.UUID]*repositories.StatsDelta
+	rollups       map[rollupKey]*repositories.StatsDelta
+	// shardSalt 每批重新生成，与视频 ID 一起派生该视频本批合并增量的分片键。
+	shardSalt uuid.UUID
+}
+
+func newBatchProjection(users batchUserStatesStore, stats batchStatsStore) *batchProjection {
+	p := &batchProjection{users: users, stats: stats}
+	p.reset()
+	return p
+}
+
+// reset 丢弃缓冲内容，开始新的一批。
+func (p *batchProjection) reset() {
+	p.states = make(map[userVideoKey]*po.VideoUserState)
+	p.dirtyStates = make(map[userVideoKey]struct{})
+	p.sessions = make(map[userVideoKey]*po.VideoViewSession)
+	p.dirtySessions = make(map[userVideoKey]struct{})
+	p.watchers = make(map[userVideoKey]*po.VideoWatcherRecord)
+	p.touched = make(map[userVideoKey]struct{})
+	p.totals = make(map[uuid.UUID]*repositories.StatsDelta)
+	p.rollups = make(map[rollupKey]*repositories.StatsDelta)
+	p.shardSalt = uuid.New()
+}
+
+// shardKeyFor 为 videoID 派生本批的分片键：合并后的增量不再属于某个用户，
+// 按视频与批次计算可使同一视频的并发批次分散到不同分片，且与本批事件顺序无关。
+func (p *batchProjection) shardKeyFor(videoID uuid.UUID) uuid.UUID {
+	return uuid.NewSHA1(p.shardSalt, videoID[:])
+}
+
+func (p *batchProjection) Get(ctx context.Context, sess txmanager.Session, userID, videoID uuid.UUID) (*po.VideoUserState, error) {
+	key := userVideoKey{UserID: userID, VideoID: videoID}
+	state, ok := p.states[key]
+	if !ok {
+		loaded, err := p.users.Get(ctx, sess, userID, videoID)
+		if err != nil {
+			return nil, err
+		}
+		p.states[key] = loaded
+		state = loaded
+	}
+	if state == nil {
+		return nil, nil
+	}
+	copied := *state
+	return &copied, nil
+}
+
+func (p *batchProjection) Upsert(_ context.Context, _ txmanager.Session, input repositories.UpsertVideoUserStateInput) error {
+	key := userVideoKey{UserID: input.UserID, VideoID: input.VideoID}
+	p.states[key] = &po.VideoUserState{
+		UserID:               input.UserID,
+		VideoID:              input.VideoID,
+		HasLiked:             input.HasLiked,
+		HasBookmarked:        input.HasBookmarked,
+		LikedOccurredAt:      cloneTime(input.LikedOccurredAt),
+		BookmarkedOccurredAt: cloneTime(input.BookmarkedOccurredAt),
+		LikedSequence:        cloneInt64(input.LikedSequence),
+		BookmarkedSequence:   cloneInt64(input.BookmarkedSequence),
+		Rating:               cloneInt32(input.Rating),
+		RatedOccurredAt:      cloneTime(input.RatedOccurredAt),
+		RatedSequence:        cloneInt64(input.RatedSequence),
+	}
+	p.dirtyStates[key] = struct{}{}
+	return nil
+}
+
+// Increment 只累加到本批的视频增量中，返回 nil；EventHandler 不读取返回值。
+func (p *batchProjection) Increment(_ context.Context, _ txmanager.Session, videoID uuid.UUID, delta repositories.StatsDelta) (*po.VideoEngagementStatsProjection, error) {
+	total, ok := p.totals[videoID]
+	if !ok {
+		total = &repositories.StatsDelta{}
+		p.totals[videoID] = total
+	}
+	mergeStatsDelta(total, delta)
+	return nil, nil
+}
+
+func (p *batchProjection) MarkWatcher(ctx context.Context, sess txmanager.Session, videoID, userID uuid.UUID, watchTime time.Time) (*po.VideoWatcherRecord, error) {
+	key := userVideoKey{UserID: userID, VideoID: videoID}
+	if record, ok := p.watchers[key]; ok {
+		if watchTime.After(record.LastWatchedAt) {
+			record.LastWatchedAt = watchTime
+			p.touched[key] = struct{}{}
+		}
+		copied := *record
+		copied.Inserted = false
+		return &copied, nil
+	}
+	record, err := p.stats.MarkWatcher(ctx, sess, videoID, userID, watchTime)
+	if err != nil {
+		return nil, err
+	}
+	cached := *record
+	p.watchers[key] = &cached
+	return record, nil
+}
+
+func (p *batchProjection) GetViewSession(ctx context.Context, sess txmanager.Session, videoID, userID uuid.UUID) (*po.VideoViewSession, error) {
+	key := userVideoKey{UserID: userID, VideoID: videoID}
+	session, ok := p.sessions[key]
+	if !ok {
+		loaded, err := p.stats.GetViewSession(ctx, sess, videoID, userID)
+		if err != nil {
+			return nil, err
+		}
+		p.sessions[key] = loaded
+		session = loaded
+	}
+	if session == nil {
+		return nil, nil
+	}
+	copied := *session
+	return &copied, nil
+}
+
+func (p *batchProjection) SaveViewSession(_ context.Context, _ txmanager.Session, session *po.VideoViewSession) error {
+	key := userVideoKey{UserID: session.UserID, VideoID: session.VideoID}
+	copied := *session
+	p.sessions[key] = &copied
+	p.dirtySessions[key] = struct{}{}
+	return nil
+}
+
+// IncrementRollups 按 (视频, UTC 小时) 累加本批的分时统计增量。
+func (p *batchProjection) IncrementRollups(_ context.Context, _ txmanager.Session, videoID uuid.UUID, at time.Time, delta repositories.StatsDelta) error {
+	key := rollupKey{VideoID: videoID, Hour: at.UTC().Truncate(time.Hour)}
+	total, ok := p.rollups[key]
+	if !ok {
+		total = &repositories.StatsDelta{}
+		p.rollups[key] = total
+	}
+	mergeStatsDelta(total, delta)
+	return nil
+}
+
+// flush 将本批合并后的写入落库，并按主键排序，使并发实例以相同顺序加锁。
+func (p *batchProjection) flush(ctx context.Context, sess txmanager.Session) error {
+	stateKeys := sortedKeys(p.dirtyStates)
+	upserts := make([]repositories.UpsertVideoUserStateInput, 0, len(stateKeys))
+	for _, key := range stateKeys {
+		state := p.states[key]
+		upserts = append(upserts, repositories.UpsertVideoUserStateInput{
+			UserID:               state.UserID,
+			VideoID:              state.VideoID,
+			HasLiked:             state.HasLiked,
+			HasBookmarked:        state.HasBookmarked,
+			LikedOccurredAt:      state.LikedOccurredAt,
+			BookmarkedOccurredAt: state.BookmarkedOccurredAt,
+			LikedSequence:        state.LikedSequence,
+			BookmarkedSequence:   state.BookmarkedSequence,
+			Rating:               state.Rating,
+			RatedOccurredAt:      state.RatedOccurredAt,
+			RatedSequence:        state.RatedSequence,
+		})
+	}
+	if err := p.users.UpsertBatch(ctx, sess, upserts); err != nil {
+		return err
+	}
+
+	var batch repositories.StatsBatch
+	videoIDs := make([]uuid.UUID, 0, len(p.totals))
+	for videoID := range p.totals {
+		videoIDs = append(videoIDs, videoID)
+	}
+	sort.Slice(videoIDs, func(i, j int) bool { return bytes.Compare(videoIDs[i][:], videoIDs[j][:]) < 0 })
+	for _, videoID := range videoIDs {
+		delta := *p.totals[videoID]
+		delta.ShardKey = p.shardKeyFor(videoID)
+		batch.Stats = append(batch.Stats, repositories.VideoStatsDelta{VideoID: videoID, Delta: delta})
+	}
+	rollupKeys := make([]rollupKey, 0, len(p.rollups))
+	for key := range p.rollups {
+		rollupKeys = append(rollupKeys, key)
+	}
+	sort.Slice(rollupKeys, func(i, j int) bool {
+		if c := bytes.Compare(rollupKeys[i].VideoID[:], rollupKeys[j].VideoID[:]); c != 0 {
+			return c < 0
+		}
+		return rollupKeys[i].Hour.Before(rollupKeys[j].Hour)
+	})
+	for _, key := range rollupKeys {
+		delta := *p.rollups[key]
+		delta.ShardKey = p.shardKeyFor(key.VideoID)
+		batch.Rollups = append(batch.Rollups, repositories.VideoStatsDelta{VideoID: key.VideoID, At: key.Hour, Delta: delta})
+	}
+	for _, key := range sortedKeys(p.dirtySessions) {
+		batch.Sessions = append(batch.Sessions, p.sessions[key])
+	}
+	for _, key := range sortedKeys(p.touched) {
+		batch.Watchers = append(batch.Watchers, p.watchers[key])
+	}
+	return p.stats.ApplyBatch(ctx, sess, batch)
+}
+
+// mergeStatsDelta 将 delta 合并进 total：计数相加，首次/最近观看时间分别取最小/最大值；分片键由 flush 按视频计算。
+func mergeStatsDelta(total *repositories.StatsDelta, delta repositories.StatsDelta) {
+	total.LikeDelta += delta.LikeDelta
+	total.BookmarkDelta += delta.BookmarkDelta
+	total.WatchDelta += delta.WatchDelta
+	total.UniqueWatcherDelta += delta.UniqueWatcherDelta
+	total.WatchSecondsDelta += delta.WatchSecondsDelta
+	total.PositionSecondsDelta += delta.PositionSecondsDelta
+	total.PositionViewerDelta += delta.PositionViewerDelta
+	total.ShareDelta += delta.ShareDelta
+	total.RatingCountDelta += delta.RatingCountDelta
+	total.RatingSumDelta += delta.RatingSumDelta
+	for i := range total.RatingDistributionDelta {
+		total.RatingDistributionDelta[i] += delta.RatingDistributionDelta[i]
+	}
+	if delta.FirstWatchAt != nil && (total.FirstWatchAt == nil || delta.FirstWatchAt.Before(*total.FirstWatchAt)) {
+		total.FirstWatchAt = cloneTime(delta.FirstWatchAt)
+	}
+	if delta.LastWatchAt != nil && (total.LastWatchAt == nil || delta.LastWatchAt.After(*total.LastWatchAt)) {
+		total.LastWatchAt = cloneTime(delta.LastWatchAt)
+	}
+}
+
+func sortedKeys(set map[userVideoKey]struct{}) []userVideoKey {
+	keys := make([]userVideoKey, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if c := bytes.Compare(keys[i].VideoID[:], keys[j].VideoID[:]); c != 0 {
+			return c < 0
+		}
+		return bytes.Compare(keys[i].UserID[:], keys[j].UserID[:]) < 0
+	})
+	return keys
+}
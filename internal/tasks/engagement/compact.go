@@ -0,0 +1,121 @@
+package engagement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// CompactPolicy 描述分片计数的折叠周期与每个事务折叠的分片行数；Interval 为 0 表示不折叠。
+type CompactPolicy struct {
+	Interval  time.Duration
+	BatchSize int
+}
+
+// NewCompactPolicy 将配置映射为 CompactPolicy；未开启分片（shards <= 1）时不折叠。
+func NewCompactPolicy(cfg configloader.CountersConfig) CompactPolicy {
+	if cfg.Shards <= 1 {
+		return CompactPolicy{}
+	}
+	return CompactPolicy{
+		Interval:  cfg.CompactInterval,
+		BatchSize: int(cfg.CompactBatchSize),
+	}
+}
+
+// shardCompactStore 定义分片计数折叠所需的仓储接口。
+type shardCompactStore interface {
+	CompactShards(ctx context.Context, sess txmanager.Session, limit int) (int, int64, error)
+}
+
+var _ shardCompactStore = (*repositories.VideoEngagementStatsRepository)(nil)
+
+// StatsCompactor 定期将分片计数行折叠回视频主行，控制分片表规模并让主行保持近实时。
+// 读取始终汇总主行与分片，折叠前后读到的计数一致。
+type StatsCompactor struct {
+	store     shardCompactStore
+	txManager txmanager.Manager
+	policy    CompactPolicy
+	log       *log.Helper
+}
+
+// NewStatsCompactor 构造 StatsCompactor。
+func NewStatsCompactor(store shardCompactStore, tx txmanager.Manager, policy CompactPolicy, logger log.Logger) (*StatsCompactor, error) {
+	if store == nil {
+		return nil, fmt.Errorf("engagement compact: stats repository is required")
+	}
+	if tx == nil {
+		return nil, fmt.Errorf("engagement compact: tx manager is required")
+	}
+	if policy.BatchSize <= 0 {
+		return nil, fmt.Errorf("engagement compact: batch_size must be positive")
+	}
+	if logger == nil {
+		logger = log.DefaultLogger
+	}
+	return &StatsCompactor{
+		store:     store,
+		txManager: tx,
+		policy:    policy,
+		log:       log.NewHelper(logger),
+	}, nil
+}
+
+// CompactOnce 分批折叠当前可取得的分片行，直到某一批不足 BatchSize，返回折叠的分片行总数。
+func (c *StatsCompactor) CompactOnce(ctx context.Context) (int64, error) {
+	var (
+		total  int64
+		videos int
+	)
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+		var (
+			batchVideos int
+			folded      int64
+		)
+		err := c.txManager.WithinTx(ctx, txmanager.TxOptions{}, func(txCtx context.Context, sess txmanager.Session) error {
+			var err error
+			batchVideos, folded, err = c.store.CompactShards(txCtx, sess, c.policy.BatchSize)
+			return err
+		})
+		if err != nil {
+			return total, fmt.Errorf("engagement compact: %w", err)
+		}
+		total += folded
+		videos += batchVideos
+		if folded < int64(c.policy.BatchSize) {
+			break
+		}
+	}
+	if total > 0 {
+		c.log.WithContext(ctx).Debugf("engagement stats shards compacted: videos=%d shard_rows=%d", videos, total)
+	}
+	return total, nil
+}
+
+// Run 启动时折叠一次，之后每个 Interval 折叠一次，直到 ctx 结束；单次失败只记录日志。
+func (c *StatsCompactor) Run(ctx context.Context) {
+	if c.policy.Interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(c.policy.Interval)
+	defer ticker.Stop()
+	for {
+		if _, err := c.CompactOnce(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			c.log.WithContext(ctx).Warnf("engagement stats compaction failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -6,8 +6,10 @@ import (
 	"strings"
 	"time"
 
+	profilecontractv1 "github.com/bionicotaku/lingo-services-catalog/api/contracts/profile/v1"
 	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
 	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/quarantine"
 	profilev1 "github.com/bionicotaku/lingo-services-profile/api/profile/v1"
 	"github.com/bionicotaku/lingo-utils/outbox/store"
 	"github.com/bionicotaku/lingo-utils/txmanager"
@@ -22,15 +24,20 @@ import (
 type EventHandler struct {
 	repo    videoUserStatesStore
 	stats   videoEngagementStatsStore
+	purges  engagementPurgeStore
+	views   ViewPolicy
 	log     *log.Helper
 	metrics *metrics
 }
 
-// NewEventHandler 构造 Engagement Event 处理器。
-func NewEventHandler(repo videoUserStatesStore, stats videoEngagementStatsStore, logger log.Logger, metrics *metrics) *EventHandler {
+// NewEventHandler 构造 Engagement Event 处理器；views 决定 watch.progressed 事件何时计为一次有效播放。
+// purges 为空时不处理视频删除/归档与用户删除事件，也不检查清理墓碑。
+func NewEventHandler(repo videoUserStatesStore, stats videoEngagementStatsStore, purges engagementPurgeStore, views ViewPolicy, logger log.Logger, metrics *metrics) *EventHandler {
 	return &EventHandler{
 		repo:    repo,
 		stats:   stats,
+		purges:  purges,
+		views:   views,
 		log:     log.NewHelper(logger),
 		metrics: metrics,
 	}
@@ -48,11 +55,15 @@ func (h *EventHandler) Handle(ctx context.Context, sess txmanager.Session, evt *
 	eventType := strings.TrimSpace(inboxEvt.EventType)
 	switch eventType {
 	case "profile.engagement.added":
-		return h.handleEngagementMutation(ctx, sess, evt.Payload, actionAdded)
+		return h.handleEngagementMutation(ctx, sess, evt.Payload, inboxEvt, actionAdded)
 	case "profile.engagement.removed":
-		return h.handleEngagementMutation(ctx, sess, evt.Payload, actionRemoved)
+		return h.handleEngagementMutation(ctx, sess, evt.Payload, inboxEvt, actionRemoved)
 	case "profile.watch.progressed":
-		return h.handleWatchProgress(ctx, sess, evt.Payload)
+		return h.handleWatchProgress(ctx, sess, evt.Payload, inboxEvt)
+	case "catalog.video.deleted", "catalog.video.updated":
+		return h.handleVideoLifecycle(ctx, sess, evt.Payload, inboxEvt)
+	case "profile.user.deleted":
+		return h.handleUserDeleted(ctx, sess, evt.Payload, inboxEvt)
 	default:
 		h.log.WithContext(ctx).Debugf("engagement: skip unsupported event type %s", eventType)
 		return nil
@@ -73,9 +84,23 @@ const (
 	kindUnknown  engagementKind = ""
 	kindLike     engagementKind = "like"
 	kindBookmark engagementKind = "bookmark"
+	kindShare    engagementKind = "share"
+	kindRating   engagementKind = "rating"
 )
 
-func (h *EventHandler) handleEngagementMutation(ctx context.Context, sess txmanager.Session, payload []byte, action actionType) error {
+// 评分星级的取值范围。
+const (
+	minRating = 1
+	maxRating = 5
+)
+
+// 点赞/收藏事件的排序依据，用作乱序指标的 ordering 属性。
+const (
+	orderingSequence   = "sequence"
+	orderingOccurredAt = "occurred_at"
+)
+
+func (h *EventHandler) handleEngagementMutation(ctx context.Context, sess txmanager.Session, payload []byte, inboxEvt *store.InboxEvent, action actionType) error {
 	if len(payload) == 0 {
 		if h.metrics != nil {
 			h.metrics.recordFailure(ctx)
@@ -85,29 +110,33 @@ func (h *EventHandler) handleEngagementMutation(ctx context.Context, sess txmana
 
 	switch action {
 	case actionAdded:
-		var msg profilev1.EngagementAddedEvent
+		var msg profilecontractv1.EngagementAddedEvent
 		if err := proto.Unmarshal(payload, &msg); err != nil {
 			if h.metrics != nil {
 				h.metrics.recordFailure(ctx)
 			}
-			return fmt.Errorf("engagement: unmarshal added event: %w", err)
+			return quarantine.Permanent(classInvalidPayload, fmt.Errorf("engagement: unmarshal added event: %w", err))
 		}
-		return h.applyEngagement(ctx, sess, msg.GetUserId(), msg.GetVideoId(), msg.GetFavoriteType(), msg.GetOccurredAt(), actionAdded)
+		return h.applyEngagement(ctx, sess, msg.GetUserId(), msg.GetVideoId(), msg.GetFavoriteType(), msg.GetOccurredAt(), profileEventSequence(msg.GetSequence()), profileEventRating(msg.GetFavoriteType(), msg.GetRating()), inboxEvt.ReceivedAt, actionAdded)
 	case actionRemoved:
-		var msg profilev1.EngagementRemovedEvent
+		var msg profilecontractv1.EngagementRemovedEvent
 		if err := proto.Unmarshal(payload, &msg); err != nil {
 			if h.metrics != nil {
 				h.metrics.recordFailure(ctx)
 			}
-			return fmt.Errorf("engagement: unmarshal removed event: %w", err)
+			return quarantine.Permanent(classInvalidPayload, fmt.Errorf("engagement: unmarshal removed event: %w", err))
 		}
-		return h.applyEngagement(ctx, sess, msg.GetUserId(), msg.GetVideoId(), msg.GetFavoriteType(), msg.GetOccurredAt(), actionRemoved)
+		return h.applyEngagement(ctx, sess, msg.GetUserId(), msg.GetVideoId(), msg.GetFavoriteType(), msg.GetOccurredAt(), profileEventSequence(msg.GetSequence()), nil, inboxEvt.ReceivedAt, actionRemoved)
 	default:
 		return nil
 	}
 }
 
-func (h *EventHandler) applyEngagement(ctx context.Context, sess txmanager.Session, userIDRaw, videoIDRaw string, favorite profilev1.FavoriteType, ts *timestamppb.Timestamp, action actionType) error {
+// applyEngagement 按 (user, video, kind) 应用点赞/收藏/分享/评分变更；seq 为 Profile 分配的事件序号，未携带时为 nil；
+// stars 为评分事件携带的星级，仅 added 评分事件需要。
+// 双方都带序号时按序号判定新旧，否则退回比较发生时间，详见 isNewerEvent。
+// 尚未支持的 favorite_type 与超出 1-5 的评分记录指标后跳过，不阻塞后续事件。
+func (h *EventHandler) applyEngagement(ctx context.Context, sess txmanager.Session, userIDRaw, videoIDRaw string, favorite profilecontractv1.FavoriteType, ts *timestamppb.Timestamp, seq *int64, stars *int32, receivedAt time.Time, action actionType) error {
 	kind, err := convertFavoriteType(favorite)
 	if err != nil {
 		if h.metrics != nil {
@@ -115,6 +144,20 @@ func (h *EventHandler) applyEngagement(ctx context.Context, sess txmanager.Sessi
 		}
 		return errors.BadRequest("invalid-favorite-type", err.Error())
 	}
+	if kind == kindUnknown {
+		h.log.WithContext(ctx).Warnf("skip unknown favorite type: user=%s video=%s favorite=%v", userIDRaw, videoIDRaw, favorite)
+		if h.metrics != nil {
+			h.metrics.recordUnknownKind(ctx, favorite)
+		}
+		return nil
+	}
+	if kind == kindRating && action == actionAdded && (stars == nil || *stars < minRating || *stars > maxRating) {
+		h.log.WithContext(ctx).Warnf("skip invalid rating: user=%s video=%s rating=%v", userIDRaw, videoIDRaw, derefInt32(stars))
+		if h.metrics != nil {
+			h.metrics.recordInvalidRating(ctx)
+		}
+		return nil
+	}
 
 	userID, err := uuid.Parse(strings.TrimSpace(userIDRaw))
 	if err != nil {
@@ -125,7 +168,28 @@ func (h *EventHandler) applyEngagement(ctx context.Context, sess txmanager.Sessi
 		return errors.BadRequest("invalid-video-id", "invalid video_id")
 	}
 
-	occurredAt := timestampToTime(ts)
+	occurredAt, ok := eventTime(ts, receivedAt)
+	if !ok {
+		if h.metrics != nil {
+			h.metrics.recordFailure(ctx)
+		}
+		return errors.BadRequest("missing-occurred-at", "occurred_at missing")
+	}
+
+	purged, err := h.purgedBefore(ctx, sess, userID, videoID, occurredAt)
+	if err != nil {
+		if h.metrics != nil {
+			h.metrics.recordFailure(ctx)
+		}
+		return err
+	}
+	if purged {
+		h.log.WithContext(ctx).Debugf("skip engagement event for purged user or video: user=%s video=%s", userID, videoID)
+		return nil
+	}
+	if kind == kindShare {
+		return h.applyShare(ctx, sess, userID, videoID, occurredAt, action)
+	}
 
 	state, repoErr := h.repo.Get(ctx, sess, userID, videoID)
 	if repoErr != nil {
@@ -139,44 +203,77 @@ func (h *EventHandler) applyEngagement(ctx context.Context, sess txmanager.Sessi
 	hasBookmarked := state != nil && state.HasBookmarked
 	prevLiked := hasLiked
 	prevBookmarked := hasBookmarked
-	var likeDelta int64
-	var bookmarkDelta int64
+	var delta repositories.StatsDelta
 	var likedAt *time.Time
 	var bookmarkedAt *time.Time
+	var likedSeq *int64
+	var bookmarkedSeq *int64
+	var rating *int32
+	var ratedAt *time.Time
+	var ratedSeq *int64
 	if state != nil {
 		likedAt = cloneTime(state.LikedOccurredAt)
 		bookmarkedAt = cloneTime(state.BookmarkedOccurredAt)
+		likedSeq = cloneInt64(state.LikedSequence)
+		bookmarkedSeq = cloneInt64(state.BookmarkedSequence)
+		rating = cloneInt32(state.Rating)
+		ratedAt = cloneTime(state.RatedOccurredAt)
+		ratedSeq = cloneInt64(state.RatedSequence)
 	}
 
 	switch kind {
 	case kindLike:
-		if state != nil && state.LikedOccurredAt != nil && !occurredAt.After(state.LikedOccurredAt.UTC()) {
-			h.log.WithContext(ctx).Debugf("skip stale like event: user=%s video=%s", userID, videoID)
+		if newer, ordering := isNewerEvent(seq, occurredAt, likedSeq, likedAt); !newer {
+			h.log.WithContext(ctx).Debugf("skip stale like event: user=%s video=%s ordering=%s", userID, videoID, ordering)
+			if h.metrics != nil {
+				h.metrics.recordOutOfOrder(ctx, kind, ordering)
+			}
 			return nil
 		}
 		hasLiked = action == actionAdded
 		likedAt = &occurredAt
+		likedSeq = seq
 		if hasLiked != prevLiked {
 			if hasLiked {
-				likeDelta = 1
+				delta.LikeDelta = 1
 			} else {
-				likeDelta = -1
+				delta.LikeDelta = -1
 			}
 		}
 	case kindBookmark:
-		if state != nil && state.BookmarkedOccurredAt != nil && !occurredAt.After(state.BookmarkedOccurredAt.UTC()) {
-			h.log.WithContext(ctx).Debugf("skip stale bookmark event: user=%s video=%s", userID, videoID)
+		if newer, ordering := isNewerEvent(seq, occurredAt, bookmarkedSeq, bookmarkedAt); !newer {
+			h.log.WithContext(ctx).Debugf("skip stale bookmark event: user=%s video=%s ordering=%s", userID, videoID, ordering)
+			if h.metrics != nil {
+				h.metrics.recordOutOfOrder(ctx, kind, ordering)
+			}
 			return nil
 		}
 		hasBookmarked = action == actionAdded
 		bookmarkedAt = &occurredAt
+		bookmarkedSeq = seq
 		if hasBookmarked != prevBookmarked {
 			if hasBookmarked {
-				bookmarkDelta = 1
+				delta.BookmarkDelta = 1
 			} else {
-				bookmarkDelta = -1
+				delta.BookmarkDelta = -1
+			}
+		}
+	case kindRating:
+		if newer, ordering := isNewerEvent(seq, occurredAt, ratedSeq, ratedAt); !newer {
+			h.log.WithContext(ctx).Debugf("skip stale rating event: user=%s video=%s ordering=%s", userID, videoID, ordering)
+			if h.metrics != nil {
+				h.metrics.recordOutOfOrder(ctx, kind, ordering)
 			}
+			return nil
+		}
+		prevRating := rating
+		rating = nil
+		if action == actionAdded {
+			rating = cloneInt32(stars)
 		}
+		ratedAt = &occurredAt
+		ratedSeq = seq
+		applyRatingDelta(&delta, prevRating, rating)
 	default:
 		h.log.WithContext(ctx).Warnf("skip unknown favorite type: user=%s video=%s favorite=%v", userID, videoID, favorite)
 		return nil
@@ -189,6 +286,11 @@ func (h *EventHandler) applyEngagement(ctx context.Context, sess txmanager.Sessi
 		HasBookmarked:        hasBookmarked,
 		LikedOccurredAt:      likedAt,
 		BookmarkedOccurredAt: bookmarkedAt,
+		LikedSequence:        likedSeq,
+		BookmarkedSequence:   bookmarkedSeq,
+		Rating:               rating,
+		RatedOccurredAt:      ratedAt,
+		RatedSequence:        ratedSeq,
 	}
 	if err := h.repo.Upsert(ctx, sess, upsert); err != nil {
 		if h.metrics != nil {
@@ -197,11 +299,8 @@ func (h *EventHandler) applyEngagement(ctx context.Context, sess txmanager.Sessi
 		return err
 	}
 
-	if h.stats != nil && (likeDelta != 0 || bookmarkDelta != 0) {
-		if _, err := h.stats.Increment(ctx, sess, videoID, repositories.StatsDelta{
-			LikeDelta:     likeDelta,
-			BookmarkDelta: bookmarkDelta,
-		}); err != nil {
+	if h.stats != nil && delta != (repositories.StatsDelta{}) {
+		if err := h.applyStats(ctx, sess, userID, videoID, occurredAt, delta); err != nil {
 			if h.metrics != nil {
 				h.metrics.recordFailure(ctx)
 			}
@@ -215,6 +314,40 @@ func (h *EventHandler) applyEngagement(ctx context.Context, sess txmanager.Sessi
 	return nil
 }
 
+// applyShare 累加分享次数。分享不可撤销，也不写入用户状态，removed 事件直接跳过。
+func (h *EventHandler) applyShare(ctx context.Context, sess txmanager.Session, userID, videoID uuid.UUID, occurredAt time.Time, action actionType) error {
+	if action != actionAdded {
+		h.log.WithContext(ctx).Debugf("skip share removal: user=%s video=%s", userID, videoID)
+		return nil
+	}
+	if h.stats != nil {
+		if err := h.applyStats(ctx, sess, userID, videoID, occurredAt, repositories.StatsDelta{ShareDelta: 1}); err != nil {
+			if h.metrics != nil {
+				h.metrics.recordFailure(ctx)
+			}
+			return err
+		}
+	}
+	if h.metrics != nil {
+		h.metrics.recordSuccess(ctx, occurredAt, time.Now())
+	}
+	return nil
+}
+
+// applyRatingDelta 将用户评分从 prev 变为 next 折算为评分人数、星级之和与星级分布的增量。
+func applyRatingDelta(delta *repositories.StatsDelta, prev, next *int32) {
+	if prev != nil && *prev >= minRating && *prev <= maxRating {
+		delta.RatingCountDelta--
+		delta.RatingSumDelta -= int64(*prev)
+		delta.RatingDistributionDelta[*prev-minRating]--
+	}
+	if next != nil {
+		delta.RatingCountDelta++
+		delta.RatingSumDelta += int64(*next)
+		delta.RatingDistributionDelta[*next-minRating]++
+	}
+}
+
 func cloneTime(t *time.Time) *time.Time {
 	if t == nil {
 		return nil
@@ -223,6 +356,22 @@ func cloneTime(t *time.Time) *time.Time {
 	return &copied
 }
 
+func cloneInt64(v *int64) *int64 {
+	if v == nil {
+		return nil
+	}
+	copied := *v
+	return &copied
+}
+
+func cloneInt32(v *int32) *int32 {
+	if v == nil {
+		return nil
+	}
+	copied := *v
+	return &copied
+}
+
 // videoUserStatesStore 定义 Engagement Handler 所需的仓储接口。
 type videoUserStatesStore interface {
 	Get(ctx context.Context, sess txmanager.Session, userID, videoID uuid.UUID) (*po.VideoUserState, error)
@@ -234,11 +383,14 @@ var _ videoUserStatesStore = (*repositories.VideoUserStatesRepository)(nil)
 type videoEngagementStatsStore interface {
 	Increment(ctx context.Context, sess txmanager.Session, videoID uuid.UUID, delta repositories.StatsDelta) (*po.VideoEngagementStatsProjection, error)
 	MarkWatcher(ctx context.Context, sess txmanager.Session, videoID, userID uuid.UUID, watchTime time.Time) (*po.VideoWatcherRecord, error)
+	GetViewSession(ctx context.Context, sess txmanager.Session, videoID, userID uuid.UUID) (*po.VideoViewSession, error)
+	SaveViewSession(ctx context.Context, sess txmanager.Session, session *po.VideoViewSession) error
+	IncrementRollups(ctx context.Context, sess txmanager.Session, videoID uuid.UUID, at time.Time, delta repositories.StatsDelta) error
 }
 
 var _ videoEngagementStatsStore = (*repositories.VideoEngagementStatsRepository)(nil)
 
-func (h *EventHandler) handleWatchProgress(ctx context.Context, sess txmanager.Session, payload []byte) error {
+func (h *EventHandler) handleWatchProgress(ctx context.Context, sess txmanager.Session, payload []byte, inboxEvt *store.InboxEvent) error {
 	if h.stats == nil {
 		h.log.WithContext(ctx).Warn("engagement: stats repository not configured, skip watch.progressed event")
 		return nil
@@ -249,7 +401,7 @@ func (h *EventHandler) handleWatchProgress(ctx context.Context, sess txmanager.S
 		if h.metrics != nil {
 			h.metrics.recordFailure(ctx)
 		}
-		return fmt.Errorf("engagement: unmarshal watch progressed: %w", err)
+		return quarantine.Permanent(classInvalidPayload, fmt.Errorf("engagement: unmarshal watch progressed: %w", err))
 	}
 
 	userID, err := uuid.Parse(strings.TrimSpace(msg.GetUserId()))
@@ -261,37 +413,87 @@ func (h *EventHandler) handleWatchProgress(ctx context.Context, sess txmanager.S
 		return errors.BadRequest("invalid-video-id", "invalid video_id")
 	}
 
-	watchTime := time.Now().UTC()
+	// 观看时间依次取 last_watched_at、first_watched_at、occurred_at，最后退回 Inbox 接收时间。
+	ts := msg.GetOccurredAt()
 	if progress := msg.GetProgress(); progress != nil {
-		if ts := progress.GetLastWatchedAt(); ts != nil {
-			watchTime = ts.AsTime().UTC()
-		} else if ts := progress.GetFirstWatchedAt(); ts != nil {
-			watchTime = ts.AsTime().UTC()
+		if progress.GetLastWatchedAt() != nil {
+			ts = progress.GetLastWatchedAt()
+		} else if progress.GetFirstWatchedAt() != nil {
+			ts = progress.GetFirstWatchedAt()
+		}
+	}
+	watchTime, ok := eventTime(ts, inboxEvt.ReceivedAt)
+	if !ok {
+		if h.metrics != nil {
+			h.metrics.recordFailure(ctx)
 		}
+		return errors.BadRequest("missing-occurred-at", "watch time missing")
 	}
 
-	record, err := h.stats.MarkWatcher(ctx, sess, videoID, userID, watchTime)
+	purged, err := h.purgedBefore(ctx, sess, userID, videoID, watchTime)
 	if err != nil {
 		if h.metrics != nil {
 			h.metrics.recordFailure(ctx)
 		}
 		return err
 	}
+	if purged {
+		h.log.WithContext(ctx).Debugf("skip watch progress for purged user or video: user=%s video=%s", userID, videoID)
+		return nil
+	}
 
-	uniqueDelta := int64(0)
-	if record != nil && record.Inserted {
-		uniqueDelta = 1
+	current, err := h.stats.GetViewSession(ctx, sess, videoID, userID)
+	if err != nil {
+		if h.metrics != nil {
+			h.metrics.recordFailure(ctx)
+		}
+		return err
 	}
+	next, qualified := h.views.advance(current, videoID, userID, msg.GetProgress(), watchTime)
+	if next != nil {
+		if err := h.stats.SaveViewSession(ctx, sess, next); err != nil {
+			if h.metrics != nil {
+				h.metrics.recordFailure(ctx)
+			}
+			return err
+		}
+	}
+	if h.metrics != nil {
+		h.metrics.recordView(ctx, qualified)
+	}
+
+	// 观看时长与最大播放位置对每条进度事件累加，不受有效播放判定影响。
+	watchSeconds, positionSeconds, newViewer := watchDeltas(current, next)
 	delta := repositories.StatsDelta{
-		WatchDelta:         1,
-		UniqueWatcherDelta: uniqueDelta,
-		LastWatchAt:        &watchTime,
+		WatchSecondsDelta:    watchSeconds,
+		PositionSecondsDelta: positionSeconds,
+		PositionViewerDelta:  newViewer,
 	}
-	if uniqueDelta == 1 {
-		delta.FirstWatchAt = &watchTime
+	if qualified {
+		record, err := h.stats.MarkWatcher(ctx, sess, videoID, userID, watchTime)
+		if err != nil {
+			if h.metrics != nil {
+				h.metrics.recordFailure(ctx)
+			}
+			return err
+		}
+		delta.WatchDelta = 1
+		delta.LastWatchAt = &watchTime
+		if record != nil && record.Inserted {
+			delta.UniqueWatcherDelta = 1
+			delta.FirstWatchAt = &watchTime
+		}
+	} else {
+		h.log.WithContext(ctx).Debugf("watch progress not counted as view: user=%s video=%s", userID, videoID)
+		if delta == (repositories.StatsDelta{}) {
+			if h.metrics != nil {
+				h.metrics.recordSuccess(ctx, watchTime, time.Now())
+			}
+			return nil
+		}
 	}
 
-	if _, err := h.stats.Increment(ctx, sess, videoID, delta); err != nil {
+	if err := h.applyStats(ctx, sess, userID, videoID, watchTime, delta); err != nil {
 		if h.metrics != nil {
 			h.metrics.recordFailure(ctx)
 		}
@@ -304,24 +506,82 @@ func (h *EventHandler) handleWatchProgress(ctx context.Context, sess txmanager.S
 	return nil
 }
 
-func convertFavoriteType(ft profilev1.FavoriteType) (engagementKind, error) {
+// applyStats 更新累计统计，并将计数增量按事件发生时间 at 写入分时统计桶；开启分片计数时按 userID 选择分片。
+func (h *EventHandler) applyStats(ctx context.Context, sess txmanager.Session, userID, videoID uuid.UUID, at time.Time, delta repositories.StatsDelta) error {
+	delta.ShardKey = userID
+	if _, err := h.stats.Increment(ctx, sess, videoID, delta); err != nil {
+		return err
+	}
+	if delta.LikeDelta == 0 && delta.BookmarkDelta == 0 && delta.WatchDelta == 0 && delta.UniqueWatcherDelta == 0 {
+		return nil
+	}
+	return h.stats.IncrementRollups(ctx, sess, videoID, at, delta)
+}
+
+func convertFavoriteType(ft profilecontractv1.FavoriteType) (engagementKind, error) {
 	switch ft {
-	case profilev1.FavoriteType_FAVORITE_TYPE_LIKE:
+	case profilecontractv1.FavoriteType_FAVORITE_TYPE_LIKE:
 		return kindLike, nil
-	case profilev1.FavoriteType_FAVORITE_TYPE_BOOKMARK:
+	case profilecontractv1.FavoriteType_FAVORITE_TYPE_BOOKMARK:
 		return kindBookmark, nil
-	default:
+	case profilecontractv1.FavoriteType_FAVORITE_TYPE_SHARE:
+		return kindShare, nil
+	case profilecontractv1.FavoriteType_FAVORITE_TYPE_RATING:
+		return kindRating, nil
+	case profilecontractv1.FavoriteType_FAVORITE_TYPE_UNSPECIFIED:
 		return kindUnknown, fmt.Errorf("unsupported favorite_type=%v", ft)
+	default:
+		// Profile 后续新增的互动类型，由调用方跳过。
+		return kindUnknown, nil
+	}
+}
+
+// eventTime 返回事件发生时间；事件未携带时间时退回 Inbox 接收时间（重放时同样取自 inbox_events.received_at，结果可复现）。
+// 两者都缺失时返回 false，由调用方拒绝事件，而不是以处理时刻代替，以免打乱排序。
+func eventTime(ts *timestamppb.Timestamp, receivedAt time.Time) (time.Time, bool) {
+	if ts != nil {
+		if t := ts.AsTime().UTC(); !t.IsZero() && t.Unix() != 0 {
+			return t, true
+		}
+	}
+	if !receivedAt.IsZero() {
+		return receivedAt.UTC(), true
 	}
+	return time.Time{}, false
 }
 
-func timestampToTime(ts *timestamppb.Timestamp) time.Time {
-	if ts == nil {
-		return time.Now().UTC()
+// isNewerEvent 判断事件是否晚于投影中同类事件最近一次应用的版本：双方都带序号时比较序号，
+// 否则退回比较发生时间，且同一时刻视为过期。返回值 ordering 为本次判定依据。
+func isNewerEvent(seq *int64, occurredAt time.Time, appliedSeq *int64, appliedAt *time.Time) (bool, string) {
+	if seq != nil && appliedSeq != nil {
+		return *seq > *appliedSeq, orderingSequence
 	}
-	t := ts.AsTime().UTC()
-	if t.IsZero() {
-		return time.Now().UTC()
+	if appliedAt == nil {
+		return true, orderingOccurredAt
+	}
+	return occurredAt.After(appliedAt.UTC()), orderingOccurredAt
+}
+
+// profileEventSequence 将 Profile 互动事件的 sequence 转为可选序号：契约中 0 表示未携带，按缺失处理。
+func profileEventSequence(value int64) *int64 {
+	if value <= 0 {
+		return nil
+	}
+	return &value
+}
+
+// profileEventRating 返回评分事件携带的星级；非评分事件返回 nil。取值范围由调用方校验。
+func profileEventRating(favorite profilecontractv1.FavoriteType, rating int32) *int32 {
+	if favorite != profilecontractv1.FavoriteType_FAVORITE_TYPE_RATING {
+		return nil
+	}
+	return &rating
+}
+
+// derefInt32 便于日志输出可选值，nil 输出 0。
+func derefInt32(v *int32) int32 {
+	if v == nil {
+		return 0
 	}
-	return t
+	return *v
 }
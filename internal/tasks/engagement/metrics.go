@@ -4,6 +4,9 @@ import (
 	"context"
 	"time"
 
+	profilecontractv1 "github.com/bionicotaku/lingo-services-catalog/api/contracts/profile/v1"
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
@@ -12,8 +15,15 @@ import (
 const meterName = "lingo-services-catalog.engagement"
 
 type metrics struct {
-	applyCounter metric.Int64Counter
-	lagHistogram metric.Int64Histogram
+	applyCounter   metric.Int64Counter
+	lagHistogram   metric.Int64Histogram
+	viewCounter    metric.Int64Counter
+	purgeCounter   metric.Int64Counter
+	batchHistogram metric.Int64Histogram
+	flushHistogram metric.Int64Histogram
+	staleCounter   metric.Int64Counter
+	unknownCounter metric.Int64Counter
+	invalidCounter metric.Int64Counter
 }
 
 func newMetrics() *metrics {
@@ -21,7 +31,24 @@ func newMetrics() *metrics {
 	m := provider.Meter(meterName)
 	applyCounter, _ := m.Int64Counter("catalog_engagement_apply_total")
 	lagHistogram, _ := m.Int64Histogram("catalog_engagement_event_lag_ms")
-	return &metrics{applyCounter: applyCounter, lagHistogram: lagHistogram}
+	viewCounter, _ := m.Int64Counter("catalog_engagement_watch_progress_total")
+	purgeCounter, _ := m.Int64Counter("catalog_engagement_purge_requests_total")
+	batchHistogram, _ := m.Int64Histogram("catalog_engagement_batch_events")
+	flushHistogram, _ := m.Int64Histogram("catalog_engagement_batch_duration_ms")
+	staleCounter, _ := m.Int64Counter("catalog_engagement_out_of_order_total")
+	unknownCounter, _ := m.Int64Counter("catalog_engagement_unknown_kind_total")
+	invalidCounter, _ := m.Int64Counter("catalog_engagement_invalid_rating_total")
+	return &metrics{
+		applyCounter:   applyCounter,
+		lagHistogram:   lagHistogram,
+		viewCounter:    viewCounter,
+		purgeCounter:   purgeCounter,
+		batchHistogram: batchHistogram,
+		flushHistogram: flushHistogram,
+		staleCounter:   staleCounter,
+		unknownCounter: unknownCounter,
+		invalidCounter: invalidCounter,
+	}
 }
 
 func (m *metrics) recordSuccess(ctx context.Context, occurred time.Time, now time.Time) {
@@ -35,9 +62,116 @@ func (m *metrics) recordSuccess(ctx context.Context, occurred time.Time, now tim
 	}
 }
 
+// recordView 按是否计为有效播放统计 watch.progressed 事件。
+func (m *metrics) recordView(ctx context.Context, qualified bool) {
+	if m == nil || m.viewCounter == nil {
+		return
+	}
+	m.viewCounter.Add(ctx, 1, metric.WithAttributes(attribute.Bool("qualified", qualified)))
+}
+
+// recordPurgeRequest 按触发原因统计登记的清理请求。
+func (m *metrics) recordPurgeRequest(ctx context.Context, reason string) {
+	if m == nil || m.purgeCounter == nil {
+		return
+	}
+	m.purgeCounter.Add(ctx, 1, metric.WithAttributes(attribute.String("reason", reason)))
+}
+
+// recordBatch 记录批量消费每批的消息数、实际应用（非重复）的事件数与事务耗时。
+func (m *metrics) recordBatch(ctx context.Context, received, applied int, elapsed time.Duration) {
+	if m == nil || m.batchHistogram == nil {
+		return
+	}
+	m.batchHistogram.Record(ctx, int64(received), metric.WithAttributes(attribute.String("kind", "received")))
+	m.batchHistogram.Record(ctx, int64(applied), metric.WithAttributes(attribute.String("kind", "applied")))
+	if m.flushHistogram != nil {
+		m.flushHistogram.Record(ctx, elapsed.Milliseconds())
+	}
+}
+
+// recordOutOfOrder 统计因晚于投影已应用事件到达而被跳过的点赞/收藏事件；ordering 为判定依据（sequence 或 occurred_at）。
+func (m *metrics) recordOutOfOrder(ctx context.Context, kind engagementKind, ordering string) {
+	if m == nil || m.staleCounter == nil {
+		return
+	}
+	m.staleCounter.Add(ctx, 1, metric.WithAttributes(attribute.String("kind", string(kind)), attribute.String("ordering", ordering)))
+}
+
+// recordUnknownKind 统计因 favorite_type 尚未支持而被跳过的互动事件，便于发现 Profile 新增的互动类型。
+func (m *metrics) recordUnknownKind(ctx context.Context, favorite profilecontractv1.FavoriteType) {
+	if m == nil || m.unknownCounter == nil {
+		return
+	}
+	m.unknownCounter.Add(ctx, 1, metric.WithAttributes(attribute.Int("favorite_type", int(favorite))))
+}
+
+// recordInvalidRating 统计因星级超出 1-5 而被跳过的评分事件。
+func (m *metrics) recordInvalidRating(ctx context.Context) {
+	if m == nil || m.invalidCounter == nil {
+		return
+	}
+	m.invalidCounter.Add(ctx, 1)
+}
+
 func (m *metrics) recordFailure(ctx context.Context) {
 	if m == nil || m.applyCounter == nil {
 		return
 	}
 	m.applyCounter.Add(ctx, 1, metric.WithAttributes(attribute.String("result", "failure")))
 }
+
+type reconcileMetrics struct {
+	driftCounter    metric.Int64Counter
+	repairedCounter metric.Int64Counter
+}
+
+func newReconcileMetrics() *reconcileMetrics {
+	m := otel.GetMeterProvider().Meter(meterName)
+	driftCounter, _ := m.Int64Counter("catalog_engagement_stats_drift_total")
+	repairedCounter, _ := m.Int64Counter("catalog_engagement_stats_repaired_total")
+	return &reconcileMetrics{driftCounter: driftCounter, repairedCounter: repairedCounter}
+}
+
+// recordDrift 按字段累计偏差绝对值。
+func (m *reconcileMetrics) recordDrift(ctx context.Context, like, bookmark, uniqueWatchers int64) {
+	if m == nil || m.driftCounter == nil {
+		return
+	}
+	m.driftCounter.Add(ctx, like, metric.WithAttributes(attribute.String("field", "like_count")))
+	m.driftCounter.Add(ctx, bookmark, metric.WithAttributes(attribute.String("field", "bookmark_count")))
+	m.driftCounter.Add(ctx, uniqueWatchers, metric.WithAttributes(attribute.String("field", "unique_watchers")))
+}
+
+func (m *reconcileMetrics) recordRepaired(ctx context.Context, count int) {
+	if m == nil || m.repairedCounter == nil {
+		return
+	}
+	m.repairedCounter.Add(ctx, int64(count))
+}
+
+type purgeMetrics struct {
+	rowsCounter metric.Int64Counter
+}
+
+func newPurgeMetrics() *purgeMetrics {
+	m := otel.GetMeterProvider().Meter(meterName)
+	rowsCounter, _ := m.Int64Counter("catalog_engagement_purged_rows_total")
+	return &purgeMetrics{rowsCounter: rowsCounter}
+}
+
+// recordRemoved 按清理范围与表名累计删除的投影行数。
+func (m *purgeMetrics) recordRemoved(ctx context.Context, scope po.EngagementPurgeScope, removed []repositories.PurgedRows) {
+	if m == nil || m.rowsCounter == nil {
+		return
+	}
+	for _, item := range removed {
+		if item.Rows == 0 {
+			continue
+		}
+		m.rowsCounter.Add(ctx, item.Rows, metric.WithAttributes(
+			attribute.String("scope", string(scope)),
+			attribute.String("table", item.Table),
+		))
+	}
+}
@@ -0,0 +1,52 @@
+package engagement
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-utils/outbox/inbox"
+	"github.com/bionicotaku/lingo-utils/outbox/store"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+)
+
+// projectionLockName 为 Engagement 投影的 advisory 锁名：重放持有排他锁，线上消费在事务内持有共享锁。
+const projectionLockName = "catalog.engagement.projection"
+
+// errReplayInProgress 表示投影正在重放，本次消费放弃，消息稍后由 Pub/Sub 重投。
+var errReplayInProgress = errors.New("engagement: projection replay in progress")
+
+// projectionGate 为线上消费提供与重放互斥的事务级共享锁。
+type projectionGate interface {
+	TryLockSharedTx(ctx context.Context, sess txmanager.Session, name string) (bool, error)
+}
+
+var _ projectionGate = (*repositories.AdvisoryLockRepository)(nil)
+
+// enterProjection 在消费事务内获取投影共享锁；重放持有或等待排他锁时返回 errReplayInProgress。
+func enterProjection(ctx context.Context, sess txmanager.Session, gate projectionGate) error {
+	if gate == nil || sess == nil {
+		return nil
+	}
+	acquired, err := gate.TryLockSharedTx(ctx, sess, projectionLockName)
+	if err != nil {
+		return err
+	}
+	if !acquired {
+		return errReplayInProgress
+	}
+	return nil
+}
+
+// gatedHandler 在调用内层处理器前先进入投影共享锁，保证重放期间线上 Runner 不写投影。
+type gatedHandler struct {
+	inner inbox.Handler[Event]
+	gate  projectionGate
+}
+
+func (h gatedHandler) Handle(ctx context.Context, sess txmanager.Session, evt *Event, inboxEvt *store.InboxEvent) error {
+	if err := enterProjection(ctx, sess, h.gate); err != nil {
+		return err
+	}
+	return h.inner.Handle(ctx, sess, evt, inboxEvt)
+}
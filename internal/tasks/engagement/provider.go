@@ -3,6 +3,7 @@ package engagement
 import (
 	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
 	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/quarantine"
 	"github.com/bionicotaku/lingo-utils/gcpubsub"
 	outboxcfg "github.com/bionicotaku/lingo-utils/outbox/config"
 	"github.com/bionicotaku/lingo-utils/txmanager"
@@ -13,10 +14,21 @@ import (
 func ProvideRunner(
 	userRepo *repositories.VideoUserStatesRepository,
 	statsRepo *repositories.VideoEngagementStatsRepository,
+	purgeRepo *repositories.EngagementPurgeRepository,
 	inboxRepo *repositories.InboxRepository,
+	quarantineRepo *repositories.InboxQuarantineRepository,
+	lockRepo *repositories.AdvisoryLockRepository,
 	tx txmanager.Manager,
 	sub configloader.EngagementSubscriber,
+	videoSub configloader.VideoEventsSubscriber,
+	userSub configloader.ProfileUserSubscriber,
 	outboxCfg outboxcfg.Config,
+	views configloader.ViewQualificationConfig,
+	rollups configloader.RollupRetentionConfig,
+	trending configloader.TrendingConfig,
+	purge configloader.PurgeConfig,
+	counters configloader.CountersConfig,
+	batch configloader.BatchConfig,
 	logger log.Logger,
 ) *Runner {
 	realSub := gcpubsub.Subscriber(sub)
@@ -24,13 +36,24 @@ func ProvideRunner(
 		return nil
 	}
 	runner, err := NewRunner(RunnerParams{
-		Subscriber: realSub,
-		InboxRepo:  inboxRepo,
-		UserRepo:   userRepo,
-		StatsRepo:  statsRepo,
-		TxManager:  tx,
-		Logger:     logger,
-		Config:     outboxCfg.Inbox,
+		Subscriber:      realSub,
+		VideoSubscriber: gcpubsub.Subscriber(videoSub),
+		UserSubscriber:  gcpubsub.Subscriber(userSub),
+		InboxRepo:       inboxRepo,
+		UserRepo:        userRepo,
+		StatsRepo:       statsRepo,
+		PurgeRepo:       purgeRepo,
+		QuarantineRepo:  quarantineRepo,
+		LockRepo:        lockRepo,
+		Views:           NewViewPolicy(views),
+		Rollups:         NewRollupRetention(rollups),
+		Trending:        NewTrendingPolicy(trending),
+		Purge:           NewPurgePolicy(purge),
+		Compact:         NewCompactPolicy(counters),
+		Batch:           NewBatchPolicy(batch),
+		TxManager:       tx,
+		Logger:          logger,
+		Config:          outboxCfg.Inbox,
 	})
 	if err != nil {
 		log.NewHelper(logger).Errorw("msg", "init engagement runner failed", "error", err)
@@ -38,3 +61,56 @@ func ProvideRunner(
 	}
 	return runner
 }
+
+// ProvideReplayer 装配 Engagement 投影重放器。
+func ProvideReplayer(
+	userRepo *repositories.VideoUserStatesRepository,
+	statsRepo *repositories.VideoEngagementStatsRepository,
+	replayRepo *repositories.EngagementReplayRepository,
+	purgeRepo *repositories.EngagementPurgeRepository,
+	lockRepo *repositories.AdvisoryLockRepository,
+	tx txmanager.Manager,
+	views configloader.ViewQualificationConfig,
+	logger log.Logger,
+) (*Replayer, error) {
+	return NewReplayer(ReplayerParams{
+		Store:     replayRepo,
+		Locker:    lockRepo,
+		UserRepo:  userRepo,
+		StatsRepo: statsRepo,
+		Purges:    purgeRepo,
+		Views:     NewViewPolicy(views),
+		TxManager: tx,
+		Logger:    logger,
+	})
+}
+
+// ProvideReconciler 装配 Engagement 统计对账器。
+func ProvideReconciler(
+	statsRepo *repositories.VideoEngagementStatsRepository,
+	tx txmanager.Manager,
+	logger log.Logger,
+) (*Reconciler, error) {
+	return NewReconciler(statsRepo, tx, logger)
+}
+
+// ProvideQuarantineAdmin 装配 Engagement 隔离事件运维入口，重放时直接使用未装饰的 EventHandler。
+func ProvideQuarantineAdmin(
+	userRepo *repositories.VideoUserStatesRepository,
+	statsRepo *repositories.VideoEngagementStatsRepository,
+	purgeRepo *repositories.EngagementPurgeRepository,
+	quarantineRepo *repositories.InboxQuarantineRepository,
+	tx txmanager.Manager,
+	views configloader.ViewQualificationConfig,
+	logger log.Logger,
+) (*quarantine.Admin, error) {
+	handler := NewEventHandler(userRepo, statsRepo, purgeRepo, NewViewPolicy(views), logger, nil)
+	return quarantine.NewAdmin(quarantine.AdminParams{
+		Consumer:  QuarantineConsumer,
+		Store:     quarantineRepo,
+		TxManager: tx,
+		Replay:    quarantine.Replayer[Event](newEventDecoder(), handler),
+		Decode:    DescribePayload,
+		Logger:    logger,
+	})
+}
@@ -0,0 +1,298 @@
+package engagement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	profilecontractv1 "github.com/bionicotaku/lingo-services-catalog/api/contracts/profile/v1"
+	videov1 "github.com/bionicotaku/lingo-services-catalog/api/video/v1"
+	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/quarantine"
+	"github.com/bionicotaku/lingo-utils/outbox/store"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	purgeReasonVideoDeleted  = "video.deleted"
+	purgeReasonVideoArchived = "video.archived"
+	purgeReasonUserDeleted   = "user.deleted"
+
+	// purgeJobsPerTick 限制每轮扫描处理的清理请求数，剩余请求留到下一轮。
+	purgeJobsPerTick = 50
+)
+
+// engagementPurgeStore 定义 EventHandler 登记清理请求与查询清理墓碑所需的接口。
+type engagementPurgeStore interface {
+	RequestPurge(ctx context.Context, sess txmanager.Session, input repositories.RequestPurgeInput) error
+	GetPurgeCutoff(ctx context.Context, sess txmanager.Session, userID, videoID uuid.UUID) (*time.Time, error)
+}
+
+var _ engagementPurgeStore = (*repositories.EngagementPurgeRepository)(nil)
+
+// handleVideoLifecycle 处理 catalog 自身的视频事件：删除或归档（status=archived）时登记按视频清理，其余更新忽略。
+func (h *EventHandler) handleVideoLifecycle(ctx context.Context, sess txmanager.Session, payload []byte, inboxEvt *store.InboxEvent) error {
+	if h.purges == nil {
+		h.log.WithContext(ctx).Warn("engagement: purge repository not configured, skip video lifecycle event")
+		return nil
+	}
+
+	var msg videov1.Event
+	if err := proto.Unmarshal(payload, &msg); err != nil {
+		if h.metrics != nil {
+			h.metrics.recordFailure(ctx)
+		}
+		return quarantine.Permanent(classInvalidPayload, fmt.Errorf("engagement: unmarshal video event: %w", err))
+	}
+
+	var videoRaw, occurredRaw, reason string
+	switch {
+	case msg.GetDeleted() != nil:
+		deleted := msg.GetDeleted()
+		videoRaw, occurredRaw, reason = deleted.GetVideoId(), deleted.GetOccurredAt(), purgeReasonVideoDeleted
+	case msg.GetUpdated() != nil && msg.GetUpdated().GetStatus() == string(po.VideoStatusArchived):
+		updated := msg.GetUpdated()
+		videoRaw, occurredRaw, reason = updated.GetVideoId(), updated.GetOccurredAt(), purgeReasonVideoArchived
+	default:
+		return nil
+	}
+
+	videoID, err := uuid.Parse(strings.TrimSpace(videoRaw))
+	if err != nil {
+		return kerrors.BadRequest("invalid-video-id", "invalid video_id")
+	}
+	// 清理截止时间取视频删除/归档的发生时间而非 Inbox 接收时间：接收时间晚于发生时间，
+	// 会把两者之间合法发生的互动事件误判为清理前事件而丢弃。
+	var requestedAt time.Time
+	for _, raw := range []string{occurredRaw, msg.GetOccurredAt()} {
+		if parsed, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(raw)); err == nil {
+			requestedAt = parsed.UTC()
+			break
+		}
+	}
+	if requestedAt.IsZero() {
+		if h.metrics != nil {
+			h.metrics.recordFailure(ctx)
+		}
+		return quarantine.Permanent(classInvalidPayload, fmt.Errorf("engagement: video event %s missing occurred_at", videoID))
+	}
+	return h.requestPurge(ctx, sess, po.EngagementPurgeScopeVideo, videoID, reason, requestedAt, inboxEvt.EventID)
+}
+
+// handleUserDeleted 处理 Profile 的 profile.user.deleted 事件，登记按用户清理，以用户删除时间作为清理截止时间。
+func (h *EventHandler) handleUserDeleted(ctx context.Context, sess txmanager.Session, payload []byte, inboxEvt *store.InboxEvent) error {
+	if h.purges == nil {
+		h.log.WithContext(ctx).Warn("engagement: purge repository not configured, skip user deleted event")
+		return nil
+	}
+
+	var msg profilecontractv1.UserDeletedEvent
+	if err := proto.Unmarshal(payload, &msg); err != nil {
+		if h.metrics != nil {
+			h.metrics.recordFailure(ctx)
+		}
+		return quarantine.Permanent(classInvalidPayload, fmt.Errorf("engagement: unmarshal user deleted: %w", err))
+	}
+	userID, err := uuid.Parse(strings.TrimSpace(msg.GetUserId()))
+	if err != nil {
+		return kerrors.BadRequest("invalid-user-id", "invalid user_id")
+	}
+	if msg.GetOccurredAt() == nil {
+		if h.metrics != nil {
+			h.metrics.recordFailure(ctx)
+		}
+		return quarantine.Permanent(classInvalidPayload, fmt.Errorf("engagement: user deleted %s missing occurred_at", userID))
+	}
+	requestedAt := msg.GetOccurredAt().AsTime().UTC()
+	return h.requestPurge(ctx, sess, po.EngagementPurgeScopeUser, userID, purgeReasonUserDeleted, requestedAt, inboxEvt.EventID)
+}
+
+func (h *EventHandler) requestPurge(ctx context.Context, sess txmanager.Session, scope po.EngagementPurgeScope, subjectID uuid.UUID, reason string, requestedAt time.Time, eventID uuid.UUID) error {
+	input := repositories.RequestPurgeInput{
+		Scope:       scope,
+		SubjectID:   subjectID,
+		Reason:      reason,
+		RequestedAt: requestedAt,
+	}
+	if eventID != uuid.Nil {
+		input.SourceEventID = &eventID
+	}
+	if err := h.purges.RequestPurge(ctx, sess, input); err != nil {
+		if h.metrics != nil {
+			h.metrics.recordFailure(ctx)
+		}
+		return err
+	}
+	h.log.WithContext(ctx).Infof("engagement purge requested: scope=%s subject=%s reason=%s", scope, subjectID, reason)
+	if h.metrics != nil {
+		h.metrics.recordPurgeRequest(ctx, reason)
+		h.metrics.recordSuccess(ctx, requestedAt, time.Now())
+	}
+	return nil
+}
+
+// purgedBefore 判断用户或视频是否已在 at 之后登记清理；此类晚到（或重放）的事件不再写回已清理的投影。
+func (h *EventHandler) purgedBefore(ctx context.Context, sess txmanager.Session, userID, videoID uuid.UUID, at time.Time) (bool, error) {
+	if h.purges == nil {
+		return false, nil
+	}
+	cutoff, err := h.purges.GetPurgeCutoff(ctx, sess, userID, videoID)
+	if err != nil {
+		return false, err
+	}
+	return cutoff != nil && !at.After(*cutoff), nil
+}
+
+// PurgePolicy 描述投影清理的扫描周期与批大小；Interval 为 0 表示不清理。
+type PurgePolicy struct {
+	Interval  time.Duration
+	BatchSize int
+}
+
+// NewPurgePolicy 将配置映射为 PurgePolicy。
+func NewPurgePolicy(cfg configloader.PurgeConfig) PurgePolicy {
+	return PurgePolicy{
+		Interval:  cfg.Interval,
+		BatchSize: int(cfg.BatchSize),
+	}
+}
+
+// purgeStore 定义后台清理循环所需的仓储接口。
+type purgeStore interface {
+	ListPendingPurges(ctx context.Context, sess txmanager.Session, limit int) ([]*po.EngagementPurge, error)
+	PurgeBatch(ctx context.Context, sess txmanager.Session, purge *po.EngagementPurge, batchSize int) ([]repositories.PurgedRows, error)
+	AddPurgeProgress(ctx context.Context, sess txmanager.Session, purge *po.EngagementPurge, rows int64) error
+	CompletePurge(ctx context.Context, sess txmanager.Session, purge *po.EngagementPurge) (bool, error)
+}
+
+var _ purgeStore = (*repositories.EngagementPurgeRepository)(nil)
+
+// ProjectionPurger 定期处理待清理请求：每个事务对每张投影表至多删除 BatchSize 行，直到清理干净后标记完成。
+// 删除天然幂等，中途失败或多实例并发处理同一请求都只会让后续批次删除更少的行。
+type ProjectionPurger struct {
+	store     purgeStore
+	txManager txmanager.Manager
+	policy    PurgePolicy
+	log       *log.Helper
+	metrics   *purgeMetrics
+}
+
+// NewProjectionPurger 构造 ProjectionPurger。
+func NewProjectionPurger(store purgeStore, tx txmanager.Manager, policy PurgePolicy, logger log.Logger) (*ProjectionPurger, error) {
+	if store == nil {
+		return nil, fmt.Errorf("engagement purge: purge repository is required")
+	}
+	if tx == nil {
+		return nil, fmt.Errorf("engagement purge: tx manager is required")
+	}
+	if policy.BatchSize <= 0 {
+		return nil, fmt.Errorf("engagement purge: batch_size must be positive")
+	}
+	if logger == nil {
+		logger = log.DefaultLogger
+	}
+	return &ProjectionPurger{
+		store:     store,
+		txManager: tx,
+		policy:    policy,
+		log:       log.NewHelper(logger),
+		metrics:   newPurgeMetrics(),
+	}, nil
+}
+
+// PurgeOnce 处理当前待清理的请求（每轮至多 purgeJobsPerTick 个），返回本轮删除的总行数。
+// 单个请求失败只记录并继续处理其余请求，失败的请求保持待处理、下一轮重试；返回值汇总本轮全部失败。
+func (p *ProjectionPurger) PurgeOnce(ctx context.Context) (int64, error) {
+	jobs, err := p.store.ListPendingPurges(ctx, nil, purgeJobsPerTick)
+	if err != nil {
+		return 0, fmt.Errorf("engagement purge: list pending: %w", err)
+	}
+	var (
+		total int64
+		errs  []error
+	)
+	for _, job := range jobs {
+		removed, err := p.purge(ctx, job)
+		total += removed
+		if err == nil {
+			continue
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			errs = append(errs, ctxErr)
+			break
+		}
+		p.log.WithContext(ctx).Warnf("engagement purge job failed: scope=%s subject=%s err=%v", job.Scope, job.SubjectID, err)
+		errs = append(errs, fmt.Errorf("engagement purge: %s %s: %w", job.Scope, job.SubjectID, err))
+	}
+	return total, errors.Join(errs...)
+}
+
+// purge 分批清理单个请求，直到某一批所有表均未删除任何行。
+func (p *ProjectionPurger) purge(ctx context.Context, job *po.EngagementPurge) (int64, error) {
+	var total int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+		var (
+			removed   []repositories.PurgedRows
+			batch     int64
+			completed bool
+		)
+		err := p.txManager.WithinTx(ctx, txmanager.TxOptions{}, func(txCtx context.Context, sess txmanager.Session) error {
+			var err error
+			removed, err = p.store.PurgeBatch(txCtx, sess, job, p.policy.BatchSize)
+			if err != nil {
+				return err
+			}
+			batch = 0
+			for _, item := range removed {
+				batch += item.Rows
+			}
+			if batch > 0 {
+				return p.store.AddPurgeProgress(txCtx, sess, job, batch)
+			}
+			completed, err = p.store.CompletePurge(txCtx, sess, job)
+			return err
+		})
+		if err != nil {
+			return total, err
+		}
+		total += batch
+		p.metrics.recordRemoved(ctx, job.Scope, removed)
+		if batch == 0 {
+			if completed {
+				p.log.WithContext(ctx).Infof("engagement purge completed: scope=%s subject=%s reason=%s rows_removed=%d",
+					job.Scope, job.SubjectID, job.Reason, job.RowsRemoved+total)
+			}
+			return total, nil
+		}
+	}
+}
+
+// Run 启动时处理一次，之后每个 Interval 处理一次，直到 ctx 结束；单次失败只记录日志。
+func (p *ProjectionPurger) Run(ctx context.Context) {
+	if p.policy.Interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(p.policy.Interval)
+	defer ticker.Stop()
+	for {
+		if _, err := p.PurgeOnce(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			p.log.WithContext(ctx).Warnf("engagement purge failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
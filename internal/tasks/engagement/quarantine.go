@@ -0,0 +1,46 @@
+package engagement
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	profilecontractv1 "github.com/bionicotaku/lingo-services-catalog/api/contracts/profile/v1"
+	videov1 "github.com/bionicotaku/lingo-services-catalog/api/video/v1"
+	profilev1 "github.com/bionicotaku/lingo-services-profile/api/profile/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// QuarantineConsumer 是 Engagement 消费者在 catalog.inbox_quarantine 中的标识。
+const QuarantineConsumer = "engagement"
+
+// classInvalidPayload 标记负载无法解码的永久错误。
+const classInvalidPayload = "invalid-payload"
+
+// DescribePayload 按事件类型将 Inbox 负载解码为 JSON，供隔离事件的 show 子命令展示。
+func DescribePayload(eventType string, payload []byte) (any, error) {
+	var msg proto.Message
+	switch strings.TrimSpace(eventType) {
+	case "profile.engagement.added":
+		msg = &profilecontractv1.EngagementAddedEvent{}
+	case "profile.engagement.removed":
+		msg = &profilecontractv1.EngagementRemovedEvent{}
+	case "profile.watch.progressed":
+		msg = &profilev1.WatchProgressedEvent{}
+	case "catalog.video.deleted", "catalog.video.updated":
+		msg = &videov1.Event{}
+	case "profile.user.deleted":
+		msg = &profilecontractv1.UserDeletedEvent{}
+	default:
+		return nil, fmt.Errorf("unsupported event type %q", eventType)
+	}
+	if err := proto.Unmarshal(payload, msg); err != nil {
+		return nil, err
+	}
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(data), nil
+}
@@ -0,0 +1,174 @@
+package engagement
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultReconcileBatchSize = 200
+	defaultReconcileSamples   = 20
+)
+
+// ReconcileOptions 控制一次统计对账。
+type ReconcileOptions struct {
+	// VideoID 仅对账单个视频。
+	VideoID *uuid.UUID
+	// Since 仅对账在该时间之后有统计或明细更新的视频。
+	Since *time.Time
+	// BatchSize 为每页扫描、每个修复事务处理的视频数。
+	BatchSize int
+	// DryRun 只报告漂移，不修复。
+	DryRun bool
+	// SampleLimit 为报告中保留的漂移样例条数。
+	SampleLimit int
+}
+
+// ReconcileReport 汇总一次对账的执行结果；*Drift 字段为各计数偏差绝对值之和。
+type ReconcileReport struct {
+	DryRun             bool     `json:"dry_run"`
+	Scanned            int      `json:"scanned"`
+	Drifted            int      `json:"drifted"`
+	Repaired           int      `json:"repaired"`
+	LikeDrift          int64    `json:"like_drift"`
+	BookmarkDrift      int64    `json:"bookmark_drift"`
+	UniqueWatcherDrift int64    `json:"unique_watcher_drift"`
+	Samples            []string `json:"samples,omitempty"`
+}
+
+// reconcileStore 定义统计对账所需的扫描与修复接口。
+type reconcileStore interface {
+	ListDrift(ctx context.Context, sess txmanager.Session, filter repositories.StatsDriftFilter) ([]*po.VideoEngagementStatsDrift, error)
+	Repair(ctx context.Context, sess txmanager.Session, videoIDs []uuid.UUID) ([]*po.VideoEngagementStatsProjection, error)
+}
+
+var _ reconcileStore = (*repositories.VideoEngagementStatsRepository)(nil)
+
+// Reconciler 以 video_user_engagements_projection 与 video_engagement_watchers 为准，
+// 校正 video_engagement_stats_projection 中因事件丢失或重复应用导致漂移的计数。
+// watch_count 没有逐次观看明细可供重算，不在对账范围内。
+type Reconciler struct {
+	store     reconcileStore
+	txManager txmanager.Manager
+	log       *log.Helper
+	metrics   *reconcileMetrics
+}
+
+// NewReconciler 构造 Reconciler。
+func NewReconciler(store reconcileStore, tx txmanager.Manager, logger log.Logger) (*Reconciler, error) {
+	if store == nil {
+		return nil, fmt.Errorf("engagement reconcile: stats repository is required")
+	}
+	if tx == nil {
+		return nil, fmt.Errorf("engagement reconcile: tx manager is required")
+	}
+	if logger == nil {
+		logger = log.DefaultLogger
+	}
+	return &Reconciler{
+		store:     store,
+		txManager: tx,
+		log:       log.NewHelper(logger),
+		metrics:   newReconcileMetrics(),
+	}, nil
+}
+
+// Run 按 video_id 分页扫描候选视频，统计漂移并在非 dry-run 模式下逐批修复。
+func (r *Reconciler) Run(ctx context.Context, opts ReconcileOptions) (*ReconcileReport, error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultReconcileBatchSize
+	}
+	if opts.SampleLimit <= 0 {
+		opts.SampleLimit = defaultReconcileSamples
+	}
+
+	report := &ReconcileReport{DryRun: opts.DryRun}
+	var samples []string
+	filter := repositories.StatsDriftFilter{
+		VideoID: opts.VideoID,
+		Since:   opts.Since,
+		Limit:   opts.BatchSize,
+	}
+	for {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		var page []*po.VideoEngagementStatsDrift
+		err := r.txManager.WithinReadOnlyTx(ctx, txmanager.TxOptions{}, func(txCtx context.Context, sess txmanager.Session) error {
+			var listErr error
+			page, listErr = r.store.ListDrift(txCtx, sess, filter)
+			return listErr
+		})
+		if err != nil {
+			return report, fmt.Errorf("engagement reconcile: scan stats: %w", err)
+		}
+		if len(page) == 0 {
+			break
+		}
+
+		drifted := make([]uuid.UUID, 0, len(page))
+		for _, row := range page {
+			report.Scanned++
+			if !statsDrifted(row) {
+				continue
+			}
+			report.Drifted++
+			like := abs64(row.StoredLikeCount - row.ActualLikeCount)
+			bookmark := abs64(row.StoredBookmarkCount - row.ActualBookmarkCount)
+			watchers := abs64(row.StoredUniqueWatchers - row.ActualUniqueWatchers)
+			report.LikeDrift += like
+			report.BookmarkDrift += bookmark
+			report.UniqueWatcherDrift += watchers
+			r.metrics.recordDrift(ctx, like, bookmark, watchers)
+			drifted = append(drifted, row.VideoID)
+			samples = append(samples, fmt.Sprintf("stats drift video=%s likes=%d->%d bookmarks=%d->%d unique_watchers=%d->%d",
+				row.VideoID, row.StoredLikeCount, row.ActualLikeCount, row.StoredBookmarkCount, row.ActualBookmarkCount,
+				row.StoredUniqueWatchers, row.ActualUniqueWatchers))
+		}
+
+		if !opts.DryRun && len(drifted) > 0 {
+			var repaired []*po.VideoEngagementStatsProjection
+			err := r.txManager.WithinTx(ctx, txmanager.TxOptions{}, func(txCtx context.Context, sess txmanager.Session) error {
+				var repairErr error
+				repaired, repairErr = r.store.Repair(txCtx, sess, drifted)
+				return repairErr
+			})
+			if err != nil {
+				return report, fmt.Errorf("engagement reconcile: repair stats: %w", err)
+			}
+			report.Repaired += len(repaired)
+			r.metrics.recordRepaired(ctx, len(repaired))
+		}
+
+		r.log.WithContext(ctx).Debugf("engagement reconcile page: scanned=%d drifted=%d", len(page), len(drifted))
+		filter.AfterVideoID = page[len(page)-1].VideoID
+		if len(page) < opts.BatchSize {
+			break
+		}
+	}
+
+	report.Samples = limitSamples(samples, opts.SampleLimit)
+	r.log.WithContext(ctx).Infof("engagement reconcile finished: scanned=%d drifted=%d repaired=%d dry_run=%t",
+		report.Scanned, report.Drifted, report.Repaired, report.DryRun)
+	return report, nil
+}
+
+func statsDrifted(row *po.VideoEngagementStatsDrift) bool {
+	return row.StoredLikeCount != row.ActualLikeCount ||
+		row.StoredBookmarkCount != row.ActualBookmarkCount ||
+		row.StoredUniqueWatchers != row.ActualUniqueWatchers
+}
+
+func abs64(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
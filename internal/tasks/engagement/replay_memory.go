@@ -0,0 +1,260 @@
+package engagement
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/google/uuid"
+)
+
+type userVideoKey struct {
+	UserID  uuid.UUID
+	VideoID uuid.UUID
+}
+
+// memoryProjection 在内存中模拟投影表语义，供 dry-run 重放使用。
+type memoryProjection struct {
+	states   map[userVideoKey]*po.VideoUserState
+	stats    map[uuid.UUID]*po.VideoEngagementStatsProjection
+	watchers map[userVideoKey]*po.VideoWatcherRecord
+	sessions map[userVideoKey]*po.VideoViewSession
+}
+
+func newMemoryProjection() *memoryProjection {
+	return &memoryProjection{
+		states:   make(map[userVideoKey]*po.VideoUserState),
+		stats:    make(map[uuid.UUID]*po.VideoEngagementStatsProjection),
+		watchers: make(map[userVideoKey]*po.VideoWatcherRecord),
+		sessions: make(map[userVideoKey]*po.VideoViewSession),
+	}
+}
+
+func (m *memoryProjection) Get(_ context.Context, _ txmanager.Session, userID, videoID uuid.UUID) (*po.VideoUserState, error) {
+	state, ok := m.states[userVideoKey{UserID: userID, VideoID: videoID}]
+	if !ok {
+		return nil, nil
+	}
+	copied := *state
+	return &copied, nil
+}
+
+func (m *memoryProjection) Upsert(_ context.Context, _ txmanager.Session, input repositories.UpsertVideoUserStateInput) error {
+	m.states[userVideoKey{UserID: input.UserID, VideoID: input.VideoID}] = &po.VideoUserState{
+		UserID:               input.UserID,
+		VideoID:              input.VideoID,
+		HasLiked:             input.HasLiked,
+		HasBookmarked:        input.HasBookmarked,
+		LikedOccurredAt:      cloneTime(input.LikedOccurredAt),
+		BookmarkedOccurredAt: cloneTime(input.BookmarkedOccurredAt),
+		LikedSequence:        cloneInt64(input.LikedSequence),
+		BookmarkedSequence:   cloneInt64(input.BookmarkedSequence),
+		Rating:               cloneInt32(input.Rating),
+		RatedOccurredAt:      cloneTime(input.RatedOccurredAt),
+		RatedSequence:        cloneInt64(input.RatedSequence),
+	}
+	return nil
+}
+
+// Increment 与 IncrementVideoEngagementStats 保持一致：计数不低于 0，首次/最近观看时间分别取最小/最大值。
+func (m *memoryProjection) Increment(_ context.Context, _ txmanager.Session, videoID uuid.UUID, delta repositories.StatsDelta) (*po.VideoEngagementStatsProjection, error) {
+	row, ok := m.stats[videoID]
+	if !ok {
+		row = &po.VideoEngagementStatsProjection{VideoID: videoID}
+		m.stats[videoID] = row
+	}
+	row.LikeCount = max(0, row.LikeCount+delta.LikeDelta)
+	row.BookmarkCount = max(0, row.BookmarkCount+delta.BookmarkDelta)
+	row.WatchCount = max(0, row.WatchCount+delta.WatchDelta)
+	row.UniqueWatchers = max(0, row.UniqueWatchers+delta.UniqueWatcherDelta)
+	row.TotalWatchSeconds = max(0, row.TotalWatchSeconds+delta.WatchSecondsDelta)
+	row.PositionSumSeconds = max(0, row.PositionSumSeconds+delta.PositionSecondsDelta)
+	row.PositionViewers = max(0, row.PositionViewers+delta.PositionViewerDelta)
+	row.ShareCount = max(0, row.ShareCount+delta.ShareDelta)
+	row.RatingCount = max(0, row.RatingCount+delta.RatingCountDelta)
+	row.RatingSum = max(0, row.RatingSum+delta.RatingSumDelta)
+	for i := range row.RatingDistribution {
+		row.RatingDistribution[i] = max(0, row.RatingDistribution[i]+delta.RatingDistributionDelta[i])
+	}
+	if delta.FirstWatchAt != nil && (row.FirstWatchAt == nil || delta.FirstWatchAt.Before(*row.FirstWatchAt)) {
+		row.FirstWatchAt = cloneTime(delta.FirstWatchAt)
+	}
+	if delta.LastWatchAt != nil && (row.LastWatchAt == nil || delta.LastWatchAt.After(*row.LastWatchAt)) {
+		row.LastWatchAt = cloneTime(delta.LastWatchAt)
+	}
+	copied := *row
+	return &copied, nil
+}
+
+func (m *memoryProjection) MarkWatcher(_ context.Context, _ txmanager.Session, videoID, userID uuid.UUID, watchTime time.Time) (*po.VideoWatcherRecord, error) {
+	key := userVideoKey{UserID: userID, VideoID: videoID}
+	record, ok := m.watchers[key]
+	if !ok {
+		record = &po.VideoWatcherRecord{VideoID: videoID, UserID: userID, FirstWatchedAt: watchTime, LastWatchedAt: watchTime}
+		m.watchers[key] = record
+		copied := *record
+		copied.Inserted = true
+		return &copied, nil
+	}
+	if watchTime.After(record.LastWatchedAt) {
+		record.LastWatchedAt = watchTime
+	}
+	copied := *record
+	return &copied, nil
+}
+
+func (m *memoryProjection) GetViewSession(_ context.Context, _ txmanager.Session, videoID, userID uuid.UUID) (*po.VideoViewSession, error) {
+	session, ok := m.sessions[userVideoKey{UserID: userID, VideoID: videoID}]
+	if !ok {
+		return nil, nil
+	}
+	copied := *session
+	return &copied, nil
+}
+
+func (m *memoryProjection) SaveViewSession(_ context.Context, _ txmanager.Session, session *po.VideoViewSession) error {
+	copied := *session
+	m.sessions[userVideoKey{UserID: session.UserID, VideoID: session.VideoID}] = &copied
+	return nil
+}
+
+// IncrementRollups 为空操作：dry-run 只对比累计统计，不比较分时统计桶。
+func (m *memoryProjection) IncrementRollups(context.Context, txmanager.Session, uuid.UUID, time.Time, repositories.StatsDelta) error {
+	return nil
+}
+
+func (m *memoryProjection) userStates() []*po.VideoUserState {
+	states := make([]*po.VideoUserState, 0, len(m.states))
+	for _, state := range m.states {
+		states = append(states, state)
+	}
+	return states
+}
+
+func (m *memoryProjection) statsRows() []*po.VideoEngagementStatsProjection {
+	rows := make([]*po.VideoEngagementStatsProjection, 0, len(m.stats))
+	for _, row := range m.stats {
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// diffUserStates 对比当前投影与重放结果；时间字段按数据库精度（微秒）比较，事件序号一并比较。
+func diffUserStates(current, replayed []*po.VideoUserState, sampleLimit int) DiffSummary {
+	want := make(map[userVideoKey]*po.VideoUserState, len(replayed))
+	for _, state := range replayed {
+		want[userVideoKey{UserID: state.UserID, VideoID: state.VideoID}] = state
+	}
+
+	var summary DiffSummary
+	var samples []string
+	for _, have := range current {
+		key := userVideoKey{UserID: have.UserID, VideoID: have.VideoID}
+		next, ok := want[key]
+		if !ok {
+			summary.Removed++
+			samples = append(samples, fmt.Sprintf("user_state removed user=%s video=%s liked=%t bookmarked=%t",
+				have.UserID, have.VideoID, have.HasLiked, have.HasBookmarked))
+			continue
+		}
+		delete(want, key)
+		if have.HasLiked == next.HasLiked && have.HasBookmarked == next.HasBookmarked &&
+			sameInstant(have.LikedOccurredAt, next.LikedOccurredAt) && sameInstant(have.BookmarkedOccurredAt, next.BookmarkedOccurredAt) &&
+			sameSequence(have.LikedSequence, next.LikedSequence) && sameSequence(have.BookmarkedSequence, next.BookmarkedSequence) &&
+			sameRating(have.Rating, next.Rating) && sameInstant(have.RatedOccurredAt, next.RatedOccurredAt) && sameSequence(have.RatedSequence, next.RatedSequence) {
+			summary.Unchanged++
+			continue
+		}
+		summary.Changed++
+		samples = append(samples, fmt.Sprintf("user_state changed user=%s video=%s liked=%t->%t bookmarked=%t->%t",
+			have.UserID, have.VideoID, have.HasLiked, next.HasLiked, have.HasBookmarked, next.HasBookmarked))
+	}
+	for _, next := range want {
+		summary.Added++
+		samples = append(samples, fmt.Sprintf("user_state added user=%s video=%s liked=%t bookmarked=%t",
+			next.UserID, next.VideoID, next.HasLiked, next.HasBookmarked))
+	}
+	summary.Samples = limitSamples(samples, sampleLimit)
+	return summary
+}
+
+func diffStats(current, replayed []*po.VideoEngagementStatsProjection, sampleLimit int) DiffSummary {
+	want := make(map[uuid.UUID]*po.VideoEngagementStatsProjection, len(replayed))
+	for _, row := range replayed {
+		want[row.VideoID] = row
+	}
+
+	var summary DiffSummary
+	var samples []string
+	for _, have := range current {
+		next, ok := want[have.VideoID]
+		if !ok {
+			summary.Removed++
+			samples = append(samples, fmt.Sprintf("stats removed video=%s likes=%d bookmarks=%d watches=%d",
+				have.VideoID, have.LikeCount, have.BookmarkCount, have.WatchCount))
+			continue
+		}
+		delete(want, have.VideoID)
+		if have.LikeCount == next.LikeCount && have.BookmarkCount == next.BookmarkCount &&
+			have.WatchCount == next.WatchCount && have.UniqueWatchers == next.UniqueWatchers &&
+			sameSeconds(have.TotalWatchSeconds, next.TotalWatchSeconds) &&
+			sameSeconds(have.PositionSumSeconds, next.PositionSumSeconds) && have.PositionViewers == next.PositionViewers &&
+			sameInstant(have.FirstWatchAt, next.FirstWatchAt) && sameInstant(have.LastWatchAt, next.LastWatchAt) &&
+			have.ShareCount == next.ShareCount && have.RatingCount == next.RatingCount && have.RatingSum == next.RatingSum &&
+			have.RatingDistribution == next.RatingDistribution {
+			summary.Unchanged++
+			continue
+		}
+		summary.Changed++
+		samples = append(samples, fmt.Sprintf("stats changed video=%s likes=%d->%d bookmarks=%d->%d watches=%d->%d unique_watchers=%d->%d watch_seconds=%.1f->%.1f",
+			have.VideoID, have.LikeCount, next.LikeCount, have.BookmarkCount, next.BookmarkCount,
+			have.WatchCount, next.WatchCount, have.UniqueWatchers, next.UniqueWatchers,
+			have.TotalWatchSeconds, next.TotalWatchSeconds))
+	}
+	for _, next := range want {
+		summary.Added++
+		samples = append(samples, fmt.Sprintf("stats added video=%s likes=%d bookmarks=%d watches=%d",
+			next.VideoID, next.LikeCount, next.BookmarkCount, next.WatchCount))
+	}
+	summary.Samples = limitSamples(samples, sampleLimit)
+	return summary
+}
+
+// sameSeconds 比较浮点累计秒数，容忍累加顺序不同带来的舍入误差。
+func sameSeconds(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
+
+func sameInstant(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Truncate(time.Microsecond).Equal(b.Truncate(time.Microsecond))
+}
+
+func sameSequence(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func sameRating(a, b *int32) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func limitSamples(samples []string, limit int) []string {
+	sort.Strings(samples)
+	if len(samples) > limit {
+		samples = samples[:limit]
+	}
+	return samples
+}
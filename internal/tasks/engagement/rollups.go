@@ -0,0 +1,133 @@
+package engagement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// RollupRetention 描述分时统计桶的保留期；保留期为 0 表示对应粒度不清理。
+// 每个清理事务每张表至多删除 PruneBatchSize 行，避免一次性删除大量过期桶长时间持锁。
+type RollupRetention struct {
+	HourlyRetention time.Duration
+	DailyRetention  time.Duration
+	PruneInterval   time.Duration
+	PruneBatchSize  int
+}
+
+// NewRollupRetention 将配置映射为 RollupRetention。
+func NewRollupRetention(cfg configloader.RollupRetentionConfig) RollupRetention {
+	return RollupRetention{
+		HourlyRetention: cfg.HourlyRetention,
+		DailyRetention:  cfg.DailyRetention,
+		PruneInterval:   cfg.PruneInterval,
+		PruneBatchSize:  int(cfg.PruneBatchSize),
+	}
+}
+
+// cutoff 返回早于该时间的桶需要清理；retention 为 0 时返回零值时间，不删除任何桶。
+func cutoff(now time.Time, retention time.Duration) time.Time {
+	if retention <= 0 {
+		return time.Time{}
+	}
+	return now.Add(-retention).UTC()
+}
+
+// rollupPruneStore 定义过期分时统计桶的清理接口。
+type rollupPruneStore interface {
+	PruneRollups(ctx context.Context, sess txmanager.Session, hourlyBefore, dailyBefore time.Time, batchSize int) (hourly, daily int64, err error)
+}
+
+var _ rollupPruneStore = (*repositories.VideoEngagementStatsRepository)(nil)
+
+// RollupPruner 按保留期定期删除过期的小时桶与天级桶。
+type RollupPruner struct {
+	store     rollupPruneStore
+	txManager txmanager.Manager
+	retention RollupRetention
+	log       *log.Helper
+	now       func() time.Time
+}
+
+// NewRollupPruner 构造 RollupPruner。
+func NewRollupPruner(store rollupPruneStore, tx txmanager.Manager, retention RollupRetention, logger log.Logger) (*RollupPruner, error) {
+	if store == nil {
+		return nil, fmt.Errorf("engagement rollups: stats repository is required")
+	}
+	if tx == nil {
+		return nil, fmt.Errorf("engagement rollups: tx manager is required")
+	}
+	if retention.PruneBatchSize <= 0 {
+		return nil, fmt.Errorf("engagement rollups: prune_batch_size must be positive")
+	}
+	if logger == nil {
+		logger = log.DefaultLogger
+	}
+	return &RollupPruner{
+		store:     store,
+		txManager: tx,
+		retention: retention,
+		log:       log.NewHelper(logger),
+		now:       time.Now,
+	}, nil
+}
+
+// PruneOnce 分批清理过期桶，每批一个事务，直到两张表的某一批都不足 PruneBatchSize；返回删除的小时桶与天级桶行数。
+func (p *RollupPruner) PruneOnce(ctx context.Context) (hourly, daily int64, err error) {
+	now := p.now()
+	hourlyBefore := cutoff(now, p.retention.HourlyRetention)
+	dailyBefore := cutoff(now, p.retention.DailyRetention)
+	batchSize := p.retention.PruneBatchSize
+	for {
+		var batchHourly, batchDaily int64
+		err = p.txManager.WithinTx(ctx, txmanager.TxOptions{}, func(txCtx context.Context, sess txmanager.Session) error {
+			var pruneErr error
+			batchHourly, batchDaily, pruneErr = p.store.PruneRollups(txCtx, sess, hourlyBefore, dailyBefore, batchSize)
+			return pruneErr
+		})
+		if err != nil {
+			return hourly, daily, fmt.Errorf("engagement rollups: prune: %w", err)
+		}
+		hourly += batchHourly
+		daily += batchDaily
+		if batchHourly < int64(batchSize) && batchDaily < int64(batchSize) {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return hourly, daily, err
+		}
+	}
+	if hourly > 0 || daily > 0 {
+		p.log.WithContext(ctx).Infof("engagement rollups pruned: hourly=%d daily=%d", hourly, daily)
+	}
+	return hourly, daily, nil
+}
+
+// Run 启动时清理一次，之后每个 PruneInterval 清理一次，直到 ctx 结束；单次失败只记录日志。
+func (p *RollupPruner) Run(ctx context.Context) {
+	if p.retention.HourlyRetention <= 0 && p.retention.DailyRetention <= 0 {
+		return
+	}
+	interval := p.retention.PruneInterval
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, _, err := p.PruneOnce(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			p.log.WithContext(ctx).Warnf("engagement rollups prune failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -2,9 +2,12 @@ package engagement
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/quarantine"
 	"github.com/bionicotaku/lingo-utils/gcpubsub"
 	"github.com/bionicotaku/lingo-utils/outbox/config"
 	"github.com/bionicotaku/lingo-utils/outbox/inbox"
@@ -12,21 +15,47 @@ import (
 	"github.com/go-kratos/kratos/v2/log"
 )
 
-// Runner 封装 Engagement 事件消费循环（基于 Inbox Runner）。
+// consumer 抽象主消费循环：逐条消费的 Inbox Runner 或批量消费的 BatchConsumer。
+type consumer interface {
+	Run(ctx context.Context) error
+}
+
+// Runner 封装 Engagement 事件消费循环（基于 Inbox Runner，可选批量消费）。
 type Runner struct {
-	delegate *inbox.Runner[Event]
-	metrics  *metrics
+	delegate  consumer
+	cleanup   []*inbox.Runner[Event]
+	pruner    *RollupPruner
+	trending  *TrendingScorer
+	purger    *ProjectionPurger
+	compactor *StatsCompactor
+	metrics   *metrics
+	log       *log.Helper
 }
 
 // RunnerParams 注入 Runner 所需依赖。
 type RunnerParams struct {
 	Subscriber gcpubsub.Subscriber
-	InboxRepo  *repositories.InboxRepository
-	UserRepo   videoUserStatesStore
-	StatsRepo  videoEngagementStatsStore
-	TxManager  txmanager.Manager
-	Logger     log.Logger
-	Config     config.InboxConfig
+	// VideoSubscriber 与 UserSubscriber 可选，分别消费 catalog 视频删除/归档事件与 Profile 用户删除事件，
+	// 与 Subscriber 共用同一 EventHandler 与 Inbox；需同时配置 PurgeRepo。
+	VideoSubscriber gcpubsub.Subscriber
+	UserSubscriber  gcpubsub.Subscriber
+	InboxRepo       *repositories.InboxRepository
+	UserRepo        videoUserStatesStore
+	StatsRepo       videoEngagementStatsStore
+	PurgeRepo       *repositories.EngagementPurgeRepository
+	QuarantineRepo  *repositories.InboxQuarantineRepository
+	// LockRepo 可选；配置后每个消费事务先获取投影共享锁，Replayer 重放期间消息放弃并等待重投。
+	LockRepo *repositories.AdvisoryLockRepository
+	Views    ViewPolicy
+	Rollups  RollupRetention
+	Trending TrendingPolicy
+	Purge    PurgePolicy
+	Compact  CompactPolicy
+	// Batch 开启时主订阅改由 BatchConsumer 攒批消费；视频/用户删除事件仍逐条消费。
+	Batch     BatchPolicy
+	TxManager txmanager.Manager
+	Logger    log.Logger
+	Config    config.InboxConfig
 }
 
 // NewRunner 构造 Engagement Runner。
@@ -48,32 +77,187 @@ func NewRunner(params RunnerParams) (*Runner, error) {
 	}
 
 	metrics := newMetrics()
-	handler := NewEventHandler(params.UserRepo, params.StatsRepo, params.Logger, metrics)
+	var purges engagementPurgeStore
+	if params.PurgeRepo != nil {
+		purges = params.PurgeRepo
+	}
+	handler := NewEventHandler(params.UserRepo, params.StatsRepo, purges, params.Views, params.Logger, metrics)
 	decoder := newEventDecoder()
+	var (
+		inboxHandler inbox.Handler[Event] = handler
+		quarantines  quarantine.Store
+	)
+	if params.QuarantineRepo != nil {
+		quarantines = params.QuarantineRepo
+		inboxHandler = quarantine.NewHandler[Event](QuarantineConsumer, handler, quarantines, params.Logger)
+	}
+	var gate projectionGate
+	if params.LockRepo != nil {
+		gate = params.LockRepo
+		inboxHandler = gatedHandler{inner: inboxHandler, gate: gate}
+	}
 
-	delegate, err := inbox.NewRunner[Event](inbox.RunnerParams[Event]{
-		Store:      params.InboxRepo.Shared(),
-		Subscriber: params.Subscriber,
-		TxManager:  params.TxManager,
-		Decoder:    decoder,
-		Handler:    handler,
-		Config:     params.Config,
-		Logger:     params.Logger,
-	})
+	newInboxRunner := func(sub gcpubsub.Subscriber) (*inbox.Runner[Event], error) {
+		return inbox.NewRunner[Event](inbox.RunnerParams[Event]{
+			Store:      params.InboxRepo.Shared(),
+			Subscriber: sub,
+			TxManager:  params.TxManager,
+			Decoder:    decoder,
+			Handler:    inboxHandler,
+			Config:     params.Config,
+			Logger:     params.Logger,
+		})
+	}
+	var (
+		delegate consumer
+		err      error
+	)
+	if params.Batch.Enabled() {
+		users, ok := params.UserRepo.(batchUserStatesStore)
+		if !ok {
+			return nil, fmt.Errorf("engagement: batch mode requires a user state repository with UpsertBatch")
+		}
+		stats, ok := params.StatsRepo.(batchStatsStore)
+		if !ok {
+			return nil, fmt.Errorf("engagement: batch mode requires a stats repository with ApplyBatch")
+		}
+		delegate, err = NewBatchConsumer(BatchConsumerParams{
+			Subscriber:    params.Subscriber,
+			Inbox:         params.InboxRepo,
+			UserRepo:      users,
+			StatsRepo:     stats,
+			Purges:        purges,
+			Quarantine:    quarantines,
+			Gate:          gate,
+			Views:         params.Views,
+			TxManager:     params.TxManager,
+			SourceService: params.Config.SourceService,
+			Policy:        params.Batch,
+			Logger:        params.Logger,
+			Metrics:       metrics,
+		})
+	} else {
+		delegate, err = newInboxRunner(params.Subscriber)
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	var (
+		cleanup []*inbox.Runner[Event]
+		purger  *ProjectionPurger
+	)
+	if params.PurgeRepo != nil {
+		for _, sub := range []gcpubsub.Subscriber{params.VideoSubscriber, params.UserSubscriber} {
+			if sub == nil {
+				continue
+			}
+			runner, err := newInboxRunner(sub)
+			if err != nil {
+				return nil, err
+			}
+			cleanup = append(cleanup, runner)
+		}
+		if params.Purge.Interval > 0 {
+			purger, err = NewProjectionPurger(params.PurgeRepo, params.TxManager, params.Purge, params.Logger)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var pruner *RollupPruner
+	if params.Rollups.HourlyRetention > 0 || params.Rollups.DailyRetention > 0 {
+		if prunerStore, ok := params.StatsRepo.(rollupPruneStore); ok {
+			pruner, err = NewRollupPruner(prunerStore, params.TxManager, params.Rollups, params.Logger)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var trending *TrendingScorer
+	if params.Trending.Interval > 0 {
+		if store, ok := params.StatsRepo.(trendingStore); ok {
+			var lock trendingLocker
+			if params.LockRepo != nil {
+				lock = params.LockRepo
+			}
+			trending, err = NewTrendingScorer(store, lock, params.TxManager, params.Trending, params.Logger)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var compactor *StatsCompactor
+	if params.Compact.Interval > 0 {
+		if store, ok := params.StatsRepo.(shardCompactStore); ok {
+			compactor, err = NewStatsCompactor(store, params.TxManager, params.Compact, params.Logger)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	logger := params.Logger
+	if logger == nil {
+		logger = log.DefaultLogger
+	}
 	return &Runner{
-		delegate: delegate,
-		metrics:  metrics,
+		delegate:  delegate,
+		cleanup:   cleanup,
+		pruner:    pruner,
+		trending:  trending,
+		purger:    purger,
+		compactor: compactor,
+		metrics:   metrics,
+		log:       log.NewHelper(logger),
 	}, nil
 }
 
-// Run 启动消费循环。
+// Run 启动消费循环，并在后台消费视频/用户删除事件、清理投影与过期分时统计桶、定期计算热度快照、折叠分片计数；
+// 消费循环退出后等待后台协程结束。
 func (r *Runner) Run(ctx context.Context) error {
 	if r == nil || r.delegate == nil {
 		return nil
 	}
-	return r.delegate.Run(ctx)
+	var background []func(context.Context)
+	for _, runner := range r.cleanup {
+		background = append(background, func(ctx context.Context) {
+			if err := runner.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				r.log.WithContext(ctx).Errorf("engagement cleanup consumer stopped: %v", err)
+			}
+		})
+	}
+	if r.purger != nil {
+		background = append(background, r.purger.Run)
+	}
+	if r.pruner != nil {
+		background = append(background, r.pruner.Run)
+	}
+	if r.trending != nil {
+		background = append(background, r.trending.Run)
+	}
+	if r.compactor != nil {
+		background = append(background, r.compactor.Run)
+	}
+	if len(background) == 0 {
+		return r.delegate.Run(ctx)
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var wg sync.WaitGroup
+	for _, run := range background {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			run(runCtx)
+		}()
+	}
+	err := r.delegate.Run(runCtx)
+	cancel()
+	wg.Wait()
+	return err
 }
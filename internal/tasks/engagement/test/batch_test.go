@@ -0,0 +1,397 @@
+package engagement_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/engagement"
+	profilev1 "github.com/bionicotaku/lingo-services-profile/api/profile/v1"
+	"github.com/bionicotaku/lingo-utils/gcpubsub"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestBatchConsumerCoalescesVideoStats(t *testing.T) {
+	videoID := uuid.New()
+	baseTime := time.Now().Add(-time.Hour).UTC()
+
+	var messages []*gcpubsub.Message
+	for i := 0; i < 3; i++ {
+		messages = append(messages, likeMessage(t, uuid.New(), uuid.New(), videoID, baseTime.Add(time.Duration(i)*time.Second)))
+	}
+	users := &fakeBatchUserRepo{fakeVideoUserStatesRepository: newFakeVideoUserStatesRepository()}
+	stats := &fakeBatchStatsRepo{}
+	inbox := newFakeBatchInbox()
+	sub := &fakeBatchSubscriber{messages: messages}
+
+	consumer := newTestBatchConsumer(t, sub, inbox, users, stats, engagement.BatchPolicy{MaxEvents: 3, MaxWait: time.Minute})
+	require.NoError(t, consumer.Run(context.Background()))
+
+	require.Len(t, sub.results, 3)
+	for _, err := range sub.results {
+		require.NoError(t, err)
+	}
+	require.Len(t, stats.batches, 1)
+	batch := stats.batches[0]
+	require.Len(t, batch.Stats, 1)
+	require.Equal(t, videoID, batch.Stats[0].VideoID)
+	require.EqualValues(t, 3, batch.Stats[0].Delta.LikeDelta)
+	require.Len(t, users.batches, 1)
+	require.Len(t, users.batches[0], 3)
+	require.Len(t, inbox.processed, 3)
+}
+
+func TestBatchConsumerSkipsProcessedEvents(t *testing.T) {
+	videoID := uuid.New()
+	baseTime := time.Now().Add(-time.Hour).UTC()
+
+	duplicate := likeMessage(t, uuid.New(), uuid.New(), videoID, baseTime)
+	fresh := likeMessage(t, uuid.New(), uuid.New(), videoID, baseTime)
+	inbox := newFakeBatchInbox()
+	inbox.processed[uuid.MustParse(duplicate.Attributes["event_id"])] = baseTime
+
+	users := &fakeBatchUserRepo{fakeVideoUserStatesRepository: newFakeVideoUserStatesRepository()}
+	stats := &fakeBatchStatsRepo{}
+	sub := &fakeBatchSubscriber{messages: []*gcpubsub.Message{duplicate, fresh}}
+
+	consumer := newTestBatchConsumer(t, sub, inbox, users, stats, engagement.BatchPolicy{MaxEvents: 2, MaxWait: time.Minute})
+	require.NoError(t, consumer.Run(context.Background()))
+
+	for _, err := range sub.results {
+		require.NoError(t, err)
+	}
+	require.Len(t, stats.batches, 1)
+	require.Len(t, stats.batches[0].Stats, 1)
+	require.EqualValues(t, 1, stats.batches[0].Stats[0].Delta.LikeDelta)
+}
+
+func TestBatchConsumerIsolatesFailingEvent(t *testing.T) {
+	videoID := uuid.New()
+	good := likeMessage(t, uuid.New(), uuid.New(), videoID, time.Now().Add(-time.Hour).UTC())
+	bad := likeMessage(t, uuid.New(), uuid.New(), videoID, time.Now().Add(-time.Hour).UTC())
+	data, err := proto.Marshal(&profilev1.EngagementAddedEvent{
+		EventId:      bad.ID,
+		UserId:       uuid.NewString(),
+		VideoId:      videoID.String(),
+		FavoriteType: profilev1.FavoriteType_FAVORITE_TYPE_UNSPECIFIED,
+		OccurredAt:   timestamppb.Now(),
+	})
+	require.NoError(t, err)
+	bad.Data = data
+
+	users := &fakeBatchUserRepo{fakeVideoUserStatesRepository: newFakeVideoUserStatesRepository()}
+	stats := &fakeBatchStatsRepo{}
+	inbox := newFakeBatchInbox()
+	sub := &fakeBatchSubscriber{messages: []*gcpubsub.Message{good, bad}}
+
+	consumer := newTestBatchConsumer(t, sub, inbox, users, stats, engagement.BatchPolicy{MaxEvents: 2, MaxWait: time.Minute})
+	require.NoError(t, consumer.Run(context.Background()))
+
+	require.NoError(t, sub.results[good.ID])
+	require.Error(t, sub.results[bad.ID])
+	require.Contains(t, inbox.processed, uuid.MustParse(good.Attributes["event_id"]))
+	require.NotContains(t, inbox.processed, uuid.MustParse(bad.Attributes["event_id"]))
+	require.Contains(t, inbox.errors, uuid.MustParse(bad.Attributes["event_id"]))
+}
+
+func TestBatchConsumerQuarantinesUndecodableMessage(t *testing.T) {
+	eventID := uuid.New()
+	good := likeMessage(t, uuid.New(), uuid.New(), uuid.New(), time.Now().Add(-time.Hour).UTC())
+	garbage := &gcpubsub.Message{
+		ID:   eventID.String(),
+		Data: []byte{0xff, 0xff, 0xff},
+		Attributes: map[string]string{
+			"event_id":   eventID.String(),
+			"event_type": "profile.engagement.added",
+		},
+	}
+	noID := &gcpubsub.Message{ID: "no-event-id", Data: []byte{0xff}}
+
+	users := &fakeBatchUserRepo{fakeVideoUserStatesRepository: newFakeVideoUserStatesRepository()}
+	stats := &fakeBatchStatsRepo{}
+	inbox := newFakeBatchInbox()
+	store := &fakeBatchQuarantine{}
+	sub := &fakeBatchSubscriber{messages: []*gcpubsub.Message{good, garbage, noID}}
+
+	consumer, err := engagement.NewBatchConsumer(engagement.BatchConsumerParams{
+		Subscriber:    sub,
+		Inbox:         inbox,
+		UserRepo:      users,
+		StatsRepo:     stats,
+		TxManager:     fakeTxManager{},
+		SourceService: "profile",
+		Policy:        engagement.BatchPolicy{MaxEvents: 2, MaxWait: 10 * time.Millisecond},
+		Quarantine:    store,
+		Logger:        log.NewStdLogger(io.Discard),
+	})
+	require.NoError(t, err)
+	require.NoError(t, consumer.Run(context.Background()))
+
+	require.NoError(t, sub.results[good.ID])
+	require.NoError(t, sub.results[garbage.ID])
+	require.NoError(t, sub.results[noID.ID])
+	require.Len(t, store.inputs, 1)
+	require.Equal(t, eventID, store.inputs[0].Event.EventID)
+	require.Equal(t, engagement.QuarantineConsumer, store.inputs[0].Consumer)
+	require.Equal(t, garbage.Data, store.inputs[0].Event.Payload)
+	require.Contains(t, inbox.processed, uuid.MustParse(good.Attributes["event_id"]))
+}
+
+func TestBatchConsumerShardsMergedDeltaPerVideo(t *testing.T) {
+	first, second := uuid.New(), uuid.New()
+	baseTime := time.Now().Add(-time.Hour).UTC()
+	messages := []*gcpubsub.Message{
+		likeMessage(t, uuid.New(), uuid.New(), first, baseTime),
+		likeMessage(t, uuid.New(), uuid.New(), first, baseTime),
+		likeMessage(t, uuid.New(), uuid.New(), second, baseTime),
+	}
+	users := &fakeBatchUserRepo{fakeVideoUserStatesRepository: newFakeVideoUserStatesRepository()}
+	stats := &fakeBatchStatsRepo{}
+	sub := &fakeBatchSubscriber{messages: messages}
+
+	consumer := newTestBatchConsumer(t, sub, newFakeBatchInbox(), users, stats, engagement.BatchPolicy{MaxEvents: 3, MaxWait: time.Minute})
+	require.NoError(t, consumer.Run(context.Background()))
+
+	require.Len(t, stats.batches, 1)
+	batch := stats.batches[0]
+	require.Len(t, batch.Stats, 2)
+	shardKeys := make(map[uuid.UUID]uuid.UUID, len(batch.Stats))
+	for _, item := range batch.Stats {
+		require.NotEqual(t, uuid.Nil, item.Delta.ShardKey)
+		shardKeys[item.VideoID] = item.Delta.ShardKey
+	}
+	require.NotEqual(t, shardKeys[first], shardKeys[second])
+	for _, item := range batch.Rollups {
+		require.Equal(t, shardKeys[item.VideoID], item.Delta.ShardKey)
+	}
+}
+
+func TestBatchConsumerFlushesAfterMaxWait(t *testing.T) {
+	msg := likeMessage(t, uuid.New(), uuid.New(), uuid.New(), time.Now().Add(-time.Hour).UTC())
+	users := &fakeBatchUserRepo{fakeVideoUserStatesRepository: newFakeVideoUserStatesRepository()}
+	stats := &fakeBatchStatsRepo{}
+	sub := &fakeBatchSubscriber{messages: []*gcpubsub.Message{msg}}
+
+	consumer := newTestBatchConsumer(t, sub, newFakeBatchInbox(), users, stats, engagement.BatchPolicy{MaxEvents: 100, MaxWait: 10 * time.Millisecond})
+	require.NoError(t, consumer.Run(context.Background()))
+
+	require.NoError(t, sub.results[msg.ID])
+	require.Len(t, stats.batches, 1)
+}
+
+func TestBatchConsumerNacksWhileReplayHoldsProjection(t *testing.T) {
+	videoID := uuid.New()
+	baseTime := time.Now().Add(-time.Hour).UTC()
+	messages := []*gcpubsub.Message{
+		likeMessage(t, uuid.New(), uuid.New(), videoID, baseTime),
+		likeMessage(t, uuid.New(), uuid.New(), videoID, baseTime),
+	}
+	users := &fakeBatchUserRepo{fakeVideoUserStatesRepository: newFakeVideoUserStatesRepository()}
+	stats := &fakeBatchStatsRepo{}
+	inbox := newFakeBatchInbox()
+	sub := &fakeBatchSubscriber{messages: messages}
+
+	consumer, err := engagement.NewBatchConsumer(engagement.BatchConsumerParams{
+		Subscriber:    sub,
+		Inbox:         inbox,
+		UserRepo:      users,
+		StatsRepo:     stats,
+		Gate:          busyProjectionGate{},
+		TxManager:     fakeTxManager{},
+		SourceService: "profile",
+		Policy:        engagement.BatchPolicy{MaxEvents: 2, MaxWait: time.Minute},
+		Logger:        log.NewStdLogger(io.Discard),
+	})
+	require.NoError(t, err)
+	require.NoError(t, consumer.Run(context.Background()))
+
+	for _, msg := range messages {
+		require.ErrorContains(t, sub.results[msg.ID], "replay in progress")
+	}
+	require.Empty(t, stats.batches)
+	require.Empty(t, inbox.processed)
+	require.Empty(t, inbox.errors, "replay contention is not an event failure")
+}
+
+func TestNewBatchConsumerRequiresBatchPolicy(t *testing.T) {
+	_, err := engagement.NewBatchConsumer(engagement.BatchConsumerParams{
+		Subscriber: &fakeBatchSubscriber{},
+		Inbox:      newFakeBatchInbox(),
+		UserRepo:   &fakeBatchUserRepo{fakeVideoUserStatesRepository: newFakeVideoUserStatesRepository()},
+		StatsRepo:  &fakeBatchStatsRepo{},
+		TxManager:  fakeTxManager{},
+		Policy:     engagement.BatchPolicy{MaxEvents: 1, MaxWait: time.Second},
+	})
+	require.Error(t, err)
+}
+
+func newTestBatchConsumer(t *testing.T, sub gcpubsub.Subscriber, inbox *fakeBatchInbox, users *fakeBatchUserRepo, stats *fakeBatchStatsRepo, policy engagement.BatchPolicy) *engagement.BatchConsumer {
+	t.Helper()
+	consumer, err := engagement.NewBatchConsumer(engagement.BatchConsumerParams{
+		Subscriber:    sub,
+		Inbox:         inbox,
+		UserRepo:      users,
+		StatsRepo:     stats,
+		TxManager:     fakeTxManager{},
+		SourceService: "profile",
+		Policy:        policy,
+		Logger:        log.NewStdLogger(io.Discard),
+	})
+	require.NoError(t, err)
+	return consumer
+}
+
+func likeMessage(t *testing.T, eventID, userID, videoID uuid.UUID, occurredAt time.Time) *gcpubsub.Message {
+	t.Helper()
+	data, err := proto.Marshal(&profilev1.EngagementAddedEvent{
+		EventId:      eventID.String(),
+		UserId:       userID.String(),
+		VideoId:      videoID.String(),
+		FavoriteType: profilev1.FavoriteType_FAVORITE_TYPE_LIKE,
+		OccurredAt:   timestamppb.New(occurredAt),
+	})
+	require.NoError(t, err)
+	return &gcpubsub.Message{
+		ID:   eventID.String(),
+		Data: data,
+		Attributes: map[string]string{
+			"event_id":   eventID.String(),
+			"event_type": "profile.engagement.added",
+		},
+	}
+}
+
+// fakeBatchSubscriber 并发投递全部消息，并记录每条消息处理函数的返回值（nil 即 ack）。
+type fakeBatchSubscriber struct {
+	messages []*gcpubsub.Message
+
+	mu      sync.Mutex
+	results map[string]error
+}
+
+func (f *fakeBatchSubscriber) Receive(ctx context.Context, handler func(context.Context, *gcpubsub.Message) error) error {
+	f.results = make(map[string]error, len(f.messages))
+	var wg sync.WaitGroup
+	for _, msg := range f.messages {
+		wg.Add(1)
+		go func(msg *gcpubsub.Message) {
+			defer wg.Done()
+			err := handler(ctx, msg)
+			f.mu.Lock()
+			f.results[msg.ID] = err
+			f.mu.Unlock()
+		}(msg)
+	}
+	wg.Wait()
+	return nil
+}
+
+func (f *fakeBatchSubscriber) Stop() {}
+
+type fakeBatchInbox struct {
+	received  map[uuid.UUID]time.Time
+	processed map[uuid.UUID]time.Time
+	errors    map[uuid.UUID]string
+}
+
+func newFakeBatchInbox() *fakeBatchInbox {
+	return &fakeBatchInbox{
+		received:  make(map[uuid.UUID]time.Time),
+		processed: make(map[uuid.UUID]time.Time),
+		errors:    make(map[uuid.UUID]string),
+	}
+}
+
+func (f *fakeBatchInbox) InsertBatch(_ context.Context, _ txmanager.Session, _ string, events []repositories.InboxMessage) error {
+	for _, evt := range events {
+		if _, ok := f.received[evt.EventID]; !ok {
+			f.received[evt.EventID] = time.Now().UTC()
+		}
+	}
+	return nil
+}
+
+func (f *fakeBatchInbox) LockUnprocessed(_ context.Context, _ txmanager.Session, eventIDs []uuid.UUID) (map[uuid.UUID]time.Time, error) {
+	pending := make(map[uuid.UUID]time.Time, len(eventIDs))
+	for _, id := range eventIDs {
+		if _, done := f.processed[id]; done {
+			continue
+		}
+		pending[id] = f.received[id]
+	}
+	return pending, nil
+}
+
+func (f *fakeBatchInbox) MarkBatchProcessed(_ context.Context, _ txmanager.Session, eventIDs []uuid.UUID, processedAt time.Time) error {
+	for _, id := range eventIDs {
+		f.processed[id] = processedAt
+	}
+	return nil
+}
+
+func (f *fakeBatchInbox) RecordError(_ context.Context, _ txmanager.Session, eventID uuid.UUID, lastErr string) error {
+	if lastErr == "" {
+		return errors.New("empty error")
+	}
+	f.errors[eventID] = lastErr
+	return nil
+}
+
+type fakeBatchUserRepo struct {
+	*fakeVideoUserStatesRepository
+	batches [][]repositories.UpsertVideoUserStateInput
+}
+
+func (f *fakeBatchUserRepo) UpsertBatch(ctx context.Context, sess txmanager.Session, inputs []repositories.UpsertVideoUserStateInput) error {
+	f.batches = append(f.batches, inputs)
+	for _, input := range inputs {
+		if err := f.Upsert(ctx, sess, input); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type fakeBatchStatsRepo struct {
+	fakeStatsRepo
+	batches []repositories.StatsBatch
+}
+
+func (f *fakeBatchStatsRepo) MarkWatcher(_ context.Context, _ txmanager.Session, videoID, userID uuid.UUID, watchTime time.Time) (*po.VideoWatcherRecord, error) {
+	return &po.VideoWatcherRecord{VideoID: videoID, UserID: userID, FirstWatchedAt: watchTime, LastWatchedAt: watchTime, Inserted: true}, nil
+}
+
+func (f *fakeBatchStatsRepo) ApplyBatch(_ context.Context, _ txmanager.Session, batch repositories.StatsBatch) error {
+	f.batches = append(f.batches, batch)
+	return nil
+}
+
+// fakeBatchQuarantine 记录隔离登记。
+type fakeBatchQuarantine struct {
+	mu     sync.Mutex
+	inputs []repositories.QuarantineInboxEventInput
+}
+
+func (f *fakeBatchQuarantine) Quarantine(_ context.Context, _ txmanager.Session, input repositories.QuarantineInboxEventInput) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.inputs = append(f.inputs, input)
+	return nil
+}
+
+// busyProjectionGate 模拟重放持有投影排他锁。
+type busyProjectionGate struct{}
+
+func (busyProjectionGate) TryLockSharedTx(context.Context, txmanager.Session, string) (bool, error) {
+	return false, nil
+}
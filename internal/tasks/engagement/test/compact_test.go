@@ -0,0 +1,53 @@
+package engagement_test
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/engagement"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsCompactorDrainsFullBatches(t *testing.T) {
+	store := &fakeShardCompactStore{batches: []int64{100, 100, 42}}
+	compactor, err := engagement.NewStatsCompactor(store, fakeTxManager{}, engagement.CompactPolicy{Interval: time.Second, BatchSize: 100}, log.NewStdLogger(io.Discard))
+	require.NoError(t, err)
+
+	folded, err := compactor.CompactOnce(context.Background())
+	require.NoError(t, err)
+	require.EqualValues(t, 242, folded)
+	require.Equal(t, []int{100, 100, 100}, store.limits)
+}
+
+func TestStatsCompactorRequiresBatchSize(t *testing.T) {
+	_, err := engagement.NewStatsCompactor(&fakeShardCompactStore{}, fakeTxManager{}, engagement.CompactPolicy{Interval: time.Second}, log.NewStdLogger(io.Discard))
+	require.Error(t, err)
+}
+
+func TestNewCompactPolicyDisabledWithoutShards(t *testing.T) {
+	cfg := configloader.CountersConfig{Shards: 1, CompactInterval: 10 * time.Second, CompactBatchSize: 1000}
+	require.Equal(t, engagement.CompactPolicy{}, engagement.NewCompactPolicy(cfg))
+
+	cfg.Shards = 16
+	require.Equal(t, engagement.CompactPolicy{Interval: 10 * time.Second, BatchSize: 1000}, engagement.NewCompactPolicy(cfg))
+}
+
+type fakeShardCompactStore struct {
+	batches []int64
+	limits  []int
+}
+
+func (f *fakeShardCompactStore) CompactShards(_ context.Context, _ txmanager.Session, limit int) (int, int64, error) {
+	f.limits = append(f.limits, limit)
+	if len(f.batches) == 0 {
+		return 0, 0, nil
+	}
+	next := f.batches[0]
+	f.batches = f.batches[1:]
+	return 1, next, nil
+}
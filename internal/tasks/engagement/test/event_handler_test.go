@@ -7,9 +7,11 @@ import (
 	"testing"
 	"time"
 
+	profilecontractv1 "github.com/bionicotaku/lingo-services-catalog/api/contracts/profile/v1"
 	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
 	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
 	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/engagement"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/quarantine"
 	profilev1 "github.com/bionicotaku/lingo-services-profile/api/profile/v1"
 	"github.com/bionicotaku/lingo-utils/outbox/store"
 	"github.com/bionicotaku/lingo-utils/txmanager"
@@ -23,7 +25,7 @@ import (
 
 func TestEventHandlerProcessesTimeline(t *testing.T) {
 	repo := newFakeVideoUserStatesRepository()
-	handler := engagement.NewEventHandler(repo, fakeStatsRepo{}, log.NewStdLogger(io.Discard), nil)
+	handler := engagement.NewEventHandler(repo, fakeStatsRepo{}, nil, engagement.ViewPolicy{}, log.NewStdLogger(io.Discard), nil)
 
 	ctx := context.Background()
 	sess := fakeSession{}
@@ -92,7 +94,7 @@ func TestEventHandlerProcessesTimeline(t *testing.T) {
 
 func TestEventHandlerInvalidFavoriteType(t *testing.T) {
 	repo := newFakeVideoUserStatesRepository()
-	handler := engagement.NewEventHandler(repo, fakeStatsRepo{}, log.NewStdLogger(io.Discard), nil)
+	handler := engagement.NewEventHandler(repo, fakeStatsRepo{}, nil, engagement.ViewPolicy{}, log.NewStdLogger(io.Discard), nil)
 
 	userID := uuid.New()
 	videoID := uuid.New()
@@ -111,6 +113,223 @@ func TestEventHandlerInvalidFavoriteType(t *testing.T) {
 	require.False(t, ok)
 }
 
+func TestEventHandlerErrorsAreClassifiedForQuarantine(t *testing.T) {
+	repo := newFakeVideoUserStatesRepository()
+	handler := engagement.NewEventHandler(repo, fakeStatsRepo{}, nil, engagement.ViewPolicy{}, log.NewStdLogger(io.Discard), nil)
+
+	err := handler.Handle(context.Background(), fakeSession{}, &engagement.Event{Payload: []byte{0xff, 0xff}}, &store.InboxEvent{EventType: "profile.engagement.added"})
+	require.Error(t, err)
+	class, permanent := quarantine.Classify(err)
+	require.True(t, permanent)
+	require.Equal(t, "invalid-payload", class)
+
+	evt := marshalEvent(t, &profilev1.EngagementAddedEvent{
+		EventId:      uuid.New().String(),
+		UserId:       "not-a-uuid",
+		VideoId:      uuid.New().String(),
+		FavoriteType: profilev1.FavoriteType_FAVORITE_TYPE_LIKE,
+		OccurredAt:   timestamppb.Now(),
+	})
+	err = handler.Handle(context.Background(), fakeSession{}, evt, &store.InboxEvent{EventType: "profile.engagement.added"})
+	class, permanent = quarantine.Classify(err)
+	require.True(t, permanent)
+	require.Equal(t, "invalid-user-id", class)
+}
+
+func TestEventHandlerOrdersBySequence(t *testing.T) {
+	repo := newFakeVideoUserStatesRepository()
+	handler := engagement.NewEventHandler(repo, fakeStatsRepo{}, nil, engagement.ViewPolicy{}, log.NewStdLogger(io.Discard), nil)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	videoID := uuid.New()
+	at := time.Now().Add(-time.Minute).UTC()
+
+	like := marshalEvent(t, &profilecontractv1.EngagementAddedEvent{
+		EventId:      uuid.New().String(),
+		UserId:       userID.String(),
+		VideoId:      videoID.String(),
+		FavoriteType: profilecontractv1.FavoriteType_FAVORITE_TYPE_LIKE,
+		OccurredAt:   timestamppb.New(at),
+		Sequence:     1,
+	})
+	require.NoError(t, handler.Handle(ctx, fakeSession{}, like, &store.InboxEvent{EventType: "profile.engagement.added"}))
+
+	// 同一时刻的取消点赞：仅比较时间会被当作过期事件，按序号应当生效。
+	unlike := marshalEvent(t, &profilecontractv1.EngagementRemovedEvent{
+		EventId:      uuid.New().String(),
+		UserId:       userID.String(),
+		VideoId:      videoID.String(),
+		FavoriteType: profilecontractv1.FavoriteType_FAVORITE_TYPE_LIKE,
+		OccurredAt:   timestamppb.New(at),
+		Sequence:     2,
+	})
+	require.NoError(t, handler.Handle(ctx, fakeSession{}, unlike, &store.InboxEvent{EventType: "profile.engagement.removed"}))
+
+	state, ok := repo.state(userID, videoID)
+	require.True(t, ok)
+	require.False(t, state.HasLiked)
+	require.NotNil(t, state.LikedSequence)
+	require.EqualValues(t, 2, *state.LikedSequence)
+
+	// 序号更小的事件即使时间更晚（时钟偏差）也被跳过。
+	skewed := marshalEvent(t, &profilecontractv1.EngagementAddedEvent{
+		EventId:      uuid.New().String(),
+		UserId:       userID.String(),
+		VideoId:      videoID.String(),
+		FavoriteType: profilecontractv1.FavoriteType_FAVORITE_TYPE_LIKE,
+		OccurredAt:   timestamppb.New(at.Add(time.Second)),
+		Sequence:     1,
+	})
+	require.NoError(t, handler.Handle(ctx, fakeSession{}, skewed, &store.InboxEvent{EventType: "profile.engagement.added"}))
+
+	state, _ = repo.state(userID, videoID)
+	require.False(t, state.HasLiked)
+	require.EqualValues(t, 2, *state.LikedSequence)
+}
+
+func TestEventHandlerOccurredAtFallsBackToReceivedAt(t *testing.T) {
+	repo := newFakeVideoUserStatesRepository()
+	handler := engagement.NewEventHandler(repo, fakeStatsRepo{}, nil, engagement.ViewPolicy{}, log.NewStdLogger(io.Discard), nil)
+
+	userID := uuid.New()
+	videoID := uuid.New()
+	evt := marshalEvent(t, &profilev1.EngagementAddedEvent{
+		EventId:      uuid.New().String(),
+		UserId:       userID.String(),
+		VideoId:      videoID.String(),
+		FavoriteType: profilev1.FavoriteType_FAVORITE_TYPE_BOOKMARK,
+	})
+
+	err := handler.Handle(context.Background(), fakeSession{}, evt, &store.InboxEvent{EventType: "profile.engagement.added"})
+	require.Error(t, err)
+	_, ok := repo.state(userID, videoID)
+	require.False(t, ok)
+
+	receivedAt := time.Now().Add(-time.Minute).UTC().Truncate(time.Microsecond)
+	require.NoError(t, handler.Handle(context.Background(), fakeSession{}, evt, &store.InboxEvent{EventType: "profile.engagement.added", ReceivedAt: receivedAt}))
+	state, ok := repo.state(userID, videoID)
+	require.True(t, ok)
+	require.True(t, state.HasBookmarked)
+	require.Equal(t, receivedAt, state.BookmarkedOccurredAt.UTC())
+	require.Nil(t, state.BookmarkedSequence)
+}
+
+func TestEventHandlerCountsShares(t *testing.T) {
+	repo := newFakeVideoUserStatesRepository()
+	stats := newRecordingStatsRepo()
+	handler := engagement.NewEventHandler(repo, stats, nil, engagement.ViewPolicy{}, log.NewStdLogger(io.Discard), nil)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	videoID := uuid.New()
+	for i := 0; i < 2; i++ {
+		share := marshalEvent(t, &profilecontractv1.EngagementAddedEvent{
+			EventId:      uuid.New().String(),
+			UserId:       userID.String(),
+			VideoId:      videoID.String(),
+			FavoriteType: profilecontractv1.FavoriteType_FAVORITE_TYPE_SHARE,
+			OccurredAt:   timestamppb.New(time.Now().Add(-time.Minute)),
+		})
+		require.NoError(t, handler.Handle(ctx, fakeSession{}, share, &store.InboxEvent{EventType: "profile.engagement.added"}))
+	}
+	// 分享不可撤销，removed 事件被跳过。
+	unshare := marshalEvent(t, &profilecontractv1.EngagementRemovedEvent{
+		EventId:      uuid.New().String(),
+		UserId:       userID.String(),
+		VideoId:      videoID.String(),
+		FavoriteType: profilecontractv1.FavoriteType_FAVORITE_TYPE_SHARE,
+		OccurredAt:   timestamppb.Now(),
+	})
+	require.NoError(t, handler.Handle(ctx, fakeSession{}, unshare, &store.InboxEvent{EventType: "profile.engagement.removed"}))
+
+	require.EqualValues(t, 2, stats.stats[videoID].ShareCount)
+	_, ok := repo.state(userID, videoID)
+	require.False(t, ok, "shares do not write user state")
+}
+
+func TestEventHandlerAppliesRatings(t *testing.T) {
+	repo := newFakeVideoUserStatesRepository()
+	stats := newRecordingStatsRepo()
+	handler := engagement.NewEventHandler(repo, stats, nil, engagement.ViewPolicy{}, log.NewStdLogger(io.Discard), nil)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	videoID := uuid.New()
+	at := time.Now().Add(-time.Hour).UTC()
+	rate := func(stars int32, occurredAt time.Time) *engagement.Event {
+		return marshalEvent(t, &profilecontractv1.EngagementAddedEvent{
+			EventId:      uuid.New().String(),
+			UserId:       userID.String(),
+			VideoId:      videoID.String(),
+			FavoriteType: profilecontractv1.FavoriteType_FAVORITE_TYPE_RATING,
+			OccurredAt:   timestamppb.New(occurredAt),
+			Rating:       stars,
+		})
+	}
+
+	require.NoError(t, handler.Handle(ctx, fakeSession{}, rate(4, at), &store.InboxEvent{EventType: "profile.engagement.added"}))
+	row := stats.stats[videoID]
+	require.EqualValues(t, 1, row.RatingCount)
+	require.EqualValues(t, 4, row.RatingSum)
+	require.Equal(t, [5]int64{0, 0, 0, 1, 0}, row.RatingDistribution)
+
+	// 改分：人数不变，旧星级 -1、新星级 +1。
+	require.NoError(t, handler.Handle(ctx, fakeSession{}, rate(2, at.Add(time.Minute)), &store.InboxEvent{EventType: "profile.engagement.added"}))
+	require.EqualValues(t, 1, row.RatingCount)
+	require.EqualValues(t, 2, row.RatingSum)
+	require.Equal(t, [5]int64{0, 1, 0, 0, 0}, row.RatingDistribution)
+	state, ok := repo.state(userID, videoID)
+	require.True(t, ok)
+	require.NotNil(t, state.Rating)
+	require.EqualValues(t, 2, *state.Rating)
+
+	// 较早的评分事件按乱序跳过。
+	require.NoError(t, handler.Handle(ctx, fakeSession{}, rate(5, at.Add(-time.Minute)), &store.InboxEvent{EventType: "profile.engagement.added"}))
+	require.EqualValues(t, 2, row.RatingSum)
+
+	unrate := marshalEvent(t, &profilecontractv1.EngagementRemovedEvent{
+		EventId:      uuid.New().String(),
+		UserId:       userID.String(),
+		VideoId:      videoID.String(),
+		FavoriteType: profilecontractv1.FavoriteType_FAVORITE_TYPE_RATING,
+		OccurredAt:   timestamppb.New(at.Add(2 * time.Minute)),
+	})
+	require.NoError(t, handler.Handle(ctx, fakeSession{}, unrate, &store.InboxEvent{EventType: "profile.engagement.removed"}))
+	require.Zero(t, row.RatingCount)
+	require.Zero(t, row.RatingSum)
+	require.Equal(t, [5]int64{}, row.RatingDistribution)
+	state, _ = repo.state(userID, videoID)
+	require.Nil(t, state.Rating)
+
+	// 超出 1-5 的星级跳过并确认，不改变投影。
+	for _, stars := range []int32{0, 6} {
+		require.NoError(t, handler.Handle(ctx, fakeSession{}, rate(stars, at.Add(3*time.Minute)), &store.InboxEvent{EventType: "profile.engagement.added"}))
+	}
+	require.Zero(t, row.RatingCount)
+	state, _ = repo.state(userID, videoID)
+	require.Nil(t, state.Rating)
+}
+
+func TestEventHandlerSkipsUnknownFavoriteType(t *testing.T) {
+	repo := newFakeVideoUserStatesRepository()
+	handler := engagement.NewEventHandler(repo, fakeStatsRepo{}, nil, engagement.ViewPolicy{}, log.NewStdLogger(io.Discard), nil)
+
+	userID := uuid.New()
+	videoID := uuid.New()
+	evt := marshalEvent(t, &profilecontractv1.EngagementAddedEvent{
+		EventId:      uuid.New().String(),
+		UserId:       userID.String(),
+		VideoId:      videoID.String(),
+		FavoriteType: profilecontractv1.FavoriteType(99),
+		OccurredAt:   timestamppb.Now(),
+	})
+	require.NoError(t, handler.Handle(context.Background(), fakeSession{}, evt, &store.InboxEvent{EventType: "profile.engagement.added"}))
+
+	_, ok := repo.state(userID, videoID)
+	require.False(t, ok)
+}
+
 // ---- Test Doubles ----
 
 type fakeVideoUserStatesRepository struct {
@@ -142,6 +361,11 @@ func (f *fakeVideoUserStatesRepository) Upsert(_ context.Context, _ txmanager.Se
 		HasBookmarked:        input.HasBookmarked,
 		LikedOccurredAt:      cloneTime(input.LikedOccurredAt),
 		BookmarkedOccurredAt: cloneTime(input.BookmarkedOccurredAt),
+		LikedSequence:        input.LikedSequence,
+		BookmarkedSequence:   input.BookmarkedSequence,
+		Rating:               input.Rating,
+		RatedOccurredAt:      cloneTime(input.RatedOccurredAt),
+		RatedSequence:        input.RatedSequence,
 		UpdatedAt:            time.Now().UTC(),
 	}
 	return nil
@@ -169,6 +393,11 @@ func cloneState(src po.VideoUserState) *po.VideoUserState {
 		HasBookmarked:        src.HasBookmarked,
 		LikedOccurredAt:      cloneTime(src.LikedOccurredAt),
 		BookmarkedOccurredAt: cloneTime(src.BookmarkedOccurredAt),
+		LikedSequence:        src.LikedSequence,
+		BookmarkedSequence:   src.BookmarkedSequence,
+		Rating:               src.Rating,
+		RatedOccurredAt:      cloneTime(src.RatedOccurredAt),
+		RatedSequence:        src.RatedSequence,
 		UpdatedAt:            src.UpdatedAt,
 	}
 }
@@ -197,6 +426,18 @@ func (fakeStatsRepo) MarkWatcher(context.Context, txmanager.Session, uuid.UUID,
 	return nil, nil
 }
 
+func (fakeStatsRepo) GetViewSession(context.Context, txmanager.Session, uuid.UUID, uuid.UUID) (*po.VideoViewSession, error) {
+	return nil, nil
+}
+
+func (fakeStatsRepo) SaveViewSession(context.Context, txmanager.Session, *po.VideoViewSession) error {
+	return nil
+}
+
+func (fakeStatsRepo) IncrementRollups(context.Context, txmanager.Session, uuid.UUID, time.Time, repositories.StatsDelta) error {
+	return nil
+}
+
 func marshalEvent(t *testing.T, msg proto.Message) *engagement.Event {
 	t.Helper()
 	data, err := proto.Marshal(msg)
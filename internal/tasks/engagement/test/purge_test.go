@@ -0,0 +1,250 @@
+package engagement_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	profilecontractv1 "github.com/bionicotaku/lingo-services-catalog/api/contracts/profile/v1"
+	videov1 "github.com/bionicotaku/lingo-services-catalog/api/video/v1"
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/engagement"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/quarantine"
+	profilev1 "github.com/bionicotaku/lingo-services-profile/api/profile/v1"
+	"github.com/bionicotaku/lingo-utils/outbox/store"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestEventHandlerRequestsVideoPurgeOnDeleteAndArchive(t *testing.T) {
+	purges := newFakePurgeStore()
+	handler := engagement.NewEventHandler(newFakeVideoUserStatesRepository(), fakeStatsRepo{}, purges, engagement.ViewPolicy{}, log.NewStdLogger(io.Discard), nil)
+	ctx := context.Background()
+
+	deletedID, archivedID := uuid.New(), uuid.New()
+	deletedAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	deleteEvt := marshalEvent(t, &videov1.Event{
+		EventType: videov1.EventType_EVENT_TYPE_VIDEO_DELETED,
+		Payload: &videov1.Event_Deleted{Deleted: &videov1.Event_VideoDeleted{
+			VideoId:    deletedID.String(),
+			OccurredAt: deletedAt.Format(time.RFC3339Nano),
+		}},
+	})
+	eventID := uuid.New()
+	require.NoError(t, handler.Handle(ctx, fakeSession{}, deleteEvt, &store.InboxEvent{EventID: eventID, EventType: "catalog.video.deleted"}))
+
+	archived := string(po.VideoStatusArchived)
+	archiveEvt := marshalEvent(t, &videov1.Event{
+		EventType: videov1.EventType_EVENT_TYPE_VIDEO_UPDATED,
+		Payload: &videov1.Event_Updated{Updated: &videov1.Event_VideoUpdated{
+			VideoId:    archivedID.String(),
+			OccurredAt: deletedAt.Add(time.Hour).Format(time.RFC3339Nano),
+			Status:     &archived,
+		}},
+	})
+	require.NoError(t, handler.Handle(ctx, fakeSession{}, archiveEvt, &store.InboxEvent{EventType: "catalog.video.updated"}))
+
+	title := "renamed"
+	renameEvt := marshalEvent(t, &videov1.Event{
+		EventType: videov1.EventType_EVENT_TYPE_VIDEO_UPDATED,
+		Payload: &videov1.Event_Updated{Updated: &videov1.Event_VideoUpdated{
+			VideoId: uuid.NewString(),
+			Title:   &title,
+		}},
+	})
+	require.NoError(t, handler.Handle(ctx, fakeSession{}, renameEvt, &store.InboxEvent{EventType: "catalog.video.updated"}))
+
+	require.Len(t, purges.requests, 2, "metadata-only updates must not trigger a purge")
+	require.Equal(t, po.EngagementPurgeScopeVideo, purges.requests[0].Scope)
+	require.Equal(t, deletedID, purges.requests[0].SubjectID)
+	require.Equal(t, "video.deleted", purges.requests[0].Reason)
+	require.Equal(t, deletedAt, purges.requests[0].RequestedAt)
+	require.NotNil(t, purges.requests[0].SourceEventID)
+	require.Equal(t, eventID, *purges.requests[0].SourceEventID)
+	require.Equal(t, archivedID, purges.requests[1].SubjectID)
+	require.Equal(t, "video.archived", purges.requests[1].Reason)
+}
+
+func TestEventHandlerRequestsUserPurgeOnProfileUserDeleted(t *testing.T) {
+	purges := newFakePurgeStore()
+	handler := engagement.NewEventHandler(newFakeVideoUserStatesRepository(), fakeStatsRepo{}, purges, engagement.ViewPolicy{}, log.NewStdLogger(io.Discard), nil)
+
+	userID := uuid.New()
+	deletedAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	evt := marshalEvent(t, &profilecontractv1.UserDeletedEvent{
+		EventId:    uuid.NewString(),
+		UserId:     userID.String(),
+		OccurredAt: timestamppb.New(deletedAt),
+	})
+
+	err := handler.Handle(context.Background(), fakeSession{}, evt, &store.InboxEvent{
+		EventType:  "profile.user.deleted",
+		ReceivedAt: deletedAt.Add(10 * time.Minute),
+	})
+	require.NoError(t, err)
+	require.Len(t, purges.requests, 1)
+	require.Equal(t, po.EngagementPurgeScopeUser, purges.requests[0].Scope)
+	require.Equal(t, userID, purges.requests[0].SubjectID)
+	require.Equal(t, deletedAt, purges.requests[0].RequestedAt, "cutoff must be the deletion time, not the inbox receive time")
+}
+
+func TestEventHandlerRejectsUserDeletedWithoutOccurredAt(t *testing.T) {
+	purges := newFakePurgeStore()
+	handler := engagement.NewEventHandler(newFakeVideoUserStatesRepository(), fakeStatsRepo{}, purges, engagement.ViewPolicy{}, log.NewStdLogger(io.Discard), nil)
+
+	evt := marshalEvent(t, &profilecontractv1.UserDeletedEvent{UserId: uuid.NewString()})
+	err := handler.Handle(context.Background(), fakeSession{}, evt, &store.InboxEvent{
+		EventType:  "profile.user.deleted",
+		ReceivedAt: time.Now(),
+	})
+	class, permanent := quarantine.Classify(err)
+	require.True(t, permanent)
+	require.Equal(t, "invalid-payload", class)
+	require.Empty(t, purges.requests)
+}
+
+func TestEventHandlerSkipsEventsBeforePurgeCutoff(t *testing.T) {
+	repo := newFakeVideoUserStatesRepository()
+	purges := newFakePurgeStore()
+	handler := engagement.NewEventHandler(repo, fakeStatsRepo{}, purges, engagement.ViewPolicy{}, log.NewStdLogger(io.Discard), nil)
+	ctx := context.Background()
+
+	userID, videoID := uuid.New(), uuid.New()
+	cutoff := time.Now().Add(-time.Minute).UTC()
+	purges.cutoffs[videoID] = cutoff
+
+	late := marshalEvent(t, &profilev1.EngagementAddedEvent{
+		UserId:       userID.String(),
+		VideoId:      videoID.String(),
+		FavoriteType: profilev1.FavoriteType_FAVORITE_TYPE_LIKE,
+		OccurredAt:   timestamppb.New(cutoff.Add(-time.Second)),
+	})
+	require.NoError(t, handler.Handle(ctx, fakeSession{}, late, &store.InboxEvent{EventType: "profile.engagement.added"}))
+	_, ok := repo.state(userID, videoID)
+	require.False(t, ok, "late event for a purged video must not recreate projection rows")
+
+	fresh := marshalEvent(t, &profilev1.EngagementAddedEvent{
+		UserId:       userID.String(),
+		VideoId:      videoID.String(),
+		FavoriteType: profilev1.FavoriteType_FAVORITE_TYPE_LIKE,
+		OccurredAt:   timestamppb.New(cutoff.Add(time.Second)),
+	})
+	require.NoError(t, handler.Handle(ctx, fakeSession{}, fresh, &store.InboxEvent{EventType: "profile.engagement.added"}))
+	state, ok := repo.state(userID, videoID)
+	require.True(t, ok)
+	require.True(t, state.HasLiked)
+}
+
+func TestProjectionPurgerDrainsInBatches(t *testing.T) {
+	job := &po.EngagementPurge{Scope: po.EngagementPurgeScopeVideo, SubjectID: uuid.New(), Reason: "video.deleted", RequestedAt: time.Now()}
+	store := &fakePurgeBatchStore{
+		pending: []*po.EngagementPurge{job},
+		batches: [][]repositories.PurgedRows{
+			{{Table: "video_user_engagements_projection", Rows: 500}, {Table: "video_engagement_watchers", Rows: 120}},
+			{{Table: "video_user_engagements_projection", Rows: 37}},
+			{{Table: "video_user_engagements_projection", Rows: 0}},
+		},
+	}
+	purger, err := engagement.NewProjectionPurger(store, fakeTxManager{}, engagement.PurgePolicy{Interval: time.Minute, BatchSize: 500}, log.NewStdLogger(io.Discard))
+	require.NoError(t, err)
+
+	removed, err := purger.PurgeOnce(context.Background())
+	require.NoError(t, err)
+	require.EqualValues(t, 657, removed)
+	require.Equal(t, []int64{620, 37}, store.progress)
+	require.Equal(t, 1, store.completed)
+	require.Equal(t, []int{500, 500, 500}, store.batchSizes)
+}
+
+func TestProjectionPurgerContinuesPastFailedJob(t *testing.T) {
+	failing := &po.EngagementPurge{Scope: po.EngagementPurgeScopeVideo, SubjectID: uuid.New(), Reason: "video.deleted", RequestedAt: time.Now()}
+	healthy := &po.EngagementPurge{Scope: po.EngagementPurgeScopeUser, SubjectID: uuid.New(), Reason: "user.deleted", RequestedAt: time.Now()}
+	store := &fakePurgeBatchStore{
+		pending: []*po.EngagementPurge{failing, healthy},
+		fail:    map[uuid.UUID]error{failing.SubjectID: errors.New("boom")},
+		batches: [][]repositories.PurgedRows{
+			{{Table: "video_user_engagements_projection", Rows: 3}},
+			{{Table: "video_user_engagements_projection", Rows: 0}},
+		},
+	}
+	purger, err := engagement.NewProjectionPurger(store, fakeTxManager{}, engagement.PurgePolicy{Interval: time.Minute, BatchSize: 500}, log.NewStdLogger(io.Discard))
+	require.NoError(t, err)
+
+	removed, err := purger.PurgeOnce(context.Background())
+	require.Error(t, err)
+	require.ErrorContains(t, err, failing.SubjectID.String())
+	require.EqualValues(t, 3, removed)
+	require.Equal(t, 1, store.completed, "later jobs must still be processed after a failure")
+}
+
+func TestProjectionPurgerRequiresBatchSize(t *testing.T) {
+	_, err := engagement.NewProjectionPurger(&fakePurgeBatchStore{}, fakeTxManager{}, engagement.PurgePolicy{Interval: time.Minute}, log.NewStdLogger(io.Discard))
+	require.Error(t, err)
+}
+
+type fakePurgeStore struct {
+	requests []repositories.RequestPurgeInput
+	cutoffs  map[uuid.UUID]time.Time
+}
+
+func newFakePurgeStore() *fakePurgeStore {
+	return &fakePurgeStore{cutoffs: make(map[uuid.UUID]time.Time)}
+}
+
+func (f *fakePurgeStore) RequestPurge(_ context.Context, _ txmanager.Session, input repositories.RequestPurgeInput) error {
+	f.requests = append(f.requests, input)
+	return nil
+}
+
+func (f *fakePurgeStore) GetPurgeCutoff(_ context.Context, _ txmanager.Session, userID, videoID uuid.UUID) (*time.Time, error) {
+	var latest *time.Time
+	for _, id := range []uuid.UUID{userID, videoID} {
+		if at, ok := f.cutoffs[id]; ok && (latest == nil || at.After(*latest)) {
+			copied := at
+			latest = &copied
+		}
+	}
+	return latest, nil
+}
+
+type fakePurgeBatchStore struct {
+	pending    []*po.EngagementPurge
+	fail       map[uuid.UUID]error
+	batches    [][]repositories.PurgedRows
+	batchSizes []int
+	progress   []int64
+	completed  int
+}
+
+func (f *fakePurgeBatchStore) ListPendingPurges(context.Context, txmanager.Session, int) ([]*po.EngagementPurge, error) {
+	return f.pending, nil
+}
+
+func (f *fakePurgeBatchStore) PurgeBatch(_ context.Context, _ txmanager.Session, purge *po.EngagementPurge, batchSize int) ([]repositories.PurgedRows, error) {
+	if err := f.fail[purge.SubjectID]; err != nil {
+		return nil, err
+	}
+	f.batchSizes = append(f.batchSizes, batchSize)
+	if len(f.batches) == 0 {
+		return nil, nil
+	}
+	next := f.batches[0]
+	f.batches = f.batches[1:]
+	return next, nil
+}
+
+func (f *fakePurgeBatchStore) AddPurgeProgress(_ context.Context, _ txmanager.Session, _ *po.EngagementPurge, rows int64) error {
+	f.progress = append(f.progress, rows)
+	return nil
+}
+
+func (f *fakePurgeBatchStore) CompletePurge(context.Context, txmanager.Session, *po.EngagementPurge) (bool, error) {
+	f.completed++
+	return true, nil
+}
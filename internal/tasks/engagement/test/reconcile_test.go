@@ -0,0 +1,111 @@
+package engagement_test
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/engagement"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcilerRepairsDriftedStats(t *testing.T) {
+	store := newFakeReconcileStore(
+		&po.VideoEngagementStatsDrift{VideoID: uuid.MustParse("00000000-0000-0000-0000-000000000001"), StoredLikeCount: 3, ActualLikeCount: 3},
+		&po.VideoEngagementStatsDrift{VideoID: uuid.MustParse("00000000-0000-0000-0000-000000000002"), StoredLikeCount: 5, ActualLikeCount: 3, StoredUniqueWatchers: 1, ActualUniqueWatchers: 2},
+		&po.VideoEngagementStatsDrift{VideoID: uuid.MustParse("00000000-0000-0000-0000-000000000003"), StoredBookmarkCount: 0, ActualBookmarkCount: 4},
+	)
+	reconciler := newTestReconciler(t, store)
+
+	report, err := reconciler.Run(context.Background(), engagement.ReconcileOptions{BatchSize: 2})
+	require.NoError(t, err)
+	require.Equal(t, 3, report.Scanned)
+	require.Equal(t, 2, report.Drifted)
+	require.Equal(t, 2, report.Repaired)
+	require.Equal(t, int64(2), report.LikeDrift)
+	require.Equal(t, int64(4), report.BookmarkDrift)
+	require.Equal(t, int64(1), report.UniqueWatcherDrift)
+	require.Len(t, report.Samples, 2)
+
+	// 两页扫描，每页的漂移视频各在一个事务中修复。
+	require.Len(t, store.repairs, 2)
+	require.Equal(t, []uuid.UUID{store.rows[1].VideoID}, store.repairs[0])
+	require.Equal(t, []uuid.UUID{store.rows[2].VideoID}, store.repairs[1])
+	require.Equal(t, uuid.Nil, store.filters[0].AfterVideoID)
+	require.Equal(t, store.rows[1].VideoID, store.filters[1].AfterVideoID)
+}
+
+func TestReconcilerDryRunOnlyReports(t *testing.T) {
+	store := newFakeReconcileStore(
+		&po.VideoEngagementStatsDrift{VideoID: uuid.New(), StoredLikeCount: 1, ActualLikeCount: 0},
+	)
+	reconciler := newTestReconciler(t, store)
+
+	report, err := reconciler.Run(context.Background(), engagement.ReconcileOptions{DryRun: true})
+	require.NoError(t, err)
+	require.True(t, report.DryRun)
+	require.Equal(t, 1, report.Drifted)
+	require.Zero(t, report.Repaired)
+	require.Empty(t, store.repairs)
+}
+
+func TestReconcilerPassesScope(t *testing.T) {
+	store := newFakeReconcileStore()
+	reconciler := newTestReconciler(t, store)
+
+	videoID := uuid.New()
+	since := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	_, err := reconciler.Run(context.Background(), engagement.ReconcileOptions{VideoID: &videoID, Since: &since})
+	require.NoError(t, err)
+	require.Len(t, store.filters, 1)
+	require.Equal(t, &videoID, store.filters[0].VideoID)
+	require.Equal(t, &since, store.filters[0].Since)
+}
+
+func newTestReconciler(t *testing.T, store *fakeReconcileStore) *engagement.Reconciler {
+	t.Helper()
+	reconciler, err := engagement.NewReconciler(store, fakeTxManager{}, log.NewStdLogger(io.Discard))
+	require.NoError(t, err)
+	return reconciler
+}
+
+type fakeReconcileStore struct {
+	rows    []*po.VideoEngagementStatsDrift
+	filters []repositories.StatsDriftFilter
+	repairs [][]uuid.UUID
+}
+
+// newFakeReconcileStore 要求 rows 已按 video_id 升序排列。
+func newFakeReconcileStore(rows ...*po.VideoEngagementStatsDrift) *fakeReconcileStore {
+	return &fakeReconcileStore{rows: rows}
+}
+
+func (f *fakeReconcileStore) ListDrift(_ context.Context, _ txmanager.Session, filter repositories.StatsDriftFilter) ([]*po.VideoEngagementStatsDrift, error) {
+	f.filters = append(f.filters, filter)
+	var out []*po.VideoEngagementStatsDrift
+	for _, row := range f.rows {
+		if row.VideoID.String() <= filter.AfterVideoID.String() {
+			continue
+		}
+		out = append(out, row)
+		if len(out) == filter.Limit {
+			break
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeReconcileStore) Repair(_ context.Context, _ txmanager.Session, videoIDs []uuid.UUID) ([]*po.VideoEngagementStatsProjection, error) {
+	f.repairs = append(f.repairs, append([]uuid.UUID(nil), videoIDs...))
+	repaired := make([]*po.VideoEngagementStatsProjection, 0, len(videoIDs))
+	for _, id := range videoIDs {
+		repaired = append(repaired, &po.VideoEngagementStatsProjection{VideoID: id})
+	}
+	return repaired, nil
+}
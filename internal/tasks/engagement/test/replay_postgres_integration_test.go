@@ -0,0 +1,102 @@
+package engagement_test
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/engagement"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/retention"
+	profilev1 "github.com/bionicotaku/lingo-services-profile/api/profile/v1"
+	outboxcfg "github.com/bionicotaku/lingo-utils/outbox/config"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// TestReplayAfterRetentionPrune 先按保留期清理 Inbox 再执行重放：Engagement 事件不参与清理，
+// 重放仍能看到完整历史并按发生时间重建出与线上一致的投影。
+func TestReplayAfterRetentionPrune(t *testing.T) {
+	ctx := context.Background()
+	pool, txMgr, cleanup := newStatsPostgres(ctx, t)
+	defer cleanup()
+
+	logger := log.NewStdLogger(io.Discard)
+	inboxRepo := repositories.NewInboxRepository(pool, logger, outboxcfg.Config{Schema: "catalog"})
+	userRepo := repositories.NewVideoUserStatesRepository(pool, logger)
+	statsRepo := repositories.NewVideoEngagementStatsRepository(pool, logger, repositories.StatsShardPolicy{})
+
+	userID, videoID := uuid.New(), uuid.New()
+	old := time.Now().UTC().Add(-60 * 24 * time.Hour).Truncate(time.Millisecond)
+
+	likeID, likePayload, err := buildEngagementAdded(userID, videoID, profilev1.FavoriteType_FAVORITE_TYPE_LIKE, old)
+	require.NoError(t, err)
+	unlikeID, unlikePayload, err := buildEngagementRemoved(userID, videoID, profilev1.FavoriteType_FAVORITE_TYPE_LIKE, old.Add(time.Minute))
+	require.NoError(t, err)
+	bookmarkID, bookmarkPayload, err := buildEngagementAdded(userID, videoID, profilev1.FavoriteType_FAVORITE_TYPE_BOOKMARK, old.Add(2*time.Minute))
+	require.NoError(t, err)
+	otherID := uuid.New()
+
+	// 取消点赞先于点赞写入 Inbox，验证重放按发生时间而非接收顺序应用。
+	events := []struct {
+		id        uuid.UUID
+		eventType string
+		payload   []byte
+	}{
+		{unlikeID, "profile.engagement.removed", unlikePayload},
+		{likeID, "profile.engagement.added", likePayload},
+		{bookmarkID, "profile.engagement.added", bookmarkPayload},
+		{otherID, "media.video.ready", []byte(`{}`)},
+	}
+	for _, evt := range events {
+		require.NoError(t, inboxRepo.Insert(ctx, nil, repositories.InboxMessage{
+			EventID:       evt.id,
+			SourceService: "profile",
+			EventType:     evt.eventType,
+			Payload:       evt.payload,
+		}))
+		require.NoError(t, inboxRepo.MarkProcessed(ctx, nil, evt.id, old))
+	}
+
+	pruner, err := retention.NewPruner(repositories.NewRetentionRepository(pool, logger), txMgr, retention.NewPolicy(configloader.RetentionConfig{
+		InboxProcessedRetention: 720 * time.Hour,
+		BatchSize:               100,
+	}), nil, logger)
+	require.NoError(t, err)
+	result, err := pruner.PruneOnce(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), result.Inbox, "only the non-engagement inbox row is pruned")
+
+	replayer, err := engagement.NewReplayer(engagement.ReplayerParams{
+		Store:     repositories.NewEngagementReplayRepository(pool, logger),
+		Locker:    repositories.NewAdvisoryLockRepository(pool, logger),
+		UserRepo:  userRepo,
+		StatsRepo: statsRepo,
+		TxManager: txMgr,
+		Logger:    logger,
+	})
+	require.NoError(t, err)
+
+	report, err := replayer.Run(ctx, engagement.ReplayOptions{ReplayID: "after-prune", BatchSize: 2})
+	require.NoError(t, err)
+	require.True(t, report.Completed)
+	require.Equal(t, 3, report.Scanned)
+	require.Equal(t, int64(3), report.Applied)
+
+	state, err := userRepo.Get(ctx, nil, userID, videoID)
+	require.NoError(t, err)
+	require.False(t, state.HasLiked, "removal occurred after the like")
+	require.True(t, state.HasBookmarked)
+
+	stats, err := statsRepo.Get(ctx, nil, videoID)
+	require.NoError(t, err)
+	require.Zero(t, stats.LikeCount)
+	require.Equal(t, int64(1), stats.BookmarkCount)
+
+	var stamped time.Time
+	require.NoError(t, pool.QueryRow(ctx, `SELECT occurred_at FROM catalog.inbox_events WHERE event_id = $1`, unlikeID).Scan(&stamped))
+	require.True(t, stamped.Equal(old.Add(time.Minute)), "replay stamps the payload occurred_at onto the inbox row")
+}
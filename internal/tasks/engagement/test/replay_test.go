@@ -0,0 +1,446 @@
+package engagement_test
+
+import (
+	"context"
+	"io"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/engagement"
+	profilev1 "github.com/bionicotaku/lingo-services-profile/api/profile/v1"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestReplayerRebuildsFromInboxHistory(t *testing.T) {
+	userID, videoA, videoB := uuid.New(), uuid.New(), uuid.New()
+	base := time.Date(2025, time.March, 1, 8, 0, 0, 0, time.UTC)
+
+	replayStore := newFakeReplayStore()
+	// 收到顺序与发生顺序相反：取消点赞先到、点赞后到；重放按发生时间排序，点赞先于取消应用。
+	removed := replayStore.add(t, base.Add(time.Minute), "profile.engagement.removed", &profilev1.EngagementRemovedEvent{
+		UserId: userID.String(), VideoId: videoA.String(),
+		FavoriteType: profilev1.FavoriteType_FAVORITE_TYPE_LIKE, OccurredAt: timestamppb.New(base.Add(2 * time.Second)),
+	})
+	added := replayStore.add(t, base.Add(2*time.Minute), "profile.engagement.added", &profilev1.EngagementAddedEvent{
+		UserId: userID.String(), VideoId: videoA.String(),
+		FavoriteType: profilev1.FavoriteType_FAVORITE_TYPE_LIKE, OccurredAt: timestamppb.New(base.Add(time.Second)),
+	})
+	bookmarked := replayStore.add(t, base.Add(3*time.Minute), "profile.engagement.added", &profilev1.EngagementAddedEvent{
+		UserId: userID.String(), VideoId: videoB.String(),
+		FavoriteType: profilev1.FavoriteType_FAVORITE_TYPE_BOOKMARK, OccurredAt: timestamppb.New(base.Add(3 * time.Second)),
+	})
+	raw := replayStore.addRaw(base.Add(4*time.Minute), "profile.engagement.added", []byte("not-protobuf"))
+
+	userRepo := newFakeVideoUserStatesRepository()
+	replayer := newTestReplayer(t, replayStore, userRepo)
+
+	report, err := replayer.Run(context.Background(), engagement.ReplayOptions{ReplayID: "rebuild", BatchSize: 2})
+	require.NoError(t, err)
+	require.True(t, report.Completed)
+	require.False(t, report.Resumed)
+	require.Equal(t, 4, report.Scanned)
+	require.Equal(t, 1, report.Undecodable)
+	require.Equal(t, int64(3), report.Applied)
+	require.Equal(t, []bool{true}, replayStore.resets)
+	require.Equal(t, []uuid.UUID{added, removed, bookmarked, raw}, replayStore.listed, "events are replayed in occurred_at order")
+
+	stateA, ok := userRepo.state(userID, videoA)
+	require.True(t, ok)
+	require.False(t, stateA.HasLiked, "removal occurred last and must win")
+	require.Equal(t, base.Add(2*time.Second), stateA.LikedOccurredAt.UTC())
+
+	stateB, ok := userRepo.state(userID, videoB)
+	require.True(t, ok)
+	require.True(t, stateB.HasBookmarked)
+
+	cp := replayStore.checkpoints["rebuild"]
+	require.NotNil(t, cp)
+	require.NotNil(t, cp.CompletedAt)
+	require.Equal(t, int64(3), cp.AppliedCount)
+	require.Equal(t, raw, *cp.LastEventID, "cursor advances past undecodable events")
+	require.Equal(t, base.Add(4*time.Minute), cp.LastOccurredAt.UTC(), "undecodable events are ordered by received_at")
+	require.Nil(t, cp.LastReceivedAt)
+}
+
+func TestReplayerResumesFromCheckpoint(t *testing.T) {
+	userID, videoID := uuid.New(), uuid.New()
+	base := time.Date(2025, time.March, 1, 8, 0, 0, 0, time.UTC)
+
+	replayStore := newFakeReplayStore()
+	first := replayStore.add(t, base, "profile.engagement.added", &profilev1.EngagementAddedEvent{
+		UserId: userID.String(), VideoId: videoID.String(),
+		FavoriteType: profilev1.FavoriteType_FAVORITE_TYPE_LIKE, OccurredAt: timestamppb.New(base),
+	})
+	replayStore.add(t, base.Add(time.Minute), "profile.engagement.added", &profilev1.EngagementAddedEvent{
+		UserId: userID.String(), VideoId: videoID.String(),
+		FavoriteType: profilev1.FavoriteType_FAVORITE_TYPE_BOOKMARK, OccurredAt: timestamppb.New(base.Add(time.Minute)),
+	})
+	replayStore.checkpoints["resume"] = &po.EngagementReplayCheckpoint{
+		ReplayID:       "resume",
+		LastOccurredAt: &base,
+		LastEventID:    &first,
+		AppliedCount:   1,
+	}
+
+	userRepo := newFakeVideoUserStatesRepository()
+	replayer := newTestReplayer(t, replayStore, userRepo)
+
+	report, err := replayer.Run(context.Background(), engagement.ReplayOptions{ReplayID: "resume"})
+	require.NoError(t, err)
+	require.True(t, report.Resumed)
+	require.Equal(t, int64(2), report.Applied)
+	require.Empty(t, replayStore.resets, "resume must not reset the projection")
+
+	state, ok := userRepo.state(userID, videoID)
+	require.True(t, ok)
+	require.True(t, state.HasBookmarked)
+	require.False(t, state.HasLiked, "event before checkpoint must not be reapplied")
+}
+
+func TestReplayerRejectsReceivedOrderCheckpoint(t *testing.T) {
+	base := time.Date(2025, time.March, 1, 8, 0, 0, 0, time.UTC)
+	eventID := uuid.New()
+	replayStore := newFakeReplayStore()
+	replayStore.checkpoints["legacy"] = &po.EngagementReplayCheckpoint{
+		ReplayID:       "legacy",
+		LastOccurredAt: &base,
+		LastEventID:    &eventID,
+		LastReceivedAt: &base,
+	}
+
+	replayer := newTestReplayer(t, replayStore, newFakeVideoUserStatesRepository())
+	_, err := replayer.Run(context.Background(), engagement.ReplayOptions{ReplayID: "legacy"})
+	require.ErrorContains(t, err, "received order")
+	require.Empty(t, replayStore.resets)
+}
+
+func TestReplayerRejectsCheckpointWithDifferentScope(t *testing.T) {
+	replayStore := newFakeReplayStore()
+	other := uuid.New()
+	replayStore.checkpoints["scoped"] = &po.EngagementReplayCheckpoint{ReplayID: "scoped", ScopeVideoID: &other}
+
+	replayer := newTestReplayer(t, replayStore, newFakeVideoUserStatesRepository())
+	_, err := replayer.Run(context.Background(), engagement.ReplayOptions{ReplayID: "scoped"})
+	require.ErrorContains(t, err, "different scope")
+
+	report, err := replayer.Run(context.Background(), engagement.ReplayOptions{ReplayID: "scoped", Restart: true})
+	require.NoError(t, err)
+	require.True(t, report.Completed)
+	require.Nil(t, replayStore.checkpoints["scoped"].ScopeVideoID)
+}
+
+func TestReplayerDryRunReportsDiff(t *testing.T) {
+	userID, videoA, videoB := uuid.New(), uuid.New(), uuid.New()
+	base := time.Date(2025, time.March, 1, 8, 0, 0, 0, time.UTC)
+
+	replayStore := newFakeReplayStore()
+	replayStore.add(t, base, "profile.engagement.added", &profilev1.EngagementAddedEvent{
+		UserId: userID.String(), VideoId: videoA.String(),
+		FavoriteType: profilev1.FavoriteType_FAVORITE_TYPE_LIKE, OccurredAt: timestamppb.New(base),
+	})
+	replayStore.add(t, base.Add(time.Minute), "profile.watch.progressed", &profilev1.WatchProgressedEvent{
+		UserId: userID.String(), VideoId: videoA.String(),
+		Progress: &profilev1.WatchProgress{LastWatchedAt: timestamppb.New(base.Add(time.Minute))},
+	})
+	stale := base.Add(-time.Hour)
+	replayStore.currentStates = []*po.VideoUserState{
+		{UserID: userID, VideoID: videoA, HasLiked: false, LikedOccurredAt: &stale},
+		{UserID: userID, VideoID: videoB, HasBookmarked: true},
+	}
+	watchedAt := base.Add(time.Minute)
+	replayStore.currentStats = []*po.VideoEngagementStatsProjection{
+		{VideoID: videoA, LikeCount: 1, WatchCount: 1, UniqueWatchers: 1, FirstWatchAt: &watchedAt, LastWatchAt: &watchedAt, PositionViewers: 1},
+	}
+
+	userRepo := newFakeVideoUserStatesRepository()
+	replayer := newTestReplayer(t, replayStore, userRepo)
+
+	report, err := replayer.Run(context.Background(), engagement.ReplayOptions{DryRun: true})
+	require.NoError(t, err)
+	require.True(t, report.DryRun)
+	require.Equal(t, int64(2), report.Applied)
+	require.Empty(t, replayStore.resets)
+	require.Empty(t, replayStore.checkpoints)
+	_, written := userRepo.state(userID, videoA)
+	require.False(t, written, "dry-run must not write projections")
+
+	require.NotNil(t, report.Diff)
+	require.Equal(t, 1, report.Diff.UserStates.Changed)
+	require.Equal(t, 1, report.Diff.UserStates.Removed)
+	require.Equal(t, 0, report.Diff.UserStates.Added)
+	require.Len(t, report.Diff.UserStates.Samples, 2)
+	require.NotNil(t, report.Diff.Stats)
+	require.Equal(t, 1, report.Diff.Stats.Unchanged)
+}
+
+func TestReplayerUserScopeLeavesStats(t *testing.T) {
+	userID, otherUser, videoID := uuid.New(), uuid.New(), uuid.New()
+	base := time.Date(2025, time.March, 1, 8, 0, 0, 0, time.UTC)
+
+	replayStore := newFakeReplayStore()
+	replayStore.add(t, base, "profile.engagement.added", &profilev1.EngagementAddedEvent{
+		UserId: userID.String(), VideoId: videoID.String(),
+		FavoriteType: profilev1.FavoriteType_FAVORITE_TYPE_LIKE, OccurredAt: timestamppb.New(base),
+	})
+	replayStore.add(t, base, "profile.engagement.added", &profilev1.EngagementAddedEvent{
+		UserId: otherUser.String(), VideoId: videoID.String(),
+		FavoriteType: profilev1.FavoriteType_FAVORITE_TYPE_LIKE, OccurredAt: timestamppb.New(base),
+	})
+	replayStore.add(t, base.Add(time.Minute), "profile.watch.progressed", &profilev1.WatchProgressedEvent{
+		UserId: userID.String(), VideoId: videoID.String(),
+		Progress: &profilev1.WatchProgress{LastWatchedAt: timestamppb.New(base.Add(time.Minute))},
+	})
+
+	userRepo := newFakeVideoUserStatesRepository()
+	replayer := newTestReplayer(t, replayStore, userRepo)
+
+	report, err := replayer.Run(context.Background(), engagement.ReplayOptions{
+		ReplayID: "user",
+		Scope:    repositories.ReplayScope{UserID: &userID},
+	})
+	require.NoError(t, err)
+	require.False(t, report.StatsRebuilt)
+	require.Equal(t, 1, report.Matched)
+	require.Equal(t, []bool{false}, replayStore.resets)
+
+	_, ok := userRepo.state(otherUser, videoID)
+	require.False(t, ok)
+}
+
+func TestReplayerPicksUpEventsArrivingBeforeCompletion(t *testing.T) {
+	userID, videoA, videoB := uuid.New(), uuid.New(), uuid.New()
+	base := time.Date(2025, time.March, 1, 8, 0, 0, 0, time.UTC)
+
+	replayStore := newFakeReplayStore()
+	replayStore.add(t, base, "profile.engagement.added", &profilev1.EngagementAddedEvent{
+		UserId: userID.String(), VideoId: videoA.String(),
+		FavoriteType: profilev1.FavoriteType_FAVORITE_TYPE_LIKE, OccurredAt: timestamppb.New(base),
+	})
+	replayStore.onDrained = func() {
+		replayStore.add(t, base.Add(time.Minute), "profile.engagement.added", &profilev1.EngagementAddedEvent{
+			UserId: userID.String(), VideoId: videoB.String(),
+			FavoriteType: profilev1.FavoriteType_FAVORITE_TYPE_LIKE, OccurredAt: timestamppb.New(base.Add(time.Minute)),
+		})
+	}
+
+	userRepo := newFakeVideoUserStatesRepository()
+	replayer := newTestReplayer(t, replayStore, userRepo)
+
+	report, err := replayer.Run(context.Background(), engagement.ReplayOptions{ReplayID: "tail"})
+	require.NoError(t, err)
+	require.True(t, report.Completed)
+	require.Equal(t, int64(2), report.Applied)
+
+	state, ok := userRepo.state(userID, videoB)
+	require.True(t, ok, "event that arrived at the high-water mark must be replayed")
+	require.True(t, state.HasLiked)
+}
+
+func TestReplayerHoldsProjectionLock(t *testing.T) {
+	replayStore := newFakeReplayStore()
+	locker := &fakeProjectionLocker{}
+	replayer, err := engagement.NewReplayer(engagement.ReplayerParams{
+		Store:     replayStore,
+		Locker:    locker,
+		UserRepo:  newFakeVideoUserStatesRepository(),
+		StatsRepo: fakeStatsRepo{},
+		TxManager: fakeTxManager{},
+		Logger:    log.NewStdLogger(io.Discard),
+	})
+	require.NoError(t, err)
+
+	_, err = replayer.Run(context.Background(), engagement.ReplayOptions{ReplayID: "locked"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"catalog.engagement.projection"}, locker.locked)
+	require.Equal(t, 1, locker.released)
+
+	_, err = replayer.Run(context.Background(), engagement.ReplayOptions{DryRun: true})
+	require.NoError(t, err)
+	require.Len(t, locker.locked, 1, "dry-run does not write and must not block live consumers")
+}
+
+func newTestReplayer(t *testing.T, store *fakeReplayStore, userRepo *fakeVideoUserStatesRepository) *engagement.Replayer {
+	t.Helper()
+	replayer, err := engagement.NewReplayer(engagement.ReplayerParams{
+		Store:     store,
+		UserRepo:  userRepo,
+		StatsRepo: fakeStatsRepo{},
+		TxManager: fakeTxManager{},
+		Logger:    log.NewStdLogger(io.Discard),
+	})
+	require.NoError(t, err)
+	return replayer
+}
+
+type fakeTxManager struct{}
+
+func (fakeTxManager) WithinTx(ctx context.Context, _ txmanager.TxOptions, fn func(context.Context, txmanager.Session) error) error {
+	return fn(ctx, fakeSession{})
+}
+
+func (fakeTxManager) WithinReadOnlyTx(ctx context.Context, _ txmanager.TxOptions, fn func(context.Context, txmanager.Session) error) error {
+	return fn(ctx, fakeSession{})
+}
+
+type fakeReplayStore struct {
+	inbox         []*po.InboxEventRecord
+	checkpoints   map[string]*po.EngagementReplayCheckpoint
+	resets        []bool
+	currentStates []*po.VideoUserState
+	currentStats  []*po.VideoEngagementStatsProjection
+	// listed 按返回顺序记录分页读出的事件；完成前 limit=1 的高水位复核不计入。
+	listed []uuid.UUID
+	// onDrained 在首次扫描到末尾时触发一次，模拟翻页结束与完成检查点之间有新事件到达。
+	onDrained func()
+}
+
+func newFakeReplayStore() *fakeReplayStore {
+	return &fakeReplayStore{checkpoints: make(map[string]*po.EngagementReplayCheckpoint)}
+}
+
+func (f *fakeReplayStore) add(t *testing.T, receivedAt time.Time, eventType string, msg proto.Message) uuid.UUID {
+	t.Helper()
+	payload, err := proto.Marshal(msg)
+	require.NoError(t, err)
+	return f.addRaw(receivedAt, eventType, payload)
+}
+
+// addRaw 登记一条已处理、尚未标注发生时间的 Inbox 事件。
+func (f *fakeReplayStore) addRaw(receivedAt time.Time, eventType string, payload []byte) uuid.UUID {
+	record := &po.InboxEventRecord{EventID: uuid.New(), EventType: eventType, Payload: payload, ReceivedAt: receivedAt}
+	f.inbox = append(f.inbox, record)
+	return record.EventID
+}
+
+func (f *fakeReplayStore) sorted(key func(*po.InboxEventRecord) time.Time) []*po.InboxEventRecord {
+	out := append([]*po.InboxEventRecord(nil), f.inbox...)
+	sort.Slice(out, func(i, j int) bool {
+		if !key(out[i]).Equal(key(out[j])) {
+			return key(out[i]).Before(key(out[j]))
+		}
+		return out[i].EventID.String() < out[j].EventID.String()
+	})
+	return out
+}
+
+func (f *fakeReplayStore) ListInboxEvents(_ context.Context, _ txmanager.Session, _ []string, after *repositories.InboxCursor, limit int) ([]*po.InboxEventRecord, error) {
+	var out []*po.InboxEventRecord
+	for _, record := range f.sorted(func(r *po.InboxEventRecord) time.Time { return r.OccurredAt }) {
+		if record.OccurredAt.IsZero() {
+			continue
+		}
+		if after != nil {
+			if record.OccurredAt.Before(after.OccurredAt) {
+				continue
+			}
+			if record.OccurredAt.Equal(after.OccurredAt) && record.EventID.String() <= after.EventID.String() {
+				continue
+			}
+		}
+		copied := *record
+		out = append(out, &copied)
+		if len(out) == limit {
+			break
+		}
+	}
+	if limit > 1 {
+		for _, record := range out {
+			f.listed = append(f.listed, record.EventID)
+		}
+	}
+	if len(out) == 0 && f.onDrained != nil {
+		hook := f.onDrained
+		f.onDrained = nil
+		hook()
+	}
+	return out, nil
+}
+
+func (f *fakeReplayStore) ListUnstampedInboxEvents(_ context.Context, _ txmanager.Session, _ []string, limit int) ([]*po.InboxEventRecord, error) {
+	var out []*po.InboxEventRecord
+	for _, record := range f.sorted(func(r *po.InboxEventRecord) time.Time { return r.ReceivedAt }) {
+		if !record.OccurredAt.IsZero() {
+			continue
+		}
+		copied := *record
+		out = append(out, &copied)
+		if len(out) == limit {
+			break
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeReplayStore) StampOccurredAt(_ context.Context, _ txmanager.Session, stamps []repositories.InboxOccurrence) error {
+	for _, stamp := range stamps {
+		for _, record := range f.inbox {
+			if record.EventID == stamp.EventID && record.OccurredAt.IsZero() {
+				record.OccurredAt = stamp.OccurredAt
+			}
+		}
+	}
+	return nil
+}
+
+func (f *fakeReplayStore) GetCheckpoint(_ context.Context, _ txmanager.Session, replayID string) (*po.EngagementReplayCheckpoint, error) {
+	cp, ok := f.checkpoints[replayID]
+	if !ok {
+		return nil, repositories.ErrReplayCheckpointNotFound
+	}
+	copied := *cp
+	return &copied, nil
+}
+
+func (f *fakeReplayStore) CreateCheckpoint(_ context.Context, _ txmanager.Session, replayID string, scope repositories.ReplayScope) error {
+	f.checkpoints[replayID] = &po.EngagementReplayCheckpoint{ReplayID: replayID, ScopeUserID: scope.UserID, ScopeVideoID: scope.VideoID}
+	return nil
+}
+
+func (f *fakeReplayStore) AdvanceCheckpoint(_ context.Context, _ txmanager.Session, replayID string, cursor repositories.InboxCursor, applied, skipped int64) error {
+	cp := f.checkpoints[replayID]
+	cp.LastOccurredAt, cp.LastEventID = &cursor.OccurredAt, &cursor.EventID
+	cp.AppliedCount, cp.SkippedCount = applied, skipped
+	return nil
+}
+
+func (f *fakeReplayStore) CompleteCheckpoint(_ context.Context, _ txmanager.Session, replayID string) error {
+	now := time.Now()
+	f.checkpoints[replayID].CompletedAt = &now
+	return nil
+}
+
+func (f *fakeReplayStore) DeleteCheckpoint(_ context.Context, _ txmanager.Session, replayID string) error {
+	delete(f.checkpoints, replayID)
+	return nil
+}
+
+func (f *fakeReplayStore) ResetProjection(_ context.Context, _ txmanager.Session, _ repositories.ReplayScope, includeStats bool) error {
+	f.resets = append(f.resets, includeStats)
+	return nil
+}
+
+func (f *fakeReplayStore) ListUserStates(context.Context, txmanager.Session, repositories.ReplayScope) ([]*po.VideoUserState, error) {
+	return f.currentStates, nil
+}
+
+func (f *fakeReplayStore) ListStats(context.Context, txmanager.Session, *uuid.UUID) ([]*po.VideoEngagementStatsProjection, error) {
+	return f.currentStats, nil
+}
+
+type fakeProjectionLocker struct {
+	locked   []string
+	released int
+}
+
+func (f *fakeProjectionLocker) Lock(_ context.Context, name string) (func(), error) {
+	f.locked = append(f.locked, name)
+	return func() { f.released++ }, nil
+}
@@ -0,0 +1,85 @@
+package engagement_test
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/engagement"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollupPrunerUsesRetentionCutoffs(t *testing.T) {
+	store := &fakeRollupPruneStore{hourly: []int64{3}}
+	pruner, err := engagement.NewRollupPruner(store, fakeTxManager{}, engagement.RollupRetention{
+		HourlyRetention: 90 * 24 * time.Hour,
+		PruneBatchSize:  100,
+	}, log.NewStdLogger(io.Discard))
+	require.NoError(t, err)
+
+	before := time.Now()
+	hourly, daily, err := pruner.PruneOnce(context.Background())
+	require.NoError(t, err)
+	require.EqualValues(t, 3, hourly)
+	require.Zero(t, daily)
+
+	require.Len(t, store.calls, 1)
+	require.WithinDuration(t, before.Add(-90*24*time.Hour), store.calls[0].hourlyBefore, time.Minute)
+	require.True(t, store.calls[0].dailyBefore.IsZero(), "zero retention keeps daily buckets forever")
+}
+
+func TestRollupPrunerRunStopsWithContext(t *testing.T) {
+	store := &fakeRollupPruneStore{}
+	pruner, err := engagement.NewRollupPruner(store, fakeTxManager{}, engagement.RollupRetention{
+		DailyRetention: 24 * time.Hour,
+		PruneInterval:  time.Hour,
+		PruneBatchSize: 100,
+	}, log.NewStdLogger(io.Discard))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	pruner.Run(ctx)
+	require.Len(t, store.calls, 1, "prunes once on start before observing cancellation")
+}
+
+func TestRollupPrunerDeletesInBatches(t *testing.T) {
+	store := &fakeRollupPruneStore{hourly: []int64{2, 2, 1}}
+	pruner, err := engagement.NewRollupPruner(store, fakeTxManager{}, engagement.RollupRetention{
+		HourlyRetention: time.Hour,
+		PruneBatchSize:  2,
+	}, log.NewStdLogger(io.Discard))
+	require.NoError(t, err)
+
+	hourly, _, err := pruner.PruneOnce(context.Background())
+	require.NoError(t, err)
+	require.EqualValues(t, 5, hourly)
+	require.Len(t, store.calls, 3, "keeps pruning while a batch is full")
+	for _, call := range store.calls {
+		require.Equal(t, 2, call.batchSize)
+	}
+}
+
+type pruneCall struct {
+	hourlyBefore time.Time
+	dailyBefore  time.Time
+	batchSize    int
+}
+
+// fakeRollupPruneStore 依次返回 hourly 中的删除行数，耗尽后返回 0。
+type fakeRollupPruneStore struct {
+	hourly []int64
+	calls  []pruneCall
+}
+
+func (f *fakeRollupPruneStore) PruneRollups(_ context.Context, _ txmanager.Session, hourlyBefore, dailyBefore time.Time, batchSize int) (int64, int64, error) {
+	f.calls = append(f.calls, pruneCall{hourlyBefore: hourlyBefore, dailyBefore: dailyBefore, batchSize: batchSize})
+	var deleted int64
+	if len(f.hourly) > 0 {
+		deleted, f.hourly = f.hourly[0], f.hourly[1:]
+	}
+	return deleted, 0, nil
+}
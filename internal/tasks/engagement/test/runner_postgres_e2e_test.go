@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"sync"
 	"testing"
 	"time"
 
@@ -66,7 +67,7 @@ func newEngagementRunnerEnv(t *testing.T) *engagementRunnerEnv {
 
 	logger := log.NewStdLogger(io.Discard)
 	repo := repositories.NewVideoUserStatesRepository(pool, logger)
-	statsRepo := repositories.NewVideoEngagementStatsRepository(pool, logger)
+	statsRepo := repositories.NewVideoEngagementStatsRepository(pool, logger, repositories.StatsShardPolicy{})
 	inboxRepo := repositories.NewInboxRepository(pool, logger, outboxcfg.Config{Schema: "catalog"})
 	txMgr, err := txmanager.NewManager(pool, txmanager.Config{}, txmanager.Dependencies{Logger: logger})
 	require.NoError(t, err)
@@ -104,8 +105,13 @@ func newEngagementRunnerEnv(t *testing.T) *engagementRunnerEnv {
 		InboxRepo:  inboxRepo,
 		UserRepo:   repo,
 		StatsRepo:  statsRepo,
-		TxManager:  txMgr,
-		Logger:     logger,
+		Views: engagement.ViewPolicy{
+			MinWatchSeconds: 30,
+			MinWatchRatio:   0.5,
+			SessionWindow:   30 * time.Minute,
+		},
+		TxManager: txMgr,
+		Logger:    logger,
 		Config: outboxcfg.InboxConfig{
 			SourceService:  "profile",
 			MaxConcurrency: 4,
@@ -245,6 +251,7 @@ func TestEngagementRunner_WithRealRepository(t *testing.T) {
 	require.EqualValues(t, 0, detail.BookmarkCount)
 	require.EqualValues(t, 1, detail.WatchCount)
 	require.EqualValues(t, 1, detail.UniqueWatchers)
+	require.InDelta(t, 45, detail.TotalWatchSeconds, 1e-9)
 
 	assertInboxProcessed(ctx, t, pool, likeEventID)
 	assertInboxProcessed(ctx, t, pool, bookmarkEventID)
@@ -494,6 +501,267 @@ func TestEngagementRunner_IdempotentDuplicateEvent(t *testing.T) {
 	assertInboxProcessed(ctx, t, pool, likeEventID)
 }
 
+func TestEngagementStats_ShardedCountersCompact(t *testing.T) {
+	ctx := context.Background()
+	pool, txMgr, cleanup := newStatsPostgres(ctx, t)
+	defer cleanup()
+
+	logger := log.NewStdLogger(io.Discard)
+	statsRepo := repositories.NewVideoEngagementStatsRepository(pool, logger, repositories.StatsShardPolicy{Shards: 8})
+	videoID := uuid.New()
+
+	const writers, perWriter = 8, 25
+	var wg sync.WaitGroup
+	errCh := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perWriter; j++ {
+				watchedAt := time.Now().UTC()
+				err := txMgr.WithinTx(ctx, txmanager.TxOptions{}, func(txCtx context.Context, sess txmanager.Session) error {
+					_, err := statsRepo.Increment(txCtx, sess, videoID, repositories.StatsDelta{
+						ShardKey:           uuid.New(),
+						LikeDelta:          1,
+						WatchDelta:         1,
+						UniqueWatcherDelta: 1,
+						FirstWatchAt:       &watchedAt,
+						LastWatchAt:        &watchedAt,
+					})
+					return err
+				})
+				if err != nil {
+					errCh <- err
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errCh)
+	for err := range errCh {
+		require.NoError(t, err)
+	}
+
+	const total = writers * perWriter
+	stats, err := statsRepo.Get(ctx, nil, videoID)
+	require.NoError(t, err)
+	require.EqualValues(t, total, stats.LikeCount)
+	require.EqualValues(t, total, stats.WatchCount)
+	require.EqualValues(t, total, stats.UniqueWatchers)
+
+	var shardRows int
+	require.NoError(t, pool.QueryRow(ctx, `select count(*) from catalog.video_engagement_stats_shards where video_id = $1`, videoID).Scan(&shardRows))
+	require.Greater(t, shardRows, 1, "increments should spread across shards")
+
+	compactor, err := engagement.NewStatsCompactor(statsRepo, txMgr, engagement.CompactPolicy{Interval: time.Minute, BatchSize: 3}, logger)
+	require.NoError(t, err)
+	folded, err := compactor.CompactOnce(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, shardRows, folded)
+
+	require.NoError(t, pool.QueryRow(ctx, `select count(*) from catalog.video_engagement_stats_shards where video_id = $1`, videoID).Scan(&shardRows))
+	require.Zero(t, shardRows)
+	compacted, err := statsRepo.Get(ctx, nil, videoID)
+	require.NoError(t, err)
+	require.EqualValues(t, total, compacted.LikeCount)
+	require.EqualValues(t, total, compacted.WatchCount)
+	require.EqualValues(t, total, compacted.UniqueWatchers)
+	require.True(t, approxEqualTime(compacted.FirstWatchAt, *stats.FirstWatchAt))
+	require.True(t, approxEqualTime(compacted.LastWatchAt, *stats.LastWatchAt))
+}
+
+func TestEngagementStats_ShardedRepairDoesNotDoubleCount(t *testing.T) {
+	ctx := context.Background()
+	pool, txMgr, cleanup := newStatsPostgres(ctx, t)
+	defer cleanup()
+
+	logger := log.NewStdLogger(io.Discard)
+	statsRepo := repositories.NewVideoEngagementStatsRepository(pool, logger, repositories.StatsShardPolicy{Shards: 8})
+	userRepo := repositories.NewVideoUserStatesRepository(pool, logger)
+	videoID := uuid.New()
+
+	// 写入方在同一事务内写明细与分片增量，修复方并发按明细重算；修复不得把已重算的分片增量再次累加。
+	const writers, perWriter = 6, 15
+	var wg sync.WaitGroup
+	errCh := make(chan error, writers+1)
+	done := make(chan struct{})
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perWriter; j++ {
+				userID := uuid.New()
+				likedAt := time.Now().UTC()
+				err := txMgr.WithinTx(ctx, txmanager.TxOptions{}, func(txCtx context.Context, sess txmanager.Session) error {
+					if err := userRepo.Upsert(txCtx, sess, repositories.UpsertVideoUserStateInput{
+						UserID:          userID,
+						VideoID:         videoID,
+						HasLiked:        true,
+						LikedOccurredAt: &likedAt,
+					}); err != nil {
+						return err
+					}
+					_, err := statsRepo.Increment(txCtx, sess, videoID, repositories.StatsDelta{ShardKey: userID, LikeDelta: 1})
+					return err
+				})
+				if err != nil {
+					errCh <- err
+					return
+				}
+			}
+		}()
+	}
+	var repairs sync.WaitGroup
+	repairs.Add(1)
+	go func() {
+		defer repairs.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			err := txMgr.WithinTx(ctx, txmanager.TxOptions{}, func(txCtx context.Context, sess txmanager.Session) error {
+				_, err := statsRepo.Repair(txCtx, sess, []uuid.UUID{videoID})
+				return err
+			})
+			if err != nil {
+				errCh <- err
+				return
+			}
+		}
+	}()
+	wg.Wait()
+	close(done)
+	repairs.Wait()
+	close(errCh)
+	for err := range errCh {
+		require.NoError(t, err)
+	}
+
+	compactor, err := engagement.NewStatsCompactor(statsRepo, txMgr, engagement.CompactPolicy{Interval: time.Minute, BatchSize: 1000}, logger)
+	require.NoError(t, err)
+	_, err = compactor.CompactOnce(ctx)
+	require.NoError(t, err)
+
+	stats, err := statsRepo.Get(ctx, nil, videoID)
+	require.NoError(t, err)
+	require.EqualValues(t, writers*perWriter, stats.LikeCount)
+}
+
+func TestBatchConsumer_AppliesBatchAgainstPostgres(t *testing.T) {
+	ctx := context.Background()
+	pool, txMgr, cleanup := newStatsPostgres(ctx, t)
+	defer cleanup()
+
+	logger := log.NewStdLogger(io.Discard)
+	statsRepo := repositories.NewVideoEngagementStatsRepository(pool, logger, repositories.StatsShardPolicy{Shards: 8})
+	userRepo := repositories.NewVideoUserStatesRepository(pool, logger)
+	inboxRepo := repositories.NewInboxRepository(pool, logger, outboxcfg.Config{Schema: "catalog"})
+	quarantineRepo := repositories.NewInboxQuarantineRepository(pool, logger)
+
+	first, second := uuid.New(), uuid.New()
+	occurred := time.Now().Add(-time.Hour).UTC()
+	var messages []*gcpubsub.Message
+	for i := 0; i < 3; i++ {
+		messages = append(messages, likeMessage(t, uuid.New(), uuid.New(), first, occurred))
+	}
+	messages = append(messages, likeMessage(t, uuid.New(), uuid.New(), second, occurred))
+	garbageID := uuid.New()
+	messages = append(messages, &gcpubsub.Message{
+		ID:   garbageID.String(),
+		Data: []byte{0xff, 0xff, 0xff},
+		Attributes: map[string]string{
+			"event_id":   garbageID.String(),
+			"event_type": "profile.engagement.added",
+		},
+	})
+
+	sub := &fakeBatchSubscriber{messages: messages}
+	consumer, err := engagement.NewBatchConsumer(engagement.BatchConsumerParams{
+		Subscriber:    sub,
+		Inbox:         inboxRepo,
+		UserRepo:      userRepo,
+		StatsRepo:     statsRepo,
+		TxManager:     txMgr,
+		SourceService: "profile",
+		Policy:        engagement.BatchPolicy{MaxEvents: 4, MaxWait: time.Minute},
+		Quarantine:    quarantineRepo,
+		Logger:        logger,
+	})
+	require.NoError(t, err)
+	require.NoError(t, consumer.Run(ctx))
+
+	for id, err := range sub.results {
+		require.NoError(t, err, "message %s", id)
+	}
+	firstStats, err := statsRepo.Get(ctx, nil, first)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, firstStats.LikeCount)
+	secondStats, err := statsRepo.Get(ctx, nil, second)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, secondStats.LikeCount)
+
+	var liked int
+	require.NoError(t, pool.QueryRow(ctx, `select count(*) from catalog.video_user_engagements_projection where video_id = $1 and has_liked`, first).Scan(&liked))
+	require.Equal(t, 3, liked)
+
+	var processed int
+	require.NoError(t, pool.QueryRow(ctx, `select count(*) from catalog.inbox_events where processed_at is not null`).Scan(&processed))
+	require.Equal(t, 4, processed)
+
+	var quarantined int
+	require.NoError(t, pool.QueryRow(ctx, `select count(*) from catalog.inbox_quarantine where event_id = $1`, garbageID).Scan(&quarantined))
+	require.Equal(t, 1, quarantined)
+}
+
+// BenchmarkStatsIncrementHotVideo 对比单行计数与分片计数在同一热点视频上的并发写入吞吐：
+//
+//	go test ./internal/tasks/engagement/test -run '^$' -bench StatsIncrementHotVideo -cpu 16
+func BenchmarkStatsIncrementHotVideo(b *testing.B) {
+	ctx := context.Background()
+	pool, txMgr, cleanup := newStatsPostgres(ctx, b)
+	defer cleanup()
+
+	logger := log.NewStdLogger(io.Discard)
+	for _, shards := range []int{0, 16} {
+		statsRepo := repositories.NewVideoEngagementStatsRepository(pool, logger, repositories.StatsShardPolicy{Shards: shards})
+		videoID := uuid.New()
+		b.Run(fmt.Sprintf("shards=%d", shards), func(b *testing.B) {
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					err := txMgr.WithinTx(ctx, txmanager.TxOptions{}, func(txCtx context.Context, sess txmanager.Session) error {
+						_, err := statsRepo.Increment(txCtx, sess, videoID, repositories.StatsDelta{ShardKey: uuid.New(), WatchDelta: 1})
+						return err
+					})
+					if err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			})
+		})
+	}
+}
+
+func newStatsPostgres(ctx context.Context, tb testing.TB) (*pgxpool.Pool, txmanager.Manager, func()) {
+	tb.Helper()
+
+	dsn, terminate := startPostgres(ctx, tb)
+	pool, err := pgxpool.New(ctx, dsn)
+	require.NoError(tb, err)
+	ensureAuthSchema(ctx, tb, pool)
+	applyMigrations(ctx, tb, pool)
+
+	txMgr, err := txmanager.NewManager(pool, txmanager.Config{}, txmanager.Dependencies{Logger: log.NewStdLogger(io.Discard)})
+	require.NoError(tb, err)
+	return pool, txMgr, func() {
+		pool.Close()
+		terminate()
+	}
+}
+
 func buildEngagementAdded(userID, videoID uuid.UUID, fav profilev1.FavoriteType, occurred time.Time) (uuid.UUID, []byte, error) {
 	eventID := uuid.New()
 	msg := &profilev1.EngagementAddedEvent{
@@ -540,9 +808,9 @@ func buildWatchProgressed(userID, videoID uuid.UUID, occurred time.Time) (uuid.U
 		UserId:  userID.String(),
 		VideoId: videoID.String(),
 		Progress: &profilev1.WatchProgress{
-			PositionSeconds:   0,
-			ProgressRatio:     0,
-			TotalWatchSeconds: 0,
+			PositionSeconds:   45,
+			ProgressRatio:     0.75,
+			TotalWatchSeconds: 45,
 			FirstWatchedAt:    timestamppb.New(occurred.UTC()),
 			LastWatchedAt:     timestamppb.New(occurred.UTC()),
 		},
@@ -669,7 +937,7 @@ func logInboxState(ctx context.Context, t *testing.T, pool *pgxpool.Pool) {
 
 func boolPtr(v bool) *bool { return &v }
 
-func ensureAuthSchema(ctx context.Context, t *testing.T, pool *pgxpool.Pool) {
+func ensureAuthSchema(ctx context.Context, t testing.TB, pool *pgxpool.Pool) {
 	_, err := pool.Exec(ctx, `create schema if not exists auth`)
 	require.NoError(t, err)
 	_, err = pool.Exec(ctx, `
@@ -681,7 +949,7 @@ func ensureAuthSchema(ctx context.Context, t *testing.T, pool *pgxpool.Pool) {
 	require.NoError(t, err)
 }
 
-func startPostgres(ctx context.Context, t *testing.T) (string, func()) {
+func startPostgres(ctx context.Context, t testing.TB) (string, func()) {
 	t.Helper()
 
 	req := testcontainers.ContainerRequest{
@@ -719,7 +987,7 @@ func startPostgres(ctx context.Context, t *testing.T) (string, func()) {
 	return dsn, cleanup
 }
 
-func applyMigrations(ctx context.Context, t *testing.T, pool *pgxpool.Pool) {
+func applyMigrations(ctx context.Context, t testing.TB, pool *pgxpool.Pool) {
 	t.Helper()
 
 	migrationsDir := findMigrationsDir(t)
@@ -743,7 +1011,7 @@ func applyMigrations(ctx context.Context, t *testing.T, pool *pgxpool.Pool) {
 	}
 }
 
-func findMigrationsDir(t *testing.T) string {
+func findMigrationsDir(t testing.TB) string {
 	t.Helper()
 
 	dir, err := os.Getwd()
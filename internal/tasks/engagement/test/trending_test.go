@@ -0,0 +1,119 @@
+package engagement_test
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/engagement"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrendingScorerRefreshesAndPrunes(t *testing.T) {
+	store := &fakeTrendingStore{count: 7}
+	scorer, err := engagement.NewTrendingScorer(store, &fakeTrendingLock{store: store}, fakeTxManager{}, engagement.TrendingPolicy{
+		Interval:    10 * time.Minute,
+		Window:      72 * time.Hour,
+		HalfLife:    24 * time.Hour,
+		LikeWeight:  3,
+		ViewWeight:  1,
+		MaxVideos:   1000,
+		SnapshotTTL: time.Hour,
+	}, log.NewStdLogger(io.Discard))
+	require.NoError(t, err)
+
+	before := time.Now()
+	snapshot, err := scorer.ScoreOnce(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, snapshot)
+
+	require.Len(t, store.refreshes, 1)
+	params := store.refreshes[0]
+	require.WithinDuration(t, before, params.ComputedAt, time.Minute)
+	require.Equal(t, 72*time.Hour, params.Window)
+	require.Equal(t, 24*time.Hour, params.HalfLife)
+	require.EqualValues(t, 3, params.LikeWeight)
+	require.EqualValues(t, 1, params.ViewWeight)
+	require.EqualValues(t, 1000, params.MaxVideos)
+
+	require.Len(t, store.prunes, 1)
+	require.Equal(t, params.ComputedAt.Add(-time.Hour), store.prunes[0])
+
+	// 先取得热度计算锁，再读取最新快照判断是否需要计算。
+	require.Equal(t, []string{"lock:catalog.engagement.trending", "latest", "refresh", "prune"}, store.calls)
+}
+
+func TestTrendingScorerSkipsFreshSnapshot(t *testing.T) {
+	store := &fakeTrendingStore{latest: &po.VideoTrendingSnapshot{SnapshotID: 3, ComputedAt: time.Now().Add(-time.Minute)}}
+	scorer, err := engagement.NewTrendingScorer(store, nil, fakeTxManager{}, engagement.TrendingPolicy{
+		Interval: 10 * time.Minute,
+		Window:   72 * time.Hour,
+		HalfLife: 24 * time.Hour,
+	}, log.NewStdLogger(io.Discard))
+	require.NoError(t, err)
+
+	snapshot, err := scorer.ScoreOnce(context.Background())
+	require.NoError(t, err)
+	require.Nil(t, snapshot, "another instance computed a snapshot less than half an interval ago")
+	require.Empty(t, store.refreshes)
+
+	store.latest.ComputedAt = time.Now().Add(-6 * time.Minute)
+	snapshot, err = scorer.ScoreOnce(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, snapshot)
+	require.Len(t, store.refreshes, 1)
+}
+
+func TestTrendingScorerRequiresPositiveWindow(t *testing.T) {
+	_, err := engagement.NewTrendingScorer(&fakeTrendingStore{}, nil, fakeTxManager{}, engagement.TrendingPolicy{
+		Interval: time.Minute,
+		HalfLife: time.Hour,
+	}, log.NewStdLogger(io.Discard))
+	require.Error(t, err)
+}
+
+type fakeTrendingStore struct {
+	latest    *po.VideoTrendingSnapshot
+	count     int64
+	refreshes []repositories.TrendingScoreParams
+	prunes    []time.Time
+	calls     []string
+}
+
+func (f *fakeTrendingStore) GetTrendingSnapshot(_ context.Context, _ txmanager.Session, snapshotID int64) (*po.VideoTrendingSnapshot, error) {
+	if snapshotID != 0 {
+		return nil, nil
+	}
+	f.calls = append(f.calls, "latest")
+	return f.latest, nil
+}
+
+func (f *fakeTrendingStore) RefreshTrending(_ context.Context, _ txmanager.Session, params repositories.TrendingScoreParams) (*po.VideoTrendingSnapshot, int64, error) {
+	f.refreshes = append(f.refreshes, params)
+	f.calls = append(f.calls, "refresh")
+	return &po.VideoTrendingSnapshot{
+		SnapshotID:  int64(len(f.refreshes)),
+		ComputedAt:  params.ComputedAt,
+		WindowStart: params.ComputedAt.Add(-params.Window),
+	}, f.count, nil
+}
+
+func (f *fakeTrendingStore) PruneTrendingSnapshots(_ context.Context, _ txmanager.Session, before time.Time) (int64, error) {
+	f.prunes = append(f.prunes, before)
+	f.calls = append(f.calls, "prune")
+	return 0, nil
+}
+
+type fakeTrendingLock struct {
+	store *fakeTrendingStore
+}
+
+func (f *fakeTrendingLock) LockTx(_ context.Context, _ txmanager.Session, name string) error {
+	f.store.calls = append(f.store.calls, "lock:"+name)
+	return nil
+}
@@ -0,0 +1,291 @@
+package engagement_test
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/engagement"
+	profilev1 "github.com/bionicotaku/lingo-services-profile/api/profile/v1"
+	"github.com/bionicotaku/lingo-utils/outbox/store"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var testViewPolicy = engagement.ViewPolicy{
+	MinWatchSeconds: 30,
+	MinWatchRatio:   0.5,
+	SessionWindow:   30 * time.Minute,
+}
+
+func TestWatchProgressCountsOneViewPerSession(t *testing.T) {
+	stats := newRecordingStatsRepo()
+	handler := engagement.NewEventHandler(newFakeVideoUserStatesRepository(), stats, nil, testViewPolicy, log.NewStdLogger(io.Discard), nil)
+	userID, videoID := uuid.New(), uuid.New()
+	base := time.Date(2025, time.March, 1, 8, 0, 0, 0, time.UTC)
+
+	// 每 10 秒上报一次进度，直到会话内累计 30 秒才计一次播放，之后同一会话不再计数。
+	for i := 1; i <= 6; i++ {
+		handleProgress(t, handler, userID, videoID, base.Add(time.Duration(i)*10*time.Second), float64(i*10), 0.05*float64(i))
+		if i < 3 {
+			require.Zero(t, stats.watchCount(videoID), "progress %d should not qualify yet", i)
+		}
+	}
+	require.EqualValues(t, 1, stats.watchCount(videoID))
+	require.EqualValues(t, 1, stats.uniqueWatchers(videoID))
+
+	// 间隔超过会话窗口后重新观看：以上一会话的累计秒数为基线，需要再看满 30 秒。
+	rewatch := base.Add(2 * time.Hour)
+	handleProgress(t, handler, userID, videoID, rewatch, 70, 0.05)
+	require.EqualValues(t, 1, stats.watchCount(videoID))
+	handleProgress(t, handler, userID, videoID, rewatch.Add(30*time.Second), 100, 0.15)
+	require.EqualValues(t, 2, stats.watchCount(videoID))
+	require.EqualValues(t, 1, stats.uniqueWatchers(videoID), "same user must not add unique watchers")
+}
+
+func TestWatchProgressQualifiesByRatio(t *testing.T) {
+	stats := newRecordingStatsRepo()
+	handler := engagement.NewEventHandler(newFakeVideoUserStatesRepository(), stats, nil, testViewPolicy, log.NewStdLogger(io.Discard), nil)
+	userID, videoID := uuid.New(), uuid.New()
+	base := time.Date(2025, time.March, 1, 8, 0, 0, 0, time.UTC)
+
+	// 短视频（10 秒）：累计不足 30 秒，但观看秒数超过时长的一半。
+	handleProgressAt(t, handler, userID, videoID, base, 4, 4, 0.4)
+	require.Zero(t, stats.watchCount(videoID))
+	handleProgressAt(t, handler, userID, videoID, base.Add(5*time.Second), 6, 6, 0.6)
+	require.EqualValues(t, 1, stats.watchCount(videoID))
+}
+
+func TestWatchProgressSeekDoesNotQualify(t *testing.T) {
+	stats := newRecordingStatsRepo()
+	handler := engagement.NewEventHandler(newFakeVideoUserStatesRepository(), stats, nil, testViewPolicy, log.NewStdLogger(io.Discard), nil)
+	userID, videoID := uuid.New(), uuid.New()
+	base := time.Date(2025, time.March, 1, 8, 0, 0, 0, time.UTC)
+
+	// 100 秒的视频看了 5 秒后拖到 90 秒处：播放位置超过一半，但实际观看时长不足。
+	handleProgressAt(t, handler, userID, videoID, base, 5, 5, 0.05)
+	handleProgressAt(t, handler, userID, videoID, base.Add(10*time.Second), 90, 6, 0.9)
+	require.Zero(t, stats.watchCount(videoID))
+
+	handleProgressAt(t, handler, userID, videoID, base.Add(time.Minute), 100, 50, 1)
+	require.EqualValues(t, 1, stats.watchCount(videoID))
+}
+
+func TestWatchProgressIgnoresEventsBeforeSession(t *testing.T) {
+	stats := newRecordingStatsRepo()
+	handler := engagement.NewEventHandler(newFakeVideoUserStatesRepository(), stats, nil, testViewPolicy, log.NewStdLogger(io.Discard), nil)
+	userID, videoID := uuid.New(), uuid.New()
+	base := time.Date(2025, time.March, 1, 8, 0, 0, 0, time.UTC)
+
+	handleProgress(t, handler, userID, videoID, base, 5, 0.1)
+	handleProgress(t, handler, userID, videoID, base.Add(-time.Hour), 60, 1)
+	require.Zero(t, stats.watchCount(videoID))
+
+	session := stats.sessions[videoID.String()+userID.String()]
+	require.NotNil(t, session)
+	require.Equal(t, base, session.SessionStartedAt)
+	require.Equal(t, 5.0, session.LastWatchSeconds)
+}
+
+func TestZeroViewPolicyCountsEveryProgress(t *testing.T) {
+	stats := newRecordingStatsRepo()
+	handler := engagement.NewEventHandler(newFakeVideoUserStatesRepository(), stats, nil, engagement.ViewPolicy{}, log.NewStdLogger(io.Discard), nil)
+	userID, videoID := uuid.New(), uuid.New()
+	base := time.Date(2025, time.March, 1, 8, 0, 0, 0, time.UTC)
+
+	handleProgress(t, handler, userID, videoID, base, 0, 0)
+	handleProgress(t, handler, userID, videoID, base.Add(time.Second), 0, 0)
+	require.EqualValues(t, 2, stats.watchCount(videoID))
+}
+
+func TestWatchProgressAccumulatesWatchTimeAndPosition(t *testing.T) {
+	stats := newRecordingStatsRepo()
+	handler := engagement.NewEventHandler(newFakeVideoUserStatesRepository(), stats, nil, testViewPolicy, log.NewStdLogger(io.Discard), nil)
+	alice, bob, videoID := uuid.New(), uuid.New(), uuid.New()
+	base := time.Date(2025, time.March, 1, 8, 0, 0, 0, time.UTC)
+
+	// 未达到有效播放阈值的进度同样累计观看时长与播放位置。
+	handleProgressAt(t, handler, alice, videoID, base, 10, 10, 0.1)
+	require.Zero(t, stats.watchCount(videoID))
+	require.InDelta(t, 10, stats.stats[videoID].TotalWatchSeconds, 1e-9)
+	handleProgressAt(t, handler, alice, videoID, base.Add(20*time.Second), 25, 30, 0.3)
+	// 拖回开头重看：最大播放位置不回退，观看时长继续累加。
+	handleProgressAt(t, handler, alice, videoID, base.Add(40*time.Second), 5, 50, 0.05)
+	handleProgressAt(t, handler, bob, videoID, base, 60, 60, 0.6)
+
+	row := stats.stats[videoID]
+	require.NotNil(t, row)
+	require.InDelta(t, 110, row.TotalWatchSeconds, 1e-9)
+	require.InDelta(t, 85, row.PositionSumSeconds, 1e-9)
+	require.EqualValues(t, 2, row.PositionViewers)
+	require.EqualValues(t, 2, row.WatchCount)
+	require.Equal(t, 5.0, stats.sessions[videoID.String()+alice.String()].LastPositionSeconds, "resume position follows the latest event")
+
+	// 重复投递的进度不会重复累计；乱序到达的较早进度不覆盖续播位置。
+	handleProgressAt(t, handler, bob, videoID, base, 60, 60, 0.6)
+	require.InDelta(t, 110, row.TotalWatchSeconds, 1e-9)
+	require.InDelta(t, 85, row.PositionSumSeconds, 1e-9)
+	handleProgressAt(t, handler, alice, videoID, base.Add(30*time.Second), 28, 40, 0.3)
+	require.Equal(t, 5.0, stats.sessions[videoID.String()+alice.String()].LastPositionSeconds)
+}
+
+func TestWatchProgressCountsLegacySessionAsPositionViewer(t *testing.T) {
+	stats := newRecordingStatsRepo()
+	handler := engagement.NewEventHandler(newFakeVideoUserStatesRepository(), stats, nil, testViewPolicy, log.NewStdLogger(io.Discard), nil)
+	userID, videoID := uuid.New(), uuid.New()
+	base := time.Date(2025, time.March, 1, 8, 0, 0, 0, time.UTC)
+
+	// 位置统计上线前遗留的会话：未计入 position_viewers，下一条进度时补计一次。
+	stats.sessions[videoID.String()+userID.String()] = &po.VideoViewSession{
+		VideoID:          videoID,
+		UserID:           userID,
+		SessionStartedAt: base,
+		LastProgressAt:   base,
+		LastWatchSeconds: 20,
+	}
+	handleProgressAt(t, handler, userID, videoID, base.Add(10*time.Second), 30, 25, 0.3)
+	handleProgressAt(t, handler, userID, videoID, base.Add(20*time.Second), 40, 35, 0.4)
+
+	row := stats.stats[videoID]
+	require.NotNil(t, row)
+	require.EqualValues(t, 1, row.PositionViewers)
+	require.InDelta(t, 40, row.PositionSumSeconds, 1e-9)
+	require.InDelta(t, 15, row.TotalWatchSeconds, 1e-9)
+	require.True(t, stats.sessions[videoID.String()+userID.String()].PositionTracked)
+}
+
+func TestWatchProgressRollupsUseEventTime(t *testing.T) {
+	stats := newRecordingStatsRepo()
+	handler := engagement.NewEventHandler(newFakeVideoUserStatesRepository(), stats, nil, testViewPolicy, log.NewStdLogger(io.Discard), nil)
+	alice, bob, videoID := uuid.New(), uuid.New(), uuid.New()
+	base := time.Date(2025, time.March, 1, 8, 50, 0, 0, time.UTC)
+
+	handleProgress(t, handler, alice, videoID, base, 10, 0.1)
+	require.Empty(t, stats.rollups, "unqualified progress must not create buckets")
+	handleProgress(t, handler, alice, videoID, base.Add(time.Minute), 40, 0.4)
+	// 跨小时到达的有效播放计入事件自身所在的桶，而非处理时间。
+	handleProgress(t, handler, bob, videoID, base.Add(15*time.Minute), 60, 0.6)
+
+	first := stats.rollups[time.Date(2025, time.March, 1, 8, 0, 0, 0, time.UTC)]
+	require.NotNil(t, first)
+	require.EqualValues(t, 1, first.WatchCount)
+	require.EqualValues(t, 1, first.UniqueWatchers)
+	second := stats.rollups[time.Date(2025, time.March, 1, 9, 0, 0, 0, time.UTC)]
+	require.NotNil(t, second)
+	require.EqualValues(t, 1, second.WatchCount)
+	require.EqualValues(t, 1, second.UniqueWatchers)
+}
+
+func handleProgress(t *testing.T, handler *engagement.EventHandler, userID, videoID uuid.UUID, at time.Time, totalSeconds, ratio float64) {
+	t.Helper()
+	handleProgressAt(t, handler, userID, videoID, at, 0, totalSeconds, ratio)
+}
+
+func handleProgressAt(t *testing.T, handler *engagement.EventHandler, userID, videoID uuid.UUID, at time.Time, positionSeconds, totalSeconds, ratio float64) {
+	t.Helper()
+	evt := marshalEvent(t, &profilev1.WatchProgressedEvent{
+		EventId: uuid.New().String(),
+		UserId:  userID.String(),
+		VideoId: videoID.String(),
+		Progress: &profilev1.WatchProgress{
+			PositionSeconds:   positionSeconds,
+			TotalWatchSeconds: totalSeconds,
+			ProgressRatio:     ratio,
+			LastWatchedAt:     timestamppb.New(at),
+		},
+	})
+	require.NoError(t, handler.Handle(context.Background(), fakeSession{}, evt, &store.InboxEvent{EventType: "profile.watch.progressed"}))
+}
+
+type recordingStatsRepo struct {
+	stats    map[uuid.UUID]*po.VideoEngagementStatsProjection
+	watchers map[string]bool
+	sessions map[string]*po.VideoViewSession
+	rollups  map[time.Time]*po.VideoStatsBucket
+}
+
+func newRecordingStatsRepo() *recordingStatsRepo {
+	return &recordingStatsRepo{
+		stats:    make(map[uuid.UUID]*po.VideoEngagementStatsProjection),
+		watchers: make(map[string]bool),
+		sessions: make(map[string]*po.VideoViewSession),
+		rollups:  make(map[time.Time]*po.VideoStatsBucket),
+	}
+}
+
+func (r *recordingStatsRepo) watchCount(videoID uuid.UUID) int64 {
+	if row, ok := r.stats[videoID]; ok {
+		return row.WatchCount
+	}
+	return 0
+}
+
+func (r *recordingStatsRepo) uniqueWatchers(videoID uuid.UUID) int64 {
+	if row, ok := r.stats[videoID]; ok {
+		return row.UniqueWatchers
+	}
+	return 0
+}
+
+func (r *recordingStatsRepo) Increment(_ context.Context, _ txmanager.Session, videoID uuid.UUID, delta repositories.StatsDelta) (*po.VideoEngagementStatsProjection, error) {
+	row, ok := r.stats[videoID]
+	if !ok {
+		row = &po.VideoEngagementStatsProjection{VideoID: videoID}
+		r.stats[videoID] = row
+	}
+	row.WatchCount += delta.WatchDelta
+	row.UniqueWatchers += delta.UniqueWatcherDelta
+	row.TotalWatchSeconds += delta.WatchSecondsDelta
+	row.PositionSumSeconds += delta.PositionSecondsDelta
+	row.PositionViewers += delta.PositionViewerDelta
+	row.ShareCount += delta.ShareDelta
+	row.RatingCount += delta.RatingCountDelta
+	row.RatingSum += delta.RatingSumDelta
+	for i := range row.RatingDistribution {
+		row.RatingDistribution[i] += delta.RatingDistributionDelta[i]
+	}
+	return row, nil
+}
+
+func (r *recordingStatsRepo) MarkWatcher(_ context.Context, _ txmanager.Session, videoID, userID uuid.UUID, watchTime time.Time) (*po.VideoWatcherRecord, error) {
+	key := videoID.String() + userID.String()
+	inserted := !r.watchers[key]
+	r.watchers[key] = true
+	return &po.VideoWatcherRecord{VideoID: videoID, UserID: userID, FirstWatchedAt: watchTime, LastWatchedAt: watchTime, Inserted: inserted}, nil
+}
+
+func (r *recordingStatsRepo) GetViewSession(_ context.Context, _ txmanager.Session, videoID, userID uuid.UUID) (*po.VideoViewSession, error) {
+	session, ok := r.sessions[videoID.String()+userID.String()]
+	if !ok {
+		return nil, nil
+	}
+	copied := *session
+	return &copied, nil
+}
+
+func (r *recordingStatsRepo) SaveViewSession(_ context.Context, _ txmanager.Session, session *po.VideoViewSession) error {
+	copied := *session
+	r.sessions[session.VideoID.String()+session.UserID.String()] = &copied
+	return nil
+}
+
+// IncrementRollups 以 UTC 小时为键记录分时增量。
+func (r *recordingStatsRepo) IncrementRollups(_ context.Context, _ txmanager.Session, _ uuid.UUID, at time.Time, delta repositories.StatsDelta) error {
+	hour := at.UTC().Truncate(time.Hour)
+	bucket, ok := r.rollups[hour]
+	if !ok {
+		bucket = &po.VideoStatsBucket{BucketStart: hour}
+		r.rollups[hour] = bucket
+	}
+	bucket.LikeDelta += delta.LikeDelta
+	bucket.BookmarkDelta += delta.BookmarkDelta
+	bucket.WatchCount += delta.WatchDelta
+	bucket.UniqueWatchers += delta.UniqueWatcherDelta
+	return nil
+}
@@ -0,0 +1,156 @@
+package engagement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// TrendingPolicy 描述热度榜的计算周期、衰减参数与快照保留期；Interval 为 0 表示不计算。
+type TrendingPolicy struct {
+	Interval    time.Duration
+	Window      time.Duration
+	HalfLife    time.Duration
+	LikeWeight  float64
+	ViewWeight  float64
+	MaxVideos   int32
+	SnapshotTTL time.Duration
+}
+
+// NewTrendingPolicy 将配置映射为 TrendingPolicy。
+func NewTrendingPolicy(cfg configloader.TrendingConfig) TrendingPolicy {
+	return TrendingPolicy{
+		Interval:    cfg.Interval,
+		Window:      cfg.Window,
+		HalfLife:    cfg.HalfLife,
+		LikeWeight:  cfg.LikeWeight,
+		ViewWeight:  cfg.ViewWeight,
+		MaxVideos:   cfg.MaxVideos,
+		SnapshotTTL: cfg.SnapshotTTL,
+	}
+}
+
+// trendingStore 定义热度快照的计算、读取与清理接口。
+type trendingStore interface {
+	GetTrendingSnapshot(ctx context.Context, sess txmanager.Session, snapshotID int64) (*po.VideoTrendingSnapshot, error)
+	RefreshTrending(ctx context.Context, sess txmanager.Session, params repositories.TrendingScoreParams) (*po.VideoTrendingSnapshot, int64, error)
+	PruneTrendingSnapshots(ctx context.Context, sess txmanager.Session, before time.Time) (int64, error)
+}
+
+var _ trendingStore = (*repositories.VideoEngagementStatsRepository)(nil)
+
+// trendingLockName 是热度计算的事务级 advisory lock 名称，保证多实例同一时刻只有一个在计算。
+const trendingLockName = "catalog.engagement.trending"
+
+// trendingLocker 在计算事务内获取阻塞式事务级 advisory lock。
+type trendingLocker interface {
+	LockTx(ctx context.Context, sess txmanager.Session, name string) error
+}
+
+var _ trendingLocker = (*repositories.AdvisoryLockRepository)(nil)
+
+// TrendingScorer 定期从小时统计桶计算时间衰减热度，生成新的热度快照并清理过期快照。
+type TrendingScorer struct {
+	store     trendingStore
+	lock      trendingLocker
+	txManager txmanager.Manager
+	policy    TrendingPolicy
+	log       *log.Helper
+	now       func() time.Time
+}
+
+// NewTrendingScorer 构造 TrendingScorer；lock 为空时不做跨实例互斥（仅适用于单实例部署）。
+func NewTrendingScorer(store trendingStore, lock trendingLocker, tx txmanager.Manager, policy TrendingPolicy, logger log.Logger) (*TrendingScorer, error) {
+	if store == nil {
+		return nil, fmt.Errorf("engagement trending: stats repository is required")
+	}
+	if tx == nil {
+		return nil, fmt.Errorf("engagement trending: tx manager is required")
+	}
+	if policy.Window <= 0 || policy.HalfLife <= 0 {
+		return nil, fmt.Errorf("engagement trending: window and half_life must be positive")
+	}
+	if logger == nil {
+		logger = log.DefaultLogger
+	}
+	return &TrendingScorer{
+		store:     store,
+		lock:      lock,
+		txManager: tx,
+		policy:    policy,
+		log:       log.NewHelper(logger),
+		now:       time.Now,
+	}, nil
+}
+
+// ScoreOnce 在同一事务内生成新的热度快照并清理过期快照，返回新快照。
+// 事务先获取热度计算锁再读取最新快照：等待锁的实例会看到持锁实例刚提交的快照，
+// 最新快照距今不足半个计算周期时跳过本次计算，返回 nil。
+func (s *TrendingScorer) ScoreOnce(ctx context.Context) (*po.VideoTrendingSnapshot, error) {
+	now := s.now().UTC()
+	var (
+		snapshot *po.VideoTrendingSnapshot
+		count    int64
+		pruned   int64
+	)
+	err := s.txManager.WithinTx(ctx, txmanager.TxOptions{}, func(txCtx context.Context, sess txmanager.Session) error {
+		if s.lock != nil {
+			if err := s.lock.LockTx(txCtx, sess, trendingLockName); err != nil {
+				return err
+			}
+		}
+		latest, err := s.store.GetTrendingSnapshot(txCtx, sess, 0)
+		if err != nil {
+			return err
+		}
+		if latest != nil && s.policy.Interval > 0 && now.Sub(latest.ComputedAt) < s.policy.Interval/2 {
+			return nil
+		}
+		snapshot, count, err = s.store.RefreshTrending(txCtx, sess, repositories.TrendingScoreParams{
+			ComputedAt: now,
+			Window:     s.policy.Window,
+			HalfLife:   s.policy.HalfLife,
+			LikeWeight: s.policy.LikeWeight,
+			ViewWeight: s.policy.ViewWeight,
+			MaxVideos:  s.policy.MaxVideos,
+		})
+		if err != nil {
+			return err
+		}
+		pruned, err = s.store.PruneTrendingSnapshots(txCtx, sess, now.Add(-s.policy.SnapshotTTL))
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("engagement trending: score: %w", err)
+	}
+	if snapshot != nil {
+		s.log.WithContext(ctx).Infof("engagement trending snapshot computed: snapshot_id=%d videos=%d pruned_snapshots=%d", snapshot.SnapshotID, count, pruned)
+	}
+	return snapshot, nil
+}
+
+// Run 启动时计算一次，之后每个 Interval 计算一次，直到 ctx 结束；单次失败只记录日志。
+func (s *TrendingScorer) Run(ctx context.Context) {
+	if s.policy.Interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(s.policy.Interval)
+	defer ticker.Stop()
+	for {
+		if _, err := s.ScoreOnce(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			s.log.WithContext(ctx).Warnf("engagement trending score failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
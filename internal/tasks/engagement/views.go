@@ -0,0 +1,125 @@
+package engagement
+
+import (
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	profilev1 "github.com/bionicotaku/lingo-services-profile/api/profile/v1"
+	"github.com/google/uuid"
+)
+
+// ViewPolicy 描述有效播放判定规则。
+//
+// 会话内观看秒数（total_watch_seconds 相对会话开始时的增量）或其占视频时长的比例任一达到阈值即构成一次有效播放；
+// 比例以实际观看秒数计算而非播放位置，拖动进度条跳到结尾不会构成有效播放。
+// 同一用户两次进度事件间隔不足 SessionWindow 视为同一会话，会话内至多计一次。
+// 零值策略不设阈值且每条事件都开启新会话，即逐条计数。
+type ViewPolicy struct {
+	MinWatchSeconds float64
+	MinWatchRatio   float64
+	SessionWindow   time.Duration
+}
+
+// NewViewPolicy 将配置映射为 ViewPolicy。
+func NewViewPolicy(cfg configloader.ViewQualificationConfig) ViewPolicy {
+	return ViewPolicy{
+		MinWatchSeconds: cfg.MinWatchSeconds,
+		MinWatchRatio:   cfg.MinWatchRatio,
+		SessionWindow:   cfg.SessionWindow,
+	}
+}
+
+// advance 依据进度事件推进播放会话，返回待写入的会话以及本事件是否构成一次新的有效播放。
+// 早于当前会话开始时间的乱序事件属于已结束的会话，返回 nil 表示不更新会话也不计数。
+func (p ViewPolicy) advance(current *po.VideoViewSession, videoID, userID uuid.UUID, progress *profilev1.WatchProgress, watchTime time.Time) (*po.VideoViewSession, bool) {
+	total := max(progress.GetTotalWatchSeconds(), 0)
+	position := max(progress.GetPositionSeconds(), 0)
+
+	var next po.VideoViewSession
+	switch {
+	case current == nil:
+		next = po.VideoViewSession{
+			VideoID:             videoID,
+			UserID:              userID,
+			SessionStartedAt:    watchTime,
+			LastProgressAt:      watchTime,
+			LastWatchSeconds:    total,
+			MaxPositionSeconds:  position,
+			LastPositionSeconds: position,
+			PositionTracked:     true,
+		}
+	case watchTime.Before(current.SessionStartedAt):
+		return nil, false
+	case watchTime.Sub(current.LastProgressAt) >= p.SessionWindow:
+		// 新会话以上一会话最后上报的累计秒数为基线；累计值回退（如 Profile 侧重置）时从 0 起算。
+		next = po.VideoViewSession{
+			VideoID:              videoID,
+			UserID:               userID,
+			SessionStartedAt:     watchTime,
+			LastProgressAt:       watchTime,
+			BaselineWatchSeconds: min(current.LastWatchSeconds, total),
+			LastWatchSeconds:     total,
+			MaxPositionSeconds:   max(current.MaxPositionSeconds, position),
+			LastPositionSeconds:  position,
+			PositionTracked:      true,
+		}
+	default:
+		next = *current
+		// 续播位置以观看时间最新的事件为准，乱序到达的较早事件不覆盖。
+		if !watchTime.Before(next.LastProgressAt) {
+			next.LastProgressAt = watchTime
+			next.LastPositionSeconds = position
+		}
+		next.LastWatchSeconds = max(next.LastWatchSeconds, total)
+		next.MaxPositionSeconds = max(next.MaxPositionSeconds, position)
+		next.PositionTracked = true
+	}
+
+	watched := next.LastWatchSeconds - next.BaselineWatchSeconds
+	if next.CountedAt != nil || !p.qualifies(watched, watchedRatio(watched, progress)) {
+		return &next, false
+	}
+	countedAt := watchTime
+	next.CountedAt = &countedAt
+	return &next, true
+}
+
+// watchDeltas 计算会话推进带来的观看时长与最大播放位置增量，供 video_engagement_stats_projection 累加。
+// 累计观看秒数只计增长部分，Profile 侧重置导致的回退不产生负增量；用户首次上报进度时计入完播率分母，
+// 位置统计上线前遗留的会话（PositionTracked 为 false）在下一条进度时补计入分母。
+func watchDeltas(current, next *po.VideoViewSession) (watchSeconds, positionSeconds float64, newViewer int64) {
+	if next == nil {
+		return 0, 0, 0
+	}
+	if current == nil {
+		return next.LastWatchSeconds, next.MaxPositionSeconds, 1
+	}
+	var viewer int64
+	if !current.PositionTracked {
+		viewer = 1
+	}
+	return max(next.LastWatchSeconds-current.LastWatchSeconds, 0),
+		max(next.MaxPositionSeconds-current.MaxPositionSeconds, 0),
+		viewer
+}
+
+// watchedRatio 计算观看秒数占视频时长的比例，视频时长由本条事件的播放位置与 progress_ratio 推算；
+// 无法推算时长（位置或比例为 0）时返回 0，仅按观看秒数判定。
+func watchedRatio(watchedSeconds float64, progress *profilev1.WatchProgress) float64 {
+	position, ratio := progress.GetPositionSeconds(), progress.GetProgressRatio()
+	if position <= 0 || ratio <= 0 {
+		return 0
+	}
+	return watchedSeconds * ratio / position
+}
+
+func (p ViewPolicy) qualifies(watchedSeconds, ratio float64) bool {
+	if p.MinWatchSeconds <= 0 && p.MinWatchRatio <= 0 {
+		return true
+	}
+	if p.MinWatchSeconds > 0 && watchedSeconds >= p.MinWatchSeconds {
+		return true
+	}
+	return p.MinWatchRatio > 0 && ratio >= p.MinWatchRatio
+}
@@ -0,0 +1,114 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	listenerMinBackoff = 500 * time.Millisecond
+	listenerMaxBackoff = 30 * time.Second
+)
+
+// Listener 持有一条专用连接 LISTEN catalog_outbox，每收到通知就向 Wake 通道发信号；
+// 信号通道容量为 1，认领期间到达的多条通知合并为一次唤醒。
+// 连接断开后按指数退避重连，重连成功时补发一次信号，覆盖断连期间漏掉的通知。
+type Listener struct {
+	config  *pgx.ConnConfig
+	channel string
+	wake    chan struct{}
+	log     *log.Helper
+	metrics *metrics
+
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+// NewListener 基于连接池的连接配置构造 Listener；LISTEN 连接独立于连接池，不占用池容量。
+func NewListener(config *pgx.ConnConfig, logger log.Logger) (*Listener, error) {
+	if config == nil {
+		return nil, fmt.Errorf("outbox listener: connection config is required")
+	}
+	if logger == nil {
+		logger = log.DefaultLogger
+	}
+	return &Listener{
+		config:     config.Copy(),
+		channel:    repositories.OutboxNotifyChannel,
+		wake:       make(chan struct{}, 1),
+		log:        log.NewHelper(logger),
+		minBackoff: listenerMinBackoff,
+		maxBackoff: listenerMaxBackoff,
+	}, nil
+}
+
+// Wake 返回唤醒信号通道。
+func (l *Listener) Wake() <-chan struct{} {
+	return l.wake
+}
+
+// Run 保持 LISTEN 连接直到 ctx 结束；连接失败只记录日志并重连，期间发布器依赖 tick 兜底。
+func (l *Listener) Run(ctx context.Context) {
+	backoff := l.minBackoff
+	for {
+		connected, err := l.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if connected {
+			backoff = l.minBackoff
+		}
+		l.log.WithContext(ctx).Warnf("outbox listener disconnected, reconnect in %s: %v", backoff, err)
+		l.metrics.recordReconnect(ctx)
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		backoff = min(backoff*2, l.maxBackoff)
+	}
+}
+
+// listen 建立连接并执行 LISTEN，随后阻塞等待通知；connected 表示本次是否成功进入监听状态。
+func (l *Listener) listen(ctx context.Context) (connected bool, err error) {
+	conn, err := pgx.ConnectConfig(ctx, l.config.Copy())
+	if err != nil {
+		return false, fmt.Errorf("connect: %w", err)
+	}
+	defer func() {
+		closeCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = conn.Close(closeCtx)
+	}()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{l.channel}.Sanitize()); err != nil {
+		return false, fmt.Errorf("listen %s: %w", l.channel, err)
+	}
+	l.log.WithContext(ctx).Infof("outbox listener ready: channel=%s", l.channel)
+	l.signal()
+
+	for {
+		if _, err := conn.WaitForNotification(ctx); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return true, err
+			}
+			return true, fmt.Errorf("wait for notification: %w", err)
+		}
+		l.signal()
+	}
+}
+
+func (l *Listener) signal() {
+	select {
+	case l.wake <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,41 @@
+package outbox
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/metric"
+)
+
+// metrics 只统计唤醒相关指标；发布成功/失败与耗时由共享 Runner 记录。
+type metrics struct {
+	wakeupCounter    metric.Int64Counter
+	reconnectCounter metric.Int64Counter
+}
+
+func newMetrics(m metric.Meter) *metrics {
+	if m == nil {
+		return nil
+	}
+	wakeupCounter, _ := m.Int64Counter("catalog_outbox_wakeups_total")
+	reconnectCounter, _ := m.Int64Counter("catalog_outbox_listener_reconnects_total")
+	return &metrics{
+		wakeupCounter:    wakeupCounter,
+		reconnectCounter: reconnectCounter,
+	}
+}
+
+// recordWakeup 统计 LISTEN 通知触发共享 Runner 立即认领的次数。
+func (m *metrics) recordWakeup(ctx context.Context) {
+	if m == nil || m.wakeupCounter == nil {
+		return
+	}
+	m.wakeupCounter.Add(ctx, 1)
+}
+
+// recordReconnect 统计 LISTEN 连接断开重连的次数。
+func (m *metrics) recordReconnect(ctx context.Context) {
+	if m == nil || m.reconnectCounter == nil {
+		return
+	}
+	m.reconnectCounter.Add(ctx, 1)
+}
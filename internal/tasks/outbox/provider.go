@@ -1,32 +1,33 @@
-// Package outbox wires shared repositories and publisher instances into
-// runnable Outbox workers for integration tests and local execution.
+// Package outbox wires the Outbox repository and the configured event sink
+// (Pub/Sub, Kafka, NATS or webhooks) into a runnable publisher that wakes on
+// LISTEN/NOTIFY and polls as a fallback.
 package outbox
 
 import (
 	outboxcfg "github.com/bionicotaku/lingo-utils/outbox/config"
-	outboxpublisher "github.com/bionicotaku/lingo-utils/outbox/publisher"
 
+	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/eventsink"
 	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
-	"github.com/bionicotaku/lingo-utils/gcpubsub"
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"go.opentelemetry.io/otel"
 	noopmetric "go.opentelemetry.io/otel/metric/noop"
 )
 
-// ProvideRunner 将共享仓储与 Pub/Sub 发布器包装为 Outbox Runner。
+// ProvideRunner 将 Outbox 仓储与发布端包装为 Outbox Runner；提供连接池时同时 LISTEN catalog_outbox。
 func ProvideRunner(
 	repo *repositories.OutboxRepository,
-	publisher gcpubsub.Publisher,
-	pubCfg gcpubsub.Config,
+	pool *pgxpool.Pool,
+	sink eventsink.Sink,
 	cfg outboxcfg.Config,
 	logger log.Logger,
-) *outboxpublisher.Runner {
+) *Runner {
 	if repo == nil || logger == nil {
 		return nil
 	}
 	helper := log.NewHelper(logger)
-	if pubCfg.TopicID == "" {
-		helper.Warn("skip initializing outbox runner: pubsub topic not configured")
+	if sink == nil {
+		helper.Warn("skip initializing outbox runner: event sink not configured")
 		return nil
 	}
 
@@ -38,19 +39,29 @@ func ProvideRunner(
 		meterProvider = noopmetric.NewMeterProvider()
 	}
 
+	var listener *Listener
+	if pool != nil {
+		var err error
+		listener, err = NewListener(pool.Config().ConnConfig, logger)
+		if err != nil {
+			helper.Warnf("outbox listener disabled, falling back to polling: %v", err)
+		}
+	}
+
 	if boolValue(pubCfgNormalized.LoggingEnabled, true) {
-		helper.Infof("init outbox runner: batch_size=%d, workers=%d, tick_interval=%s",
-			pubCfgNormalized.BatchSize, pubCfgNormalized.Workers, pubCfgNormalized.TickInterval)
+		helper.Infof("init outbox runner: batch_size=%d, tick_interval=%s, listen=%t",
+			pubCfgNormalized.BatchSize, pubCfgNormalized.TickInterval, listener != nil)
 	} else {
 		helper.Debug("init outbox runner with logging disabled by configuration")
 	}
 
-	runner, err := outboxpublisher.NewRunner(outboxpublisher.RunnerParams{
-		Store:     repo.Shared(),
-		Publisher: publisher,
-		Config:    pubCfgNormalized,
-		Logger:    logger,
-		Meter:     meterProvider.Meter("lingo-services-catalog.outbox"),
+	runner, err := NewRunner(RunnerParams{
+		Store:    repo,
+		Sink:     sink,
+		Config:   pubCfgNormalized,
+		Listener: listener,
+		Logger:   logger,
+		Meter:    meterProvider.Meter("lingo-services-catalog.outbox"),
 	})
 	if err != nil {
 		helper.Errorw("msg", "init outbox runner failed", "error", err)
@@ -0,0 +1,56 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/eventsink"
+	"github.com/bionicotaku/lingo-utils/gcpubsub"
+	"github.com/google/uuid"
+)
+
+// sinkPublisher 把共享 Runner 产出的 gcpubsub.Message 转交给配置的发布端。
+type sinkPublisher struct {
+	sink eventsink.Sink
+}
+
+var _ gcpubsub.Publisher = (*sinkPublisher)(nil)
+
+func newSinkPublisher(sink eventsink.Sink) *sinkPublisher {
+	return &sinkPublisher{sink: sink}
+}
+
+// Publish 按 attributes 中的 event_id / event_type / aggregate_id 还原事件并投递，返回 event_id 作为消息 ID。
+func (p *sinkPublisher) Publish(ctx context.Context, msg gcpubsub.Message) (string, error) {
+	out, err := toSinkMessage(msg)
+	if err != nil {
+		return "", err
+	}
+	if err := p.sink.Publish(ctx, out); err != nil {
+		return "", err
+	}
+	return out.EventID.String(), nil
+}
+
+func toSinkMessage(msg gcpubsub.Message) (eventsink.Message, error) {
+	eventID, err := uuid.Parse(strings.TrimSpace(msg.Attributes["event_id"]))
+	if err != nil {
+		return eventsink.Message{}, fmt.Errorf("outbox: invalid event_id attribute: %w", err)
+	}
+	aggregate := msg.Attributes["aggregate_id"]
+	if aggregate == "" {
+		aggregate = msg.OrderingKey
+	}
+	aggregateID, err := uuid.Parse(strings.TrimSpace(aggregate))
+	if err != nil {
+		return eventsink.Message{}, fmt.Errorf("outbox: invalid aggregate_id attribute: %w", err)
+	}
+	return eventsink.Message{
+		EventID:     eventID,
+		EventType:   msg.Attributes["event_type"],
+		AggregateID: aggregateID,
+		Payload:     msg.Data,
+		Headers:     msg.Attributes,
+	}, nil
+}
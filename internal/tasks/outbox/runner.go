@@ -0,0 +1,254 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/eventsink"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	outboxcfg "github.com/bionicotaku/lingo-utils/outbox/config"
+	outboxpublisher "github.com/bionicotaku/lingo-utils/outbox/publisher"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// RunnerParams 注入构建 Runner 所需的依赖。
+type RunnerParams struct {
+	Store  *repositories.OutboxRepository
+	Sink   eventsink.Sink
+	Config outboxcfg.PublisherConfig
+	// Listener 可选，配置后收到 catalog_outbox 通知即额外执行一轮认领；为 nil 时仅由共享 Runner 按 TickInterval 轮询。
+	Listener *Listener
+	Logger   log.Logger
+	Meter    metric.Meter
+}
+
+// Runner 常驻运行共享的 outboxpublisher.Runner，由它按 TickInterval 轮询认领、发布、退避重试与回写，
+// 事件经 sinkPublisher 交给配置的发布端。
+//
+// LISTEN 通知到达时，Runner 在共享 Runner 之外立即执行一轮认领与发布（wake pass），不取消、不重启共享 Runner。
+// 两者经同一仓储认领，租约保证同一事件只被其中一方持有；wake pass 与共享 Runner 一样以 Workers 个并发发布，
+// 同样按 InitialBackoff 指数退避重新排期失败的事件。
+type Runner struct {
+	shared   outboxpublisher.RunnerParams
+	store    *repositories.OutboxRepository
+	sink     eventsink.Sink
+	cfg      outboxcfg.PublisherConfig
+	listener *Listener
+	log      *log.Helper
+	logging  bool
+	metrics  *metrics
+	now      func() time.Time
+}
+
+// NewRunner 构造 Runner；共享 Runner 自行规范化配置，wake pass 对未配置的参数取与其一致的默认值。
+func NewRunner(params RunnerParams) (*Runner, error) {
+	if params.Store == nil {
+		return nil, fmt.Errorf("outbox: repository is required")
+	}
+	if params.Sink == nil {
+		return nil, fmt.Errorf("outbox: sink is required")
+	}
+	logger := params.Logger
+	if logger == nil {
+		logger = log.DefaultLogger
+	}
+
+	r := &Runner{
+		shared: outboxpublisher.RunnerParams{
+			Store:     params.Store.Shared(),
+			Publisher: newSinkPublisher(params.Sink),
+			Config:    params.Config,
+			Logger:    logger,
+			Meter:     params.Meter,
+		},
+		store:    params.Store,
+		sink:     params.Sink,
+		cfg:      wakePassConfig(params.Config),
+		listener: params.Listener,
+		log:      log.NewHelper(logger),
+		logging:  boolValue(params.Config.LoggingEnabled, true),
+		metrics:  newMetrics(params.Meter),
+		now:      time.Now,
+	}
+	if _, err := outboxpublisher.NewRunner(r.shared); err != nil {
+		return nil, err
+	}
+	if r.listener != nil {
+		r.listener.metrics = r.metrics
+	}
+	return r, nil
+}
+
+// wakePassConfig 补齐 wake pass 用到的参数，默认值与共享 Runner 一致。
+func wakePassConfig(cfg outboxcfg.PublisherConfig) outboxcfg.PublisherConfig {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	if cfg.InitialBackoff <= 0 {
+		cfg.InitialBackoff = 2 * time.Second
+	}
+	if cfg.MaxBackoff < cfg.InitialBackoff {
+		cfg.MaxBackoff = max(cfg.InitialBackoff, 120*time.Second)
+	}
+	if cfg.PublishTimeout <= 0 {
+		cfg.PublishTimeout = 10 * time.Second
+	}
+	if cfg.Workers <= 0 {
+		cfg.Workers = 1
+	}
+	if cfg.LockTTL <= 0 {
+		cfg.LockTTL = 2 * time.Minute
+	}
+	return cfg
+}
+
+// Run 启动共享 Runner 与监听，直到 ctx 结束或共享 Runner 退出。
+func (r *Runner) Run(ctx context.Context) error {
+	inner, err := outboxpublisher.NewRunner(r.shared)
+	if err != nil {
+		return fmt.Errorf("outbox: init shared runner: %w", err)
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if r.listener != nil {
+		wake := r.listener.Wake()
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			r.listener.Run(runCtx)
+		}()
+		go func() {
+			defer wg.Done()
+			r.wakeLoop(runCtx, wake)
+		}()
+	}
+	return inner.Run(runCtx)
+}
+
+// wakeLoop 每收到一次唤醒就执行一轮 wake pass；执行期间到达的通知由 Listener 合并为下一次唤醒。
+func (r *Runner) wakeLoop(ctx context.Context, wake <-chan struct{}) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-wake:
+			r.metrics.recordWakeup(ctx)
+			r.drain(ctx)
+		}
+	}
+}
+
+// drain 连续认领直到某一批不足 BatchSize，积压时不必等待下一次唤醒。
+func (r *Runner) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		claimed, err := r.processBatch(ctx)
+		if err != nil {
+			if !errors.Is(err, context.Canceled) {
+				r.log.WithContext(ctx).Warnf("outbox wake pass claim failed: %v", err)
+			}
+			return
+		}
+		if claimed < r.cfg.BatchSize {
+			return
+		}
+	}
+}
+
+// processBatch 认领一批事件并以 Workers 个并发发布，返回认领数量。
+func (r *Runner) processBatch(ctx context.Context) (int, error) {
+	now := r.now().UTC()
+	lockToken := uuid.NewString()
+	events, err := r.store.ClaimPending(ctx, now, now.Add(-r.cfg.LockTTL), r.cfg.BatchSize, lockToken)
+	if err != nil {
+		return 0, fmt.Errorf("claim pending: %w", err)
+	}
+
+	sem := make(chan struct{}, r.cfg.Workers)
+	var wg sync.WaitGroup
+	for i := range events {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(evt repositories.OutboxEvent) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			r.publish(ctx, evt, lockToken)
+		}(events[i])
+	}
+	wg.Wait()
+	return len(events), nil
+}
+
+// publish 发布单条事件并回写结果；回写使用独立于 ctx 的上下文，关闭时已发布的事件仍能落库，
+// 回写失败时租约过期后事件会被重新认领。
+func (r *Runner) publish(ctx context.Context, evt repositories.OutboxEvent, lockToken string) {
+	msg := eventsink.Message{
+		EventID:     evt.EventID,
+		EventType:   evt.EventType,
+		AggregateID: evt.AggregateID,
+		Payload:     evt.Payload,
+		Headers:     eventHeaders(evt),
+	}
+
+	pubCtx, cancel := context.WithTimeout(ctx, r.cfg.PublishTimeout)
+	pubErr := r.sink.Publish(pubCtx, msg)
+	cancel()
+
+	writeCtx, cancelWrite := context.WithTimeout(context.WithoutCancel(ctx), r.cfg.PublishTimeout)
+	defer cancelWrite()
+	if pubErr == nil {
+		if err := r.store.MarkPublished(writeCtx, nil, evt.EventID, lockToken, r.now().UTC()); err != nil {
+			r.log.WithContext(ctx).Errorf("outbox mark published failed: event=%s err=%v", evt.EventID, err)
+		}
+		return
+	}
+
+	attempts := int(evt.DeliveryAttempts) + 1
+	delay := r.backoff(attempts)
+	if r.cfg.MaxAttempts > 0 && attempts >= r.cfg.MaxAttempts {
+		r.log.WithContext(ctx).Errorf("outbox event exceeded max attempts: event=%s type=%s attempts=%d err=%v",
+			evt.EventID, evt.EventType, attempts, pubErr)
+	} else if r.logging {
+		r.log.WithContext(ctx).Warnf("outbox publish failed: event=%s type=%s attempts=%d retry_in=%s err=%v",
+			evt.EventID, evt.EventType, attempts, delay, pubErr)
+	}
+	if err := r.store.Reschedule(writeCtx, nil, evt.EventID, lockToken, r.now().UTC().Add(delay), pubErr.Error()); err != nil {
+		r.log.WithContext(ctx).Errorf("outbox reschedule failed: event=%s err=%v", evt.EventID, err)
+	}
+}
+
+// eventHeaders 复制事件头并补齐共享 Runner 写入消息属性的 event_id / event_type / aggregate_type / aggregate_id，
+// 两条发布路径交给发布端的属性保持一致。
+func eventHeaders(evt repositories.OutboxEvent) map[string]string {
+	headers := make(map[string]string, len(evt.Headers)+4)
+	for key, value := range evt.Headers {
+		headers[key] = value
+	}
+	headers["event_id"] = evt.EventID.String()
+	headers["event_type"] = evt.EventType
+	headers["aggregate_type"] = evt.AggregateType
+	headers["aggregate_id"] = evt.AggregateID.String()
+	return headers
+}
+
+// backoff 返回第 attempts 次失败后的重试间隔：InitialBackoff * 2^(attempts-1)，不超过 MaxBackoff。
+func (r *Runner) backoff(attempts int) time.Duration {
+	if r.cfg.MaxAttempts > 0 && attempts >= r.cfg.MaxAttempts {
+		return r.cfg.MaxBackoff
+	}
+	delay := r.cfg.InitialBackoff
+	for i := 1; i < attempts && delay < r.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, r.cfg.MaxBackoff)
+}
@@ -0,0 +1,220 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub/pstest"
+	"cloud.google.com/go/pubsub/v2/apiv1/pubsubpb"
+	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/eventsink"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/outbox"
+	outboxcfg "github.com/bionicotaku/lingo-utils/outbox/config"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// 兜底轮询间隔设为 1h，事件能在秒级发布只可能来自 NOTIFY 唤醒。
+const noPolling = time.Hour
+
+func TestOutboxListener_NotifyWakesClaimLoop(t *testing.T) {
+	t.Parallel()
+
+	env := newListenerEnv(t)
+	listener, err := outbox.NewListener(env.pool.Config().ConnConfig, log.NewStdLogger(io.Discard))
+	require.NoError(t, err)
+	stop := env.startRunner(t, listener, noPolling)
+	defer stop()
+
+	env.waitForListener(t, 0)
+	eventID := env.enqueue(t, true)
+	env.requirePublished(t, eventID, 3*time.Second)
+}
+
+func TestOutboxListener_ReconnectsAfterConnectionLoss(t *testing.T) {
+	t.Parallel()
+
+	env := newListenerEnv(t)
+	listener, err := outbox.NewListener(env.pool.Config().ConnConfig, log.NewStdLogger(io.Discard))
+	require.NoError(t, err)
+	stop := env.startRunner(t, listener, noPolling)
+	defer stop()
+
+	pid := env.waitForListener(t, 0)
+	var terminated bool
+	require.NoError(t, env.pool.QueryRow(context.Background(), `SELECT pg_terminate_backend($1)`, pid).Scan(&terminated))
+	require.True(t, terminated)
+
+	reconnected := env.waitForListener(t, pid)
+	require.NotEqual(t, pid, reconnected)
+
+	eventID := env.enqueue(t, true)
+	env.requirePublished(t, eventID, 3*time.Second)
+}
+
+// TestOutboxListener_BurstPublishesEachEventOnce 连续通知不会打断已认领的事件：
+// 全部事件在 LockTTL 到期前发布完成，且每条只发布一次。
+func TestOutboxListener_BurstPublishesEachEventOnce(t *testing.T) {
+	t.Parallel()
+
+	env := newListenerEnv(t)
+	listener, err := outbox.NewListener(env.pool.Config().ConnConfig, log.NewStdLogger(io.Discard))
+	require.NoError(t, err)
+	stop := env.startRunner(t, listener, noPolling)
+	defer stop()
+
+	env.waitForListener(t, 0)
+	eventIDs := make([]uuid.UUID, 0, 25)
+	for range 25 {
+		eventIDs = append(eventIDs, env.enqueue(t, true))
+	}
+	for _, eventID := range eventIDs {
+		env.requirePublished(t, eventID, 3*time.Second)
+	}
+	require.Len(t, env.server.Messages(), len(eventIDs))
+}
+
+func TestOutboxListener_FallsBackToTickWhenUnavailable(t *testing.T) {
+	t.Parallel()
+
+	env := newListenerEnv(t)
+	unreachable := env.pool.Config().ConnConfig.Copy()
+	unreachable.Port = 1
+	unreachable.ConnectTimeout = 200 * time.Millisecond
+	listener, err := outbox.NewListener(unreachable, log.NewStdLogger(io.Discard))
+	require.NoError(t, err)
+	stop := env.startRunner(t, listener, 100*time.Millisecond)
+	defer stop()
+
+	// 不发通知，且监听连接始终失败：事件仍由 tick 轮询发布。
+	eventID := env.enqueue(t, false)
+	env.requirePublished(t, eventID, 5*time.Second)
+}
+
+type listenerEnv struct {
+	pool   *pgxpool.Pool
+	repo   *repositories.OutboxRepository
+	server *pstest.Server
+	topic  string
+}
+
+func newListenerEnv(t *testing.T) *listenerEnv {
+	t.Helper()
+	ctx := context.Background()
+
+	dsn, terminate := startPostgres(ctx, t)
+	t.Cleanup(terminate)
+
+	pool, err := pgxpool.New(ctx, dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { pool.Close() })
+	applyMigrations(ctx, t, pool)
+
+	server := pstest.NewServer()
+	t.Cleanup(func() { _ = server.Close() })
+	topic := "catalog-video-events"
+	_, err = server.GServer.CreateTopic(ctx, &pubsubpb.Topic{Name: fmt.Sprintf("projects/test-project/topics/%s", topic)})
+	require.NoError(t, err)
+
+	return &listenerEnv{
+		pool:   pool,
+		repo:   repositories.NewOutboxRepository(pool, log.NewStdLogger(io.Discard), defaultOutboxConfig),
+		server: server,
+		topic:  topic,
+	}
+}
+
+func (e *listenerEnv) startRunner(t *testing.T, listener *outbox.Listener, tick time.Duration) func() {
+	t.Helper()
+	ctx := context.Background()
+
+	_, cleanupPub, publisher := newTestPublisher(ctx, t, e.server, "test-project", e.topic)
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewManualReader()))
+	runner, err := outbox.NewRunner(outbox.RunnerParams{
+		Store: e.repo,
+		Sink:  eventsink.NewPubSubSink(publisher, false),
+		Config: outboxcfg.PublisherConfig{
+			BatchSize:      10,
+			TickInterval:   tick,
+			InitialBackoff: 50 * time.Millisecond,
+			MaxBackoff:     200 * time.Millisecond,
+			MaxAttempts:    3,
+			PublishTimeout: time.Second,
+			Workers:        2,
+			LockTTL:        5 * time.Second,
+		},
+		Listener: listener,
+		Logger:   log.NewStdLogger(io.Discard),
+		Meter:    provider.Meter("lingo-services-catalog.outbox.test"),
+	})
+	require.NoError(t, err)
+
+	runCtx, cancel := context.WithCancel(ctx)
+	errCh := make(chan error, 1)
+	go func() { errCh <- runner.Run(runCtx) }()
+
+	return func() {
+		cancel()
+		select {
+		case err := <-errCh:
+			require.True(t, err == nil || errors.Is(err, context.Canceled))
+		case <-time.After(5 * time.Second):
+			t.Fatal("runner did not stop in time")
+		}
+		cleanupPub()
+	}
+}
+
+// waitForListener 等待 LISTEN 连接就绪并返回其后端 pid；previous 非 0 时等待新的连接。
+func (e *listenerEnv) waitForListener(t *testing.T, previous int32) int32 {
+	t.Helper()
+	var pid int32
+	require.Eventually(t, func() bool {
+		err := e.pool.QueryRow(context.Background(), `
+			SELECT pid FROM pg_stat_activity
+			WHERE query = $1 AND pid <> $2
+			ORDER BY backend_start DESC
+			LIMIT 1`, "LISTEN "+pgx.Identifier{repositories.OutboxNotifyChannel}.Sanitize(), previous).Scan(&pid)
+		return err == nil
+	}, 10*time.Second, 50*time.Millisecond)
+	return pid
+}
+
+func (e *listenerEnv) enqueue(t *testing.T, notify bool) uuid.UUID {
+	t.Helper()
+	ctx := context.Background()
+	eventID := uuid.New()
+	msg := repositories.OutboxMessage{
+		EventID:       eventID,
+		AggregateType: "video",
+		AggregateID:   uuid.New(),
+		EventType:     "catalog.video.created",
+		Payload:       []byte(`{}`),
+		Headers:       map[string]string{"schema_version": "v1"},
+		AvailableAt:   time.Now().UTC(),
+	}
+	require.NoError(t, e.repo.Enqueue(ctx, nil, msg))
+	if notify {
+		require.NoError(t, e.repo.Notify(ctx, nil, msg.EventType))
+	}
+	return eventID
+}
+
+func (e *listenerEnv) requirePublished(t *testing.T, eventID uuid.UUID, within time.Duration) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		var publishedAt pgtype.Timestamptz
+		err := e.pool.QueryRow(context.Background(), `
+			SELECT published_at FROM catalog.outbox_events WHERE event_id = $1`, eventID).Scan(&publishedAt)
+		return err == nil && publishedAt.Valid
+	}, within, 50*time.Millisecond)
+}
@@ -13,10 +13,11 @@ import (
 
 	"cloud.google.com/go/pubsub/pstest"
 	"cloud.google.com/go/pubsub/v2/apiv1/pubsubpb"
+	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/eventsink"
 	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/outbox"
 	"github.com/bionicotaku/lingo-utils/gcpubsub"
 	outboxcfg "github.com/bionicotaku/lingo-utils/outbox/config"
-	outboxpublisher "github.com/bionicotaku/lingo-utils/outbox/publisher"
 	"github.com/docker/go-connections/nat"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/google/uuid"
@@ -320,7 +321,7 @@ func newTestPublisher(ctx context.Context, t *testing.T, server *pstest.Server,
 	return component, cleanup, publisher
 }
 
-func newPublisherRunner(t *testing.T, repo *repositories.OutboxRepository, publisher gcpubsub.Publisher, meter metricapi.Meter, cfg outboxcfg.PublisherConfig) *outboxpublisher.Runner {
+func newPublisherRunner(t *testing.T, repo *repositories.OutboxRepository, publisher gcpubsub.Publisher, meter metricapi.Meter, cfg outboxcfg.PublisherConfig) *outbox.Runner {
 	t.Helper()
 
 	if cfg.BatchSize == 0 {
@@ -337,12 +338,12 @@ func newPublisherRunner(t *testing.T, repo *repositories.OutboxRepository, publi
 	cfg.LoggingEnabled = &logging
 	cfg.MetricsEnabled = &metrics
 
-	runner, err := outboxpublisher.NewRunner(outboxpublisher.RunnerParams{
-		Store:     repo.Shared(),
-		Publisher: publisher,
-		Config:    cfg,
-		Logger:    log.NewStdLogger(io.Discard),
-		Meter:     meter,
+	runner, err := outbox.NewRunner(outbox.RunnerParams{
+		Store:  repo,
+		Sink:   eventsink.NewPubSubSink(publisher, false),
+		Config: cfg,
+		Logger: log.NewStdLogger(io.Discard),
+		Meter:  meter,
 	})
 	require.NoError(t, err)
 	return runner
@@ -0,0 +1,228 @@
+package quarantine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-utils/outbox/inbox"
+	"github.com/bionicotaku/lingo-utils/outbox/store"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultListLimit = 50
+	maxListLimit     = 500
+)
+
+// AdminStore 定义运维处置隔离事件所需的接口。
+type AdminStore interface {
+	List(ctx context.Context, sess txmanager.Session, filter repositories.InboxQuarantineFilter) ([]*po.InboxQuarantineEntry, error)
+	Get(ctx context.Context, sess txmanager.Session, consumer string, eventID uuid.UUID) (*po.InboxQuarantineEntry, error)
+	Resolve(ctx context.Context, sess txmanager.Session, consumer string, eventID uuid.UUID, resolution po.InboxQuarantineResolution, note string) error
+	RecordReplayFailure(ctx context.Context, sess txmanager.Session, consumer string, eventID uuid.UUID, lastErr string) error
+}
+
+var _ AdminStore = (*repositories.InboxQuarantineRepository)(nil)
+
+// ReplayFunc 在给定事务内重新处理隔离事件，通常直接调用未装饰的 Inbox 处理器。
+type ReplayFunc func(ctx context.Context, sess txmanager.Session, evt *store.InboxEvent) error
+
+// DecodeFunc 将事件负载解码为可 JSON 序列化的结构，供 show 子命令展示。
+type DecodeFunc func(eventType string, payload []byte) (any, error)
+
+// Replayer 以消费者自身的解码器与处理器构造 ReplayFunc。
+func Replayer[T any](decoder inbox.Decoder[T], handler inbox.Handler[T]) ReplayFunc {
+	return func(ctx context.Context, sess txmanager.Session, evt *store.InboxEvent) error {
+		decoded, err := decoder.Decode(evt.Payload)
+		if err != nil {
+			return fmt.Errorf("decode payload: %w", err)
+		}
+		return handler.Handle(ctx, sess, decoded, evt)
+	}
+}
+
+// Admin 为单个消费者提供隔离事件的列表、查看、重放与丢弃。
+type Admin struct {
+	consumer string
+	store    AdminStore
+	tx       txmanager.Manager
+	replay   ReplayFunc
+	decode   DecodeFunc
+	log      *log.Helper
+	metrics  *metrics
+}
+
+// AdminParams 注入 Admin 所需依赖；Decode 可选，为空时 show 仅返回原始负载。
+type AdminParams struct {
+	Consumer  string
+	Store     AdminStore
+	TxManager txmanager.Manager
+	Replay    ReplayFunc
+	Decode    DecodeFunc
+	Logger    log.Logger
+}
+
+// NewAdmin 构造 Admin。
+func NewAdmin(params AdminParams) (*Admin, error) {
+	if params.Consumer == "" {
+		return nil, fmt.Errorf("quarantine: consumer is required")
+	}
+	if params.Store == nil {
+		return nil, fmt.Errorf("quarantine: store is required")
+	}
+	if params.TxManager == nil {
+		return nil, fmt.Errorf("quarantine: tx manager is required")
+	}
+	if params.Replay == nil {
+		return nil, fmt.Errorf("quarantine: replay func is required")
+	}
+	logger := params.Logger
+	if logger == nil {
+		logger = log.DefaultLogger
+	}
+	return &Admin{
+		consumer: params.Consumer,
+		store:    params.Store,
+		tx:       params.TxManager,
+		replay:   params.Replay,
+		decode:   params.Decode,
+		log:      log.NewHelper(logger),
+		metrics:  newMetrics(),
+	}, nil
+}
+
+// ListOptions 限定 List 返回的隔离事件；Limit <= 0 时取默认值。
+type ListOptions struct {
+	ErrorClass      string
+	IncludeResolved bool
+	Limit           int
+}
+
+// List 按隔离时间升序列出隔离事件，默认仅包含待处置记录。
+func (a *Admin) List(ctx context.Context, opts ListOptions) ([]*po.InboxQuarantineEntry, error) {
+	filter := repositories.InboxQuarantineFilter{
+		Consumer:        a.consumer,
+		IncludeResolved: opts.IncludeResolved,
+		Limit:           opts.Limit,
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultListLimit
+	}
+	if filter.Limit > maxListLimit {
+		filter.Limit = maxListLimit
+	}
+	if opts.ErrorClass != "" {
+		class := opts.ErrorClass
+		filter.ErrorClass = &class
+	}
+	var entries []*po.InboxQuarantineEntry
+	err := a.tx.WithinReadOnlyTx(ctx, txmanager.TxOptions{}, func(txCtx context.Context, sess txmanager.Session) error {
+		var err error
+		entries, err = a.store.List(txCtx, sess, filter)
+		return err
+	})
+	return entries, err
+}
+
+// Detail 是单条隔离事件及其解码后的负载；解码失败时 DecodeError 记录原因。
+type Detail struct {
+	Entry       *po.InboxQuarantineEntry
+	Decoded     any
+	DecodeError string
+}
+
+// Show 读取隔离事件并解码负载；Get 带行锁，因此在读写事务内执行。
+func (a *Admin) Show(ctx context.Context, eventID uuid.UUID) (*Detail, error) {
+	var entry *po.InboxQuarantineEntry
+	err := a.tx.WithinTx(ctx, txmanager.TxOptions{}, func(txCtx context.Context, sess txmanager.Session) error {
+		var err error
+		entry, err = a.store.Get(txCtx, sess, a.consumer, eventID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	detail := &Detail{Entry: entry}
+	if a.decode != nil {
+		decoded, err := a.decode(entry.EventType, entry.Payload)
+		if err != nil {
+			detail.DecodeError = err.Error()
+		} else {
+			detail.Decoded = decoded
+		}
+	}
+	return detail, nil
+}
+
+// Replay 在单个事务内重新处理隔离事件并标记为 replayed；处理失败时回滚，另起事务累加重放失败次数并返回错误。
+func (a *Admin) Replay(ctx context.Context, eventID uuid.UUID) error {
+	var replayErr error
+	err := a.tx.WithinTx(ctx, txmanager.TxOptions{}, func(txCtx context.Context, sess txmanager.Session) error {
+		replayErr = nil
+		entry, err := a.store.Get(txCtx, sess, a.consumer, eventID)
+		if err != nil {
+			return err
+		}
+		if entry.ResolvedAt != nil {
+			return repositories.ErrInboxQuarantineResolved
+		}
+		if err := a.replay(txCtx, sess, inboxEventFromEntry(entry)); err != nil {
+			replayErr = err
+			return err
+		}
+		return a.store.Resolve(txCtx, sess, a.consumer, eventID, po.InboxQuarantineReplayed, "")
+	})
+	if replayErr != nil && !errors.Is(replayErr, context.Canceled) {
+		a.log.WithContext(ctx).Warnf("inbox quarantine replay failed: consumer=%s event=%s err=%v", a.consumer, eventID, replayErr)
+		recordErr := a.tx.WithinTx(ctx, txmanager.TxOptions{}, func(txCtx context.Context, sess txmanager.Session) error {
+			return a.store.RecordReplayFailure(txCtx, sess, a.consumer, eventID, replayErr.Error())
+		})
+		if recordErr != nil {
+			a.log.WithContext(ctx).Warnf("inbox quarantine record replay failure failed: event=%s err=%v", eventID, recordErr)
+		}
+		return fmt.Errorf("replay event %s: %w", eventID, replayErr)
+	}
+	if err != nil {
+		return err
+	}
+	a.log.WithContext(ctx).Infof("inbox quarantine replayed: consumer=%s event=%s", a.consumer, eventID)
+	a.metrics.recordResolved(ctx, a.consumer, po.InboxQuarantineReplayed)
+	return nil
+}
+
+// Discard 将隔离事件标记为 discarded，reason 记入处置备注。
+func (a *Admin) Discard(ctx context.Context, eventID uuid.UUID, reason string) error {
+	err := a.tx.WithinTx(ctx, txmanager.TxOptions{}, func(txCtx context.Context, sess txmanager.Session) error {
+		entry, err := a.store.Get(txCtx, sess, a.consumer, eventID)
+		if err != nil {
+			return err
+		}
+		if entry.ResolvedAt != nil {
+			return repositories.ErrInboxQuarantineResolved
+		}
+		return a.store.Resolve(txCtx, sess, a.consumer, eventID, po.InboxQuarantineDiscarded, reason)
+	})
+	if err != nil {
+		return err
+	}
+	a.log.WithContext(ctx).Infof("inbox quarantine discarded: consumer=%s event=%s reason=%q", a.consumer, eventID, reason)
+	a.metrics.recordResolved(ctx, a.consumer, po.InboxQuarantineDiscarded)
+	return nil
+}
+
+func inboxEventFromEntry(entry *po.InboxQuarantineEntry) *store.InboxEvent {
+	return &store.InboxEvent{
+		EventID:       entry.EventID,
+		SourceService: entry.SourceService,
+		EventType:     entry.EventType,
+		AggregateType: entry.AggregateType,
+		AggregateID:   entry.AggregateID,
+		Payload:       entry.Payload,
+		ReceivedAt:    entry.ReceivedAt,
+	}
+}
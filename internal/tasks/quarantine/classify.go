@@ -0,0 +1,64 @@
+// Package quarantine 为 Inbox 消费者提供毒消息隔离：处理器返回永久性错误时，事件快照登记到
+// catalog.inbox_quarantine 后照常确认，不再反复 nack 占用重试；运维可列出、查看、重放或丢弃隔离事件。
+package quarantine
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+)
+
+// 未携带 reason 的永久错误使用的默认分类。
+const defaultClass = "permanent"
+
+// PermanentError 标记重试无法恢复的处理错误，Class 作为隔离记录与指标中的错误分类。
+type PermanentError struct {
+	Class string
+	Err   error
+}
+
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// Permanent 将 err 标记为永久错误；err 为 nil 时返回 nil。
+func Permanent(class string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &PermanentError{Class: class, Err: err}
+}
+
+// Classify 判断错误是否为永久错误并返回其分类：
+// 显式标记的 PermanentError 使用其 Class；kratos 400/422 错误使用 reason；
+// 其余错误（含 404 等可能因依赖数据尚未到达而暂时失败的 4xx、ctx 取消、数据库错误）视为可重试。
+func Classify(err error) (string, bool) {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return "", false
+	}
+	var perm *PermanentError
+	if errors.As(err, &perm) {
+		return classOrDefault(perm.Class), true
+	}
+	var se *kerrors.Error
+	if errors.As(err, &se) {
+		switch int(se.Code) {
+		case http.StatusBadRequest, http.StatusUnprocessableEntity:
+			return classOrDefault(se.Reason), true
+		}
+	}
+	return "", false
+}
+
+func classOrDefault(class string) string {
+	if class == "" {
+		return defaultClass
+	}
+	return class
+}
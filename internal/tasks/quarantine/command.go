@@ -0,0 +1,172 @@
+package quarantine
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/google/uuid"
+)
+
+// Usage 描述 quarantine 子命令的用法。
+const Usage = "quarantine list [-error-class C] [-all] [-limit N] | show -event-id ID | replay -event-id ID | discard -event-id ID [-reason R]"
+
+// EntryView 是隔离事件的 JSON 视图；列表中省略负载，show 额外附带原始与解码后的负载。
+type EntryView struct {
+	EventID        string     `json:"event_id"`
+	Consumer       string     `json:"consumer"`
+	SourceService  string     `json:"source_service"`
+	EventType      string     `json:"event_type"`
+	AggregateType  *string    `json:"aggregate_type,omitempty"`
+	AggregateID    *string    `json:"aggregate_id,omitempty"`
+	ReceivedAt     time.Time  `json:"received_at"`
+	ErrorClass     string     `json:"error_class"`
+	LastError      string     `json:"last_error"`
+	QuarantinedAt  time.Time  `json:"quarantined_at"`
+	ReplayAttempts int32      `json:"replay_attempts"`
+	PayloadBytes   int        `json:"payload_bytes"`
+	ResolvedAt     *time.Time `json:"resolved_at,omitempty"`
+	Resolution     string     `json:"resolution,omitempty"`
+	ResolvedNote   *string    `json:"resolved_note,omitempty"`
+}
+
+// DetailView 是 show 子命令的 JSON 输出。
+type DetailView struct {
+	EntryView
+	Payload     []byte `json:"payload"`
+	Decoded     any    `json:"decoded,omitempty"`
+	DecodeError string `json:"decode_error,omitempty"`
+}
+
+// ResultView 是 replay/discard 子命令的 JSON 输出。
+type ResultView struct {
+	EventID    string `json:"event_id"`
+	Resolution string `json:"resolution"`
+}
+
+// RunCommand 解析 `quarantine <list|show|replay|discard> [flags]` 参数并执行，结果以 JSON 写入 stdout。
+func RunCommand(ctx context.Context, admin *Admin, args []string, stdout io.Writer) error {
+	if admin == nil {
+		return fmt.Errorf("quarantine admin not initialized")
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("missing subcommand, usage: %s", Usage)
+	}
+	enc := json.NewEncoder(stdout)
+	enc.SetIndent("", "  ")
+
+	switch args[0] {
+	case "list":
+		fs := flag.NewFlagSet("quarantine list", flag.ContinueOnError)
+		class := fs.String("error-class", "", "only list entries of this error class")
+		all := fs.Bool("all", false, "include replayed and discarded entries")
+		limit := fs.Int("limit", defaultListLimit, "max entries to list")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		entries, err := admin.List(ctx, ListOptions{
+			ErrorClass:      strings.TrimSpace(*class),
+			IncludeResolved: *all,
+			Limit:           *limit,
+		})
+		if err != nil {
+			return err
+		}
+		views := make([]EntryView, 0, len(entries))
+		for _, entry := range entries {
+			views = append(views, entryView(entry))
+		}
+		return enc.Encode(views)
+	case "show":
+		fs := flag.NewFlagSet("quarantine show", flag.ContinueOnError)
+		eventFlag := fs.String("event-id", "", "quarantined event_id")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		eventID, err := parseEventID(*eventFlag)
+		if err != nil {
+			return err
+		}
+		detail, err := admin.Show(ctx, eventID)
+		if err != nil {
+			return err
+		}
+		return enc.Encode(DetailView{
+			EntryView:   entryView(detail.Entry),
+			Payload:     detail.Entry.Payload,
+			Decoded:     detail.Decoded,
+			DecodeError: detail.DecodeError,
+		})
+	case "replay":
+		fs := flag.NewFlagSet("quarantine replay", flag.ContinueOnError)
+		eventFlag := fs.String("event-id", "", "quarantined event_id")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		eventID, err := parseEventID(*eventFlag)
+		if err != nil {
+			return err
+		}
+		if err := admin.Replay(ctx, eventID); err != nil {
+			return err
+		}
+		return enc.Encode(ResultView{EventID: eventID.String(), Resolution: string(po.InboxQuarantineReplayed)})
+	case "discard":
+		fs := flag.NewFlagSet("quarantine discard", flag.ContinueOnError)
+		eventFlag := fs.String("event-id", "", "quarantined event_id")
+		reason := fs.String("reason", "", "why the event is discarded")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		eventID, err := parseEventID(*eventFlag)
+		if err != nil {
+			return err
+		}
+		if err := admin.Discard(ctx, eventID, strings.TrimSpace(*reason)); err != nil {
+			return err
+		}
+		return enc.Encode(ResultView{EventID: eventID.String(), Resolution: string(po.InboxQuarantineDiscarded)})
+	default:
+		return fmt.Errorf("unknown subcommand %q, usage: %s", args[0], Usage)
+	}
+}
+
+func parseEventID(raw string) (uuid.UUID, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return uuid.Nil, fmt.Errorf("-event-id is required")
+	}
+	eventID, err := uuid.Parse(raw)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid -event-id: %w", err)
+	}
+	return eventID, nil
+}
+
+func entryView(entry *po.InboxQuarantineEntry) EntryView {
+	view := EntryView{
+		EventID:        entry.EventID.String(),
+		Consumer:       entry.Consumer,
+		SourceService:  entry.SourceService,
+		EventType:      entry.EventType,
+		AggregateType:  entry.AggregateType,
+		AggregateID:    entry.AggregateID,
+		ReceivedAt:     entry.ReceivedAt,
+		ErrorClass:     entry.ErrorClass,
+		LastError:      entry.LastError,
+		QuarantinedAt:  entry.QuarantinedAt,
+		ReplayAttempts: entry.ReplayAttempts,
+		PayloadBytes:   len(entry.Payload),
+		ResolvedAt:     entry.ResolvedAt,
+		ResolvedNote:   entry.ResolvedNote,
+	}
+	if entry.Resolution != nil {
+		view.Resolution = string(*entry.Resolution)
+	}
+	return view
+}
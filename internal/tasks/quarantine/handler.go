@@ -0,0 +1,91 @@
+package quarantine
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-utils/outbox/inbox"
+	"github.com/bionicotaku/lingo-utils/outbox/store"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// savepointName 为处理器写入所在的保存点，永久错误时回滚到此处再登记隔离记录。
+const savepointName = "inbox_quarantine"
+
+// Store 定义登记隔离事件所需的接口。
+type Store interface {
+	Quarantine(ctx context.Context, sess txmanager.Session, input repositories.QuarantineInboxEventInput) error
+}
+
+var _ Store = (*repositories.InboxQuarantineRepository)(nil)
+
+// Handler 装饰 Inbox 处理器：处理器在保存点内执行，返回永久错误时回滚其写入、在同一事务内登记隔离记录并返回 nil，
+// 由 Runner 照常标记事件已处理并确认消息；可重试错误原样返回。
+type Handler[T any] struct {
+	consumer string
+	next     inbox.Handler[T]
+	store    Store
+	log      *log.Helper
+	metrics  *metrics
+}
+
+// NewHandler 构造隔离装饰器；consumer 标识消费者（如 engagement、uploads），用于隔离记录、指标与重放路由。
+func NewHandler[T any](consumer string, next inbox.Handler[T], store Store, logger log.Logger) *Handler[T] {
+	if logger == nil {
+		logger = log.DefaultLogger
+	}
+	return &Handler[T]{
+		consumer: consumer,
+		next:     next,
+		store:    store,
+		log:      log.NewHelper(logger),
+		metrics:  newMetrics(),
+	}
+}
+
+// Handle 执行被装饰的处理器，并按错误分类决定隔离还是交由 Runner 重试。
+func (h *Handler[T]) Handle(ctx context.Context, sess txmanager.Session, evt *T, inboxEvt *store.InboxEvent) error {
+	if inboxEvt == nil || h.store == nil {
+		return h.next.Handle(ctx, sess, evt, inboxEvt)
+	}
+
+	savepoint := sess != nil && sess.Tx() != nil
+	if savepoint {
+		if _, err := sess.Tx().Exec(ctx, "SAVEPOINT "+savepointName); err != nil {
+			return fmt.Errorf("quarantine: create savepoint: %w", err)
+		}
+	}
+	err := h.next.Handle(ctx, sess, evt, inboxEvt)
+	if err == nil {
+		if savepoint {
+			if _, err := sess.Tx().Exec(ctx, "RELEASE SAVEPOINT "+savepointName); err != nil {
+				return fmt.Errorf("quarantine: release savepoint: %w", err)
+			}
+		}
+		return nil
+	}
+	class, permanent := Classify(err)
+	if !permanent {
+		return err
+	}
+
+	if savepoint {
+		if _, rbErr := sess.Tx().Exec(ctx, "ROLLBACK TO SAVEPOINT "+savepointName); rbErr != nil {
+			return fmt.Errorf("quarantine: rollback savepoint: %w (handler error: %v)", rbErr, err)
+		}
+	}
+	if qErr := h.store.Quarantine(ctx, sess, repositories.QuarantineInboxEventInput{
+		Consumer:   h.consumer,
+		Event:      *inboxEvt,
+		ErrorClass: class,
+		LastError:  err.Error(),
+	}); qErr != nil {
+		return fmt.Errorf("quarantine event %s: %w (handler error: %v)", inboxEvt.EventID, qErr, err)
+	}
+	h.log.WithContext(ctx).Warnf("inbox event quarantined: consumer=%s event=%s type=%s class=%s err=%v",
+		h.consumer, inboxEvt.EventID, inboxEvt.EventType, class, err)
+	h.metrics.recordQuarantined(ctx, h.consumer, class)
+	return nil
+}
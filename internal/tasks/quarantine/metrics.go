@@ -0,0 +1,49 @@
+package quarantine
+
+import (
+	"context"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const meterName = "lingo-services-catalog.inbox"
+
+type metrics struct {
+	quarantined metric.Int64Counter
+	resolved    metric.Int64Counter
+}
+
+func newMetrics() *metrics {
+	m := otel.GetMeterProvider().Meter(meterName)
+	quarantined, _ := m.Int64Counter("catalog_inbox_quarantined_total")
+	resolved, _ := m.Int64Counter("catalog_inbox_quarantine_resolved_total")
+	return &metrics{
+		quarantined: quarantined,
+		resolved:    resolved,
+	}
+}
+
+// recordQuarantined 按消费者与错误分类统计隔离的事件。
+func (m *metrics) recordQuarantined(ctx context.Context, source, class string) {
+	if m == nil || m.quarantined == nil {
+		return
+	}
+	m.quarantined.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("source", source),
+		attribute.String("error_class", class),
+	))
+}
+
+// recordResolved 按消费者与处置结果统计人工处置的隔离事件。
+func (m *metrics) recordResolved(ctx context.Context, source string, resolution po.InboxQuarantineResolution) {
+	if m == nil || m.resolved == nil {
+		return
+	}
+	m.resolved.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("source", source),
+		attribute.String("resolution", string(resolution)),
+	))
+}
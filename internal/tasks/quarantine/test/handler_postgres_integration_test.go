@@ -0,0 +1,174 @@
+package quarantine_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/quarantine"
+	outboxcfg "github.com/bionicotaku/lingo-utils/outbox/config"
+	"github.com/bionicotaku/lingo-utils/outbox/store"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/docker/go-connections/nat"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/jackc/pgx/v5/pgxpool"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// TestHandlerQuarantineAgainstPostgres 按 Inbox Runner 的事务顺序执行：登记 Inbox 事件、调用装饰后的处理器、标记已处理并提交。
+// 处理器写入后返回永久错误：其写入随保存点回滚，隔离记录与 Inbox 已处理标记随事务一起提交。
+func TestHandlerQuarantineAgainstPostgres(t *testing.T) {
+	ctx := context.Background()
+	dsn, terminate := startPostgres(ctx, t)
+	defer terminate()
+
+	pool, err := pgxpool.New(ctx, dsn)
+	require.NoError(t, err)
+	defer pool.Close()
+	applyMigrations(ctx, t, pool)
+	_, err = pool.Exec(ctx, `create table catalog.quarantine_probe (event_id uuid primary key)`)
+	require.NoError(t, err)
+
+	logger := log.NewStdLogger(io.Discard)
+	txMgr, err := txmanager.NewManager(pool, txmanager.Config{}, txmanager.Dependencies{Logger: logger})
+	require.NoError(t, err)
+	inboxRepo := repositories.NewInboxRepository(pool, logger, outboxcfg.Config{Schema: "catalog"})
+	quarantineRepo := repositories.NewInboxQuarantineRepository(pool, logger)
+
+	next := &probeHandler{err: kerrors.BadRequest("invalid-user-id", "invalid user_id")}
+	handler := quarantine.NewHandler[testEvent]("engagement", next, quarantineRepo, logger)
+	evt := newInboxEvent()
+
+	err = txMgr.WithinTx(ctx, txmanager.TxOptions{}, func(txCtx context.Context, sess txmanager.Session) error {
+		if err := inboxRepo.Insert(txCtx, sess, repositories.InboxMessage{
+			EventID:       evt.EventID,
+			SourceService: evt.SourceService,
+			EventType:     evt.EventType,
+			Payload:       evt.Payload,
+		}); err != nil {
+			return err
+		}
+		if err := handler.Handle(txCtx, sess, &testEvent{}, evt); err != nil {
+			return err
+		}
+		return inboxRepo.MarkProcessed(txCtx, sess, evt.EventID, time.Now().UTC())
+	})
+	require.NoError(t, err)
+
+	var probes int
+	require.NoError(t, pool.QueryRow(ctx, `select count(*) from catalog.quarantine_probe`).Scan(&probes))
+	require.Zero(t, probes, "handler writes must be rolled back to the savepoint")
+
+	var class string
+	require.NoError(t, pool.QueryRow(ctx, `select error_class from catalog.inbox_quarantine where event_id = $1`, evt.EventID).Scan(&class))
+	require.Equal(t, "invalid-user-id", class)
+
+	var processed bool
+	require.NoError(t, pool.QueryRow(ctx, `select processed_at is not null from catalog.inbox_events where event_id = $1`, evt.EventID).Scan(&processed))
+	require.True(t, processed)
+}
+
+// probeHandler 先在事务内写入探针行，再返回 err。
+type probeHandler struct {
+	err error
+}
+
+func (h *probeHandler) Handle(ctx context.Context, sess txmanager.Session, _ *testEvent, evt *store.InboxEvent) error {
+	if _, err := sess.Tx().Exec(ctx, `insert into catalog.quarantine_probe (event_id) values ($1)`, evt.EventID); err != nil {
+		return err
+	}
+	return h.err
+}
+
+func startPostgres(ctx context.Context, t testing.TB) (string, func()) {
+	t.Helper()
+
+	req := testcontainers.ContainerRequest{
+		Image:        "postgres:16-alpine",
+		ExposedPorts: []string{"5432/tcp"},
+		Env: map[string]string{
+			"POSTGRES_PASSWORD": "postgres",
+			"POSTGRES_USER":     "postgres",
+			"POSTGRES_DB":       "catalog",
+		},
+		WaitingFor: wait.ForSQL("5432/tcp", "pgx", func(host string, port nat.Port) string {
+			return fmt.Sprintf("postgres://postgres:postgres@%s:%s/catalog?sslmode=disable", host, port.Port())
+		}).WithStartupTimeout(60 * time.Second),
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	if err != nil {
+		t.Skipf("skip integration: cannot start postgres container: %v", err)
+	}
+
+	host, err := container.Host(ctx)
+	require.NoError(t, err)
+	port, err := container.MappedPort(ctx, "5432")
+	require.NoError(t, err)
+
+	dsn := fmt.Sprintf("postgres://postgres:postgres@%s:%s/catalog?sslmode=disable", host, port.Port())
+	cleanup := func() {
+		termCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = container.Terminate(termCtx)
+	}
+	return dsn, cleanup
+}
+
+func applyMigrations(ctx context.Context, t testing.TB, pool *pgxpool.Pool) {
+	t.Helper()
+
+	_, err := pool.Exec(ctx, `create schema if not exists auth; create table if not exists auth.users (id uuid primary key, email text)`)
+	require.NoError(t, err)
+
+	migrationsDir := findMigrationsDir(t)
+	files, err := os.ReadDir(migrationsDir)
+	require.NoError(t, err)
+
+	paths := make([]string, 0, len(files))
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".sql" {
+			continue
+		}
+		paths = append(paths, filepath.Join(migrationsDir, f.Name()))
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		sqlBytes, readErr := os.ReadFile(path)
+		require.NoError(t, readErr)
+		_, execErr := pool.Exec(ctx, string(sqlBytes))
+		require.NoErrorf(t, execErr, "apply migration %s", filepath.Base(path))
+	}
+}
+
+func findMigrationsDir(t testing.TB) string {
+	t.Helper()
+
+	dir, err := os.Getwd()
+	require.NoError(t, err)
+
+	for dir != "" && dir != "/" {
+		candidate := filepath.Join(dir, "migrations")
+		if info, statErr := os.Stat(candidate); statErr == nil && info.IsDir() {
+			return candidate
+		}
+		dir = filepath.Dir(dir)
+	}
+
+	t.Fatalf("migrations directory not found from working directory")
+	return ""
+}
@@ -0,0 +1,315 @@
+package quarantine_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/quarantine"
+	"github.com/bionicotaku/lingo-utils/outbox/store"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassify(t *testing.T) {
+	cases := []struct {
+		name      string
+		err       error
+		class     string
+		permanent bool
+	}{
+		{name: "nil", err: nil},
+		{name: "plain", err: errors.New("connection reset")},
+		{name: "canceled", err: fmt.Errorf("apply: %w", context.Canceled)},
+		{name: "explicit", err: fmt.Errorf("wrap: %w", quarantine.Permanent("invalid-payload", errors.New("bad proto"))), class: "invalid-payload", permanent: true},
+		{name: "explicit without class", err: quarantine.Permanent("", errors.New("bad")), class: "permanent", permanent: true},
+		{name: "bad request", err: fmt.Errorf("event x: %w", kerrors.BadRequest("invalid-user-id", "invalid user_id")), class: "invalid-user-id", permanent: true},
+		{name: "unprocessable", err: kerrors.New(422, "invalid-rating", "out of range"), class: "invalid-rating", permanent: true},
+		{name: "not found", err: kerrors.NotFound("video-not-found", "missing")},
+		{name: "forbidden", err: kerrors.Forbidden("denied", "no access")},
+		{name: "conflict", err: kerrors.Conflict("version-conflict", "retry")},
+		{name: "too many requests", err: kerrors.New(429, "rate-limited", "slow down")},
+		{name: "internal", err: kerrors.InternalServer("db", "boom")},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			class, permanent := quarantine.Classify(tc.err)
+			require.Equal(t, tc.permanent, permanent)
+			require.Equal(t, tc.class, class)
+		})
+	}
+	require.Nil(t, quarantine.Permanent("x", nil))
+}
+
+func TestHandlerQuarantinesPermanentErrors(t *testing.T) {
+	quarantines := &fakeStore{}
+	next := &fakeHandler{err: kerrors.BadRequest("invalid-video-id", "invalid video_id")}
+	handler := quarantine.NewHandler[testEvent]("engagement", next, quarantines, log.NewStdLogger(io.Discard))
+	evt := newInboxEvent()
+
+	err := handler.Handle(context.Background(), fakeSession{}, &testEvent{}, evt)
+	require.NoError(t, err)
+	require.Equal(t, 1, next.calls)
+	require.Len(t, quarantines.entries, 1)
+	entry := quarantines.entries[0]
+	require.Equal(t, "engagement", entry.Consumer)
+	require.Equal(t, "invalid-video-id", entry.ErrorClass)
+	require.Equal(t, evt.EventID, entry.Event.EventID)
+	require.Equal(t, evt.Payload, entry.Event.Payload)
+	require.Contains(t, entry.LastError, "invalid video_id")
+}
+
+func TestHandlerReturnsTransientErrors(t *testing.T) {
+	quarantines := &fakeStore{}
+	next := &fakeHandler{err: errors.New("deadlock detected")}
+	handler := quarantine.NewHandler[testEvent]("uploads", next, quarantines, log.NewStdLogger(io.Discard))
+
+	err := handler.Handle(context.Background(), fakeSession{}, &testEvent{}, newInboxEvent())
+	require.EqualError(t, err, "deadlock detected")
+	require.Empty(t, quarantines.entries)
+
+	next.err = nil
+	require.NoError(t, handler.Handle(context.Background(), fakeSession{}, &testEvent{}, newInboxEvent()))
+	require.Empty(t, quarantines.entries)
+}
+
+func TestHandlerKeepsErrorWhenQuarantineFails(t *testing.T) {
+	quarantines := &fakeStore{err: errors.New("insert failed")}
+	next := &fakeHandler{err: quarantine.Permanent("invalid-md5", errors.New("bad md5"))}
+	handler := quarantine.NewHandler[testEvent]("uploads", next, quarantines, log.NewStdLogger(io.Discard))
+
+	err := handler.Handle(context.Background(), fakeSession{}, &testEvent{}, newInboxEvent())
+	require.Error(t, err)
+	require.ErrorContains(t, err, "insert failed")
+}
+
+func TestAdminReplayResolvesEntry(t *testing.T) {
+	admin, store, replays := newTestAdmin(t, nil)
+	entry := store.add("invalid-user-id")
+
+	require.NoError(t, admin.Replay(context.Background(), entry.EventID))
+	require.Len(t, *replays, 1)
+	require.Equal(t, entry.EventID, (*replays)[0].EventID)
+	require.Equal(t, entry.Payload, (*replays)[0].Payload)
+	require.NotNil(t, entry.ResolvedAt)
+	require.Equal(t, po.InboxQuarantineReplayed, *entry.Resolution)
+
+	err := admin.Replay(context.Background(), entry.EventID)
+	require.ErrorIs(t, err, repositories.ErrInboxQuarantineResolved)
+	require.Len(t, *replays, 1)
+}
+
+func TestAdminReplayFailureRecordsAttempt(t *testing.T) {
+	admin, store, _ := newTestAdmin(t, errors.New("still broken"))
+	entry := store.add("invalid-user-id")
+
+	err := admin.Replay(context.Background(), entry.EventID)
+	require.ErrorContains(t, err, "still broken")
+	require.Nil(t, entry.ResolvedAt)
+	require.Equal(t, int32(1), entry.ReplayAttempts)
+	require.Equal(t, "still broken", entry.LastError)
+}
+
+func TestAdminDiscardAndList(t *testing.T) {
+	admin, store, _ := newTestAdmin(t, nil)
+	first := store.add("invalid-user-id")
+	second := store.add("invalid-payload")
+
+	require.NoError(t, admin.Discard(context.Background(), first.EventID, "user already deleted"))
+	require.Equal(t, po.InboxQuarantineDiscarded, *first.Resolution)
+	require.Equal(t, "user already deleted", *first.ResolvedNote)
+	require.ErrorIs(t, admin.Discard(context.Background(), uuid.New(), ""), repositories.ErrInboxQuarantineNotFound)
+
+	pending, err := admin.List(context.Background(), quarantine.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, second.EventID, pending[0].EventID)
+	require.Equal(t, 50, store.filters[0].Limit)
+
+	all, err := admin.List(context.Background(), quarantine.ListOptions{IncludeResolved: true, ErrorClass: "invalid-user-id"})
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	require.Equal(t, first.EventID, all[0].EventID)
+}
+
+func TestRunCommandShowDecodesPayload(t *testing.T) {
+	admin, store, _ := newTestAdmin(t, nil)
+	entry := store.add("invalid-payload")
+
+	var out bytes.Buffer
+	require.NoError(t, quarantine.RunCommand(context.Background(), admin, []string{"show", "-event-id", entry.EventID.String()}, &out))
+	var view struct {
+		EventID    string            `json:"event_id"`
+		ErrorClass string            `json:"error_class"`
+		Decoded    map[string]string `json:"decoded"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &view))
+	require.Equal(t, entry.EventID.String(), view.EventID)
+	require.Equal(t, "invalid-payload", view.ErrorClass)
+	require.Equal(t, map[string]string{"event_type": "test.event", "raw": "payload"}, view.Decoded)
+
+	out.Reset()
+	require.NoError(t, quarantine.RunCommand(context.Background(), admin, []string{"replay", "-event-id", entry.EventID.String()}, &out))
+	require.Contains(t, out.String(), `"resolution": "replayed"`)
+
+	require.Error(t, quarantine.RunCommand(context.Background(), admin, []string{"replay"}, &out))
+	require.Error(t, quarantine.RunCommand(context.Background(), admin, []string{"purge"}, &out))
+	require.Error(t, quarantine.RunCommand(context.Background(), admin, nil, &out))
+}
+
+func newTestAdmin(t *testing.T, replayErr error) (*quarantine.Admin, *fakeAdminStore, *[]*store.InboxEvent) {
+	t.Helper()
+	adminStore := &fakeAdminStore{}
+	var replays []*store.InboxEvent
+	admin, err := quarantine.NewAdmin(quarantine.AdminParams{
+		Consumer:  "engagement",
+		Store:     adminStore,
+		TxManager: fakeTxManager{},
+		Replay: func(_ context.Context, _ txmanager.Session, evt *store.InboxEvent) error {
+			if replayErr != nil {
+				return replayErr
+			}
+			replays = append(replays, evt)
+			return nil
+		},
+		Decode: func(eventType string, payload []byte) (any, error) {
+			return map[string]string{"event_type": eventType, "raw": string(payload)}, nil
+		},
+		Logger: log.NewStdLogger(io.Discard),
+	})
+	require.NoError(t, err)
+	return admin, adminStore, &replays
+}
+
+type testEvent struct{}
+
+func newInboxEvent() *store.InboxEvent {
+	return &store.InboxEvent{
+		EventID:       uuid.New(),
+		SourceService: "profile",
+		EventType:     "profile.engagement.added",
+		Payload:       []byte("payload"),
+		ReceivedAt:    time.Now().UTC(),
+	}
+}
+
+type fakeHandler struct {
+	err   error
+	calls int
+}
+
+func (h *fakeHandler) Handle(context.Context, txmanager.Session, *testEvent, *store.InboxEvent) error {
+	h.calls++
+	return h.err
+}
+
+type fakeStore struct {
+	entries []repositories.QuarantineInboxEventInput
+	err     error
+}
+
+func (s *fakeStore) Quarantine(_ context.Context, _ txmanager.Session, input repositories.QuarantineInboxEventInput) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.entries = append(s.entries, input)
+	return nil
+}
+
+type fakeAdminStore struct {
+	entries []*po.InboxQuarantineEntry
+	filters []repositories.InboxQuarantineFilter
+}
+
+func (s *fakeAdminStore) add(class string) *po.InboxQuarantineEntry {
+	entry := &po.InboxQuarantineEntry{
+		EventID:       uuid.New(),
+		Consumer:      "engagement",
+		SourceService: "profile",
+		EventType:     "test.event",
+		Payload:       []byte("payload"),
+		ReceivedAt:    time.Now().UTC(),
+		ErrorClass:    class,
+		LastError:     "boom",
+		QuarantinedAt: time.Now().UTC(),
+	}
+	s.entries = append(s.entries, entry)
+	return entry
+}
+
+func (s *fakeAdminStore) List(_ context.Context, _ txmanager.Session, filter repositories.InboxQuarantineFilter) ([]*po.InboxQuarantineEntry, error) {
+	s.filters = append(s.filters, filter)
+	var out []*po.InboxQuarantineEntry
+	for _, entry := range s.entries {
+		if entry.Consumer != filter.Consumer {
+			continue
+		}
+		if filter.ErrorClass != nil && entry.ErrorClass != *filter.ErrorClass {
+			continue
+		}
+		if !filter.IncludeResolved && entry.ResolvedAt != nil {
+			continue
+		}
+		out = append(out, entry)
+	}
+	return out, nil
+}
+
+func (s *fakeAdminStore) Get(_ context.Context, _ txmanager.Session, consumer string, eventID uuid.UUID) (*po.InboxQuarantineEntry, error) {
+	for _, entry := range s.entries {
+		if entry.EventID == eventID && entry.Consumer == consumer {
+			return entry, nil
+		}
+	}
+	return nil, repositories.ErrInboxQuarantineNotFound
+}
+
+func (s *fakeAdminStore) Resolve(ctx context.Context, sess txmanager.Session, consumer string, eventID uuid.UUID, resolution po.InboxQuarantineResolution, note string) error {
+	entry, err := s.Get(ctx, sess, consumer, eventID)
+	if err != nil || entry.ResolvedAt != nil {
+		return repositories.ErrInboxQuarantineResolved
+	}
+	now := time.Now().UTC()
+	entry.ResolvedAt = &now
+	entry.Resolution = &resolution
+	if note != "" {
+		entry.ResolvedNote = &note
+	}
+	return nil
+}
+
+func (s *fakeAdminStore) RecordReplayFailure(ctx context.Context, sess txmanager.Session, consumer string, eventID uuid.UUID, lastErr string) error {
+	entry, err := s.Get(ctx, sess, consumer, eventID)
+	if err != nil {
+		return err
+	}
+	entry.ReplayAttempts++
+	entry.LastError = lastErr
+	return nil
+}
+
+type fakeTxManager struct{}
+
+func (fakeTxManager) WithinTx(ctx context.Context, _ txmanager.TxOptions, fn func(context.Context, txmanager.Session) error) error {
+	return fn(ctx, fakeSession{})
+}
+
+func (fakeTxManager) WithinReadOnlyTx(ctx context.Context, _ txmanager.TxOptions, fn func(context.Context, txmanager.Session) error) error {
+	return fn(ctx, fakeSession{})
+}
+
+type fakeSession struct{}
+
+func (fakeSession) Tx() pgx.Tx               { return nil }
+func (fakeSession) Context() context.Context { return context.Background() }
@@ -0,0 +1,145 @@
+package retention
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+)
+
+// NDJSONExporter 把每批被删除的行写成 Dir 下的一个 NDJSON 文件（每行一个 JSON 对象）。
+// 文件先写入临时名并 fsync 后再改名，目录中只会出现完整的文件；payload 以 base64 编码。
+// 若写出后删除事务提交失败，这批行会在下一轮被再次导出，下游应按 event_id 去重。
+type NDJSONExporter struct {
+	dir string
+	now func() time.Time
+}
+
+// NewNDJSONExporter 构造 NDJSONExporter，目录不存在时自动创建。
+func NewNDJSONExporter(dir string) (*NDJSONExporter, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("retention: export dir is required")
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("retention: create export dir: %w", err)
+	}
+	return &NDJSONExporter{dir: dir, now: time.Now}, nil
+}
+
+type outboxRecord struct {
+	EventID          string          `json:"event_id"`
+	AggregateType    string          `json:"aggregate_type"`
+	AggregateID      string          `json:"aggregate_id"`
+	EventType        string          `json:"event_type"`
+	Payload          []byte          `json:"payload"`
+	Headers          json.RawMessage `json:"headers,omitempty"`
+	OccurredAt       time.Time       `json:"occurred_at"`
+	AvailableAt      *time.Time      `json:"available_at,omitempty"`
+	PublishedAt      *time.Time      `json:"published_at,omitempty"`
+	DeliveryAttempts int32           `json:"delivery_attempts"`
+	LastError        *string         `json:"last_error,omitempty"`
+	AbandonedAt      *time.Time      `json:"abandoned_at,omitempty"`
+	AbandonedReason  *string         `json:"abandoned_reason,omitempty"`
+}
+
+type inboxRecord struct {
+	EventID       string     `json:"event_id"`
+	SourceService string     `json:"source_service"`
+	EventType     string     `json:"event_type"`
+	AggregateType *string    `json:"aggregate_type,omitempty"`
+	AggregateID   *string    `json:"aggregate_id,omitempty"`
+	Payload       []byte     `json:"payload"`
+	ReceivedAt    time.Time  `json:"received_at"`
+	ProcessedAt   *time.Time `json:"processed_at,omitempty"`
+	LastError     *string    `json:"last_error,omitempty"`
+}
+
+// ExportOutbox 导出一批 Outbox 行。
+func (e *NDJSONExporter) ExportOutbox(_ context.Context, entries []*po.OutboxEntry) error {
+	records := make([]any, 0, len(entries))
+	for _, entry := range entries {
+		record := outboxRecord{
+			EventID:          entry.EventID.String(),
+			AggregateType:    entry.AggregateType,
+			AggregateID:      entry.AggregateID.String(),
+			EventType:        entry.EventType,
+			Payload:          entry.Payload,
+			OccurredAt:       entry.OccurredAt,
+			AvailableAt:      entry.AvailableAt,
+			PublishedAt:      entry.PublishedAt,
+			DeliveryAttempts: entry.DeliveryAttempts,
+			LastError:        entry.LastError,
+			AbandonedAt:      entry.AbandonedAt,
+			AbandonedReason:  entry.AbandonedReason,
+		}
+		if len(entry.Headers) > 0 && json.Valid(entry.Headers) {
+			record.Headers = json.RawMessage(entry.Headers)
+		}
+		records = append(records, record)
+	}
+	return e.write(tableOutbox, records)
+}
+
+// ExportInbox 导出一批 Inbox 行。
+func (e *NDJSONExporter) ExportInbox(_ context.Context, entries []*po.InboxEntry) error {
+	records := make([]any, 0, len(entries))
+	for _, entry := range entries {
+		records = append(records, inboxRecord{
+			EventID:       entry.EventID.String(),
+			SourceService: entry.SourceService,
+			EventType:     entry.EventType,
+			AggregateType: entry.AggregateType,
+			AggregateID:   entry.AggregateID,
+			Payload:       entry.Payload,
+			ReceivedAt:    entry.ReceivedAt,
+			ProcessedAt:   entry.ProcessedAt,
+			LastError:     entry.LastError,
+		})
+	}
+	return e.write(tableInbox, records)
+}
+
+// write 写出 <table>-<UTC 纳秒时间戳>.ndjson；同名文件已存在时返回错误，不覆盖已导出的文件。
+func (e *NDJSONExporter) write(table string, records []any) error {
+	name := fmt.Sprintf("%s-%s.ndjson", table, e.now().UTC().Format("20060102T150405.000000000Z"))
+	path := filepath.Join(e.dir, name)
+	tmp, err := os.CreateTemp(e.dir, "."+name+".*")
+	if err != nil {
+		return fmt.Errorf("create %s: %w", name, err)
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	buf := bufio.NewWriter(tmp)
+	enc := json.NewEncoder(buf)
+	for _, record := range records {
+		if err := enc.Encode(record); err != nil {
+			_ = tmp.Close()
+			return fmt.Errorf("encode %s: %w", name, err)
+		}
+	}
+	if err := buf.Flush(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("sync %s: %w", name, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close %s: %w", name, err)
+	}
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("export file %s already exists", name)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("rename %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package retention
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const meterName = "lingo-services-catalog.retention"
+
+type metrics struct {
+	removed metric.Int64Counter
+}
+
+func newMetrics() *metrics {
+	m := otel.GetMeterProvider().Meter(meterName)
+	removed, _ := m.Int64Counter("catalog_retention_rows_removed_total")
+	return &metrics{removed: removed}
+}
+
+// recordRemoved 按表与是否归档统计保留期清理删除的行数。
+func (m *metrics) recordRemoved(ctx context.Context, table string, archived bool, rows int) {
+	if m == nil || m.removed == nil || rows <= 0 {
+		return
+	}
+	m.removed.Add(ctx, int64(rows), metric.WithAttributes(
+		attribute.String("table", table),
+		attribute.Bool("archived", archived),
+	))
+}
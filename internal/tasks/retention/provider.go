@@ -0,0 +1,22 @@
+package retention
+
+import (
+	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// ProvidePruner 按配置构造 Pruner；配置了 export_dir 时附带 NDJSON 导出。
+func ProvidePruner(repo *repositories.RetentionRepository, tx txmanager.Manager, cfg configloader.RetentionConfig, logger log.Logger) (*Pruner, error) {
+	policy := NewPolicy(cfg)
+	var exporter Exporter
+	if policy.ExportDir != "" {
+		ndjson, err := NewNDJSONExporter(policy.ExportDir)
+		if err != nil {
+			return nil, err
+		}
+		exporter = ndjson
+	}
+	return NewPruner(repo, tx, policy, exporter, logger)
+}
@@ -0,0 +1,236 @@
+// Package retention 按保留期分批清理 catalog.outbox_events 与 catalog.inbox_events，
+// 可选地把删除的行移入归档表并导出为 NDJSON 文件。
+package retention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/engagement"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+const (
+	tableOutbox = "outbox_events"
+	tableInbox  = "inbox_events"
+	// tableAbandoned 是已放弃 Outbox 行在指标与日志中的标签，与已发布行分开统计。
+	tableAbandoned = "outbox_events_abandoned"
+)
+
+// Policy 描述保留期与清理方式；保留期为 0 表示对应表不清理。
+type Policy struct {
+	OutboxRetention    time.Duration
+	AbandonedRetention time.Duration
+	InboxRetention     time.Duration
+	Interval           time.Duration
+	BatchSize          int
+	Archive            bool
+	ExportDir          string
+	// InboxKeepEventTypes 列出永不清理的 Inbox 事件类型。
+	InboxKeepEventTypes []string
+}
+
+// NewPolicy 将配置映射为 Policy；Engagement 重放依赖的事件类型始终保留，重放才能从完整历史重建投影。
+func NewPolicy(cfg configloader.RetentionConfig) Policy {
+	return Policy{
+		OutboxRetention:     cfg.OutboxPublishedRetention,
+		AbandonedRetention:  cfg.OutboxAbandonedRetention,
+		InboxRetention:      cfg.InboxProcessedRetention,
+		Interval:            cfg.Interval,
+		BatchSize:           int(cfg.BatchSize),
+		Archive:             cfg.Archive,
+		ExportDir:           cfg.ExportDir,
+		InboxKeepEventTypes: engagement.ReplayEventTypes(),
+	}
+}
+
+// Result 汇总一轮清理各表删除的行数。
+type Result struct {
+	Outbox    int64
+	Abandoned int64
+	Inbox     int64
+}
+
+// retentionStore 定义分批删除过期行所需的仓储接口。
+type retentionStore interface {
+	PruneOutbox(ctx context.Context, sess txmanager.Session, before time.Time, limit int, archive bool) ([]*po.OutboxEntry, error)
+	PruneAbandonedOutbox(ctx context.Context, sess txmanager.Session, before time.Time, limit int, archive bool) ([]*po.OutboxEntry, error)
+	PruneInbox(ctx context.Context, sess txmanager.Session, before time.Time, limit int, archive bool, keepEventTypes []string) ([]*po.InboxEntry, error)
+}
+
+var _ retentionStore = (*repositories.RetentionRepository)(nil)
+
+// Exporter 在删除提交前持久化每批被删除的行；返回错误时该批删除回滚。
+type Exporter interface {
+	ExportOutbox(ctx context.Context, entries []*po.OutboxEntry) error
+	ExportInbox(ctx context.Context, entries []*po.InboxEntry) error
+}
+
+// Pruner 定期删除发布或放弃超过保留期的 Outbox 行与处理完成超过保留期的 Inbox 行。
+// 每个事务每张表至多处理 BatchSize 行，选取时跳过被锁定的行，多实例并发运行互不阻塞。
+type Pruner struct {
+	store     retentionStore
+	txManager txmanager.Manager
+	policy    Policy
+	exporter  Exporter
+	log       *log.Helper
+	metrics   *metrics
+	now       func() time.Time
+}
+
+// NewPruner 构造 Pruner；exporter 为 nil 时不导出。
+func NewPruner(store retentionStore, tx txmanager.Manager, policy Policy, exporter Exporter, logger log.Logger) (*Pruner, error) {
+	if store == nil {
+		return nil, fmt.Errorf("retention: repository is required")
+	}
+	if tx == nil {
+		return nil, fmt.Errorf("retention: tx manager is required")
+	}
+	if policy.BatchSize <= 0 {
+		return nil, fmt.Errorf("retention: batch_size must be positive")
+	}
+	if logger == nil {
+		logger = log.DefaultLogger
+	}
+	return &Pruner{
+		store:     store,
+		txManager: tx,
+		policy:    policy,
+		exporter:  exporter,
+		log:       log.NewHelper(logger),
+		metrics:   newMetrics(),
+		now:       time.Now,
+	}, nil
+}
+
+// PruneOnce 分批清理各类过期行直到没有剩余，返回本轮各类删除的行数。
+func (p *Pruner) PruneOnce(ctx context.Context) (Result, error) {
+	var result Result
+	now := p.now()
+	if p.policy.OutboxRetention > 0 {
+		before := now.Add(-p.policy.OutboxRetention).UTC()
+		removed, err := p.drain(ctx, tableOutbox, func(txCtx context.Context, sess txmanager.Session) (int, error) {
+			return p.pruneOutboxBatch(txCtx, sess, before)
+		})
+		result.Outbox = removed
+		if err != nil {
+			return result, err
+		}
+	}
+	if p.policy.AbandonedRetention > 0 {
+		before := now.Add(-p.policy.AbandonedRetention).UTC()
+		removed, err := p.drain(ctx, tableAbandoned, func(txCtx context.Context, sess txmanager.Session) (int, error) {
+			return p.pruneAbandonedBatch(txCtx, sess, before)
+		})
+		result.Abandoned = removed
+		if err != nil {
+			return result, err
+		}
+	}
+	if p.policy.InboxRetention > 0 {
+		before := now.Add(-p.policy.InboxRetention).UTC()
+		removed, err := p.drain(ctx, tableInbox, func(txCtx context.Context, sess txmanager.Session) (int, error) {
+			return p.pruneInboxBatch(txCtx, sess, before)
+		})
+		result.Inbox = removed
+		if err != nil {
+			return result, err
+		}
+	}
+	if result.Outbox > 0 || result.Abandoned > 0 || result.Inbox > 0 {
+		p.log.WithContext(ctx).Infof("retention pruned: outbox=%d abandoned=%d inbox=%d archive=%t", result.Outbox, result.Abandoned, result.Inbox, p.policy.Archive)
+	}
+	return result, nil
+}
+
+// drain 循环执行单批清理，直到某一批不足 BatchSize 行。
+func (p *Pruner) drain(ctx context.Context, table string, batch func(context.Context, txmanager.Session) (int, error)) (int64, error) {
+	var total int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+		var removed int
+		err := p.txManager.WithinTx(ctx, txmanager.TxOptions{}, func(txCtx context.Context, sess txmanager.Session) error {
+			var err error
+			removed, err = batch(txCtx, sess)
+			return err
+		})
+		if err != nil {
+			return total, fmt.Errorf("retention: prune %s: %w", table, err)
+		}
+		total += int64(removed)
+		p.metrics.recordRemoved(ctx, table, p.policy.Archive, removed)
+		if removed < p.policy.BatchSize {
+			return total, nil
+		}
+	}
+}
+
+func (p *Pruner) pruneOutboxBatch(ctx context.Context, sess txmanager.Session, before time.Time) (int, error) {
+	entries, err := p.store.PruneOutbox(ctx, sess, before, p.policy.BatchSize, p.policy.Archive)
+	if err != nil {
+		return 0, err
+	}
+	if len(entries) > 0 && p.exporter != nil {
+		if err := p.exporter.ExportOutbox(ctx, entries); err != nil {
+			return 0, fmt.Errorf("export: %w", err)
+		}
+	}
+	return len(entries), nil
+}
+
+func (p *Pruner) pruneAbandonedBatch(ctx context.Context, sess txmanager.Session, before time.Time) (int, error) {
+	entries, err := p.store.PruneAbandonedOutbox(ctx, sess, before, p.policy.BatchSize, p.policy.Archive)
+	if err != nil {
+		return 0, err
+	}
+	if len(entries) > 0 && p.exporter != nil {
+		if err := p.exporter.ExportOutbox(ctx, entries); err != nil {
+			return 0, fmt.Errorf("export: %w", err)
+		}
+	}
+	return len(entries), nil
+}
+
+func (p *Pruner) pruneInboxBatch(ctx context.Context, sess txmanager.Session, before time.Time) (int, error) {
+	entries, err := p.store.PruneInbox(ctx, sess, before, p.policy.BatchSize, p.policy.Archive, p.policy.InboxKeepEventTypes)
+	if err != nil {
+		return 0, err
+	}
+	if len(entries) > 0 && p.exporter != nil {
+		if err := p.exporter.ExportInbox(ctx, entries); err != nil {
+			return 0, fmt.Errorf("export: %w", err)
+		}
+	}
+	return len(entries), nil
+}
+
+// Run 启动时清理一次，之后每个 Interval 清理一次，直到 ctx 结束；单次失败只记录日志。
+func (p *Pruner) Run(ctx context.Context) {
+	if p.policy.OutboxRetention <= 0 && p.policy.AbandonedRetention <= 0 && p.policy.InboxRetention <= 0 {
+		return
+	}
+	interval := p.policy.Interval
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := p.PruneOnce(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			p.log.WithContext(ctx).Warnf("retention prune failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,281 @@
+package retention_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/engagement"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/retention"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrunerDrainsInBoundedBatches(t *testing.T) {
+	store := &fakeRetentionStore{outbox: outboxEntries(5), inbox: inboxEntries(2)}
+	pruner, err := retention.NewPruner(store, fakeTxManager{}, retention.Policy{
+		OutboxRetention: 7 * 24 * time.Hour,
+		InboxRetention:  30 * 24 * time.Hour,
+		BatchSize:       2,
+		Archive:         true,
+	}, nil, log.NewStdLogger(io.Discard))
+	require.NoError(t, err)
+
+	before := time.Now()
+	result, err := pruner.PruneOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, retention.Result{Outbox: 5, Inbox: 2}, result)
+
+	// outbox：2 + 2 + 1，不足一批即停止；inbox：2 + 0。
+	require.Len(t, store.outboxCalls, 3)
+	require.Len(t, store.inboxCalls, 2)
+	for _, call := range store.outboxCalls {
+		require.Equal(t, 2, call.limit)
+		require.True(t, call.archive)
+		require.WithinDuration(t, before.Add(-7*24*time.Hour), call.before, time.Minute)
+	}
+	require.WithinDuration(t, before.Add(-30*24*time.Hour), store.inboxCalls[0].before, time.Minute)
+}
+
+func TestPrunerSkipsTablesWithoutRetention(t *testing.T) {
+	store := &fakeRetentionStore{outbox: outboxEntries(1), inbox: inboxEntries(1)}
+	pruner, err := retention.NewPruner(store, fakeTxManager{}, retention.Policy{
+		InboxRetention: time.Hour,
+		BatchSize:      10,
+	}, nil, log.NewStdLogger(io.Discard))
+	require.NoError(t, err)
+
+	result, err := pruner.PruneOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, retention.Result{Inbox: 1}, result)
+	require.Empty(t, store.outboxCalls, "zero retention keeps outbox rows forever")
+}
+
+func TestPolicyKeepsEngagementReplayHistory(t *testing.T) {
+	policy := retention.NewPolicy(configloader.RetentionConfig{InboxProcessedRetention: 720 * time.Hour, BatchSize: 10})
+	require.ElementsMatch(t, engagement.ReplayEventTypes(), policy.InboxKeepEventTypes)
+
+	store := &fakeRetentionStore{inbox: inboxEntries(1)}
+	pruner, err := retention.NewPruner(store, fakeTxManager{}, policy, nil, log.NewStdLogger(io.Discard))
+	require.NoError(t, err)
+	_, err = pruner.PruneOnce(context.Background())
+	require.NoError(t, err)
+	require.NotEmpty(t, store.inboxCalls)
+	require.ElementsMatch(t, engagement.ReplayEventTypes(), store.inboxCalls[0].keep, "replayable event types are never pruned")
+}
+
+func TestPrunerDrainsAbandonedOutboxSeparately(t *testing.T) {
+	store := &fakeRetentionStore{outbox: outboxEntries(1), abandoned: outboxEntries(3)}
+	pruner, err := retention.NewPruner(store, fakeTxManager{}, retention.Policy{
+		OutboxRetention:    7 * 24 * time.Hour,
+		AbandonedRetention: 30 * 24 * time.Hour,
+		BatchSize:          2,
+	}, nil, log.NewStdLogger(io.Discard))
+	require.NoError(t, err)
+
+	before := time.Now()
+	result, err := pruner.PruneOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, retention.Result{Outbox: 1, Abandoned: 3}, result)
+
+	require.Len(t, store.abandonedCalls, 2)
+	for _, call := range store.abandonedCalls {
+		require.WithinDuration(t, before.Add(-30*24*time.Hour), call.before, time.Minute)
+	}
+	require.Empty(t, store.inboxCalls)
+}
+
+func TestPrunerExportsEachBatchAsNDJSON(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "export")
+	exporter, err := retention.NewNDJSONExporter(dir)
+	require.NoError(t, err)
+
+	outbox := outboxEntries(3)
+	store := &fakeRetentionStore{outbox: append([]*po.OutboxEntry(nil), outbox...), inbox: inboxEntries(1)}
+	pruner, err := retention.NewPruner(store, fakeTxManager{}, retention.Policy{
+		OutboxRetention: time.Hour,
+		InboxRetention:  time.Hour,
+		BatchSize:       2,
+	}, exporter, log.NewStdLogger(io.Discard))
+	require.NoError(t, err)
+
+	_, err = pruner.PruneOnce(context.Background())
+	require.NoError(t, err)
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var outboxLines []map[string]any
+	var inboxFiles int
+	for _, file := range files {
+		require.True(t, strings.HasSuffix(file.Name(), ".ndjson"), "temporary files must not remain: %s", file.Name())
+		if strings.HasPrefix(file.Name(), "inbox_events-") {
+			inboxFiles++
+			continue
+		}
+		require.True(t, strings.HasPrefix(file.Name(), "outbox_events-"))
+		outboxLines = append(outboxLines, readNDJSON(t, filepath.Join(dir, file.Name()))...)
+	}
+	require.Equal(t, 1, inboxFiles)
+	require.Len(t, outboxLines, 3, "two batches exported into two files")
+	require.Equal(t, outbox[0].EventID.String(), outboxLines[0]["event_id"])
+	require.Equal(t, map[string]any{"schema_version": "v1"}, outboxLines[0]["headers"])
+	require.NotEmpty(t, outboxLines[0]["payload"])
+}
+
+func TestPrunerStopsWhenExportFails(t *testing.T) {
+	store := &fakeRetentionStore{outbox: outboxEntries(4)}
+	exportErr := errors.New("disk full")
+	pruner, err := retention.NewPruner(store, fakeTxManager{}, retention.Policy{
+		OutboxRetention: time.Hour,
+		BatchSize:       2,
+	}, failingExporter{err: exportErr}, log.NewStdLogger(io.Discard))
+	require.NoError(t, err)
+
+	result, err := pruner.PruneOnce(context.Background())
+	require.ErrorIs(t, err, exportErr)
+	require.Zero(t, result.Outbox, "failed export rolls back the batch")
+	require.Len(t, store.outboxCalls, 1)
+}
+
+func TestPrunerRunStopsWithContext(t *testing.T) {
+	store := &fakeRetentionStore{}
+	pruner, err := retention.NewPruner(store, fakeTxManager{}, retention.Policy{
+		OutboxRetention: time.Hour,
+		Interval:        time.Hour,
+		BatchSize:       10,
+	}, nil, log.NewStdLogger(io.Discard))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	pruner.Run(ctx)
+	require.Empty(t, store.outboxCalls, "cancelled context stops before the first batch")
+
+	_, err = retention.NewPruner(store, fakeTxManager{}, retention.Policy{OutboxRetention: time.Hour}, nil, nil)
+	require.Error(t, err, "batch size is required")
+}
+
+func readNDJSON(t *testing.T, path string) []map[string]any {
+	t.Helper()
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	var lines []map[string]any
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var line map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		lines = append(lines, line)
+	}
+	require.NoError(t, scanner.Err())
+	return lines
+}
+
+func outboxEntries(n int) []*po.OutboxEntry {
+	entries := make([]*po.OutboxEntry, 0, n)
+	publishedAt := time.Now().Add(-30 * 24 * time.Hour)
+	for i := 0; i < n; i++ {
+		entries = append(entries, &po.OutboxEntry{
+			EventID:     uuid.New(),
+			AggregateID: uuid.New(),
+			EventType:   "catalog.video.updated",
+			Payload:     []byte{0x0a, 0x01},
+			Headers:     []byte(`{"schema_version":"v1"}`),
+			OccurredAt:  publishedAt,
+			PublishedAt: &publishedAt,
+		})
+	}
+	return entries
+}
+
+func inboxEntries(n int) []*po.InboxEntry {
+	entries := make([]*po.InboxEntry, 0, n)
+	processedAt := time.Now().Add(-60 * 24 * time.Hour)
+	for i := 0; i < n; i++ {
+		entries = append(entries, &po.InboxEntry{
+			EventID:       uuid.New(),
+			SourceService: "profile",
+			EventType:     "media.video.ready",
+			Payload:       []byte{0x0a},
+			ReceivedAt:    processedAt,
+			ProcessedAt:   &processedAt,
+		})
+	}
+	return entries
+}
+
+type pruneCall struct {
+	before  time.Time
+	limit   int
+	archive bool
+	keep    []string
+}
+
+type fakeRetentionStore struct {
+	outbox         []*po.OutboxEntry
+	abandoned      []*po.OutboxEntry
+	inbox          []*po.InboxEntry
+	outboxCalls    []pruneCall
+	abandonedCalls []pruneCall
+	inboxCalls     []pruneCall
+}
+
+func (f *fakeRetentionStore) PruneOutbox(_ context.Context, _ txmanager.Session, before time.Time, limit int, archive bool) ([]*po.OutboxEntry, error) {
+	f.outboxCalls = append(f.outboxCalls, pruneCall{before: before, limit: limit, archive: archive})
+	n := min(limit, len(f.outbox))
+	batch := f.outbox[:n]
+	f.outbox = f.outbox[n:]
+	return batch, nil
+}
+
+func (f *fakeRetentionStore) PruneAbandonedOutbox(_ context.Context, _ txmanager.Session, before time.Time, limit int, archive bool) ([]*po.OutboxEntry, error) {
+	f.abandonedCalls = append(f.abandonedCalls, pruneCall{before: before, limit: limit, archive: archive})
+	n := min(limit, len(f.abandoned))
+	batch := f.abandoned[:n]
+	f.abandoned = f.abandoned[n:]
+	return batch, nil
+}
+
+func (f *fakeRetentionStore) PruneInbox(_ context.Context, _ txmanager.Session, before time.Time, limit int, archive bool, keepEventTypes []string) ([]*po.InboxEntry, error) {
+	f.inboxCalls = append(f.inboxCalls, pruneCall{before: before, limit: limit, archive: archive, keep: keepEventTypes})
+	n := min(limit, len(f.inbox))
+	batch := f.inbox[:n]
+	f.inbox = f.inbox[n:]
+	return batch, nil
+}
+
+type failingExporter struct {
+	err error
+}
+
+func (e failingExporter) ExportOutbox(context.Context, []*po.OutboxEntry) error { return e.err }
+
+func (e failingExporter) ExportInbox(context.Context, []*po.InboxEntry) error { return e.err }
+
+type fakeTxManager struct{}
+
+func (fakeTxManager) WithinTx(ctx context.Context, _ txmanager.TxOptions, fn func(context.Context, txmanager.Session) error) error {
+	return fn(ctx, fakeSession{})
+}
+
+func (fakeTxManager) WithinReadOnlyTx(ctx context.Context, _ txmanager.TxOptions, fn func(context.Context, txmanager.Session) error) error {
+	return fn(ctx, fakeSession{})
+}
+
+type fakeSession struct{}
+
+func (fakeSession) Tx() pgx.Tx { return nil }
+
+func (fakeSession) Context() context.Context { return context.Background() }
@@ -1,14 +1,17 @@
-// Package uploads implements the OBJECT_FINALIZE ingestion pipeline.
+// Package uploads implements the GCS object notification (finalize/delete/archive) ingestion pipeline.
 package uploads
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"strconv"
 )
 
-// Event 表示从 GCS OBJECT_FINALIZE 消息中解析出的关键信息。
+// Event 表示从 GCS 对象通知（OBJECT_FINALIZE/OBJECT_DELETE/OBJECT_ARCHIVE）消息中解析出的关键信息。
+// EventType 仅在 CloudEvents structured mode 下由信封解析得到，其余格式以消息属性为准。
 type Event struct {
+	EventType   string
 	Bucket      string
 	ObjectName  string
 	Generation  string
@@ -30,18 +33,59 @@ type gcsObjectMessage struct {
 	ETag        string `json:"etag"`
 }
 
-type eventDecoder struct{}
+type cloudEventEnvelope struct {
+	SpecVersion string          `json:"specversion"`
+	Type        string          `json:"type"`
+	Source      string          `json:"source"`
+	ID          string          `json:"id"`
+	Subject     string          `json:"subject"`
+	Data        json.RawMessage `json:"data"`
+	DataBase64  string          `json:"data_base64"`
+}
+
+// Decoder 解析经典 GCS 通知与 CloudEvents 存储事件。
+type Decoder struct{}
 
-func newDecoder() *eventDecoder {
-	return &eventDecoder{}
+// NewDecoder 构造上传通知解码器。
+func NewDecoder() *Decoder {
+	return &Decoder{}
 }
 
 // Decode 将 Pub/Sub 消息数据解析为 Event。
-func (d *eventDecoder) Decode(data []byte) (*Event, error) {
+// 经典 JSON_API_V1 与 CloudEvents binary mode 的 data 均为对象资源；structured mode 需先拆出信封中的 data。
+func (d *Decoder) Decode(data []byte) (*Event, error) {
 	if len(data) == 0 {
 		return nil, fmt.Errorf("uploads: empty payload")
 	}
 
+	var eventType string
+	if hasSpecVersion(data) {
+		var envelope cloudEventEnvelope
+		if err := json.Unmarshal(data, &envelope); err != nil {
+			return nil, fmt.Errorf("uploads: decode cloudevents envelope: %w", err)
+		}
+		if envelope.SpecVersion != cloudEventsSpecVersion {
+			return nil, fmt.Errorf("uploads: unsupported cloudevents specversion %q", envelope.SpecVersion)
+		}
+		mapped, err := CloudEventType(envelope.Type)
+		if err != nil {
+			return nil, err
+		}
+		eventType = mapped
+		switch {
+		case len(envelope.Data) > 0:
+			data = envelope.Data
+		case envelope.DataBase64 != "":
+			decoded, err := base64.StdEncoding.DecodeString(envelope.DataBase64)
+			if err != nil {
+				return nil, fmt.Errorf("uploads: decode cloudevents data_base64: %w", err)
+			}
+			data = decoded
+		default:
+			return nil, fmt.Errorf("uploads: cloudevents envelope without data")
+		}
+	}
+
 	var msg gcsObjectMessage
 	if err := json.Unmarshal(data, &msg); err != nil {
 		return nil, fmt.Errorf("uploads: decode gcs object payload: %w", err)
@@ -61,6 +105,7 @@ func (d *eventDecoder) Decode(data []byte) (*Event, error) {
 	}
 
 	return &Event{
+		EventType:   eventType,
 		Bucket:      msg.Bucket,
 		ObjectName:  msg.Name,
 		Generation:  msg.Generation,
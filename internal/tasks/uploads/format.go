@@ -0,0 +1,87 @@
+package uploads
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Format 标识 GCS 对象通知的消息格式。
+type Format string
+
+const (
+	// FormatJSONAPIV1 为经典 GCS Pub/Sub 通知：属性携带 eventType/bucketId/objectId，data 为 JSON API v1 对象资源。
+	FormatJSONAPIV1 Format = "JSON_API_V1"
+	// FormatCloudEventsBinary 为 CloudEvents binary mode：属性携带 ce-* 元数据，data 为对象资源。
+	FormatCloudEventsBinary Format = "CLOUDEVENTS_BINARY"
+	// FormatCloudEventsStructured 为 CloudEvents structured mode：data 为完整的 CloudEvent JSON 信封。
+	FormatCloudEventsStructured Format = "CLOUDEVENTS_STRUCTURED"
+)
+
+const (
+	cloudEventsSpecVersion = "1.0"
+	cloudEventsContentType = "application/cloudevents+json"
+	cloudEventsTypePrefix  = "google.cloud.storage.object.v1."
+
+	gcsPayloadFormatJSONAPIV1 = "JSON_API_V1"
+)
+
+// cloudEventTypes 将 Eventarc 存储事件类型映射为经典通知的 eventType，下游处理逻辑只认后者。
+var cloudEventTypes = map[string]string{
+	cloudEventsTypePrefix + "finalized":       gcsObjectFinalizeEvent,
+	cloudEventsTypePrefix + "deleted":         gcsObjectDeleteEvent,
+	cloudEventsTypePrefix + "archived":        gcsObjectArchiveEvent,
+	cloudEventsTypePrefix + "metadataUpdated": "OBJECT_METADATA_UPDATE",
+}
+
+// DetectFormat 根据消息属性与负载识别通知格式，并校验格式版本：
+// CloudEvents 仅接受 specversion 1.0，经典通知仅接受 payloadFormat=JSON_API_V1（NONE 不携带对象元数据）。
+// 既无经典属性也无 CloudEvents 标记的消息按 JSON_API_V1 处理，兼容内部直接投递的消息。
+func DetectFormat(attrs map[string]string, data []byte) (Format, error) {
+	if version, ok := attrs["ce-specversion"]; ok {
+		if version != cloudEventsSpecVersion {
+			return "", fmt.Errorf("uploads: unsupported cloudevents specversion %q", version)
+		}
+		if _, err := CloudEventType(attrs["ce-type"]); err != nil {
+			return "", err
+		}
+		return FormatCloudEventsBinary, nil
+	}
+
+	if strings.HasPrefix(strings.ToLower(attrs["content-type"]), cloudEventsContentType) || hasSpecVersion(data) {
+		var envelope cloudEventEnvelope
+		if err := json.Unmarshal(data, &envelope); err != nil {
+			return "", fmt.Errorf("uploads: decode cloudevents envelope: %w", err)
+		}
+		if envelope.SpecVersion != cloudEventsSpecVersion {
+			return "", fmt.Errorf("uploads: unsupported cloudevents specversion %q", envelope.SpecVersion)
+		}
+		if _, err := CloudEventType(envelope.Type); err != nil {
+			return "", err
+		}
+		return FormatCloudEventsStructured, nil
+	}
+
+	if payloadFormat, ok := attrs["payloadFormat"]; ok && payloadFormat != gcsPayloadFormatJSONAPIV1 {
+		return "", fmt.Errorf("uploads: unsupported gcs payload format %q", payloadFormat)
+	}
+	return FormatJSONAPIV1, nil
+}
+
+// CloudEventType 将 CloudEvents 存储事件类型转换为经典通知的 eventType。
+func CloudEventType(ceType string) (string, error) {
+	if eventType, ok := cloudEventTypes[ceType]; ok {
+		return eventType, nil
+	}
+	return "", fmt.Errorf("uploads: unsupported cloudevents type %q", ceType)
+}
+
+func hasSpecVersion(data []byte) bool {
+	var probe struct {
+		SpecVersion *string `json:"specversion"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return false
+	}
+	return probe.SpecVersion != nil
+}
@@ -7,10 +7,12 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
 	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
 	"github.com/bionicotaku/lingo-services-catalog/internal/services"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/quarantine"
 	"github.com/bionicotaku/lingo-utils/outbox/store"
 	"github.com/bionicotaku/lingo-utils/txmanager"
 	"github.com/go-kratos/kratos/v2/log"
@@ -19,36 +21,67 @@ import (
 
 const (
 	gcsObjectFinalizeEvent = "OBJECT_FINALIZE"
+	gcsObjectDeleteEvent   = "OBJECT_DELETE"
+	gcsObjectArchiveEvent  = "OBJECT_ARCHIVE"
 
-	errorCodeMD5Mismatch = "MD5_MISMATCH"
+	errorCodeMD5Mismatch        = "MD5_MISMATCH"
+	errorCodeContentTypeInvalid = "CONTENT_TYPE_INVALID"
+
+	// QuarantineConsumer 是上传消费者在 catalog.inbox_quarantine 中的标识。
+	QuarantineConsumer = "uploads"
+
+	classInvalidMD5 = "invalid-md5"
 )
 
 type uploadRepository interface {
 	GetByObject(ctx context.Context, sess txmanager.Session, bucket, objectName string) (*po.UploadSession, error)
 	MarkCompleted(ctx context.Context, sess txmanager.Session, input repositories.MarkUploadCompletedInput) (*po.UploadSession, error)
 	MarkFailed(ctx context.Context, sess txmanager.Session, input repositories.MarkUploadFailedInput) (*po.UploadSession, error)
+	MarkRawMissing(ctx context.Context, sess txmanager.Session, videoID uuid.UUID) (*po.UploadSession, error)
+	ReconcileUsage(ctx context.Context, sess txmanager.Session, userID uuid.UUID) (*po.UploadUsage, error)
 }
 
-// Handler 处理上传完成事件，将对象落地到视频主表并触发领域事件。
+type rawAssetRepository interface {
+	GetBySHA256(ctx context.Context, sess txmanager.Session, contentSHA256 string) (*po.RawAsset, error)
+	Acquire(ctx context.Context, sess txmanager.Session, input repositories.AcquireRawAssetInput) (*po.RawAsset, bool, error)
+	Release(ctx context.Context, sess txmanager.Session, assetID uuid.UUID) (*po.RawAsset, error)
+	GetByObject(ctx context.Context, sess txmanager.Session, bucket, objectName string) (*po.RawAsset, error)
+	ListVideoIDs(ctx context.Context, sess txmanager.Session, assetID uuid.UUID) ([]uuid.UUID, error)
+}
+
+// ObjectReader 抽象读取 GCS 对象头部字节、内容摘要与存活版本的能力，用于魔数嗅探、去重校验及删除通知校验；
+// DeleteObject 用于在去重命中并提交后删除多余的上传对象。
+type ObjectReader interface {
+	ReadObjectHead(ctx context.Context, bucket, objectName, generation string, length int64) ([]byte, error)
+	ContentSHA256(ctx context.Context, bucket, objectName, generation string) (string, error)
+	LiveGeneration(ctx context.Context, bucket, objectName string) (string, error)
+	DeleteObject(ctx context.Context, bucket, objectName, generation string) error
+}
+
+// Handler 处理上传完成及原始对象删除/归档事件，将对象状态落地到上传会话与视频主表并触发领域事件。
 type Handler struct {
 	uploads uploadRepository
+	assets  rawAssetRepository
 	writer  *services.LifecycleWriter
+	objects ObjectReader
 	log     *log.Helper
 }
 
-// NewHandler 构造上传事件处理器。
-func NewHandler(repo uploadRepository, writer *services.LifecycleWriter, logger log.Logger) *Handler {
+// NewHandler 构造上传事件处理器；assets 为空时不做原始资产去重。
+func NewHandler(repo uploadRepository, assets rawAssetRepository, writer *services.LifecycleWriter, objects ObjectReader, logger log.Logger) *Handler {
 	if logger == nil {
 		logger = log.NewStdLogger(nil)
 	}
 	return &Handler{
 		uploads: repo,
+		assets:  assets,
 		writer:  writer,
+		objects: objects,
 		log:     log.NewHelper(logger),
 	}
 }
 
-// Handle 执行 OBJECT_FINALIZE 事件的业务处理。
+// Handle 按事件类型分发 OBJECT_FINALIZE 与 OBJECT_DELETE/OBJECT_ARCHIVE，其余类型直接忽略。
 func (h *Handler) Handle(ctx context.Context, sess txmanager.Session, evt *Event, inboxEvt *store.InboxEvent) error {
 	if evt == nil {
 		return fmt.Errorf("uploads: nil event payload")
@@ -56,13 +89,21 @@ func (h *Handler) Handle(ctx context.Context, sess txmanager.Session, evt *Event
 	if inboxEvt == nil {
 		return fmt.Errorf("uploads: missing inbox event metadata")
 	}
-	if !strings.EqualFold(inboxEvt.EventType, gcsObjectFinalizeEvent) {
+	eventType := strings.ToUpper(strings.TrimSpace(inboxEvt.EventType))
+	if eventType != gcsObjectFinalizeEvent && eventType != gcsObjectDeleteEvent && eventType != gcsObjectArchiveEvent {
 		return nil
 	}
-	if h.uploads == nil || h.writer == nil {
+	if h.uploads == nil || h.writer == nil || h.objects == nil {
 		return fmt.Errorf("uploads: handler not initialized")
 	}
+	if eventType != gcsObjectFinalizeEvent {
+		return h.handleRawRemoved(ctx, sess, eventType, evt)
+	}
+	return h.handleFinalize(ctx, sess, evt)
+}
 
+// handleFinalize 执行 OBJECT_FINALIZE 事件的业务处理。
+func (h *Handler) handleFinalize(ctx context.Context, sess txmanager.Session, evt *Event) error {
 	session, err := h.uploads.GetByObject(ctx, sess, evt.Bucket, evt.ObjectName)
 	if err != nil {
 		if errors.Is(err, repositories.ErrUploadNotFound) {
@@ -72,16 +113,14 @@ func (h *Handler) Handle(ctx context.Context, sess txmanager.Session, evt *Event
 		return fmt.Errorf("uploads: load session: %w", err)
 	}
 
-	if session.Status == po.UploadStatusCompleted {
-		if session.GCSGeneration != nil && evt.Generation != "" && strings.EqualFold(*session.GCSGeneration, evt.Generation) {
-			h.log.WithContext(ctx).Debugf("uploads: skip duplicate finalize bucket=%s object=%s generation=%s", evt.Bucket, evt.ObjectName, evt.Generation)
-			return nil
-		}
+	if finalizeApplied(session, evt) {
+		h.log.WithContext(ctx).Debugf("uploads: skip duplicate finalize bucket=%s object=%s generation=%s", evt.Bucket, evt.ObjectName, evt.Generation)
+		return nil
 	}
 
 	md5Hex, err := base64MD5ToHex(evt.MD5Base64)
 	if err != nil {
-		return fmt.Errorf("uploads: decode md5: %w", err)
+		return quarantine.Permanent(classInvalidMD5, fmt.Errorf("uploads: decode md5: %w", err))
 	}
 	expectedMD5 := strings.ToLower(session.ContentMD5)
 	if expectedMD5 != "" && md5Hex != "" && md5Hex != expectedMD5 {
@@ -96,36 +135,101 @@ func (h *Handler) Handle(ctx context.Context, sess txmanager.Session, evt *Event
 		return nil
 	}
 
+	// 不信任客户端声明的 content_type，读取对象头部魔数确认真实格式。
+	head, err := h.objectHead(ctx, evt)
+	if err != nil {
+		return fmt.Errorf("uploads: read object head: %w", err)
+	}
+	sniffed := sniffContentType(head)
+	declared := evt.ContentType
+	if session.ContentType != nil && strings.TrimSpace(*session.ContentType) != "" {
+		declared = *session.ContentType
+	}
+	if reason := checkSniffedContentType(declared, sniffed); reason != "" {
+		h.log.WithContext(ctx).Warnf("uploads: content type rejected video_id=%s declared=%s sniffed=%s", session.VideoID, declared, sniffed)
+		if _, failErr := h.uploads.MarkFailed(ctx, sess, repositories.MarkUploadFailedInput{
+			VideoID:            session.VideoID,
+			ErrorCode:          strPtr(errorCodeContentTypeInvalid),
+			ErrorMessage:       strPtr(reason),
+			SniffedContentType: optionalString(sniffed),
+		}); failErr != nil {
+			return fmt.Errorf("uploads: mark failed: %w", failErr)
+		}
+		return nil
+	}
+
+	rawReference := fmt.Sprintf("gs://%s/%s", evt.Bucket, evt.ObjectName)
+	var (
+		assetID   *uuid.UUID
+		redundant bool
+	)
+	if session.Status != po.UploadStatusCompleted && session.AssetID == nil {
+		asset, inserted, err := h.acquireRawAsset(ctx, sess, session, evt, md5Hex, sniffed)
+		if err != nil {
+			return err
+		}
+		if asset != nil {
+			assetID = &asset.AssetID
+			rawReference = fmt.Sprintf("gs://%s/%s", asset.Bucket, asset.ObjectName)
+			redundant = !inserted && (asset.Bucket != evt.Bucket || asset.ObjectName != evt.ObjectName)
+		}
+	}
+
 	completed, err := h.uploads.MarkCompleted(ctx, sess, repositories.MarkUploadCompletedInput{
-		VideoID:       session.VideoID,
-		SizeBytes:     evt.SizeBytes,
-		MD5Hash:       optionalString(md5Hex),
-		CRC32C:        optionalString(evt.CRC32C),
-		GCSGeneration: optionalString(evt.Generation),
-		GCSEtag:       optionalString(evt.ETag),
-		ContentType:   optionalString(evt.ContentType),
+		VideoID:            session.VideoID,
+		SizeBytes:          evt.SizeBytes,
+		MD5Hash:            optionalString(md5Hex),
+		CRC32C:             optionalString(evt.CRC32C),
+		GCSGeneration:      optionalString(evt.Generation),
+		GCSEtag:            optionalString(evt.ETag),
+		ContentType:        optionalString(evt.ContentType),
+		SniffedContentType: optionalString(sniffed),
+		AssetID:            assetID,
 	})
 	if err != nil {
 		return fmt.Errorf("uploads: mark completed: %w", err)
 	}
 
+	// 以 catalog.uploads.size_bytes 为准重算存储用量，重复投递时同样幂等。
+	if _, err := h.uploads.ReconcileUsage(ctx, sess, session.UserID); err != nil {
+		return fmt.Errorf("uploads: reconcile usage: %w", err)
+	}
+
 	if session.Status == po.UploadStatusCompleted && completed.Status == po.UploadStatusCompleted {
-		// 已在前序处理完成，无需重复创建视频。
+		// 已在前序处理完成，无需重复创建视频；若此前原始对象被标记缺失，重新上传后清除引用该对象的视频侧标记。
+		if session.RawMissing {
+			videoIDs, _, err := h.rawReferrers(ctx, sess, session, evt)
+			if err != nil {
+				return err
+			}
+			if err := h.setVideosRawMissing(ctx, videoIDs, false); err != nil {
+				return fmt.Errorf("uploads: clear raw missing: %w", err)
+			}
+			h.log.WithContext(ctx).Infof("uploads: raw object restored video_id=%s generation=%s videos=%d", session.VideoID, evt.Generation, len(videoIDs))
+		}
 		return nil
 	}
 
-	rawReference := fmt.Sprintf("gs://%s/%s", evt.Bucket, evt.ObjectName)
 	createInput := services.CreateVideoInput{
 		VideoID:          session.VideoID,
 		UploadUserID:     session.UserID,
 		Title:            session.Title,
 		Description:      stringPtrNonEmpty(session.Description),
 		RawFileReference: rawReference,
+		AssetID:          assetID,
 	}
 	revision, err := h.writer.CreateVideo(ctx, createInput)
 	if err != nil {
 		return fmt.Errorf("uploads: create video: %w", err)
 	}
+	if assetID != nil && revision != nil && revision.EventID == uuid.Nil {
+		// 视频记录已存在且沿用其原有原始文件，本次登记的资产引用无人持有，随即释放。
+		if _, err := h.assets.Release(ctx, sess, *assetID); err != nil {
+			return fmt.Errorf("uploads: release raw asset: %w", err)
+		}
+		h.log.WithContext(ctx).Infof("uploads: video already exists, released raw asset video_id=%s asset_id=%s", session.VideoID, *assetID)
+		redundant = false
+	}
 
 	var (
 		statusProcessing = po.VideoStatusProcessing
@@ -147,10 +251,285 @@ func (h *Handler) Handle(ctx context.Context, sess txmanager.Session, evt *Event
 		}
 	}
 
+	if redundant {
+		// 视频引用规范对象，本次上传的对象不再被引用；最后一步才登记，处理器出错时不会删除仍需重试的对象。
+		h.markRedundant(ctx, session, evt)
+	}
+
 	h.log.WithContext(ctx).Infof("uploads: finalized video_id=%s object=%s generation=%s size=%d", session.VideoID, evt.ObjectName, evt.Generation, evt.SizeBytes)
 	return nil
 }
 
+// handleRawRemoved 处理 OBJECT_DELETE/OBJECT_ARCHIVE：确认原始对象确实不可用后，
+// 标记上传会话与引用该对象的全部视频的 raw_missing，并通过 VideoUpdated 事件通知下游。
+// 覆盖写入同样会为旧版本产生删除/归档通知，因此需同时校验 generation 与对象当前是否仍存在。
+func (h *Handler) handleRawRemoved(ctx context.Context, sess txmanager.Session, eventType string, evt *Event) error {
+	session, err := h.uploads.GetByObject(ctx, sess, evt.Bucket, evt.ObjectName)
+	if err != nil {
+		if errors.Is(err, repositories.ErrUploadNotFound) {
+			h.log.WithContext(ctx).Debugf("uploads: %s for unknown object bucket=%s object=%s", strings.ToLower(eventType), evt.Bucket, evt.ObjectName)
+			return nil
+		}
+		return fmt.Errorf("uploads: load session: %w", err)
+	}
+
+	if session.GCSGeneration != nil && evt.Generation != "" && !strings.EqualFold(*session.GCSGeneration, evt.Generation) {
+		h.log.WithContext(ctx).Debugf("uploads: skip %s for stale generation video_id=%s session_generation=%s event_generation=%s", strings.ToLower(eventType), session.VideoID, *session.GCSGeneration, evt.Generation)
+		return nil
+	}
+
+	videoIDs, owned, err := h.rawReferrers(ctx, sess, session, evt)
+	if err != nil {
+		return err
+	}
+	if !owned {
+		// 去重后视频引用的是规范对象，本次上传的重复对象被删除不影响任何视频。
+		h.log.WithContext(ctx).Infof("uploads: skip %s for deduplicated object video_id=%s asset_id=%s", strings.ToLower(eventType), session.VideoID, *session.AssetID)
+		return nil
+	}
+
+	live, err := h.liveGeneration(ctx, evt)
+	if err != nil {
+		return fmt.Errorf("uploads: check live object: %w", err)
+	}
+	if live != "" {
+		h.log.WithContext(ctx).Infof("uploads: skip %s, object overwritten video_id=%s event_generation=%s live_generation=%s", strings.ToLower(eventType), session.VideoID, evt.Generation, live)
+		return nil
+	}
+	if session.RawMissing {
+		return nil
+	}
+
+	if _, err := h.uploads.MarkRawMissing(ctx, sess, session.VideoID); err != nil {
+		return fmt.Errorf("uploads: mark raw missing: %w", err)
+	}
+	if err := h.setVideosRawMissing(ctx, videoIDs, true); err != nil {
+		return fmt.Errorf("uploads: update video raw missing: %w", err)
+	}
+
+	h.log.WithContext(ctx).Warnf("uploads: raw object %s video_id=%s object=%s generation=%s videos=%d", strings.ToLower(strings.TrimPrefix(eventType, "OBJECT_")), session.VideoID, evt.ObjectName, evt.Generation, len(videoIDs))
+	return nil
+}
+
+type prefetchedHeadKey struct{}
+
+type prefetchedLiveKey struct{}
+
+type prefetchedDigestKey struct{}
+
+type redundantObjectsKey struct{}
+
+// prefetchedHead 是 Inbox 事务开启前读取的对象头部，读取失败时记录错误。
+type prefetchedHead struct {
+	key  string
+	head []byte
+	err  error
+}
+
+// prefetchedDigest 是 Inbox 事务开启前计算的对象 SHA-256，计算失败时记录错误。
+type prefetchedDigest struct {
+	key    string
+	sha256 string
+	err    error
+}
+
+// ObjectRef 定位 GCS 对象的一个版本。
+type ObjectRef struct {
+	Bucket     string
+	ObjectName string
+	Generation string
+}
+
+// redundantObjects 收集处理器在事务内登记的冗余对象。
+type redundantObjects struct {
+	mu      sync.Mutex
+	objects []ObjectRef
+}
+
+func (r *redundantObjects) add(ref ObjectRef) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.objects = append(r.objects, ref)
+}
+
+func (r *redundantObjects) list() []ObjectRef {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]ObjectRef(nil), r.objects...)
+}
+
+// TrackRedundantObjects 在 context 上挂载冗余对象登记器，返回的函数列出处理器登记的对象。
+// 调用方应仅在处理成功（事务已提交）后删除这些对象；处理失败时对象保持原样，重投后重新判定。
+func TrackRedundantObjects(ctx context.Context) (context.Context, func() []ObjectRef) {
+	tracker := &redundantObjects{}
+	return context.WithValue(ctx, redundantObjectsKey{}, tracker), tracker.list
+}
+
+// prefetchedLive 是 Inbox 事务开启前查询的对象存活版本，查询失败时记录错误。
+type prefetchedLive struct {
+	key        string
+	generation string
+	err        error
+}
+
+// PrefetchObjectHead 在进入 Inbox 事务前读取对象头部并挂到 context 上，避免在持有行锁的事务内访问 GCS。
+// 读取错误同样随 context 传递，由处理器在确认上传会话存在后再返回，未知对象的通知不会因此反复重试。
+func PrefetchObjectHead(ctx context.Context, objects ObjectReader, evt *Event) context.Context {
+	if objects == nil || evt == nil {
+		return ctx
+	}
+	head, err := objects.ReadObjectHead(ctx, evt.Bucket, evt.ObjectName, evt.Generation, sniffLength)
+	return context.WithValue(ctx, prefetchedHeadKey{}, prefetchedHead{key: objectKey(evt), head: head, err: err})
+}
+
+// PrefetchContentSHA256 在进入 Inbox 事务前计算对象的 SHA-256 并挂到 context 上，供去重时校验客户端声明的摘要。
+func PrefetchContentSHA256(ctx context.Context, objects ObjectReader, evt *Event) context.Context {
+	if objects == nil || evt == nil {
+		return ctx
+	}
+	digest, err := objects.ContentSHA256(ctx, evt.Bucket, evt.ObjectName, evt.Generation)
+	return context.WithValue(ctx, prefetchedDigestKey{}, prefetchedDigest{key: objectKey(evt), sha256: strings.ToLower(digest), err: err})
+}
+
+// PrefetchLiveGeneration 在进入 Inbox 事务前查询对象当前存活版本，供删除/归档通知判断对象是否已被覆盖写入。
+func PrefetchLiveGeneration(ctx context.Context, objects ObjectReader, evt *Event) context.Context {
+	if objects == nil || evt == nil {
+		return ctx
+	}
+	live, err := objects.LiveGeneration(ctx, evt.Bucket, evt.ObjectName)
+	return context.WithValue(ctx, prefetchedLiveKey{}, prefetchedLive{key: objectKey(evt), generation: live, err: err})
+}
+
+// objectHead 优先使用事务外预读的头部；隔离重放等不经过订阅器的路径退回直接读取。
+func (h *Handler) objectHead(ctx context.Context, evt *Event) ([]byte, error) {
+	if pre, ok := ctx.Value(prefetchedHeadKey{}).(prefetchedHead); ok && pre.key == objectKey(evt) {
+		return pre.head, pre.err
+	}
+	return h.objects.ReadObjectHead(ctx, evt.Bucket, evt.ObjectName, evt.Generation, sniffLength)
+}
+
+// contentSHA256 优先使用事务外预先计算的摘要，未预先计算时退回直接读取对象。
+func (h *Handler) contentSHA256(ctx context.Context, evt *Event) (string, error) {
+	if pre, ok := ctx.Value(prefetchedDigestKey{}).(prefetchedDigest); ok && pre.key == objectKey(evt) {
+		return pre.sha256, pre.err
+	}
+	digest, err := h.objects.ContentSHA256(ctx, evt.Bucket, evt.ObjectName, evt.Generation)
+	return strings.ToLower(digest), err
+}
+
+// liveGeneration 优先使用事务外预读的存活版本，未预读时退回直接查询。
+func (h *Handler) liveGeneration(ctx context.Context, evt *Event) (string, error) {
+	if pre, ok := ctx.Value(prefetchedLiveKey{}).(prefetchedLive); ok && pre.key == objectKey(evt) {
+		return pre.generation, pre.err
+	}
+	return h.objects.LiveGeneration(ctx, evt.Bucket, evt.ObjectName)
+}
+
+// finalizeApplied 判断上传会话是否已按该通知的 generation 完成，即重复投递的 OBJECT_FINALIZE。
+func finalizeApplied(session *po.UploadSession, evt *Event) bool {
+	return session.Status == po.UploadStatusCompleted && session.GCSGeneration != nil &&
+		evt.Generation != "" && strings.EqualFold(*session.GCSGeneration, evt.Generation)
+}
+
+func objectKey(evt *Event) string {
+	return fmt.Sprintf("%s/%s#%s", evt.Bucket, evt.ObjectName, evt.Generation)
+}
+
+// rawReferrers 返回原始文件即该对象的视频，以及该对象是否仍是会话视频的原始文件。
+// 对象是某个原始资产的规范对象时，所有引用该资产的视频都受影响；
+// 会话已去重到其他规范对象时，本次上传的对象不被任何视频引用。
+func (h *Handler) rawReferrers(ctx context.Context, sess txmanager.Session, session *po.UploadSession, evt *Event) ([]uuid.UUID, bool, error) {
+	if h.assets != nil {
+		asset, err := h.assets.GetByObject(ctx, sess, evt.Bucket, evt.ObjectName)
+		if err != nil && !errors.Is(err, repositories.ErrRawAssetNotFound) {
+			return nil, false, fmt.Errorf("uploads: load raw asset by object: %w", err)
+		}
+		if asset != nil {
+			videoIDs, err := h.assets.ListVideoIDs(ctx, sess, asset.AssetID)
+			if err != nil {
+				return nil, false, fmt.Errorf("uploads: list raw asset videos: %w", err)
+			}
+			return videoIDs, true, nil
+		}
+		if session.AssetID != nil {
+			return nil, false, nil
+		}
+	}
+	if session.Status != po.UploadStatusCompleted {
+		return nil, true, nil
+	}
+	return []uuid.UUID{session.VideoID}, true, nil
+}
+
+// setVideosRawMissing 逐个更新视频的 raw_missing 并写入 VideoUpdated 事件，已不存在的视频跳过。
+func (h *Handler) setVideosRawMissing(ctx context.Context, videoIDs []uuid.UUID, missing bool) error {
+	for _, videoID := range videoIDs {
+		flag := missing
+		if _, err := h.writer.UpdateVideo(ctx, services.UpdateVideoInput{VideoID: videoID, RawMissing: &flag}); err != nil {
+			if !errors.Is(err, services.ErrVideoNotFound) {
+				return err
+			}
+			h.log.WithContext(ctx).Warnf("uploads: video not found when setting raw missing video_id=%s", videoID)
+		}
+	}
+	return nil
+}
+
+// acquireRawAsset 按服务端校验过的 SHA-256 登记或复用原始资产，inserted 表示以本次对象登记了新资产。
+// 客户端声明的 SHA-256 只决定是否尝试去重：与对象实际摘要不一致时视为声明不可信，跳过去重并继续使用本次上传的对象；
+// 复用前仍以 GCS 校验过的 MD5 与对象大小核对既有资产。
+func (h *Handler) acquireRawAsset(ctx context.Context, sess txmanager.Session, session *po.UploadSession, evt *Event, md5Hex, sniffed string) (*po.RawAsset, bool, error) {
+	if h.assets == nil || session.ContentSHA256 == nil || md5Hex == "" {
+		return nil, false, nil
+	}
+	declared := strings.ToLower(*session.ContentSHA256)
+	sha256Hex, err := h.contentSHA256(ctx, evt)
+	if err != nil {
+		return nil, false, fmt.Errorf("uploads: hash object: %w", err)
+	}
+	if sha256Hex != declared {
+		h.log.WithContext(ctx).Warnf("uploads: declared sha256 does not match object, skip dedupe video_id=%s declared=%s actual=%s", session.VideoID, declared, sha256Hex)
+		return nil, false, nil
+	}
+
+	existing, err := h.assets.GetBySHA256(ctx, sess, sha256Hex)
+	if err != nil && !errors.Is(err, repositories.ErrRawAssetNotFound) {
+		return nil, false, fmt.Errorf("uploads: load raw asset: %w", err)
+	}
+	if existing != nil && (existing.ContentMD5 != md5Hex || existing.SizeBytes != evt.SizeBytes) {
+		h.log.WithContext(ctx).Warnf("uploads: raw asset hash mismatch, skip dedupe video_id=%s asset_id=%s sha256=%s", session.VideoID, existing.AssetID, sha256Hex)
+		return nil, false, nil
+	}
+
+	asset, inserted, err := h.assets.Acquire(ctx, sess, repositories.AcquireRawAssetInput{
+		ContentSHA256: sha256Hex,
+		ContentMD5:    md5Hex,
+		SizeBytes:     evt.SizeBytes,
+		Bucket:        evt.Bucket,
+		ObjectName:    evt.ObjectName,
+		ContentType:   optionalString(sniffed),
+		CreatedBy:     session.UserID,
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("uploads: acquire raw asset: %w", err)
+	}
+	if !inserted {
+		h.log.WithContext(ctx).Infof("uploads: deduplicated video_id=%s asset_id=%s object=%s/%s refs=%d", session.VideoID, asset.AssetID, asset.Bucket, asset.ObjectName, asset.RefCount)
+	}
+	return asset, inserted, nil
+}
+
+// markRedundant 登记去重后不再被引用的上传对象，由订阅器在 Inbox 事务提交后删除。
+// 不经过订阅器的路径（如隔离重放）没有登记器，对象保留，交由桶生命周期规则回收。
+func (h *Handler) markRedundant(ctx context.Context, session *po.UploadSession, evt *Event) {
+	tracker, ok := ctx.Value(redundantObjectsKey{}).(*redundantObjects)
+	if !ok || evt.Generation == "" {
+		h.log.WithContext(ctx).Infof("uploads: redundant object kept video_id=%s object=%s/%s", session.VideoID, evt.Bucket, evt.ObjectName)
+		return
+	}
+	tracker.add(ObjectRef{Bucket: evt.Bucket, ObjectName: evt.ObjectName, Generation: evt.Generation})
+}
+
 func base64MD5ToHex(value string) (string, error) {
 	if strings.TrimSpace(value) == "" {
 		return "", nil
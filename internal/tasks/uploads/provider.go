@@ -4,6 +4,7 @@ import (
 	"github.com/bionicotaku/lingo-services-catalog/internal/infrastructure/configloader"
 	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
 	"github.com/bionicotaku/lingo-services-catalog/internal/services"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/quarantine"
 	"github.com/bionicotaku/lingo-utils/gcpubsub"
 	outboxcfg "github.com/bionicotaku/lingo-utils/outbox/config"
 	"github.com/bionicotaku/lingo-utils/txmanager"
@@ -13,26 +14,32 @@ import (
 // ProvideRunner 装配 Uploads Runner。
 func ProvideRunner(
 	uploadRepo *repositories.UploadRepository,
+	assetRepo *repositories.RawAssetRepository,
 	inboxRepo *repositories.InboxRepository,
+	quarantineRepo *repositories.InboxQuarantineRepository,
 	lifecycle *services.LifecycleWriter,
+	objects ObjectReader,
 	tx txmanager.Manager,
 	sub configloader.UploadSubscriber,
 	outboxCfg outboxcfg.Config,
 	logger log.Logger,
 ) *Runner {
 	realSub := gcpubsub.Subscriber(sub)
-	if uploadRepo == nil || inboxRepo == nil || lifecycle == nil || realSub == nil || logger == nil {
+	if uploadRepo == nil || assetRepo == nil || inboxRepo == nil || lifecycle == nil || objects == nil || realSub == nil || logger == nil {
 		return nil
 	}
 
 	runner, err := NewRunner(RunnerParams{
-		Subscriber: realSub,
-		InboxRepo:  inboxRepo,
-		UploadRepo: uploadRepo,
-		Lifecycle:  lifecycle,
-		TxManager:  tx,
-		Logger:     logger,
-		Config:     outboxCfg.Inbox,
+		Subscriber:     realSub,
+		InboxRepo:      inboxRepo,
+		UploadRepo:     uploadRepo,
+		AssetRepo:      assetRepo,
+		Lifecycle:      lifecycle,
+		Objects:        objects,
+		TxManager:      tx,
+		Logger:         logger,
+		Config:         outboxCfg.Inbox,
+		QuarantineRepo: quarantineRepo,
 	})
 	if err != nil {
 		log.NewHelper(logger).Errorw("msg", "init uploads runner failed", "error", err)
@@ -40,3 +47,27 @@ func ProvideRunner(
 	}
 	return runner
 }
+
+// ProvideQuarantineAdmin 装配上传隔离事件运维入口，重放时直接使用未装饰的 Handler。
+func ProvideQuarantineAdmin(
+	uploadRepo *repositories.UploadRepository,
+	assetRepo *repositories.RawAssetRepository,
+	quarantineRepo *repositories.InboxQuarantineRepository,
+	lifecycle *services.LifecycleWriter,
+	objects ObjectReader,
+	tx txmanager.Manager,
+	logger log.Logger,
+) (*quarantine.Admin, error) {
+	decoder := NewDecoder()
+	handler := NewHandler(uploadRepo, assetRepo, lifecycle, objects, logger)
+	return quarantine.NewAdmin(quarantine.AdminParams{
+		Consumer:  QuarantineConsumer,
+		Store:     quarantineRepo,
+		TxManager: tx,
+		Replay:    quarantine.Replayer[Event](decoder, handler),
+		Decode: func(_ string, payload []byte) (any, error) {
+			return decoder.Decode(payload)
+		},
+		Logger: logger,
+	})
+}
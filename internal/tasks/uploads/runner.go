@@ -6,6 +6,7 @@ import (
 
 	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
 	"github.com/bionicotaku/lingo-services-catalog/internal/services"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/quarantine"
 	"github.com/bionicotaku/lingo-utils/gcpubsub"
 	"github.com/bionicotaku/lingo-utils/outbox/config"
 	"github.com/bionicotaku/lingo-utils/outbox/inbox"
@@ -13,7 +14,7 @@ import (
 	"github.com/go-kratos/kratos/v2/log"
 )
 
-// Runner 负责消费 GCS OBJECT_FINALIZE 事件。
+// Runner 负责消费 GCS OBJECT_FINALIZE/OBJECT_DELETE/OBJECT_ARCHIVE 事件。
 type Runner struct {
 	delegate *inbox.Runner[Event]
 }
@@ -23,10 +24,14 @@ type RunnerParams struct {
 	Subscriber gcpubsub.Subscriber
 	InboxRepo  *repositories.InboxRepository
 	UploadRepo *repositories.UploadRepository
+	AssetRepo  *repositories.RawAssetRepository
 	Lifecycle  *services.LifecycleWriter
+	Objects    ObjectReader
 	TxManager  txmanager.Manager
 	Logger     log.Logger
 	Config     config.InboxConfig
+	// QuarantineRepo 可选，配置后处理器返回永久错误的事件登记到 catalog.inbox_quarantine 并确认，不再重试。
+	QuarantineRepo *repositories.InboxQuarantineRepository
 }
 
 // NewRunner 构造上传事件 Runner。
@@ -40,22 +45,32 @@ func NewRunner(params RunnerParams) (*Runner, error) {
 	if params.UploadRepo == nil {
 		return nil, fmt.Errorf("uploads: upload repository is required")
 	}
+	if params.AssetRepo == nil {
+		return nil, fmt.Errorf("uploads: raw asset repository is required")
+	}
 	if params.Lifecycle == nil {
 		return nil, fmt.Errorf("uploads: lifecycle writer is required")
 	}
+	if params.Objects == nil {
+		return nil, fmt.Errorf("uploads: object reader is required")
+	}
 	if params.TxManager == nil {
 		return nil, fmt.Errorf("uploads: transaction manager is required")
 	}
 
-	handler := NewHandler(params.UploadRepo, params.Lifecycle, params.Logger)
-	decoder := newDecoder()
+	handler := NewHandler(params.UploadRepo, params.AssetRepo, params.Lifecycle, params.Objects, params.Logger)
+	decoder := NewDecoder()
+	var inboxHandler inbox.Handler[Event] = handler
+	if params.QuarantineRepo != nil {
+		inboxHandler = quarantine.NewHandler[Event](QuarantineConsumer, handler, params.QuarantineRepo, params.Logger)
+	}
 
 	delegate, err := inbox.NewRunner[Event](inbox.RunnerParams[Event]{
 		Store:      params.InboxRepo.Shared(),
-		Subscriber: params.Subscriber,
+		Subscriber: newSubscriber(params.Subscriber, params.UploadRepo, params.Objects, params.Logger),
 		TxManager:  params.TxManager,
 		Decoder:    decoder,
-		Handler:    handler,
+		Handler:    inboxHandler,
 		Config:     params.Config,
 		Logger:     params.Logger,
 	})
@@ -0,0 +1,113 @@
+package uploads
+
+import (
+	"bytes"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// sniffLength 为内容嗅探读取的对象头部字节数，覆盖 ftyp box 与 EBML DocType。
+const sniffLength = 512
+
+const (
+	mimeMP4       = "video/mp4"
+	mimeQuickTime = "video/quicktime"
+	mimeM4V       = "video/x-m4v"
+	mimeWebM      = "video/webm"
+	mimeMatroska  = "video/x-matroska"
+	mimeThreeGPP  = "video/3gpp"
+	mimeThreeGPP2 = "video/3gpp2"
+	mimeAudioMP4  = "audio/mp4"
+	mimeGeneric   = "application/octet-stream"
+)
+
+// sniffedFamilies 定义允许进入媒体流水线的真实格式及其容器族。
+// 同一容器族内的 MIME 视为兼容（如客户端把 .mov 声明为 video/mp4）。
+var sniffedFamilies = map[string]string{
+	mimeMP4:       "isobmff",
+	mimeQuickTime: "isobmff",
+	mimeM4V:       "isobmff",
+	mimeThreeGPP:  "3gpp",
+	mimeThreeGPP2: "3gpp",
+	mimeWebM:      "webm",
+}
+
+var ebmlMagic = []byte{0x1A, 0x45, 0xDF, 0xA3}
+
+// sniffContentType 根据对象头部魔数推断真实的 MIME 类型。
+// 先识别 ISO-BMFF ftyp/QuickTime atom 与 EBML 头，其余交给 http.DetectContentType 兜底。
+func sniffContentType(head []byte) string {
+	if len(head) == 0 {
+		return ""
+	}
+	if len(head) >= 12 && bytes.Equal(head[4:8], []byte("ftyp")) {
+		return contentTypeFromBrand(string(head[8:12]))
+	}
+	if len(head) >= 8 {
+		switch string(head[4:8]) {
+		case "moov", "mdat", "wide", "free", "skip", "pnot":
+			// 早期 QuickTime 文件没有 ftyp，直接以 atom 开头。
+			return mimeQuickTime
+		}
+	}
+	if bytes.HasPrefix(head, ebmlMagic) {
+		// DocType 位于 EBML 头部内，webm 之外的 EBML 容器统一视为 Matroska。
+		if bytes.Contains(head, []byte("webm")) {
+			return mimeWebM
+		}
+		return mimeMatroska
+	}
+	return normalizeMIME(http.DetectContentType(head))
+}
+
+// contentTypeFromBrand 将 ftyp major brand 映射为 MIME 类型。
+func contentTypeFromBrand(brand string) string {
+	switch {
+	case brand == "qt  ":
+		return mimeQuickTime
+	case strings.HasPrefix(brand, "3g2"):
+		return mimeThreeGPP2
+	case strings.HasPrefix(brand, "3g"):
+		return mimeThreeGPP
+	case strings.HasPrefix(brand, "M4V"):
+		return mimeM4V
+	case brand == "M4A " || brand == "M4B " || brand == "M4P ":
+		return mimeAudioMP4
+	default:
+		// isom/iso2~iso9/mp41/mp42/avc1/dash/MSNV 等均为 MP4 兼容品牌。
+		return mimeMP4
+	}
+}
+
+// checkSniffedContentType 校验嗅探结果是否允许进入流水线，以及是否与客户端声明一致。
+// 返回空字符串表示校验通过，否则返回失败原因。
+func checkSniffedContentType(declared, sniffed string) string {
+	sniffedFamily, ok := sniffedFamilies[sniffed]
+	if !ok {
+		if sniffed == "" {
+			return "unable to detect object content type"
+		}
+		return "content type " + sniffed + " is not allowed"
+	}
+	declared = normalizeMIME(declared)
+	if declared == "" || declared == mimeGeneric {
+		return ""
+	}
+	declaredFamily, ok := sniffedFamilies[declared]
+	if !ok || declaredFamily != sniffedFamily {
+		return "declared content type " + declared + " does not match sniffed " + sniffed
+	}
+	return ""
+}
+
+func normalizeMIME(value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return ""
+	}
+	if mediaType, _, err := mime.ParseMediaType(value); err == nil {
+		return strings.ToLower(mediaType)
+	}
+	return strings.ToLower(value)
+}
@@ -0,0 +1,168 @@
+package uploads
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-utils/gcpubsub"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+)
+
+// subscriber 在消息进入 Inbox 前识别通知格式，补齐 Inbox 所需的 event_type/event_id 属性，
+// 并预读对象头部（OBJECT_FINALIZE）或存活版本（OBJECT_DELETE/OBJECT_ARCHIVE），使 GCS 网络 I/O 发生在 Inbox 事务之外。
+// 已处理过的 OBJECT_FINALIZE 重复投递时不再预读，处理器对这类通知不会读取对象头部；会话声明了 SHA-256 时一并计算对象摘要供去重校验。
+// 处理成功（Inbox 事务已提交）后删除处理器登记的去重冗余对象。
+type subscriber struct {
+	inner   gcpubsub.Subscriber
+	uploads uploadRepository
+	objects ObjectReader
+	log     *log.Helper
+}
+
+func newSubscriber(inner gcpubsub.Subscriber, uploads uploadRepository, objects ObjectReader, logger log.Logger) gcpubsub.Subscriber {
+	if logger == nil {
+		logger = log.NewStdLogger(nil)
+	}
+	return subscriber{inner: inner, uploads: uploads, objects: objects, log: log.NewHelper(logger)}
+}
+
+func (s subscriber) Receive(ctx context.Context, handler func(context.Context, *gcpubsub.Message) error) error {
+	if s.inner == nil {
+		return nil
+	}
+	return s.inner.Receive(ctx, func(c context.Context, msg *gcpubsub.Message) error {
+		if err := NormalizeAttributes(msg); err != nil {
+			s.log.WithContext(c).Warnf("uploads: reject notification message_id=%s err=%v", msg.ID, err)
+			return err
+		}
+		if evt, err := NewDecoder().Decode(msg.Data); err == nil {
+			switch strings.ToUpper(msg.Attributes["event_type"]) {
+			case gcsObjectFinalizeEvent:
+				c = s.prefetchFinalize(c, evt)
+			case gcsObjectDeleteEvent, gcsObjectArchiveEvent:
+				c = PrefetchLiveGeneration(c, s.objects, evt)
+			}
+		}
+		c, redundant := TrackRedundantObjects(c)
+		if err := handler(c, msg); err != nil {
+			return err
+		}
+		s.deleteRedundant(c, redundant())
+		return nil
+	})
+}
+
+// prefetchFinalize 为需要处理的 OBJECT_FINALIZE 预读对象头部，会话待去重时同时计算对象摘要。
+func (s subscriber) prefetchFinalize(ctx context.Context, evt *Event) context.Context {
+	session, settled := s.finalizeSession(ctx, evt)
+	if settled {
+		return ctx
+	}
+	ctx = PrefetchObjectHead(ctx, s.objects, evt)
+	if session != nil && session.ContentSHA256 != nil && session.AssetID == nil && session.Status != po.UploadStatusCompleted {
+		ctx = PrefetchContentSHA256(ctx, s.objects, evt)
+	}
+	return ctx
+}
+
+// deleteRedundant 删除去重后不再被引用的上传对象；失败只告警，对象留给桶生命周期规则回收。
+func (s subscriber) deleteRedundant(ctx context.Context, refs []ObjectRef) {
+	for _, ref := range refs {
+		if err := s.objects.DeleteObject(ctx, ref.Bucket, ref.ObjectName, ref.Generation); err != nil {
+			s.log.WithContext(ctx).Warnf("uploads: delete redundant object failed bucket=%s object=%s generation=%s err=%v", ref.Bucket, ref.ObjectName, ref.Generation, err)
+			continue
+		}
+		s.log.WithContext(ctx).Infof("uploads: deleted redundant object bucket=%s object=%s generation=%s", ref.Bucket, ref.ObjectName, ref.Generation)
+	}
+}
+
+// finalizeSession 读取 OBJECT_FINALIZE 对应的上传会话；settled 表示无需预读：对象没有对应的上传会话（处理器直接忽略），
+// 或会话已按同一 generation 完成（重复投递）。查询失败时返回空会话，照常预读对象头部。
+func (s subscriber) finalizeSession(ctx context.Context, evt *Event) (*po.UploadSession, bool) {
+	if s.uploads == nil {
+		return nil, false
+	}
+	session, err := s.uploads.GetByObject(ctx, nil, evt.Bucket, evt.ObjectName)
+	switch {
+	case errors.Is(err, repositories.ErrUploadNotFound):
+		return nil, true
+	case err != nil:
+		s.log.WithContext(ctx).Warnf("uploads: load session before prefetch failed bucket=%s object=%s err=%v", evt.Bucket, evt.ObjectName, err)
+		return nil, false
+	}
+	return session, finalizeApplied(session, evt)
+}
+
+func (s subscriber) Stop() {
+	if s.inner != nil {
+		s.inner.Stop()
+	}
+}
+
+// NormalizeAttributes 识别消息格式并补齐 event_type/event_id，已存在的属性保持不变。
+// event_id 统一按 {bucket}/{object}#{generation} 生成，经典通知与 CloudEvents 同时投递时可互相去重；
+// 删除/归档事件追加事件类型后缀，避免与同一 generation 的 OBJECT_FINALIZE 撞键。
+func NormalizeAttributes(msg *gcpubsub.Message) error {
+	if msg == nil {
+		return nil
+	}
+	if msg.Attributes == nil {
+		msg.Attributes = make(map[string]string)
+	}
+	attrs := msg.Attributes
+
+	format, err := DetectFormat(attrs, msg.Data)
+	if err != nil {
+		return err
+	}
+
+	eventType := attrs["eventType"]
+	bucket, object, generation := attrs["bucketId"], attrs["objectId"], attrs["objectGeneration"]
+	fallbackID := msg.ID
+	if format != FormatJSONAPIV1 {
+		evt, err := NewDecoder().Decode(msg.Data)
+		if err != nil {
+			return err
+		}
+		if format == FormatCloudEventsBinary {
+			eventType, _ = CloudEventType(attrs["ce-type"])
+			if id := attrs["ce-id"]; id != "" {
+				fallbackID = id
+			}
+		} else {
+			eventType = evt.EventType
+		}
+		bucket, object, generation = evt.Bucket, evt.ObjectName, evt.Generation
+	}
+	if eventType == "" {
+		eventType = gcsObjectFinalizeEvent
+	}
+
+	if _, ok := attrs["event_type"]; !ok {
+		attrs["event_type"] = eventType
+	}
+	if _, ok := attrs["event_id"]; !ok {
+		switch {
+		case bucket != "" && object != "" && generation != "":
+			attrs["event_id"] = objectEventID(bucket, object, generation, attrs["event_type"])
+		case fallbackID != "":
+			attrs["event_id"] = fallbackID
+		default:
+			attrs["event_id"] = uuid.NewString()
+		}
+	}
+	return nil
+}
+
+func objectEventID(bucket, object, generation, eventType string) string {
+	id := fmt.Sprintf("%s/%s#%s", bucket, object, generation)
+	if eventType != gcsObjectFinalizeEvent {
+		id += ":" + eventType
+	}
+	return id
+}
@@ -0,0 +1,620 @@
+package uploads_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/models/po"
+	"github.com/bionicotaku/lingo-services-catalog/internal/repositories"
+	"github.com/bionicotaku/lingo-services-catalog/internal/services"
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/uploads"
+	"github.com/bionicotaku/lingo-utils/outbox/store"
+	"github.com/bionicotaku/lingo-utils/txmanager"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerRejectsSniffedContentType(t *testing.T) {
+	cases := []struct {
+		name     string
+		declared string
+		head     []byte
+		sniffed  string
+	}{
+		{
+			name:     "declared webm but object is mp4",
+			declared: "video/webm",
+			head:     []byte{0x00, 0x00, 0x00, 0x18, 'f', 't', 'y', 'p', 'i', 's', 'o', 'm', 0x00, 0x00, 0x02, 0x00},
+			sniffed:  "video/mp4",
+		},
+		{
+			name:     "octet-stream executable",
+			declared: "application/octet-stream",
+			head:     []byte{0x7F, 'E', 'L', 'F', 0x02, 0x01, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00},
+			sniffed:  "application/octet-stream",
+		},
+		{
+			name:     "matroska is not allowed",
+			declared: "video/webm",
+			head:     append([]byte{0x1A, 0x45, 0xDF, 0xA3, 0x9F, 0x42, 0x82, 0x88}, []byte("matroska")...),
+			sniffed:  "video/x-matroska",
+		},
+		{
+			name:     "m4a audio",
+			declared: "video/mp4",
+			head:     []byte{0x00, 0x00, 0x00, 0x20, 'f', 't', 'y', 'p', 'M', '4', 'A', ' ', 0x00, 0x00, 0x00, 0x00},
+			sniffed:  "audio/mp4",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			session := newUploadSession(tc.declared)
+			repo := &fakeUploadRepo{session: session}
+			objects := staticObjectReader(tc.head)
+			writer := services.NewLifecycleWriter(nil, nil, nil, log.NewStdLogger(io.Discard))
+			handler := uploads.NewHandler(repo, nil, writer, objects, log.NewStdLogger(io.Discard))
+
+			err := handler.Handle(context.Background(), handlerSession{}, &uploads.Event{
+				Bucket:      session.Bucket,
+				ObjectName:  session.ObjectName,
+				Generation:  "7",
+				SizeBytes:   1024,
+				ContentType: tc.declared,
+			}, &store.InboxEvent{EventType: "OBJECT_FINALIZE"})
+			require.NoError(t, err)
+
+			require.NotNil(t, repo.failed)
+			require.False(t, repo.completed)
+			require.Equal(t, session.VideoID, repo.failed.VideoID)
+			require.Equal(t, "CONTENT_TYPE_INVALID", *repo.failed.ErrorCode)
+			require.NotNil(t, repo.failed.SniffedContentType)
+			require.Equal(t, tc.sniffed, *repo.failed.SniffedContentType)
+		})
+	}
+}
+
+func TestHandlerUsesPrefetchedObjectHead(t *testing.T) {
+	session := newUploadSession("video/webm")
+	repo := &fakeUploadRepo{session: session}
+	writer := services.NewLifecycleWriter(nil, nil, nil, log.NewStdLogger(io.Discard))
+	handler := uploads.NewHandler(repo, nil, writer, failingObjectReader{t: t}, log.NewStdLogger(io.Discard))
+
+	evt := &uploads.Event{
+		Bucket:      session.Bucket,
+		ObjectName:  session.ObjectName,
+		Generation:  "9",
+		SizeBytes:   1024,
+		ContentType: "video/webm",
+	}
+	ctx := uploads.PrefetchObjectHead(context.Background(), staticObjectReader(mp4Head), evt)
+
+	err := handler.Handle(ctx, handlerSession{}, evt, &store.InboxEvent{EventType: "OBJECT_FINALIZE"})
+	require.NoError(t, err)
+	require.NotNil(t, repo.failed)
+	require.Equal(t, "video/mp4", *repo.failed.SniffedContentType)
+}
+
+func TestHandlerDeduplicatesRawAsset(t *testing.T) {
+	session := newUploadSession("video/mp4")
+	session.ContentSHA256 = strPtr(strings.Repeat("ab", 32))
+	md5Sum := bytes.Repeat([]byte{0x5a}, 16)
+	canonical := &po.RawAsset{
+		AssetID:       uuid.New(),
+		ContentSHA256: *session.ContentSHA256,
+		ContentMD5:    hex.EncodeToString(md5Sum),
+		SizeBytes:     2048,
+		Bucket:        "media-test",
+		ObjectName:    "raw_videos/other/original",
+		RefCount:      2,
+	}
+	repo := &fakeUploadRepo{session: session}
+	assets := &fakeRawAssetRepo{existing: canonical}
+	videos := &fakeLifecycleRepo{}
+	writer := services.NewLifecycleWriter(videos, &fakeOutbox{}, fakeTxManager{}, log.NewStdLogger(io.Discard))
+	handler := uploads.NewHandler(repo, assets, writer, digestObjectReader(*session.ContentSHA256), log.NewStdLogger(io.Discard))
+
+	ctx, redundant := uploads.TrackRedundantObjects(context.Background())
+	err := handler.Handle(ctx, handlerSession{}, &uploads.Event{
+		Bucket:      session.Bucket,
+		ObjectName:  session.ObjectName,
+		Generation:  "3",
+		SizeBytes:   2048,
+		MD5Base64:   base64.StdEncoding.EncodeToString(md5Sum),
+		ContentType: "video/mp4",
+	}, &store.InboxEvent{EventType: "OBJECT_FINALIZE"})
+	require.NoError(t, err)
+
+	require.NotNil(t, assets.acquired)
+	require.Equal(t, *session.ContentSHA256, assets.acquired.ContentSHA256)
+	require.NotNil(t, repo.completedInput)
+	require.Equal(t, canonical.AssetID, *repo.completedInput.AssetID)
+	require.NotNil(t, videos.created)
+	require.Equal(t, "gs://media-test/raw_videos/other/original", videos.created.RawFileReference)
+	require.Equal(t, canonical.AssetID, *videos.created.AssetID)
+	// 本次上传的对象不再被引用，登记为冗余对象，由订阅器在提交后删除。
+	require.Equal(t, []uploads.ObjectRef{{Bucket: session.Bucket, ObjectName: session.ObjectName, Generation: "3"}}, redundant())
+}
+
+func TestHandlerSkipsDedupeOnUnverifiedSHA256(t *testing.T) {
+	session := newUploadSession("video/mp4")
+	session.ContentSHA256 = strPtr(strings.Repeat("ab", 32))
+	md5Sum := bytes.Repeat([]byte{0x5a}, 16)
+	repo := &fakeUploadRepo{session: session}
+	assets := &fakeRawAssetRepo{existing: &po.RawAsset{
+		AssetID:       uuid.New(),
+		ContentSHA256: *session.ContentSHA256,
+		ContentMD5:    hex.EncodeToString(md5Sum),
+		SizeBytes:     2048,
+		Bucket:        "media-test",
+		ObjectName:    "raw_videos/other/original",
+	}}
+	videos := &fakeLifecycleRepo{}
+	writer := services.NewLifecycleWriter(videos, &fakeOutbox{}, fakeTxManager{}, log.NewStdLogger(io.Discard))
+	handler := uploads.NewHandler(repo, assets, writer, failingObjectReader{t: t}, log.NewStdLogger(io.Discard))
+
+	evt := &uploads.Event{
+		Bucket:      session.Bucket,
+		ObjectName:  session.ObjectName,
+		Generation:  "5",
+		SizeBytes:   2048,
+		MD5Base64:   base64.StdEncoding.EncodeToString(md5Sum),
+		ContentType: "video/mp4",
+	}
+	// 客户端声明了他人资产的摘要，但上传内容的实际摘要不同：不得挂到既有资产上。
+	ctx := uploads.PrefetchObjectHead(context.Background(), staticObjectReader(mp4Head), evt)
+	ctx = uploads.PrefetchContentSHA256(ctx, digestObjectReader(strings.Repeat("cd", 32)), evt)
+	ctx, redundant := uploads.TrackRedundantObjects(ctx)
+
+	err := handler.Handle(ctx, handlerSession{}, evt, &store.InboxEvent{EventType: "OBJECT_FINALIZE"})
+	require.NoError(t, err)
+
+	require.Nil(t, assets.acquired)
+	require.Nil(t, repo.completedInput.AssetID)
+	require.Equal(t, "gs://"+session.Bucket+"/"+session.ObjectName, videos.created.RawFileReference)
+	require.Empty(t, redundant())
+}
+
+func TestHandlerReleasesRawAssetWhenVideoExists(t *testing.T) {
+	session := newUploadSession("video/mp4")
+	session.ContentSHA256 = strPtr(strings.Repeat("ef", 32))
+	md5Sum := bytes.Repeat([]byte{0x22}, 16)
+	repo := &fakeUploadRepo{session: session}
+	assets := &fakeRawAssetRepo{}
+	videos := &fakeLifecycleRepo{exists: true}
+	writer := services.NewLifecycleWriter(videos, &fakeOutbox{}, fakeTxManager{}, log.NewStdLogger(io.Discard))
+	handler := uploads.NewHandler(repo, assets, writer, digestObjectReader(*session.ContentSHA256), log.NewStdLogger(io.Discard))
+
+	err := handler.Handle(context.Background(), handlerSession{}, &uploads.Event{
+		Bucket:      session.Bucket,
+		ObjectName:  session.ObjectName,
+		Generation:  "2",
+		SizeBytes:   2048,
+		MD5Base64:   base64.StdEncoding.EncodeToString(md5Sum),
+		ContentType: "video/mp4",
+	}, &store.InboxEvent{EventType: "OBJECT_FINALIZE"})
+	require.NoError(t, err)
+
+	require.NotNil(t, assets.acquired)
+	require.NotNil(t, repo.completedInput.AssetID)
+	require.Equal(t, []uuid.UUID{*repo.completedInput.AssetID}, assets.released)
+}
+
+func TestHandlerSkipsDedupeOnHashMismatch(t *testing.T) {
+	session := newUploadSession("video/mp4")
+	session.ContentSHA256 = strPtr(strings.Repeat("cd", 32))
+	md5Sum := bytes.Repeat([]byte{0x11}, 16)
+	repo := &fakeUploadRepo{session: session}
+	assets := &fakeRawAssetRepo{existing: &po.RawAsset{
+		AssetID:       uuid.New(),
+		ContentSHA256: *session.ContentSHA256,
+		ContentMD5:    strings.Repeat("f", 32),
+		SizeBytes:     2048,
+		Bucket:        "media-test",
+		ObjectName:    "raw_videos/other/original",
+	}}
+	videos := &fakeLifecycleRepo{}
+	writer := services.NewLifecycleWriter(videos, &fakeOutbox{}, fakeTxManager{}, log.NewStdLogger(io.Discard))
+	handler := uploads.NewHandler(repo, assets, writer, digestObjectReader(*session.ContentSHA256), log.NewStdLogger(io.Discard))
+
+	err := handler.Handle(context.Background(), handlerSession{}, &uploads.Event{
+		Bucket:      session.Bucket,
+		ObjectName:  session.ObjectName,
+		Generation:  "4",
+		SizeBytes:   2048,
+		MD5Base64:   base64.StdEncoding.EncodeToString(md5Sum),
+		ContentType: "video/mp4",
+	}, &store.InboxEvent{EventType: "OBJECT_FINALIZE"})
+	require.NoError(t, err)
+
+	require.Nil(t, assets.acquired)
+	require.Nil(t, repo.completedInput.AssetID)
+	require.NotNil(t, videos.created)
+	require.Equal(t, "gs://"+session.Bucket+"/"+session.ObjectName, videos.created.RawFileReference)
+	require.Nil(t, videos.created.AssetID)
+}
+
+func TestHandlerFlagsRawMissingOnDelete(t *testing.T) {
+	for _, eventType := range []string{"OBJECT_DELETE", "OBJECT_ARCHIVE"} {
+		t.Run(eventType, func(t *testing.T) {
+			session := newUploadSession("video/mp4")
+			session.Status = po.UploadStatusCompleted
+			session.GCSGeneration = strPtr("5")
+			repo := &fakeUploadRepo{session: session}
+			videos := &fakeLifecycleRepo{}
+			writer := services.NewLifecycleWriter(videos, &fakeOutbox{}, fakeTxManager{}, log.NewStdLogger(io.Discard))
+			handler := uploads.NewHandler(repo, nil, writer, staticObjectReader(mp4Head), log.NewStdLogger(io.Discard))
+
+			err := handler.Handle(context.Background(), handlerSession{}, &uploads.Event{
+				Bucket:     session.Bucket,
+				ObjectName: session.ObjectName,
+				Generation: "5",
+			}, &store.InboxEvent{EventType: eventType})
+			require.NoError(t, err)
+
+			require.Equal(t, session.VideoID, repo.rawMissing)
+			require.NotNil(t, videos.updated)
+			require.NotNil(t, videos.updated.RawMissing)
+			require.True(t, *videos.updated.RawMissing)
+		})
+	}
+}
+
+func TestHandlerIgnoresDeleteForOverwrittenObject(t *testing.T) {
+	cases := []struct {
+		name       string
+		generation string
+		objects    uploads.ObjectReader
+	}{
+		{
+			name:       "stale generation",
+			generation: "4",
+			objects:    staticObjectReader(mp4Head),
+		},
+		{
+			name:       "live object exists",
+			generation: "5",
+			objects:    liveObjectReader("6"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			session := newUploadSession("video/mp4")
+			session.Status = po.UploadStatusCompleted
+			session.GCSGeneration = strPtr("5")
+			repo := &fakeUploadRepo{session: session}
+			videos := &fakeLifecycleRepo{}
+			writer := services.NewLifecycleWriter(videos, &fakeOutbox{}, fakeTxManager{}, log.NewStdLogger(io.Discard))
+			handler := uploads.NewHandler(repo, nil, writer, tc.objects, log.NewStdLogger(io.Discard))
+
+			err := handler.Handle(context.Background(), handlerSession{}, &uploads.Event{
+				Bucket:     session.Bucket,
+				ObjectName: session.ObjectName,
+				Generation: tc.generation,
+			}, &store.InboxEvent{EventType: "OBJECT_DELETE"})
+			require.NoError(t, err)
+
+			require.Equal(t, uuid.Nil, repo.rawMissing)
+			require.Nil(t, videos.updated)
+		})
+	}
+}
+
+func TestHandlerIgnoresDeleteForDeduplicatedObject(t *testing.T) {
+	session := newUploadSession("video/mp4")
+	session.Status = po.UploadStatusCompleted
+	session.GCSGeneration = strPtr("5")
+	assetID := uuid.New()
+	session.AssetID = &assetID
+	repo := &fakeUploadRepo{session: session}
+	// 视频已去重到其他规范对象，本次上传的对象不是任何资产的规范对象。
+	assets := &fakeRawAssetRepo{byObject: &po.RawAsset{
+		AssetID:    assetID,
+		Bucket:     session.Bucket,
+		ObjectName: "raw_videos/canonical",
+	}}
+	videos := &fakeLifecycleRepo{}
+	writer := services.NewLifecycleWriter(videos, &fakeOutbox{}, fakeTxManager{}, log.NewStdLogger(io.Discard))
+	handler := uploads.NewHandler(repo, assets, writer, staticObjectReader(mp4Head), log.NewStdLogger(io.Discard))
+
+	err := handler.Handle(context.Background(), handlerSession{}, &uploads.Event{
+		Bucket:     session.Bucket,
+		ObjectName: session.ObjectName,
+		Generation: "5",
+	}, &store.InboxEvent{EventType: "OBJECT_DELETE"})
+	require.NoError(t, err)
+
+	require.Equal(t, uuid.Nil, repo.rawMissing)
+	require.Empty(t, videos.updatedIDs)
+}
+
+func TestHandlerFlagsAllAssetVideosOnCanonicalDelete(t *testing.T) {
+	session := newUploadSession("video/mp4")
+	session.Status = po.UploadStatusCompleted
+	session.GCSGeneration = strPtr("5")
+	assetID := uuid.New()
+	session.AssetID = &assetID
+	repo := &fakeUploadRepo{session: session}
+	other := uuid.New()
+	assets := &fakeRawAssetRepo{
+		byObject: &po.RawAsset{
+			AssetID:    assetID,
+			Bucket:     session.Bucket,
+			ObjectName: session.ObjectName,
+		},
+		referrers: []uuid.UUID{session.VideoID, other},
+	}
+	videos := &fakeLifecycleRepo{}
+	writer := services.NewLifecycleWriter(videos, &fakeOutbox{}, fakeTxManager{}, log.NewStdLogger(io.Discard))
+	handler := uploads.NewHandler(repo, assets, writer, staticObjectReader(mp4Head), log.NewStdLogger(io.Discard))
+
+	err := handler.Handle(context.Background(), handlerSession{}, &uploads.Event{
+		Bucket:     session.Bucket,
+		ObjectName: session.ObjectName,
+		Generation: "5",
+	}, &store.InboxEvent{EventType: "OBJECT_DELETE"})
+	require.NoError(t, err)
+
+	require.Equal(t, session.VideoID, repo.rawMissing)
+	require.ElementsMatch(t, []uuid.UUID{session.VideoID, other}, videos.updatedIDs)
+	require.True(t, *videos.updated.RawMissing)
+}
+
+// ---- Test Doubles ----
+
+var mp4Head = []byte{0x00, 0x00, 0x00, 0x18, 'f', 't', 'y', 'p', 'i', 's', 'o', 'm', 0x00, 0x00, 0x02, 0x00}
+
+type fakeUploadRepo struct {
+	session        *po.UploadSession
+	failed         *repositories.MarkUploadFailedInput
+	completed      bool
+	completedInput *repositories.MarkUploadCompletedInput
+	rawMissing     uuid.UUID
+}
+
+func (f *fakeUploadRepo) GetByObject(context.Context, txmanager.Session, string, string) (*po.UploadSession, error) {
+	return f.session, nil
+}
+
+func (f *fakeUploadRepo) MarkCompleted(_ context.Context, _ txmanager.Session, input repositories.MarkUploadCompletedInput) (*po.UploadSession, error) {
+	f.completed = true
+	f.completedInput = &input
+	return f.session, nil
+}
+
+func (f *fakeUploadRepo) MarkFailed(_ context.Context, _ txmanager.Session, input repositories.MarkUploadFailedInput) (*po.UploadSession, error) {
+	f.failed = &input
+	return f.session, nil
+}
+
+func (f *fakeUploadRepo) MarkRawMissing(_ context.Context, _ txmanager.Session, videoID uuid.UUID) (*po.UploadSession, error) {
+	f.rawMissing = videoID
+	return f.session, nil
+}
+
+func (f *fakeUploadRepo) ReconcileUsage(context.Context, txmanager.Session, uuid.UUID) (*po.UploadUsage, error) {
+	return &po.UploadUsage{}, nil
+}
+
+type fakeRawAssetRepo struct {
+	existing *po.RawAsset
+	acquired *repositories.AcquireRawAssetInput
+	released []uuid.UUID
+	// byObject 为规范对象对应的资产，referrers 为引用该资产的视频。
+	byObject  *po.RawAsset
+	referrers []uuid.UUID
+}
+
+func (f *fakeRawAssetRepo) GetBySHA256(context.Context, txmanager.Session, string) (*po.RawAsset, error) {
+	if f.existing == nil {
+		return nil, repositories.ErrRawAssetNotFound
+	}
+	return f.existing, nil
+}
+
+func (f *fakeRawAssetRepo) Acquire(_ context.Context, _ txmanager.Session, input repositories.AcquireRawAssetInput) (*po.RawAsset, bool, error) {
+	f.acquired = &input
+	if f.existing != nil {
+		return f.existing, false, nil
+	}
+	return &po.RawAsset{AssetID: uuid.New(), Bucket: input.Bucket, ObjectName: input.ObjectName, RefCount: 1}, true, nil
+}
+
+func (f *fakeRawAssetRepo) Release(_ context.Context, _ txmanager.Session, assetID uuid.UUID) (*po.RawAsset, error) {
+	f.released = append(f.released, assetID)
+	return &po.RawAsset{AssetID: assetID}, nil
+}
+
+func (f *fakeRawAssetRepo) GetByObject(_ context.Context, _ txmanager.Session, bucket, objectName string) (*po.RawAsset, error) {
+	if f.byObject == nil || f.byObject.Bucket != bucket || f.byObject.ObjectName != objectName {
+		return nil, repositories.ErrRawAssetNotFound
+	}
+	return f.byObject, nil
+}
+
+func (f *fakeRawAssetRepo) ListVideoIDs(context.Context, txmanager.Session, uuid.UUID) ([]uuid.UUID, error) {
+	return f.referrers, nil
+}
+
+type fakeLifecycleRepo struct {
+	created *repositories.CreateVideoInput
+	updated *repositories.UpdateVideoInput
+	// updatedIDs 按调用顺序记录所有被更新的视频。
+	updatedIDs []uuid.UUID
+	// exists 模拟视频记录已存在（Create 未插入新行）。
+	exists bool
+}
+
+func (f *fakeLifecycleRepo) Create(_ context.Context, _ txmanager.Session, input repositories.CreateVideoInput) (*po.Video, bool, error) {
+	f.created = &input
+	if f.exists {
+		return &po.Video{
+			VideoID:          input.VideoID,
+			UploadUserID:     input.UploadUserID,
+			Title:            input.Title,
+			RawFileReference: "gs://media-test/raw_videos/registered",
+			Status:           po.VideoStatusProcessing,
+			MediaStatus:      po.StagePending,
+			AnalysisStatus:   po.StagePending,
+			UpdatedAt:        time.Now().UTC(),
+		}, false, nil
+	}
+	return &po.Video{
+		VideoID:          input.VideoID,
+		UploadUserID:     input.UploadUserID,
+		Title:            input.Title,
+		RawFileReference: input.RawFileReference,
+		Status:           po.VideoStatusPendingUpload,
+		MediaStatus:      po.StagePending,
+		AnalysisStatus:   po.StagePending,
+		AssetID:          input.AssetID,
+		CreatedAt:        time.Now().UTC(),
+		UpdatedAt:        time.Now().UTC(),
+	}, true, nil
+}
+
+func (f *fakeLifecycleRepo) Update(_ context.Context, _ txmanager.Session, input repositories.UpdateVideoInput) (*po.Video, error) {
+	f.updated = &input
+	f.updatedIDs = append(f.updatedIDs, input.VideoID)
+	video := &po.Video{
+		VideoID:        input.VideoID,
+		Title:          "Sniff",
+		Status:         po.VideoStatusProcessing,
+		MediaStatus:    po.StagePending,
+		AnalysisStatus: po.StagePending,
+		UpdatedAt:      time.Now().UTC(),
+	}
+	if f.created != nil {
+		video.UploadUserID = f.created.UploadUserID
+		video.RawFileReference = f.created.RawFileReference
+	}
+	return video, nil
+}
+
+type fakeOutbox struct{}
+
+func (fakeOutbox) Enqueue(context.Context, txmanager.Session, repositories.OutboxMessage) error {
+	return nil
+}
+
+func (fakeOutbox) Notify(context.Context, txmanager.Session, string) error {
+	return nil
+}
+
+type fakeTxManager struct{}
+
+func (fakeTxManager) WithinTx(ctx context.Context, _ txmanager.TxOptions, fn func(context.Context, txmanager.Session) error) error {
+	return fn(ctx, handlerSession{})
+}
+
+func (fakeTxManager) WithinReadOnlyTx(ctx context.Context, _ txmanager.TxOptions, fn func(context.Context, txmanager.Session) error) error {
+	return fn(ctx, handlerSession{})
+}
+
+type staticObjectReader []byte
+
+func (s staticObjectReader) ReadObjectHead(context.Context, string, string, string, int64) ([]byte, error) {
+	return []byte(s), nil
+}
+
+func (staticObjectReader) ContentSHA256(context.Context, string, string, string) (string, error) {
+	return "", nil
+}
+
+func (staticObjectReader) LiveGeneration(context.Context, string, string) (string, error) {
+	return "", nil
+}
+
+func (staticObjectReader) DeleteObject(context.Context, string, string, string) error {
+	return nil
+}
+
+// digestObjectReader 返回 mp4 头部与固定的对象摘要。
+type digestObjectReader string
+
+func (digestObjectReader) ReadObjectHead(context.Context, string, string, string, int64) ([]byte, error) {
+	return mp4Head, nil
+}
+
+func (d digestObjectReader) ContentSHA256(context.Context, string, string, string) (string, error) {
+	return string(d), nil
+}
+
+func (digestObjectReader) LiveGeneration(context.Context, string, string) (string, error) {
+	return "", nil
+}
+
+func (digestObjectReader) DeleteObject(context.Context, string, string, string) error {
+	return nil
+}
+
+// failingObjectReader 断言处理器不在事务内访问 GCS。
+type failingObjectReader struct{ t *testing.T }
+
+func (f failingObjectReader) ReadObjectHead(context.Context, string, string, string, int64) ([]byte, error) {
+	f.t.Fatalf("object head should be prefetched outside the inbox transaction")
+	return nil, nil
+}
+
+func (f failingObjectReader) ContentSHA256(context.Context, string, string, string) (string, error) {
+	f.t.Fatalf("object digest should be computed outside the inbox transaction")
+	return "", nil
+}
+
+func (failingObjectReader) LiveGeneration(context.Context, string, string) (string, error) {
+	return "", nil
+}
+
+func (f failingObjectReader) DeleteObject(context.Context, string, string, string) error {
+	f.t.Fatalf("objects must not be deleted inside the inbox transaction")
+	return nil
+}
+
+type liveObjectReader string
+
+func (liveObjectReader) ReadObjectHead(context.Context, string, string, string, int64) ([]byte, error) {
+	return mp4Head, nil
+}
+
+func (liveObjectReader) ContentSHA256(context.Context, string, string, string) (string, error) {
+	return "", nil
+}
+
+func (l liveObjectReader) LiveGeneration(context.Context, string, string) (string, error) {
+	return string(l), nil
+}
+
+func (liveObjectReader) DeleteObject(context.Context, string, string, string) error {
+	return nil
+}
+
+type handlerSession struct{}
+
+func (handlerSession) Tx() pgx.Tx { return nil }
+
+func (handlerSession) Context() context.Context { return context.Background() }
+
+func newUploadSession(contentType string) *po.UploadSession {
+	userID := uuid.New()
+	videoID := uuid.New()
+	return &po.UploadSession{
+		VideoID:     videoID,
+		UserID:      userID,
+		Bucket:      "media-test",
+		ObjectName:  "raw_videos/" + userID.String() + "/" + videoID.String(),
+		ContentType: &contentType,
+		Status:      po.UploadStatusUploading,
+		Title:       "Sniff",
+	}
+}
+
+func strPtr(value string) *string { return &value }
@@ -0,0 +1,142 @@
+package uploads_test
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bionicotaku/lingo-services-catalog/internal/tasks/uploads"
+	"github.com/bionicotaku/lingo-utils/gcpubsub"
+	"github.com/stretchr/testify/require"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite golden files under testdata/notifications")
+
+type notificationInput struct {
+	ID         string            `json:"id"`
+	Attributes map[string]string `json:"attributes"`
+	Data       json.RawMessage   `json:"data"`
+}
+
+type notificationGolden struct {
+	Format    string             `json:"format,omitempty"`
+	EventType string             `json:"event_type,omitempty"`
+	EventID   string             `json:"event_id,omitempty"`
+	Event     *notificationEvent `json:"event,omitempty"`
+	Error     string             `json:"error,omitempty"`
+}
+
+type notificationEvent struct {
+	EventType   string `json:"event_type,omitempty"`
+	Bucket      string `json:"bucket"`
+	ObjectName  string `json:"object_name"`
+	Generation  string `json:"generation"`
+	SizeBytes   int64  `json:"size_bytes"`
+	ContentType string `json:"content_type"`
+	MD5Base64   string `json:"md5_base64"`
+	CRC32C      string `json:"crc32c"`
+	ETag        string `json:"etag"`
+}
+
+func TestNotificationFormatsGolden(t *testing.T) {
+	inputs, err := filepath.Glob(filepath.Join("testdata", "notifications", "*.input.json"))
+	require.NoError(t, err)
+	require.NotEmpty(t, inputs)
+
+	for _, inputPath := range inputs {
+		name := strings.TrimSuffix(filepath.Base(inputPath), ".input.json")
+		t.Run(name, func(t *testing.T) {
+			raw, err := os.ReadFile(inputPath)
+			require.NoError(t, err)
+			var input notificationInput
+			require.NoError(t, json.Unmarshal(raw, &input))
+
+			got := decodeNotification(input)
+			encoded, err := json.MarshalIndent(got, "", "  ")
+			require.NoError(t, err)
+			encoded = append(encoded, '\n')
+
+			goldenPath := filepath.Join("testdata", "notifications", name+".golden.json")
+			if *updateGolden {
+				require.NoError(t, os.WriteFile(goldenPath, encoded, 0o644))
+			}
+			want, err := os.ReadFile(goldenPath)
+			require.NoError(t, err, "missing golden file, run go test -update")
+			require.JSONEq(t, string(want), string(encoded))
+		})
+	}
+}
+
+func decodeNotification(input notificationInput) notificationGolden {
+	msg := &gcpubsub.Message{ID: input.ID, Data: []byte(input.Data), Attributes: input.Attributes}
+	if string(input.Data) == "null" {
+		msg.Data = nil
+	}
+
+	format, err := uploads.DetectFormat(msg.Attributes, msg.Data)
+	if err != nil {
+		return notificationGolden{Error: err.Error()}
+	}
+	if err := uploads.NormalizeAttributes(msg); err != nil {
+		return notificationGolden{Error: err.Error()}
+	}
+	evt, err := uploads.NewDecoder().Decode(msg.Data)
+	if err != nil {
+		return notificationGolden{Error: err.Error()}
+	}
+	return notificationGolden{
+		Format:    string(format),
+		EventType: msg.Attributes["event_type"],
+		EventID:   msg.Attributes["event_id"],
+		Event: &notificationEvent{
+			EventType:   evt.EventType,
+			Bucket:      evt.Bucket,
+			ObjectName:  evt.ObjectName,
+			Generation:  evt.Generation,
+			SizeBytes:   evt.SizeBytes,
+			ContentType: evt.ContentType,
+			MD5Base64:   evt.MD5Base64,
+			CRC32C:      evt.CRC32C,
+			ETag:        evt.ETag,
+		},
+	}
+}
+
+func TestNormalizeAttributesKeepsExplicitKeys(t *testing.T) {
+	msg := &gcpubsub.Message{
+		ID:   "42",
+		Data: []byte(`{"bucket":"media-test","name":"raw_videos/u1/v1","generation":"7"}`),
+		Attributes: map[string]string{
+			"event_type": "OBJECT_FINALIZE",
+			"event_id":   "custom-id",
+		},
+	}
+	require.NoError(t, uploads.NormalizeAttributes(msg))
+	require.Equal(t, "OBJECT_FINALIZE", msg.Attributes["event_type"])
+	require.Equal(t, "custom-id", msg.Attributes["event_id"])
+}
+
+func TestNormalizeAttributesSeparatesFinalizeAndDelete(t *testing.T) {
+	attrsFor := func(eventType string) map[string]string {
+		return map[string]string{
+			"payloadFormat":    "JSON_API_V1",
+			"eventType":        eventType,
+			"bucketId":         "media-test",
+			"objectId":         "raw_videos/u1/v1",
+			"objectGeneration": "7",
+		}
+	}
+	data := []byte(`{"bucket":"media-test","name":"raw_videos/u1/v1","generation":"7"}`)
+
+	finalize := &gcpubsub.Message{ID: "1", Data: data, Attributes: attrsFor("OBJECT_FINALIZE")}
+	require.NoError(t, uploads.NormalizeAttributes(finalize))
+	deleted := &gcpubsub.Message{ID: "2", Data: data, Attributes: attrsFor("OBJECT_DELETE")}
+	require.NoError(t, uploads.NormalizeAttributes(deleted))
+
+	require.Equal(t, "media-test/raw_videos/u1/v1#7", finalize.Attributes["event_id"])
+	require.Equal(t, "media-test/raw_videos/u1/v1#7:OBJECT_DELETE", deleted.Attributes["event_id"])
+	require.NotEqual(t, finalize.Attributes["event_id"], deleted.Attributes["event_id"])
+}
@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -39,6 +40,7 @@ type uploadsRunnerEnv struct {
 	txMgr      txmanager.Manager
 	publisher  gcpubsub.Publisher
 	subscriber gcpubsub.Subscriber
+	objects    *fakeObjectReader
 	runner     *uploads.Runner
 	logger     log.Logger
 	cancel     context.CancelFunc
@@ -101,12 +103,14 @@ func newUploadsRunnerEnv(t *testing.T) *uploadsRunnerEnv {
 
 	publisher := gcpubsub.ProvidePublisher(component)
 	subscriber := gcpubsub.ProvideSubscriber(component)
+	objects := newFakeObjectReader()
 
 	runner, err := uploads.NewRunner(uploads.RunnerParams{
 		Subscriber: subscriber,
 		InboxRepo:  inboxRepo,
 		UploadRepo: uploadRepo,
 		Lifecycle:  lifecycle,
+		Objects:    objects,
 		TxManager:  txMgr,
 		Logger:     logger,
 		Config: outboxcfg.InboxConfig{
@@ -144,6 +148,7 @@ func newUploadsRunnerEnv(t *testing.T) *uploadsRunnerEnv {
 		txMgr:      txMgr,
 		publisher:  publisher,
 		subscriber: subscriber,
+		objects:    objects,
 		runner:     runner,
 		logger:     logger,
 		cancel:     cancel,
@@ -240,6 +245,7 @@ func TestUploadsRunner_ObjectFinalizeCreatesVideo(t *testing.T) {
 		return row.Status == "completed" && row.SizeBytes == expectedSize && row.MD5Hex == md5Hex
 	})
 	require.NotNil(t, upload)
+	require.Equal(t, "video/mp4", upload.SniffedContentType)
 
 	video := waitForVideoRecord(ctx, t, pool, videoID, 20*time.Second, func(row videoRecord) bool {
 		return row.RawFileReference == fmt.Sprintf("gs://%s/%s", bucket, objectName) && row.Status == string(po.VideoStatusProcessing)
@@ -248,6 +254,7 @@ func TestUploadsRunner_ObjectFinalizeCreatesVideo(t *testing.T) {
 
 	events := countVideoCreatedEvents(ctx, t, pool, videoID)
 	require.EqualValues(t, 1, events)
+	readsBefore := env.objects.Reads()
 
 	// Publish the same finalize event again to ensure idempotency.
 	_, err = publisher.Publish(ctx, gcpubsub.Message{Data: data, Attributes: attrs})
@@ -262,6 +269,8 @@ func TestUploadsRunner_ObjectFinalizeCreatesVideo(t *testing.T) {
 
 	eventsAfter := countVideoCreatedEvents(ctx, t, pool, videoID)
 	require.EqualValues(t, 1, eventsAfter)
+	// 重复投递的 finalize 在预读前即识别为已处理，不再读取对象头部。
+	require.Equal(t, readsBefore, env.objects.Reads())
 }
 
 func TestUploadsRunner_MD5MismatchMarksFailed(t *testing.T) {
@@ -346,6 +355,93 @@ func TestUploadsRunner_MD5MismatchMarksFailed(t *testing.T) {
 	require.EqualValues(t, 0, events)
 }
 
+func TestUploadsRunner_ContentTypeMismatchMarksFailed(t *testing.T) {
+	env := newUploadsRunnerEnv(t)
+	defer env.Shutdown()
+
+	ctx := env.ctx
+	pool := env.pool
+	publisher := env.publisher
+
+	userID := uuid.New()
+	videoID := uuid.New()
+	bucket := "media-test"
+	objectName := fmt.Sprintf("raw_videos/%s/%s", userID.String(), videoID.String())
+	contentType := "application/octet-stream"
+	expectedSize := int64(1024)
+
+	md5Hex := "d41d8cd98f00b204e9800998ecf8427e"
+	md5Base64 := "1B2M2Y8AsgTpgAmY7PhCfg=="
+
+	// 客户端声明为 octet-stream，实际上传的是 ZIP 压缩包。
+	env.objects.Set(bucket, objectName, []byte("PK\x03\x04\x14\x00\x00\x00\x08\x00"))
+
+	_, err := pool.Exec(ctx, `
+        insert into catalog.uploads (
+            video_id,
+            user_id,
+            bucket,
+            object_name,
+            content_type,
+            expected_size,
+            size_bytes,
+            content_md5,
+            title,
+            description,
+            signed_url,
+            signed_url_expires_at,
+            status,
+            created_at,
+            updated_at
+        ) values (
+            $1,$2,$3,$4,$5,$6,0,$7,'Runner Test','Sniff mismatch','https://signed.example',$8,'uploading',$9,$9
+        )
+    `, videoID, userID, bucket, objectName, contentType, expectedSize, md5Hex, time.Now().Add(5*time.Minute), time.Now())
+	require.NoError(t, err)
+
+	payload := map[string]any{
+		"bucket":      bucket,
+		"name":        objectName,
+		"generation":  "5",
+		"size":        fmt.Sprintf("%d", expectedSize),
+		"contentType": contentType,
+		"md5Hash":     md5Base64,
+		"crc32c":      "AAAAAA==",
+		"etag":        "etag-zip",
+	}
+
+	data, err := json.Marshal(payload)
+	require.NoError(t, err)
+
+	attrs := map[string]string{
+		"event_type":       "OBJECT_FINALIZE",
+		"event_id":         fmt.Sprintf("%s/%s#5", bucket, objectName),
+		"bucketId":         bucket,
+		"objectId":         objectName,
+		"objectGeneration": "5",
+		"aggregate_type":   "upload",
+		"aggregate_id":     videoID.String(),
+		"schema_version":   "v1",
+	}
+
+	_, err = publisher.Publish(ctx, gcpubsub.Message{Data: data, Attributes: attrs})
+	require.NoError(t, err)
+
+	upload := waitForUploadSession(ctx, t, pool, videoID, 10*time.Second, func(row uploadSessionRow) bool {
+		return row.Status == "failed" && row.ErrorCode == "CONTENT_TYPE_INVALID"
+	})
+	require.NotNil(t, upload)
+	require.Equal(t, "application/zip", upload.SniffedContentType)
+
+	var videoCount int
+	err = pool.QueryRow(ctx, `select count(*) from catalog.videos where video_id = $1`, videoID).Scan(&videoCount)
+	require.NoError(t, err)
+	require.Equal(t, 0, videoCount)
+
+	events := countVideoCreatedEvents(ctx, t, pool, videoID)
+	require.EqualValues(t, 0, events)
+}
+
 func TestUploadsRunner_FinalizeForUnknownObjectIgnored(t *testing.T) {
 	env := newUploadsRunnerEnv(t)
 	defer env.Shutdown()
@@ -510,13 +606,14 @@ func TestUploadsRunner_ReprocessAfterFailure(t *testing.T) {
 }
 
 type uploadSessionRow struct {
-	Status      string
-	SizeBytes   int64
-	MD5Hex      string
-	Generation  string
-	ContentType string
-	ErrorCode   string
-	ErrorMsg    string
+	Status             string
+	SizeBytes          int64
+	MD5Hex             string
+	Generation         string
+	ContentType        string
+	SniffedContentType string
+	ErrorCode          string
+	ErrorMsg           string
 }
 
 type videoRecord struct {
@@ -528,15 +625,16 @@ func waitForUploadSession(ctx context.Context, t *testing.T, pool *pgxpool.Pool,
 	t.Helper()
 	deadline := time.Now().Add(timeout)
 	for time.Now().Before(deadline) {
-		row := pool.QueryRow(ctx, `select status, size_bytes, md5_hash, gcs_generation, content_type, error_code, error_message from catalog.uploads where video_id = $1`, videoID)
+		row := pool.QueryRow(ctx, `select status, size_bytes, md5_hash, gcs_generation, content_type, sniffed_content_type, error_code, error_message from catalog.uploads where video_id = $1`, videoID)
 		var status string
 		var size int64
 		var md5 pgtype.Text
 		var generation pgtype.Text
 		var contentType pgtype.Text
+		var sniffedContentType pgtype.Text
 		var errorCode pgtype.Text
 		var errorMessage pgtype.Text
-		err := row.Scan(&status, &size, &md5, &generation, &contentType, &errorCode, &errorMessage)
+		err := row.Scan(&status, &size, &md5, &generation, &contentType, &sniffedContentType, &errorCode, &errorMessage)
 		if err != nil {
 			if errors.Is(err, pgx.ErrNoRows) {
 				time.Sleep(50 * time.Millisecond)
@@ -545,13 +643,14 @@ func waitForUploadSession(ctx context.Context, t *testing.T, pool *pgxpool.Pool,
 			t.Fatalf("scan upload session: %v", err)
 		}
 		item := uploadSessionRow{
-			Status:      status,
-			SizeBytes:   size,
-			MD5Hex:      md5.String,
-			Generation:  generation.String,
-			ContentType: contentType.String,
-			ErrorCode:   errorCode.String,
-			ErrorMsg:    errorMessage.String,
+			Status:             status,
+			SizeBytes:          size,
+			MD5Hex:             md5.String,
+			Generation:         generation.String,
+			ContentType:        contentType.String,
+			SniffedContentType: sniffedContentType.String,
+			ErrorCode:          errorCode.String,
+			ErrorMsg:           errorMessage.String,
 		}
 		if predicate == nil || predicate(item) {
 			return &item
@@ -590,6 +689,59 @@ func countVideoCreatedEvents(ctx context.Context, t *testing.T, pool *pgxpool.Po
 	return count
 }
 
+// fakeObjectReader 以内存 map 模拟 GCS 对象头部读取；未登记的对象默认返回 MP4 ftyp 头。
+type fakeObjectReader struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	reads   int
+}
+
+func newFakeObjectReader() *fakeObjectReader {
+	return &fakeObjectReader{objects: make(map[string][]byte)}
+}
+
+func (f *fakeObjectReader) Set(bucket, objectName string, head []byte) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.objects[bucket+"/"+objectName] = append([]byte(nil), head...)
+}
+
+func (f *fakeObjectReader) ReadObjectHead(_ context.Context, bucket, objectName, _ string, length int64) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.reads++
+	head, ok := f.objects[bucket+"/"+objectName]
+	if !ok {
+		head = mp4Header()
+	}
+	if int64(len(head)) > length {
+		head = head[:length]
+	}
+	return append([]byte(nil), head...), nil
+}
+
+func (f *fakeObjectReader) Reads() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.reads
+}
+
+func (f *fakeObjectReader) ContentSHA256(context.Context, string, string, string) (string, error) {
+	return "", nil
+}
+
+func (f *fakeObjectReader) LiveGeneration(context.Context, string, string) (string, error) {
+	return "", nil
+}
+
+func (f *fakeObjectReader) DeleteObject(context.Context, string, string, string) error {
+	return nil
+}
+
+func mp4Header() []byte {
+	return []byte{0x00, 0x00, 0x00, 0x18, 'f', 't', 'y', 'p', 'i', 's', 'o', 'm', 0x00, 0x00, 0x02, 0x00, 'i', 's', 'o', 'm', 'i', 's', 'o', '2'}
+}
+
 func boolPtr(v bool) *bool { return &v }
 
 func ensureAuthSchema(ctx context.Context, t *testing.T, pool *pgxpool.Pool) {
@@ -0,0 +1,8 @@
+-- ============================================
+-- 6) 上传会话补充：对象魔数嗅探结果
+-- ============================================
+alter table catalog.uploads
+  add column if not exists sniffed_content_type text;
+
+comment on column catalog.uploads.sniffed_content_type is 'OBJECT_FINALIZE 时读取对象头部字节（ftyp/EBML 等魔数）推断出的真实 MIME 类型';
+comment on column catalog.uploads.error_code            is '失败时记录的错误代码（如 MD5_MISMATCH、CONTENT_TYPE_INVALID）';
@@ -0,0 +1,23 @@
+-- ============================================
+-- 7) 上传配额用量：catalog.upload_usage
+-- ============================================
+create table if not exists catalog.upload_usage (
+  user_id           uuid primary key,
+  stored_bytes      bigint not null default 0 check (stored_bytes >= 0),
+  completed_uploads bigint not null default 0 check (completed_uploads >= 0),
+  reconciled_at     timestamptz not null default now(),
+  updated_at        timestamptz not null default now()
+);
+
+comment on table catalog.upload_usage is '用户上传配额用量：OBJECT_FINALIZE 成功后依据 catalog.uploads.size_bytes 对账汇总';
+
+comment on column catalog.upload_usage.user_id           is '上传用户 ID（auth.users.id）';
+comment on column catalog.upload_usage.stored_bytes      is '已完成上传的对象总字节数（sum(catalog.uploads.size_bytes) where status=completed）';
+comment on column catalog.upload_usage.completed_uploads is '已完成上传的会话数';
+comment on column catalog.upload_usage.reconciled_at     is '最近一次与 catalog.uploads 对账的时间';
+comment on column catalog.upload_usage.updated_at        is '最近更新时间';
+
+create index if not exists uploads_user_created_idx
+  on catalog.uploads (user_id, created_at desc);
+
+comment on index catalog.uploads_user_created_idx is '按用户统计当日会话数与并发 uploading 会话';
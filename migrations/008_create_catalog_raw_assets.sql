@@ -0,0 +1,67 @@
+-- ============================================
+-- 8) 原始资产表：catalog.raw_assets（按 SHA-256 去重，一份文件可支撑多条视频）
+-- ============================================
+create table if not exists catalog.raw_assets (
+  asset_id       uuid primary key default gen_random_uuid(),
+  content_sha256 char(64) not null,
+  content_md5    char(32) not null,
+  size_bytes     bigint not null default 0,
+  bucket         text not null,
+  object_name    text not null,
+  content_type   text,
+  ref_count      bigint not null default 0 check (ref_count >= 0),
+  created_by     uuid not null,
+  created_at     timestamptz not null default now(),
+  updated_at     timestamptz not null default now()
+);
+
+comment on table catalog.raw_assets is '原始上传资产：以内容 SHA-256 唯一，记录规范对象位置与引用计数';
+
+comment on column catalog.raw_assets.asset_id       is '资产主键';
+comment on column catalog.raw_assets.content_sha256 is '客户端上报的内容 SHA-256（hex 64），全局唯一';
+comment on column catalog.raw_assets.content_md5    is '首次落盘时 GCS 校验通过的 MD5（hex 32），用于复用时核对客户端声明';
+comment on column catalog.raw_assets.size_bytes     is '对象实际大小（字节）';
+comment on column catalog.raw_assets.bucket         is '规范对象所在 GCS 存储桶';
+comment on column catalog.raw_assets.object_name    is '规范对象路径；复用该资产的视频均引用此对象';
+comment on column catalog.raw_assets.content_type   is '魔数嗅探得到的 MIME 类型';
+comment on column catalog.raw_assets.ref_count      is '引用该资产的视频数量';
+comment on column catalog.raw_assets.created_by     is '首次上传该内容的用户 ID';
+comment on column catalog.raw_assets.created_at     is '记录创建时间';
+comment on column catalog.raw_assets.updated_at     is '最近更新时间；触发器自动维护';
+
+create unique index if not exists raw_assets_sha256_unique
+  on catalog.raw_assets (content_sha256);
+
+comment on index catalog.raw_assets_sha256_unique is '同一内容（SHA-256）只保留一份资产';
+
+do $$
+begin
+  if not exists (
+    select 1 from pg_trigger where tgname = 'set_updated_at_on_raw_assets'
+  ) then
+    create trigger set_updated_at_on_raw_assets
+      before update on catalog.raw_assets
+      for each row execute function catalog.tg_set_updated_at();
+  end if;
+end$$;
+
+comment on trigger set_updated_at_on_raw_assets on catalog.raw_assets
+  is '更新 catalog.raw_assets 任意列时自动刷新 updated_at';
+
+alter table catalog.uploads
+  add column if not exists content_sha256 char(64),
+  add column if not exists asset_id uuid;
+
+comment on column catalog.uploads.content_sha256 is '客户端可选上报的内容 SHA-256（hex 64）；用于 raw_assets 去重';
+comment on column catalog.uploads.asset_id       is '上传完成后关联的 catalog.raw_assets.asset_id';
+
+alter table catalog.videos
+  add column if not exists asset_id uuid;
+
+comment on column catalog.videos.asset_id is '视频引用的原始资产（catalog.raw_assets.asset_id），多条视频可共享同一资产';
+
+create index if not exists videos_asset_idx
+  on catalog.videos (asset_id)
+  where asset_id is not null;
+
+comment on index catalog.videos_asset_idx is '按资产反查引用视频（引用计数核对/清理）';
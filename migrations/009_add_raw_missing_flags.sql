@@ -0,0 +1,20 @@
+-- ============================================
+-- 9) 原始对象缺失标记：catalog.uploads / catalog.videos.raw_missing
+-- ============================================
+alter table catalog.uploads
+  add column if not exists raw_missing boolean not null default false,
+  add column if not exists raw_missing_at timestamptz;
+
+comment on column catalog.uploads.raw_missing    is '原始对象已被删除/归档（GCS OBJECT_DELETE/OBJECT_ARCHIVE），raw_file_reference 不再可读';
+comment on column catalog.uploads.raw_missing_at is '最近一次检测到原始对象缺失的时间';
+
+alter table catalog.videos
+  add column if not exists raw_missing boolean not null default false;
+
+comment on column catalog.videos.raw_missing is '原始对象缺失标记；为 true 时重处理会失败，需要用户重传或运维恢复';
+
+create index if not exists videos_raw_missing_idx
+  on catalog.videos (updated_at desc)
+  where raw_missing;
+
+comment on index catalog.videos_raw_missing_idx is '后台巡检原始对象缺失的视频';
@@ -0,0 +1,33 @@
+-- ============================================
+-- 10) Engagement 重放检查点：catalog.engagement_replay_checkpoints
+-- ============================================
+create table if not exists catalog.engagement_replay_checkpoints (
+  replay_id        text primary key,
+  scope_user_id    uuid,
+  scope_video_id   uuid,
+  last_occurred_at timestamptz,
+  last_event_id    uuid,
+  applied_count    bigint not null default 0 check (applied_count >= 0),
+  skipped_count    bigint not null default 0 check (skipped_count >= 0),
+  started_at       timestamptz not null default now(),
+  updated_at       timestamptz not null default now(),
+  completed_at     timestamptz
+);
+
+comment on table catalog.engagement_replay_checkpoints is 'Engagement 投影重放进度：按 (occurred_at, event_id) 记录最后一条已重放的 Inbox 事件，支持中断后续跑';
+
+comment on column catalog.engagement_replay_checkpoints.replay_id        is '重放任务标识（CLI -id），同一标识可断点续跑';
+comment on column catalog.engagement_replay_checkpoints.scope_user_id    is '限定重放的用户；NULL 表示不限';
+comment on column catalog.engagement_replay_checkpoints.scope_video_id   is '限定重放的视频；NULL 表示不限';
+comment on column catalog.engagement_replay_checkpoints.last_occurred_at is '最后一条已重放事件的发生时间';
+comment on column catalog.engagement_replay_checkpoints.last_event_id    is '最后一条已重放事件的 event_id（同一时间戳内的排序键）';
+comment on column catalog.engagement_replay_checkpoints.applied_count    is '已重放事件数';
+comment on column catalog.engagement_replay_checkpoints.skipped_count    is '重放时被处理器判定为非法而跳过的事件数';
+comment on column catalog.engagement_replay_checkpoints.started_at       is '重放开始（投影清空）时间';
+comment on column catalog.engagement_replay_checkpoints.updated_at       is '最近一次推进检查点的时间';
+comment on column catalog.engagement_replay_checkpoints.completed_at     is '重放完成时间；NULL 表示仍可续跑';
+
+create index if not exists inbox_events_type_received_idx
+  on catalog.inbox_events (event_type, received_at, event_id);
+
+comment on index catalog.inbox_events_type_received_idx is '按事件类型键集扫描 Inbox 历史（Engagement 重放）';
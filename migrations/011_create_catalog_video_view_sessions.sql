@@ -0,0 +1,23 @@
+-- ============================================
+-- 11) 有效播放会话：catalog.video_view_sessions
+-- ============================================
+-- watch_count 仅统计满足观看时长/比例阈值的有效播放，且同一用户在一个会话窗口内至多计一次。
+create table if not exists catalog.video_view_sessions (
+  video_id               uuid not null,
+  user_id                uuid not null,
+  session_started_at     timestamptz not null,
+  last_progress_at       timestamptz not null,
+  baseline_watch_seconds double precision not null default 0 check (baseline_watch_seconds >= 0),
+  last_watch_seconds     double precision not null default 0 check (last_watch_seconds >= 0),
+  counted_at             timestamptz,
+  updated_at             timestamptz not null default now(),
+  primary key (video_id, user_id)
+);
+
+comment on table catalog.video_view_sessions is 'Engagement 投影内部状态：每个用户-视频当前播放会话，用于判定有效播放并按会话窗口去重';
+
+comment on column catalog.video_view_sessions.session_started_at     is '当前会话开始时间（会话内首条进度事件的观看时间）';
+comment on column catalog.video_view_sessions.last_progress_at       is '当前会话最近一条进度事件的观看时间';
+comment on column catalog.video_view_sessions.baseline_watch_seconds is '会话开始前已累计的观看秒数，会话内观看时长 = last_watch_seconds - baseline';
+comment on column catalog.video_view_sessions.last_watch_seconds     is '最近上报的累计观看秒数（total_watch_seconds）';
+comment on column catalog.video_view_sessions.counted_at             is '本会话计入 watch_count 的时间；NULL 表示尚未达到有效播放阈值';
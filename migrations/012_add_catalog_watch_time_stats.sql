@@ -0,0 +1,18 @@
+-- ============================================
+-- 12) 观看时长与完播率：video_view_sessions / video_engagement_stats_projection
+-- ============================================
+-- 每个用户-视频保留历史最大播放位置；视频维度累计观看秒数与各用户最大播放位置之和，
+-- 完播率 = position_sum_seconds / (position_viewers × 视频时长)，在读取时结合 duration_micros 计算。
+alter table catalog.video_view_sessions
+  add column if not exists max_position_seconds double precision not null default 0 check (max_position_seconds >= 0);
+
+comment on column catalog.video_view_sessions.max_position_seconds is '该用户在此视频上到达过的最大播放位置（秒），跨会话保留';
+
+alter table catalog.video_engagement_stats_projection
+  add column if not exists total_watch_seconds double precision not null default 0 check (total_watch_seconds >= 0),
+  add column if not exists position_sum_seconds double precision not null default 0 check (position_sum_seconds >= 0),
+  add column if not exists position_viewers bigint not null default 0 check (position_viewers >= 0);
+
+comment on column catalog.video_engagement_stats_projection.total_watch_seconds  is '累计观看秒数（各用户 total_watch_seconds 增量之和，不区分是否为有效播放）';
+comment on column catalog.video_engagement_stats_projection.position_sum_seconds is '各用户最大播放位置之和（秒），用于计算完播率';
+comment on column catalog.video_engagement_stats_projection.position_viewers     is '上报过播放进度的用户数，完播率的分母人数';
@@ -0,0 +1,13 @@
+-- ============================================
+-- 13) 继续观看：video_view_sessions.last_position_seconds
+-- ============================================
+-- 每个用户-视频记录最近一次上报的播放位置，用于 GetVideoDetail 的 resume_position_micros 与 ListMyWatchHistory。
+alter table catalog.video_view_sessions
+  add column if not exists last_position_seconds double precision not null default 0 check (last_position_seconds >= 0);
+
+comment on column catalog.video_view_sessions.last_position_seconds is '最近一条进度事件（按观看时间）上报的播放位置（秒），用于继续观看';
+
+create index if not exists video_view_sessions_user_recent_idx
+  on catalog.video_view_sessions (user_id, last_progress_at desc, video_id desc);
+
+comment on index catalog.video_view_sessions_user_recent_idx is '按用户倒序列出观看历史（ListMyWatchHistory 键集分页）';
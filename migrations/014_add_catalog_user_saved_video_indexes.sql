@@ -0,0 +1,14 @@
+-- ============================================
+-- 14) 我的点赞 / 我的收藏：video_user_engagements_projection 用户维度索引
+-- ============================================
+-- ListMyLikedVideos / ListMyBookmarkedVideos 按 (occurred_at DESC, video_id DESC) 键集分页。
+create index if not exists video_user_engagements_projection_user_liked_idx
+  on catalog.video_user_engagements_projection (user_id, liked_occurred_at desc, video_id desc)
+  where has_liked;
+
+create index if not exists video_user_engagements_projection_user_bookmarked_idx
+  on catalog.video_user_engagements_projection (user_id, bookmarked_occurred_at desc, video_id desc)
+  where has_bookmarked;
+
+comment on index catalog.video_user_engagements_projection_user_liked_idx      is '按用户倒序列出点赞视频（ListMyLikedVideos）';
+comment on index catalog.video_user_engagements_projection_user_bookmarked_idx is '按用户倒序列出收藏视频（ListMyBookmarkedVideos）';
@@ -0,0 +1,52 @@
+-- ============================================
+-- 15) 分时统计：catalog.video_engagement_stats_hourly / catalog.video_engagement_stats_daily
+-- ============================================
+-- video_engagement_stats_projection 只保存累计值；创作者分析需要按小时/按天的时间序列。
+-- Engagement Runner 在更新累计值的同一事务内按事件发生时间（UTC）写入对应桶，保留期由 engagement.rollups 配置控制。
+create table if not exists catalog.video_engagement_stats_hourly (
+  video_id        uuid not null,
+  bucket_start    timestamptz not null,
+  like_delta      bigint not null default 0,
+  bookmark_delta  bigint not null default 0,
+  watch_count     bigint not null default 0 check (watch_count >= 0),
+  unique_watchers bigint not null default 0 check (unique_watchers >= 0),
+  updated_at      timestamptz not null default now(),
+  primary key (video_id, bucket_start)
+);
+
+comment on table catalog.video_engagement_stats_hourly is 'Engagement 投影的小时级统计桶，bucket_start 为 UTC 整点';
+
+comment on column catalog.video_engagement_stats_hourly.bucket_start    is '桶起点（UTC 整点，含）';
+comment on column catalog.video_engagement_stats_hourly.like_delta      is '桶内点赞净增量（新增 - 取消，可为负）';
+comment on column catalog.video_engagement_stats_hourly.bookmark_delta  is '桶内收藏净增量（新增 - 取消，可为负）';
+comment on column catalog.video_engagement_stats_hourly.watch_count     is '桶内有效播放次数';
+comment on column catalog.video_engagement_stats_hourly.unique_watchers is '桶内新增唯一观看用户数（用户首次有效播放所在的桶）';
+
+create index if not exists video_engagement_stats_hourly_bucket_idx
+  on catalog.video_engagement_stats_hourly (bucket_start);
+
+comment on index catalog.video_engagement_stats_hourly_bucket_idx is '按保留期清理过期小时桶';
+
+create table if not exists catalog.video_engagement_stats_daily (
+  video_id        uuid not null,
+  bucket_start    timestamptz not null,
+  like_delta      bigint not null default 0,
+  bookmark_delta  bigint not null default 0,
+  watch_count     bigint not null default 0 check (watch_count >= 0),
+  unique_watchers bigint not null default 0 check (unique_watchers >= 0),
+  updated_at      timestamptz not null default now(),
+  primary key (video_id, bucket_start)
+);
+
+comment on table catalog.video_engagement_stats_daily is 'Engagement 投影的天级统计桶，bucket_start 为 UTC 零点；保留期长于小时桶';
+
+comment on column catalog.video_engagement_stats_daily.bucket_start    is '桶起点（UTC 零点，含）';
+comment on column catalog.video_engagement_stats_daily.like_delta      is '桶内点赞净增量（新增 - 取消，可为负）';
+comment on column catalog.video_engagement_stats_daily.bookmark_delta  is '桶内收藏净增量（新增 - 取消，可为负）';
+comment on column catalog.video_engagement_stats_daily.watch_count     is '桶内有效播放次数';
+comment on column catalog.video_engagement_stats_daily.unique_watchers is '桶内新增唯一观看用户数（用户首次有效播放所在的桶）';
+
+create index if not exists video_engagement_stats_daily_bucket_idx
+  on catalog.video_engagement_stats_daily (bucket_start);
+
+comment on index catalog.video_engagement_stats_daily_bucket_idx is '按保留期清理过期天级桶';
@@ -0,0 +1,41 @@
+-- ============================================
+-- 16) 热度榜：catalog.video_trending_snapshots / catalog.video_trending_scores
+-- ============================================
+-- 首页“热门”栏目按近期互动排序；Engagement Runner 按 engagement.trending.interval 周期性地从小时统计桶计算时间衰减热度，
+-- 每次计算生成一个新快照并在同一事务内写入全部得分。ListTrendingVideos 的分页游标绑定快照 ID，
+-- 翻页期间快照刷新不影响后续页；旧快照保留 engagement.trending.snapshot_ttl 后连同得分一起删除。
+create table if not exists catalog.video_trending_snapshots (
+  snapshot_id       bigint generated always as identity primary key,
+  computed_at       timestamptz not null,
+  window_start      timestamptz not null,
+  half_life_seconds double precision not null check (half_life_seconds > 0)
+);
+
+comment on table catalog.video_trending_snapshots is '热度快照，snapshot_id 最大者为当前快照';
+
+comment on column catalog.video_trending_snapshots.computed_at       is '计算时间，也是衰减的参考时间';
+comment on column catalog.video_trending_snapshots.window_start      is '参与计算的小时桶起点下界（含）';
+comment on column catalog.video_trending_snapshots.half_life_seconds is '热度半衰期（秒）';
+
+create index if not exists video_trending_snapshots_computed_at_idx
+  on catalog.video_trending_snapshots (computed_at);
+
+comment on index catalog.video_trending_snapshots_computed_at_idx is '按保留期清理过期快照';
+
+create table if not exists catalog.video_trending_scores (
+  snapshot_id bigint not null references catalog.video_trending_snapshots (snapshot_id) on delete cascade,
+  video_id    uuid not null,
+  rank        integer not null check (rank > 0),
+  score       double precision not null,
+  like_delta  bigint not null default 0,
+  watch_count bigint not null default 0,
+  primary key (snapshot_id, rank),
+  unique (snapshot_id, video_id)
+);
+
+comment on table catalog.video_trending_scores is '热度快照内各视频的得分与名次，仅包含已发布的公开视频';
+
+comment on column catalog.video_trending_scores.rank        is '快照内名次（从 1 开始，按 score DESC, video_id 排序），分页游标';
+comment on column catalog.video_trending_scores.score       is '时间衰减热度：各小时桶 (like_weight * like_delta + view_weight * watch_count) * 0.5^(桶龄/半衰期) 之和';
+comment on column catalog.video_trending_scores.like_delta  is '窗口内点赞净增量';
+comment on column catalog.video_trending_scores.watch_count is '窗口内有效播放次数';
@@ -0,0 +1,40 @@
+-- ============================================
+-- 17) Engagement 清理：catalog.engagement_purges
+-- ============================================
+-- Engagement 投影与 catalog.videos 之间没有外键：视频删除/归档、Profile 删除用户后，相关投影行会一直残留。
+-- Engagement Runner 消费 catalog.video.deleted / catalog.video.updated(status=archived) 与 profile.user.deleted，
+-- 在 Inbox 事务内登记清理请求；后台清理循环按 engagement.purge.batch_size 分批删除，每批一个事务，全部删完后标记完成。
+-- 清理请求同时充当墓碑：requested_at 之前发生、但晚到（或重放）的 Profile 事件不再写回已清理的投影。
+create table if not exists catalog.engagement_purges (
+  scope           text not null check (scope in ('video', 'user')),
+  subject_id      uuid not null,
+  reason          text not null,
+  requested_at    timestamptz not null,
+  source_event_id uuid,
+  rows_removed    bigint not null default 0 check (rows_removed >= 0),
+  created_at      timestamptz not null default now(),
+  updated_at      timestamptz not null default now(),
+  completed_at    timestamptz,
+  primary key (scope, subject_id)
+);
+
+comment on table catalog.engagement_purges is 'Engagement 投影清理请求与墓碑，按 (scope, subject_id) 去重';
+
+comment on column catalog.engagement_purges.scope           is '清理范围：video 清理该视频的全部投影；user 清理该用户的逐用户投影';
+comment on column catalog.engagement_purges.subject_id      is 'scope=video 时为 video_id，scope=user 时为 user_id';
+comment on column catalog.engagement_purges.reason          is '触发原因：video.deleted / video.archived / user.deleted';
+comment on column catalog.engagement_purges.requested_at    is '触发事件的发生时间；早于（含）该时间的 Engagement 事件不再投影';
+comment on column catalog.engagement_purges.source_event_id is '最近一次触发清理的事件 ID';
+comment on column catalog.engagement_purges.rows_removed    is '累计删除的投影行数';
+comment on column catalog.engagement_purges.completed_at    is '清理完成时间；NULL 表示仍待清理';
+
+create index if not exists engagement_purges_pending_idx
+  on catalog.engagement_purges (created_at)
+  where completed_at is null;
+
+comment on index catalog.engagement_purges_pending_idx is '后台清理循环扫描待处理请求';
+
+create index if not exists video_engagement_watchers_user_idx
+  on catalog.video_engagement_watchers (user_id);
+
+comment on index catalog.video_engagement_watchers_user_idx is '按用户清理唯一观看记录';
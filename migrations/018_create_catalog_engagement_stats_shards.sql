@@ -0,0 +1,53 @@
+-- ============================================
+-- 18) 分片计数：catalog.video_engagement_stats_shards
+-- ============================================
+-- 热门视频的每个点赞/播放事件都会 upsert video_engagement_stats_projection 的同一行，Inbox 并发处理时在行锁上排队。
+-- engagement.counters.shards > 1 时，累计增量改为写入按分片键（用户 ID）哈希选中的分片行，读取时累加主行与全部分片；
+-- 后台压实循环按 engagement.counters.compact_interval 将分片行折叠回主行并删除。
+-- 分时统计桶同理增加 shard 列：未分片模式始终写入 shard = 0，读取方按 bucket_start 汇总。
+create table if not exists catalog.video_engagement_stats_shards (
+  video_id               uuid not null,
+  shard                  smallint not null check (shard >= 0),
+  like_delta             bigint not null default 0,
+  bookmark_delta         bigint not null default 0,
+  watch_delta            bigint not null default 0,
+  unique_watcher_delta   bigint not null default 0,
+  watch_seconds_delta    double precision not null default 0,
+  position_seconds_delta double precision not null default 0,
+  position_viewer_delta  bigint not null default 0,
+  first_watch_at         timestamptz,
+  last_watch_at          timestamptz,
+  updated_at             timestamptz not null default now(),
+  primary key (video_id, shard)
+);
+
+comment on table catalog.video_engagement_stats_shards is '尚未压实进 video_engagement_stats_projection 的分片计数增量';
+
+comment on column catalog.video_engagement_stats_shards.shard          is '分片编号，取值 [0, engagement.counters.shards)';
+comment on column catalog.video_engagement_stats_shards.like_delta     is '点赞净增量（可为负，读取与压实时与主行相加后不低于 0）';
+comment on column catalog.video_engagement_stats_shards.bookmark_delta is '收藏净增量（可为负）';
+comment on column catalog.video_engagement_stats_shards.first_watch_at is '分片内最早一次有效播放时间';
+comment on column catalog.video_engagement_stats_shards.last_watch_at  is '分片内最近一次有效播放时间';
+
+create index if not exists video_engagement_stats_shards_updated_idx
+  on catalog.video_engagement_stats_shards (updated_at);
+
+comment on index catalog.video_engagement_stats_shards_updated_idx is '压实循环按写入先后取出分片行';
+
+alter table catalog.video_engagement_stats_hourly
+  add column if not exists shard smallint not null default 0 check (shard >= 0);
+
+alter table catalog.video_engagement_stats_hourly
+  drop constraint if exists video_engagement_stats_hourly_pkey,
+  add primary key (video_id, bucket_start, shard);
+
+comment on column catalog.video_engagement_stats_hourly.shard is '分片编号；未启用分片计数时为 0，读取时按 bucket_start 汇总';
+
+alter table catalog.video_engagement_stats_daily
+  add column if not exists shard smallint not null default 0 check (shard >= 0);
+
+alter table catalog.video_engagement_stats_daily
+  drop constraint if exists video_engagement_stats_daily_pkey,
+  add primary key (video_id, bucket_start, shard);
+
+comment on column catalog.video_engagement_stats_daily.shard is '分片编号；未启用分片计数时为 0，读取时按 bucket_start 汇总';
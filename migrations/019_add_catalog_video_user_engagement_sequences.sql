@@ -0,0 +1,12 @@
+-- ============================================
+-- 19) 互动事件序号：catalog.video_user_engagements_projection
+-- ============================================
+-- 仅比较 occurred_at 时，同一毫秒内的两次切换或 Profile 副本间的时钟偏差会让最新状态被当作过期事件丢弃。
+-- Profile 为每个 (user, video, favorite_type) 分配单调递增的 sequence；投影记录最近一次应用的序号，
+-- 双方都带序号时按序号排序，否则退回比较 occurred_at。
+alter table catalog.video_user_engagements_projection
+  add column if not exists liked_sequence bigint,
+  add column if not exists bookmarked_sequence bigint;
+
+comment on column catalog.video_user_engagements_projection.liked_sequence      is '最近一次应用的点赞事件序号；事件未携带序号时为空';
+comment on column catalog.video_user_engagements_projection.bookmarked_sequence is '最近一次应用的收藏事件序号；事件未携带序号时为空';
@@ -0,0 +1,38 @@
+-- ============================================
+-- 20) 分享与星级评分：catalog.video_engagement_stats_projection / video_user_engagements_projection
+-- ============================================
+-- Profile 新增 share 与 rating 两种互动：分享按事件次数累加；评分为每个用户对视频的 1–5 星，可修改或撤销。
+-- 统计投影记录分享次数、评分人数、星级总和与各星级人数（平均分 = rating_sum / rating_count）；
+-- 用户投影记录用户自己的评分及其事件时间与序号，排序规则与点赞/收藏一致。
+alter table catalog.video_engagement_stats_projection
+  add column if not exists share_count    bigint not null default 0 check (share_count >= 0),
+  add column if not exists rating_count   bigint not null default 0 check (rating_count >= 0),
+  add column if not exists rating_sum     bigint not null default 0 check (rating_sum >= 0),
+  add column if not exists rating_1_count bigint not null default 0 check (rating_1_count >= 0),
+  add column if not exists rating_2_count bigint not null default 0 check (rating_2_count >= 0),
+  add column if not exists rating_3_count bigint not null default 0 check (rating_3_count >= 0),
+  add column if not exists rating_4_count bigint not null default 0 check (rating_4_count >= 0),
+  add column if not exists rating_5_count bigint not null default 0 check (rating_5_count >= 0);
+
+comment on column catalog.video_engagement_stats_projection.share_count  is '分享次数（每条 share 事件计 1 次）';
+comment on column catalog.video_engagement_stats_projection.rating_count is '当前持有评分的用户数';
+comment on column catalog.video_engagement_stats_projection.rating_sum   is '当前评分星级总和，平均分 = rating_sum / rating_count';
+
+alter table catalog.video_engagement_stats_shards
+  add column if not exists share_delta        bigint not null default 0,
+  add column if not exists rating_count_delta bigint not null default 0,
+  add column if not exists rating_sum_delta   bigint not null default 0,
+  add column if not exists rating_1_delta     bigint not null default 0,
+  add column if not exists rating_2_delta     bigint not null default 0,
+  add column if not exists rating_3_delta     bigint not null default 0,
+  add column if not exists rating_4_delta     bigint not null default 0,
+  add column if not exists rating_5_delta     bigint not null default 0;
+
+alter table catalog.video_user_engagements_projection
+  add column if not exists rating            integer check (rating between 1 and 5),
+  add column if not exists rated_occurred_at timestamptz,
+  add column if not exists rated_sequence    bigint;
+
+comment on column catalog.video_user_engagements_projection.rating            is '用户对视频的星级评分（1–5），未评分或已撤销时为空';
+comment on column catalog.video_user_engagements_projection.rated_occurred_at is '最近一次评分/撤销评分事件时间';
+comment on column catalog.video_user_engagements_projection.rated_sequence    is '最近一次应用的评分事件序号；事件未携带序号时为空';
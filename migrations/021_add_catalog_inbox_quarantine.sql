@@ -0,0 +1,35 @@
+-- ============================================
+-- 21) Inbox 毒消息隔离：catalog.inbox_quarantine
+-- ============================================
+-- Inbox 消费者遇到永久性错误（负载无法解析、字段非法等）时，不再让事件无限 nack 重试，
+-- 而是把事件快照与错误分类登记到隔离表并确认消息；运维可通过 quarantine 子命令查看、重放或丢弃。
+-- 隔离表保留完整负载快照，不依赖 inbox_events 的保留策略，因此不设外键。
+create table if not exists catalog.inbox_quarantine (
+  event_id         uuid primary key,                       -- 来源事件唯一标识（同 inbox_events.event_id）
+  consumer         text not null,                          -- 隔离该事件的消费者，例如 engagement、uploads
+  source_service   text not null,                          -- 事件来源服务
+  event_type       text not null,                          -- 事件名
+  aggregate_type   text,                                   -- 来源聚合根类型
+  aggregate_id     text,                                   -- 来源聚合根主键
+  payload          bytea not null,                         -- 原始事件载荷快照
+  received_at      timestamptz not null,                   -- Inbox 收到事件时间
+  error_class      text not null,                          -- 错误分类（如 invalid-user-id、invalid-payload）
+  last_error       text not null,                          -- 最近一次失败信息（隔离或重放失败）
+  quarantined_at   timestamptz not null default now(),     -- 隔离时间
+  replay_attempts  integer not null default 0,             -- 人工重放失败次数
+  resolved_at      timestamptz,                            -- 处置时间，NULL 表示待处置
+  resolution       text check (resolution in ('replayed', 'discarded')),
+  resolved_note    text                                    -- 处置备注（丢弃原因等）
+);
+
+comment on table catalog.inbox_quarantine is 'Inbox 毒消息隔离表：记录因永久性错误被跳过的事件，供人工重放或丢弃';
+comment on column catalog.inbox_quarantine.consumer        is '隔离该事件的消费者（engagement/uploads），决定重放时使用的处理器';
+comment on column catalog.inbox_quarantine.error_class     is '错误分类：kratos 错误的 reason 或处理器显式标注的永久错误类别';
+comment on column catalog.inbox_quarantine.replay_attempts is '人工重放失败次数';
+comment on column catalog.inbox_quarantine.resolution      is '处置结果：replayed 重放成功，discarded 人工丢弃';
+
+create index if not exists inbox_quarantine_pending_idx
+  on catalog.inbox_quarantine (consumer, quarantined_at, event_id)
+  where resolved_at is null;
+
+comment on index catalog.inbox_quarantine_pending_idx is '按消费者列出待处置的隔离事件';
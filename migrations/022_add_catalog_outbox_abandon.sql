@@ -0,0 +1,18 @@
+-- ============================================
+-- 22) Outbox 运维处置：catalog.outbox_events 放弃标记
+-- ============================================
+-- 运维可通过 catalogctl outbox / CatalogOutboxAdminService 查看卡住的事件、强制重试或放弃。
+-- 放弃的事件保留在表中以便审计：abandoned_at 记录放弃时间，available_at 置为 'infinity'，
+-- 发布器按 available_at <= now() 认领时自然跳过，无需修改共享的 Outbox 发布实现。
+alter table catalog.outbox_events
+  add column if not exists abandoned_at     timestamptz,  -- 人工放弃时间，NULL 表示仍待发布
+  add column if not exists abandoned_reason text;         -- 放弃原因
+
+comment on column catalog.outbox_events.abandoned_at     is '人工放弃时间；放弃后 available_at 置为 infinity，发布器不再认领';
+comment on column catalog.outbox_events.abandoned_reason is '人工放弃原因，供审计';
+
+create index if not exists outbox_events_abandoned_idx
+  on catalog.outbox_events (abandoned_at)
+  where abandoned_at is not null;
+
+comment on index catalog.outbox_events_abandoned_idx is '列出人工放弃的 Outbox 事件';
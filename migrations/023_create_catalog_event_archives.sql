@@ -0,0 +1,49 @@
+-- ============================================
+-- 23) Outbox/Inbox 保留与归档：catalog.outbox_events_archive / catalog.inbox_events_archive
+-- ============================================
+-- Retention Runner（cmd/tasks/retention）分批删除发布超过保留期的 Outbox 行与处理完成超过保留期的 Inbox 行；
+-- 开启 messaging.retention.archive 时，被删除的行在同一语句中移入下列归档表。
+-- 归档表不设外键与默认值，列与源表保持一致，另加 archived_at。
+create table if not exists catalog.outbox_events_archive (
+  event_id            uuid primary key,     -- 事件唯一标识
+  aggregate_type      text not null,        -- 聚合根类型
+  aggregate_id        uuid not null,        -- 聚合根主键
+  event_type          text not null,        -- 领域事件名
+  payload             bytea not null,       -- 事件负载
+  headers             jsonb not null,       -- 追踪/幂等等头信息
+  occurred_at         timestamptz not null, -- 事件产生时间
+  available_at        timestamptz not null, -- 可发布时间
+  published_at        timestamptz not null, -- 发布成功时间
+  delivery_attempts   integer not null,     -- 投递尝试次数
+  last_error          text,                 -- 最近一次失败原因
+  abandoned_at        timestamptz,          -- 人工放弃时间
+  abandoned_reason    text,                 -- 放弃原因
+  archived_at         timestamptz not null default now() -- 归档时间
+);
+
+comment on table catalog.outbox_events_archive is 'Outbox 归档表：保存超过保留期后从 outbox_events 移出的已发布事件';
+comment on column catalog.outbox_events_archive.archived_at is '行从 outbox_events 移入归档表的时间';
+
+create index if not exists outbox_events_archive_archived_idx
+  on catalog.outbox_events_archive (archived_at);
+comment on index catalog.outbox_events_archive_archived_idx is '按归档时间清理或导出归档表';
+
+create table if not exists catalog.inbox_events_archive (
+  event_id         uuid primary key,       -- 来源事件唯一标识
+  source_service   text not null,          -- 事件来源服务
+  event_type       text not null,          -- 事件名
+  aggregate_type   text,                   -- 来源聚合根类型
+  aggregate_id     text,                   -- 来源聚合根主键
+  payload          bytea not null,         -- 原始事件载荷快照
+  received_at      timestamptz not null,   -- 收到事件时间
+  processed_at     timestamptz not null,   -- 处理完成时间
+  last_error       text,                   -- 最近一次处理失败信息
+  archived_at      timestamptz not null default now() -- 归档时间
+);
+
+comment on table catalog.inbox_events_archive is 'Inbox 归档表：保存超过保留期后从 inbox_events 移出的已处理事件';
+comment on column catalog.inbox_events_archive.archived_at is '行从 inbox_events 移入归档表的时间';
+
+create index if not exists inbox_events_archive_archived_idx
+  on catalog.inbox_events_archive (archived_at);
+comment on index catalog.inbox_events_archive_archived_idx is '按归档时间清理或导出归档表';
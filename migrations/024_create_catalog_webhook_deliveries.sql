@@ -0,0 +1,30 @@
+-- ============================================
+-- 24) Webhook 投递日志：catalog.webhook_deliveries
+-- ============================================
+-- messaging.topics.default.sink 为 webhook 时，Outbox Runner 把每个事件投递到全部配置的端点，
+-- 每次 HTTP 尝试（无论成败）写入一行；同一事件重新认领时跳过已有成功记录的端点，避免重复投递。
+-- 日志只追加不更新，attempt 为该事件在该端点上的累计尝试序号。
+create table if not exists catalog.webhook_deliveries (
+  id             bigserial primary key,
+  event_id       uuid not null,                       -- Outbox 事件唯一标识（不设外键，Outbox 行可能先被清理）
+  endpoint       text not null,                       -- 端点名（messaging.topics.*.webhook.endpoints[].name）
+  event_type     text not null,                       -- 领域事件名
+  attempt        integer not null,                    -- 该事件在该端点上的累计尝试序号，从 1 开始
+  status_code    integer,                             -- HTTP 状态码，请求未得到响应时为空
+  error          text,                                -- 失败原因，成功时为空
+  duration_ms    integer not null,                    -- 请求耗时（毫秒）
+  succeeded      boolean not null,                    -- 是否投递成功（2xx）
+  attempted_at   timestamptz not null default now()   -- 尝试时间
+);
+
+comment on table catalog.webhook_deliveries is 'Webhook 投递日志：记录 Outbox 事件到各 Webhook 端点的每次投递尝试';
+comment on column catalog.webhook_deliveries.attempt   is '该事件在该端点上的累计尝试序号，跨 Outbox 重新认领递增';
+comment on column catalog.webhook_deliveries.succeeded is '端点返回 2xx 视为成功，之后重新认领该事件时跳过该端点';
+
+create index if not exists webhook_deliveries_event_idx
+  on catalog.webhook_deliveries (event_id, endpoint);
+comment on index catalog.webhook_deliveries_event_idx is '按事件查询投递历史与已成功端点';
+
+create index if not exists webhook_deliveries_attempted_idx
+  on catalog.webhook_deliveries (attempted_at);
+comment on index catalog.webhook_deliveries_attempted_idx is '按尝试时间排查投递失败或清理历史';
@@ -0,0 +1,30 @@
+-- ============================================
+-- 25) 事件回填检查点：catalog.event_backfill_checkpoints
+-- ============================================
+-- outbox backfill 按 video_id 键集分页扫描 catalog.videos，为每个视频发布 catalog.video.snapshot 快照事件，
+-- 每页发布成功后推进检查点；中断后以相同 backfill_id 续跑，只会重发最后一个未完成页。
+create table if not exists catalog.event_backfill_checkpoints (
+  backfill_id       text primary key,
+  target            text not null,
+  scope_statuses    text[] not null default '{}',
+  scope_visibility  text,
+  scope_uploader_id uuid,
+  last_video_id     uuid,
+  published_count   bigint not null default 0 check (published_count >= 0),
+  started_at        timestamptz not null default now(),
+  updated_at        timestamptz not null default now(),
+  completed_at      timestamptz
+);
+
+comment on table catalog.event_backfill_checkpoints is '事件回填进度：按 video_id 记录最后一个已发布快照的视频，支持中断后续跑';
+
+comment on column catalog.event_backfill_checkpoints.backfill_id       is '回填任务标识（CLI -id），同时作为快照事件的 backfill_id 属性';
+comment on column catalog.event_backfill_checkpoints.target            is '发布目标（messaging.topics 下的 topic 键）';
+comment on column catalog.event_backfill_checkpoints.scope_statuses    is '限定回填的视频状态；空数组表示不限';
+comment on column catalog.event_backfill_checkpoints.scope_visibility  is '限定回填的可见性；NULL 表示不限';
+comment on column catalog.event_backfill_checkpoints.scope_uploader_id is '限定回填的上传者；NULL 表示不限';
+comment on column catalog.event_backfill_checkpoints.last_video_id     is '最后一个已发布快照的 video_id（键集游标）';
+comment on column catalog.event_backfill_checkpoints.published_count   is '已发布的快照事件数';
+comment on column catalog.event_backfill_checkpoints.started_at        is '回填开始时间，参与快照 event_id 的派生';
+comment on column catalog.event_backfill_checkpoints.updated_at        is '最近一次推进检查点的时间';
+comment on column catalog.event_backfill_checkpoints.completed_at      is '回填完成时间；NULL 表示仍可续跑';
@@ -0,0 +1,7 @@
+-- ============================================
+-- 26) 原始资产按对象位置查找：catalog.raw_assets(bucket, object_name)
+-- ============================================
+create index if not exists raw_assets_object_idx
+  on catalog.raw_assets (bucket, object_name);
+
+comment on index catalog.raw_assets_object_idx is 'OBJECT_DELETE/OBJECT_ARCHIVE 通知按对象位置判断是否命中规范资产';
@@ -0,0 +1,10 @@
+-- ============================================
+-- 27) Engagement 重放改为按 Inbox 接收顺序键集分页：记录 (received_at, event_id) 游标
+-- ============================================
+alter table catalog.engagement_replay_checkpoints
+  add column if not exists last_received_at timestamptz;
+
+comment on column catalog.engagement_replay_checkpoints.last_received_at is '最后一条已重放事件的 Inbox 接收时间，与 last_event_id 组成续跑游标';
+comment on column catalog.engagement_replay_checkpoints.last_occurred_at is '最后一条已重放事件的发生时间（仅用于观测）';
+comment on column catalog.engagement_replay_checkpoints.last_event_id    is '最后一条已重放事件的 event_id（同一接收时间内的排序键）';
+comment on table catalog.engagement_replay_checkpoints is 'Engagement 投影重放进度：按 (received_at, event_id) 记录最后一条已重放的 Inbox 事件，支持中断后续跑';
@@ -0,0 +1,34 @@
+-- ============================================
+-- 28) 完播率分母排除 012 之前的遗留会话：video_view_sessions.position_tracked
+-- ============================================
+-- 012 之前创建的会话 max_position_seconds 默认 0 且未计入 position_viewers，之后再上报进度时只累加位置不累加人数，
+-- 导致完播率偏高。新增 position_tracked 标记该会话是否已计入完播率分母：已上报过位置的会话视为已计入，
+-- 其余遗留会话在下一条进度事件时按新观看用户计入；按标记重算各视频的 position_sum_seconds / position_viewers，
+-- 并清空分片中尚未压实的位置增量，使主行与会话表一致。
+alter table catalog.video_view_sessions
+  add column if not exists position_tracked boolean not null default false;
+
+update catalog.video_view_sessions
+   set position_tracked = true
+ where max_position_seconds > 0;
+
+update catalog.video_engagement_stats_shards
+   set position_seconds_delta = 0,
+       position_viewer_delta = 0;
+
+update catalog.video_engagement_stats_projection p
+   set position_sum_seconds = coalesce(s.position_sum_seconds, 0),
+       position_viewers = coalesce(s.position_viewers, 0),
+       updated_at = now()
+  from catalog.video_engagement_stats_projection p2
+  left join (
+    select video_id,
+           sum(max_position_seconds) as position_sum_seconds,
+           count(*) as position_viewers
+      from catalog.video_view_sessions
+     where position_tracked
+     group by video_id
+  ) s on s.video_id = p2.video_id
+ where p.video_id = p2.video_id;
+
+comment on column catalog.video_view_sessions.position_tracked is '该用户是否已计入 video_engagement_stats_projection.position_viewers；012 之前的遗留会话为 false，下一条进度事件时计入';
@@ -0,0 +1,10 @@
+-- ============================================
+-- 29) 热度榜先过滤再截断：video_trending_snapshots.max_videos
+-- ============================================
+-- 原先计算快照时只写入前 max_videos 名，读取时再按难度/标签过滤，过滤后的榜单会少于预期甚至为空。
+-- 快照改为写入全部得分为正的视频，并记录计算时的 max_videos；读取时先按条件过滤，再在过滤结果中截取前 max_videos 名。
+-- 存量快照的 max_videos 记为 0（不截断），其内容本身已按旧逻辑截断。
+alter table catalog.video_trending_snapshots
+  add column if not exists max_videos integer not null default 0;
+
+comment on column catalog.video_trending_snapshots.max_videos is '过滤后榜单保留的最大视频数，0 表示不截断';
@@ -0,0 +1,10 @@
+-- ============================================
+-- 30) 已放弃 Outbox 行的保留期：outbox_events_archive.published_at 允许为空
+-- ============================================
+-- Retention Runner 按 messaging.retention.outbox_abandoned_retention 清理放弃后仍未发布的 Outbox 行；
+-- 开启归档时这些行同样移入 outbox_events_archive，其 published_at 为空，以 abandoned_at 标识放弃时间。
+alter table catalog.outbox_events_archive
+  alter column published_at drop not null;
+
+comment on table catalog.outbox_events_archive is 'Outbox 归档表：保存超过保留期后从 outbox_events 移出的已发布或已放弃事件';
+comment on column catalog.outbox_events_archive.published_at is '发布成功时间；已放弃且未发布的事件为空';
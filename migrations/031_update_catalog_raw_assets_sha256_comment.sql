@@ -0,0 +1,7 @@
+-- ============================================
+-- 31) 原始资产以服务端计算的 SHA-256 去重：更新 raw_assets / uploads 的列注释
+-- ============================================
+-- 客户端声明的 SHA-256 只决定是否尝试去重；回调在 Inbox 事务外流式计算对象实际摘要，
+-- 一致时才以该摘要查找或登记资产，声明与实际不符的上传不会挂到既有资产上。
+comment on column catalog.raw_assets.content_sha256 is '服务端读取规范对象计算的内容 SHA-256（hex 64），全局唯一';
+comment on column catalog.uploads.content_sha256    is '客户端可选上报的内容 SHA-256（hex 64）；回调校验与对象实际摘要一致后才用于 raw_assets 去重';
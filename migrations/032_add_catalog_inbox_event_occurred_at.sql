@@ -0,0 +1,19 @@
+-- ============================================
+-- 32) Engagement 重放改为按事件发生时间键集分页：Inbox 记录负载内的 occurred_at
+-- ============================================
+-- occurred_at 只存在于 Protobuf 负载中，由重放任务在扫描前解码回写；无法解码的事件以 received_at 兜底。
+alter table catalog.inbox_events
+  add column if not exists occurred_at timestamptz;
+
+comment on column catalog.inbox_events.occurred_at is '事件发生时间（由负载解码回写）；NULL 表示尚未被 Engagement 重放标注';
+
+create index if not exists inbox_events_type_occurred_idx
+  on catalog.inbox_events (event_type, occurred_at, event_id)
+  where occurred_at is not null;
+
+comment on index catalog.inbox_events_type_occurred_idx is '按事件类型与发生时间键集扫描 Inbox 历史（Engagement 重放）';
+
+comment on column catalog.engagement_replay_checkpoints.last_occurred_at is '最后一条已重放事件的发生时间，与 last_event_id 组成续跑游标';
+comment on column catalog.engagement_replay_checkpoints.last_event_id    is '最后一条已重放事件的 event_id（同一发生时间内的排序键）';
+comment on column catalog.engagement_replay_checkpoints.last_received_at is '已废弃：按接收顺序重放时的游标；非空的未完成检查点需以 -restart 重新执行';
+comment on table catalog.engagement_replay_checkpoints is 'Engagement 投影重放进度：按 (occurred_at, event_id) 记录最后一条已重放的 Inbox 事件，支持中断后续跑';
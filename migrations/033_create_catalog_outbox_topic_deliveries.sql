@@ -0,0 +1,20 @@
+-- ============================================
+-- 33) Outbox topic 投递记录：catalog.outbox_topic_deliveries
+-- ============================================
+-- 除 default 外还有 topic 配置了 outbox_event_types 时，Outbox Runner 把同一事件发布到全部匹配的 topic，
+-- 每个 topic 接收成功后写入一行；任一 topic 失败时事件整体退避重试，重新认领后跳过已有记录的 topic，
+-- 已接收的 topic 不会再次收到同一事件。
+create table if not exists catalog.outbox_topic_deliveries (
+  event_id      uuid not null,                       -- Outbox 事件唯一标识（不设外键，Outbox 行可能先被清理）
+  topic         text not null,                       -- messaging.topics 下的键
+  event_type    text not null,                       -- 领域事件名
+  delivered_at  timestamptz not null default now(),  -- topic 接收成功的时间
+  primary key (event_id, topic)
+);
+
+comment on table catalog.outbox_topic_deliveries is 'Outbox 多 topic 发布记录：事件已被哪些 topic 接收，重新认领时跳过这些 topic';
+comment on column catalog.outbox_topic_deliveries.topic is 'messaging.topics 下的键，例如 default、partners';
+
+create index if not exists outbox_topic_deliveries_delivered_idx
+  on catalog.outbox_topic_deliveries (delivered_at);
+comment on index catalog.outbox_topic_deliveries_delivered_idx is '按接收时间排查或清理历史';
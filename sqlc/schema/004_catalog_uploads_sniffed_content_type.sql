@@ -0,0 +1 @@
+ALTER TABLE catalog.uploads ADD COLUMN sniffed_content_type TEXT;
@@ -0,0 +1,9 @@
+CREATE TABLE catalog.upload_usage (
+  user_id UUID PRIMARY KEY,
+  stored_bytes BIGINT NOT NULL DEFAULT 0,
+  completed_uploads BIGINT NOT NULL DEFAULT 0,
+  reconciled_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX uploads_user_created_idx ON catalog.uploads (user_id, created_at DESC);
@@ -0,0 +1,22 @@
+CREATE TABLE catalog.raw_assets (
+  asset_id UUID PRIMARY KEY,
+  content_sha256 CHAR(64) NOT NULL,
+  content_md5 CHAR(32) NOT NULL,
+  size_bytes BIGINT NOT NULL DEFAULT 0,
+  bucket TEXT NOT NULL,
+  object_name TEXT NOT NULL,
+  content_type TEXT,
+  ref_count BIGINT NOT NULL DEFAULT 0,
+  created_by UUID NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX raw_assets_sha256_unique ON catalog.raw_assets (content_sha256);
+
+ALTER TABLE catalog.uploads ADD COLUMN content_sha256 CHAR(64);
+ALTER TABLE catalog.uploads ADD COLUMN asset_id UUID;
+
+ALTER TABLE catalog.videos ADD COLUMN asset_id UUID;
+
+CREATE INDEX videos_asset_idx ON catalog.videos (asset_id) WHERE asset_id IS NOT NULL;
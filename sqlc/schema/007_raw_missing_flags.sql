@@ -0,0 +1,6 @@
+ALTER TABLE catalog.uploads ADD COLUMN raw_missing BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE catalog.uploads ADD COLUMN raw_missing_at TIMESTAMPTZ;
+
+ALTER TABLE catalog.videos ADD COLUMN raw_missing BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX videos_raw_missing_idx ON catalog.videos (updated_at DESC) WHERE raw_missing;
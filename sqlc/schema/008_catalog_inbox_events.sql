@@ -0,0 +1,13 @@
+CREATE TABLE catalog.inbox_events (
+  event_id UUID PRIMARY KEY,
+  source_service TEXT NOT NULL,
+  event_type TEXT NOT NULL,
+  aggregate_type TEXT,
+  aggregate_id TEXT,
+  payload BYTEA NOT NULL,
+  received_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  processed_at TIMESTAMPTZ,
+  last_error TEXT
+);
+
+CREATE INDEX inbox_events_type_received_idx ON catalog.inbox_events (event_type, received_at, event_id);
@@ -0,0 +1,12 @@
+CREATE TABLE catalog.engagement_replay_checkpoints (
+  replay_id TEXT PRIMARY KEY,
+  scope_user_id UUID,
+  scope_video_id UUID,
+  last_occurred_at TIMESTAMPTZ,
+  last_event_id UUID,
+  applied_count BIGINT NOT NULL DEFAULT 0,
+  skipped_count BIGINT NOT NULL DEFAULT 0,
+  started_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  completed_at TIMESTAMPTZ
+);
@@ -0,0 +1,11 @@
+CREATE TABLE catalog.video_view_sessions (
+  video_id UUID NOT NULL,
+  user_id UUID NOT NULL,
+  session_started_at TIMESTAMPTZ NOT NULL,
+  last_progress_at TIMESTAMPTZ NOT NULL,
+  baseline_watch_seconds DOUBLE PRECISION NOT NULL DEFAULT 0,
+  last_watch_seconds DOUBLE PRECISION NOT NULL DEFAULT 0,
+  counted_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (video_id, user_id)
+);
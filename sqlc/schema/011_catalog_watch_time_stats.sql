@@ -0,0 +1,5 @@
+ALTER TABLE catalog.video_view_sessions ADD COLUMN max_position_seconds DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+ALTER TABLE catalog.video_engagement_stats_projection ADD COLUMN total_watch_seconds DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE catalog.video_engagement_stats_projection ADD COLUMN position_sum_seconds DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE catalog.video_engagement_stats_projection ADD COLUMN position_viewers BIGINT NOT NULL DEFAULT 0;
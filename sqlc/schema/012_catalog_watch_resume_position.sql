@@ -0,0 +1,3 @@
+ALTER TABLE catalog.video_view_sessions ADD COLUMN last_position_seconds DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+CREATE INDEX video_view_sessions_user_recent_idx ON catalog.video_view_sessions (user_id, last_progress_at DESC, video_id DESC);
@@ -0,0 +1,3 @@
+CREATE INDEX video_user_engagements_projection_user_liked_idx ON catalog.video_user_engagements_projection (user_id, liked_occurred_at DESC, video_id DESC) WHERE has_liked;
+
+CREATE INDEX video_user_engagements_projection_user_bookmarked_idx ON catalog.video_user_engagements_projection (user_id, bookmarked_occurred_at DESC, video_id DESC) WHERE has_bookmarked;
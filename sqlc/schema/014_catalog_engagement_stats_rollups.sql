@@ -0,0 +1,25 @@
+CREATE TABLE catalog.video_engagement_stats_hourly (
+  video_id UUID NOT NULL,
+  bucket_start TIMESTAMPTZ NOT NULL,
+  like_delta BIGINT NOT NULL DEFAULT 0,
+  bookmark_delta BIGINT NOT NULL DEFAULT 0,
+  watch_count BIGINT NOT NULL DEFAULT 0,
+  unique_watchers BIGINT NOT NULL DEFAULT 0,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (video_id, bucket_start)
+);
+
+CREATE INDEX video_engagement_stats_hourly_bucket_idx ON catalog.video_engagement_stats_hourly (bucket_start);
+
+CREATE TABLE catalog.video_engagement_stats_daily (
+  video_id UUID NOT NULL,
+  bucket_start TIMESTAMPTZ NOT NULL,
+  like_delta BIGINT NOT NULL DEFAULT 0,
+  bookmark_delta BIGINT NOT NULL DEFAULT 0,
+  watch_count BIGINT NOT NULL DEFAULT 0,
+  unique_watchers BIGINT NOT NULL DEFAULT 0,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (video_id, bucket_start)
+);
+
+CREATE INDEX video_engagement_stats_daily_bucket_idx ON catalog.video_engagement_stats_daily (bucket_start);
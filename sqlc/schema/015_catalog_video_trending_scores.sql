@@ -0,0 +1,19 @@
+CREATE TABLE catalog.video_trending_snapshots (
+  snapshot_id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+  computed_at TIMESTAMPTZ NOT NULL,
+  window_start TIMESTAMPTZ NOT NULL,
+  half_life_seconds DOUBLE PRECISION NOT NULL
+);
+
+CREATE INDEX video_trending_snapshots_computed_at_idx ON catalog.video_trending_snapshots (computed_at);
+
+CREATE TABLE catalog.video_trending_scores (
+  snapshot_id BIGINT NOT NULL REFERENCES catalog.video_trending_snapshots (snapshot_id) ON DELETE CASCADE,
+  video_id UUID NOT NULL,
+  rank INTEGER NOT NULL,
+  score DOUBLE PRECISION NOT NULL,
+  like_delta BIGINT NOT NULL DEFAULT 0,
+  watch_count BIGINT NOT NULL DEFAULT 0,
+  PRIMARY KEY (snapshot_id, rank),
+  UNIQUE (snapshot_id, video_id)
+);
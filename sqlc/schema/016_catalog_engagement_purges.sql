@@ -0,0 +1,16 @@
+CREATE TABLE catalog.engagement_purges (
+  scope TEXT NOT NULL,
+  subject_id UUID NOT NULL,
+  reason TEXT NOT NULL,
+  requested_at TIMESTAMPTZ NOT NULL,
+  source_event_id UUID,
+  rows_removed BIGINT NOT NULL DEFAULT 0,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  completed_at TIMESTAMPTZ,
+  PRIMARY KEY (scope, subject_id)
+);
+
+CREATE INDEX engagement_purges_pending_idx ON catalog.engagement_purges (created_at) WHERE completed_at IS NULL;
+
+CREATE INDEX video_engagement_watchers_user_idx ON catalog.video_engagement_watchers (user_id);
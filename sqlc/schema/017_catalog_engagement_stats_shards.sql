@@ -0,0 +1,20 @@
+CREATE TABLE catalog.video_engagement_stats_shards (
+  video_id UUID NOT NULL,
+  shard SMALLINT NOT NULL,
+  like_delta BIGINT NOT NULL DEFAULT 0,
+  bookmark_delta BIGINT NOT NULL DEFAULT 0,
+  watch_delta BIGINT NOT NULL DEFAULT 0,
+  unique_watcher_delta BIGINT NOT NULL DEFAULT 0,
+  watch_seconds_delta DOUBLE PRECISION NOT NULL DEFAULT 0,
+  position_seconds_delta DOUBLE PRECISION NOT NULL DEFAULT 0,
+  position_viewer_delta BIGINT NOT NULL DEFAULT 0,
+  first_watch_at TIMESTAMPTZ,
+  last_watch_at TIMESTAMPTZ,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (video_id, shard)
+);
+
+CREATE INDEX video_engagement_stats_shards_updated_idx ON catalog.video_engagement_stats_shards (updated_at);
+
+ALTER TABLE catalog.video_engagement_stats_hourly ADD COLUMN shard SMALLINT NOT NULL DEFAULT 0;
+ALTER TABLE catalog.video_engagement_stats_daily ADD COLUMN shard SMALLINT NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+ALTER TABLE catalog.video_user_engagements_projection ADD COLUMN liked_sequence BIGINT;
+ALTER TABLE catalog.video_user_engagements_projection ADD COLUMN bookmarked_sequence BIGINT;
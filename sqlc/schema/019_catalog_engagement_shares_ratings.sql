@@ -0,0 +1,21 @@
+ALTER TABLE catalog.video_engagement_stats_projection ADD COLUMN share_count BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE catalog.video_engagement_stats_projection ADD COLUMN rating_count BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE catalog.video_engagement_stats_projection ADD COLUMN rating_sum BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE catalog.video_engagement_stats_projection ADD COLUMN rating_1_count BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE catalog.video_engagement_stats_projection ADD COLUMN rating_2_count BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE catalog.video_engagement_stats_projection ADD COLUMN rating_3_count BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE catalog.video_engagement_stats_projection ADD COLUMN rating_4_count BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE catalog.video_engagement_stats_projection ADD COLUMN rating_5_count BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE catalog.video_engagement_stats_shards ADD COLUMN share_delta BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE catalog.video_engagement_stats_shards ADD COLUMN rating_count_delta BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE catalog.video_engagement_stats_shards ADD COLUMN rating_sum_delta BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE catalog.video_engagement_stats_shards ADD COLUMN rating_1_delta BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE catalog.video_engagement_stats_shards ADD COLUMN rating_2_delta BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE catalog.video_engagement_stats_shards ADD COLUMN rating_3_delta BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE catalog.video_engagement_stats_shards ADD COLUMN rating_4_delta BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE catalog.video_engagement_stats_shards ADD COLUMN rating_5_delta BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE catalog.video_user_engagements_projection ADD COLUMN rating INTEGER;
+ALTER TABLE catalog.video_user_engagements_projection ADD COLUMN rated_occurred_at TIMESTAMPTZ;
+ALTER TABLE catalog.video_user_engagements_projection ADD COLUMN rated_sequence BIGINT;
@@ -0,0 +1,17 @@
+CREATE TABLE catalog.inbox_quarantine (
+  event_id UUID PRIMARY KEY,
+  consumer TEXT NOT NULL,
+  source_service TEXT NOT NULL,
+  event_type TEXT NOT NULL,
+  aggregate_type TEXT,
+  aggregate_id TEXT,
+  payload BYTEA NOT NULL,
+  received_at TIMESTAMPTZ NOT NULL,
+  error_class TEXT NOT NULL,
+  last_error TEXT NOT NULL,
+  quarantined_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  replay_attempts INTEGER NOT NULL DEFAULT 0,
+  resolved_at TIMESTAMPTZ,
+  resolution TEXT,
+  resolved_note TEXT
+);
@@ -0,0 +1,20 @@
+CREATE TABLE catalog.outbox_events (
+  event_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  aggregate_type TEXT NOT NULL,
+  aggregate_id UUID NOT NULL,
+  event_type TEXT NOT NULL,
+  payload BYTEA NOT NULL,
+  headers JSONB NOT NULL DEFAULT '{}'::jsonb,
+  occurred_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  available_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  published_at TIMESTAMPTZ,
+  delivery_attempts INTEGER NOT NULL DEFAULT 0 CHECK (delivery_attempts >= 0),
+  last_error TEXT,
+  lock_token TEXT,
+  locked_at TIMESTAMPTZ,
+  abandoned_at TIMESTAMPTZ,
+  abandoned_reason TEXT
+);
+
+CREATE INDEX outbox_events_available_idx ON catalog.outbox_events (available_at) WHERE published_at IS NULL;
+CREATE INDEX outbox_events_abandoned_idx ON catalog.outbox_events (abandoned_at) WHERE abandoned_at IS NOT NULL;
@@ -0,0 +1,29 @@
+CREATE TABLE catalog.outbox_events_archive (
+  event_id UUID PRIMARY KEY,
+  aggregate_type TEXT NOT NULL,
+  aggregate_id UUID NOT NULL,
+  event_type TEXT NOT NULL,
+  payload BYTEA NOT NULL,
+  headers JSONB NOT NULL,
+  occurred_at TIMESTAMPTZ NOT NULL,
+  available_at TIMESTAMPTZ NOT NULL,
+  published_at TIMESTAMPTZ NOT NULL,
+  delivery_attempts INTEGER NOT NULL,
+  last_error TEXT,
+  abandoned_at TIMESTAMPTZ,
+  abandoned_reason TEXT,
+  archived_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE catalog.inbox_events_archive (
+  event_id UUID PRIMARY KEY,
+  source_service TEXT NOT NULL,
+  event_type TEXT NOT NULL,
+  aggregate_type TEXT,
+  aggregate_id TEXT,
+  payload BYTEA NOT NULL,
+  received_at TIMESTAMPTZ NOT NULL,
+  processed_at TIMESTAMPTZ NOT NULL,
+  last_error TEXT,
+  archived_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
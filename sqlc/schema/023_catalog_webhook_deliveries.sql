@@ -0,0 +1,18 @@
+CREATE TABLE catalog.webhook_deliveries (
+  id BIGSERIAL PRIMARY KEY,
+  event_id UUID NOT NULL,
+  endpoint TEXT NOT NULL,
+  event_type TEXT NOT NULL,
+  attempt INTEGER NOT NULL,
+  status_code INTEGER,
+  error TEXT,
+  duration_ms INTEGER NOT NULL,
+  succeeded BOOLEAN NOT NULL,
+  attempted_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX webhook_deliveries_event_idx
+  ON catalog.webhook_deliveries (event_id, endpoint);
+
+CREATE INDEX webhook_deliveries_attempted_idx
+  ON catalog.webhook_deliveries (attempted_at);
@@ -0,0 +1,12 @@
+CREATE TABLE catalog.event_backfill_checkpoints (
+  backfill_id TEXT PRIMARY KEY,
+  target TEXT NOT NULL,
+  scope_statuses TEXT[] NOT NULL DEFAULT '{}',
+  scope_visibility TEXT,
+  scope_uploader_id UUID,
+  last_video_id UUID,
+  published_count BIGINT NOT NULL DEFAULT 0,
+  started_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  completed_at TIMESTAMPTZ
+);
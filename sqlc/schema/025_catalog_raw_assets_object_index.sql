@@ -0,0 +1 @@
+CREATE INDEX raw_assets_object_idx ON catalog.raw_assets (bucket, object_name);
@@ -0,0 +1 @@
+ALTER TABLE catalog.engagement_replay_checkpoints ADD COLUMN last_received_at TIMESTAMPTZ;
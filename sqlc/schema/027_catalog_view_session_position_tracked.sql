@@ -0,0 +1 @@
+ALTER TABLE catalog.video_view_sessions ADD COLUMN position_tracked BOOLEAN NOT NULL DEFAULT false;
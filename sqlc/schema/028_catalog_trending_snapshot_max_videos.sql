@@ -0,0 +1 @@
+ALTER TABLE catalog.video_trending_snapshots ADD COLUMN max_videos INTEGER NOT NULL DEFAULT 0;
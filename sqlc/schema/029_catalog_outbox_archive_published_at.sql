@@ -0,0 +1 @@
+ALTER TABLE catalog.outbox_events_archive ALTER COLUMN published_at DROP NOT NULL;
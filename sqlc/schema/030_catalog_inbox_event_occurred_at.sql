@@ -0,0 +1,3 @@
+ALTER TABLE catalog.inbox_events ADD COLUMN occurred_at TIMESTAMPTZ;
+
+CREATE INDEX inbox_events_type_occurred_idx ON catalog.inbox_events (event_type, occurred_at, event_id) WHERE occurred_at IS NOT NULL;
@@ -0,0 +1,10 @@
+CREATE TABLE catalog.outbox_topic_deliveries (
+  event_id UUID NOT NULL,
+  topic TEXT NOT NULL,
+  event_type TEXT NOT NULL,
+  delivered_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (event_id, topic)
+);
+
+CREATE INDEX outbox_topic_deliveries_delivered_idx
+  ON catalog.outbox_topic_deliveries (delivered_at);
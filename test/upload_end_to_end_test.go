@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"context"
 	"crypto/md5"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -50,7 +52,7 @@ func TestUploadEndToEnd(t *testing.T) {
 	ctx := env.ctx
 
 	// 1. 客户端准备要上传的文件并计算 MD5。
-	content := []byte(strings.Repeat("learning-app-demo-", 128)) // 2KB 样例
+	content := sampleMP4Content("learning-app-demo-", 128) // 2KB 样例
 	tmpFile := filepath.Join(t.TempDir(), "sample.mp4")
 	require.NoError(t, os.WriteFile(tmpFile, content, 0o600))
 
@@ -184,7 +186,7 @@ func TestUploadEndToEnd_SessionExpiryRenewsSignedURL(t *testing.T) {
 	userID := uuid.New()
 	require.NoError(t, env.bootstrapAuthUser(ctx, userID))
 
-	content := []byte(strings.Repeat("renew-flow", 128))
+	content := sampleMP4Content("renew-flow", 128)
 	md5Sum := md5.Sum(content)
 	md5Hex := fmt.Sprintf("%x", md5Sum)
 	md5Base64 := base64.StdEncoding.EncodeToString(md5Sum[:])
@@ -252,7 +254,7 @@ func TestUploadEndToEnd_DuplicateFinalizeIdempotent(t *testing.T) {
 	userID := uuid.New()
 	require.NoError(t, env.bootstrapAuthUser(ctx, userID))
 
-	content := []byte(strings.Repeat("dup-finalize", 64))
+	content := sampleMP4Content("dup-finalize", 64)
 	md5Sum := md5.Sum(content)
 	md5Hex := fmt.Sprintf("%x", md5Sum)
 	md5Base64 := base64.StdEncoding.EncodeToString(md5Sum[:])
@@ -419,11 +421,14 @@ func newUploadE2EEnv(t *testing.T) *uploadE2EEnv {
 	publisher := gcpubsub.ProvidePublisher(component)
 	subscriber := gcpubsub.ProvideSubscriber(component)
 
+	fakeGCS := newFakeGCSServer(t)
+
 	runner, err := uploads.NewRunner(uploads.RunnerParams{
 		Subscriber: subscriber,
 		InboxRepo:  inboxRepo,
 		UploadRepo: uploadRepo,
 		Lifecycle:  lifecycle,
+		Objects:    fakeGCS,
 		TxManager:  txMgr,
 		Logger:     logger,
 		Config: outboxcfg.InboxConfig{
@@ -451,12 +456,11 @@ func newUploadE2EEnv(t *testing.T) *uploadE2EEnv {
 		}
 	}
 
-	fakeGCS := newFakeGCSServer(t)
 	signer := &fakeResumableSigner{server: fakeGCS}
 
 	bucket := "catalog-upload-e2e"
 
-	uploadSvc, err := services.NewUploadService(uploadRepo, signer, bucket, 15*time.Minute, logger)
+	uploadSvc, err := services.NewUploadService(uploadRepo, signer, nil, bucket, 15*time.Minute, logger)
 	require.NoError(t, err)
 
 	return &uploadE2EEnv{
@@ -770,6 +774,49 @@ func (f *fakeGCSServer) handleUpload(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
+// ReadObjectHead 实现 uploads.ObjectReader，返回已上传对象的头部字节。
+func (f *fakeGCSServer) ReadObjectHead(_ context.Context, _ string, objectName, _ string, length int64) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	body, ok := f.objects[objectName]
+	if !ok {
+		return nil, fmt.Errorf("object %s not found", objectName)
+	}
+	if int64(len(body)) > length {
+		body = body[:length]
+	}
+	return append([]byte(nil), body...), nil
+}
+
+// ContentSHA256 实现 uploads.ObjectReader，返回已上传对象的 SHA-256。
+func (f *fakeGCSServer) ContentSHA256(_ context.Context, _ string, objectName, _ string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	body, ok := f.objects[objectName]
+	if !ok {
+		return "", fmt.Errorf("object %s not found", objectName)
+	}
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// DeleteObject 实现 uploads.ObjectReader，移除已上传的对象。
+func (f *fakeGCSServer) DeleteObject(_ context.Context, _ string, objectName, _ string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.objects, objectName)
+	return nil
+}
+
+func (f *fakeGCSServer) LiveGeneration(_ context.Context, _ string, objectName string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.objects[objectName]; !ok {
+		return "", nil
+	}
+	return "1", nil
+}
+
 func (f *fakeGCSServer) InvalidateSession(sessionURI string) {
 	parsed, err := url.Parse(sessionURI)
 	if err != nil {
@@ -800,6 +847,12 @@ func (f *fakeResumableSigner) SignedResumableInitURL(_ context.Context, bucket,
 
 // --- 通用工具 ---
 
+// sampleMP4Content 构造带 ISO-BMFF ftyp 头的样例内容，确保通过魔数嗅探。
+func sampleMP4Content(filler string, repeat int) []byte {
+	header := []byte{0x00, 0x00, 0x00, 0x18, 'f', 't', 'y', 'p', 'm', 'p', '4', '2', 0x00, 0x00, 0x00, 0x00, 'm', 'p', '4', '2', 'i', 's', 'o', 'm'}
+	return append(header, []byte(strings.Repeat(filler, repeat))...)
+}
+
 func boolPtr(v bool) *bool { return &v }
 
 // --- Postgres + 迁移 ---